@@ -14,7 +14,8 @@ func Default() UserFeatures {
 			PermanentlyDeleteOnDestroy: false,
 		},
 		Network: NetworkFeatures{
-			RelaxedLocking: false,
+			RelaxedLocking:       false,
+			SecurityRuleAnalysis: NetworkSecurityRuleAnalysisDisabled,
 		},
 		TemplateDeployment: TemplateDeploymentFeatures{
 			DeleteNestedItemsDuringDeletion: true,
@@ -0,0 +1,13 @@
+package features
+
+const (
+	// NetworkSecurityRuleAnalysisDisabled skips the analysis of Network Security Group rules during plan
+	NetworkSecurityRuleAnalysisDisabled = "Disabled"
+
+	// NetworkSecurityRuleAnalysisWarn exposes a warning for shadowed and overlapping Network Security Group rules
+	// through the `security_rule_analysis_warnings` attribute, and returns an error for rules which Azure would reject (such as duplicate priorities)
+	NetworkSecurityRuleAnalysisWarn = "Warn"
+
+	// NetworkSecurityRuleAnalysisError returns an error for every issue found in the Network Security Group rules
+	NetworkSecurityRuleAnalysisError = "Error"
+)
@@ -31,7 +31,8 @@ type KeyVaultFeatures struct {
 }
 
 type NetworkFeatures struct {
-	RelaxedLocking bool
+	RelaxedLocking       bool
+	SecurityRuleAnalysis string
 }
 
 type TemplateDeploymentFeatures struct {
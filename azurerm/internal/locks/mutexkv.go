@@ -5,12 +5,12 @@ import (
 	"sync"
 )
 
-// mutexKV is a simple key/value store for arbitrary mutexes. It can be used to
-// serialize changes across arbitrary collaborators that share knowledge of the
-// keys they must serialize on.
+// mutexKV is a simple key/value store for arbitrary read/write mutexes. It can be
+// used to serialize changes across arbitrary collaborators that share knowledge of
+// the keys they must serialize on.
 type mutexKV struct {
 	lock  sync.Mutex
-	store map[string]*sync.Mutex
+	store map[string]*sync.RWMutex
 }
 
 // Locks the mutex for the given key. Caller is responsible for calling Unlock
@@ -28,13 +28,30 @@ func (m *mutexKV) Unlock(key string) {
 	log.Printf("[DEBUG] Unlocked %q", key)
 }
 
+// RLock takes a shared lock on the mutex for the given key, which can be held by
+// multiple callers at once but excludes Lock. Caller is responsible for calling
+// RUnlock for the same key
+func (m *mutexKV) RLock(key string) {
+	log.Printf("[DEBUG] Locking %q (shared)", key)
+	m.get(key).RLock()
+	log.Printf("[DEBUG] Locked %q (shared)", key)
+}
+
+// RUnlock releases a shared lock on the mutex for the given key. Caller must have
+// called RLock for the same key first
+func (m *mutexKV) RUnlock(key string) {
+	log.Printf("[DEBUG] Unlocking %q (shared)", key)
+	m.get(key).RUnlock()
+	log.Printf("[DEBUG] Unlocked %q (shared)", key)
+}
+
 // Returns a mutex for the given key, no guarantee of its lock status
-func (m *mutexKV) get(key string) *sync.Mutex {
+func (m *mutexKV) get(key string) *sync.RWMutex {
 	m.lock.Lock()
 	defer m.lock.Unlock()
 	mutex, ok := m.store[key]
 	if !ok {
-		mutex = &sync.Mutex{}
+		mutex = &sync.RWMutex{}
 		m.store[key] = mutex
 	}
 	return mutex
@@ -43,6 +60,6 @@ func (m *mutexKV) get(key string) *sync.Mutex {
 // Returns a properly initialized mutexKV
 func NewMutexKV() *mutexKV {
 	return &mutexKV{
-		store: make(map[string]*sync.Mutex),
+		store: make(map[string]*sync.RWMutex),
 	}
 }
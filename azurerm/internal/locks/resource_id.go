@@ -0,0 +1,107 @@
+package locks
+
+import (
+	"sort"
+	"strings"
+)
+
+// ResourceID is implemented by the parsed Resource ID types generated within each service package
+type ResourceID interface {
+	ID() string
+}
+
+// ByResourceID takes an exclusive lock on the given Resource ID, which waits for (and blocks)
+// both exclusive and shared locks on the same Resource ID
+func ByResourceID(id ResourceID) {
+	armMutexKV.Lock(resourceIDKey(id))
+}
+
+// UnlockByResourceID releases an exclusive lock taken using ByResourceID
+func UnlockByResourceID(id ResourceID) {
+	armMutexKV.Unlock(resourceIDKey(id))
+}
+
+// SharedByResourceID takes a shared lock on the given Resource ID - which can be held by multiple
+// callers at once, but which excludes an exclusive lock on the same Resource ID
+func SharedByResourceID(id ResourceID) {
+	armMutexKV.RLock(resourceIDKey(id))
+}
+
+// UnlockSharedByResourceID releases a shared lock taken using SharedByResourceID
+func UnlockSharedByResourceID(id ResourceID) {
+	armMutexKV.RUnlock(resourceIDKey(id))
+}
+
+// ChildByResourceID takes a shared lock on the parent Resource ID and then an exclusive lock on the
+// child Resource ID - meaning that operations on different children of the same parent can run in
+// parallel, whilst operations on the parent itself (which take an exclusive lock) cannot
+func ChildByResourceID(parent ResourceID, child ResourceID) {
+	SharedByResourceID(parent)
+	ByResourceID(child)
+}
+
+// UnlockChildByResourceID releases the locks taken using ChildByResourceID
+func UnlockChildByResourceID(parent ResourceID, child ResourceID) {
+	UnlockByResourceID(child)
+	UnlockSharedByResourceID(parent)
+}
+
+// MultipleChildrenByResourceID takes a shared lock on each of the unique parent Resource IDs and then an
+// exclusive lock on each of the unique child Resource IDs
+func MultipleChildrenByResourceID(parents []ResourceID, children []ResourceID) {
+	MultipleSharedByResourceID(parents)
+	MultipleByResourceID(children)
+}
+
+// UnlockMultipleChildrenByResourceID releases the locks taken using MultipleChildrenByResourceID
+func UnlockMultipleChildrenByResourceID(parents []ResourceID, children []ResourceID) {
+	UnlockMultipleByResourceID(children)
+	UnlockMultipleSharedByResourceID(parents)
+}
+
+// MultipleByResourceID takes an exclusive lock on each of the unique Resource IDs, in a consistent
+// order so that callers locking overlapping sets of Resource IDs can't deadlock
+func MultipleByResourceID(ids []ResourceID) {
+	for _, key := range resourceIDKeys(ids) {
+		armMutexKV.Lock(key)
+	}
+}
+
+// UnlockMultipleByResourceID releases the locks taken using MultipleByResourceID
+func UnlockMultipleByResourceID(ids []ResourceID) {
+	for _, key := range resourceIDKeys(ids) {
+		armMutexKV.Unlock(key)
+	}
+}
+
+// MultipleSharedByResourceID takes a shared lock on each of the unique Resource IDs, in a consistent
+// order so that callers locking overlapping sets of Resource IDs can't deadlock
+func MultipleSharedByResourceID(ids []ResourceID) {
+	for _, key := range resourceIDKeys(ids) {
+		armMutexKV.RLock(key)
+	}
+}
+
+// UnlockMultipleSharedByResourceID releases the locks taken using MultipleSharedByResourceID
+func UnlockMultipleSharedByResourceID(ids []ResourceID) {
+	for _, key := range resourceIDKeys(ids) {
+		armMutexKV.RUnlock(key)
+	}
+}
+
+// resource IDs are case-insensitive in ARM, so the casing returned from the API (or used in
+// the configuration) shouldn't result in two different locks for the same resource
+func resourceIDKey(id ResourceID) string {
+	return strings.ToLower(id.ID())
+}
+
+func resourceIDKeys(ids []ResourceID) []string {
+	keys := make([]string, 0, len(ids))
+	for _, id := range ids {
+		keys = append(keys, resourceIDKey(id))
+	}
+
+	keys = removeDuplicatesFromStringArray(keys)
+	sort.Strings(keys)
+	return keys
+}
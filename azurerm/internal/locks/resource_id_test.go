@@ -0,0 +1,113 @@
+package locks
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+type testResourceId string
+
+func (id testResourceId) ID() string {
+	return string(id)
+}
+
+const (
+	testVirtualNetworkId = "/subscriptions/11111111-1111-1111-1111-111111111111/resourceGroups/group1/providers/Microsoft.Network/virtualNetworks/network1"
+	testSubnet1Id        = testVirtualNetworkId + "/subnets/subnet1"
+	testSubnet2Id        = testVirtualNetworkId + "/subnets/subnet2"
+)
+
+func TestResourceIDKeys(t *testing.T) {
+	cases := []struct {
+		Name   string
+		Input  []ResourceID
+		Result []string
+	}{
+		{
+			Name:   "empty",
+			Input:  []ResourceID{},
+			Result: []string{},
+		},
+		{
+			Name: "sorted and lower-cased",
+			Input: []ResourceID{
+				testResourceId("/subscriptions/SUB/resourceGroups/b"),
+				testResourceId("/subscriptions/sub/resourceGroups/A"),
+			},
+			Result: []string{
+				"/subscriptions/sub/resourcegroups/a",
+				"/subscriptions/sub/resourcegroups/b",
+			},
+		},
+		{
+			Name: "duplicates differing by casing",
+			Input: []ResourceID{
+				testResourceId("/subscriptions/sub/resourceGroups/a"),
+				testResourceId("/SUBSCRIPTIONS/SUB/RESOURCEGROUPS/A"),
+			},
+			Result: []string{
+				"/subscriptions/sub/resourcegroups/a",
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			if actual := resourceIDKeys(tc.Input); !reflect.DeepEqual(actual, tc.Result) {
+				t.Fatalf("Expected %+v but got %+v", tc.Result, actual)
+			}
+		})
+	}
+}
+
+func TestChildByResourceID_siblingsRunInParallel(t *testing.T) {
+	parent := testResourceId(testVirtualNetworkId)
+	subnet1 := testResourceId(testSubnet1Id)
+	subnet2 := testResourceId(testSubnet2Id)
+
+	ChildByResourceID(parent, subnet1)
+	defer UnlockChildByResourceID(parent, subnet1)
+
+	done := make(chan struct{})
+	go func() {
+		ChildByResourceID(parent, subnet2)
+		UnlockChildByResourceID(parent, subnet2)
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("expected a lock on a sibling to be obtained whilst the parent is share-locked")
+	}
+}
+
+func TestChildByResourceID_parentIsExclusive(t *testing.T) {
+	parent := testResourceId(testVirtualNetworkId)
+	subnet := testResourceId(testSubnet1Id)
+
+	ByResourceID(parent)
+
+	obtained := make(chan struct{})
+	go func() {
+		ChildByResourceID(parent, subnet)
+		close(obtained)
+		UnlockChildByResourceID(parent, subnet)
+	}()
+
+	select {
+	case <-obtained:
+		t.Fatalf("expected the lock on the child to wait for the exclusive lock on the parent")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	// the Resource ID is case-insensitive, so this releases the same lock
+	UnlockByResourceID(testResourceId("/subscriptions/11111111-1111-1111-1111-111111111111/RESOURCEGROUPS/group1/providers/Microsoft.Network/virtualNetworks/NETWORK1"))
+
+	select {
+	case <-obtained:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("expected the lock on the child to be obtained once the parent was unlocked")
+	}
+}
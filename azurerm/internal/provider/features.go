@@ -4,6 +4,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/features"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tf/pluginsdk"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tf/validation"
 )
 
 func schemaFeatures(supportLegacyTestSuite bool) *pluginsdk.Schema {
@@ -65,7 +66,19 @@ func schemaFeatures(supportLegacyTestSuite bool) *pluginsdk.Schema {
 				Schema: map[string]*pluginsdk.Schema{
 					"relaxed_locking": {
 						Type:     pluginsdk.TypeBool,
-						Required: true,
+						Optional: true,
+						Default:  false,
+					},
+
+					"security_rule_analysis": {
+						Type:     pluginsdk.TypeString,
+						Optional: true,
+						Default:  features.NetworkSecurityRuleAnalysisDisabled,
+						ValidateFunc: validation.StringInSlice([]string{
+							features.NetworkSecurityRuleAnalysisDisabled,
+							features.NetworkSecurityRuleAnalysisWarn,
+							features.NetworkSecurityRuleAnalysisError,
+						}, false),
 					},
 				},
 			},
@@ -200,6 +213,9 @@ func expandFeatures(input []interface{}) features.UserFeatures {
 			if v, ok := networkRaw["relaxed_locking"]; ok {
 				features.Network.RelaxedLocking = v.(bool)
 			}
+			if v, ok := networkRaw["security_rule_analysis"]; ok && v.(string) != "" {
+				features.Network.SecurityRuleAnalysis = v.(string)
+			}
 		}
 	}
 
@@ -29,7 +29,8 @@ func TestExpandFeatures(t *testing.T) {
 					PermanentlyDeleteOnDestroy: false,
 				},
 				Network: features.NetworkFeatures{
-					RelaxedLocking: false,
+					RelaxedLocking:       false,
+					SecurityRuleAnalysis: "Disabled",
 				},
 				TemplateDeployment: features.TemplateDeploymentFeatures{
 					DeleteNestedItemsDuringDeletion: true,
@@ -67,7 +68,8 @@ func TestExpandFeatures(t *testing.T) {
 					},
 					"network": []interface{}{
 						map[string]interface{}{
-							"relaxed_locking": true,
+							"relaxed_locking":        true,
+							"security_rule_analysis": "Error",
 						},
 					},
 					"template_deployment": []interface{}{
@@ -102,7 +104,8 @@ func TestExpandFeatures(t *testing.T) {
 					PermanentlyDeleteOnDestroy: true,
 				},
 				Network: features.NetworkFeatures{
-					RelaxedLocking: true,
+					RelaxedLocking:       true,
+					SecurityRuleAnalysis: "Error",
 				},
 				TemplateDeployment: features.TemplateDeploymentFeatures{
 					DeleteNestedItemsDuringDeletion: true,
@@ -175,7 +178,8 @@ func TestExpandFeatures(t *testing.T) {
 					PermanentlyDeleteOnDestroy: false,
 				},
 				Network: features.NetworkFeatures{
-					RelaxedLocking: false,
+					RelaxedLocking:       false,
+					SecurityRuleAnalysis: "Disabled",
 				},
 				TemplateDeployment: features.TemplateDeploymentFeatures{
 					DeleteNestedItemsDuringDeletion: false,
@@ -353,7 +357,8 @@ func TestExpandFeaturesNetwork(t *testing.T) {
 			},
 			Expected: features.UserFeatures{
 				Network: features.NetworkFeatures{
-					RelaxedLocking: false,
+					RelaxedLocking:       false,
+					SecurityRuleAnalysis: "Disabled",
 				},
 			},
 		},
@@ -370,7 +375,8 @@ func TestExpandFeaturesNetwork(t *testing.T) {
 			},
 			Expected: features.UserFeatures{
 				Network: features.NetworkFeatures{
-					RelaxedLocking: true,
+					RelaxedLocking:       true,
+					SecurityRuleAnalysis: "Disabled",
 				},
 			},
 		},
@@ -387,7 +393,27 @@ func TestExpandFeaturesNetwork(t *testing.T) {
 			},
 			Expected: features.UserFeatures{
 				Network: features.NetworkFeatures{
-					RelaxedLocking: false,
+					RelaxedLocking:       false,
+					SecurityRuleAnalysis: "Disabled",
+				},
+			},
+		},
+		{
+			Name: "Security Rule Analysis Warn",
+			Input: []interface{}{
+				map[string]interface{}{
+					"network": []interface{}{
+						map[string]interface{}{
+							"relaxed_locking":        false,
+							"security_rule_analysis": "Warn",
+						},
+					},
+				},
+			},
+			Expected: features.UserFeatures{
+				Network: features.NetworkFeatures{
+					RelaxedLocking:       false,
+					SecurityRuleAnalysis: "Warn",
 				},
 			},
 		},
@@ -103,6 +103,7 @@ func SupportedTypedServices() []sdk.TypedServiceRegistration {
 	return []sdk.TypedServiceRegistration{
 		eventhub.Registration{},
 		loadbalancer.Registration{},
+		mssql.Registration{},
 		policy.Registration{},
 		resource.Registration{},
 		web.Registration{},
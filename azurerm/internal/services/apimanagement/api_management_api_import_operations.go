@@ -0,0 +1,435 @@
+package apimanagement
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"log"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/services/apimanagement/mgmt/2020-12-01/apimanagement"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tf/pluginsdk"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+	"gopkg.in/yaml.v2"
+)
+
+// apiManagementApiImportedOperation is a summary of an Operation defined within the content imported into an API
+type apiManagementApiImportedOperation struct {
+	OperationId string
+	Method      string
+	UrlTemplate string
+}
+
+type apiManagementApiImportOptions struct {
+	ContentFormat    string
+	ContentValue     string
+	WsdlServiceName  string
+	WsdlEndpointName string
+	SoapPassThrough  bool
+}
+
+func expandApiManagementApiImportOptions(input []interface{}, soapPassThrough bool) *apiManagementApiImportOptions {
+	if len(input) == 0 || input[0] == nil {
+		return nil
+	}
+	v := input[0].(map[string]interface{})
+
+	options := apiManagementApiImportOptions{
+		ContentFormat:   v["content_format"].(string),
+		ContentValue:    v["content_value"].(string),
+		SoapPassThrough: soapPassThrough,
+	}
+	if selectors := v["wsdl_selector"].([]interface{}); len(selectors) > 0 && selectors[0] != nil {
+		selector := selectors[0].(map[string]interface{})
+		options.WsdlServiceName = selector["service_name"].(string)
+		options.WsdlEndpointName = selector["endpoint_name"].(string)
+	}
+
+	return &options
+}
+
+func flattenApiManagementApiImportedOperations(input []apiManagementApiImportedOperation) []interface{} {
+	output := make([]interface{}, 0)
+	for _, v := range input {
+		output = append(output, map[string]interface{}{
+			"operation_id": v.OperationId,
+			"method":       v.Method,
+			"url_template": v.UrlTemplate,
+		})
+	}
+	return output
+}
+
+// parseApiManagementApiImportedOperations parses the Operations from the content being imported into the API - returning
+// false if the content can't be parsed locally, which is the case when the content is a link to the definition.
+func parseApiManagementApiImportedOperations(options apiManagementApiImportOptions) ([]apiManagementApiImportedOperation, bool, error) {
+	var operations []apiManagementApiImportedOperation
+	var err error
+
+	switch apimanagement.ContentFormat(options.ContentFormat) {
+	case apimanagement.Openapijson, apimanagement.SwaggerJSON:
+		operations, err = parseApiManagementApiOpenApiOperations([]byte(options.ContentValue), json.Unmarshal)
+	case apimanagement.Openapi:
+		operations, err = parseApiManagementApiOpenApiOperations([]byte(options.ContentValue), yaml.Unmarshal)
+	case apimanagement.WadlXML:
+		operations, err = parseApiManagementApiWadlOperations(options.ContentValue)
+	case apimanagement.Wsdl:
+		operations, err = parseApiManagementApiWsdlOperations(options)
+	default:
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, true, fmt.Errorf("parsing the Operations from the %q content: %+v", options.ContentFormat, err)
+	}
+
+	sort.Slice(operations, func(i, j int) bool {
+		if operations[i].UrlTemplate != operations[j].UrlTemplate {
+			return operations[i].UrlTemplate < operations[j].UrlTemplate
+		}
+		if operations[i].Method != operations[j].Method {
+			return operations[i].Method < operations[j].Method
+		}
+		return operations[i].OperationId < operations[j].OperationId
+	})
+
+	return operations, true, nil
+}
+
+var apiManagementApiOpenApiMethods = []string{"get", "put", "post", "delete", "options", "head", "patch", "trace"}
+
+func parseApiManagementApiOpenApiOperations(content []byte, unmarshal func([]byte, interface{}) error) ([]apiManagementApiImportedOperation, error) {
+	var spec struct {
+		Paths map[string]map[string]interface{} `json:"paths" yaml:"paths"`
+	}
+	if err := unmarshal(content, &spec); err != nil {
+		return nil, err
+	}
+
+	operations := make([]apiManagementApiImportedOperation, 0)
+	for path, item := range spec.Paths {
+		for _, method := range apiManagementApiOpenApiMethods {
+			operation, ok := item[method]
+			if !ok {
+				continue
+			}
+
+			operationId := ""
+			switch v := operation.(type) {
+			case map[string]interface{}:
+				operationId, _ = v["operationId"].(string)
+			case map[interface{}]interface{}:
+				operationId, _ = v["operationId"].(string)
+			}
+			if operationId == "" {
+				operationId = generateApiManagementApiOperationId(method, path)
+			}
+
+			operations = append(operations, apiManagementApiImportedOperation{
+				OperationId: operationId,
+				Method:      strings.ToUpper(method),
+				UrlTemplate: path,
+			})
+		}
+	}
+
+	return operations, nil
+}
+
+func parseApiManagementApiWadlOperations(content string) ([]apiManagementApiImportedOperation, error) {
+	decoder := xml.NewDecoder(strings.NewReader(content))
+
+	operations := make([]apiManagementApiImportedOperation, 0)
+	paths := make([]string, 0)
+	// tracks whether each element which is open is a `resource`, so that the path can be unwound as they're closed
+	resources := make([]bool, 0)
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			if err == io.EOF {
+				break
+			}
+			return nil, err
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			isResource := t.Name.Local == "resource"
+			resources = append(resources, isResource)
+
+			switch t.Name.Local {
+			case "resource":
+				paths = append(paths, strings.Trim(apiManagementApiXmlAttr(t, "path"), "/"))
+
+			case "method":
+				if len(paths) == 0 {
+					continue
+				}
+				method := strings.ToUpper(apiManagementApiXmlAttr(t, "name"))
+				if method == "" {
+					// a reference to a method defined elsewhere
+					continue
+				}
+				path := "/" + strings.Join(paths, "/")
+
+				operationId := apiManagementApiXmlAttr(t, "id")
+				if operationId == "" {
+					operationId = generateApiManagementApiOperationId(method, path)
+				}
+
+				operations = append(operations, apiManagementApiImportedOperation{
+					OperationId: operationId,
+					Method:      method,
+					UrlTemplate: path,
+				})
+			}
+
+		case xml.EndElement:
+			if len(resources) == 0 {
+				continue
+			}
+			if resources[len(resources)-1] {
+				paths = paths[:len(paths)-1]
+			}
+			resources = resources[:len(resources)-1]
+		}
+	}
+
+	return operations, nil
+}
+
+type apiManagementApiWsdlDefinitions struct {
+	Bindings []struct {
+		Name       string `xml:"name,attr"`
+		Operations []struct {
+			Name          string `xml:"name,attr"`
+			SoapOperation []struct {
+				SoapAction string `xml:"soapAction,attr"`
+			} `xml:"operation"`
+		} `xml:"operation"`
+	} `xml:"binding"`
+	Services []struct {
+		Name  string `xml:"name,attr"`
+		Ports []struct {
+			Name    string `xml:"name,attr"`
+			Binding string `xml:"binding,attr"`
+		} `xml:"port"`
+	} `xml:"service"`
+}
+
+func parseApiManagementApiWsdlOperations(options apiManagementApiImportOptions) ([]apiManagementApiImportedOperation, error) {
+	var definitions apiManagementApiWsdlDefinitions
+	if err := xml.Unmarshal([]byte(options.ContentValue), &definitions); err != nil {
+		return nil, err
+	}
+
+	// the `wsdl_selector` determines which Binding is imported, otherwise the first Port of the first Service is used
+	bindingName := ""
+	for _, service := range definitions.Services {
+		if options.WsdlServiceName != "" && service.Name != options.WsdlServiceName {
+			continue
+		}
+		for _, port := range service.Ports {
+			if options.WsdlEndpointName != "" && port.Name != options.WsdlEndpointName {
+				continue
+			}
+			bindingName = port.Binding
+			break
+		}
+		if bindingName != "" {
+			break
+		}
+	}
+	if bindingName == "" {
+		return nil, fmt.Errorf("the Service %q / Endpoint %q was not found", options.WsdlServiceName, options.WsdlEndpointName)
+	}
+	// the binding is a qualified name, e.g. `ns:CalculatorSoap11Binding`
+	if i := strings.LastIndex(bindingName, ":"); i >= 0 {
+		bindingName = bindingName[i+1:]
+	}
+
+	operations := make([]apiManagementApiImportedOperation, 0)
+	for _, binding := range definitions.Bindings {
+		if binding.Name != bindingName {
+			continue
+		}
+
+		for _, operation := range binding.Operations {
+			urlTemplate := fmt.Sprintf("/%s", operation.Name)
+			if options.SoapPassThrough {
+				soapAction := ""
+				if len(operation.SoapOperation) > 0 {
+					soapAction = operation.SoapOperation[0].SoapAction
+				}
+				urlTemplate = fmt.Sprintf("/?soapAction=%s", soapAction)
+			}
+
+			operations = append(operations, apiManagementApiImportedOperation{
+				OperationId: operation.Name,
+				Method:      "POST",
+				UrlTemplate: urlTemplate,
+			})
+		}
+	}
+
+	return operations, nil
+}
+
+func apiManagementApiXmlAttr(element xml.StartElement, name string) string {
+	for _, attr := range element.Attr {
+		if attr.Name.Local == name {
+			return attr.Value
+		}
+	}
+	return ""
+}
+
+// generateApiManagementApiOperationId generates an ID for an Operation which doesn't specify one, e.g. `get-pets-petid`
+func generateApiManagementApiOperationId(method, path string) string {
+	id := strings.ToLower(fmt.Sprintf("%s-%s", method, path))
+	id = regexp.MustCompile(`[^a-z0-9]+`).ReplaceAllString(id, "-")
+	return strings.Trim(id, "-")
+}
+
+// apiManagementApiOperationMatches returns whether the existing Operation within the API matches the imported Operation,
+// either by ID or by Method and URL Template (ignoring any query parameters API Management adds to the URL Template).
+func apiManagementApiOperationMatches(imported apiManagementApiImportedOperation, existing apimanagement.OperationContract) bool {
+	if existing.Name != nil && strings.EqualFold(*existing.Name, imported.OperationId) {
+		return true
+	}
+
+	props := existing.OperationContractProperties
+	if props == nil || props.Method == nil || props.URLTemplate == nil || !strings.EqualFold(*props.Method, imported.Method) {
+		return false
+	}
+
+	urlTemplate := *props.URLTemplate
+	if !strings.Contains(imported.UrlTemplate, "?") {
+		urlTemplate = strings.SplitN(urlTemplate, "?", 2)[0]
+	}
+	return strings.EqualFold(urlTemplate, imported.UrlTemplate)
+}
+
+// retrieveApiManagementApiPreservedOperations retrieves the Operations which should be preserved when content is
+// re-imported into the API, since these are managed outside of the imported content (e.g. by `azurerm_api_management_api_operation`)
+func retrieveApiManagementApiPreservedOperations(ctx context.Context, client *apimanagement.APIOperationClient, resourceGroup, serviceName, apiId string, operationIds []interface{}) ([]apimanagement.OperationContract, error) {
+	output := make([]apimanagement.OperationContract, 0)
+	for _, v := range operationIds {
+		operationId := v.(string)
+		resp, err := client.Get(ctx, resourceGroup, serviceName, apiId, operationId)
+		if err != nil {
+			if utils.ResponseWasNotFound(resp.Response) {
+				continue
+			}
+			return nil, fmt.Errorf("retrieving Operation %q (API %q / API Management Service %q / Resource Group %q): %+v", operationId, apiId, serviceName, resourceGroup, err)
+		}
+
+		output = append(output, apimanagement.OperationContract{
+			OperationContractProperties: resp.OperationContractProperties,
+			Name:                        utils.String(operationId),
+		})
+	}
+	return output, nil
+}
+
+// reconcileApiManagementApiImportedOperations is run after content has been imported into the API - restoring any of the
+// preserved Operations which were removed by the import and, when `prune` is set, removing any Operations which aren't
+// defined within the imported content.
+func reconcileApiManagementApiImportedOperations(ctx context.Context, client *apimanagement.APIOperationClient, resourceGroup, serviceName, apiId string, imported []apiManagementApiImportedOperation, preserved []apimanagement.OperationContract, prune bool) error {
+	existing, err := client.ListByAPIComplete(ctx, resourceGroup, serviceName, apiId, "", nil, nil, "")
+	if err != nil {
+		return fmt.Errorf("listing Operations (API %q / API Management Service %q / Resource Group %q): %+v", apiId, serviceName, resourceGroup, err)
+	}
+
+	existingNames := make(map[string]bool)
+	operations := make([]apimanagement.OperationContract, 0)
+	for existing.NotDone() {
+		operation := existing.Value()
+		if operation.Name != nil {
+			existingNames[strings.ToLower(*operation.Name)] = true
+			operations = append(operations, operation)
+		}
+
+		if err := existing.NextWithContext(ctx); err != nil {
+			return fmt.Errorf("listing Operations (API %q / API Management Service %q / Resource Group %q): %+v", apiId, serviceName, resourceGroup, err)
+		}
+	}
+
+	preservedNames := make(map[string]bool)
+	for _, operation := range preserved {
+		name := *operation.Name
+		preservedNames[strings.ToLower(name)] = true
+
+		if existingNames[strings.ToLower(name)] {
+			continue
+		}
+
+		log.Printf("[DEBUG] Restoring preserved Operation %q (API %q / API Management Service %q / Resource Group %q)", name, apiId, serviceName, resourceGroup)
+		if _, err := client.CreateOrUpdate(ctx, resourceGroup, serviceName, apiId, name, operation, ""); err != nil {
+			return fmt.Errorf("restoring preserved Operation %q (API %q / API Management Service %q / Resource Group %q): %+v", name, apiId, serviceName, resourceGroup, err)
+		}
+	}
+
+	if !prune {
+		return nil
+	}
+
+	for _, operation := range operations {
+		name := *operation.Name
+		if preservedNames[strings.ToLower(name)] {
+			continue
+		}
+
+		found := false
+		for _, v := range imported {
+			if apiManagementApiOperationMatches(v, operation) {
+				found = true
+				break
+			}
+		}
+		if found {
+			continue
+		}
+
+		log.Printf("[DEBUG] Pruning Operation %q which isn't defined in the imported content (API %q / API Management Service %q / Resource Group %q)", name, apiId, serviceName, resourceGroup)
+		if resp, err := client.Delete(ctx, resourceGroup, serviceName, apiId, name, "*"); err != nil {
+			if !utils.ResponseWasNotFound(resp) {
+				return fmt.Errorf("pruning Operation %q (API %q / API Management Service %q / Resource Group %q): %+v", name, apiId, serviceName, resourceGroup, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// resourceApiManagementApiCustomizeDiff parses the Operations from the content being imported into the API, so that
+// any Operations being added, removed or changed are shown in the plan.
+func resourceApiManagementApiCustomizeDiff(_ context.Context, d *pluginsdk.ResourceDiff, _ interface{}) error {
+	for _, key := range []string{"import", "import.0.content_value", "import.0.content_format", "import.0.wsdl_selector", "soap_pass_through"} {
+		if !d.NewValueKnown(key) {
+			return d.SetNewComputed("operations")
+		}
+	}
+
+	operations := make([]interface{}, 0)
+	if options := expandApiManagementApiImportOptions(d.Get("import").([]interface{}), d.Get("soap_pass_through").(bool)); options != nil {
+		imported, supported, err := parseApiManagementApiImportedOperations(*options)
+		if err != nil {
+			// the content is validated by API Management during the import, so this is only an error when pruning
+			if d.Get("import.0.prune_operations").(bool) {
+				return fmt.Errorf("`prune_operations` requires the content to be parsed: %+v", err)
+			}
+			log.Printf("[WARN] %+v - the Operations defined within the content won't be exposed", err)
+			return d.SetNew("operations", operations)
+		}
+		if !supported && d.Get("import.0.prune_operations").(bool) {
+			return fmt.Errorf("`prune_operations` cannot be used when `content_format` is %q since the content can't be parsed locally", options.ContentFormat)
+		}
+		operations = flattenApiManagementApiImportedOperations(imported)
+	}
+
+	return d.SetNew("operations", operations)
+}
@@ -0,0 +1,230 @@
+package apimanagement
+
+import (
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/apimanagement/mgmt/2020-12-01/apimanagement"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+func TestParseApiManagementApiImportedOperations(t *testing.T) {
+	swagger, err := os.ReadFile("testdata/api_management_api_swagger.json")
+	if err != nil {
+		t.Fatalf("reading swagger: %+v", err)
+	}
+	wsdl, err := os.ReadFile("testdata/api_management_api_wsdl.xml")
+	if err != nil {
+		t.Fatalf("reading wsdl: %+v", err)
+	}
+
+	testData := []struct {
+		Name      string
+		Options   apiManagementApiImportOptions
+		Expected  []apiManagementApiImportedOperation
+		Supported bool
+		Error     bool
+	}{
+		{
+			Name: "Swagger JSON",
+			Options: apiManagementApiImportOptions{
+				ContentFormat: string(apimanagement.SwaggerJSON),
+				ContentValue:  string(swagger),
+			},
+			Expected: []apiManagementApiImportedOperation{
+				{OperationId: "searchInventory", Method: "GET", UrlTemplate: "/inventory"},
+				{OperationId: "addInventory", Method: "POST", UrlTemplate: "/inventory"},
+			},
+			Supported: true,
+		},
+		{
+			Name: "OpenAPI YAML",
+			Options: apiManagementApiImportOptions{
+				ContentFormat: string(apimanagement.Openapi),
+				ContentValue: `openapi: 3.0.1
+info:
+  title: pets
+  version: "1.0"
+paths:
+  /pets:
+    parameters:
+      - name: limit
+        in: query
+    get:
+      operationId: listPets
+    post:
+      summary: create a pet
+  /pets/{petId}:
+    delete:
+      operationId: deletePet
+`,
+			},
+			Expected: []apiManagementApiImportedOperation{
+				{OperationId: "listPets", Method: "GET", UrlTemplate: "/pets"},
+				{OperationId: "post-pets", Method: "POST", UrlTemplate: "/pets"},
+				{OperationId: "deletePet", Method: "DELETE", UrlTemplate: "/pets/{petId}"},
+			},
+			Supported: true,
+		},
+		{
+			Name: "WADL",
+			Options: apiManagementApiImportOptions{
+				ContentFormat: string(apimanagement.WadlXML),
+				ContentValue: `<application xmlns="http://wadl.dev.java.net/2009/02">
+  <resources base="https://example.com/">
+    <resource path="pets">
+      <method name="GET" id="listPets" />
+      <resource path="{petId}">
+        <param name="petId" style="template" />
+        <method name="DELETE" />
+      </resource>
+    </resource>
+  </resources>
+</application>`,
+			},
+			Expected: []apiManagementApiImportedOperation{
+				{OperationId: "listPets", Method: "GET", UrlTemplate: "/pets"},
+				{OperationId: "delete-pets-petid", Method: "DELETE", UrlTemplate: "/pets/{petId}"},
+			},
+			Supported: true,
+		},
+		{
+			Name: "WSDL",
+			Options: apiManagementApiImportOptions{
+				ContentFormat:    string(apimanagement.Wsdl),
+				ContentValue:     string(wsdl),
+				WsdlServiceName:  "Calculator",
+				WsdlEndpointName: "CalculatorHttpsSoap11Endpoint",
+			},
+			Expected: []apiManagementApiImportedOperation{
+				{OperationId: "add", Method: "POST", UrlTemplate: "/add"},
+			},
+			Supported: true,
+		},
+		{
+			Name: "WSDL Pass Through",
+			Options: apiManagementApiImportOptions{
+				ContentFormat:    string(apimanagement.Wsdl),
+				ContentValue:     string(wsdl),
+				WsdlServiceName:  "Calculator",
+				WsdlEndpointName: "CalculatorHttpsSoap11Endpoint",
+				SoapPassThrough:  true,
+			},
+			Expected: []apiManagementApiImportedOperation{
+				{OperationId: "add", Method: "POST", UrlTemplate: "/?soapAction=urn:add"},
+			},
+			Supported: true,
+		},
+		{
+			Name: "WSDL Unknown Endpoint",
+			Options: apiManagementApiImportOptions{
+				ContentFormat:    string(apimanagement.Wsdl),
+				ContentValue:     string(wsdl),
+				WsdlServiceName:  "Calculator",
+				WsdlEndpointName: "Unknown",
+			},
+			Supported: true,
+			Error:     true,
+		},
+		{
+			Name: "Invalid JSON",
+			Options: apiManagementApiImportOptions{
+				ContentFormat: string(apimanagement.Openapijson),
+				ContentValue:  "{",
+			},
+			Supported: true,
+			Error:     true,
+		},
+		{
+			Name: "Link",
+			Options: apiManagementApiImportOptions{
+				ContentFormat: string(apimanagement.OpenapiLink),
+				ContentValue:  "https://example.com/openapi.yaml",
+			},
+			Supported: false,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Name)
+
+		actual, supported, err := parseApiManagementApiImportedOperations(v.Options)
+		if supported != v.Supported {
+			t.Fatalf("Expected supported to be %t but got %t", v.Supported, supported)
+		}
+		if err != nil {
+			if v.Error {
+				continue
+			}
+			t.Fatalf("Expected no error but got: %+v", err)
+		}
+		if v.Error {
+			t.Fatal("Expected an error but didn't get one")
+		}
+
+		if !reflect.DeepEqual(actual, v.Expected) {
+			t.Fatalf("Expected %+v but got %+v", v.Expected, actual)
+		}
+	}
+}
+
+func TestApiManagementApiOperationMatches(t *testing.T) {
+	testData := []struct {
+		Name     string
+		Imported apiManagementApiImportedOperation
+		Existing apimanagement.OperationContract
+		Expected bool
+	}{
+		{
+			Name:     "Same ID",
+			Imported: apiManagementApiImportedOperation{OperationId: "listPets", Method: "GET", UrlTemplate: "/pets"},
+			Existing: apimanagement.OperationContract{Name: utils.String("listpets")},
+			Expected: true,
+		},
+		{
+			Name:     "Same Method and URL Template",
+			Imported: apiManagementApiImportedOperation{OperationId: "listPets", Method: "GET", UrlTemplate: "/pets"},
+			Existing: apimanagement.OperationContract{
+				Name: utils.String("get-pets"),
+				OperationContractProperties: &apimanagement.OperationContractProperties{
+					Method:      utils.String("GET"),
+					URLTemplate: utils.String("/pets?limit={limit}"),
+				},
+			},
+			Expected: true,
+		},
+		{
+			Name:     "Different Method",
+			Imported: apiManagementApiImportedOperation{OperationId: "listPets", Method: "GET", UrlTemplate: "/pets"},
+			Existing: apimanagement.OperationContract{
+				Name: utils.String("post-pets"),
+				OperationContractProperties: &apimanagement.OperationContractProperties{
+					Method:      utils.String("POST"),
+					URLTemplate: utils.String("/pets"),
+				},
+			},
+			Expected: false,
+		},
+		{
+			Name:     "Different SOAP Action",
+			Imported: apiManagementApiImportedOperation{OperationId: "add", Method: "POST", UrlTemplate: "/?soapAction=urn:add"},
+			Existing: apimanagement.OperationContract{
+				Name: utils.String("subtract"),
+				OperationContractProperties: &apimanagement.OperationContractProperties{
+					Method:      utils.String("POST"),
+					URLTemplate: utils.String("/?soapAction=urn:subtract"),
+				},
+			},
+			Expected: false,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Name)
+
+		if actual := apiManagementApiOperationMatches(v.Imported, v.Existing); actual != v.Expected {
+			t.Fatalf("Expected %t but got %t", v.Expected, actual)
+		}
+	}
+}
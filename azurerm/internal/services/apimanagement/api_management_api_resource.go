@@ -137,6 +137,21 @@ func resourceApiManagementApi() *pluginsdk.Resource {
 								},
 							},
 						},
+
+						"prune_operations": {
+							Type:     pluginsdk.TypeBool,
+							Optional: true,
+							Default:  false,
+						},
+
+						"preserved_operation_ids": {
+							Type:     pluginsdk.TypeSet,
+							Optional: true,
+							Elem: &pluginsdk.Schema{
+								Type:         pluginsdk.TypeString,
+								ValidateFunc: validation.StringIsNotEmpty,
+							},
+						},
 					},
 				},
 			},
@@ -260,12 +275,38 @@ func resourceApiManagementApi() *pluginsdk.Resource {
 				Computed: true,
 				Optional: true,
 			},
+
+			"operations": {
+				Type:     pluginsdk.TypeList,
+				Computed: true,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"operation_id": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+
+						"method": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+
+						"url_template": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
 		},
+
+		CustomizeDiff: pluginsdk.CustomizeDiffShim(resourceApiManagementApiCustomizeDiff),
 	}
 }
 
 func resourceApiManagementApiCreateUpdate(d *pluginsdk.ResourceData, meta interface{}) error {
 	client := meta.(*clients.Client).ApiManagement.ApiClient
+	operationsClient := meta.(*clients.Client).ApiManagement.ApiOperationsClient
 	ctx, cancel := timeouts.ForCreateUpdate(meta.(*clients.Client).StopContext, d)
 	defer cancel()
 
@@ -356,6 +397,27 @@ func resourceApiManagementApiCreateUpdate(d *pluginsdk.ResourceData, meta interf
 			apiParams.APICreateOrUpdateProperties.APIVersionSetID = utils.String(versionSetId)
 		}
 
+		// the Operations are parsed before the content is imported - these are only needed to prune the Operations
+		// afterwards, so otherwise a failure to parse the content is left for API Management to validate
+		importedOperations, importParsed, err := parseApiManagementApiImportedOperations(*expandApiManagementApiImportOptions(importVs, soapPassThrough))
+		if err != nil {
+			if importV["prune_operations"].(bool) {
+				return fmt.Errorf("`prune_operations` requires the content to be parsed: %+v", err)
+			}
+			log.Printf("[WARN] %+v - the Operations defined within the content won't be exposed", err)
+			importParsed = false
+		}
+
+		// Operations managed outside of the imported content are removed when the content is re-imported, so retrieve
+		// these beforehand so that they can be restored
+		preservedOperations := make([]apimanagement.OperationContract, 0)
+		if !d.IsNewResource() {
+			preservedOperations, err = retrieveApiManagementApiPreservedOperations(ctx, operationsClient, resourceGroup, serviceName, apiId, importV["preserved_operation_ids"].(*pluginsdk.Set).List())
+			if err != nil {
+				return err
+			}
+		}
+
 		future, err := client.CreateOrUpdate(ctx, resourceGroup, serviceName, apiId, apiParams, "")
 		if err != nil {
 			return fmt.Errorf("creating/updating API Management API %q (Resource Group %q): %+v", name, resourceGroup, err)
@@ -364,6 +426,10 @@ func resourceApiManagementApiCreateUpdate(d *pluginsdk.ResourceData, meta interf
 		if err = future.WaitForCompletionRef(ctx, client.Client); err != nil {
 			return fmt.Errorf("waiting on creating/updating API Management API %q (Resource Group %q): %+v", name, resourceGroup, err)
 		}
+
+		if err := reconcileApiManagementApiImportedOperations(ctx, operationsClient, resourceGroup, serviceName, apiId, importedOperations, preservedOperations, importParsed && importV["prune_operations"].(bool)); err != nil {
+			return err
+		}
 	}
 
 	description := d.Get("description").(string)
@@ -502,6 +568,17 @@ func resourceApiManagementApiRead(d *pluginsdk.ResourceData, meta interface{}) e
 		}
 	}
 
+	// the imported content isn't returned from the API, so the Operations are parsed from the content in the state
+	operations := make([]interface{}, 0)
+	if options := expandApiManagementApiImportOptions(d.Get("import").([]interface{}), d.Get("soap_pass_through").(bool)); options != nil {
+		if imported, _, err := parseApiManagementApiImportedOperations(*options); err == nil {
+			operations = flattenApiManagementApiImportedOperations(imported)
+		}
+	}
+	if err := d.Set("operations", operations); err != nil {
+		return fmt.Errorf("setting `operations`: %+v", err)
+	}
+
 	return nil
 }
 
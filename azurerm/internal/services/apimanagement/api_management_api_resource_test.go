@@ -171,6 +171,8 @@ func TestAccApiManagementApi_importSwagger(t *testing.T) {
 			Config: r.importSwagger(data),
 			Check: acceptance.ComposeTestCheckFunc(
 				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("operations.#").HasValue("2"),
+				check.That(data.ResourceName).Key("operations.0.operation_id").HasValue("searchInventory"),
 			),
 		},
 		{
@@ -180,6 +182,7 @@ func TestAccApiManagementApi_importSwagger(t *testing.T) {
 			ImportStateVerifyIgnore: []string{
 				// not returned from the API
 				"import",
+				"operations",
 			},
 		},
 	})
@@ -203,6 +206,7 @@ func TestAccApiManagementApi_importWsdl(t *testing.T) {
 			ImportStateVerifyIgnore: []string{
 				// not returned from the API
 				"import",
+				"operations",
 			},
 		},
 	})
@@ -226,12 +230,15 @@ func TestAccApiManagementApi_importUpdate(t *testing.T) {
 			ImportStateVerifyIgnore: []string{
 				// not returned from the API
 				"import",
+				"operations",
 			},
 		},
 		{
 			Config: r.importSwagger(data),
 			Check: acceptance.ComposeTestCheckFunc(
 				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("operations.#").HasValue("2"),
+				check.That(data.ResourceName).Key("operations.0.operation_id").HasValue("searchInventory"),
 			),
 		},
 		{
@@ -241,6 +248,7 @@ func TestAccApiManagementApi_importUpdate(t *testing.T) {
 			ImportStateVerifyIgnore: []string{
 				// not returned from the API
 				"import",
+				"operations",
 			},
 		},
 	})
@@ -10,6 +10,7 @@ import (
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/azure"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/clients"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/apimanagement/parse"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/apimanagement/validate"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tf/pluginsdk"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/timeouts"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
@@ -46,6 +47,7 @@ func resourceApiManagementPolicy() *pluginsdk.Resource {
 				ConflictsWith:    []string{"xml_link"},
 				ExactlyOneOf:     []string{"xml_link", "xml_content"},
 				DiffSuppressFunc: XmlWithDotNetInterpolationsDiffSuppress,
+				ValidateFunc:     validate.ApiManagementPolicyXmlContent,
 			},
 
 			"xml_link": {
@@ -440,6 +440,7 @@ func resourceApiManagementService() *pluginsdk.Resource {
 							Computed:         true,
 							ConflictsWith:    []string{"policy.0.xml_link"},
 							DiffSuppressFunc: XmlWithDotNetInterpolationsDiffSuppress,
+							ValidateFunc:     apimValidate.ApiManagementPolicyXmlContent,
 						},
 
 						"xml_link": {
@@ -0,0 +1,287 @@
+package validate
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// the sections which can be specified within the `policies` element of an API Management Policy
+var apiManagementPolicySections = []string{
+	"inbound",
+	"backend",
+	"outbound",
+	"on-error",
+}
+
+// ApiManagementPolicyXmlContent validates the XML document of an API Management Policy, checking that the
+// policy expressions (attribute values and text starting with `@(...)` or `@{...}`) are well formed and that the `policies` element only contains
+// the known sections, each of which can be specified at most once.
+func ApiManagementPolicyXmlContent(v interface{}, k string) (warnings []string, errors []error) {
+	value, ok := v.(string)
+	if !ok {
+		errors = append(errors, fmt.Errorf("expected type of %q to be string", k))
+		return warnings, errors
+	}
+
+	if strings.TrimSpace(value) == "" {
+		return warnings, errors
+	}
+
+	// policy expressions are C# and so can contain characters (such as unescaped quotes) which aren't valid XML,
+	// however they're accepted by API Management - so these are replaced prior to parsing the XML
+	document, err := replaceApiManagementPolicyExpressions(value)
+	if err != nil {
+		errors = append(errors, fmt.Errorf("%q contains a malformed policy expression: %+v", k, err))
+		return warnings, errors
+	}
+
+	decoder := xml.NewDecoder(strings.NewReader(document))
+	depth := 0
+	hasRoot := false
+	sections := make(map[string]bool)
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			if err == io.EOF {
+				break
+			}
+			errors = append(errors, fmt.Errorf("%q is not a valid XML document: %+v", k, err))
+			return warnings, errors
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			depth++
+
+			switch depth {
+			case 1:
+				hasRoot = true
+				if t.Name.Local != "policies" {
+					errors = append(errors, fmt.Errorf("the root element of %q must be `policies` but got %q", k, t.Name.Local))
+					return warnings, errors
+				}
+			case 2:
+				if !isApiManagementPolicySection(t.Name.Local) {
+					errors = append(errors, fmt.Errorf("%q contains the unknown section %q within `policies` - possible values are %q", k, t.Name.Local, apiManagementPolicySections))
+					continue
+				}
+				if sections[t.Name.Local] {
+					errors = append(errors, fmt.Errorf("%q contains the section %q more than once", k, t.Name.Local))
+				}
+				sections[t.Name.Local] = true
+			}
+
+		case xml.EndElement:
+			depth--
+		}
+	}
+
+	if !hasRoot {
+		errors = append(errors, fmt.Errorf("%q must be an XML document with a `policies` root element", k))
+	}
+
+	return warnings, errors
+}
+
+func isApiManagementPolicySection(input string) bool {
+	for _, v := range apiManagementPolicySections {
+		if v == input {
+			return true
+		}
+	}
+	return false
+}
+
+// replaceApiManagementPolicyExpressions replaces each of the policy expressions within the input with a placeholder,
+// returning an error if any of the policy expressions are malformed. Only attribute values and text which start with
+// a policy expression are treated as one, and comments and CDATA sections are left as-is.
+func replaceApiManagementPolicyExpressions(input string) (string, error) {
+	var sb strings.Builder
+	for i := 0; i < len(input); {
+		switch {
+		case strings.HasPrefix(input[i:], "<!--"):
+			i = copyApiManagementPolicyUntil(&sb, input, i, "-->")
+
+		case strings.HasPrefix(input[i:], "<![CDATA["):
+			i = copyApiManagementPolicyUntil(&sb, input, i, "]]>")
+
+		case input[i] == '<':
+			end, err := replaceApiManagementPolicyTagExpressions(&sb, input, i)
+			if err != nil {
+				return "", err
+			}
+			i = end
+
+		default:
+			// text, which is a policy expression when it starts with one (ignoring any leading whitespace)
+			for i < len(input) && isApiManagementPolicyWhitespace(input[i]) {
+				sb.WriteByte(input[i])
+				i++
+			}
+			end, err := replaceApiManagementPolicyExpression(&sb, input, i)
+			if err != nil {
+				return "", err
+			}
+			i = end
+			for i < len(input) && input[i] != '<' {
+				sb.WriteByte(input[i])
+				i++
+			}
+		}
+	}
+
+	return sb.String(), nil
+}
+
+// replaceApiManagementPolicyTagExpressions copies the tag starting at the offset `start`, replacing any attribute values
+// which are policy expressions - returning the offset following the tag.
+func replaceApiManagementPolicyTagExpressions(sb *strings.Builder, input string, start int) (int, error) {
+	sb.WriteByte(input[start])
+	for i := start + 1; i < len(input); {
+		c := input[i]
+		if c == '>' {
+			sb.WriteByte(c)
+			return i + 1, nil
+		}
+		if c != '"' && c != '\'' {
+			sb.WriteByte(c)
+			i++
+			continue
+		}
+
+		// an attribute value, which either starts with a policy expression or is copied as-is
+		sb.WriteByte(c)
+		end, err := replaceApiManagementPolicyExpression(sb, input, i+1)
+		if err != nil {
+			return 0, err
+		}
+		i = end
+		for i < len(input) && input[i] != c {
+			sb.WriteByte(input[i])
+			i++
+		}
+		if i < len(input) {
+			sb.WriteByte(input[i])
+			i++
+		}
+	}
+
+	return len(input), nil
+}
+
+// replaceApiManagementPolicyExpression replaces the policy expression at the offset `start` (if any) with a placeholder,
+// returning the offset following it.
+func replaceApiManagementPolicyExpression(sb *strings.Builder, input string, start int) (int, error) {
+	if start+1 >= len(input) || input[start] != '@' || (input[start+1] != '(' && input[start+1] != '{') {
+		return start, nil
+	}
+
+	end, err := findApiManagementPolicyExpressionEnd(input, start+1)
+	if err != nil {
+		return 0, fmt.Errorf("the expression starting at offset %d %+v", start, err)
+	}
+	if strings.TrimSpace(input[start+2:end]) == "" {
+		return 0, fmt.Errorf("the expression starting at offset %d is empty", start)
+	}
+
+	sb.WriteString("policy-expression")
+	return end + 1, nil
+}
+
+// copyApiManagementPolicyUntil copies the input from the offset `start` up to and including the `terminator`,
+// returning the offset following it.
+func copyApiManagementPolicyUntil(sb *strings.Builder, input string, start int, terminator string) int {
+	end := strings.Index(input[start:], terminator)
+	if end == -1 {
+		sb.WriteString(input[start:])
+		return len(input)
+	}
+
+	end = start + end + len(terminator)
+	sb.WriteString(input[start:end])
+	return end
+}
+
+func isApiManagementPolicyWhitespace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\r' || c == '\n'
+}
+
+// findApiManagementPolicyExpressionEnd returns the offset of the bracket closing the bracket at the offset `start`,
+// skipping over any string and character literals within the expression.
+func findApiManagementPolicyExpressionEnd(input string, start int) (int, error) {
+	closingBrackets := map[byte]byte{
+		'(': ')',
+		'{': '}',
+		'[': ']',
+	}
+
+	expected := make([]byte, 0)
+	for i := start; i < len(input); i++ {
+		c := input[i]
+
+		if closing, ok := closingBrackets[c]; ok {
+			expected = append(expected, closing)
+			continue
+		}
+
+		switch c {
+		case ')', '}', ']':
+			if len(expected) == 0 || expected[len(expected)-1] != c {
+				return 0, fmt.Errorf("contains an unexpected %q at offset %d", string(c), i)
+			}
+			expected = expected[:len(expected)-1]
+			if len(expected) == 0 {
+				return i, nil
+			}
+			continue
+		}
+
+		if quote := apiManagementPolicyQuoteAt(input, i); quote != "" {
+			end, err := findApiManagementPolicyLiteralEnd(input, i, quote)
+			if err != nil {
+				return 0, err
+			}
+			i = end
+		}
+	}
+
+	return 0, fmt.Errorf("is missing the closing %q", string(expected[len(expected)-1]))
+}
+
+// apiManagementPolicyQuoteAt returns the quote starting at the offset `i`, since within the XML document a quote
+// can either be written as-is or as an entity reference.
+func apiManagementPolicyQuoteAt(input string, i int) string {
+	for _, quote := range []string{`"`, `'`, "&quot;", "&apos;"} {
+		if strings.HasPrefix(input[i:], quote) {
+			return quote
+		}
+	}
+	return ""
+}
+
+// findApiManagementPolicyLiteralEnd returns the offset of the final character of the string/character literal
+// starting with the quote at offset `start`.
+func findApiManagementPolicyLiteralEnd(input string, start int, quote string) (int, error) {
+	// within a verbatim string (e.g. `@"C:\"`) a backslash isn't an escape character
+	verbatim := (start > 0 && input[start-1] == '@') || (start > 1 && input[start-2:start] == "@$")
+
+	for i := start + len(quote); i < len(input); i++ {
+		if input[i] == '\\' && !verbatim {
+			i++
+			continue
+		}
+
+		if strings.HasPrefix(input[i:], quote) {
+			// within a verbatim string a quote is escaped by doubling it
+			if verbatim && strings.HasPrefix(input[i+len(quote):], quote) {
+				i += 2*len(quote) - 1
+				continue
+			}
+			return i + len(quote) - 1, nil
+		}
+	}
+
+	return 0, fmt.Errorf("contains an unterminated literal starting at offset %d", start)
+}
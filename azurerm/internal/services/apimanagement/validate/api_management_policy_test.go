@@ -0,0 +1,136 @@
+package validate
+
+import "testing"
+
+func TestApiManagementPolicyXmlContent(t *testing.T) {
+	cases := []struct {
+		Name   string
+		Input  string
+		Errors int
+	}{
+		{
+			Name:   "empty",
+			Input:  "",
+			Errors: 0,
+		},
+		{
+			Name:   "not xml",
+			Input:  "this is not xml",
+			Errors: 1,
+		},
+		{
+			Name:   "invalid xml",
+			Input:  "<policies><inbound></policies>",
+			Errors: 1,
+		},
+		{
+			Name:   "unknown root element",
+			Input:  "<policy><inbound /></policy>",
+			Errors: 1,
+		},
+		{
+			Name:   "all sections",
+			Input:  "<policies><inbound><base /></inbound><backend><base /></backend><outbound /><on-error /></policies>",
+			Errors: 0,
+		},
+		{
+			Name:   "unknown section",
+			Input:  "<policies><inbound /><inbund /></policies>",
+			Errors: 1,
+		},
+		{
+			Name:   "duplicate section",
+			Input:  "<policies><inbound /><inbound /></policies>",
+			Errors: 1,
+		},
+		{
+			Name:   "nested elements aren't sections",
+			Input:  "<policies><inbound><choose><when condition=\"true\"><inbound /></when></choose></inbound></policies>",
+			Errors: 0,
+		},
+		{
+			Name: "expression with unescaped quotes",
+			Input: `<policies>
+  <inbound>
+    <set-variable name="abc" value="@(context.Request.Headers.GetValueOrDefault("X-Header-Name", ""))" />
+    <find-and-replace from="xyz" to="abc" />
+  </inbound>
+</policies>`,
+			Errors: 0,
+		},
+		{
+			Name:   "expression with escaped quotes",
+			Input:  `<policies><inbound><set-variable name="abc" value="@(context.Variables[&quot;a)b&quot;])" /></inbound></policies>`,
+			Errors: 0,
+		},
+		{
+			Name:   "expression with generics",
+			Input:  `<policies><inbound><choose><when condition="@(context.Variables.GetValueOrDefault<bool>("isAuthOk") && 1 < 2)" /></choose></inbound></policies>`,
+			Errors: 0,
+		},
+		{
+			Name:   "expression with brackets in literals",
+			Input:  `<policies><inbound><set-body>@{ var a = "}"; var b = '('; var c = @"C:\"")"; return a + b + c; }</set-body></inbound></policies>`,
+			Errors: 0,
+		},
+		{
+			Name:   "escaped at",
+			Input:  `<policies><inbound><set-header name="x"><value>user@@(example)</value></set-header></inbound></policies>`,
+			Errors: 0,
+		},
+		{
+			Name:   "at within text",
+			Input:  `<policies><inbound><set-header name="x"><value>user@(example</value></set-header></inbound></policies>`,
+			Errors: 0,
+		},
+		{
+			Name:   "at within attribute value",
+			Input:  `<policies><inbound><set-header name="user@{example" exists-action="override" /></inbound></policies>`,
+			Errors: 0,
+		},
+		{
+			Name:   "expression within comment",
+			Input:  `<policies><!-- @(context.Request.Id --><inbound /></policies>`,
+			Errors: 0,
+		},
+		{
+			Name:   "expression within text with leading whitespace",
+			Input:  "<policies><inbound><set-body>\n  @(context.Request.Id\n</set-body></inbound></policies>",
+			Errors: 1,
+		},
+		{
+			Name:   "unterminated expression",
+			Input:  `<policies><inbound><set-variable name="abc" value="@(context.Request.Id" /></inbound></policies>`,
+			Errors: 1,
+		},
+		{
+			Name:   "brackets following expression",
+			Input:  `<policies><inbound><set-variable name="abc" value="@(context.Variables["a"])]" /></inbound></policies>`,
+			Errors: 0,
+		},
+		{
+			Name:   "mismatched brackets within expression",
+			Input:  `<policies><inbound><set-variable name="abc" value="@(context.Variables["a")]" /></inbound></policies>`,
+			Errors: 1,
+		},
+		{
+			Name:   "unterminated string literal",
+			Input:  `<policies><inbound><set-variable name="abc" value="@(context.Variables["a])" /></inbound></policies>`,
+			Errors: 1,
+		},
+		{
+			Name:   "empty expression",
+			Input:  `<policies><inbound><set-variable name="abc" value="@( )" /></inbound></policies>`,
+			Errors: 1,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			_, errors := ApiManagementPolicyXmlContent(tc.Input, "xml_content")
+			if len(errors) != tc.Errors {
+				t.Fatalf("Expected %d errors but got %d: %+v", tc.Errors, len(errors), errors)
+			}
+		})
+	}
+}
@@ -17,7 +17,6 @@ import (
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/cognitive/validate"
 	msiparse "github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/msi/parse"
 	msiValidate "github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/msi/validate"
-	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/network"
 	networkParse "github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/network/parse"
 	storageValidate "github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/storage/validate"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tags"
@@ -350,20 +349,21 @@ func resourceCognitiveAccountCreate(d *pluginsdk.ResourceData, meta interface{})
 
 	networkAcls, subnetIds := expandCognitiveAccountNetworkAcls(d)
 
-	// also lock on the Virtual Network ID's since modifications in the networking stack are exclusive
-	virtualNetworkNames := make([]string, 0)
+	// also lock on the Subnets (sharing a lock on their Virtual Networks) since modifications in the networking stack are exclusive
+	subnetIdsToLock := make([]locks.ResourceID, 0)
+	virtualNetworkIdsToLock := make([]locks.ResourceID, 0)
 	for _, v := range subnetIds {
 		id, err := networkParse.SubnetIDInsensitively(v)
 		if err != nil {
 			return err
 		}
-		if !utils.SliceContainsValue(virtualNetworkNames, id.VirtualNetworkName) {
-			virtualNetworkNames = append(virtualNetworkNames, id.VirtualNetworkName)
-		}
+
+		subnetIdsToLock = append(subnetIdsToLock, *id)
+		virtualNetworkIdsToLock = append(virtualNetworkIdsToLock, networkParse.NewVirtualNetworkID(id.SubscriptionId, id.ResourceGroup, id.VirtualNetworkName))
 	}
 
-	locks.MultipleByName(&virtualNetworkNames, network.VirtualNetworkResourceName)
-	defer locks.UnlockMultipleByName(&virtualNetworkNames, network.VirtualNetworkResourceName)
+	locks.MultipleChildrenByResourceID(virtualNetworkIdsToLock, subnetIdsToLock)
+	defer locks.UnlockMultipleChildrenByResourceID(virtualNetworkIdsToLock, subnetIdsToLock)
 	publicNetworkAccess := cognitiveservices.PublicNetworkAccessEnabled
 	if !d.Get("public_network_access_enabled").(bool) {
 		publicNetworkAccess = cognitiveservices.PublicNetworkAccessDisabled
@@ -435,20 +435,21 @@ func resourceCognitiveAccountUpdate(d *pluginsdk.ResourceData, meta interface{})
 
 	networkAcls, subnetIds := expandCognitiveAccountNetworkAcls(d)
 
-	// also lock on the Virtual Network ID's since modifications in the networking stack are exclusive
-	virtualNetworkNames := make([]string, 0)
+	// also lock on the Subnets (sharing a lock on their Virtual Networks) since modifications in the networking stack are exclusive
+	subnetIdsToLock := make([]locks.ResourceID, 0)
+	virtualNetworkIdsToLock := make([]locks.ResourceID, 0)
 	for _, v := range subnetIds {
 		id, err := networkParse.SubnetIDInsensitively(v)
 		if err != nil {
 			return err
 		}
-		if !utils.SliceContainsValue(virtualNetworkNames, id.VirtualNetworkName) {
-			virtualNetworkNames = append(virtualNetworkNames, id.VirtualNetworkName)
-		}
+
+		subnetIdsToLock = append(subnetIdsToLock, *id)
+		virtualNetworkIdsToLock = append(virtualNetworkIdsToLock, networkParse.NewVirtualNetworkID(id.SubscriptionId, id.ResourceGroup, id.VirtualNetworkName))
 	}
 
-	locks.MultipleByName(&virtualNetworkNames, network.VirtualNetworkResourceName)
-	defer locks.UnlockMultipleByName(&virtualNetworkNames, network.VirtualNetworkResourceName)
+	locks.MultipleChildrenByResourceID(virtualNetworkIdsToLock, subnetIdsToLock)
+	defer locks.UnlockMultipleChildrenByResourceID(virtualNetworkIdsToLock, subnetIdsToLock)
 
 	publicNetworkAccess := cognitiveservices.PublicNetworkAccessEnabled
 	if !d.Get("public_network_access_enabled").(bool) {
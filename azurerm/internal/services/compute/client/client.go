@@ -1,7 +1,7 @@
 package client
 
 import (
-	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2020-12-01/compute"
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-03-01/compute"
 	"github.com/Azure/azure-sdk-for-go/services/marketplaceordering/mgmt/2015-06-01/marketplaceordering"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/common"
 )
@@ -23,6 +23,7 @@ type Client struct {
 	UsageClient                     *compute.UsageClient
 	VMExtensionImageClient          *compute.VirtualMachineExtensionImagesClient
 	VMExtensionClient               *compute.VirtualMachineExtensionsClient
+	VMRunCommandsClient             *compute.VirtualMachineRunCommandsClient
 	VMScaleSetClient                *compute.VirtualMachineScaleSetsClient
 	VMScaleSetExtensionsClient      *compute.VirtualMachineScaleSetExtensionsClient
 	VMScaleSetRollingUpgradesClient *compute.VirtualMachineScaleSetRollingUpgradesClient
@@ -81,6 +82,9 @@ func NewClient(o *common.ClientOptions) *Client {
 	vmExtensionClient := compute.NewVirtualMachineExtensionsClientWithBaseURI(o.ResourceManagerEndpoint, o.SubscriptionId)
 	o.ConfigureClient(&vmExtensionClient.Client, o.ResourceManagerAuthorizer)
 
+	vmRunCommandsClient := compute.NewVirtualMachineRunCommandsClientWithBaseURI(o.ResourceManagerEndpoint, o.SubscriptionId)
+	o.ConfigureClient(&vmRunCommandsClient.Client, o.ResourceManagerAuthorizer)
+
 	vmImageClient := compute.NewVirtualMachineImagesClientWithBaseURI(o.ResourceManagerEndpoint, o.SubscriptionId)
 	o.ConfigureClient(&vmImageClient.Client, o.ResourceManagerAuthorizer)
 
@@ -119,6 +123,7 @@ func NewClient(o *common.ClientOptions) *Client {
 		UsageClient:                     &usageClient,
 		VMExtensionImageClient:          &vmExtensionImageClient,
 		VMExtensionClient:               &vmExtensionClient,
+		VMRunCommandsClient:             &vmRunCommandsClient,
 		VMScaleSetClient:                &vmScaleSetClient,
 		VMScaleSetExtensionsClient:      &vmScaleSetExtensionsClient,
 		VMScaleSetRollingUpgradesClient: &vmScaleSetRollingUpgradesClient,
@@ -7,7 +7,7 @@ import (
 	"strings"
 	"time"
 
-	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2020-12-01/compute"
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-03-01/compute"
 	"github.com/hashicorp/go-azure-helpers/response"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/azure"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/tf"
@@ -37,7 +37,7 @@ func resourceLinuxVirtualMachine() *pluginsdk.Resource {
 		Importer: pluginsdk.ImporterValidatingResourceIdThen(func(id string) error {
 			_, err := parse.VirtualMachineID(id)
 			return err
-		}, importVirtualMachine(compute.Linux, "azurerm_linux_virtual_machine")),
+		}, importVirtualMachine(compute.OperatingSystemTypesLinux, "azurerm_linux_virtual_machine")),
 
 		Timeouts: &pluginsdk.ResourceTimeout{
 			Create: pluginsdk.DefaultTimeout(45 * time.Minute),
@@ -133,6 +133,8 @@ func resourceLinuxVirtualMachine() *pluginsdk.Resource {
 
 			"custom_data": base64.OptionalSchema(true),
 
+			"data_disk": virtualMachineDataDiskSchema(),
+
 			"dedicated_host_id": {
 				Type:         pluginsdk.TypeString,
 				Optional:     true,
@@ -162,7 +164,7 @@ func resourceLinuxVirtualMachine() *pluginsdk.Resource {
 				ForceNew: true,
 				ValidateFunc: validation.StringInSlice([]string{
 					// NOTE: whilst Delete is an option here, it's only applicable for VMSS
-					string(compute.Deallocate),
+					string(compute.VirtualMachineEvictionPolicyTypesDeallocate),
 				}, false),
 			},
 
@@ -197,10 +199,10 @@ func resourceLinuxVirtualMachine() *pluginsdk.Resource {
 				Type:     pluginsdk.TypeString,
 				Optional: true,
 				ForceNew: true,
-				Default:  string(compute.Regular),
+				Default:  string(compute.VirtualMachinePriorityTypesRegular),
 				ValidateFunc: validation.StringInSlice([]string{
-					string(compute.Regular),
-					string(compute.Spot),
+					string(compute.VirtualMachinePriorityTypesRegular),
+					string(compute.VirtualMachinePriorityTypesSpot),
 				}, false),
 			},
 
@@ -358,7 +360,12 @@ func resourceLinuxVirtualMachineCreate(d *pluginsdk.ResourceData, meta interface
 	networkInterfaceIds := expandVirtualMachineNetworkInterfaceIDs(networkInterfaceIdsRaw)
 
 	osDiskRaw := d.Get("os_disk").([]interface{})
-	osDisk := expandVirtualMachineOSDisk(osDiskRaw, compute.Linux)
+	osDisk := expandVirtualMachineOSDisk(osDiskRaw, compute.OperatingSystemTypesLinux)
+
+	dataDiskChanges, err := expandVirtualMachineDataDisks([]interface{}{}, d.Get("data_disk").([]interface{}), nil)
+	if err != nil {
+		return fmt.Errorf("expanding `data_disk`: %+v", err)
+	}
 
 	secretsRaw := d.Get("secret").([]interface{})
 	secrets := expandLinuxSecrets(secretsRaw)
@@ -403,9 +410,9 @@ func resourceLinuxVirtualMachineCreate(d *pluginsdk.ResourceData, meta interface
 				ImageReference: sourceImageReference,
 				OsDisk:         osDisk,
 
-				// Data Disks are instead handled via the Association resource - as such we can send an empty value here
-				// but for Updates this'll need to be nil, else any associations will be overwritten
-				DataDisks: &[]compute.DataDisk{},
+				// Data Disks can also be attached using the Association resource - as such Updates only send the
+				// Data Disks when the `data_disk` block has changed, else any associations will be overwritten
+				DataDisks: &dataDiskChanges.dataDisks,
 			},
 
 			// Optional
@@ -447,17 +454,17 @@ func resourceLinuxVirtualMachineCreate(d *pluginsdk.ResourceData, meta interface
 	}
 
 	if evictionPolicyRaw, ok := d.GetOk("eviction_policy"); ok {
-		if params.Priority != compute.Spot {
+		if params.Priority != compute.VirtualMachinePriorityTypesSpot {
 			return fmt.Errorf("An `eviction_policy` can only be specified when `priority` is set to `Spot`")
 		}
 
 		params.EvictionPolicy = compute.VirtualMachineEvictionPolicyTypes(evictionPolicyRaw.(string))
-	} else if priority == compute.Spot {
+	} else if priority == compute.VirtualMachinePriorityTypesSpot {
 		return fmt.Errorf("An `eviction_policy` must be specified when `priority` is set to `Spot`")
 	}
 
 	if v, ok := d.Get("max_bid_price").(float64); ok && v > 0 {
-		if priority != compute.Spot {
+		if priority != compute.VirtualMachinePriorityTypesSpot {
 			return fmt.Errorf("`max_bid_price` can only be configured when `priority` is set to `Spot`")
 		}
 
@@ -655,7 +662,7 @@ func resourceLinuxVirtualMachineRead(d *pluginsdk.ResourceData, meta interface{}
 	}
 	// Resources created with azurerm_virtual_machine have priority set to ""
 	// We need to treat "" as equal to "Regular" to allow migration azurerm_virtual_machine -> azurerm_linux_virtual_machine
-	priority := string(compute.Regular)
+	priority := string(compute.VirtualMachinePriorityTypesRegular)
 	if props.Priority != "" {
 		priority = string(props.Priority)
 	}
@@ -676,6 +683,14 @@ func resourceLinuxVirtualMachineRead(d *pluginsdk.ResourceData, meta interface{}
 			return fmt.Errorf("settings `os_disk`: %+v", err)
 		}
 
+		flattenedDataDisks, err := flattenVirtualMachineDataDisks(ctx, disksClient, profile.DataDisks, d.Get("data_disk").([]interface{}))
+		if err != nil {
+			return fmt.Errorf("flattening `data_disk`: %+v", err)
+		}
+		if err := d.Set("data_disk", flattenedDataDisks); err != nil {
+			return fmt.Errorf("setting `data_disk`: %+v", err)
+		}
+
 		var storageImageId string
 		if profile.ImageReference != nil && profile.ImageReference.ID != nil {
 			storageImageId = *profile.ImageReference.ID
@@ -749,7 +764,7 @@ func resourceLinuxVirtualMachineUpdate(d *pluginsdk.ResourceData, meta interface
 		if storage := props.StorageProfile; storage != nil {
 			if disk := storage.OsDisk; disk != nil {
 				if settings := disk.DiffDiskSettings; settings != nil {
-					hasEphemeralOSDisk = settings.Option == compute.Local
+					hasEphemeralOSDisk = settings.Option == compute.DiffDiskOptionsLocal
 				}
 			}
 		}
@@ -865,12 +880,39 @@ func resourceLinuxVirtualMachineUpdate(d *pluginsdk.ResourceData, meta interface
 		shouldDeallocate = true
 
 		osDiskRaw := d.Get("os_disk").([]interface{})
-		osDisk := expandVirtualMachineOSDisk(osDiskRaw, compute.Linux)
+		osDisk := expandVirtualMachineOSDisk(osDiskRaw, compute.OperatingSystemTypesLinux)
 		update.VirtualMachineProperties.StorageProfile = &compute.StorageProfile{
 			OsDisk: osDisk,
 		}
 	}
 
+	var dataDiskChanges *virtualMachineDataDiskChanges
+	if d.HasChange("data_disk") {
+		shouldUpdate = true
+
+		var existingDataDisks *[]compute.DataDisk
+		if props := existing.VirtualMachineProperties; props != nil && props.StorageProfile != nil {
+			existingDataDisks = props.StorageProfile.DataDisks
+		}
+
+		oldDataDisks, newDataDisks := d.GetChange("data_disk")
+		dataDiskChanges, err = expandVirtualMachineDataDisks(oldDataDisks.([]interface{}), newDataDisks.([]interface{}), existingDataDisks)
+		if err != nil {
+			return fmt.Errorf("expanding `data_disk`: %+v", err)
+		}
+
+		// Portal: "Disks can be resized or account type changed only when they are unattached or the owner VM is deallocated."
+		if dataDiskChanges.requiresDeallocation() {
+			shouldShutDown = true
+			shouldDeallocate = true
+		}
+
+		if update.VirtualMachineProperties.StorageProfile == nil {
+			update.VirtualMachineProperties.StorageProfile = &compute.StorageProfile{}
+		}
+		update.VirtualMachineProperties.StorageProfile.DataDisks = &dataDiskChanges.dataDisks
+	}
+
 	if d.HasChange("proximity_placement_group_id") {
 		shouldUpdate = true
 
@@ -1093,6 +1135,18 @@ func resourceLinuxVirtualMachineUpdate(d *pluginsdk.ResourceData, meta interface
 		}
 	}
 
+	if dataDiskChanges != nil {
+		// Data Disks can't be moved to another LUN in-place, so these are detached and then re-attached below
+		if err := dataDiskChanges.detachMovingDataDisks(ctx, client, *id); err != nil {
+			return err
+		}
+
+		disksClient := meta.(*clients.Client).Compute.DisksClient
+		if err := dataDiskChanges.updateManagedDisks(ctx, disksClient); err != nil {
+			return fmt.Errorf("updating Data Disks for Linux Virtual Machine %q (Resource Group %q): %+v", id.Name, id.ResourceGroup, err)
+		}
+	}
+
 	if shouldUpdate {
 		log.Printf("[DEBUG] Updating Linux Virtual Machine %q (Resource Group %q)..", id.Name, id.ResourceGroup)
 		future, err := client.Update(ctx, id.ResourceGroup, id.Name, update)
@@ -1107,6 +1161,14 @@ func resourceLinuxVirtualMachineUpdate(d *pluginsdk.ResourceData, meta interface
 		log.Printf("[DEBUG] Updated Linux Virtual Machine %q (Resource Group %q).", id.Name, id.ResourceGroup)
 	}
 
+	// the Data Disks which have been removed are only deleted once they've been detached
+	if dataDiskChanges != nil {
+		disksClient := meta.(*clients.Client).Compute.DisksClient
+		if err := deleteVirtualMachineDataDisks(ctx, disksClient, dataDiskChanges.disksToDelete); err != nil {
+			return fmt.Errorf("deleting Data Disks for Linux Virtual Machine %q (Resource Group %q): %+v", id.Name, id.ResourceGroup, err)
+		}
+	}
+
 	// if we've shut it down and it was turned off, let's boot it back up
 	if shouldTurnBackOn && shouldShutDown {
 		log.Printf("[DEBUG] Starting Linux Virtual Machine %q (Resource Group %q)..", id.Name, id.ResourceGroup)
@@ -1261,5 +1323,12 @@ func resourceLinuxVirtualMachineDelete(d *pluginsdk.ResourceData, meta interface
 		}
 	}
 
+	// unlike the OS Disk, Data Disks aren't deleted along with the Virtual Machine - so those managed by a `data_disk`
+	// block with `delete_on_termination` enabled are deleted once the Virtual Machine has gone
+	dataDisksToDelete := virtualMachineDataDisksToDelete(d.Get("data_disk").([]interface{}), &existing)
+	if err := deleteVirtualMachineDataDisks(ctx, meta.(*clients.Client).Compute.DisksClient, dataDisksToDelete); err != nil {
+		return fmt.Errorf("deleting Data Disks for Linux Virtual Machine %q (Resource Group %q): %+v", id.Name, id.ResourceGroup, err)
+	}
+
 	return nil
 }
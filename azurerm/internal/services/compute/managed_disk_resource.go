@@ -6,7 +6,7 @@ import (
 	"strings"
 	"time"
 
-	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2020-12-01/compute"
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-03-01/compute"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/azure"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/tf"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/clients"
@@ -57,10 +57,10 @@ func resourceManagedDisk() *pluginsdk.Resource {
 				Type:     pluginsdk.TypeString,
 				Required: true,
 				ValidateFunc: validation.StringInSlice([]string{
-					string(compute.StandardLRS),
-					string(compute.PremiumLRS),
-					string(compute.StandardSSDLRS),
-					string(compute.UltraSSDLRS),
+					string(compute.DiskStorageAccountTypesStandardLRS),
+					string(compute.DiskStorageAccountTypesPremiumLRS),
+					string(compute.DiskStorageAccountTypesStandardSSDLRS),
+					string(compute.DiskStorageAccountTypesUltraSSDLRS),
 				}, false),
 				DiffSuppressFunc: suppress.CaseDifference,
 			},
@@ -70,11 +70,11 @@ func resourceManagedDisk() *pluginsdk.Resource {
 				Required: true,
 				ForceNew: true,
 				ValidateFunc: validation.StringInSlice([]string{
-					string(compute.Copy),
-					string(compute.Empty),
-					string(compute.FromImage),
-					string(compute.Import),
-					string(compute.Restore),
+					string(compute.DiskCreateOptionCopy),
+					string(compute.DiskCreateOptionEmpty),
+					string(compute.DiskCreateOptionFromImage),
+					string(compute.DiskCreateOptionImport),
+					string(compute.DiskCreateOptionRestore),
 				}, false),
 			},
 
@@ -108,8 +108,8 @@ func resourceManagedDisk() *pluginsdk.Resource {
 				Type:     pluginsdk.TypeString,
 				Optional: true,
 				ValidateFunc: validation.StringInSlice([]string{
-					string(compute.Windows),
-					string(compute.Linux),
+					string(compute.OperatingSystemTypesWindows),
+					string(compute.OperatingSystemTypesLinux),
 				}, true),
 			},
 
@@ -147,9 +147,9 @@ func resourceManagedDisk() *pluginsdk.Resource {
 				Type:     pluginsdk.TypeString,
 				Optional: true,
 				ValidateFunc: validation.StringInSlice([]string{
-					string(compute.AllowAll),
-					string(compute.AllowPrivate),
-					string(compute.DenyAll),
+					string(compute.NetworkAccessPolicyAllowAll),
+					string(compute.NetworkAccessPolicyAllowPrivate),
+					string(compute.NetworkAccessPolicyDenyAll),
 				}, false),
 			},
 			"disk_access_id": {
@@ -221,7 +221,7 @@ func resourceManagedDiskCreate(d *pluginsdk.ResourceData, meta interface{}) erro
 		props.DiskSizeGB = &diskSize
 	}
 
-	if storageAccountType == string(compute.UltraSSDLRS) {
+	if storageAccountType == string(compute.DiskStorageAccountTypesUltraSSDLRS) {
 		if d.HasChange("disk_iops_read_write") {
 			v := d.Get("disk_iops_read_write")
 			diskIOPS := int64(v.(int))
@@ -237,7 +237,7 @@ func resourceManagedDiskCreate(d *pluginsdk.ResourceData, meta interface{}) erro
 		return fmt.Errorf("[ERROR] disk_iops_read_write and disk_mbps_read_write are only available for UltraSSD disks")
 	}
 
-	if createOption == compute.Import {
+	if createOption == compute.DiskCreateOptionImport {
 		sourceUri := d.Get("source_uri").(string)
 		if sourceUri == "" {
 			return fmt.Errorf("`source_uri` must be specified when `create_option` is set to `Import`")
@@ -251,7 +251,7 @@ func resourceManagedDiskCreate(d *pluginsdk.ResourceData, meta interface{}) erro
 		props.CreationData.StorageAccountID = utils.String(storageAccountId)
 		props.CreationData.SourceURI = utils.String(sourceUri)
 	}
-	if createOption == compute.Copy || createOption == compute.Restore {
+	if createOption == compute.DiskCreateOptionCopy || createOption == compute.DiskCreateOptionRestore {
 		sourceResourceId := d.Get("source_resource_id").(string)
 		if sourceResourceId == "" {
 			return fmt.Errorf("`source_resource_id` must be specified when `create_option` is set to `Copy` or `Restore`")
@@ -259,7 +259,7 @@ func resourceManagedDiskCreate(d *pluginsdk.ResourceData, meta interface{}) erro
 
 		props.CreationData.SourceResourceID = utils.String(sourceResourceId)
 	}
-	if createOption == compute.FromImage {
+	if createOption == compute.DiskCreateOptionFromImage {
 		imageReferenceId := d.Get("image_reference_id").(string)
 		if imageReferenceId == "" {
 			return fmt.Errorf("`image_reference_id` must be specified when `create_option` is set to `Import`")
@@ -286,14 +286,14 @@ func resourceManagedDiskCreate(d *pluginsdk.ResourceData, meta interface{}) erro
 	if networkAccessPolicy := d.Get("network_access_policy").(string); networkAccessPolicy != "" {
 		props.NetworkAccessPolicy = compute.NetworkAccessPolicy(networkAccessPolicy)
 	} else {
-		props.NetworkAccessPolicy = compute.AllowAll
+		props.NetworkAccessPolicy = compute.NetworkAccessPolicyAllowAll
 	}
 
 	if diskAccessID := d.Get("disk_access_id").(string); d.HasChange("disk_access_id") {
 		switch {
-		case props.NetworkAccessPolicy == compute.AllowPrivate:
+		case props.NetworkAccessPolicy == compute.NetworkAccessPolicyAllowPrivate:
 			props.DiskAccessID = utils.String(diskAccessID)
-		case diskAccessID != "" && props.NetworkAccessPolicy != compute.AllowPrivate:
+		case diskAccessID != "" && props.NetworkAccessPolicy != compute.NetworkAccessPolicyAllowPrivate:
 			return fmt.Errorf("[ERROR] disk_access_id is only available when network_access_policy is set to AllowPrivate")
 		default:
 			props.DiskAccessID = nil
@@ -301,7 +301,7 @@ func resourceManagedDiskCreate(d *pluginsdk.ResourceData, meta interface{}) erro
 	}
 
 	if tier := d.Get("tier").(string); tier != "" {
-		if storageAccountType != string(compute.PremiumZRS) && storageAccountType != string(compute.PremiumLRS) {
+		if storageAccountType != string(compute.DiskStorageAccountTypesPremiumZRS) && storageAccountType != string(compute.DiskStorageAccountTypesPremiumLRS) {
 			return fmt.Errorf("`tier` can only be specified when `storage_account_type` is set to `Premium_LRS` or `Premium_ZRS`")
 		}
 		props.Tier = &tier
@@ -366,7 +366,7 @@ func resourceManagedDiskUpdate(d *pluginsdk.ResourceData, meta interface{}) erro
 	}
 
 	if d.HasChange("tier") {
-		if storageAccountType != string(compute.PremiumZRS) && storageAccountType != string(compute.PremiumLRS) {
+		if storageAccountType != string(compute.DiskStorageAccountTypesPremiumZRS) && storageAccountType != string(compute.DiskStorageAccountTypesPremiumLRS) {
 			return fmt.Errorf("`tier` can only be specified when `storage_account_type` is set to `Premium_LRS` or `Premium_ZRS`")
 		}
 		shouldShutDown = true
@@ -392,7 +392,7 @@ func resourceManagedDiskUpdate(d *pluginsdk.ResourceData, meta interface{}) erro
 		}
 	}
 
-	if strings.EqualFold(storageAccountType, string(compute.UltraSSDLRS)) {
+	if strings.EqualFold(storageAccountType, string(compute.DiskStorageAccountTypesUltraSSDLRS)) {
 		if d.HasChange("disk_iops_read_write") {
 			v := d.Get("disk_iops_read_write")
 			diskIOPS := int64(v.(int))
@@ -436,14 +436,14 @@ func resourceManagedDiskUpdate(d *pluginsdk.ResourceData, meta interface{}) erro
 	if networkAccessPolicy := d.Get("network_access_policy").(string); networkAccessPolicy != "" {
 		diskUpdate.NetworkAccessPolicy = compute.NetworkAccessPolicy(networkAccessPolicy)
 	} else {
-		diskUpdate.NetworkAccessPolicy = compute.AllowAll
+		diskUpdate.NetworkAccessPolicy = compute.NetworkAccessPolicyAllowAll
 	}
 
 	if diskAccessID := d.Get("disk_access_id").(string); d.HasChange("disk_access_id") {
 		switch {
-		case diskUpdate.NetworkAccessPolicy == compute.AllowPrivate:
+		case diskUpdate.NetworkAccessPolicy == compute.NetworkAccessPolicyAllowPrivate:
 			diskUpdate.DiskAccessID = utils.String(diskAccessID)
-		case diskAccessID != "" && diskUpdate.NetworkAccessPolicy != compute.AllowPrivate:
+		case diskAccessID != "" && diskUpdate.NetworkAccessPolicy != compute.NetworkAccessPolicyAllowPrivate:
 			return fmt.Errorf("[ERROR] disk_access_id is only available when network_access_policy is set to AllowPrivate")
 		default:
 			diskUpdate.DiskAccessID = nil
@@ -623,7 +623,7 @@ func resourceManagedDiskRead(d *pluginsdk.ResourceData, meta interface{}) error
 		d.Set("os_type", props.OsType)
 		d.Set("tier", props.Tier)
 
-		if networkAccessPolicy := props.NetworkAccessPolicy; networkAccessPolicy != compute.AllowAll {
+		if networkAccessPolicy := props.NetworkAccessPolicy; networkAccessPolicy != compute.NetworkAccessPolicyAllowAll {
 			d.Set("network_access_policy", props.NetworkAccessPolicy)
 		}
 		d.Set("disk_access_id", props.DiskAccessID)
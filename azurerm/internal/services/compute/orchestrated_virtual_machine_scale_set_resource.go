@@ -1,11 +1,12 @@
 package compute
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"time"
 
-	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2020-12-01/compute"
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-03-01/compute"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/azure"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/tf"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/clients"
@@ -13,6 +14,7 @@ import (
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/compute/parse"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/compute/validate"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tags"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tf/base64"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tf/pluginsdk"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tf/suppress"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tf/validation"
@@ -51,6 +53,23 @@ func resourceOrchestratedVirtualMachineScaleSet() *pluginsdk.Resource {
 
 			"location": azure.SchemaLocation(),
 
+			"data_disk": VirtualMachineScaleSetDataDiskSchema(),
+
+			"extension": VirtualMachineScaleSetExtensionsSchema(),
+
+			"instances": {
+				Type:         pluginsdk.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntBetween(0, 1000),
+			},
+
+			"network_interface": orchestratedVirtualMachineScaleSetOptionalSchema(VirtualMachineScaleSetNetworkInterfaceSchema()),
+
+			"os_disk": orchestratedVirtualMachineScaleSetOptionalSchema(VirtualMachineScaleSetOSDiskSchema()),
+
+			"os_profile": orchestratedVirtualMachineScaleSetOSProfileSchema(),
+
 			"platform_fault_domain_count": {
 				Type:     pluginsdk.TypeInt,
 				Required: true,
@@ -75,6 +94,26 @@ func resourceOrchestratedVirtualMachineScaleSet() *pluginsdk.Resource {
 				Default:  false,
 			},
 
+			"sku_name": {
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"source_image_id": {
+				Type:     pluginsdk.TypeString,
+				Optional: true,
+				ValidateFunc: validation.Any(
+					validate.ImageID,
+					validate.SharedImageID,
+					validate.SharedImageVersionID,
+				),
+			},
+
+			"source_image_reference": sourceImageReferenceSchema(false),
+
+			"termination_notification": VirtualMachineScaleSetTerminateNotificationSchema(),
+
 			// the VMO mode can only be deployed into one zone for now, and its zone will also be assigned to all its VM instances
 			"zones": azure.SchemaSingleZone(),
 
@@ -85,6 +124,146 @@ func resourceOrchestratedVirtualMachineScaleSet() *pluginsdk.Resource {
 
 			"tags": tags.Schema(),
 		},
+
+		CustomizeDiff: pluginsdk.CustomDiffWithAll(
+			// an instance profile can't be added to (or removed from) an existing Orchestrated Virtual Machine Scale Set
+			pluginsdk.ForceNewIfChange("sku_name", func(ctx context.Context, old, new, meta interface{}) bool {
+				return old.(string) == "" || new.(string) == ""
+			}),
+		),
+	}
+}
+
+// the instance profile is optional for an Orchestrated Virtual Machine Scale Set, since Virtual Machines
+// can also be attached individually using the `virtual_machine_scale_set_id` field on the Virtual Machine
+func orchestratedVirtualMachineScaleSetOptionalSchema(input *pluginsdk.Schema) *pluginsdk.Schema {
+	input.Required = false
+	input.Optional = true
+	return input
+}
+
+func orchestratedVirtualMachineScaleSetOSProfileSchema() *pluginsdk.Schema {
+	return &pluginsdk.Schema{
+		Type:     pluginsdk.TypeList,
+		Optional: true,
+		ForceNew: true,
+		MaxItems: 1,
+		Elem: &pluginsdk.Resource{
+			Schema: map[string]*pluginsdk.Schema{
+				"custom_data": base64.OptionalSchema(true),
+
+				"linux_configuration": {
+					Type:         pluginsdk.TypeList,
+					Optional:     true,
+					ForceNew:     true,
+					MaxItems:     1,
+					ExactlyOneOf: []string{"os_profile.0.linux_configuration", "os_profile.0.windows_configuration"},
+					Elem: &pluginsdk.Resource{
+						Schema: map[string]*pluginsdk.Schema{
+							"admin_username": {
+								Type:         pluginsdk.TypeString,
+								Required:     true,
+								ForceNew:     true,
+								ValidateFunc: validation.StringIsNotEmpty,
+							},
+
+							"admin_password": {
+								Type:         pluginsdk.TypeString,
+								Optional:     true,
+								ForceNew:     true,
+								Sensitive:    true,
+								ValidateFunc: validation.StringIsNotEmpty,
+							},
+
+							"admin_ssh_key": SSHKeysSchema(true),
+
+							"computer_name_prefix": {
+								Type:     pluginsdk.TypeString,
+								Optional: true,
+
+								// Computed since we reuse the VM name if one's not specified
+								Computed: true,
+								ForceNew: true,
+
+								ValidateFunc: validate.LinuxComputerNamePrefix,
+							},
+
+							"disable_password_authentication": {
+								Type:     pluginsdk.TypeBool,
+								Optional: true,
+								ForceNew: true,
+								Default:  true,
+							},
+
+							"provision_vm_agent": {
+								Type:     pluginsdk.TypeBool,
+								Optional: true,
+								ForceNew: true,
+								Default:  true,
+							},
+						},
+					},
+				},
+
+				"windows_configuration": {
+					Type:         pluginsdk.TypeList,
+					Optional:     true,
+					ForceNew:     true,
+					MaxItems:     1,
+					ExactlyOneOf: []string{"os_profile.0.linux_configuration", "os_profile.0.windows_configuration"},
+					Elem: &pluginsdk.Resource{
+						Schema: map[string]*pluginsdk.Schema{
+							"admin_username": {
+								Type:         pluginsdk.TypeString,
+								Required:     true,
+								ForceNew:     true,
+								ValidateFunc: validation.StringIsNotEmpty,
+							},
+
+							"admin_password": {
+								Type:         pluginsdk.TypeString,
+								Required:     true,
+								ForceNew:     true,
+								Sensitive:    true,
+								ValidateFunc: validation.StringIsNotEmpty,
+							},
+
+							"computer_name_prefix": {
+								Type:     pluginsdk.TypeString,
+								Optional: true,
+
+								// Computed since we reuse the VM name if one's not specified
+								Computed: true,
+								ForceNew: true,
+
+								ValidateFunc: validate.WindowsComputerNamePrefix,
+							},
+
+							"enable_automatic_updates": {
+								Type:     pluginsdk.TypeBool,
+								Optional: true,
+								ForceNew: true,
+								Default:  true,
+							},
+
+							"provision_vm_agent": {
+								Type:     pluginsdk.TypeBool,
+								Optional: true,
+								ForceNew: true,
+								Default:  true,
+							},
+
+							"timezone": {
+								Type:         pluginsdk.TypeString,
+								Optional:     true,
+								ForceNew:     true,
+								ValidateFunc: validate.VirtualMachineTimeZone(),
+							},
+						},
+					},
+				},
+			},
+		},
 	}
 }
 
@@ -97,7 +276,7 @@ func resourceOrchestratedVirtualMachineScaleSetCreateUpdate(d *pluginsdk.Resourc
 	name := d.Get("name").(string)
 
 	if d.IsNewResource() {
-		existing, err := client.Get(ctx, resourceGroup, name)
+		existing, err := client.Get(ctx, resourceGroup, name, "")
 		if err != nil {
 			if !utils.ResponseWasNotFound(existing.Response) {
 				return fmt.Errorf("checking for existing Orchestrated Virtual Machine Scale Set %q (Resource Group %q): %+v", name, resourceGroup, err)
@@ -125,6 +304,26 @@ func resourceOrchestratedVirtualMachineScaleSetCreateUpdate(d *pluginsdk.Resourc
 		}
 	}
 
+	if skuName := d.Get("sku_name").(string); skuName != "" {
+		virtualMachineProfile, err := expandOrchestratedVirtualMachineScaleSetVMProfile(d)
+		if err != nil {
+			return err
+		}
+
+		props.Sku = &compute.Sku{
+			Name:     utils.String(skuName),
+			Capacity: utils.Int64(int64(d.Get("instances").(int))),
+		}
+		props.VirtualMachineScaleSetProperties.OrchestrationMode = compute.OrchestrationModeFlexible
+		props.VirtualMachineScaleSetProperties.VirtualMachineProfile = virtualMachineProfile
+	} else {
+		for _, key := range []string{"data_disk", "extension", "network_interface", "os_disk", "os_profile", "source_image_id", "source_image_reference"} {
+			if _, ok := d.GetOk(key); ok {
+				return fmt.Errorf("`sku_name` must be specified when `%s` is set", key)
+			}
+		}
+	}
+
 	future, err := client.CreateOrUpdate(ctx, resourceGroup, name, props)
 	if err != nil {
 		return fmt.Errorf("creating Orchestrated Virtual Machine Scale Set %q (Resource Group %q): %+v", name, resourceGroup, err)
@@ -134,7 +333,7 @@ func resourceOrchestratedVirtualMachineScaleSetCreateUpdate(d *pluginsdk.Resourc
 		return fmt.Errorf("waiting for creation of Orchestrated Virtual Machine Scale Set %q (Resource Group %q): %+v", name, resourceGroup, err)
 	}
 
-	resp, err := client.Get(ctx, resourceGroup, name)
+	resp, err := client.Get(ctx, resourceGroup, name, "")
 	if err != nil {
 		return fmt.Errorf("retrieving Orchestrated Virtual Machine Scale Set %q (Resource Group %q): %+v", name, resourceGroup, err)
 	}
@@ -157,7 +356,7 @@ func resourceOrchestratedVirtualMachineScaleSetRead(d *pluginsdk.ResourceData, m
 		return err
 	}
 
-	resp, err := client.Get(ctx, id.ResourceGroup, id.Name)
+	resp, err := client.Get(ctx, id.ResourceGroup, id.Name, "")
 	if err != nil {
 		if utils.ResponseWasNotFound(resp.Response) {
 			log.Printf("[DEBUG] Orchestrated Virtual Machine Scale Set %q was not found in Resource Group %q - removing from state!", id.Name, id.ResourceGroup)
@@ -183,6 +382,27 @@ func resourceOrchestratedVirtualMachineScaleSetRead(d *pluginsdk.ResourceData, m
 		d.Set("unique_id", props.UniqueID)
 	}
 
+	skuName := ""
+	instances := 0
+	if resp.Sku != nil {
+		if resp.Sku.Name != nil {
+			skuName = *resp.Sku.Name
+		}
+		if resp.Sku.Capacity != nil {
+			instances = int(*resp.Sku.Capacity)
+		}
+	}
+	d.Set("sku_name", skuName)
+	d.Set("instances", instances)
+
+	var profile *compute.VirtualMachineScaleSetVMProfile
+	if props := resp.VirtualMachineScaleSetProperties; props != nil {
+		profile = props.VirtualMachineProfile
+	}
+	if err := flattenOrchestratedVirtualMachineScaleSetVMProfile(d, profile); err != nil {
+		return err
+	}
+
 	if err := d.Set("zones", resp.Zones); err != nil {
 		return fmt.Errorf("setting `zones`: %+v", err)
 	}
@@ -202,8 +422,10 @@ func resourceOrchestratedVirtualMachineScaleSetDelete(d *pluginsdk.ResourceData,
 
 	// @ArcturusZhang (mimicking from linux_virtual_machine_pluginsdk.go): sending `nil` here omits this value from being sent
 	// which matches the previous behaviour - we're only splitting this out so it's clear why
-	// TODO: support force deletion once it's out of Preview, if applicable
 	var forceDeletion *bool = nil
+	if meta.(*clients.Client).Features.VirtualMachineScaleSet.ForceDelete {
+		forceDeletion = utils.Bool(true)
+	}
 	future, err := client.Delete(ctx, id.ResourceGroup, id.Name, forceDeletion)
 	if err != nil {
 		return fmt.Errorf("deleting Orchestrated Virtual Machine Scale Set %q (Resource Group %q): %+v", id.Name, id.ResourceGroup, err)
@@ -215,3 +437,291 @@ func resourceOrchestratedVirtualMachineScaleSetDelete(d *pluginsdk.ResourceData,
 
 	return nil
 }
+
+func expandOrchestratedVirtualMachineScaleSetVMProfile(d *pluginsdk.ResourceData) (*compute.VirtualMachineScaleSetVMProfile, error) {
+	osProfileRaw := d.Get("os_profile").([]interface{})
+	if len(osProfileRaw) == 0 {
+		return nil, fmt.Errorf("an `os_profile` block must be specified when `sku_name` is set")
+	}
+
+	osProfile, osType, err := expandOrchestratedVirtualMachineScaleSetOSProfile(osProfileRaw, d.Get("name").(string))
+	if err != nil {
+		return nil, err
+	}
+
+	networkInterfacesRaw := d.Get("network_interface").([]interface{})
+	if len(networkInterfacesRaw) == 0 {
+		return nil, fmt.Errorf("at least one `network_interface` block must be specified when `sku_name` is set")
+	}
+	networkInterfaces, err := ExpandVirtualMachineScaleSetNetworkInterface(networkInterfacesRaw)
+	if err != nil {
+		return nil, fmt.Errorf("expanding `network_interface`: %+v", err)
+	}
+
+	osDiskRaw := d.Get("os_disk").([]interface{})
+	if len(osDiskRaw) == 0 {
+		return nil, fmt.Errorf("an `os_disk` block must be specified when `sku_name` is set")
+	}
+
+	dataDisks, err := ExpandVirtualMachineScaleSetDataDisk(d.Get("data_disk").([]interface{}), false)
+	if err != nil {
+		return nil, fmt.Errorf("expanding `data_disk`: %+v", err)
+	}
+
+	sourceImageReference, err := expandSourceImageReference(d.Get("source_image_reference").([]interface{}), d.Get("source_image_id").(string))
+	if err != nil {
+		return nil, err
+	}
+
+	profile := compute.VirtualMachineScaleSetVMProfile{
+		OsProfile: osProfile,
+		NetworkProfile: &compute.VirtualMachineScaleSetNetworkProfile{
+			NetworkInterfaceConfigurations: networkInterfaces,
+			// Flexible Scale Sets create the Network Interfaces for each instance themselves, which requires
+			// the version of the Network API to be specified
+			NetworkAPIVersion: compute.NetworkAPIVersionTwoZeroTwoZeroHyphenMinusOneOneHyphenMinusZeroOne,
+		},
+		StorageProfile: &compute.VirtualMachineScaleSetStorageProfile{
+			ImageReference: sourceImageReference,
+			OsDisk:         ExpandVirtualMachineScaleSetOSDisk(osDiskRaw, osType),
+			DataDisks:      dataDisks,
+		},
+	}
+
+	if v, ok := d.GetOk("extension"); ok {
+		profile.ExtensionProfile, _, err = expandVirtualMachineScaleSetExtensions(v.(*pluginsdk.Set).List())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if v, ok := d.GetOk("termination_notification"); ok {
+		profile.ScheduledEventsProfile = ExpandVirtualMachineScaleSetScheduledEventsProfile(v.([]interface{}))
+	}
+
+	return &profile, nil
+}
+
+func expandOrchestratedVirtualMachineScaleSetOSProfile(input []interface{}, name string) (*compute.VirtualMachineScaleSetOSProfile, compute.OperatingSystemTypes, error) {
+	raw := input[0].(map[string]interface{})
+
+	output := compute.VirtualMachineScaleSetOSProfile{}
+	if v := raw["custom_data"].(string); v != "" {
+		output.CustomData = utils.String(v)
+	}
+
+	if linuxRaw := raw["linux_configuration"].([]interface{}); len(linuxRaw) > 0 {
+		linux := linuxRaw[0].(map[string]interface{})
+
+		computerNamePrefix := linux["computer_name_prefix"].(string)
+		if computerNamePrefix == "" {
+			if _, errs := validate.LinuxComputerNamePrefix(name, "computer_name_prefix"); len(errs) > 0 {
+				return nil, "", fmt.Errorf("unable to assume default computer name prefix %s. Please adjust the %q, or specify an explicit %q", errs[0], "name", "computer_name_prefix")
+			}
+			computerNamePrefix = name
+		}
+
+		sshKeys := ExpandSSHKeys(linux["admin_ssh_key"].(*pluginsdk.Set).List())
+		disablePasswordAuthentication := linux["disable_password_authentication"].(bool)
+		adminPassword := linux["admin_password"].(string)
+
+		// Azure API: "Authentication using either SSH or by user name and password must be enabled in Linux profile."
+		if disablePasswordAuthentication && adminPassword == "" && len(sshKeys) == 0 {
+			return nil, "", fmt.Errorf("At least one SSH key must be specified if `disable_password_authentication` is enabled")
+		}
+
+		output.AdminUsername = utils.String(linux["admin_username"].(string))
+		output.ComputerNamePrefix = utils.String(computerNamePrefix)
+		output.LinuxConfiguration = &compute.LinuxConfiguration{
+			DisablePasswordAuthentication: utils.Bool(disablePasswordAuthentication),
+			ProvisionVMAgent:              utils.Bool(linux["provision_vm_agent"].(bool)),
+			SSH: &compute.SSHConfiguration{
+				PublicKeys: &sshKeys,
+			},
+		}
+		if adminPassword != "" {
+			output.AdminPassword = utils.String(adminPassword)
+		}
+
+		return &output, compute.OperatingSystemTypesLinux, nil
+	}
+
+	windowsRaw := raw["windows_configuration"].([]interface{})
+	if len(windowsRaw) == 0 {
+		return nil, "", fmt.Errorf("either a `linux_configuration` or a `windows_configuration` block must be specified within `os_profile`")
+	}
+	windows := windowsRaw[0].(map[string]interface{})
+
+	computerNamePrefix := windows["computer_name_prefix"].(string)
+	if computerNamePrefix == "" {
+		if _, errs := validate.WindowsComputerNamePrefix(name, "computer_name_prefix"); len(errs) > 0 {
+			return nil, "", fmt.Errorf("unable to assume default computer name prefix %s. Please adjust the %q, or specify an explicit %q", errs[0], "name", "computer_name_prefix")
+		}
+		computerNamePrefix = name
+	}
+
+	output.AdminUsername = utils.String(windows["admin_username"].(string))
+	output.AdminPassword = utils.String(windows["admin_password"].(string))
+	output.ComputerNamePrefix = utils.String(computerNamePrefix)
+	output.WindowsConfiguration = &compute.WindowsConfiguration{
+		EnableAutomaticUpdates: utils.Bool(windows["enable_automatic_updates"].(bool)),
+		ProvisionVMAgent:       utils.Bool(windows["provision_vm_agent"].(bool)),
+	}
+	if v := windows["timezone"].(string); v != "" {
+		output.WindowsConfiguration.TimeZone = utils.String(v)
+	}
+
+	return &output, compute.OperatingSystemTypesWindows, nil
+}
+
+func flattenOrchestratedVirtualMachineScaleSetVMProfile(d *pluginsdk.ResourceData, input *compute.VirtualMachineScaleSetVMProfile) error {
+	dataDisks := make([]interface{}, 0)
+	extensions := make([]map[string]interface{}, 0)
+	networkInterfaces := make([]interface{}, 0)
+	osDisk := make([]interface{}, 0)
+	osProfile := make([]interface{}, 0)
+	sourceImageId := ""
+	sourceImageReference := make([]interface{}, 0)
+	terminationNotification := make([]interface{}, 0)
+
+	if input != nil {
+		if storageProfile := input.StorageProfile; storageProfile != nil {
+			dataDisks = FlattenVirtualMachineScaleSetDataDisk(storageProfile.DataDisks)
+			osDisk = FlattenVirtualMachineScaleSetOSDisk(storageProfile.OsDisk)
+			sourceImageReference = flattenSourceImageReference(storageProfile.ImageReference)
+			if storageProfile.ImageReference != nil && storageProfile.ImageReference.ID != nil {
+				sourceImageId = *storageProfile.ImageReference.ID
+			}
+		}
+
+		if networkProfile := input.NetworkProfile; networkProfile != nil {
+			networkInterfaces = FlattenVirtualMachineScaleSetNetworkInterface(networkProfile.NetworkInterfaceConfigurations)
+		}
+
+		var err error
+		osProfile, err = flattenOrchestratedVirtualMachineScaleSetOSProfile(d, input.OsProfile)
+		if err != nil {
+			return fmt.Errorf("flattening `os_profile`: %+v", err)
+		}
+
+		extensions, err = flattenVirtualMachineScaleSetExtensions(input.ExtensionProfile, d)
+		if err != nil {
+			return fmt.Errorf("flattening `extension`: %+v", err)
+		}
+
+		terminationNotification = FlattenVirtualMachineScaleSetScheduledEventsProfile(input.ScheduledEventsProfile)
+	}
+
+	if err := d.Set("data_disk", dataDisks); err != nil {
+		return fmt.Errorf("setting `data_disk`: %+v", err)
+	}
+	if err := d.Set("extension", extensions); err != nil {
+		return fmt.Errorf("setting `extension`: %+v", err)
+	}
+	if err := d.Set("network_interface", networkInterfaces); err != nil {
+		return fmt.Errorf("setting `network_interface`: %+v", err)
+	}
+	if err := d.Set("os_disk", osDisk); err != nil {
+		return fmt.Errorf("setting `os_disk`: %+v", err)
+	}
+	if err := d.Set("os_profile", osProfile); err != nil {
+		return fmt.Errorf("setting `os_profile`: %+v", err)
+	}
+	d.Set("source_image_id", sourceImageId)
+	if err := d.Set("source_image_reference", sourceImageReference); err != nil {
+		return fmt.Errorf("setting `source_image_reference`: %+v", err)
+	}
+	if err := d.Set("termination_notification", terminationNotification); err != nil {
+		return fmt.Errorf("setting `termination_notification`: %+v", err)
+	}
+
+	return nil
+}
+
+func flattenOrchestratedVirtualMachineScaleSetOSProfile(d *pluginsdk.ResourceData, input *compute.VirtualMachineScaleSetOSProfile) ([]interface{}, error) {
+	if input == nil {
+		return []interface{}{}, nil
+	}
+
+	adminUsername := ""
+	if input.AdminUsername != nil {
+		adminUsername = *input.AdminUsername
+	}
+	computerNamePrefix := ""
+	if input.ComputerNamePrefix != nil {
+		computerNamePrefix = *input.ComputerNamePrefix
+	}
+
+	// neither the Admin Password nor the Custom Data are returned from the API, so we look these up from the config
+	adminPassword := ""
+	customData := ""
+	if existing := d.Get("os_profile").([]interface{}); len(existing) > 0 && existing[0] != nil {
+		raw := existing[0].(map[string]interface{})
+		customData = raw["custom_data"].(string)
+
+		for _, key := range []string{"linux_configuration", "windows_configuration"} {
+			if v := raw[key].([]interface{}); len(v) > 0 && v[0] != nil {
+				adminPassword = v[0].(map[string]interface{})["admin_password"].(string)
+			}
+		}
+	}
+
+	linuxConfiguration := make([]interface{}, 0)
+	if linux := input.LinuxConfiguration; linux != nil {
+		disablePasswordAuthentication := false
+		if linux.DisablePasswordAuthentication != nil {
+			disablePasswordAuthentication = *linux.DisablePasswordAuthentication
+		}
+		provisionVMAgent := false
+		if linux.ProvisionVMAgent != nil {
+			provisionVMAgent = *linux.ProvisionVMAgent
+		}
+
+		flattenedSshKeys, err := FlattenSSHKeys(linux.SSH)
+		if err != nil {
+			return nil, fmt.Errorf("flattening `admin_ssh_key`: %+v", err)
+		}
+
+		linuxConfiguration = append(linuxConfiguration, map[string]interface{}{
+			"admin_username":                  adminUsername,
+			"admin_password":                  adminPassword,
+			"admin_ssh_key":                   pluginsdk.NewSet(SSHKeySchemaHash, *flattenedSshKeys),
+			"computer_name_prefix":            computerNamePrefix,
+			"disable_password_authentication": disablePasswordAuthentication,
+			"provision_vm_agent":              provisionVMAgent,
+		})
+	}
+
+	windowsConfiguration := make([]interface{}, 0)
+	if windows := input.WindowsConfiguration; windows != nil {
+		enableAutomaticUpdates := false
+		if windows.EnableAutomaticUpdates != nil {
+			enableAutomaticUpdates = *windows.EnableAutomaticUpdates
+		}
+		provisionVMAgent := false
+		if windows.ProvisionVMAgent != nil {
+			provisionVMAgent = *windows.ProvisionVMAgent
+		}
+		timeZone := ""
+		if windows.TimeZone != nil {
+			timeZone = *windows.TimeZone
+		}
+
+		windowsConfiguration = append(windowsConfiguration, map[string]interface{}{
+			"admin_username":           adminUsername,
+			"admin_password":           adminPassword,
+			"computer_name_prefix":     computerNamePrefix,
+			"enable_automatic_updates": enableAutomaticUpdates,
+			"provision_vm_agent":       provisionVMAgent,
+			"timezone":                 timeZone,
+		})
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"custom_data":           customData,
+			"linux_configuration":   linuxConfiguration,
+			"windows_configuration": windowsConfiguration,
+		},
+	}, nil
+}
@@ -3,6 +3,7 @@ package compute_test
 import (
 	"context"
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/acceptance"
@@ -90,13 +91,70 @@ func TestAccOrchestratedVirtualMachineScaleSet_requiresImport(t *testing.T) {
 	})
 }
 
+func TestAccOrchestratedVirtualMachineScaleSet_linuxInstances(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_orchestrated_virtual_machine_scale_set", "test")
+	r := OrchestratedVirtualMachineScaleSetResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.linuxInstances(data, 1),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("instances").HasValue("1"),
+				data.CheckWithClient(r.instancesProvisioned(1)),
+			),
+		},
+		data.ImportStep("os_profile.0.linux_configuration.0.admin_password"),
+		{
+			Config: r.linuxInstances(data, 2),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("instances").HasValue("2"),
+				data.CheckWithClient(r.instancesProvisioned(2)),
+			),
+		},
+		data.ImportStep("os_profile.0.linux_configuration.0.admin_password"),
+	})
+}
+
+func TestAccOrchestratedVirtualMachineScaleSet_linuxComplete(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_orchestrated_virtual_machine_scale_set", "test")
+	r := OrchestratedVirtualMachineScaleSetResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.linuxComplete(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep("os_profile.0.custom_data"),
+	})
+}
+
+func TestAccOrchestratedVirtualMachineScaleSet_windowsInstances(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_orchestrated_virtual_machine_scale_set", "test")
+	r := OrchestratedVirtualMachineScaleSetResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.windowsInstances(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				data.CheckWithClient(r.instancesProvisioned(1)),
+			),
+		},
+		data.ImportStep("os_profile.0.windows_configuration.0.admin_password"),
+	})
+}
+
 func (t OrchestratedVirtualMachineScaleSetResource) Exists(ctx context.Context, clients *clients.Client, state *pluginsdk.InstanceState) (*bool, error) {
 	id, err := parse.VirtualMachineScaleSetID(state.ID)
 	if err != nil {
 		return nil, err
 	}
 
-	resp, err := clients.Compute.VMScaleSetClient.Get(ctx, id.ResourceGroup, id.Name)
+	resp, err := clients.Compute.VMScaleSetClient.Get(ctx, id.ResourceGroup, id.Name, "")
 	if err != nil {
 		return nil, fmt.Errorf("retrieving Compute Marketplace Agreement %q", id.String())
 	}
@@ -104,6 +162,45 @@ func (t OrchestratedVirtualMachineScaleSetResource) Exists(ctx context.Context,
 	return utils.Bool(resp.ID != nil), nil
 }
 
+// instancesProvisioned checks the expected number of Virtual Machines have been created within the Scale Set, each
+// with a Network Interface created from the `network_interface` block
+func (OrchestratedVirtualMachineScaleSetResource) instancesProvisioned(expected int) acceptance.ClientCheckFunc {
+	return func(ctx context.Context, clients *clients.Client, state *pluginsdk.InstanceState) error {
+		id, err := parse.VirtualMachineScaleSetID(state.ID)
+		if err != nil {
+			return err
+		}
+
+		iterator, err := clients.Compute.VMClient.ListComplete(ctx, id.ResourceGroup)
+		if err != nil {
+			return fmt.Errorf("listing Virtual Machines within Resource Group %q: %+v", id.ResourceGroup, err)
+		}
+
+		count := 0
+		for iterator.NotDone() {
+			vm := iterator.Value()
+			if props := vm.VirtualMachineProperties; props != nil && props.VirtualMachineScaleSet != nil && props.VirtualMachineScaleSet.ID != nil {
+				if strings.EqualFold(*props.VirtualMachineScaleSet.ID, id.ID()) {
+					if props.NetworkProfile == nil || props.NetworkProfile.NetworkInterfaces == nil || len(*props.NetworkProfile.NetworkInterfaces) == 0 {
+						return fmt.Errorf("Virtual Machine %q within %s has no Network Interfaces", *vm.Name, id.String())
+					}
+					count++
+				}
+			}
+
+			if err := iterator.NextWithContext(ctx); err != nil {
+				return fmt.Errorf("listing Virtual Machines within Resource Group %q: %+v", id.ResourceGroup, err)
+			}
+		}
+
+		if count != expected {
+			return fmt.Errorf("expected %d Virtual Machines within %s but got %d", expected, id.String(), count)
+		}
+
+		return nil
+	}
+}
+
 func (r OrchestratedVirtualMachineScaleSetResource) basic(data acceptance.TestData) string {
 	return fmt.Sprintf(`
 %s
@@ -178,6 +275,210 @@ resource "azurerm_orchestrated_virtual_machine_scale_set" "test" {
 `, r.template(data), data.RandomInteger)
 }
 
+func (r OrchestratedVirtualMachineScaleSetResource) linuxInstances(data acceptance.TestData, instances int) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_orchestrated_virtual_machine_scale_set" "test" {
+  name                = "acctestVMO-%d"
+  location            = azurerm_resource_group.test.location
+  resource_group_name = azurerm_resource_group.test.name
+
+  platform_fault_domain_count = 1
+
+  sku_name  = "Standard_F2"
+  instances = %d
+
+  os_profile {
+    linux_configuration {
+      computer_name_prefix = "testvm"
+      admin_username       = "myadmin"
+      admin_password       = "Passwword1234"
+
+      disable_password_authentication = false
+    }
+  }
+
+  network_interface {
+    name    = "TestNetworkProfile"
+    primary = true
+
+    ip_configuration {
+      name      = "TestIPConfiguration"
+      primary   = true
+      subnet_id = azurerm_subnet.test.id
+    }
+  }
+
+  os_disk {
+    storage_account_type = "Standard_LRS"
+    caching              = "ReadWrite"
+  }
+
+  source_image_reference {
+    publisher = "Canonical"
+    offer     = "UbuntuServer"
+    sku       = "16.04-LTS"
+    version   = "latest"
+  }
+}
+`, r.instancesTemplate(data), data.RandomInteger, instances)
+}
+
+func (r OrchestratedVirtualMachineScaleSetResource) linuxComplete(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_orchestrated_virtual_machine_scale_set" "test" {
+  name                = "acctestVMO-%d"
+  location            = azurerm_resource_group.test.location
+  resource_group_name = azurerm_resource_group.test.name
+
+  platform_fault_domain_count = 1
+
+  sku_name  = "Standard_F2"
+  instances = 2
+  zones     = ["1"]
+
+  os_profile {
+    custom_data = base64encode("/bin/bash")
+
+    linux_configuration {
+      computer_name_prefix = "testvm"
+      admin_username       = "myadmin"
+
+      admin_ssh_key {
+        username   = "myadmin"
+        public_key = local.first_public_key
+      }
+    }
+  }
+
+  network_interface {
+    name    = "TestNetworkProfile"
+    primary = true
+
+    ip_configuration {
+      name      = "TestIPConfiguration"
+      primary   = true
+      subnet_id = azurerm_subnet.test.id
+    }
+  }
+
+  os_disk {
+    storage_account_type = "Standard_LRS"
+    caching              = "ReadWrite"
+  }
+
+  data_disk {
+    storage_account_type = "Standard_LRS"
+    caching              = "ReadWrite"
+    disk_size_gb         = 10
+    lun                  = 10
+  }
+
+  source_image_reference {
+    publisher = "Canonical"
+    offer     = "UbuntuServer"
+    sku       = "16.04-LTS"
+    version   = "latest"
+  }
+
+  extension {
+    name                 = "CustomScript"
+    publisher            = "Microsoft.Azure.Extensions"
+    type                 = "CustomScript"
+    type_handler_version = "2.0"
+
+    settings = jsonencode({
+      "commandToExecute" = "echo $HOSTNAME"
+    })
+  }
+
+  termination_notification {
+    enabled = true
+    timeout = "PT15M"
+  }
+
+  tags = {
+    ENV = "Test"
+  }
+}
+`, r.instancesTemplate(data), data.RandomInteger)
+}
+
+func (r OrchestratedVirtualMachineScaleSetResource) windowsInstances(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_orchestrated_virtual_machine_scale_set" "test" {
+  name                = "acctestVMO-%d"
+  location            = azurerm_resource_group.test.location
+  resource_group_name = azurerm_resource_group.test.name
+
+  platform_fault_domain_count = 1
+
+  sku_name  = "Standard_F2"
+  instances = 1
+
+  os_profile {
+    windows_configuration {
+      computer_name_prefix = "testvm"
+      admin_username       = "adminuser"
+      admin_password       = "P@ssword1234!"
+    }
+  }
+
+  network_interface {
+    name    = "TestNetworkProfile"
+    primary = true
+
+    ip_configuration {
+      name      = "TestIPConfiguration"
+      primary   = true
+      subnet_id = azurerm_subnet.test.id
+    }
+  }
+
+  os_disk {
+    storage_account_type = "Standard_LRS"
+    caching              = "ReadWrite"
+  }
+
+  source_image_reference {
+    publisher = "MicrosoftWindowsServer"
+    offer     = "WindowsServer"
+    sku       = "2019-Datacenter"
+    version   = "latest"
+  }
+}
+`, r.instancesTemplate(data), data.RandomInteger)
+}
+
+func (r OrchestratedVirtualMachineScaleSetResource) instancesTemplate(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+locals {
+  first_public_key = "ssh-rsa AAAAB3NzaC1yc2EAAAADAQABAAABAQC+wWK73dCr+jgQOAxNsHAnNNNMEMWOHYEccp6wJm2gotpr9katuF/ZAdou5AaW1C61slRkHRkpRRX9FA9CYBiitZgvCCz+3nWNN7l/Up54Zps/pHWGZLHNJZRYyAB6j5yVLMVHIHriY49d/GZTZVNB8GoJv9Gakwc/fuEZYYl4YDFiGMBP///TzlI4jhiJzjKnEvqPFki5p2ZRJqcbCiF4pJrxUQR/RXqVFQdbRLZgYfJ8xGB878RENq3yQ39d8dVOkq4edbkzwcUmwwwkYVPIoDGsYLaRHnG+To7FvMeyO7xDVQkMKzopTQV8AuKpyvpqu0a9pWOMaiCyDytO7GGN you@me.com"
+}
+
+resource "azurerm_virtual_network" "test" {
+  name                = "acctvn-%d"
+  address_space       = ["10.0.0.0/16"]
+  location            = azurerm_resource_group.test.location
+  resource_group_name = azurerm_resource_group.test.name
+}
+
+resource "azurerm_subnet" "test" {
+  name                 = "acctsub-%d"
+  resource_group_name  = azurerm_resource_group.test.name
+  virtual_network_name = azurerm_virtual_network.test.name
+  address_prefixes     = ["10.0.2.0/24"]
+}
+`, r.template(data), data.RandomInteger, data.RandomInteger)
+}
+
 func (OrchestratedVirtualMachineScaleSetResource) template(data acceptance.TestData) string {
 	return fmt.Sprintf(`
 provider "azurerm" {
@@ -0,0 +1,485 @@
+package compute
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-03-01/compute"
+	"github.com/hashicorp/go-azure-helpers/response"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/compute/parse"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tf/pluginsdk"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tf/validation"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+// the maximum LUN which can be used for a Data Disk, the number of Data Disks which can be attached depends on the
+// size of the Virtual Machine - which the API validates for us
+const virtualMachineDataDiskMaximumLun = 63
+
+func virtualMachineDataDiskSchema() *pluginsdk.Schema {
+	return &pluginsdk.Schema{
+		Type:     pluginsdk.TypeList,
+		Optional: true,
+		MaxItems: virtualMachineDataDiskMaximumLun + 1,
+		Elem: &pluginsdk.Resource{
+			Schema: map[string]*pluginsdk.Schema{
+				"name": {
+					Type:         pluginsdk.TypeString,
+					Required:     true,
+					ValidateFunc: validation.StringIsNotEmpty,
+				},
+
+				"caching": {
+					Type:     pluginsdk.TypeString,
+					Required: true,
+					ValidateFunc: validation.StringInSlice([]string{
+						string(compute.CachingTypesNone),
+						string(compute.CachingTypesReadOnly),
+						string(compute.CachingTypesReadWrite),
+					}, false),
+				},
+
+				"disk_size_gb": {
+					Type:         pluginsdk.TypeInt,
+					Required:     true,
+					ValidateFunc: validation.IntBetween(1, 32767),
+				},
+
+				"storage_account_type": {
+					Type:     pluginsdk.TypeString,
+					Required: true,
+					ValidateFunc: validation.StringInSlice([]string{
+						string(compute.StorageAccountTypesPremiumLRS),
+						string(compute.StorageAccountTypesPremiumZRS),
+						string(compute.StorageAccountTypesStandardLRS),
+						string(compute.StorageAccountTypesStandardSSDLRS),
+						string(compute.StorageAccountTypesStandardSSDZRS),
+						string(compute.StorageAccountTypesUltraSSDLRS),
+					}, false),
+				},
+
+				"delete_on_termination": {
+					Type:     pluginsdk.TypeBool,
+					Optional: true,
+					Default:  true,
+				},
+
+				"lun": {
+					Type:         pluginsdk.TypeInt,
+					Optional:     true,
+					Computed:     true,
+					ValidateFunc: validation.IntBetween(0, virtualMachineDataDiskMaximumLun),
+				},
+
+				"write_accelerator_enabled": {
+					Type:     pluginsdk.TypeBool,
+					Optional: true,
+					Default:  false,
+				},
+
+				"managed_disk_id": {
+					Type:     pluginsdk.TypeString,
+					Computed: true,
+				},
+			},
+		},
+	}
+}
+
+// virtualMachineDataDiskChanges are the changes required to move the Data Disks attached to a Virtual Machine from
+// their current state to the state defined in the `data_disk` block
+type virtualMachineDataDiskChanges struct {
+	// dataDisks are the Data Disks which should be attached once the update has completed - including any Data Disks
+	// which aren't managed by the `data_disk` block (for example those attached using a Data Disk Attachment)
+	dataDisks []compute.DataDisk
+
+	// intermediateDataDisks are the Data Disks which should remain attached whilst any Data Disks which are moving
+	// to another LUN are detached, or nil when no Data Disks are moving
+	intermediateDataDisks *[]compute.DataDisk
+
+	// diskUpdates are the changes to the (already attached) Managed Disks, keyed by ID - which can only be made
+	// whilst the Virtual Machine is deallocated
+	diskUpdates map[string]compute.DiskUpdate
+
+	// disksToDelete are the IDs of the Managed Disks which should be deleted once they've been detached
+	disksToDelete []string
+}
+
+func (c virtualMachineDataDiskChanges) requiresDeallocation() bool {
+	return len(c.diskUpdates) > 0
+}
+
+// expandVirtualMachineDataDisks compares the `data_disk` blocks in the old and new configuration against the Data Disks
+// currently attached to the Virtual Machine (which is nil when creating) - matching them by name
+func expandVirtualMachineDataDisks(oldRaw []interface{}, newRaw []interface{}, existing *[]compute.DataDisk) (*virtualMachineDataDiskChanges, error) {
+	changes := virtualMachineDataDiskChanges{
+		dataDisks:     make([]compute.DataDisk, 0),
+		diskUpdates:   make(map[string]compute.DiskUpdate),
+		disksToDelete: make([]string, 0),
+	}
+
+	previous := make(map[string]map[string]interface{})
+	for _, raw := range oldRaw {
+		v := raw.(map[string]interface{})
+		previous[strings.ToLower(v["name"].(string))] = v
+	}
+
+	configured := make(map[string]struct{})
+	for _, raw := range newRaw {
+		name := raw.(map[string]interface{})["name"].(string)
+		if _, ok := configured[strings.ToLower(name)]; ok {
+			return nil, fmt.Errorf("the name %q is used by more than one `data_disk` block", name)
+		}
+		configured[strings.ToLower(name)] = struct{}{}
+	}
+
+	// the Data Disks which remain attached whilst any Data Disks change LUN
+	retained := make([]compute.DataDisk, 0)
+	attached := make(map[string]compute.DataDisk)
+	usedLuns := make(map[int32]string)
+	if existing != nil {
+		for _, disk := range *existing {
+			if disk.Name == nil {
+				continue
+			}
+
+			key := strings.ToLower(*disk.Name)
+			_, wasManaged := previous[key]
+			_, isManaged := configured[key]
+
+			if !wasManaged && !isManaged {
+				// Data Disks which aren't managed by the `data_disk` block are left as-is
+				changes.dataDisks = append(changes.dataDisks, disk)
+				retained = append(retained, disk)
+				if disk.Lun != nil {
+					usedLuns[*disk.Lun] = *disk.Name
+				}
+				continue
+			}
+
+			if !isManaged {
+				// this Data Disk has been removed from the configuration, so it's detached and then optionally deleted
+				if previous[key]["delete_on_termination"].(bool) && disk.ManagedDisk != nil && disk.ManagedDisk.ID != nil {
+					changes.disksToDelete = append(changes.disksToDelete, *disk.ManagedDisk.ID)
+				}
+				continue
+			}
+
+			if disk.ManagedDisk == nil || disk.ManagedDisk.ID == nil {
+				return nil, fmt.Errorf("the Data Disk %q isn't a Managed Disk and so can't be managed using a `data_disk` block", *disk.Name)
+			}
+			attached[key] = disk
+		}
+	}
+
+	// explicit LUNs (and those of the Data Disks which are already attached) are reserved first, so that any
+	// remaining Data Disks are assigned the lowest LUN which isn't in use
+	luns := make([]*int32, len(newRaw))
+	for i, raw := range newRaw {
+		v := raw.(map[string]interface{})
+		name := v["name"].(string)
+		_, previouslyExisted := previous[strings.ToLower(name)]
+
+		lun := virtualMachineDataDiskExplicitLun(oldRaw, v, i, previouslyExisted)
+		if disk, ok := attached[strings.ToLower(name)]; ok && lun == nil && disk.Lun != nil {
+			lun = utils.Int32(*disk.Lun)
+		}
+		if lun == nil {
+			continue
+		}
+
+		if other, ok := usedLuns[*lun]; ok {
+			return nil, fmt.Errorf("the Data Disk %q can't use LUN %d since it's already used by the Data Disk %q", name, *lun, other)
+		}
+		usedLuns[*lun] = name
+		luns[i] = lun
+	}
+
+	next := int32(0)
+	for i, raw := range newRaw {
+		if luns[i] != nil {
+			continue
+		}
+
+		for {
+			if _, ok := usedLuns[next]; !ok {
+				break
+			}
+			next++
+		}
+		if next > virtualMachineDataDiskMaximumLun {
+			return nil, fmt.Errorf("no LUNs are available for the Data Disk %q", raw.(map[string]interface{})["name"].(string))
+		}
+
+		name := raw.(map[string]interface{})["name"].(string)
+		usedLuns[next] = name
+		luns[i] = utils.Int32(next)
+	}
+
+	moving := false
+	for i, raw := range newRaw {
+		v := raw.(map[string]interface{})
+		name := v["name"].(string)
+		diskSizeGb := int32(v["disk_size_gb"].(int))
+		storageAccountType := v["storage_account_type"].(string)
+
+		disk := compute.DataDisk{
+			Name:         utils.String(name),
+			Lun:          luns[i],
+			Caching:      compute.CachingTypes(v["caching"].(string)),
+			CreateOption: compute.DiskCreateOptionTypesEmpty,
+			DiskSizeGB:   utils.Int32(diskSizeGb),
+			ManagedDisk: &compute.ManagedDiskParameters{
+				StorageAccountType: compute.StorageAccountTypes(storageAccountType),
+			},
+			WriteAcceleratorEnabled: utils.Bool(v["write_accelerator_enabled"].(bool)),
+		}
+
+		if existingDisk, ok := attached[strings.ToLower(name)]; ok {
+			managedDiskId := *existingDisk.ManagedDisk.ID
+			disk.Name = existingDisk.Name
+			disk.CreateOption = compute.DiskCreateOptionTypesAttach
+			disk.ManagedDisk.ID = utils.String(managedDiskId)
+
+			if existingDisk.Lun != nil && *existingDisk.Lun == *disk.Lun {
+				retained = append(retained, existingDisk)
+			} else {
+				moving = true
+			}
+
+			update := compute.DiskUpdate{
+				DiskUpdateProperties: &compute.DiskUpdateProperties{},
+			}
+			hasUpdate := false
+			if existingDisk.DiskSizeGB != nil && *existingDisk.DiskSizeGB != diskSizeGb {
+				if diskSizeGb < *existingDisk.DiskSizeGB {
+					return nil, fmt.Errorf("the Data Disk %q can't be shrunk from %dGB to %dGB", name, *existingDisk.DiskSizeGB, diskSizeGb)
+				}
+
+				update.DiskUpdateProperties.DiskSizeGB = utils.Int32(diskSizeGb)
+				hasUpdate = true
+			}
+			if existingType := existingDisk.ManagedDisk.StorageAccountType; existingType != "" && !strings.EqualFold(string(existingType), storageAccountType) {
+				update.Sku = &compute.DiskSku{
+					Name: compute.DiskStorageAccountTypes(storageAccountType),
+				}
+				hasUpdate = true
+			}
+			if hasUpdate {
+				changes.diskUpdates[managedDiskId] = update
+			}
+		}
+
+		changes.dataDisks = append(changes.dataDisks, disk)
+	}
+
+	if moving {
+		changes.intermediateDataDisks = &retained
+	}
+
+	return &changes, nil
+}
+
+// virtualMachineDataDiskExplicitLun returns the LUN specified for the `data_disk` block at the specified index, or
+// nil when a LUN should be assigned. Since `lun` is Optional & Computed an unset value can't be distinguished from `0`
+// for new Data Disks - and when a Data Disk is removed from the list, the LUN of the Data Disk which was previously
+// at this index is carried over to the Data Disk which is now at this index
+func virtualMachineDataDiskExplicitLun(oldRaw []interface{}, v map[string]interface{}, index int, previouslyExisted bool) *int32 {
+	lun := v["lun"].(int)
+
+	if index < len(oldRaw) {
+		old := oldRaw[index].(map[string]interface{})
+		if !strings.EqualFold(old["name"].(string), v["name"].(string)) && old["lun"].(int) == lun {
+			return nil
+		}
+	}
+
+	if lun == 0 && !previouslyExisted {
+		return nil
+	}
+
+	return utils.Int32(int32(lun))
+}
+
+func (c virtualMachineDataDiskChanges) detachMovingDataDisks(ctx context.Context, client *compute.VirtualMachinesClient, id parse.VirtualMachineId) error {
+	if c.intermediateDataDisks == nil {
+		return nil
+	}
+
+	log.Printf("[DEBUG] Detaching the Data Disks which are changing LUN from %s..", id)
+	update := compute.VirtualMachineUpdate{
+		VirtualMachineProperties: &compute.VirtualMachineProperties{
+			StorageProfile: &compute.StorageProfile{
+				DataDisks: c.intermediateDataDisks,
+			},
+		},
+	}
+	future, err := client.Update(ctx, id.ResourceGroup, id.Name, update)
+	if err != nil {
+		return fmt.Errorf("detaching the Data Disks which are changing LUN from %s: %+v", id, err)
+	}
+	if err := future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		return fmt.Errorf("waiting for the Data Disks which are changing LUN to be detached from %s: %+v", id, err)
+	}
+	log.Printf("[DEBUG] Detached the Data Disks which are changing LUN from %s.", id)
+
+	return nil
+}
+
+func (c virtualMachineDataDiskChanges) updateManagedDisks(ctx context.Context, disksClient *compute.DisksClient) error {
+	for managedDiskId, update := range c.diskUpdates {
+		diskId, err := parse.ManagedDiskID(managedDiskId)
+		if err != nil {
+			return err
+		}
+
+		log.Printf("[DEBUG] Updating Data Disk %s..", *diskId)
+		future, err := disksClient.Update(ctx, diskId.ResourceGroup, diskId.DiskName, update)
+		if err != nil {
+			return fmt.Errorf("updating Data Disk %s: %+v", *diskId, err)
+		}
+		if err := future.WaitForCompletionRef(ctx, disksClient.Client); err != nil {
+			return fmt.Errorf("waiting for update of Data Disk %s: %+v", *diskId, err)
+		}
+		log.Printf("[DEBUG] Updated Data Disk %s.", *diskId)
+	}
+
+	return nil
+}
+
+// virtualMachineDataDisksToDelete returns the IDs of the Managed Disks attached to the Virtual Machine which are
+// managed by a `data_disk` block with `delete_on_termination` enabled
+func virtualMachineDataDisksToDelete(input []interface{}, existing *compute.VirtualMachine) []string {
+	output := make([]string, 0)
+	if existing == nil || existing.VirtualMachineProperties == nil || existing.StorageProfile == nil || existing.StorageProfile.DataDisks == nil {
+		return output
+	}
+
+	for _, disk := range *existing.StorageProfile.DataDisks {
+		if disk.Name == nil || disk.ManagedDisk == nil || disk.ManagedDisk.ID == nil {
+			continue
+		}
+
+		for _, raw := range input {
+			v := raw.(map[string]interface{})
+			if strings.EqualFold(v["name"].(string), *disk.Name) && v["delete_on_termination"].(bool) {
+				output = append(output, *disk.ManagedDisk.ID)
+				break
+			}
+		}
+	}
+
+	return output
+}
+
+func deleteVirtualMachineDataDisks(ctx context.Context, disksClient *compute.DisksClient, managedDiskIds []string) error {
+	for _, managedDiskId := range managedDiskIds {
+		diskId, err := parse.ManagedDiskID(managedDiskId)
+		if err != nil {
+			return err
+		}
+
+		log.Printf("[DEBUG] Deleting Data Disk %s..", *diskId)
+		future, err := disksClient.Delete(ctx, diskId.ResourceGroup, diskId.DiskName)
+		if err != nil {
+			if response.WasNotFound(future.Response()) {
+				continue
+			}
+
+			return fmt.Errorf("deleting Data Disk %s: %+v", *diskId, err)
+		}
+		if err := future.WaitForCompletionRef(ctx, disksClient.Client); err != nil {
+			return fmt.Errorf("waiting for deletion of Data Disk %s: %+v", *diskId, err)
+		}
+		log.Printf("[DEBUG] Deleted Data Disk %s.", *diskId)
+	}
+
+	return nil
+}
+
+// flattenVirtualMachineDataDisks flattens the Data Disks which are managed by the `data_disk` block (in the order in
+// which they're defined), so that Data Disks attached using a Data Disk Attachment are ignored
+func flattenVirtualMachineDataDisks(ctx context.Context, disksClient *compute.DisksClient, input *[]compute.DataDisk, managed []interface{}) ([]interface{}, error) {
+	output := make([]interface{}, 0)
+	if input == nil {
+		return output, nil
+	}
+
+	for _, raw := range managed {
+		v := raw.(map[string]interface{})
+
+		var disk *compute.DataDisk
+		for _, item := range *input {
+			if item.Name != nil && strings.EqualFold(*item.Name, v["name"].(string)) {
+				item := item
+				disk = &item
+				break
+			}
+		}
+		if disk == nil {
+			// the Data Disk has been detached outside of Terraform
+			continue
+		}
+
+		lun := 0
+		if disk.Lun != nil {
+			lun = int(*disk.Lun)
+		}
+
+		diskSizeGb := 0
+		if disk.DiskSizeGB != nil {
+			diskSizeGb = int(*disk.DiskSizeGB)
+		}
+
+		managedDiskId := ""
+		storageAccountType := ""
+		if disk.ManagedDisk != nil {
+			storageAccountType = string(disk.ManagedDisk.StorageAccountType)
+
+			if disk.ManagedDisk.ID != nil {
+				managedDiskId = *disk.ManagedDisk.ID
+
+				// the Storage Account Type & Size aren't always returned for attached disks, so we need to look them up
+				if storageAccountType == "" || diskSizeGb == 0 {
+					id, err := parse.ManagedDiskID(managedDiskId)
+					if err != nil {
+						return nil, err
+					}
+
+					resp, err := disksClient.Get(ctx, id.ResourceGroup, id.DiskName)
+					if err != nil {
+						return nil, fmt.Errorf("retrieving Data Disk %s: %+v", *id, err)
+					}
+
+					if storageAccountType == "" && resp.Sku != nil {
+						storageAccountType = string(resp.Sku.Name)
+					}
+					if diskSizeGb == 0 && resp.DiskProperties != nil && resp.DiskProperties.DiskSizeGB != nil {
+						diskSizeGb = int(*resp.DiskProperties.DiskSizeGB)
+					}
+				}
+			}
+		}
+
+		writeAcceleratorEnabled := false
+		if disk.WriteAcceleratorEnabled != nil {
+			writeAcceleratorEnabled = *disk.WriteAcceleratorEnabled
+		}
+
+		output = append(output, map[string]interface{}{
+			"name":    *disk.Name,
+			"caching": string(disk.Caching),
+			// this isn't a property of the Data Disk, so the value from the configuration is used
+			"delete_on_termination":     v["delete_on_termination"].(bool),
+			"disk_size_gb":              diskSizeGb,
+			"lun":                       lun,
+			"managed_disk_id":           managedDiskId,
+			"storage_account_type":      storageAccountType,
+			"write_accelerator_enabled": writeAcceleratorEnabled,
+		})
+	}
+
+	return output, nil
+}
@@ -0,0 +1,183 @@
+package compute
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-03-01/compute"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+func testVirtualMachineDataDisk(name string, lun int, diskSizeGb int) map[string]interface{} {
+	return map[string]interface{}{
+		"name":                      name,
+		"caching":                   "ReadWrite",
+		"delete_on_termination":     true,
+		"disk_size_gb":              diskSizeGb,
+		"lun":                       lun,
+		"storage_account_type":      "Standard_LRS",
+		"write_accelerator_enabled": false,
+	}
+}
+
+func testVirtualMachineAttachedDataDisk(name string, lun int32, diskSizeGb int32) compute.DataDisk {
+	return compute.DataDisk{
+		Name:         utils.String(name),
+		Lun:          utils.Int32(lun),
+		CreateOption: compute.DiskCreateOptionTypesEmpty,
+		DiskSizeGB:   utils.Int32(diskSizeGb),
+		ManagedDisk: &compute.ManagedDiskParameters{
+			ID:                 utils.String("/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.Compute/disks/" + name),
+			StorageAccountType: compute.StorageAccountTypesStandardLRS,
+		},
+	}
+}
+
+func TestExpandVirtualMachineDataDisks(t *testing.T) {
+	cases := []struct {
+		Name          string
+		Old           []interface{}
+		New           []interface{}
+		Existing      *[]compute.DataDisk
+		ExpectedLuns  map[string]int32
+		ExpectMoving  bool
+		ExpectDeleted []string
+		ExpectUpdates int
+		ExpectError   bool
+	}{
+		{
+			Name: "create with automatic and explicit LUNs",
+			New: []interface{}{
+				testVirtualMachineDataDisk("first", 0, 10),
+				testVirtualMachineDataDisk("second", 1, 10),
+				testVirtualMachineDataDisk("third", 0, 10),
+			},
+			ExpectedLuns: map[string]int32{"first": 0, "second": 1, "third": 2},
+		},
+		{
+			Name: "duplicate names",
+			New: []interface{}{
+				testVirtualMachineDataDisk("first", 0, 10),
+				testVirtualMachineDataDisk("FIRST", 1, 10),
+			},
+			ExpectError: true,
+		},
+		{
+			Name: "duplicate explicit LUNs",
+			New: []interface{}{
+				testVirtualMachineDataDisk("first", 2, 10),
+				testVirtualMachineDataDisk("second", 2, 10),
+			},
+			ExpectError: true,
+		},
+		{
+			Name: "unmanaged data disks are retained",
+			New: []interface{}{
+				testVirtualMachineDataDisk("first", 0, 10),
+			},
+			Existing: &[]compute.DataDisk{
+				testVirtualMachineAttachedDataDisk("attachment", 0, 10),
+			},
+			ExpectedLuns: map[string]int32{"attachment": 0, "first": 1},
+		},
+		{
+			Name: "removing the first data disk keeps the LUN of the second",
+			Old: []interface{}{
+				testVirtualMachineDataDisk("first", 0, 10),
+				testVirtualMachineDataDisk("second", 1, 10),
+			},
+			New: []interface{}{
+				// the LUN of the first data disk is carried over from the state
+				testVirtualMachineDataDisk("second", 0, 10),
+			},
+			Existing: &[]compute.DataDisk{
+				testVirtualMachineAttachedDataDisk("first", 0, 10),
+				testVirtualMachineAttachedDataDisk("second", 1, 10),
+			},
+			ExpectedLuns:  map[string]int32{"second": 1},
+			ExpectDeleted: []string{"first"},
+		},
+		{
+			Name: "changing the LUN detaches the data disk first",
+			Old: []interface{}{
+				testVirtualMachineDataDisk("first", 0, 10),
+			},
+			New: []interface{}{
+				testVirtualMachineDataDisk("first", 5, 10),
+			},
+			Existing: &[]compute.DataDisk{
+				testVirtualMachineAttachedDataDisk("first", 0, 10),
+			},
+			ExpectedLuns: map[string]int32{"first": 5},
+			ExpectMoving: true,
+		},
+		{
+			Name: "resizing a data disk",
+			Old: []interface{}{
+				testVirtualMachineDataDisk("first", 0, 10),
+			},
+			New: []interface{}{
+				testVirtualMachineDataDisk("first", 0, 20),
+			},
+			Existing: &[]compute.DataDisk{
+				testVirtualMachineAttachedDataDisk("first", 0, 10),
+			},
+			ExpectedLuns:  map[string]int32{"first": 0},
+			ExpectUpdates: 1,
+		},
+		{
+			Name: "shrinking a data disk",
+			Old: []interface{}{
+				testVirtualMachineDataDisk("first", 0, 20),
+			},
+			New: []interface{}{
+				testVirtualMachineDataDisk("first", 0, 10),
+			},
+			Existing: &[]compute.DataDisk{
+				testVirtualMachineAttachedDataDisk("first", 0, 20),
+			},
+			ExpectError: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			actual, err := expandVirtualMachineDataDisks(tc.Old, tc.New, tc.Existing)
+			if err != nil {
+				if tc.ExpectError {
+					return
+				}
+
+				t.Fatalf("unexpected error: %+v", err)
+			}
+			if tc.ExpectError {
+				t.Fatalf("expected an error but didn't get one")
+			}
+
+			luns := make(map[string]int32)
+			for _, disk := range actual.dataDisks {
+				luns[*disk.Name] = *disk.Lun
+			}
+			if !reflect.DeepEqual(luns, tc.ExpectedLuns) {
+				t.Fatalf("expected LUNs %+v but got %+v", tc.ExpectedLuns, luns)
+			}
+
+			if moving := actual.intermediateDataDisks != nil; moving != tc.ExpectMoving {
+				t.Fatalf("expected moving to be %t but got %t", tc.ExpectMoving, moving)
+			}
+
+			if len(actual.diskUpdates) != tc.ExpectUpdates {
+				t.Fatalf("expected %d disk updates but got %d", tc.ExpectUpdates, len(actual.diskUpdates))
+			}
+
+			if len(actual.disksToDelete) != len(tc.ExpectDeleted) {
+				t.Fatalf("expected %d disks to be deleted but got %d", len(tc.ExpectDeleted), len(actual.disksToDelete))
+			}
+			for i, name := range tc.ExpectDeleted {
+				if expected := *testVirtualMachineAttachedDataDisk(name, 0, 0).ManagedDisk.ID; actual.disksToDelete[i] != expected {
+					t.Fatalf("expected %q to be deleted but got %q", expected, actual.disksToDelete[i])
+				}
+			}
+		})
+	}
+}
@@ -4,7 +4,7 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2020-12-01/compute"
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-03-01/compute"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/clients"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/compute/parse"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tf/pluginsdk"
@@ -48,7 +48,7 @@ func importVirtualMachine(osType compute.OperatingSystemTypes, resourceType stri
 		}
 
 		hasSshKeys := false
-		if osType == compute.Linux {
+		if osType == compute.OperatingSystemTypesLinux {
 			if linux := vm.VirtualMachineProperties.OsProfile.LinuxConfiguration; linux != nil {
 				if linux.SSH != nil && linux.SSH.PublicKeys != nil {
 					hasSshKeys = len(*linux.SSH.PublicKeys) > 0
@@ -3,7 +3,7 @@ package compute
 import (
 	"strings"
 
-	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2020-12-01/compute"
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-03-01/compute"
 )
 
 // virtualMachineShouldBeStarted determines if the Virtual Machine should be started after
@@ -0,0 +1,517 @@
+package compute
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-03-01/compute"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/tf"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/clients"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/compute/parse"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/compute/validate"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tags"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tf/pluginsdk"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tf/validation"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/timeouts"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+func resourceVirtualMachineRunCommand() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		Create: resourceVirtualMachineRunCommandCreateUpdate,
+		Read:   resourceVirtualMachineRunCommandRead,
+		Update: resourceVirtualMachineRunCommandUpdate,
+		Delete: resourceVirtualMachineRunCommandDelete,
+
+		Importer: pluginsdk.ImporterValidatingResourceId(func(id string) error {
+			_, err := parse.VirtualMachineRunCommandID(id)
+			return err
+		}),
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Create: pluginsdk.DefaultTimeout(90 * time.Minute),
+			Read:   pluginsdk.DefaultTimeout(5 * time.Minute),
+			Update: pluginsdk.DefaultTimeout(90 * time.Minute),
+			Delete: pluginsdk.DefaultTimeout(30 * time.Minute),
+		},
+
+		Schema: map[string]*pluginsdk.Schema{
+			"name": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"virtual_machine_id": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validate.VirtualMachineID,
+			},
+
+			"source": {
+				Type:     pluginsdk.TypeList,
+				Required: true,
+				MaxItems: 1,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"command_id": {
+							Type:         pluginsdk.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.StringIsNotEmpty,
+							ExactlyOneOf: []string{"source.0.command_id", "source.0.script", "source.0.script_uri"},
+						},
+
+						// only a hash of the script is stored in the state, which means that the Run Command
+						// is re-run whenever the content of the script changes
+						"script": {
+							Type:         pluginsdk.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.StringIsNotEmpty,
+							StateFunc:    virtualMachineRunCommandScriptStateFunc,
+							ExactlyOneOf: []string{"source.0.command_id", "source.0.script", "source.0.script_uri"},
+						},
+
+						// this can contain a SAS Token
+						"script_uri": {
+							Type:         pluginsdk.TypeString,
+							Optional:     true,
+							Sensitive:    true,
+							ValidateFunc: validation.IsURLWithHTTPS,
+							ExactlyOneOf: []string{"source.0.command_id", "source.0.script", "source.0.script_uri"},
+						},
+					},
+				},
+			},
+
+			"parameter": virtualMachineRunCommandParameterSchema(false),
+
+			// due to the sensitive nature, these are not returned by the API
+			"protected_parameter": virtualMachineRunCommandParameterSchema(true),
+
+			"run_as_user": {
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			// due to the sensitive nature, this is not returned by the API
+			"run_as_password": {
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				RequiredWith: []string{"run_as_user"},
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"timeout_in_seconds": {
+				Type:         pluginsdk.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+
+			"instance_view": {
+				Type:     pluginsdk.TypeList,
+				Computed: true,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"execution_state": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+
+						"execution_message": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+
+						"exit_code": {
+							Type:     pluginsdk.TypeInt,
+							Computed: true,
+						},
+
+						"output": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+
+						"error": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+
+						"start_time": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+
+						"end_time": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+
+			"tags": tags.Schema(),
+		},
+	}
+}
+
+func virtualMachineRunCommandParameterSchema(sensitive bool) *pluginsdk.Schema {
+	return &pluginsdk.Schema{
+		Type:      pluginsdk.TypeList,
+		Optional:  true,
+		Sensitive: sensitive,
+		Elem: &pluginsdk.Resource{
+			Schema: map[string]*pluginsdk.Schema{
+				"name": {
+					Type:         pluginsdk.TypeString,
+					Required:     true,
+					ValidateFunc: validation.StringIsNotEmpty,
+				},
+
+				"value": {
+					Type:      pluginsdk.TypeString,
+					Required:  true,
+					Sensitive: sensitive,
+				},
+			},
+		},
+	}
+}
+
+func virtualMachineRunCommandScriptStateFunc(v interface{}) string {
+	switch s := v.(type) {
+	case string:
+		hash := sha256.Sum256([]byte(s))
+		return hex.EncodeToString(hash[:])
+	default:
+		return ""
+	}
+}
+
+func resourceVirtualMachineRunCommandCreateUpdate(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Compute.VMRunCommandsClient
+	vmClient := meta.(*clients.Client).Compute.VMClient
+	ctx, cancel := timeouts.ForCreateUpdate(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	virtualMachineId, err := parse.VirtualMachineID(d.Get("virtual_machine_id").(string))
+	if err != nil {
+		return err
+	}
+
+	id := parse.NewVirtualMachineRunCommandID(virtualMachineId.SubscriptionId, virtualMachineId.ResourceGroup, virtualMachineId.Name, d.Get("name").(string))
+
+	if d.IsNewResource() {
+		existing, err := client.GetByVirtualMachine(ctx, id.ResourceGroup, id.VirtualMachineName, id.RunCommandName, "")
+		if err != nil {
+			if !utils.ResponseWasNotFound(existing.Response) {
+				return fmt.Errorf("checking for presence of existing %s: %+v", id, err)
+			}
+		}
+
+		if existing.ID != nil && *existing.ID != "" {
+			return tf.ImportAsExistsError("azurerm_virtual_machine_run_command", id.ID())
+		}
+	}
+
+	virtualMachine, err := vmClient.Get(ctx, virtualMachineId.ResourceGroup, virtualMachineId.Name, "")
+	if err != nil {
+		return fmt.Errorf("retrieving %s: %+v", *virtualMachineId, err)
+	}
+
+	if virtualMachine.Location == nil || *virtualMachine.Location == "" {
+		return fmt.Errorf("retrieving %s: `location` was nil", *virtualMachineId)
+	}
+
+	runCommand := compute.VirtualMachineRunCommand{
+		Location: virtualMachine.Location,
+		VirtualMachineRunCommandProperties: &compute.VirtualMachineRunCommandProperties{
+			Source:              expandVirtualMachineRunCommandSource(d.Get("source").([]interface{})),
+			Parameters:          expandVirtualMachineRunCommandParameters(d.Get("parameter").([]interface{})),
+			ProtectedParameters: expandVirtualMachineRunCommandParameters(d.Get("protected_parameter").([]interface{})),
+			// the Run Command is run synchronously so that the output can be captured
+			AsyncExecution: utils.Bool(false),
+		},
+		Tags: tags.Expand(d.Get("tags").(map[string]interface{})),
+	}
+
+	if v, ok := d.GetOk("run_as_user"); ok {
+		runCommand.VirtualMachineRunCommandProperties.RunAsUser = utils.String(v.(string))
+	}
+
+	if v, ok := d.GetOk("run_as_password"); ok {
+		runCommand.VirtualMachineRunCommandProperties.RunAsPassword = utils.String(v.(string))
+	}
+
+	if v, ok := d.GetOk("timeout_in_seconds"); ok {
+		runCommand.VirtualMachineRunCommandProperties.TimeoutInSeconds = utils.Int32(int32(v.(int)))
+	}
+
+	future, err := client.CreateOrUpdate(ctx, id.ResourceGroup, id.VirtualMachineName, id.RunCommandName, runCommand)
+	if err != nil {
+		return fmt.Errorf("creating/updating %s: %+v", id, err)
+	}
+
+	if err := future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		return fmt.Errorf("waiting for creation/update of %s: %+v", id, err)
+	}
+
+	d.SetId(id.ID())
+
+	return resourceVirtualMachineRunCommandRead(d, meta)
+}
+
+func resourceVirtualMachineRunCommandUpdate(d *pluginsdk.ResourceData, meta interface{}) error {
+	// sending the Run Command re-runs it on the Virtual Machine, which should only happen when what's run changes
+	if d.HasChanges("source", "parameter", "protected_parameter", "run_as_user", "run_as_password", "timeout_in_seconds") {
+		return resourceVirtualMachineRunCommandCreateUpdate(d, meta)
+	}
+
+	client := meta.(*clients.Client).Compute.VMRunCommandsClient
+	ctx, cancel := timeouts.ForUpdate(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := parse.VirtualMachineRunCommandID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	if d.HasChange("tags") {
+		update := compute.VirtualMachineRunCommandUpdate{
+			Tags: tags.Expand(d.Get("tags").(map[string]interface{})),
+		}
+
+		future, err := client.Update(ctx, id.ResourceGroup, id.VirtualMachineName, id.RunCommandName, update)
+		if err != nil {
+			return fmt.Errorf("updating %s: %+v", *id, err)
+		}
+
+		if err := future.WaitForCompletionRef(ctx, client.Client); err != nil {
+			return fmt.Errorf("waiting for update of %s: %+v", *id, err)
+		}
+	}
+
+	return resourceVirtualMachineRunCommandRead(d, meta)
+}
+
+func resourceVirtualMachineRunCommandRead(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Compute.VMRunCommandsClient
+	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := parse.VirtualMachineRunCommandID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.GetByVirtualMachine(ctx, id.ResourceGroup, id.VirtualMachineName, id.RunCommandName, "instanceView")
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			log.Printf("[DEBUG] %s was not found - removing from state!", *id)
+			d.SetId("")
+			return nil
+		}
+
+		return fmt.Errorf("retrieving %s: %+v", *id, err)
+	}
+
+	d.Set("name", id.RunCommandName)
+	d.Set("virtual_machine_id", parse.NewVirtualMachineID(id.SubscriptionId, id.ResourceGroup, id.VirtualMachineName).ID())
+
+	if props := resp.VirtualMachineRunCommandProperties; props != nil {
+		if err := d.Set("source", flattenVirtualMachineRunCommandSource(props.Source, d)); err != nil {
+			return fmt.Errorf("setting `source`: %+v", err)
+		}
+
+		if err := d.Set("parameter", flattenVirtualMachineRunCommandParameters(props.Parameters)); err != nil {
+			return fmt.Errorf("setting `parameter`: %+v", err)
+		}
+
+		d.Set("run_as_user", props.RunAsUser)
+
+		timeoutInSeconds := 0
+		if props.TimeoutInSeconds != nil {
+			timeoutInSeconds = int(*props.TimeoutInSeconds)
+		}
+		d.Set("timeout_in_seconds", timeoutInSeconds)
+
+		if err := d.Set("instance_view", flattenVirtualMachineRunCommandInstanceView(props.InstanceView)); err != nil {
+			return fmt.Errorf("setting `instance_view`: %+v", err)
+		}
+	}
+
+	return tags.FlattenAndSet(d, resp.Tags)
+}
+
+func resourceVirtualMachineRunCommandDelete(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Compute.VMRunCommandsClient
+	ctx, cancel := timeouts.ForDelete(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := parse.VirtualMachineRunCommandID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	future, err := client.Delete(ctx, id.ResourceGroup, id.VirtualMachineName, id.RunCommandName)
+	if err != nil {
+		return fmt.Errorf("deleting %s: %+v", *id, err)
+	}
+
+	if err := future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		return fmt.Errorf("waiting for deletion of %s: %+v", *id, err)
+	}
+
+	return nil
+}
+
+func expandVirtualMachineRunCommandSource(input []interface{}) *compute.VirtualMachineRunCommandScriptSource {
+	if len(input) == 0 || input[0] == nil {
+		return nil
+	}
+
+	raw := input[0].(map[string]interface{})
+	output := compute.VirtualMachineRunCommandScriptSource{}
+
+	if v := raw["command_id"].(string); v != "" {
+		output.CommandID = utils.String(v)
+	}
+
+	if v := raw["script"].(string); v != "" {
+		output.Script = utils.String(v)
+	}
+
+	if v := raw["script_uri"].(string); v != "" {
+		output.ScriptURI = utils.String(v)
+	}
+
+	return &output
+}
+
+func flattenVirtualMachineRunCommandSource(input *compute.VirtualMachineRunCommandScriptSource, d *pluginsdk.ResourceData) []interface{} {
+	if input == nil {
+		return []interface{}{}
+	}
+
+	commandId := ""
+	if input.CommandID != nil {
+		commandId = *input.CommandID
+	}
+
+	script := ""
+	if input.Script != nil {
+		script = virtualMachineRunCommandScriptStateFunc(*input.Script)
+	}
+
+	// the Script URI isn't returned by the API since it can contain a SAS Token, so we look this up from the config
+	scriptUri := ""
+	if input.ScriptURI != nil {
+		scriptUri = *input.ScriptURI
+	} else if v, ok := d.GetOk("source.0.script_uri"); ok {
+		scriptUri = v.(string)
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"command_id": commandId,
+			"script":     script,
+			"script_uri": scriptUri,
+		},
+	}
+}
+
+func expandVirtualMachineRunCommandParameters(input []interface{}) *[]compute.RunCommandInputParameter {
+	output := make([]compute.RunCommandInputParameter, 0)
+
+	for _, v := range input {
+		raw := v.(map[string]interface{})
+
+		output = append(output, compute.RunCommandInputParameter{
+			Name:  utils.String(raw["name"].(string)),
+			Value: utils.String(raw["value"].(string)),
+		})
+	}
+
+	return &output
+}
+
+func flattenVirtualMachineRunCommandParameters(input *[]compute.RunCommandInputParameter) []interface{} {
+	if input == nil {
+		return []interface{}{}
+	}
+
+	output := make([]interface{}, 0)
+	for _, v := range *input {
+		name := ""
+		if v.Name != nil {
+			name = *v.Name
+		}
+
+		value := ""
+		if v.Value != nil {
+			value = *v.Value
+		}
+
+		output = append(output, map[string]interface{}{
+			"name":  name,
+			"value": value,
+		})
+	}
+
+	return output
+}
+
+func flattenVirtualMachineRunCommandInstanceView(input *compute.VirtualMachineRunCommandInstanceView) []interface{} {
+	if input == nil {
+		return []interface{}{}
+	}
+
+	executionMessage := ""
+	if input.ExecutionMessage != nil {
+		executionMessage = *input.ExecutionMessage
+	}
+
+	exitCode := 0
+	if input.ExitCode != nil {
+		exitCode = int(*input.ExitCode)
+	}
+
+	output := ""
+	if input.Output != nil {
+		output = *input.Output
+	}
+
+	errorOutput := ""
+	if input.Error != nil {
+		errorOutput = *input.Error
+	}
+
+	startTime := ""
+	if input.StartTime != nil {
+		startTime = input.StartTime.Format(time.RFC3339)
+	}
+
+	endTime := ""
+	if input.EndTime != nil {
+		endTime = input.EndTime.Format(time.RFC3339)
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"execution_state":   string(input.ExecutionState),
+			"execution_message": executionMessage,
+			"exit_code":         exitCode,
+			"output":            output,
+			"error":             errorOutput,
+			"start_time":        startTime,
+			"end_time":          endTime,
+		},
+	}
+}
@@ -4,7 +4,7 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2020-12-01/compute"
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-03-01/compute"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/clients"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/compute/parse"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tf/pluginsdk"
@@ -17,7 +17,7 @@ func importOrchestratedVirtualMachineScaleSet(ctx context.Context, d *pluginsdk.
 	}
 
 	client := meta.(*clients.Client).Compute.VMScaleSetClient
-	vm, err := client.Get(ctx, id.ResourceGroup, id.Name)
+	vm, err := client.Get(ctx, id.ResourceGroup, id.Name, "")
 	if err != nil {
 		return []*pluginsdk.ResourceData{}, fmt.Errorf("retrieving Virtual Machine Scale Set %q (Resource Group %q): %+v", id.Name, id.ResourceGroup, err)
 	}
@@ -34,8 +34,9 @@ func assertOrchestratedVirtualMachineScaleSet(resp compute.VirtualMachineScaleSe
 		return fmt.Errorf("`properties` is nil")
 	}
 
-	if resp.VirtualMachineScaleSetProperties.VirtualMachineProfile != nil {
-		return fmt.Errorf("the virtual machine scale set is an orchestration virtual machine scale set")
+	// Orchestrated Virtual Machine Scale Sets either have no instance profile at all, or use the Flexible orchestration mode
+	if resp.VirtualMachineScaleSetProperties.VirtualMachineProfile != nil && resp.VirtualMachineScaleSetProperties.OrchestrationMode != compute.OrchestrationModeFlexible {
+		return fmt.Errorf("the virtual machine scale set is not an orchestrated virtual machine scale set")
 	}
 
 	return nil
@@ -49,7 +50,7 @@ func importVirtualMachineScaleSet(osType compute.OperatingSystemTypes, resourceT
 		}
 
 		client := meta.(*clients.Client).Compute.VMScaleSetClient
-		vm, err := client.Get(ctx, id.ResourceGroup, id.Name)
+		vm, err := client.Get(ctx, id.ResourceGroup, id.Name, "")
 		if err != nil {
 			return []*pluginsdk.ResourceData{}, fmt.Errorf("Error retrieving Virtual Machine Scale Set %q (Resource Group %q): %+v", id.Name, id.ResourceGroup, err)
 		}
@@ -69,7 +70,7 @@ func importVirtualMachineScaleSet(osType compute.OperatingSystemTypes, resourceT
 		isCorrectOS := false
 		hasSshKeys := false
 		if profile := vm.VirtualMachineScaleSetProperties.VirtualMachineProfile.OsProfile; profile != nil {
-			if profile.LinuxConfiguration != nil && osType == compute.Linux {
+			if profile.LinuxConfiguration != nil && osType == compute.OperatingSystemTypesLinux {
 				isCorrectOS = true
 
 				if profile.LinuxConfiguration.SSH != nil && profile.LinuxConfiguration.SSH.PublicKeys != nil {
@@ -77,7 +78,7 @@ func importVirtualMachineScaleSet(osType compute.OperatingSystemTypes, resourceT
 				}
 			}
 
-			if profile.WindowsConfiguration != nil && osType == compute.Windows {
+			if profile.WindowsConfiguration != nil && osType == compute.OperatingSystemTypesWindows {
 				isCorrectOS = true
 			}
 		}
@@ -823,7 +823,7 @@ func (VirtualMachineScaleSetResource) hasLoadBalancer(ctx context.Context, clien
 		return err
 	}
 
-	read, err := client.Compute.VMScaleSetClient.Get(ctx, id.ResourceGroup, id.Name)
+	read, err := client.Compute.VMScaleSetClient.Get(ctx, id.ResourceGroup, id.Name, "")
 	if err != nil {
 		return err
 	}
@@ -861,7 +861,7 @@ func (VirtualMachineScaleSetResource) hasApplicationGateway(ctx context.Context,
 		return err
 	}
 
-	read, err := client.Compute.VMScaleSetClient.Get(ctx, id.ResourceGroup, id.Name)
+	read, err := client.Compute.VMScaleSetClient.Get(ctx, id.ResourceGroup, id.Name, "")
 	if err != nil {
 		return err
 	}
@@ -901,7 +901,7 @@ func (t VirtualMachineScaleSetResource) Exists(ctx context.Context, clients *cli
 	resGroup := id.ResourceGroup
 	name := id.Path["virtualMachineScaleSets"]
 
-	resp, err := clients.Compute.VMScaleSetClient.Get(ctx, resGroup, name)
+	resp, err := clients.Compute.VMScaleSetClient.Get(ctx, resGroup, name, "")
 	if err != nil {
 		return nil, fmt.Errorf("retrieving Compute Virtual Machine Scale Set %q", id)
 	}
@@ -7,7 +7,7 @@ import (
 	"strings"
 	"time"
 
-	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2020-12-01/compute"
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-03-01/compute"
 	"github.com/hashicorp/go-azure-helpers/response"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/azure"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/tf"
@@ -38,7 +38,7 @@ func resourceWindowsVirtualMachine() *pluginsdk.Resource {
 		Importer: pluginsdk.ImporterValidatingResourceIdThen(func(id string) error {
 			_, err := parse.VirtualMachineID(id)
 			return err
-		}, importVirtualMachine(compute.Windows, "azurerm_windows_virtual_machine")),
+		}, importVirtualMachine(compute.OperatingSystemTypesWindows, "azurerm_windows_virtual_machine")),
 
 		Timeouts: &pluginsdk.ResourceTimeout{
 			Create: pluginsdk.DefaultTimeout(45 * time.Minute),
@@ -134,6 +134,8 @@ func resourceWindowsVirtualMachine() *pluginsdk.Resource {
 
 			"custom_data": base64.OptionalSchema(true),
 
+			"data_disk": virtualMachineDataDiskSchema(),
+
 			"dedicated_host_id": {
 				Type:         pluginsdk.TypeString,
 				Optional:     true,
@@ -163,7 +165,7 @@ func resourceWindowsVirtualMachine() *pluginsdk.Resource {
 				ForceNew: true,
 				ValidateFunc: validation.StringInSlice([]string{
 					// NOTE: whilst Delete is an option here, it's only applicable for VMSS
-					string(compute.Deallocate),
+					string(compute.VirtualMachineEvictionPolicyTypesDeallocate),
 				}, false),
 			},
 
@@ -218,10 +220,10 @@ func resourceWindowsVirtualMachine() *pluginsdk.Resource {
 				Type:     pluginsdk.TypeString,
 				Optional: true,
 				ForceNew: true,
-				Default:  string(compute.Regular),
+				Default:  string(compute.VirtualMachinePriorityTypesRegular),
 				ValidateFunc: validation.StringInSlice([]string{
-					string(compute.Regular),
-					string(compute.Spot),
+					string(compute.VirtualMachinePriorityTypesRegular),
+					string(compute.VirtualMachinePriorityTypesSpot),
 				}, false),
 			},
 
@@ -391,7 +393,12 @@ func resourceWindowsVirtualMachineCreate(d *pluginsdk.ResourceData, meta interfa
 	networkInterfaceIds := expandVirtualMachineNetworkInterfaceIDs(networkInterfaceIdsRaw)
 
 	osDiskRaw := d.Get("os_disk").([]interface{})
-	osDisk := expandVirtualMachineOSDisk(osDiskRaw, compute.Windows)
+	osDisk := expandVirtualMachineOSDisk(osDiskRaw, compute.OperatingSystemTypesWindows)
+
+	dataDiskChanges, err := expandVirtualMachineDataDisks([]interface{}{}, d.Get("data_disk").([]interface{}), nil)
+	if err != nil {
+		return fmt.Errorf("expanding `data_disk`: %+v", err)
+	}
 
 	secretsRaw := d.Get("secret").([]interface{})
 	secrets := expandWindowsSecrets(secretsRaw)
@@ -435,9 +442,9 @@ func resourceWindowsVirtualMachineCreate(d *pluginsdk.ResourceData, meta interfa
 				ImageReference: sourceImageReference,
 				OsDisk:         osDisk,
 
-				// Data Disks are instead handled via the Association resource - as such we can send an empty value here
-				// but for Updates this'll need to be nil, else any associations will be overwritten
-				DataDisks: &[]compute.DataDisk{},
+				// Data Disks can also be attached using the Association resource - as such Updates only send the
+				// Data Disks when the `data_disk` block has changed, else any associations will be overwritten
+				DataDisks: &dataDiskChanges.dataDisks,
 			},
 
 			// Optional
@@ -486,12 +493,12 @@ func resourceWindowsVirtualMachineCreate(d *pluginsdk.ResourceData, meta interfa
 	}
 
 	if evictionPolicyRaw, ok := d.GetOk("eviction_policy"); ok {
-		if params.Priority != compute.Spot {
+		if params.Priority != compute.VirtualMachinePriorityTypesSpot {
 			return fmt.Errorf("An `eviction_policy` can only be specified when `priority` is set to `Spot`")
 		}
 
 		params.EvictionPolicy = compute.VirtualMachineEvictionPolicyTypes(evictionPolicyRaw.(string))
-	} else if priority == compute.Spot {
+	} else if priority == compute.VirtualMachinePriorityTypesSpot {
 		return fmt.Errorf("An `eviction_policy` must be specified when `priority` is set to `Spot`")
 	}
 
@@ -500,7 +507,7 @@ func resourceWindowsVirtualMachineCreate(d *pluginsdk.ResourceData, meta interfa
 	}
 
 	if v, ok := d.Get("max_bid_price").(float64); ok && v > 0 {
-		if priority != compute.Spot {
+		if priority != compute.VirtualMachinePriorityTypesSpot {
 			return fmt.Errorf("`max_bid_price` can only be configured when `priority` is set to `Spot`")
 		}
 
@@ -692,7 +699,7 @@ func resourceWindowsVirtualMachineRead(d *pluginsdk.ResourceData, meta interface
 	}
 	// Resources created with azurerm_virtual_machine have priority set to ""
 	// We need to treat "" as equal to "Regular" to allow migration azurerm_virtual_machine -> azurerm_linux_virtual_machine
-	priority := string(compute.Regular)
+	priority := string(compute.VirtualMachinePriorityTypesRegular)
 	if props.Priority != "" {
 		priority = string(props.Priority)
 	}
@@ -713,6 +720,14 @@ func resourceWindowsVirtualMachineRead(d *pluginsdk.ResourceData, meta interface
 			return fmt.Errorf("settings `os_disk`: %+v", err)
 		}
 
+		flattenedDataDisks, err := flattenVirtualMachineDataDisks(ctx, disksClient, profile.DataDisks, d.Get("data_disk").([]interface{}))
+		if err != nil {
+			return fmt.Errorf("flattening `data_disk`: %+v", err)
+		}
+		if err := d.Set("data_disk", flattenedDataDisks); err != nil {
+			return fmt.Errorf("setting `data_disk`: %+v", err)
+		}
+
 		var storageImageId string
 		if profile.ImageReference != nil && profile.ImageReference.ID != nil {
 			storageImageId = *profile.ImageReference.ID
@@ -786,7 +801,7 @@ func resourceWindowsVirtualMachineUpdate(d *pluginsdk.ResourceData, meta interfa
 		if storage := props.StorageProfile; storage != nil {
 			if disk := storage.OsDisk; disk != nil {
 				if settings := disk.DiffDiskSettings; settings != nil {
-					hasEphemeralOSDisk = settings.Option == compute.Local
+					hasEphemeralOSDisk = settings.Option == compute.DiffDiskOptionsLocal
 				}
 			}
 		}
@@ -922,12 +937,39 @@ func resourceWindowsVirtualMachineUpdate(d *pluginsdk.ResourceData, meta interfa
 		shouldDeallocate = true
 
 		osDiskRaw := d.Get("os_disk").([]interface{})
-		osDisk := expandVirtualMachineOSDisk(osDiskRaw, compute.Windows)
+		osDisk := expandVirtualMachineOSDisk(osDiskRaw, compute.OperatingSystemTypesWindows)
 		update.VirtualMachineProperties.StorageProfile = &compute.StorageProfile{
 			OsDisk: osDisk,
 		}
 	}
 
+	var dataDiskChanges *virtualMachineDataDiskChanges
+	if d.HasChange("data_disk") {
+		shouldUpdate = true
+
+		var existingDataDisks *[]compute.DataDisk
+		if props := existing.VirtualMachineProperties; props != nil && props.StorageProfile != nil {
+			existingDataDisks = props.StorageProfile.DataDisks
+		}
+
+		oldDataDisks, newDataDisks := d.GetChange("data_disk")
+		dataDiskChanges, err = expandVirtualMachineDataDisks(oldDataDisks.([]interface{}), newDataDisks.([]interface{}), existingDataDisks)
+		if err != nil {
+			return fmt.Errorf("expanding `data_disk`: %+v", err)
+		}
+
+		// Portal: "Disks can be resized or account type changed only when they are unattached or the owner VM is deallocated."
+		if dataDiskChanges.requiresDeallocation() {
+			shouldShutDown = true
+			shouldDeallocate = true
+		}
+
+		if update.VirtualMachineProperties.StorageProfile == nil {
+			update.VirtualMachineProperties.StorageProfile = &compute.StorageProfile{}
+		}
+		update.VirtualMachineProperties.StorageProfile.DataDisks = &dataDiskChanges.dataDisks
+	}
+
 	if d.HasChange("proximity_placement_group_id") {
 		shouldUpdate = true
 
@@ -1151,6 +1193,18 @@ func resourceWindowsVirtualMachineUpdate(d *pluginsdk.ResourceData, meta interfa
 		}
 	}
 
+	if dataDiskChanges != nil {
+		// Data Disks can't be moved to another LUN in-place, so these are detached and then re-attached below
+		if err := dataDiskChanges.detachMovingDataDisks(ctx, client, *id); err != nil {
+			return err
+		}
+
+		disksClient := meta.(*clients.Client).Compute.DisksClient
+		if err := dataDiskChanges.updateManagedDisks(ctx, disksClient); err != nil {
+			return fmt.Errorf("updating Data Disks for Windows Virtual Machine %q (Resource Group %q): %+v", id.Name, id.ResourceGroup, err)
+		}
+	}
+
 	if shouldUpdate {
 		log.Printf("[DEBUG] Updating Windows Virtual Machine %q (Resource Group %q)..", id.Name, id.ResourceGroup)
 		future, err := client.Update(ctx, id.ResourceGroup, id.Name, update)
@@ -1165,6 +1219,14 @@ func resourceWindowsVirtualMachineUpdate(d *pluginsdk.ResourceData, meta interfa
 		log.Printf("[DEBUG] Updated Windows Virtual Machine %q (Resource Group %q).", id.Name, id.ResourceGroup)
 	}
 
+	// the Data Disks which have been removed are only deleted once they've been detached
+	if dataDiskChanges != nil {
+		disksClient := meta.(*clients.Client).Compute.DisksClient
+		if err := deleteVirtualMachineDataDisks(ctx, disksClient, dataDiskChanges.disksToDelete); err != nil {
+			return fmt.Errorf("deleting Data Disks for Windows Virtual Machine %q (Resource Group %q): %+v", id.Name, id.ResourceGroup, err)
+		}
+	}
+
 	// if we've shut it down and it was turned off, let's boot it back up
 	if shouldTurnBackOn && shouldShutDown {
 		log.Printf("[DEBUG] Starting Windows Virtual Machine %q (Resource Group %q)..", id.Name, id.ResourceGroup)
@@ -1319,5 +1381,12 @@ func resourceWindowsVirtualMachineDelete(d *pluginsdk.ResourceData, meta interfa
 		}
 	}
 
+	// unlike the OS Disk, Data Disks aren't deleted along with the Virtual Machine - so those managed by a `data_disk`
+	// block with `delete_on_termination` enabled are deleted once the Virtual Machine has gone
+	dataDisksToDelete := virtualMachineDataDisksToDelete(d.Get("data_disk").([]interface{}), &existing)
+	if err := deleteVirtualMachineDataDisks(ctx, meta.(*clients.Client).Compute.DisksClient, dataDisksToDelete); err != nil {
+		return fmt.Errorf("deleting Data Disks for Windows Virtual Machine %q (Resource Group %q): %+v", id.Name, id.ResourceGroup, err)
+	}
+
 	return nil
 }
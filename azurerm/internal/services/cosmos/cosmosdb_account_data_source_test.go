@@ -4,7 +4,7 @@ import (
 	"fmt"
 	"testing"
 
-	"github.com/Azure/azure-sdk-for-go/services/cosmos-db/mgmt/2021-01-15/documentdb"
+	"github.com/Azure/azure-sdk-for-go/services/cosmos-db/mgmt/2021-06-15/documentdb"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/acceptance"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/acceptance/check"
 )
@@ -20,7 +20,7 @@ func TestAccDataSourceCosmosDBAccount_basic(t *testing.T) {
 		{
 			Config: r.basic(data),
 			Check: acceptance.ComposeAggregateTestCheckFunc(
-				checkAccCosmosDBAccount_basic(data, documentdb.BoundedStaleness, 1),
+				checkAccCosmosDBAccount_basic(data, documentdb.DefaultConsistencyLevelBoundedStaleness, 1),
 			),
 		},
 	})
@@ -34,7 +34,7 @@ func TestAccDataSourceCosmosDBAccount_complete(t *testing.T) {
 		{
 			Config: r.complete(data),
 			Check: acceptance.ComposeAggregateTestCheckFunc(
-				checkAccCosmosDBAccount_basic(data, documentdb.BoundedStaleness, 3),
+				checkAccCosmosDBAccount_basic(data, documentdb.DefaultConsistencyLevelBoundedStaleness, 3),
 				check.That(data.ResourceName).Key("geo_location.0.location").HasValue(data.Locations.Primary),
 				check.That(data.ResourceName).Key("geo_location.1.location").HasValue(data.Locations.Secondary),
 				check.That(data.ResourceName).Key("geo_location.2.location").HasValue(data.Locations.Ternary),
@@ -54,7 +54,7 @@ data "azurerm_cosmosdb_account" "test" {
   name                = azurerm_cosmosdb_account.test.name
   resource_group_name = azurerm_resource_group.test.name
 }
-`, CosmosDBAccountResource{}.basic(data, documentdb.GlobalDocumentDB, documentdb.BoundedStaleness))
+`, CosmosDBAccountResource{}.basic(data, documentdb.DatabaseAccountKindGlobalDocumentDB, documentdb.DefaultConsistencyLevelBoundedStaleness))
 }
 
 func (CosmosDBAccountDataSourceResource) complete(data acceptance.TestData) string {
@@ -65,5 +65,5 @@ data "azurerm_cosmosdb_account" "test" {
   name                = azurerm_cosmosdb_account.test.name
   resource_group_name = azurerm_resource_group.test.name
 }
-`, CosmosDBAccountResource{}.complete(data, documentdb.GlobalDocumentDB, documentdb.BoundedStaleness))
+`, CosmosDBAccountResource{}.complete(data, documentdb.DatabaseAccountKindGlobalDocumentDB, documentdb.DefaultConsistencyLevelBoundedStaleness))
 }
@@ -10,13 +10,15 @@ import (
 	"strings"
 	"time"
 
-	"github.com/Azure/azure-sdk-for-go/services/cosmos-db/mgmt/2021-01-15/documentdb"
+	"github.com/Azure/azure-sdk-for-go/services/cosmos-db/mgmt/2021-06-15/documentdb"
+	"github.com/Azure/go-autorest/autorest/date"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/azure"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/tf"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/clients"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/location"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/cosmos/common"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/cosmos/parse"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/cosmos/validate"
 	keyVaultParse "github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/keyvault/parse"
 	keyVaultSuppress "github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/keyvault/suppress"
 	keyVaultValidate "github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/keyvault/validate"
@@ -38,7 +40,7 @@ func suppressConsistencyPolicyStalenessConfiguration(_, _, _ string, d *pluginsd
 
 	consistencyPolicy := consistencyPolicyList[0].(map[string]interface{})
 
-	return consistencyPolicy["consistency_level"].(string) != string(documentdb.BoundedStaleness)
+	return consistencyPolicy["consistency_level"].(string) != string(documentdb.DefaultConsistencyLevelBoundedStaleness)
 }
 
 func resourceCosmosDbAccount() *pluginsdk.Resource {
@@ -50,6 +52,8 @@ func resourceCosmosDbAccount() *pluginsdk.Resource {
 		// TODO: replace this with an importer which validates the ID during import
 		Importer: pluginsdk.DefaultImporter(),
 
+		CustomizeDiff: pluginsdk.CustomizeDiffShim(resourceCosmosDbAccountCustomizeDiff),
+
 		Timeouts: &pluginsdk.ResourceTimeout{
 			Create: pluginsdk.DefaultTimeout(180 * time.Minute),
 			Read:   pluginsdk.DefaultTimeout(5 * time.Minute),
@@ -78,7 +82,7 @@ func resourceCosmosDbAccount() *pluginsdk.Resource {
 				Required:         true,
 				DiffSuppressFunc: suppress.CaseDifference,
 				ValidateFunc: validation.StringInSlice([]string{
-					string(documentdb.Standard),
+					string(documentdb.DatabaseAccountOfferTypeStandard),
 				}, true),
 			},
 
@@ -86,12 +90,12 @@ func resourceCosmosDbAccount() *pluginsdk.Resource {
 				Type:             pluginsdk.TypeString,
 				Optional:         true,
 				ForceNew:         true,
-				Default:          string(documentdb.GlobalDocumentDB),
+				Default:          string(documentdb.DatabaseAccountKindGlobalDocumentDB),
 				DiffSuppressFunc: suppress.CaseDifference,
 				ValidateFunc: validation.StringInSlice([]string{
-					string(documentdb.GlobalDocumentDB),
-					string(documentdb.MongoDB),
-					string(documentdb.Parse),
+					string(documentdb.DatabaseAccountKindGlobalDocumentDB),
+					string(documentdb.DatabaseAccountKindMongoDB),
+					string(documentdb.DatabaseAccountKindParse),
 				}, true),
 			},
 
@@ -149,11 +153,11 @@ func resourceCosmosDbAccount() *pluginsdk.Resource {
 							Required:         true,
 							DiffSuppressFunc: suppress.CaseDifference,
 							ValidateFunc: validation.StringInSlice([]string{
-								string(documentdb.BoundedStaleness),
-								string(documentdb.ConsistentPrefix),
-								string(documentdb.Eventual),
-								string(documentdb.Session),
-								string(documentdb.Strong),
+								string(documentdb.DefaultConsistencyLevelBoundedStaleness),
+								string(documentdb.DefaultConsistencyLevelConsistentPrefix),
+								string(documentdb.DefaultConsistencyLevelEventual),
+								string(documentdb.DefaultConsistencyLevelSession),
+								string(documentdb.DefaultConsistencyLevelStrong),
 							}, true),
 						},
 
@@ -288,9 +292,9 @@ func resourceCosmosDbAccount() *pluginsdk.Resource {
 				ForceNew: true,
 				Computed: true,
 				ValidateFunc: validation.StringInSlice([]string{
-					string(documentdb.ThreeFullStopTwo),
-					string(documentdb.ThreeFullStopSix),
-					string(documentdb.FourFullStopZero),
+					string(documentdb.ServerVersionThreeFullStopTwo),
+					string(documentdb.ServerVersionThreeFullStopSix),
+					string(documentdb.ServerVersionFourFullStopZero),
 				}, false),
 			},
 
@@ -342,6 +346,68 @@ func resourceCosmosDbAccount() *pluginsdk.Resource {
 				},
 			},
 
+			"create_mode": {
+				Type:     pluginsdk.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					string(documentdb.CreateModeDefault),
+					string(documentdb.CreateModeRestore),
+				}, false),
+			},
+
+			"restore": {
+				Type:     pluginsdk.TypeList,
+				Optional: true,
+				ForceNew: true,
+				MaxItems: 1,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"source_cosmosdb_account_id": {
+							Type:         pluginsdk.TypeString,
+							Required:     true,
+							ForceNew:     true,
+							ValidateFunc: validate.RestorableDatabaseAccountID,
+						},
+
+						"restore_timestamp_in_utc": {
+							Type:             pluginsdk.TypeString,
+							Required:         true,
+							ForceNew:         true,
+							ValidateFunc:     validation.IsRFC3339Time,
+							DiffSuppressFunc: suppress.RFC3339Time,
+						},
+
+						"database": {
+							Type:     pluginsdk.TypeSet,
+							Optional: true,
+							ForceNew: true,
+							Elem: &pluginsdk.Resource{
+								Schema: map[string]*pluginsdk.Schema{
+									"name": {
+										Type:         pluginsdk.TypeString,
+										Required:     true,
+										ForceNew:     true,
+										ValidateFunc: validate.CosmosEntityName,
+									},
+
+									"collection_names": {
+										Type:     pluginsdk.TypeSet,
+										Optional: true,
+										ForceNew: true,
+										Elem: &pluginsdk.Schema{
+											Type:         pluginsdk.TypeString,
+											ValidateFunc: validate.CosmosEntityName,
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+
 			"identity": {
 				Type:     pluginsdk.TypeList,
 				Optional: true,
@@ -461,6 +527,26 @@ func resourceCosmosDbAccount() *pluginsdk.Resource {
 	}
 }
 
+func resourceCosmosDbAccountCustomizeDiff(ctx context.Context, d *pluginsdk.ResourceDiff, _ interface{}) error {
+	createMode := d.Get("create_mode").(string)
+	restore := d.Get("restore").([]interface{})
+
+	if createMode == string(documentdb.CreateModeRestore) {
+		if len(restore) == 0 || restore[0] == nil {
+			return fmt.Errorf("`restore` must be specified when `create_mode` is `%s`", documentdb.CreateModeRestore)
+		}
+
+		backup := d.Get("backup").([]interface{})
+		if len(backup) == 0 || backup[0] == nil || backup[0].(map[string]interface{})["type"].(string) != string(documentdb.TypeContinuous) {
+			return fmt.Errorf("`backup` must be specified with a `type` of `%s` when `create_mode` is `%s`", documentdb.TypeContinuous, documentdb.CreateModeRestore)
+		}
+	} else if len(restore) > 0 && d.NewValueKnown("create_mode") {
+		return fmt.Errorf("`create_mode` must be `%s` when `restore` is specified", documentdb.CreateModeRestore)
+	}
+
+	return nil
+}
+
 func resourceCosmosDbAccountCreate(d *pluginsdk.ResourceData, meta interface{}) error {
 	client := meta.(*clients.Client).Cosmos.DatabaseClient
 	ctx, cancel := timeouts.ForCreate(meta.(*clients.Client).StopContext, d)
@@ -510,9 +596,9 @@ func resourceCosmosDbAccountCreate(d *pluginsdk.ResourceData, meta interface{})
 		return fmt.Errorf("expanding CosmosDB Account %q (Resource Group %q) geo locations: %+v", name, resourceGroup, err)
 	}
 
-	publicNetworkAccess := documentdb.Enabled
+	publicNetworkAccess := documentdb.PublicNetworkAccessEnabled
 	if enabled := d.Get("public_network_access_enabled").(bool); !enabled {
-		publicNetworkAccess = documentdb.Disabled
+		publicNetworkAccess = documentdb.PublicNetworkAccessDisabled
 	}
 
 	networkByPass := documentdb.NetworkACLBypassNone
@@ -567,9 +653,21 @@ func resourceCosmosDbAccountCreate(d *pluginsdk.ResourceData, meta interface{})
 		account.DatabaseAccountCreateUpdateProperties.KeyVaultKeyURI = utils.String(keyVaultKey.ID())
 	}
 
+	if v, ok := d.GetOk("create_mode"); ok {
+		account.DatabaseAccountCreateUpdateProperties.CreateMode = documentdb.CreateMode(v.(string))
+	}
+
+	if v, ok := d.GetOk("restore"); ok {
+		restoreParameters, err := expandCosmosdbAccountRestoreParameters(v.([]interface{}))
+		if err != nil {
+			return fmt.Errorf("expanding `restore`: %+v", err)
+		}
+		account.DatabaseAccountCreateUpdateProperties.RestoreParameters = restoreParameters
+	}
+
 	// additional validation on MaxStalenessPrefix as it varies depending on if the DB is multi region or not
 	consistencyPolicy := account.DatabaseAccountCreateUpdateProperties.ConsistencyPolicy
-	if len(geoLocations) > 1 && consistencyPolicy != nil && consistencyPolicy.DefaultConsistencyLevel == documentdb.BoundedStaleness {
+	if len(geoLocations) > 1 && consistencyPolicy != nil && consistencyPolicy.DefaultConsistencyLevel == documentdb.DefaultConsistencyLevelBoundedStaleness {
 		if msp := consistencyPolicy.MaxStalenessPrefix; msp != nil && *msp < 100000 {
 			return fmt.Errorf("max_staleness_prefix (%d) must be greater then 100000 when more then one geo_location is used", *msp)
 		}
@@ -640,9 +738,9 @@ func resourceCosmosDbAccountUpdate(d *pluginsdk.ResourceData, meta interface{})
 		oldLocationsMap[azure.NormalizeLocation(*location.LocationName)] = location
 	}
 
-	publicNetworkAccess := documentdb.Enabled
+	publicNetworkAccess := documentdb.PublicNetworkAccessEnabled
 	if enabled := d.Get("public_network_access_enabled").(bool); !enabled {
-		publicNetworkAccess = documentdb.Disabled
+		publicNetworkAccess = documentdb.PublicNetworkAccessDisabled
 	}
 
 	networkByPass := documentdb.NetworkACLBypassNone
@@ -788,7 +886,7 @@ func resourceCosmosDbAccountRead(d *pluginsdk.ResourceData, meta interface{}) er
 
 		d.Set("enable_free_tier", props.EnableFreeTier)
 		d.Set("analytical_storage_enabled", props.EnableAnalyticalStorage)
-		d.Set("public_network_access_enabled", props.PublicNetworkAccess == documentdb.Enabled)
+		d.Set("public_network_access_enabled", props.PublicNetworkAccess == documentdb.PublicNetworkAccessEnabled)
 
 		if v := resp.IsVirtualNetworkFilterEnabled; v != nil {
 			d.Set("is_virtual_network_filter_enabled", props.IsVirtualNetworkFilterEnabled)
@@ -838,6 +936,12 @@ func resourceCosmosDbAccountRead(d *pluginsdk.ResourceData, meta interface{}) er
 			return fmt.Errorf("setting `backup`: %+v", err)
 		}
 
+		d.Set("create_mode", string(props.CreateMode))
+
+		if err = d.Set("restore", flattenCosmosdbAccountRestoreParameters(props.RestoreParameters)); err != nil {
+			return fmt.Errorf("setting `restore`: %+v", err)
+		}
+
 		d.Set("cors_rule", common.FlattenCosmosCorsRule(props.Cors))
 	}
 
@@ -1322,6 +1426,70 @@ func flattenCosmosdbAccountBackup(input documentdb.BasicBackupPolicy) ([]interfa
 	}
 }
 
+func expandCosmosdbAccountRestoreParameters(input []interface{}) (*documentdb.RestoreParameters, error) {
+	if len(input) == 0 || input[0] == nil {
+		return nil, nil
+	}
+	v := input[0].(map[string]interface{})
+
+	restoreTimestamp, err := time.Parse(time.RFC3339, v["restore_timestamp_in_utc"].(string))
+	if err != nil {
+		return nil, fmt.Errorf("parsing `restore_timestamp_in_utc`: %+v", err)
+	}
+
+	databases := make([]documentdb.DatabaseRestoreResource, 0)
+	for _, item := range v["database"].(*pluginsdk.Set).List() {
+		database := item.(map[string]interface{})
+		databases = append(databases, documentdb.DatabaseRestoreResource{
+			DatabaseName:    utils.String(database["name"].(string)),
+			CollectionNames: utils.ExpandStringSlice(database["collection_names"].(*pluginsdk.Set).List()),
+		})
+	}
+
+	return &documentdb.RestoreParameters{
+		RestoreMode:           documentdb.RestoreModePointInTime,
+		RestoreSource:         utils.String(v["source_cosmosdb_account_id"].(string)),
+		RestoreTimestampInUtc: &date.Time{Time: restoreTimestamp},
+		DatabasesToRestore:    &databases,
+	}, nil
+}
+
+func flattenCosmosdbAccountRestoreParameters(input *documentdb.RestoreParameters) []interface{} {
+	// the restore parameters are only returned with a source for Database Accounts which were restored
+	if input == nil || input.RestoreSource == nil || *input.RestoreSource == "" {
+		return []interface{}{}
+	}
+	restoreSource := *input.RestoreSource
+
+	var restoreTimestamp string
+	if input.RestoreTimestampInUtc != nil {
+		restoreTimestamp = input.RestoreTimestampInUtc.Format(time.RFC3339)
+	}
+
+	databases := make([]interface{}, 0)
+	if input.DatabasesToRestore != nil {
+		for _, item := range *input.DatabasesToRestore {
+			var databaseName string
+			if item.DatabaseName != nil {
+				databaseName = *item.DatabaseName
+			}
+
+			databases = append(databases, map[string]interface{}{
+				"name":             databaseName,
+				"collection_names": utils.FlattenStringSlice(item.CollectionNames),
+			})
+		}
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"source_cosmosdb_account_id": restoreSource,
+			"restore_timestamp_in_utc":   restoreTimestamp,
+			"database":                   databases,
+		},
+	}
+}
+
 func expandCosmosdbAccountIdentity(vs []interface{}) *documentdb.ManagedServiceIdentity {
 	if len(vs) == 0 || vs[0] == nil {
 		return &documentdb.ManagedServiceIdentity{
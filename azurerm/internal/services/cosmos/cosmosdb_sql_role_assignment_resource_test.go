@@ -0,0 +1,134 @@
+package cosmos_test
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/acceptance"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/acceptance/check"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/clients"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/cosmos/parse"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tf/pluginsdk"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+type CosmosDbSQLRoleAssignmentResource struct{}
+
+func TestAccCosmosDbSQLRoleAssignment_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_cosmosdb_sql_role_assignment", "test")
+	r := CosmosDbSQLRoleAssignmentResource{}
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccCosmosDbSQLRoleAssignment_requiresImport(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_cosmosdb_sql_role_assignment", "test")
+	r := CosmosDbSQLRoleAssignmentResource{}
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.RequiresImportErrorStep(r.requiresImport),
+	})
+}
+
+func TestAccCosmosDbSQLRoleAssignment_containerScope(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_cosmosdb_sql_role_assignment", "test")
+	r := CosmosDbSQLRoleAssignmentResource{}
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.scope(data, `"${azurerm_cosmosdb_account.test.id}/dbs/${azurerm_cosmosdb_sql_database.test.name}/colls/${azurerm_cosmosdb_sql_container.test.name}"`),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccCosmosDbSQLRoleAssignment_resourceManagerScope(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_cosmosdb_sql_role_assignment", "test")
+	r := CosmosDbSQLRoleAssignmentResource{}
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config:      r.scope(data, "azurerm_cosmosdb_sql_container.test.id"),
+			ExpectError: regexp.MustCompile("a scope must use the data plane format"),
+		},
+	})
+}
+
+func (r CosmosDbSQLRoleAssignmentResource) Exists(ctx context.Context, client *clients.Client, state *pluginsdk.InstanceState) (*bool, error) {
+	id, err := parse.SqlRoleAssignmentID(state.ID)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Cosmos.SqlResourceClient.GetSQLRoleAssignment(ctx, id.Name, id.ResourceGroup, id.DatabaseAccountName)
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			return utils.Bool(false), nil
+		}
+		return nil, fmt.Errorf("retrieving %s: %+v", *id, err)
+	}
+	return utils.Bool(true), nil
+}
+
+func (r CosmosDbSQLRoleAssignmentResource) template(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+data "azurerm_client_config" "current" {}
+
+resource "azurerm_cosmosdb_sql_container" "test" {
+  name                = "acctest-%d"
+  resource_group_name = azurerm_cosmosdb_account.test.resource_group_name
+  account_name        = azurerm_cosmosdb_account.test.name
+  database_name       = azurerm_cosmosdb_sql_database.test.name
+  partition_key_path  = "/definition/id"
+}
+`, CosmosDbSQLRoleDefinitionResource{}.basic(data), data.RandomInteger)
+}
+
+func (r CosmosDbSQLRoleAssignmentResource) basic(data acceptance.TestData) string {
+	return r.scope(data, "azurerm_cosmosdb_account.test.id")
+}
+
+func (r CosmosDbSQLRoleAssignmentResource) scope(data acceptance.TestData, scope string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_cosmosdb_sql_role_assignment" "test" {
+  resource_group_name = azurerm_resource_group.test.name
+  account_name        = azurerm_cosmosdb_account.test.name
+  role_definition_id  = azurerm_cosmosdb_sql_role_definition.test.id
+  principal_id        = data.azurerm_client_config.current.object_id
+  scope               = %s
+}
+`, r.template(data), scope)
+}
+
+func (r CosmosDbSQLRoleAssignmentResource) requiresImport(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_cosmosdb_sql_role_assignment" "import" {
+  name                = azurerm_cosmosdb_sql_role_assignment.test.name
+  resource_group_name = azurerm_cosmosdb_sql_role_assignment.test.resource_group_name
+  account_name        = azurerm_cosmosdb_sql_role_assignment.test.account_name
+  role_definition_id  = azurerm_cosmosdb_sql_role_assignment.test.role_definition_id
+  principal_id        = azurerm_cosmosdb_sql_role_assignment.test.principal_id
+  scope               = azurerm_cosmosdb_sql_role_assignment.test.scope
+}
+`, r.basic(data))
+}
@@ -0,0 +1,303 @@
+package cosmos
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/cosmos-db/mgmt/2021-06-15/documentdb"
+	"github.com/hashicorp/go-uuid"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/azure"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/tf"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/clients"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/cosmos/parse"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/cosmos/validate"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tf/pluginsdk"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tf/validation"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/timeouts"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+func resourceCosmosDbSQLRoleDefinition() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		Create: resourceCosmosDbSQLRoleDefinitionCreate,
+		Read:   resourceCosmosDbSQLRoleDefinitionRead,
+		Update: resourceCosmosDbSQLRoleDefinitionUpdate,
+		Delete: resourceCosmosDbSQLRoleDefinitionDelete,
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Create: pluginsdk.DefaultTimeout(30 * time.Minute),
+			Read:   pluginsdk.DefaultTimeout(5 * time.Minute),
+			Update: pluginsdk.DefaultTimeout(30 * time.Minute),
+			Delete: pluginsdk.DefaultTimeout(30 * time.Minute),
+		},
+
+		Importer: pluginsdk.ImporterValidatingResourceId(func(id string) error {
+			_, err := parse.SqlRoleDefinitionID(id)
+			return err
+		}),
+
+		Schema: map[string]*pluginsdk.Schema{
+			"role_definition_id": {
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IsUUID,
+			},
+
+			"resource_group_name": azure.SchemaResourceGroupName(),
+
+			"account_name": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validate.CosmosAccountName,
+			},
+
+			"name": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"assignable_scopes": {
+				Type:     pluginsdk.TypeSet,
+				Required: true,
+				Elem: &pluginsdk.Schema{
+					Type:         pluginsdk.TypeString,
+					ValidateFunc: validate.SqlRoleScope,
+				},
+			},
+
+			"permissions": {
+				Type:     pluginsdk.TypeSet,
+				Required: true,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"data_actions": {
+							Type:     pluginsdk.TypeSet,
+							Required: true,
+							Elem: &pluginsdk.Schema{
+								Type:         pluginsdk.TypeString,
+								ValidateFunc: validation.StringIsNotEmpty,
+							},
+						},
+					},
+				},
+			},
+
+			"type": {
+				Type:     pluginsdk.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Default:  string(documentdb.RoleDefinitionTypeCustomRole),
+				ValidateFunc: validation.StringInSlice([]string{
+					string(documentdb.RoleDefinitionTypeBuiltInRole),
+					string(documentdb.RoleDefinitionTypeCustomRole),
+				}, false),
+			},
+		},
+	}
+}
+
+func resourceCosmosDbSQLRoleDefinitionCreate(d *pluginsdk.ResourceData, meta interface{}) error {
+	subscriptionId := meta.(*clients.Client).Account.SubscriptionId
+	client := meta.(*clients.Client).Cosmos.SqlResourceClient
+	ctx, cancel := timeouts.ForCreate(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	roleDefinitionId := d.Get("role_definition_id").(string)
+	if roleDefinitionId == "" {
+		v, err := uuid.GenerateUUID()
+		if err != nil {
+			return fmt.Errorf("generating UUID for Cosmos DB SQL Role Definition: %+v", err)
+		}
+		roleDefinitionId = v
+	}
+
+	id := parse.NewSqlRoleDefinitionID(subscriptionId, d.Get("resource_group_name").(string), d.Get("account_name").(string), roleDefinitionId)
+
+	existing, err := client.GetSQLRoleDefinition(ctx, id.Name, id.ResourceGroup, id.DatabaseAccountName)
+	if err != nil {
+		if !utils.ResponseWasNotFound(existing.Response) {
+			return fmt.Errorf("checking for existing %s: %+v", id, err)
+		}
+	}
+	if !utils.ResponseWasNotFound(existing.Response) {
+		return tf.ImportAsExistsError("azurerm_cosmosdb_sql_role_definition", id.ID())
+	}
+
+	accountId := parse.NewDatabaseAccountID(id.SubscriptionId, id.ResourceGroup, id.DatabaseAccountName)
+	assignableScopes := utils.ExpandStringSlice(d.Get("assignable_scopes").(*pluginsdk.Set).List())
+	if err := validateCosmosDbSQLRoleScopes(accountId, "assignable_scopes", *assignableScopes...); err != nil {
+		return err
+	}
+
+	parameters := documentdb.SQLRoleDefinitionCreateUpdateParameters{
+		SQLRoleDefinitionResource: &documentdb.SQLRoleDefinitionResource{
+			RoleName:         utils.String(d.Get("name").(string)),
+			Type:             documentdb.RoleDefinitionType(d.Get("type").(string)),
+			AssignableScopes: assignableScopes,
+			Permissions:      expandCosmosDbSQLRoleDefinitionPermissions(d.Get("permissions").(*pluginsdk.Set).List()),
+		},
+	}
+
+	future, err := client.CreateUpdateSQLRoleDefinition(ctx, id.Name, id.ResourceGroup, id.DatabaseAccountName, parameters)
+	if err != nil {
+		return fmt.Errorf("creating %s: %+v", id, err)
+	}
+
+	if err := future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		return fmt.Errorf("waiting for creation of %s: %+v", id, err)
+	}
+
+	d.SetId(id.ID())
+	return resourceCosmosDbSQLRoleDefinitionRead(d, meta)
+}
+
+func resourceCosmosDbSQLRoleDefinitionRead(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Cosmos.SqlResourceClient
+	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := parse.SqlRoleDefinitionID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.GetSQLRoleDefinition(ctx, id.Name, id.ResourceGroup, id.DatabaseAccountName)
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			log.Printf("[INFO] %s does not exist - removing from state", *id)
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("retrieving %s: %+v", *id, err)
+	}
+
+	d.Set("role_definition_id", id.Name)
+	d.Set("resource_group_name", id.ResourceGroup)
+	d.Set("account_name", id.DatabaseAccountName)
+
+	if props := resp.SQLRoleDefinitionResource; props != nil {
+		d.Set("name", props.RoleName)
+		d.Set("type", string(props.Type))
+
+		if err := d.Set("assignable_scopes", utils.FlattenStringSlice(props.AssignableScopes)); err != nil {
+			return fmt.Errorf("setting `assignable_scopes`: %+v", err)
+		}
+
+		if err := d.Set("permissions", flattenCosmosDbSQLRoleDefinitionPermissions(props.Permissions)); err != nil {
+			return fmt.Errorf("setting `permissions`: %+v", err)
+		}
+	}
+
+	return nil
+}
+
+func resourceCosmosDbSQLRoleDefinitionUpdate(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Cosmos.SqlResourceClient
+	ctx, cancel := timeouts.ForUpdate(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := parse.SqlRoleDefinitionID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	accountId := parse.NewDatabaseAccountID(id.SubscriptionId, id.ResourceGroup, id.DatabaseAccountName)
+	assignableScopes := utils.ExpandStringSlice(d.Get("assignable_scopes").(*pluginsdk.Set).List())
+	if err := validateCosmosDbSQLRoleScopes(accountId, "assignable_scopes", *assignableScopes...); err != nil {
+		return err
+	}
+
+	parameters := documentdb.SQLRoleDefinitionCreateUpdateParameters{
+		SQLRoleDefinitionResource: &documentdb.SQLRoleDefinitionResource{
+			RoleName:         utils.String(d.Get("name").(string)),
+			Type:             documentdb.RoleDefinitionType(d.Get("type").(string)),
+			AssignableScopes: assignableScopes,
+			Permissions:      expandCosmosDbSQLRoleDefinitionPermissions(d.Get("permissions").(*pluginsdk.Set).List()),
+		},
+	}
+
+	future, err := client.CreateUpdateSQLRoleDefinition(ctx, id.Name, id.ResourceGroup, id.DatabaseAccountName, parameters)
+	if err != nil {
+		return fmt.Errorf("updating %s: %+v", *id, err)
+	}
+
+	if err := future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		return fmt.Errorf("waiting for update of %s: %+v", *id, err)
+	}
+
+	return resourceCosmosDbSQLRoleDefinitionRead(d, meta)
+}
+
+func resourceCosmosDbSQLRoleDefinitionDelete(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Cosmos.SqlResourceClient
+	ctx, cancel := timeouts.ForDelete(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := parse.SqlRoleDefinitionID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	future, err := client.DeleteSQLRoleDefinition(ctx, id.Name, id.ResourceGroup, id.DatabaseAccountName)
+	if err != nil {
+		return fmt.Errorf("deleting %s: %+v", *id, err)
+	}
+
+	if err := future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		return fmt.Errorf("waiting for deletion of %s: %+v", *id, err)
+	}
+
+	return nil
+}
+
+// validateCosmosDbSQLRoleScopes validates that each of the data plane scopes is within the Database Account,
+// since Role Definitions and Role Assignments can't refer to the data of another Database Account.
+func validateCosmosDbSQLRoleScopes(accountId parse.DatabaseAccountId, key string, scopes ...string) error {
+	for _, v := range scopes {
+		scope, err := parse.SqlRoleScopeID(v)
+		if err != nil {
+			return fmt.Errorf("parsing `%s` %q: %+v", key, v, err)
+		}
+
+		if !strings.EqualFold(scope.DatabaseAccountId.ID(), accountId.ID()) {
+			return fmt.Errorf("`%s` %q must be within the Cosmos DB Account %q", key, v, accountId.ID())
+		}
+	}
+
+	return nil
+}
+
+func expandCosmosDbSQLRoleDefinitionPermissions(input []interface{}) *[]documentdb.Permission {
+	results := make([]documentdb.Permission, 0)
+
+	for _, item := range input {
+		v := item.(map[string]interface{})
+
+		results = append(results, documentdb.Permission{
+			DataActions: utils.ExpandStringSlice(v["data_actions"].(*pluginsdk.Set).List()),
+		})
+	}
+
+	return &results
+}
+
+func flattenCosmosDbSQLRoleDefinitionPermissions(input *[]documentdb.Permission) []interface{} {
+	results := make([]interface{}, 0)
+	if input == nil {
+		return results
+	}
+
+	for _, item := range *input {
+		results = append(results, map[string]interface{}{
+			"data_actions": utils.FlattenStringSlice(item.DataActions),
+		})
+	}
+
+	return results
+}
@@ -0,0 +1,67 @@
+package parse
+
+// NOTE: this file is generated via 'go:generate' - manual changes will be overwritten
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/azure"
+)
+
+type RestorableDatabaseAccountId struct {
+	SubscriptionId string
+	LocationName   string
+	Name           string
+}
+
+func NewRestorableDatabaseAccountID(subscriptionId, locationName, name string) RestorableDatabaseAccountId {
+	return RestorableDatabaseAccountId{
+		SubscriptionId: subscriptionId,
+		LocationName:   locationName,
+		Name:           name,
+	}
+}
+
+func (id RestorableDatabaseAccountId) String() string {
+	segments := []string{
+		fmt.Sprintf("Name %q", id.Name),
+		fmt.Sprintf("Location Name %q", id.LocationName),
+	}
+	segmentsStr := strings.Join(segments, " / ")
+	return fmt.Sprintf("%s: (%s)", "Restorable Database Account", segmentsStr)
+}
+
+func (id RestorableDatabaseAccountId) ID() string {
+	fmtString := "/subscriptions/%s/providers/Microsoft.DocumentDB/locations/%s/restorableDatabaseAccounts/%s"
+	return fmt.Sprintf(fmtString, id.SubscriptionId, id.LocationName, id.Name)
+}
+
+// RestorableDatabaseAccountID parses a RestorableDatabaseAccount ID into an RestorableDatabaseAccountId struct
+func RestorableDatabaseAccountID(input string) (*RestorableDatabaseAccountId, error) {
+	id, err := azure.ParseAzureResourceID(input)
+	if err != nil {
+		return nil, err
+	}
+
+	resourceId := RestorableDatabaseAccountId{
+		SubscriptionId: id.SubscriptionID,
+	}
+
+	if resourceId.SubscriptionId == "" {
+		return nil, fmt.Errorf("ID was missing the 'subscriptions' element")
+	}
+
+	if resourceId.LocationName, err = id.PopSegment("locations"); err != nil {
+		return nil, err
+	}
+	if resourceId.Name, err = id.PopSegment("restorableDatabaseAccounts"); err != nil {
+		return nil, err
+	}
+
+	if err := id.ValidateNoEmptySegments(input); err != nil {
+		return nil, err
+	}
+
+	return &resourceId, nil
+}
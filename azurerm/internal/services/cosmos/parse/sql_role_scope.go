@@ -0,0 +1,71 @@
+package parse
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SqlRoleScopeId is the data plane scope of a Cosmos DB SQL Role Definition/Assignment, which is either
+// a Database Account, a Database within it (`{accountId}/dbs/{databaseName}`) or a Container within
+// a Database (`{accountId}/dbs/{databaseName}/colls/{containerName}`).
+type SqlRoleScopeId struct {
+	DatabaseAccountId
+	DatabaseName  string
+	ContainerName string
+}
+
+func (id SqlRoleScopeId) ID() string {
+	scope := id.DatabaseAccountId.ID()
+	if id.DatabaseName != "" {
+		scope += fmt.Sprintf("/dbs/%s", id.DatabaseName)
+	}
+	if id.ContainerName != "" {
+		scope += fmt.Sprintf("/colls/%s", id.ContainerName)
+	}
+	return scope
+}
+
+// SqlRoleScopeID parses a Cosmos DB SQL Role Scope into an SqlRoleScopeId struct
+func SqlRoleScopeID(input string) (*SqlRoleScopeId, error) {
+	accountId := input
+	var segments []string
+	if i := strings.Index(strings.ToLower(input), "/dbs/"); i != -1 {
+		accountId = input[:i]
+		segments = strings.Split(input[i+len("/dbs/"):], "/")
+	}
+
+	account, err := DatabaseAccountID(accountId)
+	if err != nil {
+		if strings.Contains(strings.ToLower(input), "/sqldatabases/") {
+			return nil, fmt.Errorf("a scope must use the data plane format `{accountId}/dbs/{databaseName}[/colls/{containerName}]` rather than the ID of the resource: %+v", err)
+		}
+		return nil, err
+	}
+
+	scope := SqlRoleScopeId{
+		DatabaseAccountId: *account,
+	}
+
+	switch len(segments) {
+	case 0:
+	case 1:
+		scope.DatabaseName = segments[0]
+	case 3:
+		if !strings.EqualFold(segments[1], "colls") {
+			return nil, fmt.Errorf("expected the segment %q to be `colls` in %q", segments[1], input)
+		}
+		scope.DatabaseName = segments[0]
+		scope.ContainerName = segments[2]
+	default:
+		return nil, fmt.Errorf("expected a scope in the format `{accountId}/dbs/{databaseName}[/colls/{containerName}]` but got %q", input)
+	}
+
+	if len(segments) > 0 && scope.DatabaseName == "" {
+		return nil, fmt.Errorf("the database name was empty in %q", input)
+	}
+	if len(segments) == 3 && scope.ContainerName == "" {
+		return nil, fmt.Errorf("the container name was empty in %q", input)
+	}
+
+	return &scope, nil
+}
@@ -0,0 +1,97 @@
+package parse
+
+import (
+	"testing"
+)
+
+func TestSqlRoleScopeID(t *testing.T) {
+	accountId := "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/resGroup1/providers/Microsoft.DocumentDB/databaseAccounts/acc1"
+	testData := []struct {
+		Input    string
+		Error    bool
+		Expected *SqlRoleScopeId
+	}{
+		{
+			// empty
+			Input: "",
+			Error: true,
+		},
+		{
+			// database account
+			Input: accountId,
+			Expected: &SqlRoleScopeId{
+				DatabaseAccountId: NewDatabaseAccountID("12345678-1234-9876-4563-123456789012", "resGroup1", "acc1"),
+			},
+		},
+		{
+			// database
+			Input: accountId + "/dbs/db1",
+			Expected: &SqlRoleScopeId{
+				DatabaseAccountId: NewDatabaseAccountID("12345678-1234-9876-4563-123456789012", "resGroup1", "acc1"),
+				DatabaseName:      "db1",
+			},
+		},
+		{
+			// container
+			Input: accountId + "/dbs/db1/colls/coll1",
+			Expected: &SqlRoleScopeId{
+				DatabaseAccountId: NewDatabaseAccountID("12345678-1234-9876-4563-123456789012", "resGroup1", "acc1"),
+				DatabaseName:      "db1",
+				ContainerName:     "coll1",
+			},
+		},
+		{
+			// missing database name
+			Input: accountId + "/dbs/",
+			Error: true,
+		},
+		{
+			// missing container name
+			Input: accountId + "/dbs/db1/colls/",
+			Error: true,
+		},
+		{
+			// unknown segment
+			Input: accountId + "/dbs/db1/containers/coll1",
+			Error: true,
+		},
+		{
+			// too many segments
+			Input: accountId + "/dbs/db1/colls/coll1/docs/doc1",
+			Error: true,
+		},
+		{
+			// resource manager ID of a database
+			Input: accountId + "/sqlDatabases/db1",
+			Error: true,
+		},
+		{
+			// resource manager ID of a container
+			Input: accountId + "/sqlDatabases/db1/containers/coll1",
+			Error: true,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Input)
+
+		actual, err := SqlRoleScopeID(v.Input)
+		if err != nil {
+			if v.Error {
+				continue
+			}
+
+			t.Fatalf("Expect a value but got an error: %s", err)
+		}
+		if v.Error {
+			t.Fatal("Expect an error but didn't get one")
+		}
+
+		if *actual != *v.Expected {
+			t.Fatalf("Expected %+v but got %+v", *v.Expected, *actual)
+		}
+		if actual.ID() != v.Input {
+			t.Fatalf("Expected the ID to be %q but got %q", v.Input, actual.ID())
+		}
+	}
+}
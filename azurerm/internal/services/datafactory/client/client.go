@@ -6,6 +6,7 @@ import (
 )
 
 type Client struct {
+	DataFlowsClient              *datafactory.DataFlowsClient
 	DatasetClient                *datafactory.DatasetsClient
 	FactoriesClient              *datafactory.FactoriesClient
 	IntegrationRuntimesClient    *datafactory.IntegrationRuntimesClient
@@ -16,6 +17,9 @@ type Client struct {
 }
 
 func NewClient(o *common.ClientOptions) *Client {
+	DataFlowsClient := datafactory.NewDataFlowsClientWithBaseURI(o.ResourceManagerEndpoint, o.SubscriptionId)
+	o.ConfigureClient(&DataFlowsClient.Client, o.ResourceManagerAuthorizer)
+
 	DatasetClient := datafactory.NewDatasetsClientWithBaseURI(o.ResourceManagerEndpoint, o.SubscriptionId)
 	o.ConfigureClient(&DatasetClient.Client, o.ResourceManagerAuthorizer)
 
@@ -38,6 +42,7 @@ func NewClient(o *common.ClientOptions) *Client {
 	o.ConfigureClient(&TriggersClient.Client, o.ResourceManagerAuthorizer)
 
 	return &Client{
+		DataFlowsClient:              &DataFlowsClient,
 		DatasetClient:                &DatasetClient,
 		FactoriesClient:              &FactoriesClient,
 		IntegrationRuntimesClient:    &IntegrationRuntimesClient,
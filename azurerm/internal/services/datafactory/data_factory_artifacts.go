@@ -0,0 +1,436 @@
+package datafactory
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/services/datafactory/mgmt/2018-06-01/datafactory"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/datafactory/client"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/datafactory/parse"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+// the folders used by the Git integration of the Data Factory Studio, in the order in which the
+// artifacts within them are deployed - artifacts can only reference artifacts in an earlier folder
+// or in the same folder
+var dataFactoryArtifactTypes = []string{
+	"linkedService",
+	"dataset",
+	"dataflow",
+	"pipeline",
+	"trigger",
+}
+
+// the types of the references between artifacts, mapped to the folder of the referenced artifact
+var dataFactoryArtifactReferenceTypes = map[string]string{
+	"LinkedServiceReference": "linkedService",
+	"DatasetReference":       "dataset",
+	"DataFlowReference":      "dataflow",
+	"PipelineReference":      "pipeline",
+}
+
+type dataFactoryArtifact struct {
+	// Type is the folder containing the artifact, e.g. `pipeline`
+	Type string
+
+	Name string
+
+	// Definition is the JSON definition of the artifact, containing the `name` and `properties`
+	Definition []byte
+
+	// Hash is a hash of the normalized JSON definition, used to work out which artifacts have changed
+	Hash string
+
+	// DependsOn contains the keys of the other artifacts in the folder which this artifact references
+	DependsOn []string
+
+	// Started is whether a Trigger should be started once it's been deployed
+	Started bool
+}
+
+func (a dataFactoryArtifact) key() string {
+	return dataFactoryArtifactKey(a.Type, a.Name)
+}
+
+func dataFactoryArtifactKey(artifactType, name string) string {
+	return fmt.Sprintf("%s/%s", artifactType, name)
+}
+
+func parseDataFactoryArtifactKey(input string) (artifactType string, name string, err error) {
+	segments := strings.SplitN(input, "/", 2)
+	if len(segments) != 2 || segments[0] == "" || segments[1] == "" {
+		return "", "", fmt.Errorf("expected an artifact key in the format `{type}/{name}` but got %q", input)
+	}
+
+	return segments[0], segments[1], nil
+}
+
+func dataFactoryArtifactTypeRank(artifactType string) int {
+	for i, v := range dataFactoryArtifactTypes {
+		if v == artifactType {
+			return i
+		}
+	}
+
+	return len(dataFactoryArtifactTypes)
+}
+
+// loadDataFactoryArtifacts reads the artifacts from a folder exported by the Data Factory Studio and
+// returns them in the order in which they need to be deployed
+func loadDataFactoryArtifacts(path string) ([]dataFactoryArtifact, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading %q: %+v", path, err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("%q is not a directory", path)
+	}
+
+	artifacts := make([]dataFactoryArtifact, 0)
+	for _, artifactType := range dataFactoryArtifactTypes {
+		folder := filepath.Join(path, artifactType)
+		files, err := ioutil.ReadDir(folder)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+
+			return nil, fmt.Errorf("reading %q: %+v", folder, err)
+		}
+
+		names := make(map[string]string)
+		for _, file := range files {
+			if file.IsDir() || !strings.EqualFold(filepath.Ext(file.Name()), ".json") {
+				continue
+			}
+
+			fileName := filepath.Join(folder, file.Name())
+			contents, err := ioutil.ReadFile(fileName)
+			if err != nil {
+				return nil, fmt.Errorf("reading %q: %+v", fileName, err)
+			}
+
+			artifact, err := parseDataFactoryArtifact(artifactType, strings.TrimSuffix(file.Name(), filepath.Ext(file.Name())), contents)
+			if err != nil {
+				return nil, fmt.Errorf("parsing %q: %+v", fileName, err)
+			}
+
+			// names are case-insensitive within a Data Factory
+			if existing, ok := names[strings.ToLower(artifact.Name)]; ok {
+				return nil, fmt.Errorf("the %s %q is defined in both %q and %q", artifactType, artifact.Name, existing, fileName)
+			}
+			names[strings.ToLower(artifact.Name)] = fileName
+
+			artifacts = append(artifacts, *artifact)
+		}
+	}
+
+	return sortDataFactoryArtifacts(artifacts)
+}
+
+func parseDataFactoryArtifact(artifactType, fileName string, contents []byte) (*dataFactoryArtifact, error) {
+	var definition map[string]interface{}
+	if err := json.Unmarshal(contents, &definition); err != nil {
+		return nil, err
+	}
+
+	name := fileName
+	if v, ok := definition["name"].(string); ok && v != "" {
+		name = v
+	}
+
+	properties, ok := definition["properties"].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("`properties` must be specified")
+	}
+
+	started := false
+	if artifactType == "trigger" {
+		if v, ok := properties["runtimeState"].(string); ok {
+			started = strings.EqualFold(v, string(datafactory.TriggerRuntimeStateStarted))
+		}
+	}
+
+	normalized, err := json.Marshal(map[string]interface{}{
+		"name":       name,
+		"properties": properties,
+	})
+	if err != nil {
+		return nil, err
+	}
+	hash := sha256.Sum256(normalized)
+
+	dependsOn := make([]string, 0)
+	for _, reference := range dataFactoryArtifactReferences(properties) {
+		if reference == dataFactoryArtifactKey(artifactType, name) {
+			continue
+		}
+		dependsOn = append(dependsOn, reference)
+	}
+
+	return &dataFactoryArtifact{
+		Type:       artifactType,
+		Name:       name,
+		Definition: normalized,
+		Hash:       hex.EncodeToString(hash[:]),
+		DependsOn:  dependsOn,
+		Started:    started,
+	}, nil
+}
+
+// dataFactoryArtifactReferences returns the keys of all artifacts referenced within the specified definition
+func dataFactoryArtifactReferences(input interface{}) []string {
+	found := make(map[string]struct{})
+
+	var walk func(v interface{})
+	walk = func(v interface{}) {
+		switch value := v.(type) {
+		case map[string]interface{}:
+			referenceType, _ := value["type"].(string)
+			referenceName, _ := value["referenceName"].(string)
+			if artifactType, ok := dataFactoryArtifactReferenceTypes[referenceType]; ok && referenceName != "" {
+				found[dataFactoryArtifactKey(artifactType, referenceName)] = struct{}{}
+			}
+
+			for _, item := range value {
+				walk(item)
+			}
+		case []interface{}:
+			for _, item := range value {
+				walk(item)
+			}
+		}
+	}
+	walk(input)
+
+	output := make([]string, 0)
+	for key := range found {
+		output = append(output, key)
+	}
+	sort.Strings(output)
+	return output
+}
+
+// sortDataFactoryArtifacts orders the artifacts so that each artifact comes after the artifacts it references
+func sortDataFactoryArtifacts(input []dataFactoryArtifact) ([]dataFactoryArtifact, error) {
+	artifacts := make([]dataFactoryArtifact, len(input))
+	copy(artifacts, input)
+	sort.SliceStable(artifacts, func(i, j int) bool {
+		if rankI, rankJ := dataFactoryArtifactTypeRank(artifacts[i].Type), dataFactoryArtifactTypeRank(artifacts[j].Type); rankI != rankJ {
+			return rankI < rankJ
+		}
+		return strings.ToLower(artifacts[i].Name) < strings.ToLower(artifacts[j].Name)
+	})
+
+	indexes := make(map[string]int)
+	for i, artifact := range artifacts {
+		indexes[strings.ToLower(artifact.key())] = i
+	}
+
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	states := make([]int, len(artifacts))
+	output := make([]dataFactoryArtifact, 0, len(artifacts))
+
+	var visit func(i int, path []string) error
+	visit = func(i int, path []string) error {
+		switch states[i] {
+		case visited:
+			return nil
+		case visiting:
+			return fmt.Errorf("found a circular reference between the artifacts: %s", strings.Join(append(path, artifacts[i].key()), " -> "))
+		}
+
+		states[i] = visiting
+		for _, dependency := range artifacts[i].DependsOn {
+			// references to artifacts which aren't defined in the folder are assumed to exist already
+			j, ok := indexes[strings.ToLower(dependency)]
+			if !ok {
+				continue
+			}
+
+			if err := visit(j, append(path, artifacts[i].key())); err != nil {
+				return err
+			}
+		}
+		states[i] = visited
+
+		output = append(output, artifacts[i])
+		return nil
+	}
+
+	for i := range artifacts {
+		if err := visit(i, []string{}); err != nil {
+			return nil, err
+		}
+	}
+
+	return output, nil
+}
+
+// sortDataFactoryArtifactKeysForDeletion orders the keys of the artifacts so that the artifacts which
+// can reference other artifacts are deleted first
+func sortDataFactoryArtifactKeysForDeletion(input []string) []string {
+	output := make([]string, len(input))
+	copy(output, input)
+	sort.SliceStable(output, func(i, j int) bool {
+		typeI, nameI, _ := parseDataFactoryArtifactKey(output[i])
+		typeJ, nameJ, _ := parseDataFactoryArtifactKey(output[j])
+		if rankI, rankJ := dataFactoryArtifactTypeRank(typeI), dataFactoryArtifactTypeRank(typeJ); rankI != rankJ {
+			return rankI > rankJ
+		}
+		return nameI < nameJ
+	})
+	return output
+}
+
+func deployDataFactoryArtifact(ctx context.Context, client *client.Client, id parse.DataFactoryId, artifact dataFactoryArtifact) error {
+	switch artifact.Type {
+	case "linkedService":
+		var payload datafactory.LinkedServiceResource
+		if err := json.Unmarshal(artifact.Definition, &payload); err != nil {
+			return err
+		}
+		_, err := client.LinkedServiceClient.CreateOrUpdate(ctx, id.ResourceGroup, id.FactoryName, artifact.Name, payload, "")
+		return err
+
+	case "dataset":
+		var payload datafactory.DatasetResource
+		if err := json.Unmarshal(artifact.Definition, &payload); err != nil {
+			return err
+		}
+		_, err := client.DatasetClient.CreateOrUpdate(ctx, id.ResourceGroup, id.FactoryName, artifact.Name, payload, "")
+		return err
+
+	case "dataflow":
+		var payload datafactory.DataFlowResource
+		if err := json.Unmarshal(artifact.Definition, &payload); err != nil {
+			return err
+		}
+		_, err := client.DataFlowsClient.CreateOrUpdate(ctx, id.ResourceGroup, id.FactoryName, artifact.Name, payload, "")
+		return err
+
+	case "pipeline":
+		var payload datafactory.PipelineResource
+		if err := json.Unmarshal(artifact.Definition, &payload); err != nil {
+			return err
+		}
+		_, err := client.PipelinesClient.CreateOrUpdate(ctx, id.ResourceGroup, id.FactoryName, artifact.Name, payload, "")
+		return err
+
+	case "trigger":
+		var payload datafactory.TriggerResource
+		if err := json.Unmarshal(artifact.Definition, &payload); err != nil {
+			return err
+		}
+		_, err := client.TriggersClient.CreateOrUpdate(ctx, id.ResourceGroup, id.FactoryName, artifact.Name, payload, "")
+		return err
+	}
+
+	return fmt.Errorf("unsupported artifact type %q", artifact.Type)
+}
+
+func deleteDataFactoryArtifact(ctx context.Context, client *client.Client, id parse.DataFactoryId, artifactType, name string) error {
+	var resp autorest.Response
+	var err error
+
+	switch artifactType {
+	case "linkedService":
+		resp, err = client.LinkedServiceClient.Delete(ctx, id.ResourceGroup, id.FactoryName, name)
+	case "dataset":
+		resp, err = client.DatasetClient.Delete(ctx, id.ResourceGroup, id.FactoryName, name)
+	case "dataflow":
+		resp, err = client.DataFlowsClient.Delete(ctx, id.ResourceGroup, id.FactoryName, name)
+	case "pipeline":
+		resp, err = client.PipelinesClient.Delete(ctx, id.ResourceGroup, id.FactoryName, name)
+	case "trigger":
+		if err := stopDataFactoryTrigger(ctx, client, id, name); err != nil {
+			return err
+		}
+		resp, err = client.TriggersClient.Delete(ctx, id.ResourceGroup, id.FactoryName, name)
+	default:
+		return fmt.Errorf("unsupported artifact type %q", artifactType)
+	}
+
+	if err != nil && !utils.ResponseWasNotFound(resp) {
+		return err
+	}
+
+	return nil
+}
+
+// deleteDataFactoryArtifacts deletes the specified artifacts - since the artifacts which reference an artifact
+// have to be deleted before it, any failed deletions are retried for as long as other deletions are succeeding
+func deleteDataFactoryArtifacts(ctx context.Context, client *client.Client, id parse.DataFactoryId, keys []string) error {
+	pending := sortDataFactoryArtifactKeysForDeletion(keys)
+	for len(pending) > 0 {
+		failed := make([]string, 0)
+		errors := make([]string, 0)
+		for _, key := range pending {
+			artifactType, name, err := parseDataFactoryArtifactKey(key)
+			if err != nil {
+				return err
+			}
+
+			log.Printf("[DEBUG] Deleting %s %q from %s..", artifactType, name, id)
+			if err := deleteDataFactoryArtifact(ctx, client, id, artifactType, name); err != nil {
+				failed = append(failed, key)
+				errors = append(errors, fmt.Sprintf("deleting %s %q: %+v", artifactType, name, err))
+			}
+		}
+
+		if len(failed) == len(pending) {
+			return fmt.Errorf("deleting artifacts from %s:\n%s", id, strings.Join(errors, "\n"))
+		}
+		pending = failed
+	}
+
+	return nil
+}
+
+func dataFactoryArtifactExists(ctx context.Context, client *client.Client, id parse.DataFactoryId, artifactType, name string) (bool, error) {
+	var resp autorest.Response
+	var err error
+
+	switch artifactType {
+	case "linkedService":
+		r, e := client.LinkedServiceClient.Get(ctx, id.ResourceGroup, id.FactoryName, name, "")
+		resp, err = r.Response, e
+	case "dataset":
+		r, e := client.DatasetClient.Get(ctx, id.ResourceGroup, id.FactoryName, name, "")
+		resp, err = r.Response, e
+	case "dataflow":
+		r, e := client.DataFlowsClient.Get(ctx, id.ResourceGroup, id.FactoryName, name, "")
+		resp, err = r.Response, e
+	case "pipeline":
+		r, e := client.PipelinesClient.Get(ctx, id.ResourceGroup, id.FactoryName, name, "")
+		resp, err = r.Response, e
+	case "trigger":
+		r, e := client.TriggersClient.Get(ctx, id.ResourceGroup, id.FactoryName, name, "")
+		resp, err = r.Response, e
+	default:
+		return false, fmt.Errorf("unsupported artifact type %q", artifactType)
+	}
+
+	if err != nil {
+		if utils.ResponseWasNotFound(resp) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return true, nil
+}
@@ -0,0 +1,310 @@
+package datafactory
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/clients"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/datafactory/parse"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/datafactory/validate"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tf/pluginsdk"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tf/validation"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/timeouts"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+func resourceDataFactoryArtifacts() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		Create: resourceDataFactoryArtifactsCreateUpdate,
+		Read:   resourceDataFactoryArtifactsRead,
+		Update: resourceDataFactoryArtifactsCreateUpdate,
+		Delete: resourceDataFactoryArtifactsDelete,
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Create: pluginsdk.DefaultTimeout(60 * time.Minute),
+			Read:   pluginsdk.DefaultTimeout(5 * time.Minute),
+			Update: pluginsdk.DefaultTimeout(60 * time.Minute),
+			Delete: pluginsdk.DefaultTimeout(60 * time.Minute),
+		},
+
+		Schema: map[string]*pluginsdk.Schema{
+			"data_factory_id": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validate.DataFactoryID,
+			},
+
+			"path": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			// a map of `{type}/{name}` to a hash of the definition of each artifact, which is computed from the
+			// contents of `path` during the plan so that the changes to each artifact show up in the diff
+			"artifacts": {
+				Type:     pluginsdk.TypeMap,
+				Computed: true,
+				Elem: &pluginsdk.Schema{
+					Type: pluginsdk.TypeString,
+				},
+			},
+		},
+
+		CustomizeDiff: pluginsdk.CustomizeDiffShim(resourceDataFactoryArtifactsCustomizeDiff),
+	}
+}
+
+func resourceDataFactoryArtifactsCustomizeDiff(ctx context.Context, d *pluginsdk.ResourceDiff, _ interface{}) error {
+	// the path may not be known until apply time, for example when it's the output of another resource
+	if !d.NewValueKnown("path") {
+		return d.SetNewComputed("artifacts")
+	}
+
+	artifacts, err := loadDataFactoryArtifacts(d.Get("path").(string))
+	if err != nil {
+		return err
+	}
+
+	hashes := flattenDataFactoryArtifactHashes(artifacts)
+
+	// only set the new value when something's changed, to avoid a perpetual diff
+	if old := d.Get("artifacts").(map[string]interface{}); d.Id() != "" && dataFactoryArtifactHashesEqual(old, hashes) {
+		return nil
+	}
+
+	return d.SetNew("artifacts", hashes)
+}
+
+func resourceDataFactoryArtifactsCreateUpdate(d *pluginsdk.ResourceData, meta interface{}) (err error) {
+	client := meta.(*clients.Client).DataFactory
+	ctx, cancel := timeouts.ForCreateUpdate(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := parse.DataFactoryID(d.Get("data_factory_id").(string))
+	if err != nil {
+		return err
+	}
+
+	artifacts, err := loadDataFactoryArtifacts(d.Get("path").(string))
+	if err != nil {
+		return err
+	}
+
+	// the hashes of the artifacts which are currently deployed - this starts out as the previous state and is
+	// updated as each artifact is deployed or deleted, so that a failure part-way through only records what's
+	// actually been deployed
+	deployed := make(map[string]interface{})
+
+	// the hashes of the artifacts which were deployed previously, keyed case-insensitively since Data Factory
+	// treats the names of artifacts as case-insensitive
+	existing := make(map[string]string)
+	existingKeys := make(map[string]string)
+	if !d.IsNewResource() {
+		old, _ := d.GetChange("artifacts")
+		for key, hash := range old.(map[string]interface{}) {
+			deployed[key] = hash
+			existing[strings.ToLower(key)] = hash.(string)
+			existingKeys[strings.ToLower(key)] = key
+		}
+	}
+
+	// started Triggers are stopped before they're updated - should anything fail these are started again, so
+	// that they're left as they were
+	stopped := make([]string, 0)
+	defer func() {
+		if err == nil {
+			return
+		}
+
+		for _, name := range stopped {
+			if startErr := startDataFactoryTrigger(ctx, client, *id, name); startErr != nil {
+				err = multierror.Append(err, startErr)
+			}
+		}
+
+		if len(deployed) > 0 {
+			d.SetId(id.ID())
+		}
+		if setErr := d.Set("artifacts", deployed); setErr != nil {
+			err = multierror.Append(err, fmt.Errorf("setting `artifacts`: %+v", setErr))
+		}
+
+		// so that the artifacts which weren't deployed show up in the diff on the next plan
+		d.Partial(true)
+	}()
+
+	// artifacts are deployed in order, so that everything an artifact references exists before the artifact itself
+	for _, artifact := range artifacts {
+		key := strings.ToLower(artifact.key())
+		if hash, ok := existing[key]; ok && hash == artifact.Hash {
+			delete(existingKeys, key)
+			continue
+		}
+
+		if artifact.Type == "trigger" {
+			started, err := isDataFactoryTriggerStarted(ctx, client, *id, artifact.Name)
+			if err != nil {
+				return fmt.Errorf("updating %s: %+v", *id, err)
+			}
+			if started {
+				if err := stopDataFactoryTrigger(ctx, client, *id, artifact.Name); err != nil {
+					return fmt.Errorf("updating %s: %+v", *id, err)
+				}
+				stopped = append(stopped, artifact.Name)
+			}
+		}
+
+		log.Printf("[DEBUG] Deploying %s %q to %s..", artifact.Type, artifact.Name, *id)
+		if err := deployDataFactoryArtifact(ctx, client, *id, artifact); err != nil {
+			return fmt.Errorf("deploying %s %q to %s: %+v", artifact.Type, artifact.Name, *id, err)
+		}
+
+		// the casing of the name may have changed, in which case the previous key is replaced
+		if previousKey, ok := existingKeys[key]; ok {
+			delete(deployed, previousKey)
+			delete(existingKeys, key)
+		}
+		deployed[artifact.key()] = artifact.Hash
+	}
+
+	// artifacts which have been removed from the folder are deleted once the artifacts which might have
+	// referenced them have been updated
+	removed := make([]string, 0)
+	for _, key := range existingKeys {
+		removed = append(removed, key)
+	}
+	if err := deleteDataFactoryArtifacts(ctx, client, *id, removed); err != nil {
+		// some of these may have been deleted, which Read will remove from the state
+		return err
+	}
+	for _, key := range removed {
+		delete(deployed, key)
+	}
+
+	for _, artifact := range artifacts {
+		if artifact.Type != "trigger" || !artifact.Started {
+			continue
+		}
+
+		started, err := isDataFactoryTriggerStarted(ctx, client, *id, artifact.Name)
+		if err != nil {
+			return fmt.Errorf("updating %s: %+v", *id, err)
+		}
+		if !started {
+			if err := startDataFactoryTrigger(ctx, client, *id, artifact.Name); err != nil {
+				return fmt.Errorf("updating %s: %+v", *id, err)
+			}
+		}
+
+		// this Trigger is running again, so doesn't need to be started should anything else fail
+		for i, name := range stopped {
+			if strings.EqualFold(name, artifact.Name) {
+				stopped = append(stopped[:i], stopped[i+1:]...)
+				break
+			}
+		}
+	}
+
+	d.SetId(id.ID())
+	if err := d.Set("artifacts", flattenDataFactoryArtifactHashes(artifacts)); err != nil {
+		return fmt.Errorf("setting `artifacts`: %+v", err)
+	}
+
+	return resourceDataFactoryArtifactsRead(d, meta)
+}
+
+func resourceDataFactoryArtifactsRead(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).DataFactory
+	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := parse.DataFactoryID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	factory, err := client.FactoriesClient.Get(ctx, id.ResourceGroup, id.FactoryName, "")
+	if err != nil {
+		if utils.ResponseWasNotFound(factory.Response) {
+			log.Printf("[DEBUG] %s was not found - removing from state!", *id)
+			d.SetId("")
+			return nil
+		}
+
+		return fmt.Errorf("retrieving %s: %+v", *id, err)
+	}
+
+	d.Set("data_factory_id", id.ID())
+
+	// any artifacts which have been deleted outside of Terraform are removed, so that they're deployed again
+	artifacts := make(map[string]interface{})
+	for key, hash := range d.Get("artifacts").(map[string]interface{}) {
+		artifactType, name, err := parseDataFactoryArtifactKey(key)
+		if err != nil {
+			return err
+		}
+
+		exists, err := dataFactoryArtifactExists(ctx, client, *id, artifactType, name)
+		if err != nil {
+			return fmt.Errorf("retrieving %s %q from %s: %+v", artifactType, name, *id, err)
+		}
+		if !exists {
+			log.Printf("[DEBUG] %s %q was not found in %s - removing from state!", artifactType, name, *id)
+			continue
+		}
+
+		artifacts[key] = hash
+	}
+
+	if err := d.Set("artifacts", artifacts); err != nil {
+		return fmt.Errorf("setting `artifacts`: %+v", err)
+	}
+
+	return nil
+}
+
+func resourceDataFactoryArtifactsDelete(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).DataFactory
+	ctx, cancel := timeouts.ForDelete(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := parse.DataFactoryID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	keys := make([]string, 0)
+	for key := range d.Get("artifacts").(map[string]interface{}) {
+		keys = append(keys, key)
+	}
+
+	return deleteDataFactoryArtifacts(ctx, client, *id, keys)
+}
+
+func flattenDataFactoryArtifactHashes(input []dataFactoryArtifact) map[string]interface{} {
+	output := make(map[string]interface{})
+	for _, artifact := range input {
+		output[artifact.key()] = artifact.Hash
+	}
+	return output
+}
+
+func dataFactoryArtifactHashesEqual(old, new map[string]interface{}) bool {
+	if len(old) != len(new) {
+		return false
+	}
+
+	for key, hash := range new {
+		if v, ok := old[key]; !ok || v != hash {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,332 @@
+package datafactory_test
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/acceptance"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/acceptance/check"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/clients"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/datafactory/parse"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tf/pluginsdk"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+type DataFactoryArtifactsResource struct {
+}
+
+func TestAccDataFactoryArtifacts_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_data_factory_artifacts", "test")
+	r := DataFactoryArtifactsResource{}
+	path := r.folder(t, map[string]string{
+		"pipeline/pipeline1.json": r.pipeline("pipeline1", "first"),
+	})
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data, path),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("artifacts.%").HasValue("1"),
+			),
+		},
+	})
+}
+
+func TestAccDataFactoryArtifacts_update(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_data_factory_artifacts", "test")
+	r := DataFactoryArtifactsResource{}
+	path := r.folder(t, map[string]string{
+		"linkedService/storage.json": r.linkedService("storage"),
+		"dataset/input.json":         r.dataset("input", "storage"),
+		"pipeline/inner.json":        r.pipelineWithDataset("inner", "input"),
+		"pipeline/outer.json":        r.pipelineExecutingPipeline("outer", "inner"),
+		"pipeline/removed.json":      r.pipeline("removed", "first"),
+	})
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data, path),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("artifacts.%").HasValue("5"),
+			),
+		},
+		{
+			PreConfig: func() {
+				r.writeFile(t, path, "pipeline/inner.json", r.pipeline("inner", "second"))
+				r.removeFile(t, path, "pipeline/removed.json")
+				r.removeFile(t, path, "dataset/input.json")
+			},
+			Config: r.basic(data, path),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("artifacts.%").HasValue("3"),
+			),
+		},
+	})
+}
+
+func TestAccDataFactoryArtifacts_trigger(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_data_factory_artifacts", "test")
+	r := DataFactoryArtifactsResource{}
+	path := r.folder(t, map[string]string{
+		"pipeline/pipeline1.json": r.pipeline("pipeline1", "first"),
+		"trigger/trigger1.json":   r.trigger("trigger1", "pipeline1", "Started"),
+	})
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data, path),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		{
+			PreConfig: func() {
+				r.writeFile(t, path, "trigger/trigger1.json", r.trigger("trigger1", "pipeline1", "Stopped"))
+			},
+			Config: r.basic(data, path),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+	})
+}
+
+func (DataFactoryArtifactsResource) Exists(ctx context.Context, clients *clients.Client, state *pluginsdk.InstanceState) (*bool, error) {
+	id, err := parse.DataFactoryID(state.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	for key := range state.Attributes {
+		if !strings.HasPrefix(key, "artifacts.") || key == "artifacts.%" {
+			continue
+		}
+
+		segments := strings.SplitN(strings.TrimPrefix(key, "artifacts."), "/", 2)
+		if len(segments) != 2 {
+			return nil, fmt.Errorf("parsing the artifact key %q", key)
+		}
+		artifactType, name := segments[0], segments[1]
+
+		var resourceId *string
+		switch artifactType {
+		case "linkedService":
+			resp, err := clients.DataFactory.LinkedServiceClient.Get(ctx, id.ResourceGroup, id.FactoryName, name, "")
+			if err != nil {
+				return nil, fmt.Errorf("retrieving Linked Service %q from %s: %+v", name, *id, err)
+			}
+			resourceId = resp.ID
+		case "dataset":
+			resp, err := clients.DataFactory.DatasetClient.Get(ctx, id.ResourceGroup, id.FactoryName, name, "")
+			if err != nil {
+				return nil, fmt.Errorf("retrieving Dataset %q from %s: %+v", name, *id, err)
+			}
+			resourceId = resp.ID
+		case "pipeline":
+			resp, err := clients.DataFactory.PipelinesClient.Get(ctx, id.ResourceGroup, id.FactoryName, name, "")
+			if err != nil {
+				return nil, fmt.Errorf("retrieving Pipeline %q from %s: %+v", name, *id, err)
+			}
+			resourceId = resp.ID
+		case "trigger":
+			resp, err := clients.DataFactory.TriggersClient.Get(ctx, id.ResourceGroup, id.FactoryName, name, "")
+			if err != nil {
+				return nil, fmt.Errorf("retrieving Trigger %q from %s: %+v", name, *id, err)
+			}
+			resourceId = resp.ID
+		default:
+			continue
+		}
+
+		if resourceId == nil {
+			return utils.Bool(false), nil
+		}
+	}
+
+	return utils.Bool(true), nil
+}
+
+func (DataFactoryArtifactsResource) folder(t *testing.T, files map[string]string) string {
+	path, err := ioutil.TempDir("", "acctestdfartifacts")
+	if err != nil {
+		t.Fatalf("creating the temporary directory: %+v", err)
+	}
+	t.Cleanup(func() {
+		os.RemoveAll(path)
+	})
+
+	for name, contents := range files {
+		DataFactoryArtifactsResource{}.writeFile(t, path, name, contents)
+	}
+
+	return path
+}
+
+func (DataFactoryArtifactsResource) writeFile(t *testing.T, path, name, contents string) {
+	fileName := filepath.Join(path, name)
+	if err := os.MkdirAll(filepath.Dir(fileName), 0755); err != nil {
+		t.Fatalf("creating %q: %+v", filepath.Dir(fileName), err)
+	}
+	if err := ioutil.WriteFile(fileName, []byte(contents), 0600); err != nil {
+		t.Fatalf("writing %q: %+v", fileName, err)
+	}
+}
+
+func (DataFactoryArtifactsResource) removeFile(t *testing.T, path, name string) {
+	if err := os.Remove(filepath.Join(path, name)); err != nil {
+		t.Fatalf("removing %q: %+v", name, err)
+	}
+}
+
+func (DataFactoryArtifactsResource) linkedService(name string) string {
+	return fmt.Sprintf(`{
+  "name": "%s",
+  "properties": {
+    "type": "AzureBlobStorage",
+    "typeProperties": {
+      "connectionString": "DefaultEndpointsProtocol=https;AccountName=acctest;EndpointSuffix=core.windows.net;"
+    }
+  }
+}`, name)
+}
+
+func (DataFactoryArtifactsResource) dataset(name, linkedServiceName string) string {
+	return fmt.Sprintf(`{
+  "name": "%s",
+  "properties": {
+    "type": "Binary",
+    "linkedServiceName": {
+      "referenceName": "%s",
+      "type": "LinkedServiceReference"
+    },
+    "typeProperties": {
+      "location": {
+        "type": "AzureBlobStorageLocation",
+        "container": "input"
+      }
+    }
+  }
+}`, name, linkedServiceName)
+}
+
+func (DataFactoryArtifactsResource) pipeline(name, value string) string {
+	return fmt.Sprintf(`{
+  "name": "%s",
+  "properties": {
+    "activities": [
+      {
+        "name": "Append variable",
+        "type": "AppendVariable",
+        "typeProperties": {
+          "variableName": "bob",
+          "value": "%s"
+        }
+      }
+    ],
+    "variables": {
+      "bob": {
+        "type": "Array"
+      }
+    }
+  }
+}`, name, value)
+}
+
+func (DataFactoryArtifactsResource) pipelineWithDataset(name, datasetName string) string {
+	return fmt.Sprintf(`{
+  "name": "%s",
+  "properties": {
+    "activities": [
+      {
+        "name": "Metadata",
+        "type": "GetMetadata",
+        "typeProperties": {
+          "dataset": {
+            "referenceName": "%s",
+            "type": "DatasetReference"
+          },
+          "fieldList": ["exists"]
+        }
+      }
+    ]
+  }
+}`, name, datasetName)
+}
+
+func (DataFactoryArtifactsResource) pipelineExecutingPipeline(name, pipelineName string) string {
+	return fmt.Sprintf(`{
+  "name": "%s",
+  "properties": {
+    "activities": [
+      {
+        "name": "Execute",
+        "type": "ExecutePipeline",
+        "typeProperties": {
+          "pipeline": {
+            "referenceName": "%s",
+            "type": "PipelineReference"
+          }
+        }
+      }
+    ]
+  }
+}`, name, pipelineName)
+}
+
+func (DataFactoryArtifactsResource) trigger(name, pipelineName, runtimeState string) string {
+	return fmt.Sprintf(`{
+  "name": "%s",
+  "properties": {
+    "type": "ScheduleTrigger",
+    "runtimeState": "%s",
+    "pipelines": [
+      {
+        "pipelineReference": {
+          "referenceName": "%s",
+          "type": "PipelineReference"
+        }
+      }
+    ],
+    "typeProperties": {
+      "recurrence": {
+        "frequency": "Day",
+        "interval": 1,
+        "startTime": "2030-01-01T00:00:00Z",
+        "timeZone": "UTC"
+      }
+    }
+  }
+}`, name, runtimeState, pipelineName)
+}
+
+func (DataFactoryArtifactsResource) basic(data acceptance.TestData, path string) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-df-%d"
+  location = "%s"
+}
+
+resource "azurerm_data_factory" "test" {
+  name                = "acctestdfv2%d"
+  location            = azurerm_resource_group.test.location
+  resource_group_name = azurerm_resource_group.test.name
+}
+
+resource "azurerm_data_factory_artifacts" "test" {
+  data_factory_id = azurerm_data_factory.test.id
+  path            = %q
+}
+`, data.RandomInteger, data.Locations.Primary, data.RandomInteger, path)
+}
@@ -0,0 +1,176 @@
+package datafactory
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDataFactoryArtifactReferences(t *testing.T) {
+	cases := []struct {
+		Type     string
+		Input    string
+		Expected []string
+	}{
+		{
+			Type:     "pipeline",
+			Input:    `{"name": "pipeline1", "properties": {"activities": []}}`,
+			Expected: []string{},
+		},
+		{
+			Type: "pipeline",
+			Input: `{"name": "pipeline1", "properties": {"activities": [
+				{"name": "copy", "inputs": [{"referenceName": "dataset1", "type": "DatasetReference"}], "outputs": [{"referenceName": "dataset2", "type": "DatasetReference"}]},
+				{"name": "run", "typeProperties": {"pipeline": {"referenceName": "pipeline2", "type": "PipelineReference"}}}
+			]}}`,
+			Expected: []string{"dataset/dataset1", "dataset/dataset2", "pipeline/pipeline2"},
+		},
+		{
+			Type:     "dataset",
+			Input:    `{"name": "dataset1", "properties": {"linkedServiceName": {"referenceName": "linkedService1", "type": "LinkedServiceReference"}}}`,
+			Expected: []string{"linkedService/linkedService1"},
+		},
+		{
+			// a reference to the pipeline itself isn't a dependency
+			Type:     "pipeline",
+			Input:    `{"name": "pipeline1", "properties": {"activities": [{"typeProperties": {"pipeline": {"referenceName": "pipeline1", "type": "PipelineReference"}}}]}}`,
+			Expected: []string{},
+		},
+		{
+			// references of unknown types are ignored
+			Type:     "pipeline",
+			Input:    `{"name": "pipeline1", "properties": {"activities": [{"typeProperties": {"reference": {"referenceName": "runtime1", "type": "IntegrationRuntimeReference"}}}]}}`,
+			Expected: []string{},
+		},
+	}
+
+	for _, tc := range cases {
+		artifact, err := parseDataFactoryArtifact(tc.Type, "file", []byte(tc.Input))
+		if err != nil {
+			t.Fatalf("parsing %q: %+v", tc.Input, err)
+		}
+
+		if !reflect.DeepEqual(artifact.DependsOn, tc.Expected) {
+			t.Fatalf("Expected the dependencies to be %+v but got %+v", tc.Expected, artifact.DependsOn)
+		}
+	}
+}
+
+func TestDataFactoryArtifactParse(t *testing.T) {
+	artifact, err := parseDataFactoryArtifact("trigger", "trigger1", []byte(`{"properties": {"type": "ScheduleTrigger", "runtimeState": "Started"}}`))
+	if err != nil {
+		t.Fatalf("parsing: %+v", err)
+	}
+	if artifact.Name != "trigger1" {
+		t.Fatalf("Expected the name to fall back to the file name but got %q", artifact.Name)
+	}
+	if !artifact.Started {
+		t.Fatalf("Expected the trigger to be started")
+	}
+
+	// whitespace and the order of the keys doesn't affect the hash
+	first, err := parseDataFactoryArtifact("pipeline", "pipeline1", []byte(`{"name": "pipeline1", "properties": {"a": 1, "b": 2}}`))
+	if err != nil {
+		t.Fatalf("parsing: %+v", err)
+	}
+	second, err := parseDataFactoryArtifact("pipeline", "pipeline1", []byte(`{"properties":{"b":2,"a":1},"name":"pipeline1","etag":"abc"}`))
+	if err != nil {
+		t.Fatalf("parsing: %+v", err)
+	}
+	if first.Hash != second.Hash {
+		t.Fatalf("Expected the hashes to match but got %q and %q", first.Hash, second.Hash)
+	}
+
+	if _, err := parseDataFactoryArtifact("pipeline", "pipeline1", []byte(`{"name": "pipeline1"}`)); err == nil {
+		t.Fatalf("Expected an error when `properties` isn't specified")
+	}
+}
+
+func TestDataFactoryArtifactSort(t *testing.T) {
+	artifacts := []dataFactoryArtifact{
+		{Type: "trigger", Name: "trigger1", DependsOn: []string{"pipeline/outer"}},
+		{Type: "pipeline", Name: "outer", DependsOn: []string{"pipeline/inner", "dataset/dataset1"}},
+		{Type: "pipeline", Name: "inner", DependsOn: []string{"dataset/dataset1", "dataset/external"}},
+		{Type: "dataset", Name: "dataset1", DependsOn: []string{"linkedService/linkedService1"}},
+		{Type: "linkedService", Name: "linkedService1"},
+	}
+
+	sorted, err := sortDataFactoryArtifacts(artifacts)
+	if err != nil {
+		t.Fatalf("sorting: %+v", err)
+	}
+
+	actual := make([]string, 0)
+	for _, artifact := range sorted {
+		actual = append(actual, artifact.key())
+	}
+	expected := []string{"linkedService/linkedService1", "dataset/dataset1", "pipeline/inner", "pipeline/outer", "trigger/trigger1"}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("Expected the order to be %+v but got %+v", expected, actual)
+	}
+
+	circular := []dataFactoryArtifact{
+		{Type: "pipeline", Name: "first", DependsOn: []string{"pipeline/second"}},
+		{Type: "pipeline", Name: "second", DependsOn: []string{"pipeline/first"}},
+	}
+	if _, err := sortDataFactoryArtifacts(circular); err == nil || !strings.Contains(err.Error(), "pipeline/first -> pipeline/second -> pipeline/first") {
+		t.Fatalf("Expected an error for the circular reference but got %+v", err)
+	}
+}
+
+func TestDataFactoryArtifactSortForDeletion(t *testing.T) {
+	actual := sortDataFactoryArtifactKeysForDeletion([]string{"dataset/dataset1", "linkedService/linkedService1", "trigger/trigger1", "pipeline/pipeline1"})
+	expected := []string{"trigger/trigger1", "pipeline/pipeline1", "dataset/dataset1", "linkedService/linkedService1"}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("Expected the order to be %+v but got %+v", expected, actual)
+	}
+}
+
+func TestDataFactoryArtifactLoad(t *testing.T) {
+	dir, err := ioutil.TempDir("", "adf-artifacts")
+	if err != nil {
+		t.Fatalf("creating the temporary directory: %+v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	files := map[string]string{
+		"pipeline/pipeline1.json":     `{"name": "pipeline1", "properties": {"activities": [{"inputs": [{"referenceName": "dataset1", "type": "DatasetReference"}]}]}}`,
+		"dataset/dataset1.json":       `{"name": "dataset1", "properties": {"linkedServiceName": {"referenceName": "linkedService1", "type": "LinkedServiceReference"}}}`,
+		"linkedService/ls1.json":      `{"name": "linkedService1", "properties": {"type": "AzureBlobStorage"}}`,
+		"linkedService/README.md":     `not an artifact`,
+		"integrationRuntime/ir1.json": `{"name": "ir1", "properties": {}}`,
+	}
+	for name, contents := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("creating %q: %+v", filepath.Dir(path), err)
+		}
+		if err := ioutil.WriteFile(path, []byte(contents), 0600); err != nil {
+			t.Fatalf("writing %q: %+v", path, err)
+		}
+	}
+
+	artifacts, err := loadDataFactoryArtifacts(dir)
+	if err != nil {
+		t.Fatalf("loading: %+v", err)
+	}
+
+	actual := make([]string, 0)
+	for _, artifact := range artifacts {
+		actual = append(actual, artifact.key())
+	}
+	expected := []string{"linkedService/linkedService1", "dataset/dataset1", "pipeline/pipeline1"}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("Expected the artifacts to be %+v but got %+v", expected, actual)
+	}
+
+	if err := ioutil.WriteFile(filepath.Join(dir, "pipeline", "duplicate.json"), []byte(`{"name": "PIPELINE1", "properties": {}}`), 0600); err != nil {
+		t.Fatalf("writing: %+v", err)
+	}
+	if _, err := loadDataFactoryArtifacts(dir); err == nil {
+		t.Fatalf("Expected an error for the duplicate artifact")
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/azure"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/tf"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/clients"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/datafactory/parse"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/datafactory/validate"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tf/pluginsdk"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tf/validation"
@@ -97,7 +98,9 @@ func resourceDataFactoryPipeline() *pluginsdk.Resource {
 }
 
 func resourceDataFactoryPipelineCreateUpdate(d *pluginsdk.ResourceData, meta interface{}) error {
-	client := meta.(*clients.Client).DataFactory.PipelinesClient
+	dataFactoryClient := meta.(*clients.Client).DataFactory
+	client := dataFactoryClient.PipelinesClient
+	subscriptionId := meta.(*clients.Client).Account.SubscriptionId
 	ctx, cancel := timeouts.ForCreateUpdate(meta.(*clients.Client).StopContext, d)
 	defer cancel()
 
@@ -154,7 +157,23 @@ func resourceDataFactoryPipelineCreateUpdate(d *pluginsdk.ResourceData, meta int
 		Pipeline: pipeline,
 	}
 
-	if _, err := client.CreateOrUpdate(ctx, resourceGroupName, dataFactoryName, name, config, ""); err != nil {
+	// a Pipeline can't be updated whilst a Trigger which runs it is started, so these are stopped during the
+	// update and then started again afterwards
+	triggers := make([]string, 0)
+	dataFactoryId := parse.NewDataFactoryID(subscriptionId, resourceGroupName, dataFactoryName)
+	if !d.IsNewResource() {
+		started, err := startedDataFactoryTriggersForPipeline(ctx, dataFactoryClient, dataFactoryId, name)
+		if err != nil {
+			return fmt.Errorf("updating Data Factory Pipeline %q (Resource Group %q / Data Factory %q): %+v", name, resourceGroupName, dataFactoryName, err)
+		}
+		triggers = started
+	}
+
+	err := withDataFactoryTriggersStopped(ctx, dataFactoryClient, dataFactoryId, triggers, func() error {
+		_, err := client.CreateOrUpdate(ctx, resourceGroupName, dataFactoryName, name, config, "")
+		return err
+	})
+	if err != nil {
 		return fmt.Errorf("creating Data Factory Pipeline %q (Resource Group %q / Data Factory %q): %+v", name, resourceGroupName, dataFactoryName, err)
 	}
 
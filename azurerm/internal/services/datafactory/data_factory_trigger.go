@@ -0,0 +1,200 @@
+package datafactory
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/services/datafactory/mgmt/2018-06-01/datafactory"
+	"github.com/hashicorp/go-multierror"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/datafactory/client"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/datafactory/parse"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+// dataFactoryTriggerRuntimeState returns the runtime state of a Trigger, which is only exposed on the specific types
+func dataFactoryTriggerRuntimeState(input datafactory.BasicTrigger) datafactory.TriggerRuntimeState {
+	if input == nil {
+		return ""
+	}
+
+	if v, ok := input.AsScheduleTrigger(); ok && v != nil {
+		return v.RuntimeState
+	}
+	if v, ok := input.AsBlobTrigger(); ok && v != nil {
+		return v.RuntimeState
+	}
+	if v, ok := input.AsBlobEventsTrigger(); ok && v != nil {
+		return v.RuntimeState
+	}
+	if v, ok := input.AsCustomEventsTrigger(); ok && v != nil {
+		return v.RuntimeState
+	}
+	if v, ok := input.AsTumblingWindowTrigger(); ok && v != nil {
+		return v.RuntimeState
+	}
+	if v, ok := input.AsRerunTumblingWindowTrigger(); ok && v != nil {
+		return v.RuntimeState
+	}
+	if v, ok := input.AsChainingTrigger(); ok && v != nil {
+		return v.RuntimeState
+	}
+	if v, ok := input.AsMultiplePipelineTrigger(); ok && v != nil {
+		return v.RuntimeState
+	}
+	if v, ok := input.AsTrigger(); ok && v != nil {
+		return v.RuntimeState
+	}
+
+	return ""
+}
+
+// dataFactoryTriggerPipelineNames returns the names of the Pipelines which are run by a Trigger
+func dataFactoryTriggerPipelineNames(input datafactory.BasicTrigger) []string {
+	references := make([]datafactory.TriggerPipelineReference, 0)
+	if input != nil {
+		if v, ok := input.AsScheduleTrigger(); ok && v != nil && v.Pipelines != nil {
+			references = append(references, *v.Pipelines...)
+		}
+		if v, ok := input.AsBlobTrigger(); ok && v != nil && v.Pipelines != nil {
+			references = append(references, *v.Pipelines...)
+		}
+		if v, ok := input.AsBlobEventsTrigger(); ok && v != nil && v.Pipelines != nil {
+			references = append(references, *v.Pipelines...)
+		}
+		if v, ok := input.AsCustomEventsTrigger(); ok && v != nil && v.Pipelines != nil {
+			references = append(references, *v.Pipelines...)
+		}
+		if v, ok := input.AsMultiplePipelineTrigger(); ok && v != nil && v.Pipelines != nil {
+			references = append(references, *v.Pipelines...)
+		}
+		if v, ok := input.AsTumblingWindowTrigger(); ok && v != nil && v.Pipeline != nil {
+			references = append(references, *v.Pipeline)
+		}
+		if v, ok := input.AsChainingTrigger(); ok && v != nil && v.Pipeline != nil {
+			references = append(references, *v.Pipeline)
+		}
+	}
+
+	names := make([]string, 0)
+	for _, reference := range references {
+		if reference.PipelineReference != nil && reference.PipelineReference.ReferenceName != nil {
+			names = append(names, *reference.PipelineReference.ReferenceName)
+		}
+	}
+	return names
+}
+
+// isDataFactoryTriggerStarted returns whether the specified Trigger exists and has been started
+func isDataFactoryTriggerStarted(ctx context.Context, client *client.Client, id parse.DataFactoryId, name string) (bool, error) {
+	resp, err := client.TriggersClient.Get(ctx, id.ResourceGroup, id.FactoryName, name, "")
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			return false, nil
+		}
+		return false, fmt.Errorf("retrieving trigger %q: %+v", name, err)
+	}
+
+	return dataFactoryTriggerRuntimeState(resp.Properties) == datafactory.TriggerRuntimeStateStarted, nil
+}
+
+// startedDataFactoryTriggersForPipeline returns the names of the started Triggers which run the specified Pipeline
+func startedDataFactoryTriggersForPipeline(ctx context.Context, client *client.Client, id parse.DataFactoryId, pipelineName string) ([]string, error) {
+	names := make([]string, 0)
+
+	iterator, err := client.TriggersClient.ListByFactoryComplete(ctx, id.ResourceGroup, id.FactoryName)
+	if err != nil {
+		return nil, fmt.Errorf("listing the triggers within %s: %+v", id, err)
+	}
+	for iterator.NotDone() {
+		trigger := iterator.Value()
+		if trigger.Name != nil && dataFactoryTriggerRuntimeState(trigger.Properties) == datafactory.TriggerRuntimeStateStarted {
+			for _, name := range dataFactoryTriggerPipelineNames(trigger.Properties) {
+				if strings.EqualFold(name, pipelineName) {
+					names = append(names, *trigger.Name)
+					break
+				}
+			}
+		}
+
+		if err := iterator.NextWithContext(ctx); err != nil {
+			return nil, fmt.Errorf("listing the triggers within %s: %+v", id, err)
+		}
+	}
+
+	return names, nil
+}
+
+// started Triggers can be neither updated nor deleted, so they need to be stopped first
+func stopDataFactoryTrigger(ctx context.Context, client *client.Client, id parse.DataFactoryId, name string) error {
+	started, err := isDataFactoryTriggerStarted(ctx, client, id, name)
+	if err != nil {
+		return err
+	}
+	if !started {
+		return nil
+	}
+
+	log.Printf("[DEBUG] Stopping trigger %q within %s..", name, id)
+	future, err := client.TriggersClient.Stop(ctx, id.ResourceGroup, id.FactoryName, name)
+	if err != nil {
+		return fmt.Errorf("stopping trigger %q: %+v", name, err)
+	}
+	if err := future.WaitForCompletionRef(ctx, client.TriggersClient.Client); err != nil {
+		return fmt.Errorf("waiting for trigger %q to stop: %+v", name, err)
+	}
+
+	return nil
+}
+
+func startDataFactoryTrigger(ctx context.Context, client *client.Client, id parse.DataFactoryId, name string) error {
+	log.Printf("[DEBUG] Starting trigger %q within %s..", name, id)
+	future, err := client.TriggersClient.Start(ctx, id.ResourceGroup, id.FactoryName, name)
+	if err != nil {
+		return fmt.Errorf("starting trigger %q: %+v", name, err)
+	}
+	if err := future.WaitForCompletionRef(ctx, client.TriggersClient.Client); err != nil {
+		return fmt.Errorf("waiting for trigger %q to start: %+v", name, err)
+	}
+
+	return nil
+}
+
+// withDataFactoryTriggersStopped stops any of the specified Triggers which are started, calls `f` and then starts
+// the Triggers which were stopped again - regardless of whether `f` succeeded, so that they're left as they were
+func withDataFactoryTriggersStopped(ctx context.Context, client *client.Client, id parse.DataFactoryId, names []string, f func() error) error {
+	var result *multierror.Error
+
+	stopped := make([]string, 0)
+	for _, name := range names {
+		started, err := isDataFactoryTriggerStarted(ctx, client, id, name)
+		if err != nil {
+			result = multierror.Append(result, err)
+			break
+		}
+		if !started {
+			continue
+		}
+
+		if err := stopDataFactoryTrigger(ctx, client, id, name); err != nil {
+			result = multierror.Append(result, err)
+			break
+		}
+		stopped = append(stopped, name)
+	}
+
+	if result == nil {
+		if err := f(); err != nil {
+			result = multierror.Append(result, err)
+		}
+	}
+
+	for _, name := range stopped {
+		if err := startDataFactoryTrigger(ctx, client, id, name); err != nil {
+			result = multierror.Append(result, err)
+		}
+	}
+
+	return result.ErrorOrNil()
+}
@@ -138,7 +138,8 @@ func resourceDataFactoryTriggerBlobEvent() *pluginsdk.Resource {
 }
 
 func resourceDataFactoryTriggerBlobEventCreateUpdate(d *pluginsdk.ResourceData, meta interface{}) error {
-	client := meta.(*clients.Client).DataFactory.TriggersClient
+	dataFactoryClient := meta.(*clients.Client).DataFactory
+	client := dataFactoryClient.TriggersClient
 	subscriptionId := meta.(*clients.Client).Account.SubscriptionId
 	ctx, cancel := timeouts.ForCreateUpdate(meta.(*clients.Client).StopContext, d)
 	defer cancel()
@@ -193,7 +194,17 @@ func resourceDataFactoryTriggerBlobEventCreateUpdate(d *pluginsdk.ResourceData,
 		Properties: blobEventProps,
 	}
 
-	if _, err := client.CreateOrUpdate(ctx, id.ResourceGroup, id.FactoryName, id.Name, trigger, ""); err != nil {
+	// a started Trigger can't be updated, so it's stopped during the update and then started again afterwards
+	triggers := make([]string, 0)
+	if !d.IsNewResource() {
+		triggers = append(triggers, id.Name)
+	}
+
+	err = withDataFactoryTriggersStopped(ctx, dataFactoryClient, *dataFactoryId, triggers, func() error {
+		_, err := client.CreateOrUpdate(ctx, id.ResourceGroup, id.FactoryName, id.Name, trigger, "")
+		return err
+	})
+	if err != nil {
 		return fmt.Errorf("creating %s: %+v", id, err)
 	}
 
@@ -256,7 +267,8 @@ func resourceDataFactoryTriggerBlobEventRead(d *pluginsdk.ResourceData, meta int
 }
 
 func resourceDataFactoryTriggerBlobEventDelete(d *pluginsdk.ResourceData, meta interface{}) error {
-	client := meta.(*clients.Client).DataFactory.TriggersClient
+	dataFactoryClient := meta.(*clients.Client).DataFactory
+	client := dataFactoryClient.TriggersClient
 	ctx, cancel := timeouts.ForCreateUpdate(meta.(*clients.Client).StopContext, d)
 	defer cancel()
 
@@ -265,6 +277,11 @@ func resourceDataFactoryTriggerBlobEventDelete(d *pluginsdk.ResourceData, meta i
 		return err
 	}
 
+	// a started Trigger can't be deleted
+	if err := stopDataFactoryTrigger(ctx, dataFactoryClient, parse.NewDataFactoryID(id.SubscriptionId, id.ResourceGroup, id.FactoryName), id.Name); err != nil {
+		return fmt.Errorf("deleting %s: %+v", id, err)
+	}
+
 	if _, err = client.Delete(ctx, id.ResourceGroup, id.FactoryName, id.Name); err != nil {
 		return fmt.Errorf("deleting %s: %+v", id, err)
 	}
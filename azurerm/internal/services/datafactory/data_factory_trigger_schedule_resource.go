@@ -10,6 +10,7 @@ import (
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/azure"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/tf"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/clients"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/datafactory/parse"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/datafactory/validate"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tf/pluginsdk"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tf/suppress"
@@ -121,7 +122,9 @@ func resourceDataFactoryTriggerSchedule() *pluginsdk.Resource {
 }
 
 func resourceDataFactoryTriggerScheduleCreateUpdate(d *pluginsdk.ResourceData, meta interface{}) error {
-	client := meta.(*clients.Client).DataFactory.TriggersClient
+	dataFactoryClient := meta.(*clients.Client).DataFactory
+	client := dataFactoryClient.TriggersClient
+	subscriptionId := meta.(*clients.Client).Account.SubscriptionId
 	ctx, cancel := timeouts.ForCreateUpdate(meta.(*clients.Client).StopContext, d)
 	defer cancel()
 
@@ -187,7 +190,18 @@ func resourceDataFactoryTriggerScheduleCreateUpdate(d *pluginsdk.ResourceData, m
 		Properties: scheduleProps,
 	}
 
-	if _, err := client.CreateOrUpdate(ctx, resourceGroupName, dataFactoryName, triggerName, trigger, ""); err != nil {
+	// a started Trigger can't be updated, so it's stopped during the update and then started again afterwards
+	triggers := make([]string, 0)
+	if !d.IsNewResource() {
+		triggers = append(triggers, triggerName)
+	}
+
+	dataFactoryId := parse.NewDataFactoryID(subscriptionId, resourceGroupName, dataFactoryName)
+	err := withDataFactoryTriggersStopped(ctx, dataFactoryClient, dataFactoryId, triggers, func() error {
+		_, err := client.CreateOrUpdate(ctx, resourceGroupName, dataFactoryName, triggerName, trigger, "")
+		return err
+	})
+	if err != nil {
 		return fmt.Errorf("Error creating Data Factory Trigger Schedule %q (Resource Group %q / Data Factory %q): %+v", triggerName, resourceGroupName, dataFactoryName, err)
 	}
 
@@ -268,7 +282,9 @@ func resourceDataFactoryTriggerScheduleRead(d *pluginsdk.ResourceData, meta inte
 }
 
 func resourceDataFactoryTriggerScheduleDelete(d *pluginsdk.ResourceData, meta interface{}) error {
-	client := meta.(*clients.Client).DataFactory.TriggersClient
+	dataFactoryClient := meta.(*clients.Client).DataFactory
+	client := dataFactoryClient.TriggersClient
+	subscriptionId := meta.(*clients.Client).Account.SubscriptionId
 	ctx, cancel := timeouts.ForCreateUpdate(meta.(*clients.Client).StopContext, d)
 	defer cancel()
 
@@ -279,6 +295,11 @@ func resourceDataFactoryTriggerScheduleDelete(d *pluginsdk.ResourceData, meta in
 	dataFactoryName := id.Path["factories"]
 	triggerName := id.Path["triggers"]
 
+	// a started Trigger can't be deleted
+	if err := stopDataFactoryTrigger(ctx, dataFactoryClient, parse.NewDataFactoryID(subscriptionId, id.ResourceGroup, dataFactoryName), triggerName); err != nil {
+		return fmt.Errorf("Error deleting Data Factory Trigger Schedule %q (Resource Group %q / Data Factory %q): %+v", triggerName, id.ResourceGroup, dataFactoryName, err)
+	}
+
 	if _, err = client.Delete(ctx, id.ResourceGroup, dataFactoryName, triggerName); err != nil {
 		return fmt.Errorf("Error deleting Data Factory Trigger Schedule %q (Resource Group %q / Data Factory %q): %+v", triggerName, id.ResourceGroup, dataFactoryName, err)
 	}
@@ -6,10 +6,12 @@ import (
 	"testing"
 	"time"
 
+	"github.com/Azure/azure-sdk-for-go/services/datafactory/mgmt/2018-06-01/datafactory"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/azure"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/acceptance"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/acceptance/check"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/clients"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/datafactory/parse"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tf/pluginsdk"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
 )
@@ -56,6 +58,38 @@ func TestAccDataFactoryTriggerSchedule_complete(t *testing.T) {
 	})
 }
 
+func TestAccDataFactoryTriggerSchedule_startedPipelineUpdate(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_data_factory_trigger_schedule", "test")
+	r := TriggerScheduleResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				data.CheckWithClient(r.start),
+			),
+		},
+		{
+			// the started Trigger is stopped whilst the Pipeline is updated, and started again afterwards
+			Config: r.pipelineUpdated(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				data.CheckWithClient(r.isStarted),
+			),
+		},
+		{
+			// the started Trigger is stopped whilst it's being updated
+			Config: r.triggerUpdated(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				data.CheckWithClient(r.isStarted),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
 func (t TriggerScheduleResource) Exists(ctx context.Context, clients *clients.Client, state *pluginsdk.InstanceState) (*bool, error) {
 	id, err := azure.ParseAzureResourceID(state.ID)
 	if err != nil {
@@ -73,6 +107,45 @@ func (t TriggerScheduleResource) Exists(ctx context.Context, clients *clients.Cl
 	return utils.Bool(resp.ID != nil), nil
 }
 
+func (TriggerScheduleResource) start(ctx context.Context, clients *clients.Client, state *pluginsdk.InstanceState) error {
+	id, err := parse.TriggerID(state.ID)
+	if err != nil {
+		return err
+	}
+
+	future, err := clients.DataFactory.TriggersClient.Start(ctx, id.ResourceGroup, id.FactoryName, id.Name)
+	if err != nil {
+		return fmt.Errorf("starting %s: %+v", *id, err)
+	}
+	if err := future.WaitForCompletionRef(ctx, clients.DataFactory.TriggersClient.Client); err != nil {
+		return fmt.Errorf("waiting for %s to start: %+v", *id, err)
+	}
+
+	return nil
+}
+
+func (TriggerScheduleResource) isStarted(ctx context.Context, clients *clients.Client, state *pluginsdk.InstanceState) error {
+	id, err := parse.TriggerID(state.ID)
+	if err != nil {
+		return err
+	}
+
+	resp, err := clients.DataFactory.TriggersClient.Get(ctx, id.ResourceGroup, id.FactoryName, id.Name, "")
+	if err != nil {
+		return fmt.Errorf("retrieving %s: %+v", *id, err)
+	}
+
+	trigger, ok := resp.Properties.AsScheduleTrigger()
+	if !ok || trigger == nil {
+		return fmt.Errorf("classifying %s: Expected: %q", *id, datafactory.TypeBasicTriggerTypeScheduleTrigger)
+	}
+	if trigger.RuntimeState != datafactory.TriggerRuntimeStateStarted {
+		return fmt.Errorf("expected %s to be started but it was %q", *id, trigger.RuntimeState)
+	}
+
+	return nil
+}
+
 func (TriggerScheduleResource) basic(data acceptance.TestData) string {
 	return fmt.Sprintf(`
 provider "azurerm" {
@@ -152,3 +225,82 @@ resource "azurerm_data_factory_trigger_schedule" "test" {
 }
 `, data.RandomInteger, data.Locations.Primary, data.RandomInteger, data.RandomInteger, data.RandomInteger, endTime)
 }
+
+func (TriggerScheduleResource) pipelineUpdated(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-df-%d"
+  location = "%s"
+}
+
+resource "azurerm_data_factory" "test" {
+  name                = "acctestdf%d"
+  location            = azurerm_resource_group.test.location
+  resource_group_name = azurerm_resource_group.test.name
+}
+
+resource "azurerm_data_factory_pipeline" "test" {
+  name                = "acctest%d"
+  resource_group_name = azurerm_resource_group.test.name
+  data_factory_name   = azurerm_data_factory.test.name
+  description         = "updated whilst the trigger is started"
+
+  parameters = {
+    test = "testparameter"
+  }
+}
+
+resource "azurerm_data_factory_trigger_schedule" "test" {
+  name                = "acctestdf%d"
+  data_factory_name   = azurerm_data_factory.test.name
+  resource_group_name = azurerm_resource_group.test.name
+  pipeline_name       = azurerm_data_factory_pipeline.test.name
+
+  annotations = ["test1", "test2", "test3"]
+}
+`, data.RandomInteger, data.Locations.Primary, data.RandomInteger, data.RandomInteger, data.RandomInteger)
+}
+
+func (TriggerScheduleResource) triggerUpdated(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-df-%d"
+  location = "%s"
+}
+
+resource "azurerm_data_factory" "test" {
+  name                = "acctestdf%d"
+  location            = azurerm_resource_group.test.location
+  resource_group_name = azurerm_resource_group.test.name
+}
+
+resource "azurerm_data_factory_pipeline" "test" {
+  name                = "acctest%d"
+  resource_group_name = azurerm_resource_group.test.name
+  data_factory_name   = azurerm_data_factory.test.name
+  description         = "updated whilst the trigger is started"
+
+  parameters = {
+    test = "testparameter"
+  }
+}
+
+resource "azurerm_data_factory_trigger_schedule" "test" {
+  name                = "acctestdf%d"
+  data_factory_name   = azurerm_data_factory.test.name
+  resource_group_name = azurerm_resource_group.test.name
+  pipeline_name       = azurerm_data_factory_pipeline.test.name
+
+  annotations = ["test4"]
+  frequency   = "Hour"
+}
+`, data.RandomInteger, data.Locations.Primary, data.RandomInteger, data.RandomInteger, data.RandomInteger)
+}
@@ -0,0 +1,66 @@
+package datafactory
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/datafactory/mgmt/2018-06-01/datafactory"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+func TestDataFactoryTriggerRuntimeStateAndPipelineNames(t *testing.T) {
+	reference := func(name string) datafactory.TriggerPipelineReference {
+		return datafactory.TriggerPipelineReference{
+			PipelineReference: &datafactory.PipelineReference{
+				ReferenceName: utils.String(name),
+				Type:          utils.String("PipelineReference"),
+			},
+		}
+	}
+
+	cases := []struct {
+		Input         datafactory.BasicTrigger
+		ExpectedState datafactory.TriggerRuntimeState
+		ExpectedNames []string
+	}{
+		{
+			Input:         nil,
+			ExpectedState: "",
+			ExpectedNames: []string{},
+		},
+		{
+			Input: datafactory.ScheduleTrigger{
+				RuntimeState: datafactory.TriggerRuntimeStateStarted,
+				Pipelines:    &[]datafactory.TriggerPipelineReference{reference("first"), reference("second")},
+			},
+			ExpectedState: datafactory.TriggerRuntimeStateStarted,
+			ExpectedNames: []string{"first", "second"},
+		},
+		{
+			Input: datafactory.BlobEventsTrigger{
+				RuntimeState: datafactory.TriggerRuntimeStateStopped,
+				Pipelines:    &[]datafactory.TriggerPipelineReference{reference("first")},
+			},
+			ExpectedState: datafactory.TriggerRuntimeStateStopped,
+			ExpectedNames: []string{"first"},
+		},
+		{
+			Input: datafactory.TumblingWindowTrigger{
+				RuntimeState: datafactory.TriggerRuntimeStateStarted,
+				Pipeline:     func() *datafactory.TriggerPipelineReference { v := reference("first"); return &v }(),
+			},
+			ExpectedState: datafactory.TriggerRuntimeStateStarted,
+			ExpectedNames: []string{"first"},
+		},
+	}
+
+	for _, tc := range cases {
+		if actual := dataFactoryTriggerRuntimeState(tc.Input); actual != tc.ExpectedState {
+			t.Fatalf("Expected the runtime state to be %q but got %q", tc.ExpectedState, actual)
+		}
+
+		if actual := dataFactoryTriggerPipelineNames(tc.Input); !reflect.DeepEqual(actual, tc.ExpectedNames) {
+			t.Fatalf("Expected the pipeline names to be %+v but got %+v", tc.ExpectedNames, actual)
+		}
+	}
+}
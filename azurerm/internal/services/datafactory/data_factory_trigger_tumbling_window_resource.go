@@ -0,0 +1,521 @@
+package datafactory
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/datafactory/mgmt/2018-06-01/datafactory"
+	"github.com/Azure/go-autorest/autorest/date"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/tf"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/clients"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/datafactory/parse"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/datafactory/validate"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tf/pluginsdk"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tf/suppress"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tf/validation"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/timeouts"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+func resourceDataFactoryTriggerTumblingWindow() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		Create: resourceDataFactoryTriggerTumblingWindowCreateUpdate,
+		Read:   resourceDataFactoryTriggerTumblingWindowRead,
+		Update: resourceDataFactoryTriggerTumblingWindowCreateUpdate,
+		Delete: resourceDataFactoryTriggerTumblingWindowDelete,
+
+		Importer: pluginsdk.ImporterValidatingResourceId(func(id string) error {
+			_, err := parse.TriggerID(id)
+			return err
+		}),
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Create: pluginsdk.DefaultTimeout(30 * time.Minute),
+			Read:   pluginsdk.DefaultTimeout(5 * time.Minute),
+			Update: pluginsdk.DefaultTimeout(30 * time.Minute),
+			Delete: pluginsdk.DefaultTimeout(30 * time.Minute),
+		},
+
+		Schema: map[string]*pluginsdk.Schema{
+			"name": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validate.DataFactoryPipelineAndTriggerName(),
+			},
+
+			"data_factory_id": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validate.DataFactoryID,
+			},
+
+			// the time windows can't be changed once the Trigger's been created
+			"frequency": {
+				Type:     pluginsdk.TypeString,
+				Required: true,
+				ForceNew: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					string(datafactory.TumblingWindowFrequencyMinute),
+					string(datafactory.TumblingWindowFrequencyHour),
+					string(datafactory.TumblingWindowFrequencyMonth),
+				}, false),
+			},
+
+			"interval": {
+				Type:         pluginsdk.TypeInt,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+
+			"start_time": {
+				Type:             pluginsdk.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				DiffSuppressFunc: suppress.RFC3339Time,
+				ValidateFunc:     validation.IsRFC3339Time,
+			},
+
+			"pipeline": {
+				Type:     pluginsdk.TypeList,
+				Required: true,
+				MaxItems: 1,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"name": {
+							Type:         pluginsdk.TypeString,
+							Required:     true,
+							ValidateFunc: validate.DataFactoryPipelineAndTriggerName(),
+						},
+
+						"parameters": {
+							Type:     pluginsdk.TypeMap,
+							Optional: true,
+							Elem: &pluginsdk.Schema{
+								Type: pluginsdk.TypeString,
+							},
+						},
+					},
+				},
+			},
+
+			"additional_properties": {
+				Type:     pluginsdk.TypeMap,
+				Optional: true,
+				Elem: &pluginsdk.Schema{
+					Type: pluginsdk.TypeString,
+				},
+			},
+
+			"annotations": {
+				Type:     pluginsdk.TypeList,
+				Optional: true,
+				Elem: &pluginsdk.Schema{
+					Type:         pluginsdk.TypeString,
+					ValidateFunc: validation.StringIsNotEmpty,
+				},
+			},
+
+			"delay": {
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				ValidateFunc: validate.TriggerTimeSpan(),
+			},
+
+			"description": {
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"end_time": {
+				Type:             pluginsdk.TypeString,
+				Optional:         true,
+				DiffSuppressFunc: suppress.RFC3339Time,
+				ValidateFunc:     validation.IsRFC3339Time,
+			},
+
+			"max_concurrency": {
+				Type:         pluginsdk.TypeInt,
+				Optional:     true,
+				Default:      50,
+				ValidateFunc: validation.IntBetween(1, 50),
+			},
+
+			"retry": {
+				Type:     pluginsdk.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"count": {
+							Type:         pluginsdk.TypeInt,
+							Required:     true,
+							ValidateFunc: validation.IntAtLeast(0),
+						},
+
+						"interval": {
+							Type:         pluginsdk.TypeInt,
+							Optional:     true,
+							Default:      30,
+							ValidateFunc: validation.IntAtLeast(30),
+						},
+					},
+				},
+			},
+
+			"trigger_dependency": {
+				Type:     pluginsdk.TypeSet,
+				Optional: true,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						// when omitted, this is a dependency on the previous windows of this Trigger
+						"trigger_name": {
+							Type:         pluginsdk.TypeString,
+							Optional:     true,
+							ValidateFunc: validate.DataFactoryPipelineAndTriggerName(),
+						},
+
+						"offset": {
+							Type:         pluginsdk.TypeString,
+							Optional:     true,
+							ValidateFunc: validate.TriggerTimeSpanOffset(),
+						},
+
+						"size": {
+							Type:         pluginsdk.TypeString,
+							Optional:     true,
+							ValidateFunc: validate.TriggerTimeSpan(),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceDataFactoryTriggerTumblingWindowCreateUpdate(d *pluginsdk.ResourceData, meta interface{}) error {
+	dataFactoryClient := meta.(*clients.Client).DataFactory
+	client := dataFactoryClient.TriggersClient
+	subscriptionId := meta.(*clients.Client).Account.SubscriptionId
+	ctx, cancel := timeouts.ForCreateUpdate(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	dataFactoryId, err := parse.DataFactoryID(d.Get("data_factory_id").(string))
+	if err != nil {
+		return err
+	}
+
+	id := parse.NewTriggerID(subscriptionId, dataFactoryId.ResourceGroup, dataFactoryId.FactoryName, d.Get("name").(string))
+	if d.IsNewResource() {
+		existing, err := client.Get(ctx, id.ResourceGroup, id.FactoryName, id.Name, "")
+		if err != nil {
+			if !utils.ResponseWasNotFound(existing.Response) {
+				return fmt.Errorf("checking for presence of existing %s: %+v", id, err)
+			}
+		}
+		if !utils.ResponseWasNotFound(existing.Response) {
+			return tf.ImportAsExistsError("azurerm_data_factory_trigger_tumbling_window", id.ID())
+		}
+	}
+
+	startTime, _ := time.Parse(time.RFC3339, d.Get("start_time").(string)) // should be validated by the schema
+	props := &datafactory.TumblingWindowTriggerTypeProperties{
+		Frequency:      datafactory.TumblingWindowFrequency(d.Get("frequency").(string)),
+		Interval:       utils.Int32(int32(d.Get("interval").(int))),
+		StartTime:      &date.Time{Time: startTime},
+		MaxConcurrency: utils.Int32(int32(d.Get("max_concurrency").(int))),
+		RetryPolicy:    expandDataFactoryTriggerTumblingWindowRetryPolicy(d.Get("retry").([]interface{})),
+		DependsOn:      expandDataFactoryTriggerTumblingWindowDependencies(d.Get("trigger_dependency").(*pluginsdk.Set).List()),
+	}
+
+	if v, ok := d.GetOk("delay"); ok {
+		props.Delay = v.(string)
+	}
+
+	if v, ok := d.GetOk("end_time"); ok {
+		t, _ := time.Parse(time.RFC3339, v.(string)) // should be validated by the schema
+		props.EndTime = &date.Time{Time: t}
+	}
+
+	tumblingWindowProps := &datafactory.TumblingWindowTrigger{
+		TumblingWindowTriggerTypeProperties: props,
+		Pipeline:                            expandDataFactoryTriggerTumblingWindowPipeline(d.Get("pipeline").([]interface{})),
+		Description:                         utils.String(d.Get("description").(string)),
+		Type:                                datafactory.TypeBasicTriggerTypeTumblingWindowTrigger,
+	}
+
+	if v, ok := d.GetOk("annotations"); ok {
+		annotations := v.([]interface{})
+		tumblingWindowProps.Annotations = &annotations
+	}
+
+	if v, ok := d.GetOk("additional_properties"); ok {
+		tumblingWindowProps.AdditionalProperties = v.(map[string]interface{})
+	}
+
+	trigger := datafactory.TriggerResource{
+		Properties: tumblingWindowProps,
+	}
+
+	// a started Trigger can't be updated, so it's stopped during the update and then started again afterwards
+	triggers := make([]string, 0)
+	if !d.IsNewResource() {
+		triggers = append(triggers, id.Name)
+	}
+
+	err = withDataFactoryTriggersStopped(ctx, dataFactoryClient, *dataFactoryId, triggers, func() error {
+		_, err := client.CreateOrUpdate(ctx, id.ResourceGroup, id.FactoryName, id.Name, trigger, "")
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("creating %s: %+v", id, err)
+	}
+
+	d.SetId(id.ID())
+
+	return resourceDataFactoryTriggerTumblingWindowRead(d, meta)
+}
+
+func resourceDataFactoryTriggerTumblingWindowRead(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).DataFactory.TriggersClient
+	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := parse.TriggerID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.Get(ctx, id.ResourceGroup, id.FactoryName, id.Name, "")
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			log.Printf("[DEBUG] %s was not found - removing from state!", *id)
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("retrieving %s: %+v", id, err)
+	}
+
+	tumblingWindowTrigger, ok := resp.Properties.AsTumblingWindowTrigger()
+	if !ok {
+		return fmt.Errorf("classifying %s: Expected: %q", id, datafactory.TypeBasicTriggerTypeTumblingWindowTrigger)
+	}
+
+	d.Set("name", id.Name)
+	d.Set("data_factory_id", parse.NewDataFactoryID(id.SubscriptionId, id.ResourceGroup, id.FactoryName).ID())
+
+	d.Set("additional_properties", tumblingWindowTrigger.AdditionalProperties)
+	d.Set("description", tumblingWindowTrigger.Description)
+
+	if err := d.Set("annotations", flattenDataFactoryAnnotations(tumblingWindowTrigger.Annotations)); err != nil {
+		return fmt.Errorf("setting `annotations`: %+v", err)
+	}
+
+	if err := d.Set("pipeline", flattenDataFactoryTriggerTumblingWindowPipeline(tumblingWindowTrigger.Pipeline)); err != nil {
+		return fmt.Errorf("setting `pipeline`: %+v", err)
+	}
+
+	if props := tumblingWindowTrigger.TumblingWindowTriggerTypeProperties; props != nil {
+		d.Set("frequency", string(props.Frequency))
+		d.Set("interval", props.Interval)
+		d.Set("max_concurrency", props.MaxConcurrency)
+
+		startTime := ""
+		if v := props.StartTime; v != nil {
+			startTime = v.Format(time.RFC3339)
+		}
+		d.Set("start_time", startTime)
+
+		endTime := ""
+		if v := props.EndTime; v != nil {
+			endTime = v.Format(time.RFC3339)
+		}
+		d.Set("end_time", endTime)
+
+		delay := ""
+		if v, ok := props.Delay.(string); ok {
+			delay = v
+		}
+		d.Set("delay", delay)
+
+		if err := d.Set("retry", flattenDataFactoryTriggerTumblingWindowRetryPolicy(props.RetryPolicy)); err != nil {
+			return fmt.Errorf("setting `retry`: %+v", err)
+		}
+
+		if err := d.Set("trigger_dependency", flattenDataFactoryTriggerTumblingWindowDependencies(props.DependsOn)); err != nil {
+			return fmt.Errorf("setting `trigger_dependency`: %+v", err)
+		}
+	}
+
+	return nil
+}
+
+func resourceDataFactoryTriggerTumblingWindowDelete(d *pluginsdk.ResourceData, meta interface{}) error {
+	dataFactoryClient := meta.(*clients.Client).DataFactory
+	client := dataFactoryClient.TriggersClient
+	ctx, cancel := timeouts.ForDelete(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := parse.TriggerID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	// a started Trigger can't be deleted
+	if err := stopDataFactoryTrigger(ctx, dataFactoryClient, parse.NewDataFactoryID(id.SubscriptionId, id.ResourceGroup, id.FactoryName), id.Name); err != nil {
+		return fmt.Errorf("deleting %s: %+v", id, err)
+	}
+
+	if _, err = client.Delete(ctx, id.ResourceGroup, id.FactoryName, id.Name); err != nil {
+		return fmt.Errorf("deleting %s: %+v", id, err)
+	}
+
+	return nil
+}
+
+func expandDataFactoryTriggerTumblingWindowPipeline(input []interface{}) *datafactory.TriggerPipelineReference {
+	if len(input) == 0 || input[0] == nil {
+		return nil
+	}
+
+	raw := input[0].(map[string]interface{})
+	return &datafactory.TriggerPipelineReference{
+		PipelineReference: &datafactory.PipelineReference{
+			ReferenceName: utils.String(raw["name"].(string)),
+			Type:          utils.String("PipelineReference"),
+		},
+		Parameters: raw["parameters"].(map[string]interface{}),
+	}
+}
+
+func expandDataFactoryTriggerTumblingWindowRetryPolicy(input []interface{}) *datafactory.RetryPolicy {
+	if len(input) == 0 || input[0] == nil {
+		return nil
+	}
+
+	raw := input[0].(map[string]interface{})
+	return &datafactory.RetryPolicy{
+		Count:             raw["count"].(int),
+		IntervalInSeconds: utils.Int32(int32(raw["interval"].(int))),
+	}
+}
+
+func expandDataFactoryTriggerTumblingWindowDependencies(input []interface{}) *[]datafactory.BasicDependencyReference {
+	if len(input) == 0 {
+		return nil
+	}
+
+	result := make([]datafactory.BasicDependencyReference, 0)
+	for _, item := range input {
+		raw := item.(map[string]interface{})
+
+		var offset, size *string
+		if v := raw["offset"].(string); v != "" {
+			offset = utils.String(v)
+		}
+		if v := raw["size"].(string); v != "" {
+			size = utils.String(v)
+		}
+
+		if name := raw["trigger_name"].(string); name != "" {
+			result = append(result, datafactory.TumblingWindowTriggerDependencyReference{
+				ReferenceTrigger: &datafactory.TriggerReference{
+					ReferenceName: utils.String(name),
+					Type:          utils.String("TriggerReference"),
+				},
+				Offset: offset,
+				Size:   size,
+				Type:   datafactory.TypeBasicDependencyReferenceTypeTumblingWindowTriggerDependencyReference,
+			})
+			continue
+		}
+
+		result = append(result, datafactory.SelfDependencyTumblingWindowTriggerReference{
+			Offset: offset,
+			Size:   size,
+			Type:   datafactory.TypeBasicDependencyReferenceTypeSelfDependencyTumblingWindowTriggerReference,
+		})
+	}
+	return &result
+}
+
+func flattenDataFactoryTriggerTumblingWindowPipeline(input *datafactory.TriggerPipelineReference) []interface{} {
+	if input == nil {
+		return []interface{}{}
+	}
+
+	name := ""
+	if input.PipelineReference != nil && input.PipelineReference.ReferenceName != nil {
+		name = *input.PipelineReference.ReferenceName
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"name":       name,
+			"parameters": input.Parameters,
+		},
+	}
+}
+
+func flattenDataFactoryTriggerTumblingWindowRetryPolicy(input *datafactory.RetryPolicy) []interface{} {
+	if input == nil {
+		return []interface{}{}
+	}
+
+	// the count is an expression in the API, which is returned as a number when it's a literal
+	count := 0
+	switch v := input.Count.(type) {
+	case float64:
+		count = int(v)
+	case int:
+		count = v
+	}
+
+	interval := 0
+	if input.IntervalInSeconds != nil {
+		interval = int(*input.IntervalInSeconds)
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"count":    count,
+			"interval": interval,
+		},
+	}
+}
+
+func flattenDataFactoryTriggerTumblingWindowDependencies(input *[]datafactory.BasicDependencyReference) []interface{} {
+	if input == nil {
+		return []interface{}{}
+	}
+
+	result := make([]interface{}, 0)
+	for _, item := range *input {
+		if v, ok := item.AsTumblingWindowTriggerDependencyReference(); ok && v != nil {
+			name := ""
+			if v.ReferenceTrigger != nil && v.ReferenceTrigger.ReferenceName != nil {
+				name = *v.ReferenceTrigger.ReferenceName
+			}
+
+			result = append(result, map[string]interface{}{
+				"trigger_name": name,
+				"offset":       utils.NormalizeNilableString(v.Offset),
+				"size":         utils.NormalizeNilableString(v.Size),
+			})
+			continue
+		}
+
+		if v, ok := item.AsSelfDependencyTumblingWindowTriggerReference(); ok && v != nil {
+			result = append(result, map[string]interface{}{
+				"trigger_name": "",
+				"offset":       utils.NormalizeNilableString(v.Offset),
+				"size":         utils.NormalizeNilableString(v.Size),
+			})
+		}
+	}
+	return result
+}
@@ -0,0 +1,229 @@
+package datafactory_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/acceptance"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/acceptance/check"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/clients"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/datafactory/parse"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tf/pluginsdk"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+type TriggerTumblingWindowResource struct {
+}
+
+func TestAccDataFactoryTriggerTumblingWindow_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_data_factory_trigger_tumbling_window", "test")
+	r := TriggerTumblingWindowResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccDataFactoryTriggerTumblingWindow_requiresImport(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_data_factory_trigger_tumbling_window", "test")
+	r := TriggerTumblingWindowResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.RequiresImportErrorStep(r.requiresImport),
+	})
+}
+
+func TestAccDataFactoryTriggerTumblingWindow_complete(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_data_factory_trigger_tumbling_window", "test")
+	r := TriggerTumblingWindowResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.complete(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("trigger_dependency.#").HasValue("2"),
+				check.That(data.ResourceName).Key("retry.0.count").HasValue("3"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccDataFactoryTriggerTumblingWindow_update(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_data_factory_trigger_tumbling_window", "test")
+	r := TriggerTumblingWindowResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.complete(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func (t TriggerTumblingWindowResource) Exists(ctx context.Context, clients *clients.Client, state *pluginsdk.InstanceState) (*bool, error) {
+	id, err := parse.TriggerID(state.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := clients.DataFactory.TriggersClient.Get(ctx, id.ResourceGroup, id.FactoryName, id.Name, "")
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %+v", id, err)
+	}
+
+	return utils.Bool(resp.ID != nil), nil
+}
+
+func (r TriggerTumblingWindowResource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_data_factory_trigger_tumbling_window" "test" {
+  name            = "acctestdf%d"
+  data_factory_id = azurerm_data_factory.test.id
+  start_time      = "2030-01-01T00:00:00Z"
+  frequency       = "Hour"
+  interval        = 1
+
+  pipeline {
+    name = azurerm_data_factory_pipeline.test.name
+  }
+}
+`, r.template(data), data.RandomInteger)
+}
+
+func (r TriggerTumblingWindowResource) requiresImport(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_data_factory_trigger_tumbling_window" "import" {
+  name            = azurerm_data_factory_trigger_tumbling_window.test.name
+  data_factory_id = azurerm_data_factory_trigger_tumbling_window.test.data_factory_id
+  start_time      = azurerm_data_factory_trigger_tumbling_window.test.start_time
+  frequency       = azurerm_data_factory_trigger_tumbling_window.test.frequency
+  interval        = azurerm_data_factory_trigger_tumbling_window.test.interval
+
+  pipeline {
+    name = azurerm_data_factory_pipeline.test.name
+  }
+}
+`, r.basic(data))
+}
+
+func (r TriggerTumblingWindowResource) complete(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_data_factory_trigger_tumbling_window" "upstream" {
+  name            = "acctestdfup%d"
+  data_factory_id = azurerm_data_factory.test.id
+  start_time      = "2030-01-01T00:00:00Z"
+  frequency       = "Hour"
+  interval        = 1
+
+  pipeline {
+    name = azurerm_data_factory_pipeline.test.name
+  }
+}
+
+resource "azurerm_data_factory_trigger_tumbling_window" "test" {
+  name            = "acctestdf%d"
+  data_factory_id = azurerm_data_factory.test.id
+  start_time      = "2030-01-01T00:00:00Z"
+  end_time        = "2031-01-01T00:00:00Z"
+  frequency       = "Hour"
+  interval        = 1
+  delay           = "00:15:00"
+  max_concurrency = 10
+  description     = "test description"
+  annotations     = ["test1", "test2", "test3"]
+
+  pipeline {
+    name = azurerm_data_factory_pipeline.test.name
+    parameters = {
+      windowStart = "@{trigger().outputs.windowStartTime}"
+    }
+  }
+
+  retry {
+    count    = 3
+    interval = 60
+  }
+
+  trigger_dependency {
+    trigger_name = azurerm_data_factory_trigger_tumbling_window.upstream.name
+    size         = "02:00:00"
+  }
+
+  trigger_dependency {
+    offset = "-01:00:00"
+    size   = "01:00:00"
+  }
+
+  additional_properties = {
+    foo = "test1"
+  }
+}
+`, r.template(data), data.RandomInteger, data.RandomInteger)
+}
+
+func (TriggerTumblingWindowResource) template(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-df-%d"
+  location = "%s"
+}
+
+resource "azurerm_data_factory" "test" {
+  name                = "acctestdf%d"
+  location            = azurerm_resource_group.test.location
+  resource_group_name = azurerm_resource_group.test.name
+}
+
+resource "azurerm_data_factory_pipeline" "test" {
+  name                = "acctest%d"
+  resource_group_name = azurerm_resource_group.test.name
+  data_factory_name   = azurerm_data_factory.test.name
+
+  parameters = {
+    windowStart = ""
+  }
+}
+`, data.RandomInteger, data.Locations.Primary, data.RandomInteger, data.RandomInteger)
+}
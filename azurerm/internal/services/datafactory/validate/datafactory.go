@@ -28,3 +28,27 @@ func DataFactoryName() pluginsdk.SchemaValidateFunc {
 		return warnings, errors
 	}
 }
+
+// TriggerTimeSpan validates a time span used by a Tumbling Window Trigger, in the format `[d.]hh:mm:ss`
+func TriggerTimeSpan() pluginsdk.SchemaValidateFunc {
+	return func(i interface{}, k string) (warnings []string, errors []error) {
+		value := i.(string)
+		if !regexp.MustCompile(`^((\d+)\.)?(\d\d):(60|([0-5][0-9])):(60|([0-5][0-9]))$`).MatchString(value) {
+			errors = append(errors, fmt.Errorf("%q must be a time span in the format `[d.]hh:mm:ss`, got %q", k, value))
+		}
+
+		return warnings, errors
+	}
+}
+
+// TriggerTimeSpanOffset validates the offset of a Tumbling Window Trigger dependency, in the format `[-][d.]hh:mm:ss`
+func TriggerTimeSpanOffset() pluginsdk.SchemaValidateFunc {
+	return func(i interface{}, k string) (warnings []string, errors []error) {
+		value := i.(string)
+		if !regexp.MustCompile(`^-?((\d+)\.)?(\d\d):(60|([0-5][0-9])):(60|([0-5][0-9]))$`).MatchString(value) {
+			errors = append(errors, fmt.Errorf("%q must be a time span in the format `[-][d.]hh:mm:ss`, got %q", k, value))
+		}
+
+		return warnings, errors
+	}
+}
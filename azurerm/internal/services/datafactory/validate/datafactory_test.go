@@ -55,3 +55,31 @@ func TestValidateDataFactoryName(t *testing.T) {
 		}
 	}
 }
+
+func TestValidateTriggerTimeSpan(t *testing.T) {
+	cases := []struct {
+		Input       string
+		Valid       bool
+		ValidOffset bool
+	}{
+		{Input: "", Valid: false, ValidOffset: false},
+		{Input: "00:00:00", Valid: true, ValidOffset: true},
+		{Input: "01:30:00", Valid: true, ValidOffset: true},
+		{Input: "1.01:30:00", Valid: true, ValidOffset: true},
+		{Input: "-01:30:00", Valid: false, ValidOffset: true},
+		{Input: "-1.01:30:00", Valid: false, ValidOffset: true},
+		{Input: "1:30:00", Valid: false, ValidOffset: false},
+		{Input: "01:61:00", Valid: false, ValidOffset: false},
+		{Input: "01:30", Valid: false, ValidOffset: false},
+		{Input: "PT1H", Valid: false, ValidOffset: false},
+	}
+	for _, tc := range cases {
+		if _, errors := TriggerTimeSpan()(tc.Input, "delay"); (len(errors) == 0) != tc.Valid {
+			t.Fatalf("Expected TriggerTimeSpan to be %t for %q", tc.Valid, tc.Input)
+		}
+
+		if _, errors := TriggerTimeSpanOffset()(tc.Input, "offset"); (len(errors) == 0) != tc.ValidOffset {
+			t.Fatalf("Expected TriggerTimeSpanOffset to be %t for %q", tc.ValidOffset, tc.Input)
+		}
+	}
+}
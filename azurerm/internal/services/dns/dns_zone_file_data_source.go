@@ -0,0 +1,301 @@
+package dns
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/clients"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/dns/zonefile"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tf/pluginsdk"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tf/validation"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/timeouts"
+)
+
+func dataSourceDnsZoneFile() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		Read: dataSourceDnsZoneFileRead,
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Read: pluginsdk.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: map[string]*pluginsdk.Schema{
+			"zone_name": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"content": {
+				Type:     pluginsdk.TypeString,
+				Required: true,
+			},
+
+			"default_ttl": {
+				Type:         pluginsdk.TypeInt,
+				Optional:     true,
+				Default:      3600,
+				ValidateFunc: validation.IntBetween(0, 2147483647),
+			},
+
+			"a_record": dnsZoneFileRecordsSchema(),
+
+			"aaaa_record": dnsZoneFileRecordsSchema(),
+
+			"caa_record": dnsZoneFileRecordBlockSchema(map[string]*pluginsdk.Schema{
+				"flags": {
+					Type:     pluginsdk.TypeInt,
+					Computed: true,
+				},
+
+				"tag": {
+					Type:     pluginsdk.TypeString,
+					Computed: true,
+				},
+
+				"value": {
+					Type:     pluginsdk.TypeString,
+					Computed: true,
+				},
+			}),
+
+			"cname_record": {
+				Type:     pluginsdk.TypeList,
+				Computed: true,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"name": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+
+						"ttl": {
+							Type:     pluginsdk.TypeInt,
+							Computed: true,
+						},
+
+						"record": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+
+			"mx_record": dnsZoneFileRecordBlockSchema(map[string]*pluginsdk.Schema{
+				// this is a string to match the `azurerm_dns_mx_record` resource
+				"preference": {
+					Type:     pluginsdk.TypeString,
+					Computed: true,
+				},
+
+				"exchange": {
+					Type:     pluginsdk.TypeString,
+					Computed: true,
+				},
+			}),
+
+			"ns_record": dnsZoneFileRecordsSchema(),
+
+			"ptr_record": dnsZoneFileRecordsSchema(),
+
+			"srv_record": dnsZoneFileRecordBlockSchema(map[string]*pluginsdk.Schema{
+				"priority": {
+					Type:     pluginsdk.TypeInt,
+					Computed: true,
+				},
+
+				"weight": {
+					Type:     pluginsdk.TypeInt,
+					Computed: true,
+				},
+
+				"port": {
+					Type:     pluginsdk.TypeInt,
+					Computed: true,
+				},
+
+				"target": {
+					Type:     pluginsdk.TypeString,
+					Computed: true,
+				},
+			}),
+
+			"txt_record": dnsZoneFileRecordBlockSchema(map[string]*pluginsdk.Schema{
+				"value": {
+					Type:     pluginsdk.TypeString,
+					Computed: true,
+				},
+			}),
+		},
+	}
+}
+
+// dnsZoneFileRecordsSchema is the schema for Record Sets whose Records are a list of values, such as `azurerm_dns_a_record`
+func dnsZoneFileRecordsSchema() *pluginsdk.Schema {
+	return &pluginsdk.Schema{
+		Type:     pluginsdk.TypeList,
+		Computed: true,
+		Elem: &pluginsdk.Resource{
+			Schema: map[string]*pluginsdk.Schema{
+				"name": {
+					Type:     pluginsdk.TypeString,
+					Computed: true,
+				},
+
+				"ttl": {
+					Type:     pluginsdk.TypeInt,
+					Computed: true,
+				},
+
+				"records": {
+					Type:     pluginsdk.TypeList,
+					Computed: true,
+					Elem: &pluginsdk.Schema{
+						Type: pluginsdk.TypeString,
+					},
+				},
+			},
+		},
+	}
+}
+
+// dnsZoneFileRecordBlockSchema is the schema for Record Sets whose Records are `record` blocks, such as `azurerm_dns_mx_record`
+func dnsZoneFileRecordBlockSchema(record map[string]*pluginsdk.Schema) *pluginsdk.Schema {
+	return &pluginsdk.Schema{
+		Type:     pluginsdk.TypeList,
+		Computed: true,
+		Elem: &pluginsdk.Resource{
+			Schema: map[string]*pluginsdk.Schema{
+				"name": {
+					Type:     pluginsdk.TypeString,
+					Computed: true,
+				},
+
+				"ttl": {
+					Type:     pluginsdk.TypeInt,
+					Computed: true,
+				},
+
+				"record": {
+					Type:     pluginsdk.TypeList,
+					Computed: true,
+					Elem: &pluginsdk.Resource{
+						Schema: record,
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceDnsZoneFileRead(d *pluginsdk.ResourceData, meta interface{}) error {
+	_, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	zoneName := d.Get("zone_name").(string)
+	content := d.Get("content").(string)
+
+	zone, err := zonefile.Parse(content, zoneName, int64(d.Get("default_ttl").(int)))
+	if err != nil {
+		return fmt.Errorf("parsing Zone File for %q: %+v", zoneName, err)
+	}
+
+	// the SOA Record is managed by Azure DNS, so it's only used to parse the Zone File
+	recordSets := map[string][]interface{}{
+		"A":     make([]interface{}, 0),
+		"AAAA":  make([]interface{}, 0),
+		"CAA":   make([]interface{}, 0),
+		"CNAME": make([]interface{}, 0),
+		"MX":    make([]interface{}, 0),
+		"NS":    make([]interface{}, 0),
+		"PTR":   make([]interface{}, 0),
+		"SRV":   make([]interface{}, 0),
+		"TXT":   make([]interface{}, 0),
+	}
+	for _, rs := range zone.RecordSets {
+		if _, ok := recordSets[rs.Type]; !ok {
+			continue
+		}
+
+		recordSets[rs.Type] = append(recordSets[rs.Type], flattenDnsZoneFileRecordSet(rs))
+	}
+
+	for recordType, values := range recordSets {
+		key := fmt.Sprintf("%s_record", strings.ToLower(recordType))
+		if err := d.Set(key, values); err != nil {
+			return fmt.Errorf("setting `%s`: %+v", key, err)
+		}
+	}
+
+	d.SetId(fmt.Sprintf("%s/%x", strings.TrimSuffix(zone.Origin, "."), sha256.Sum256([]byte(content))))
+
+	return nil
+}
+
+func flattenDnsZoneFileRecordSet(input zonefile.RecordSet) map[string]interface{} {
+	output := map[string]interface{}{
+		"name": input.Name,
+		"ttl":  int(input.TTL),
+	}
+
+	switch input.Type {
+	case "A", "AAAA", "NS", "PTR":
+		output["records"] = input.Values
+
+	case "CNAME":
+		record := ""
+		if len(input.Values) > 0 {
+			record = input.Values[0]
+		}
+		output["record"] = record
+
+	case "CAA":
+		records := make([]interface{}, 0)
+		for _, v := range input.CAA {
+			records = append(records, map[string]interface{}{
+				"flags": v.Flags,
+				"tag":   v.Tag,
+				"value": v.Value,
+			})
+		}
+		output["record"] = records
+
+	case "MX":
+		records := make([]interface{}, 0)
+		for _, v := range input.MX {
+			records = append(records, map[string]interface{}{
+				"preference": strconv.Itoa(v.Preference),
+				"exchange":   v.Exchange,
+			})
+		}
+		output["record"] = records
+
+	case "SRV":
+		records := make([]interface{}, 0)
+		for _, v := range input.SRV {
+			records = append(records, map[string]interface{}{
+				"priority": v.Priority,
+				"weight":   v.Weight,
+				"port":     v.Port,
+				"target":   v.Target,
+			})
+		}
+		output["record"] = records
+
+	case "TXT":
+		// consistent with the `azurerm_dns_txt_record` resource, the character strings of a Record are joined
+		records := make([]interface{}, 0)
+		for _, v := range input.TXT {
+			records = append(records, map[string]interface{}{
+				"value": strings.Join(v, ""),
+			})
+		}
+		output["record"] = records
+	}
+
+	return output
+}
@@ -0,0 +1,58 @@
+package dns_test
+
+import (
+	"testing"
+
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/acceptance"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/acceptance/check"
+)
+
+type DnsZoneFileDataSource struct {
+}
+
+func TestAccDnsZoneFileDataSource_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azurerm_dns_zone_file", "test")
+	r := DnsZoneFileDataSource{}
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: r.basic(),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("a_record.#").HasValue("1"),
+				check.That(data.ResourceName).Key("a_record.0.name").HasValue("www"),
+				check.That(data.ResourceName).Key("a_record.0.ttl").HasValue("300"),
+				check.That(data.ResourceName).Key("a_record.0.records.#").HasValue("2"),
+				check.That(data.ResourceName).Key("cname_record.0.record").HasValue("www.example.com"),
+				check.That(data.ResourceName).Key("mx_record.0.record.0.preference").HasValue("10"),
+				check.That(data.ResourceName).Key("mx_record.0.record.0.exchange").HasValue("mail.example.com"),
+				check.That(data.ResourceName).Key("srv_record.0.record.0.port").HasValue("5060"),
+				check.That(data.ResourceName).Key("txt_record.0.record.0.value").HasValue("first second"),
+				check.That(data.ResourceName).Key("caa_record.0.record.0.tag").HasValue("issue"),
+			),
+		},
+	})
+}
+
+func (DnsZoneFileDataSource) basic() string {
+	return `
+provider "azurerm" {
+  features {}
+}
+
+data "azurerm_dns_zone_file" "test" {
+  zone_name = "example.com"
+  content   = <<ZONE
+$ORIGIN example.com.
+$TTL 1h
+@         IN SOA   ns1 hostmaster ( 1 3600 300 2419200 300 )
+@         IN MX    10 mail
+www   300 IN A     10.0.0.1
+      300 IN A     10.0.0.2
+ftp       IN CNAME www
+_sip._tcp IN SRV   10 60 5060 sip
+txt       IN TXT   "first " "second"
+@         IN CAA   0 issue "letsencrypt.org"
+ZONE
+}
+`
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/dns/migration"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/dns/parse"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/dns/validate"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/dns/zonefile"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tags"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tf/pluginsdk"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tf/validation"
@@ -141,6 +142,17 @@ func resourceDnsZone() *pluginsdk.Resource {
 				},
 			},
 
+			"export_enabled": {
+				Type:     pluginsdk.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"export": {
+				Type:     pluginsdk.TypeString,
+				Computed: true,
+			},
+
 			"tags": tags.Schema(),
 		},
 	}
@@ -252,6 +264,27 @@ func resourceDnsZoneRead(d *pluginsdk.ResourceData, meta interface{}) error {
 		return fmt.Errorf("setting `soa_record`: %+v", err)
 	}
 
+	// listing every Record Set is expensive for large zones, so this is only done when the export is requested
+	exportEnabled := d.Get("export_enabled").(bool)
+	d.Set("export_enabled", exportEnabled)
+
+	export := ""
+	if exportEnabled {
+		recordSets := make([]dns.RecordSet, 0)
+		iterator, err := recordSetsClient.ListAllByDNSZoneComplete(ctx, id.ResourceGroup, id.Name, nil, "")
+		if err != nil {
+			return fmt.Errorf("listing Record Sets for DNS Zone %q (Resource Group %q): %+v", id.Name, id.ResourceGroup, err)
+		}
+		for iterator.NotDone() {
+			recordSets = append(recordSets, iterator.Value())
+			if err := iterator.NextWithContext(ctx); err != nil {
+				return fmt.Errorf("listing Record Sets for DNS Zone %q (Resource Group %q): %+v", id.Name, id.ResourceGroup, err)
+			}
+		}
+		export = zonefile.Render(zonefile.FromRecordSets(id.Name, recordSets))
+	}
+	d.Set("export", export)
+
 	return tags.FlattenAndSet(d, resp.Tags)
 }
 
@@ -25,6 +25,31 @@ func TestAccDnsZone_basic(t *testing.T) {
 			Config: r.basic(data),
 			Check: acceptance.ComposeTestCheckFunc(
 				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("export").HasValue(""),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccDnsZone_export(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_dns_zone", "test")
+	r := DnsZoneResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.export(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("export").Exists(),
+			),
+		},
+		data.ImportStep("export_enabled", "export"),
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("export").HasValue(""),
 			),
 		},
 		data.ImportStep(),
@@ -133,6 +158,25 @@ resource "azurerm_dns_zone" "test" {
 `, data.RandomInteger, data.Locations.Primary, data.RandomInteger)
 }
 
+func (DnsZoneResource) export(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%d"
+  location = "%s"
+}
+
+resource "azurerm_dns_zone" "test" {
+  name                = "acctestzone%d.com"
+  resource_group_name = azurerm_resource_group.test.name
+  export_enabled      = true
+}
+`, data.RandomInteger, data.Locations.Primary, data.RandomInteger)
+}
+
 func (DnsZoneResource) requiresImport(data acceptance.TestData) string {
 	template := DnsZoneResource{}.basic(data)
 	return fmt.Sprintf(`
@@ -0,0 +1,178 @@
+package zonefile
+
+import (
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/services/dns/mgmt/2018-05-01/dns"
+)
+
+// FromRecordSets converts the Record Sets returned by the Azure API into a Zone.
+// Alias Record Sets, which have no Records of their own, are omitted.
+func FromRecordSets(zoneName string, input []dns.RecordSet) ZoneFile {
+	output := ZoneFile{
+		Origin:     strings.TrimSuffix(zoneName, ".") + ".",
+		RecordSets: make([]RecordSet, 0),
+	}
+
+	for _, v := range input {
+		if v.Name == nil || v.Type == nil || v.RecordSetProperties == nil {
+			continue
+		}
+
+		// the type is returned in the format `Microsoft.Network/dnszones/{type}`
+		recordType := *v.Type
+		if index := strings.LastIndex(recordType, "/"); index != -1 {
+			recordType = recordType[index+1:]
+		}
+
+		rs := RecordSet{
+			Name: *v.Name,
+			Type: strings.ToUpper(recordType),
+		}
+		if v.TTL != nil {
+			rs.TTL = *v.TTL
+		}
+
+		props := *v.RecordSetProperties
+		switch rs.Type {
+		case "A":
+			if props.ARecords != nil {
+				for _, r := range *props.ARecords {
+					if r.Ipv4Address != nil {
+						rs.Values = append(rs.Values, *r.Ipv4Address)
+					}
+				}
+			}
+
+		case "AAAA":
+			if props.AaaaRecords != nil {
+				for _, r := range *props.AaaaRecords {
+					if r.Ipv6Address != nil {
+						rs.Values = append(rs.Values, *r.Ipv6Address)
+					}
+				}
+			}
+
+		case "CAA":
+			if props.CaaRecords != nil {
+				for _, r := range *props.CaaRecords {
+					record := CaaRecord{}
+					if r.Flags != nil {
+						record.Flags = int(*r.Flags)
+					}
+					if r.Tag != nil {
+						record.Tag = *r.Tag
+					}
+					if r.Value != nil {
+						record.Value = *r.Value
+					}
+					rs.CAA = append(rs.CAA, record)
+				}
+			}
+
+		case "CNAME":
+			if props.CnameRecord != nil && props.CnameRecord.Cname != nil {
+				rs.Values = append(rs.Values, *props.CnameRecord.Cname)
+			}
+
+		case "MX":
+			if props.MxRecords != nil {
+				for _, r := range *props.MxRecords {
+					record := MxRecord{}
+					if r.Preference != nil {
+						record.Preference = int(*r.Preference)
+					}
+					if r.Exchange != nil {
+						record.Exchange = *r.Exchange
+					}
+					rs.MX = append(rs.MX, record)
+				}
+			}
+
+		case "NS":
+			if props.NsRecords != nil {
+				for _, r := range *props.NsRecords {
+					if r.Nsdname != nil {
+						rs.Values = append(rs.Values, *r.Nsdname)
+					}
+				}
+			}
+
+		case "PTR":
+			if props.PtrRecords != nil {
+				for _, r := range *props.PtrRecords {
+					if r.Ptrdname != nil {
+						rs.Values = append(rs.Values, *r.Ptrdname)
+					}
+				}
+			}
+
+		case "SOA":
+			if r := props.SoaRecord; r != nil {
+				record := SoaRecord{}
+				if r.Host != nil {
+					record.Host = *r.Host
+				}
+				if r.Email != nil {
+					record.Email = *r.Email
+				}
+				if r.SerialNumber != nil {
+					record.SerialNumber = *r.SerialNumber
+				}
+				if r.RefreshTime != nil {
+					record.RefreshTime = *r.RefreshTime
+				}
+				if r.RetryTime != nil {
+					record.RetryTime = *r.RetryTime
+				}
+				if r.ExpireTime != nil {
+					record.ExpireTime = *r.ExpireTime
+				}
+				if r.MinimumTTL != nil {
+					record.MinimumTTL = *r.MinimumTTL
+				}
+				rs.SOA = &record
+			}
+
+		case "SRV":
+			if props.SrvRecords != nil {
+				for _, r := range *props.SrvRecords {
+					record := SrvRecord{}
+					if r.Priority != nil {
+						record.Priority = int(*r.Priority)
+					}
+					if r.Weight != nil {
+						record.Weight = int(*r.Weight)
+					}
+					if r.Port != nil {
+						record.Port = int(*r.Port)
+					}
+					if r.Target != nil {
+						record.Target = *r.Target
+					}
+					rs.SRV = append(rs.SRV, record)
+				}
+			}
+
+		case "TXT":
+			if props.TxtRecords != nil {
+				for _, r := range *props.TxtRecords {
+					if r.Value != nil {
+						rs.TXT = append(rs.TXT, *r.Value)
+					}
+				}
+			}
+
+		default:
+			continue
+		}
+
+		if len(rs.Values) == 0 && len(rs.CAA) == 0 && len(rs.MX) == 0 && rs.SOA == nil && len(rs.SRV) == 0 && len(rs.TXT) == 0 {
+			continue
+		}
+
+		output.RecordSets = append(output.RecordSets, rs)
+	}
+
+	return output
+}
@@ -0,0 +1,114 @@
+package zonefile
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/dns/mgmt/2018-05-01/dns"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+func TestFromRecordSets(t *testing.T) {
+	input := []dns.RecordSet{
+		{
+			Name: utils.String("@"),
+			Type: utils.String("Microsoft.Network/dnszones/SOA"),
+			RecordSetProperties: &dns.RecordSetProperties{
+				TTL: utils.Int64(3600),
+				SoaRecord: &dns.SoaRecord{
+					Host:         utils.String("ns1-01.azure-dns.com."),
+					Email:        utils.String("azuredns-hostmaster.microsoft.com"),
+					SerialNumber: utils.Int64(1),
+					RefreshTime:  utils.Int64(3600),
+					RetryTime:    utils.Int64(300),
+					ExpireTime:   utils.Int64(2419200),
+					MinimumTTL:   utils.Int64(300),
+				},
+			},
+		},
+		{
+			Name: utils.String("www"),
+			Type: utils.String("Microsoft.Network/dnszones/A"),
+			RecordSetProperties: &dns.RecordSetProperties{
+				TTL: utils.Int64(300),
+				ARecords: &[]dns.ARecord{
+					{Ipv4Address: utils.String("10.0.0.1")},
+				},
+			},
+		},
+		{
+			// alias record sets have no records of their own
+			Name: utils.String("alias"),
+			Type: utils.String("Microsoft.Network/dnszones/A"),
+			RecordSetProperties: &dns.RecordSetProperties{
+				TTL: utils.Int64(300),
+				TargetResource: &dns.SubResource{
+					ID: utils.String("/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.Network/publicIPAddresses/ip1"),
+				},
+			},
+		},
+		{
+			Name: utils.String("@"),
+			Type: utils.String("Microsoft.Network/dnszones/MX"),
+			RecordSetProperties: &dns.RecordSetProperties{
+				TTL: utils.Int64(3600),
+				MxRecords: &[]dns.MxRecord{
+					{Preference: utils.Int32(10), Exchange: utils.String("mail.example.com")},
+				},
+			},
+		},
+		{
+			Name: utils.String("txt"),
+			Type: utils.String("Microsoft.Network/dnszones/TXT"),
+			RecordSetProperties: &dns.RecordSetProperties{
+				TTL: utils.Int64(60),
+				TxtRecords: &[]dns.TxtRecord{
+					{Value: &[]string{"first", "second"}},
+				},
+			},
+		},
+	}
+
+	expected := ZoneFile{
+		Origin: "example.com.",
+		RecordSets: []RecordSet{
+			{
+				Name: "@",
+				Type: "SOA",
+				TTL:  3600,
+				SOA: &SoaRecord{
+					Host:         "ns1-01.azure-dns.com.",
+					Email:        "azuredns-hostmaster.microsoft.com",
+					SerialNumber: 1,
+					RefreshTime:  3600,
+					RetryTime:    300,
+					ExpireTime:   2419200,
+					MinimumTTL:   300,
+				},
+			},
+			{
+				Name:   "www",
+				Type:   "A",
+				TTL:    300,
+				Values: []string{"10.0.0.1"},
+			},
+			{
+				Name: "@",
+				Type: "MX",
+				TTL:  3600,
+				MX:   []MxRecord{{Preference: 10, Exchange: "mail.example.com"}},
+			},
+			{
+				Name: "txt",
+				Type: "TXT",
+				TTL:  60,
+				TXT:  [][]string{{"first", "second"}},
+			},
+		},
+	}
+
+	actual := FromRecordSets("example.com", input)
+	if !reflect.DeepEqual(expected, actual) {
+		t.Fatalf("Expected %+v but got %+v", expected, actual)
+	}
+}
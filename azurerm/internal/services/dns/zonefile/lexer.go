@@ -0,0 +1,155 @@
+package zonefile
+
+import (
+	"fmt"
+	"strings"
+)
+
+// token is a single field within an entry, quoted tokens are tracked since
+// they can't be mistaken for a directive, TTL or class
+type token struct {
+	value  string
+	quoted bool
+}
+
+// entry is a single logical line of a Zone File, which may span multiple
+// physical lines when parentheses are used
+type entry struct {
+	line int
+
+	// blankOwner is set when the entry starts with whitespace, meaning the
+	// owner name of the previous entry should be used
+	blankOwner bool
+
+	tokens []token
+}
+
+// lex splits the Zone File into entries, stripping comments and handling
+// parentheses, quoted strings and escape sequences as described in RFC 1035 section 5.1
+func lex(input string) ([]entry, error) {
+	entries := make([]entry, 0)
+
+	line := 1
+	depth := 0
+	inQuote := false
+	inToken := false
+	var value strings.Builder
+
+	current := entry{line: line}
+	startOfLine := true
+
+	endToken := func(quoted bool) {
+		if inToken {
+			current.tokens = append(current.tokens, token{
+				value:  value.String(),
+				quoted: quoted,
+			})
+		}
+		value.Reset()
+		inToken = false
+	}
+
+	endEntry := func() {
+		if len(current.tokens) > 0 {
+			entries = append(entries, current)
+		}
+		current = entry{line: line}
+	}
+
+	runes := []rune(input)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+
+		if startOfLine && depth == 0 {
+			current.blankOwner = r == ' ' || r == '\t'
+		}
+		startOfLine = false
+
+		switch {
+		case r == '\\':
+			if i+1 >= len(runes) {
+				return nil, fmt.Errorf("line %d: incomplete escape sequence", line)
+			}
+
+			// `\DDD` is the octet with the decimal value DDD, otherwise the escaped character is taken literally
+			if i+3 < len(runes) && isDigit(runes[i+1]) && isDigit(runes[i+2]) && isDigit(runes[i+3]) {
+				octet := int(runes[i+1]-'0')*100 + int(runes[i+2]-'0')*10 + int(runes[i+3]-'0')
+				if octet > 255 {
+					return nil, fmt.Errorf("line %d: escape sequence `\\%s` is out of range", line, string(runes[i+1:i+4]))
+				}
+				value.WriteByte(byte(octet))
+				i += 3
+			} else {
+				value.WriteRune(runes[i+1])
+				if runes[i+1] == '\n' {
+					line++
+				}
+				i++
+			}
+			inToken = true
+
+		case inQuote:
+			if r == '"' {
+				inQuote = false
+				endToken(true)
+				continue
+			}
+			if r == '\n' {
+				line++
+			}
+			value.WriteRune(r)
+
+		case r == '"':
+			endToken(false)
+			inQuote = true
+			inToken = true
+
+		case r == ';':
+			for i+1 < len(runes) && runes[i+1] != '\n' {
+				i++
+			}
+
+		case r == '(':
+			endToken(false)
+			depth++
+
+		case r == ')':
+			endToken(false)
+			depth--
+			if depth < 0 {
+				return nil, fmt.Errorf("line %d: unexpected `)`", line)
+			}
+
+		case r == '\n':
+			endToken(false)
+			line++
+			startOfLine = true
+			if depth == 0 {
+				endEntry()
+			}
+
+		case r == ' ' || r == '\t' || r == '\r':
+			endToken(false)
+
+		default:
+			value.WriteRune(r)
+			inToken = true
+		}
+	}
+
+	if inQuote {
+		return nil, fmt.Errorf("line %d: unterminated quoted string", line)
+	}
+	if depth > 0 {
+		return nil, fmt.Errorf("line %d: unbalanced parentheses, expected `)`", line)
+	}
+
+	endToken(false)
+	endEntry()
+
+	return entries, nil
+}
+
+func isDigit(r rune) bool {
+	return r >= '0' && r <= '9'
+}
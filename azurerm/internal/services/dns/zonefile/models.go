@@ -0,0 +1,76 @@
+package zonefile
+
+// ZoneFile is the parsed representation of an RFC 1035 Zone File
+type ZoneFile struct {
+	// Origin is the fully qualified name of the Zone, including the trailing `.`
+	Origin string
+
+	// RecordSets are the Record Sets within this Zone, in the order they were first defined
+	RecordSets []RecordSet
+}
+
+// RecordSet groups the Records of a single type sharing the same name, as a DNS Zone in Azure does
+type RecordSet struct {
+	// Name is the name of this Record Set relative to the Zone, `@` represents the apex of the Zone
+	Name string
+
+	// Type is the upper-case type of the Records within this Record Set, e.g. `A` or `MX`
+	Type string
+
+	// TTL is the Time To Live of this Record Set in seconds
+	TTL int64
+
+	// Values contains the Records for the `A`, `AAAA`, `CNAME`, `NS` and `PTR` types.
+	// Domain names are fully qualified without the trailing `.`, to match the Azure API.
+	Values []string
+
+	CAA []CaaRecord
+	MX  []MxRecord
+	SOA *SoaRecord
+	SRV []SrvRecord
+
+	// TXT contains one entry per TXT Record, each of which is made up of one or more character strings
+	TXT [][]string
+}
+
+type CaaRecord struct {
+	Flags int
+	Tag   string
+	Value string
+}
+
+type MxRecord struct {
+	Preference int
+	Exchange   string
+}
+
+type SoaRecord struct {
+	Host         string
+	Email        string
+	SerialNumber int64
+	RefreshTime  int64
+	RetryTime    int64
+	ExpireTime   int64
+	MinimumTTL   int64
+}
+
+type SrvRecord struct {
+	Priority int
+	Weight   int
+	Port     int
+	Target   string
+}
+
+// SupportedRecordTypes are the Record Types which can be parsed and rendered, which are those supported by Azure DNS
+var SupportedRecordTypes = []string{
+	"SOA",
+	"NS",
+	"A",
+	"AAAA",
+	"CAA",
+	"CNAME",
+	"MX",
+	"PTR",
+	"SRV",
+	"TXT",
+}
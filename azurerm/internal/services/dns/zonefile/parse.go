@@ -0,0 +1,463 @@
+package zonefile
+
+import (
+	"fmt"
+	"math"
+	"net"
+	"strconv"
+	"strings"
+)
+
+type parser struct {
+	// zone is the fully qualified, lower-cased name of the Zone which all Records must belong to
+	zone string
+
+	origin string
+
+	// directiveTTL is the TTL specified using the `$TTL` directive
+	directiveTTL *int64
+
+	// lastTTL is the last TTL specified explicitly on a Record, which applies to
+	// subsequent Records without a TTL when no `$TTL` directive has been specified
+	lastTTL *int64
+
+	defaultTTL int64
+
+	lastOwner string
+
+	recordSets []*RecordSet
+	index      map[string]*RecordSet
+}
+
+// Parse parses the RFC 1035 Zone File `input` for the Zone `zoneName`, grouping the Records into Record Sets.
+// `defaultTTL` is used for Records which don't specify a TTL when neither a `$TTL` directive nor an earlier
+// Record specifies one.
+func Parse(input string, zoneName string, defaultTTL int64) (*ZoneFile, error) {
+	zone := strings.ToLower(strings.TrimSuffix(zoneName, ".")) + "."
+	if zone == "." {
+		return nil, fmt.Errorf("a zone name must be specified")
+	}
+
+	entries, err := lex(input)
+	if err != nil {
+		return nil, err
+	}
+
+	p := parser{
+		zone:       zone,
+		origin:     zone,
+		defaultTTL: defaultTTL,
+		recordSets: make([]*RecordSet, 0),
+		index:      make(map[string]*RecordSet),
+	}
+
+	for _, e := range entries {
+		if !e.blankOwner && !e.tokens[0].quoted && strings.HasPrefix(e.tokens[0].value, "$") {
+			if err := p.parseDirective(e); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		if err := p.parseRecord(e); err != nil {
+			return nil, err
+		}
+	}
+
+	result := ZoneFile{
+		Origin:     zone,
+		RecordSets: make([]RecordSet, 0, len(p.recordSets)),
+	}
+
+	// a CNAME can't coexist with other data at the same name
+	types := make(map[string][]string)
+	for _, rs := range p.recordSets {
+		key := strings.ToLower(rs.Name)
+		types[key] = append(types[key], rs.Type)
+	}
+	for _, rs := range p.recordSets {
+		if rs.Type == "CNAME" && len(types[strings.ToLower(rs.Name)]) > 1 {
+			return nil, fmt.Errorf("%q has a CNAME record in addition to other records, which isn't allowed", rs.Name)
+		}
+
+		result.RecordSets = append(result.RecordSets, *rs)
+	}
+
+	return &result, nil
+}
+
+func (p *parser) parseDirective(e entry) error {
+	directive := strings.ToUpper(e.tokens[0].value)
+	args := e.tokens[1:]
+
+	switch directive {
+	case "$ORIGIN":
+		if len(args) != 1 {
+			return fmt.Errorf("line %d: `$ORIGIN` expects a single domain name but got %d values", e.line, len(args))
+		}
+		origin := args[0].value
+		if !strings.HasSuffix(origin, ".") {
+			origin = origin + "." + p.origin
+		}
+		p.origin = origin
+
+	case "$TTL":
+		if len(args) != 1 {
+			return fmt.Errorf("line %d: `$TTL` expects a single TTL but got %d values", e.line, len(args))
+		}
+		ttl, err := parseTTL(args[0].value)
+		if err != nil {
+			return fmt.Errorf("line %d: parsing `$TTL`: %+v", e.line, err)
+		}
+		p.directiveTTL = &ttl
+
+	default:
+		return fmt.Errorf("line %d: the `%s` directive is not supported", e.line, e.tokens[0].value)
+	}
+
+	return nil
+}
+
+func (p *parser) parseRecord(e entry) error {
+	tokens := e.tokens
+
+	owner := p.lastOwner
+	if !e.blankOwner {
+		owner = p.absolute(tokens[0].value)
+		tokens = tokens[1:]
+	}
+	if owner == "" {
+		return fmt.Errorf("line %d: the first record must specify an owner name", e.line)
+	}
+	p.lastOwner = owner
+
+	name, err := p.relativeName(owner)
+	if err != nil {
+		return fmt.Errorf("line %d: %+v", e.line, err)
+	}
+
+	// the TTL and class are both optional and can be specified in either order
+	var ttl *int64
+	for i := 0; i < 2 && len(tokens) > 0 && !tokens[0].quoted; i++ {
+		v := tokens[0].value
+		if isClass(v) {
+			if !strings.EqualFold(v, "IN") {
+				return fmt.Errorf("line %d: only the `IN` class is supported but got %q", e.line, v)
+			}
+			tokens = tokens[1:]
+			continue
+		}
+
+		if ttl == nil {
+			if parsed, err := parseTTL(v); err == nil {
+				ttl = &parsed
+				tokens = tokens[1:]
+				continue
+			}
+		}
+
+		break
+	}
+
+	if len(tokens) == 0 {
+		return fmt.Errorf("line %d: expected a record type for %q", e.line, owner)
+	}
+
+	recordType := strings.ToUpper(tokens[0].value)
+	rdata := tokens[1:]
+
+	if ttl != nil {
+		p.lastTTL = ttl
+	} else {
+		switch {
+		case p.directiveTTL != nil:
+			ttl = p.directiveTTL
+		case p.lastTTL != nil:
+			ttl = p.lastTTL
+		default:
+			ttl = &p.defaultTTL
+		}
+	}
+
+	rs, err := p.recordSet(name, recordType, *ttl)
+	if err != nil {
+		return fmt.Errorf("line %d: %+v", e.line, err)
+	}
+
+	if err := p.parseRData(rs, rdata); err != nil {
+		return fmt.Errorf("line %d: parsing %s record for %q: %+v", e.line, recordType, owner, err)
+	}
+
+	return nil
+}
+
+// recordSet returns the Record Set for the specified name and type, creating it if it doesn't exist
+func (p *parser) recordSet(name, recordType string, ttl int64) (*RecordSet, error) {
+	supported := false
+	for _, v := range SupportedRecordTypes {
+		if v == recordType {
+			supported = true
+			break
+		}
+	}
+	if !supported {
+		return nil, fmt.Errorf("the record type %q is not supported by Azure DNS", recordType)
+	}
+
+	key := strings.ToLower(name) + "|" + recordType
+	if rs, ok := p.index[key]; ok {
+		switch recordType {
+		case "CNAME", "SOA":
+			return nil, fmt.Errorf("only a single %s record can exist for %q", recordType, name)
+		}
+
+		// RFC 2181 section 5.2 - differing TTLs within a Record Set are treated as the lowest of them
+		if ttl < rs.TTL {
+			rs.TTL = ttl
+		}
+		return rs, nil
+	}
+
+	if recordType == "SOA" && name != "@" {
+		return nil, fmt.Errorf("a SOA record can only exist at the apex of the zone but got %q", name)
+	}
+
+	rs := &RecordSet{
+		Name: name,
+		Type: recordType,
+		TTL:  ttl,
+	}
+	p.index[key] = rs
+	p.recordSets = append(p.recordSets, rs)
+	return rs, nil
+}
+
+func (p *parser) parseRData(rs *RecordSet, rdata []token) error {
+	expectFields := func(count int) error {
+		if len(rdata) != count {
+			return fmt.Errorf("expected %d fields but got %d", count, len(rdata))
+		}
+		return nil
+	}
+
+	switch rs.Type {
+	case "A":
+		if err := expectFields(1); err != nil {
+			return err
+		}
+		ip := net.ParseIP(rdata[0].value)
+		if ip == nil || ip.To4() == nil || strings.Contains(rdata[0].value, ":") {
+			return fmt.Errorf("%q is not a valid IPv4 address", rdata[0].value)
+		}
+		rs.Values = append(rs.Values, rdata[0].value)
+
+	case "AAAA":
+		if err := expectFields(1); err != nil {
+			return err
+		}
+		if ip := net.ParseIP(rdata[0].value); ip == nil || !strings.Contains(rdata[0].value, ":") {
+			return fmt.Errorf("%q is not a valid IPv6 address", rdata[0].value)
+		}
+		rs.Values = append(rs.Values, rdata[0].value)
+
+	case "CNAME", "NS", "PTR":
+		if err := expectFields(1); err != nil {
+			return err
+		}
+		rs.Values = append(rs.Values, p.domainName(rdata[0].value))
+
+	case "MX":
+		if err := expectFields(2); err != nil {
+			return err
+		}
+		preference, err := parseUint(rdata[0].value, math.MaxUint16)
+		if err != nil {
+			return fmt.Errorf("parsing preference: %+v", err)
+		}
+		rs.MX = append(rs.MX, MxRecord{
+			Preference: int(preference),
+			Exchange:   p.domainName(rdata[1].value),
+		})
+
+	case "SRV":
+		if err := expectFields(4); err != nil {
+			return err
+		}
+		fields := make([]int, 3)
+		for i, field := range []string{"priority", "weight", "port"} {
+			v, err := parseUint(rdata[i].value, math.MaxUint16)
+			if err != nil {
+				return fmt.Errorf("parsing %s: %+v", field, err)
+			}
+			fields[i] = int(v)
+		}
+		rs.SRV = append(rs.SRV, SrvRecord{
+			Priority: fields[0],
+			Weight:   fields[1],
+			Port:     fields[2],
+			Target:   p.domainName(rdata[3].value),
+		})
+
+	case "CAA":
+		if err := expectFields(3); err != nil {
+			return err
+		}
+		flags, err := parseUint(rdata[0].value, math.MaxUint8)
+		if err != nil {
+			return fmt.Errorf("parsing flags: %+v", err)
+		}
+		rs.CAA = append(rs.CAA, CaaRecord{
+			Flags: int(flags),
+			Tag:   rdata[1].value,
+			Value: rdata[2].value,
+		})
+
+	case "TXT":
+		if len(rdata) == 0 {
+			return fmt.Errorf("expected at least one character string")
+		}
+		values := make([]string, 0, len(rdata))
+		for _, v := range rdata {
+			if len(v.value) > 255 {
+				return fmt.Errorf("character strings can be at most 255 characters but got %d", len(v.value))
+			}
+			values = append(values, v.value)
+		}
+		rs.TXT = append(rs.TXT, values)
+
+	case "SOA":
+		if err := expectFields(7); err != nil {
+			return err
+		}
+		serial, err := parseUint(rdata[2].value, math.MaxUint32)
+		if err != nil {
+			return fmt.Errorf("parsing serial: %+v", err)
+		}
+		times := make([]int64, 4)
+		for i, field := range []string{"refresh", "retry", "expire", "minimum"} {
+			v, err := parseTTL(rdata[3+i].value)
+			if err != nil {
+				return fmt.Errorf("parsing %s: %+v", field, err)
+			}
+			times[i] = v
+		}
+		rs.SOA = &SoaRecord{
+			Host:         p.domainName(rdata[0].value),
+			Email:        p.domainName(rdata[1].value),
+			SerialNumber: serial,
+			RefreshTime:  times[0],
+			RetryTime:    times[1],
+			ExpireTime:   times[2],
+			MinimumTTL:   times[3],
+		}
+	}
+
+	return nil
+}
+
+// absolute returns the fully qualified form of the name, including the trailing `.`
+func (p *parser) absolute(name string) string {
+	if name == "@" {
+		return p.origin
+	}
+	if strings.HasSuffix(name, ".") {
+		return name
+	}
+	return name + "." + p.origin
+}
+
+// domainName returns the fully qualified form of a domain name used within RDATA,
+// without the trailing `.` since that's how the Azure API represents these
+func (p *parser) domainName(name string) string {
+	if name == "." {
+		return name
+	}
+	return strings.TrimSuffix(p.absolute(name), ".")
+}
+
+// relativeName returns the name of the Record Set relative to the Zone
+func (p *parser) relativeName(fqdn string) (string, error) {
+	lower := strings.ToLower(fqdn)
+	if lower == p.zone {
+		return "@", nil
+	}
+
+	if strings.HasSuffix(lower, "."+p.zone) {
+		return fqdn[:len(fqdn)-len(p.zone)-1], nil
+	}
+
+	return "", fmt.Errorf("%q is outside of the zone %q", fqdn, p.zone)
+}
+
+func isClass(input string) bool {
+	switch strings.ToUpper(input) {
+	case "IN", "CH", "CS", "HS":
+		return true
+	}
+	return false
+}
+
+func parseUint(input string, max uint64) (int64, error) {
+	v, err := strconv.ParseUint(input, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%q is not a valid number", input)
+	}
+	if v > max {
+		return 0, fmt.Errorf("%d exceeds the maximum of %d", v, max)
+	}
+	return int64(v), nil
+}
+
+// parseTTL parses a TTL either in seconds or using the BIND unit format, e.g. `1h30m` or `1W`
+func parseTTL(input string) (int64, error) {
+	if input == "" || !isDigit(rune(input[0])) {
+		return 0, fmt.Errorf("%q is not a valid TTL", input)
+	}
+
+	if v, err := strconv.ParseInt(input, 10, 64); err == nil {
+		if v > math.MaxInt32 {
+			return 0, fmt.Errorf("%q exceeds the maximum TTL of %d", input, math.MaxInt32)
+		}
+		return v, nil
+	}
+
+	units := map[byte]int64{
+		's': 1,
+		'm': 60,
+		'h': 60 * 60,
+		'd': 24 * 60 * 60,
+		'w': 7 * 24 * 60 * 60,
+	}
+
+	total := int64(0)
+	number := int64(0)
+	hasNumber := false
+	for i := 0; i < len(input); i++ {
+		c := input[i]
+		if isDigit(rune(c)) {
+			number = number*10 + int64(c-'0')
+			hasNumber = true
+			if number > math.MaxInt32 {
+				return 0, fmt.Errorf("%q exceeds the maximum TTL of %d", input, math.MaxInt32)
+			}
+			continue
+		}
+
+		multiplier, ok := units[c|0x20]
+		if !ok || !hasNumber {
+			return 0, fmt.Errorf("%q is not a valid TTL", input)
+		}
+		total += number * multiplier
+		number = 0
+		hasNumber = false
+	}
+
+	if hasNumber {
+		return 0, fmt.Errorf("%q is not a valid TTL, the final value must have a unit", input)
+	}
+	if total > math.MaxInt32 {
+		return 0, fmt.Errorf("%q exceeds the maximum TTL of %d", input, math.MaxInt32)
+	}
+
+	return total, nil
+}
@@ -0,0 +1,353 @@
+package zonefile
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	testData := []struct {
+		Name     string
+		Input    string
+		Expected *ZoneFile
+		Error    bool
+	}{
+		{
+			Name:  "Empty",
+			Input: "",
+			Expected: &ZoneFile{
+				Origin:     "example.com.",
+				RecordSets: []RecordSet{},
+			},
+		},
+		{
+			Name: "Comments Only",
+			Input: `
+; this zone is intentionally empty
+   ; indented comment
+`,
+			Expected: &ZoneFile{
+				Origin:     "example.com.",
+				RecordSets: []RecordSet{},
+			},
+		},
+		{
+			Name: "Full Zone",
+			Input: `$ORIGIN example.com.
+$TTL 1h
+@       IN SOA ns1.example.com. hostmaster.example.com. (
+            2021070101 ; serial
+            3600       ; refresh
+            300        ; retry
+            2419200    ; expire
+            300 )      ; minimum
+        IN NS  ns1
+        IN NS  ns2.example.net.
+        IN MX  10 mail
+        IN MX  20 mail.example.net.
+www  300 IN A  10.0.0.1
+     IN 300 A  10.0.0.2
+        IN AAAA 2001:db8::1
+ftp     IN CNAME www
+_sip._tcp IN SRV 10 60 5060 sip.example.com.
+@       IN TXT "v=spf1 include:example.net -all"
+txt     IN TXT "first part " "second part"
+        IN TXT unquoted
+@       IN CAA 0 issue "letsencrypt.org"
+`,
+			Expected: &ZoneFile{
+				Origin: "example.com.",
+				RecordSets: []RecordSet{
+					{
+						Name: "@",
+						Type: "SOA",
+						TTL:  3600,
+						SOA: &SoaRecord{
+							Host:         "ns1.example.com",
+							Email:        "hostmaster.example.com",
+							SerialNumber: 2021070101,
+							RefreshTime:  3600,
+							RetryTime:    300,
+							ExpireTime:   2419200,
+							MinimumTTL:   300,
+						},
+					},
+					{
+						Name:   "@",
+						Type:   "NS",
+						TTL:    3600,
+						Values: []string{"ns1.example.com", "ns2.example.net"},
+					},
+					{
+						Name: "@",
+						Type: "MX",
+						TTL:  3600,
+						MX: []MxRecord{
+							{Preference: 10, Exchange: "mail.example.com"},
+							{Preference: 20, Exchange: "mail.example.net"},
+						},
+					},
+					{
+						Name:   "www",
+						Type:   "A",
+						TTL:    300,
+						Values: []string{"10.0.0.1", "10.0.0.2"},
+					},
+					{
+						Name:   "www",
+						Type:   "AAAA",
+						TTL:    3600,
+						Values: []string{"2001:db8::1"},
+					},
+					{
+						Name:   "ftp",
+						Type:   "CNAME",
+						TTL:    3600,
+						Values: []string{"www.example.com"},
+					},
+					{
+						Name: "_sip._tcp",
+						Type: "SRV",
+						TTL:  3600,
+						SRV: []SrvRecord{
+							{Priority: 10, Weight: 60, Port: 5060, Target: "sip.example.com"},
+						},
+					},
+					{
+						Name: "@",
+						Type: "TXT",
+						TTL:  3600,
+						TXT:  [][]string{{"v=spf1 include:example.net -all"}},
+					},
+					{
+						Name: "txt",
+						Type: "TXT",
+						TTL:  3600,
+						TXT:  [][]string{{"first part ", "second part"}, {"unquoted"}},
+					},
+					{
+						Name: "@",
+						Type: "CAA",
+						TTL:  3600,
+						CAA: []CaaRecord{
+							{Flags: 0, Tag: "issue", Value: "letsencrypt.org"},
+						},
+					},
+				},
+			},
+		},
+		{
+			Name: "Relative Origin And Default TTL",
+			Input: `$ORIGIN sub
+www A 10.0.0.1
+$ORIGIN example.com.
+mail 60 A 10.0.0.2
+other A 10.0.0.3
+`,
+			Expected: &ZoneFile{
+				Origin: "example.com.",
+				RecordSets: []RecordSet{
+					{
+						Name:   "www.sub",
+						Type:   "A",
+						TTL:    3600,
+						Values: []string{"10.0.0.1"},
+					},
+					{
+						Name:   "mail",
+						Type:   "A",
+						TTL:    60,
+						Values: []string{"10.0.0.2"},
+					},
+					{
+						// without a `$TTL` the last explicit TTL is used
+						Name:   "other",
+						Type:   "A",
+						TTL:    60,
+						Values: []string{"10.0.0.3"},
+					},
+				},
+			},
+		},
+		{
+			Name: "Differing TTLs Use The Lowest",
+			Input: `www 300 A 10.0.0.1
+www 60 A 10.0.0.2
+`,
+			Expected: &ZoneFile{
+				Origin: "example.com.",
+				RecordSets: []RecordSet{
+					{
+						Name:   "www",
+						Type:   "A",
+						TTL:    60,
+						Values: []string{"10.0.0.1", "10.0.0.2"},
+					},
+				},
+			},
+		},
+		{
+			Name:  "Escaped TXT",
+			Input: `@ TXT "say \"hello\"" "semi\;colon" "\065\066"`,
+			Expected: &ZoneFile{
+				Origin: "example.com.",
+				RecordSets: []RecordSet{
+					{
+						Name: "@",
+						Type: "TXT",
+						TTL:  3600,
+						TXT:  [][]string{{`say "hello"`, "semi;colon", "AB"}},
+					},
+				},
+			},
+		},
+		{
+			Name:  "Case Insensitive Zone Name",
+			Input: "WWW.EXAMPLE.COM. A 10.0.0.1",
+			Expected: &ZoneFile{
+				Origin: "example.com.",
+				RecordSets: []RecordSet{
+					{
+						Name:   "WWW",
+						Type:   "A",
+						TTL:    3600,
+						Values: []string{"10.0.0.1"},
+					},
+				},
+			},
+		},
+		{
+			Name:  "Outside Of Zone",
+			Input: "www.example.net. A 10.0.0.1",
+			Error: true,
+		},
+		{
+			Name:  "Blank Owner On First Record",
+			Input: "   A 10.0.0.1",
+			Error: true,
+		},
+		{
+			Name:  "Unsupported Record Type",
+			Input: "@ DNSKEY 256 3 8 AwEAAa==",
+			Error: true,
+		},
+		{
+			Name:  "Unsupported Class",
+			Input: "@ CH A 10.0.0.1",
+			Error: true,
+		},
+		{
+			Name:  "Unsupported Directive",
+			Input: "$INCLUDE other.zone",
+			Error: true,
+		},
+		{
+			Name:  "Invalid IPv4 Address",
+			Input: "www A 2001:db8::1",
+			Error: true,
+		},
+		{
+			Name:  "Invalid IPv6 Address",
+			Input: "www AAAA 10.0.0.1",
+			Error: true,
+		},
+		{
+			Name:  "MX Preference Out Of Range",
+			Input: "@ MX 65536 mail",
+			Error: true,
+		},
+		{
+			Name:  "Missing SRV Fields",
+			Input: "_sip._tcp SRV 10 60 sip",
+			Error: true,
+		},
+		{
+			Name: "Multiple CNAME Records",
+			Input: `www CNAME a
+www CNAME b`,
+			Error: true,
+		},
+		{
+			Name: "CNAME Alongside Other Records",
+			Input: `www CNAME a
+www A 10.0.0.1`,
+			Error: true,
+		},
+		{
+			Name:  "SOA Not At The Apex",
+			Input: "www SOA ns1 hostmaster 1 2 3 4 5",
+			Error: true,
+		},
+		{
+			Name:  "Unterminated Quote",
+			Input: `@ TXT "hello`,
+			Error: true,
+		},
+		{
+			Name:  "Unbalanced Parentheses",
+			Input: "@ SOA ns1 hostmaster ( 1 2 3 4 5",
+			Error: true,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Name)
+
+		actual, err := Parse(v.Input, "example.com", 3600)
+		if err != nil {
+			if v.Error {
+				continue
+			}
+
+			t.Fatalf("Expected no error but got: %+v", err)
+		}
+
+		if v.Error {
+			t.Fatalf("Expected an error but didn't get one")
+		}
+
+		if !reflect.DeepEqual(*v.Expected, *actual) {
+			t.Fatalf("Expected %+v but got %+v", *v.Expected, *actual)
+		}
+	}
+}
+
+func TestParseTTL(t *testing.T) {
+	testData := []struct {
+		Input    string
+		Expected int64
+		Error    bool
+	}{
+		{Input: "", Error: true},
+		{Input: "0", Expected: 0},
+		{Input: "3600", Expected: 3600},
+		{Input: "1h", Expected: 3600},
+		{Input: "1H30M", Expected: 5400},
+		{Input: "1w2d", Expected: 777600},
+		{Input: "1h30", Error: true},
+		{Input: "h", Error: true},
+		{Input: "1y", Error: true},
+		{Input: "2147483648", Error: true},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Input)
+
+		actual, err := parseTTL(v.Input)
+		if err != nil {
+			if v.Error {
+				continue
+			}
+
+			t.Fatalf("Expected no error but got: %+v", err)
+		}
+
+		if v.Error {
+			t.Fatalf("Expected an error but didn't get one")
+		}
+
+		if actual != v.Expected {
+			t.Fatalf("Expected %d but got %d", v.Expected, actual)
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package zonefile
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Render renders the Zone as an RFC 1035 Zone File. Record Sets are ordered with the apex of
+// the Zone first and then by name and type, so that the output is stable for the same Records.
+func Render(zone ZoneFile) string {
+	origin := zone.Origin
+	if !strings.HasSuffix(origin, ".") {
+		origin += "."
+	}
+
+	recordSets := make([]RecordSet, len(zone.RecordSets))
+	copy(recordSets, zone.RecordSets)
+	sort.SliceStable(recordSets, func(i, j int) bool {
+		a, b := recordSets[i], recordSets[j]
+		if a.Name != b.Name {
+			if a.Name == "@" || b.Name == "@" {
+				return a.Name == "@"
+			}
+			return strings.ToLower(a.Name) < strings.ToLower(b.Name)
+		}
+		return recordTypeOrder(a.Type) < recordTypeOrder(b.Type)
+	})
+
+	var out strings.Builder
+	out.WriteString(fmt.Sprintf("$ORIGIN %s\n", origin))
+
+	for _, rs := range recordSets {
+		for _, rdata := range renderRData(rs) {
+			out.WriteString(fmt.Sprintf("%s\t%d\tIN\t%s\t%s\n", rs.Name, rs.TTL, rs.Type, rdata))
+		}
+	}
+
+	return out.String()
+}
+
+func renderRData(rs RecordSet) []string {
+	results := make([]string, 0)
+
+	switch rs.Type {
+	case "A", "AAAA":
+		results = append(results, rs.Values...)
+
+	case "CNAME", "NS", "PTR":
+		for _, v := range rs.Values {
+			results = append(results, renderDomainName(v))
+		}
+
+	case "CAA":
+		for _, v := range rs.CAA {
+			results = append(results, fmt.Sprintf("%d %s %s", v.Flags, v.Tag, quote(v.Value)))
+		}
+
+	case "MX":
+		for _, v := range rs.MX {
+			results = append(results, fmt.Sprintf("%d %s", v.Preference, renderDomainName(v.Exchange)))
+		}
+
+	case "SOA":
+		if v := rs.SOA; v != nil {
+			results = append(results, fmt.Sprintf("%s %s %d %d %d %d %d", renderDomainName(v.Host), renderDomainName(v.Email), v.SerialNumber, v.RefreshTime, v.RetryTime, v.ExpireTime, v.MinimumTTL))
+		}
+
+	case "SRV":
+		for _, v := range rs.SRV {
+			results = append(results, fmt.Sprintf("%d %d %d %s", v.Priority, v.Weight, v.Port, renderDomainName(v.Target)))
+		}
+
+	case "TXT":
+		for _, strs := range rs.TXT {
+			quoted := make([]string, 0)
+			for _, s := range strs {
+				// a single character string is limited to 255 characters
+				for len(s) > 255 {
+					quoted = append(quoted, quote(s[:255]))
+					s = s[255:]
+				}
+				quoted = append(quoted, quote(s))
+			}
+			results = append(results, strings.Join(quoted, " "))
+		}
+	}
+
+	return results
+}
+
+func renderDomainName(input string) string {
+	if strings.HasSuffix(input, ".") {
+		return input
+	}
+	return input + "."
+}
+
+// quote returns the input as a quoted character string, escaping quotes,
+// backslashes and non-printable characters
+func quote(input string) string {
+	var out strings.Builder
+	out.WriteByte('"')
+	for i := 0; i < len(input); i++ {
+		c := input[i]
+		switch {
+		case c == '"' || c == '\\':
+			out.WriteByte('\\')
+			out.WriteByte(c)
+		case c < 0x20 || c == 0x7f:
+			out.WriteString(fmt.Sprintf("\\%03d", c))
+		default:
+			out.WriteByte(c)
+		}
+	}
+	out.WriteByte('"')
+	return out.String()
+}
+
+func recordTypeOrder(recordType string) int {
+	for i, v := range SupportedRecordTypes {
+		if v == recordType {
+			return i
+		}
+	}
+	return len(SupportedRecordTypes)
+}
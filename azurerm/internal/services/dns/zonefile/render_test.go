@@ -0,0 +1,152 @@
+package zonefile
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRender(t *testing.T) {
+	input := ZoneFile{
+		Origin: "example.com",
+		RecordSets: []RecordSet{
+			{
+				Name: "www",
+				Type: "TXT",
+				TTL:  300,
+				TXT:  [][]string{{`say "hello"`, "world"}},
+			},
+			{
+				Name:   "www",
+				Type:   "A",
+				TTL:    300,
+				Values: []string{"10.0.0.1", "10.0.0.2"},
+			},
+			{
+				Name: "@",
+				Type: "MX",
+				TTL:  3600,
+				MX:   []MxRecord{{Preference: 10, Exchange: "mail.example.com"}},
+			},
+			{
+				Name:   "@",
+				Type:   "NS",
+				TTL:    172800,
+				Values: []string{"ns1-01.azure-dns.com."},
+			},
+			{
+				Name: "@",
+				Type: "SOA",
+				TTL:  3600,
+				SOA: &SoaRecord{
+					Host:         "ns1-01.azure-dns.com.",
+					Email:        "azuredns-hostmaster.microsoft.com",
+					SerialNumber: 1,
+					RefreshTime:  3600,
+					RetryTime:    300,
+					ExpireTime:   2419200,
+					MinimumTTL:   300,
+				},
+			},
+			{
+				Name: "_sip._tcp",
+				Type: "SRV",
+				TTL:  60,
+				SRV:  []SrvRecord{{Priority: 10, Weight: 60, Port: 5060, Target: "sip.example.com"}},
+			},
+			{
+				Name: "@",
+				Type: "CAA",
+				TTL:  3600,
+				CAA:  []CaaRecord{{Flags: 0, Tag: "issue", Value: "letsencrypt.org"}},
+			},
+			{
+				Name:   "alias",
+				Type:   "CNAME",
+				TTL:    60,
+				Values: []string{"www.example.com"},
+			},
+		},
+	}
+
+	expected := strings.Join([]string{
+		"$ORIGIN example.com.",
+		"@\t3600\tIN\tSOA\tns1-01.azure-dns.com. azuredns-hostmaster.microsoft.com. 1 3600 300 2419200 300",
+		"@\t172800\tIN\tNS\tns1-01.azure-dns.com.",
+		"@\t3600\tIN\tCAA\t0 issue \"letsencrypt.org\"",
+		"@\t3600\tIN\tMX\t10 mail.example.com.",
+		"_sip._tcp\t60\tIN\tSRV\t10 60 5060 sip.example.com.",
+		"alias\t60\tIN\tCNAME\twww.example.com.",
+		"www\t300\tIN\tA\t10.0.0.1",
+		"www\t300\tIN\tA\t10.0.0.2",
+		"www\t300\tIN\tTXT\t\"say \\\"hello\\\"\" \"world\"",
+		"",
+	}, "\n")
+
+	actual := Render(input)
+	if actual != expected {
+		t.Fatalf("Expected:\n%s\nbut got:\n%s", expected, actual)
+	}
+}
+
+func TestRenderLongTXT(t *testing.T) {
+	input := ZoneFile{
+		Origin: "example.com.",
+		RecordSets: []RecordSet{
+			{
+				Name: "long",
+				Type: "TXT",
+				TTL:  300,
+				TXT:  [][]string{{strings.Repeat("a", 300)}},
+			},
+		},
+	}
+
+	expected := "$ORIGIN example.com.\nlong\t300\tIN\tTXT\t\"" + strings.Repeat("a", 255) + "\" \"" + strings.Repeat("a", 45) + "\"\n"
+	if actual := Render(input); actual != expected {
+		t.Fatalf("Expected:\n%s\nbut got:\n%s", expected, actual)
+	}
+}
+
+func TestRenderRoundTrip(t *testing.T) {
+	input := `$ORIGIN example.com.
+$TTL 300
+@ IN SOA ns1 hostmaster ( 5 3600 300 2419200 300 )
+@ IN NS ns1
+www IN A 10.0.0.1
+www IN TXT "quoted \"value\"" "tab\009"
+@ IN CAA 128 iodef "mailto:security@example.com"
+_ldap._tcp IN SRV 0 0 389 ldap
+`
+
+	parsed, err := Parse(input, "example.com", 3600)
+	if err != nil {
+		t.Fatalf("parsing input: %+v", err)
+	}
+
+	reparsed, err := Parse(Render(*parsed), "example.com", 3600)
+	if err != nil {
+		t.Fatalf("parsing rendered output: %+v", err)
+	}
+
+	if len(parsed.RecordSets) != len(reparsed.RecordSets) {
+		t.Fatalf("Expected %d Record Sets but got %d", len(parsed.RecordSets), len(reparsed.RecordSets))
+	}
+
+	// rendering orders the Record Sets, so compare them by name and type
+	for _, expected := range parsed.RecordSets {
+		found := false
+		for _, actual := range reparsed.RecordSets {
+			if actual.Name == expected.Name && actual.Type == expected.Type {
+				found = true
+				if !reflect.DeepEqual(expected, actual) {
+					t.Fatalf("Expected %+v but got %+v", expected, actual)
+				}
+			}
+		}
+
+		if !found {
+			t.Fatalf("Expected a %s Record Set named %q but didn't find one", expected.Type, expected.Name)
+		}
+	}
+}
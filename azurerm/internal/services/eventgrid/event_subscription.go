@@ -9,6 +9,7 @@ import (
 	"github.com/Azure/azure-sdk-for-go/services/preview/eventgrid/mgmt/2020-10-15-preview/eventgrid"
 	"github.com/Azure/go-autorest/autorest/date"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/azure"
+	msivalidate "github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/msi/validate"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tf/pluginsdk"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tf/validation"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
@@ -38,20 +39,37 @@ const (
 	WebHookEndpoint EventSubscriptionEndpointType = "webhook_endpoint"
 )
 
+// eventSubscriptionCustomizeDiffAdvancedFilter enforces the documented limits on advanced filters, since
+// the API only rejects these once the (long running) create/update has been submitted
+// https://docs.microsoft.com/en-us/azure/event-grid/event-filtering#limitations
 func eventSubscriptionCustomizeDiffAdvancedFilter(ctx context.Context, d *pluginsdk.ResourceDiff, _ interface{}) error {
-	if filterRaw := d.Get("advanced_filter"); len(filterRaw.([]interface{})) == 1 {
+	if filterRaw := d.Get("advanced_filter"); len(filterRaw.([]interface{})) == 1 && filterRaw.([]interface{})[0] != nil {
 		filters := filterRaw.([]interface{})[0].(map[string]interface{})
+		filterCount := 0
 		valueCount := 0
-		for _, valRaw := range filters {
+		for operatorType, valRaw := range filters {
 			for _, val := range valRaw.([]interface{}) {
+				if val == nil {
+					continue
+				}
+				filterCount++
+
 				v := val.(map[string]interface{})
 				if values, ok := v["values"]; ok {
 					valueCount += len(values.([]interface{}))
+					for _, value := range values.([]interface{}) {
+						if str, ok := value.(string); ok && len(str) > 512 {
+							return fmt.Errorf("the values of the `%s` advanced filter %q must be at most 512 characters long, but a value is %d characters long", operatorType, v["key"], len(str))
+						}
+					}
 				} else if _, ok := v["value"]; ok {
 					valueCount++
 				}
 			}
 		}
+		if filterCount > 25 {
+			return fmt.Errorf("the total number of `advanced_filter` filters allowed on a single event subscription is 25, but %d are configured", filterCount)
+		}
 		if valueCount > 25 {
 			return fmt.Errorf("the total number of `advanced_filter` values allowed on a single event subscription is 25, but %d are configured", valueCount)
 		}
@@ -59,6 +77,48 @@ func eventSubscriptionCustomizeDiffAdvancedFilter(ctx context.Context, d *plugin
 	return nil
 }
 
+func eventSubscriptionCustomizeDiffDeliveryProperty(ctx context.Context, d *pluginsdk.ResourceDiff, _ interface{}) error {
+	properties := d.Get("delivery_property").([]interface{})
+	if len(properties) == 0 {
+		return nil
+	}
+
+	if v := d.Get("storage_queue_endpoint").([]interface{}); len(v) > 0 {
+		return fmt.Errorf("`delivery_property` isn't supported when using a `storage_queue_endpoint`")
+	}
+
+	for i, raw := range properties {
+		if raw == nil {
+			continue
+		}
+		property := raw.(map[string]interface{})
+		headerName := property["header_name"].(string)
+
+		switch property["type"].(string) {
+		case string(eventgrid.TypeStatic):
+			if property["source_field"].(string) != "" {
+				return fmt.Errorf("`source_field` can't be specified for the Static `delivery_property` %q", headerName)
+			}
+			if property["value"].(string) == "" && d.NewValueKnown(fmt.Sprintf("delivery_property.%d.value", i)) {
+				return fmt.Errorf("`value` must be specified for the Static `delivery_property` %q", headerName)
+			}
+
+		case string(eventgrid.TypeDynamic):
+			if property["value"].(string) != "" {
+				return fmt.Errorf("`value` can't be specified for the Dynamic `delivery_property` %q", headerName)
+			}
+			if property["secret"].(bool) {
+				return fmt.Errorf("`secret` can't be enabled for the Dynamic `delivery_property` %q", headerName)
+			}
+			if property["source_field"].(string) == "" && d.NewValueKnown(fmt.Sprintf("delivery_property.%d.source_field", i)) {
+				return fmt.Errorf("`source_field` must be specified for the Dynamic `delivery_property` %q", headerName)
+			}
+		}
+	}
+
+	return nil
+}
+
 func eventSubscriptionSchemaEventSubscriptionName() *pluginsdk.Schema {
 	return &pluginsdk.Schema{
 		Type:     pluginsdk.TypeString,
@@ -770,6 +830,78 @@ func eventSubscriptionSchemaLabels() *pluginsdk.Schema {
 	}
 }
 
+func eventSubscriptionSchemaIdentity() *pluginsdk.Schema {
+	return &pluginsdk.Schema{
+		Type:     pluginsdk.TypeList,
+		MaxItems: 1,
+		Optional: true,
+		Elem: &pluginsdk.Resource{
+			Schema: map[string]*pluginsdk.Schema{
+				"type": {
+					Type:     pluginsdk.TypeString,
+					Required: true,
+					ValidateFunc: validation.StringInSlice([]string{
+						string(eventgrid.SystemAssigned),
+						string(eventgrid.UserAssigned),
+					}, false),
+				},
+				"user_assigned_identity": {
+					Type:         pluginsdk.TypeString,
+					Optional:     true,
+					ValidateFunc: msivalidate.UserAssignedIdentityID,
+				},
+			},
+		},
+	}
+}
+
+func eventSubscriptionSchemaDeadLetterIdentity() *pluginsdk.Schema {
+	s := eventSubscriptionSchemaIdentity()
+	s.RequiredWith = []string{"storage_blob_dead_letter_destination"}
+	return s
+}
+
+func eventSubscriptionSchemaDeliveryProperty() *pluginsdk.Schema {
+	return &pluginsdk.Schema{
+		Type:     pluginsdk.TypeList,
+		Optional: true,
+		MaxItems: 10,
+		Elem: &pluginsdk.Resource{
+			Schema: map[string]*pluginsdk.Schema{
+				"header_name": {
+					Type:         pluginsdk.TypeString,
+					Required:     true,
+					ValidateFunc: validation.StringIsNotEmpty,
+				},
+				"type": {
+					Type:     pluginsdk.TypeString,
+					Required: true,
+					ValidateFunc: validation.StringInSlice([]string{
+						string(eventgrid.TypeStatic),
+						string(eventgrid.TypeDynamic),
+					}, false),
+				},
+				"source_field": {
+					Type:         pluginsdk.TypeString,
+					Optional:     true,
+					ValidateFunc: validation.StringIsNotEmpty,
+				},
+				"value": {
+					Type:         pluginsdk.TypeString,
+					Optional:     true,
+					Sensitive:    true,
+					ValidateFunc: validation.StringLenBetween(1, 4096),
+				},
+				"secret": {
+					Type:     pluginsdk.TypeBool,
+					Optional: true,
+					Default:  false,
+				},
+			},
+		},
+	}
+}
+
 func expandEventGridExpirationTime(d *pluginsdk.ResourceData) (*date.Time, error) {
 	if expirationTimeUtc, ok := d.GetOk("expiration_time_utc"); ok {
 		if expirationTimeUtc == "" {
@@ -788,37 +920,42 @@ func expandEventGridExpirationTime(d *pluginsdk.ResourceData) (*date.Time, error
 }
 
 func expandEventGridEventSubscriptionDestination(d *pluginsdk.ResourceData) eventgrid.BasicEventSubscriptionDestination {
+	deliveryMappings := expandEventGridEventSubscriptionDeliveryAttributeMappings(d.Get("delivery_property").([]interface{}))
+
 	if v, ok := d.GetOk("azure_function_endpoint"); ok {
-		return expandEventGridEventSubscriptionAzureFunctionEndpoint(v)
+		return expandEventGridEventSubscriptionAzureFunctionEndpoint(v, deliveryMappings)
 	}
 
 	if v, ok := d.GetOk("eventhub_endpoint_id"); ok {
 		return &eventgrid.EventHubEventSubscriptionDestination{
 			EndpointType: eventgrid.EndpointTypeEventHub,
 			EventHubEventSubscriptionDestinationProperties: &eventgrid.EventHubEventSubscriptionDestinationProperties{
-				ResourceID: utils.String(v.(string)),
+				ResourceID:                utils.String(v.(string)),
+				DeliveryAttributeMappings: deliveryMappings,
 			},
 		}
 	} else if _, ok := d.GetOk("eventhub_endpoint"); ok {
-		return expandEventGridEventSubscriptionEventhubEndpoint(d)
+		return expandEventGridEventSubscriptionEventhubEndpoint(d, deliveryMappings)
 	}
 
 	if v, ok := d.GetOk("hybrid_connection_endpoint_id"); ok {
 		return &eventgrid.HybridConnectionEventSubscriptionDestination{
 			EndpointType: eventgrid.EndpointTypeHybridConnection,
 			HybridConnectionEventSubscriptionDestinationProperties: &eventgrid.HybridConnectionEventSubscriptionDestinationProperties{
-				ResourceID: utils.String(v.(string)),
+				ResourceID:                utils.String(v.(string)),
+				DeliveryAttributeMappings: deliveryMappings,
 			},
 		}
 	} else if _, ok := d.GetOk("hybrid_connection_endpoint"); ok {
-		return expandEventGridEventSubscriptionHybridConnectionEndpoint(d)
+		return expandEventGridEventSubscriptionHybridConnectionEndpoint(d, deliveryMappings)
 	}
 
 	if v, ok := d.GetOk("service_bus_queue_endpoint_id"); ok {
 		return &eventgrid.ServiceBusQueueEventSubscriptionDestination{
 			EndpointType: eventgrid.EndpointTypeServiceBusQueue,
 			ServiceBusQueueEventSubscriptionDestinationProperties: &eventgrid.ServiceBusQueueEventSubscriptionDestinationProperties{
-				ResourceID: utils.String(v.(string)),
+				ResourceID:                utils.String(v.(string)),
+				DeliveryAttributeMappings: deliveryMappings,
 			},
 		}
 	}
@@ -827,7 +964,8 @@ func expandEventGridEventSubscriptionDestination(d *pluginsdk.ResourceData) even
 		return &eventgrid.ServiceBusTopicEventSubscriptionDestination{
 			EndpointType: eventgrid.EndpointTypeServiceBusTopic,
 			ServiceBusTopicEventSubscriptionDestinationProperties: &eventgrid.ServiceBusTopicEventSubscriptionDestinationProperties{
-				ResourceID: utils.String(v.(string)),
+				ResourceID:                utils.String(v.(string)),
+				DeliveryAttributeMappings: deliveryMappings,
 			},
 		}
 	}
@@ -837,7 +975,7 @@ func expandEventGridEventSubscriptionDestination(d *pluginsdk.ResourceData) even
 	}
 
 	if v, ok := d.GetOk("webhook_endpoint"); ok {
-		return expandEventGridEventSubscriptionWebhookEndpoint(v)
+		return expandEventGridEventSubscriptionWebhookEndpoint(v, deliveryMappings)
 	}
 
 	return nil
@@ -857,7 +995,7 @@ func expandEventGridEventSubscriptionStorageQueueEndpoint(d *pluginsdk.ResourceD
 	}
 }
 
-func expandEventGridEventSubscriptionEventhubEndpoint(d *pluginsdk.ResourceData) eventgrid.BasicEventSubscriptionDestination {
+func expandEventGridEventSubscriptionEventhubEndpoint(d *pluginsdk.ResourceData, deliveryMappings *[]eventgrid.BasicDeliveryAttributeMapping) eventgrid.BasicEventSubscriptionDestination {
 	ep := d.Get("eventhub_endpoint").([]interface{})
 	if len(ep) == 0 || ep[0] == nil {
 		return eventgrid.EventHubEventSubscriptionDestination{}
@@ -868,12 +1006,13 @@ func expandEventGridEventSubscriptionEventhubEndpoint(d *pluginsdk.ResourceData)
 	return eventgrid.EventHubEventSubscriptionDestination{
 		EndpointType: eventgrid.EndpointTypeEventHub,
 		EventHubEventSubscriptionDestinationProperties: &eventgrid.EventHubEventSubscriptionDestinationProperties{
-			ResourceID: &eventHubID,
+			ResourceID:                &eventHubID,
+			DeliveryAttributeMappings: deliveryMappings,
 		},
 	}
 }
 
-func expandEventGridEventSubscriptionHybridConnectionEndpoint(d *pluginsdk.ResourceData) eventgrid.BasicEventSubscriptionDestination {
+func expandEventGridEventSubscriptionHybridConnectionEndpoint(d *pluginsdk.ResourceData, deliveryMappings *[]eventgrid.BasicDeliveryAttributeMapping) eventgrid.BasicEventSubscriptionDestination {
 	ep := d.Get("hybrid_connection_endpoint").([]interface{})
 	if len(ep) == 0 || ep[0] == nil {
 		return eventgrid.HybridConnectionEventSubscriptionDestination{}
@@ -884,15 +1023,18 @@ func expandEventGridEventSubscriptionHybridConnectionEndpoint(d *pluginsdk.Resou
 	return eventgrid.HybridConnectionEventSubscriptionDestination{
 		EndpointType: eventgrid.EndpointTypeHybridConnection,
 		HybridConnectionEventSubscriptionDestinationProperties: &eventgrid.HybridConnectionEventSubscriptionDestinationProperties{
-			ResourceID: &hybridConnectionID,
+			ResourceID:                &hybridConnectionID,
+			DeliveryAttributeMappings: deliveryMappings,
 		},
 	}
 }
 
-func expandEventGridEventSubscriptionAzureFunctionEndpoint(input interface{}) eventgrid.BasicEventSubscriptionDestination {
+func expandEventGridEventSubscriptionAzureFunctionEndpoint(input interface{}, deliveryMappings *[]eventgrid.BasicDeliveryAttributeMapping) eventgrid.BasicEventSubscriptionDestination {
 	configs := input.([]interface{})
 
-	props := eventgrid.AzureFunctionEventSubscriptionDestinationProperties{}
+	props := eventgrid.AzureFunctionEventSubscriptionDestinationProperties{
+		DeliveryAttributeMappings: deliveryMappings,
+	}
 	azureFunctionDestination := &eventgrid.AzureFunctionEventSubscriptionDestination{
 		EndpointType: eventgrid.EndpointTypeAzureFunction,
 		AzureFunctionEventSubscriptionDestinationProperties: &props,
@@ -919,10 +1061,12 @@ func expandEventGridEventSubscriptionAzureFunctionEndpoint(input interface{}) ev
 	return azureFunctionDestination
 }
 
-func expandEventGridEventSubscriptionWebhookEndpoint(input interface{}) eventgrid.BasicEventSubscriptionDestination {
+func expandEventGridEventSubscriptionWebhookEndpoint(input interface{}, deliveryMappings *[]eventgrid.BasicDeliveryAttributeMapping) eventgrid.BasicEventSubscriptionDestination {
 	configs := input.([]interface{})
 
-	props := eventgrid.WebHookEventSubscriptionDestinationProperties{}
+	props := eventgrid.WebHookEventSubscriptionDestinationProperties{
+		DeliveryAttributeMappings: deliveryMappings,
+	}
 	webhookDestination := &eventgrid.WebHookEventSubscriptionDestination{
 		EndpointType: eventgrid.EndpointTypeWebHook,
 		WebHookEventSubscriptionDestinationProperties: &props,
@@ -1075,6 +1219,59 @@ func expandEventGridEventSubscriptionStorageBlobDeadLetterDestination(d *plugins
 	return nil
 }
 
+func expandEventGridEventSubscriptionIdentity(input []interface{}) *eventgrid.EventSubscriptionIdentity {
+	if len(input) == 0 || input[0] == nil {
+		return nil
+	}
+
+	raw := input[0].(map[string]interface{})
+	identity := eventgrid.EventSubscriptionIdentity{
+		Type: eventgrid.EventSubscriptionIdentityType(raw["type"].(string)),
+	}
+	if v := raw["user_assigned_identity"].(string); v != "" {
+		identity.UserAssignedIdentity = utils.String(v)
+	}
+
+	return &identity
+}
+
+func expandEventGridEventSubscriptionDeliveryAttributeMappings(input []interface{}) *[]eventgrid.BasicDeliveryAttributeMapping {
+	if len(input) == 0 {
+		return nil
+	}
+
+	mappings := make([]eventgrid.BasicDeliveryAttributeMapping, 0)
+	for _, r := range input {
+		if r == nil {
+			continue
+		}
+		raw := r.(map[string]interface{})
+		headerName := raw["header_name"].(string)
+
+		if raw["type"].(string) == string(eventgrid.TypeDynamic) {
+			mappings = append(mappings, eventgrid.DynamicDeliveryAttributeMapping{
+				Name: utils.String(headerName),
+				Type: eventgrid.TypeDynamic,
+				DynamicDeliveryAttributeMappingProperties: &eventgrid.DynamicDeliveryAttributeMappingProperties{
+					SourceField: utils.String(raw["source_field"].(string)),
+				},
+			})
+			continue
+		}
+
+		mappings = append(mappings, eventgrid.StaticDeliveryAttributeMapping{
+			Name: utils.String(headerName),
+			Type: eventgrid.TypeStatic,
+			StaticDeliveryAttributeMappingProperties: &eventgrid.StaticDeliveryAttributeMappingProperties{
+				Value:    utils.String(raw["value"].(string)),
+				IsSecret: utils.Bool(raw["secret"].(bool)),
+			},
+		})
+	}
+
+	return &mappings
+}
+
 func expandEventGridEventSubscriptionRetryPolicy(d *pluginsdk.ResourceData) *eventgrid.RetryPolicy {
 	if v, ok := d.GetOk("retry_policy"); ok {
 		dest := v.([]interface{})[0].(map[string]interface{})
@@ -1360,6 +1557,96 @@ func flattenEventGridEventSubscriptionStorageBlobDeadLetterDestination(dest *eve
 	return []interface{}{result}
 }
 
+func flattenEventGridEventSubscriptionIdentity(input *eventgrid.EventSubscriptionIdentity) []interface{} {
+	if input == nil {
+		return []interface{}{}
+	}
+
+	userAssignedIdentity := ""
+	if input.UserAssignedIdentity != nil {
+		userAssignedIdentity = *input.UserAssignedIdentity
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"type":                   string(input.Type),
+			"user_assigned_identity": userAssignedIdentity,
+		},
+	}
+}
+
+// flattenEventGridEventSubscriptionDeliveryAttributeMappings flattens the delivery attributes, the values of secret
+// static attributes aren't returned by the API so these are taken from the existing configuration
+func flattenEventGridEventSubscriptionDeliveryAttributeMappings(input *[]eventgrid.BasicDeliveryAttributeMapping, existing []interface{}) []interface{} {
+	results := make([]interface{}, 0)
+	if input == nil {
+		return results
+	}
+
+	secretValues := make(map[string]string)
+	for _, r := range existing {
+		if r == nil {
+			continue
+		}
+		raw := r.(map[string]interface{})
+		if raw["secret"].(bool) {
+			secretValues[raw["header_name"].(string)] = raw["value"].(string)
+		}
+	}
+
+	for _, item := range *input {
+		if v, ok := item.AsStaticDeliveryAttributeMapping(); ok {
+			headerName := ""
+			if v.Name != nil {
+				headerName = *v.Name
+			}
+
+			value := ""
+			secret := false
+			if props := v.StaticDeliveryAttributeMappingProperties; props != nil {
+				if props.IsSecret != nil {
+					secret = *props.IsSecret
+				}
+				if secret {
+					value = secretValues[headerName]
+				} else if props.Value != nil {
+					value = *props.Value
+				}
+			}
+
+			results = append(results, map[string]interface{}{
+				"header_name":  headerName,
+				"type":         string(eventgrid.TypeStatic),
+				"source_field": "",
+				"value":        value,
+				"secret":       secret,
+			})
+		}
+
+		if v, ok := item.AsDynamicDeliveryAttributeMapping(); ok {
+			headerName := ""
+			if v.Name != nil {
+				headerName = *v.Name
+			}
+
+			sourceField := ""
+			if props := v.DynamicDeliveryAttributeMappingProperties; props != nil && props.SourceField != nil {
+				sourceField = *props.SourceField
+			}
+
+			results = append(results, map[string]interface{}{
+				"header_name":  headerName,
+				"type":         string(eventgrid.TypeDynamic),
+				"source_field": sourceField,
+				"value":        "",
+				"secret":       false,
+			})
+		}
+	}
+
+	return results
+}
+
 func flattenEventGridEventSubscriptionRetryPolicy(retryPolicy *eventgrid.RetryPolicy) []interface{} {
 	result := make(map[string]interface{})
 
@@ -2,11 +2,15 @@ package eventgrid
 
 import (
 	"github.com/Azure/azure-sdk-for-go/services/preview/eventgrid/mgmt/2020-10-15-preview/eventgrid"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/identity"
+	msiparse "github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/msi/parse"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tf/pluginsdk"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tf/validation"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
 )
 
+type eventGridIdentity = identity.SystemAssignedUserAssigned
+
 func eventSubscriptionPublicNetworkAccessEnabled() *pluginsdk.Schema {
 	return &pluginsdk.Schema{
 		Type:     pluginsdk.TypeBool,
@@ -93,3 +97,46 @@ func flattenInboundIPRules(in *[]eventgrid.InboundIPRule) []interface{} {
 	}
 	return rules
 }
+
+func expandEventGridIdentity(input []interface{}) (*eventgrid.IdentityInfo, error) {
+	config, err := eventGridIdentity{}.Expand(input)
+	if err != nil {
+		return nil, err
+	}
+
+	var identityIds map[string]*eventgrid.UserIdentityProperties
+	if config.UserAssignedIdentityIds != nil {
+		identityIds = map[string]*eventgrid.UserIdentityProperties{}
+		for _, id := range *config.UserAssignedIdentityIds {
+			identityIds[id] = &eventgrid.UserIdentityProperties{}
+		}
+	}
+
+	return &eventgrid.IdentityInfo{
+		Type:                   eventgrid.IdentityType(config.Type),
+		UserAssignedIdentities: identityIds,
+	}, nil
+}
+
+func flattenEventGridIdentity(input *eventgrid.IdentityInfo) ([]interface{}, error) {
+	var config *identity.ExpandedConfig
+
+	if input != nil {
+		var identityIds []string
+		for id := range input.UserAssignedIdentities {
+			parsedId, err := msiparse.UserAssignedIdentityIDInsensitively(id)
+			if err != nil {
+				return nil, err
+			}
+			identityIds = append(identityIds, parsedId.ID())
+		}
+
+		config = &identity.ExpandedConfig{
+			Type:                    string(input.Type),
+			PrincipalId:             input.PrincipalID,
+			TenantId:                input.TenantID,
+			UserAssignedIdentityIds: &identityIds,
+		}
+	}
+	return eventGridIdentity{}.Flatten(config), nil
+}
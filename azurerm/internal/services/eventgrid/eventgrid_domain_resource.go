@@ -139,6 +139,8 @@ func resourceEventGridDomain() *pluginsdk.Resource {
 				},
 			},
 
+			"identity": eventGridIdentity{}.Schema(),
+
 			"public_network_access_enabled": eventSubscriptionPublicNetworkAccessEnabled(),
 
 			"inbound_ip_rule": eventSubscriptionInboundIPRule(),
@@ -194,9 +196,15 @@ func resourceEventGridDomainCreateUpdate(d *pluginsdk.ResourceData, meta interfa
 		InboundIPRules:      expandInboundIPRules(d),
 	}
 
+	identity, err := expandEventGridIdentity(d.Get("identity").([]interface{}))
+	if err != nil {
+		return fmt.Errorf("expanding `identity`: %+v", err)
+	}
+
 	domain := eventgrid.Domain{
 		Location:         &location,
 		DomainProperties: domainProperties,
+		Identity:         identity,
 		Tags:             tags.Expand(t),
 	}
 
@@ -251,6 +259,14 @@ func resourceEventGridDomainRead(d *pluginsdk.ResourceData, meta interface{}) er
 		d.Set("location", azure.NormalizeLocation(*location))
 	}
 
+	identity, err := flattenEventGridIdentity(resp.Identity)
+	if err != nil {
+		return fmt.Errorf("flattening `identity` for EventGrid Domain %q (Resource Group %q): %+v", id.Name, id.ResourceGroup, err)
+	}
+	if err := d.Set("identity", identity); err != nil {
+		return fmt.Errorf("setting `identity` for EventGrid Domain %q (Resource Group %q): %+v", id.Name, id.ResourceGroup, err)
+	}
+
 	if props := resp.DomainProperties; props != nil {
 		d.Set("endpoint", props.Endpoint)
 
@@ -44,7 +44,10 @@ func resourceEventGridEventSubscription() *pluginsdk.Resource {
 			Delete: pluginsdk.DefaultTimeout(30 * time.Minute),
 		},
 
-		CustomizeDiff: pluginsdk.CustomizeDiffShim(eventSubscriptionCustomizeDiffAdvancedFilter),
+		CustomizeDiff: pluginsdk.CustomDiffWithAll(
+			eventSubscriptionCustomizeDiffAdvancedFilter,
+			eventSubscriptionCustomizeDiffDeliveryProperty,
+		),
 
 		Importer: pluginsdk.ImporterValidatingResourceId(func(id string) error {
 			_, err := parse.EventSubscriptionID(id)
@@ -141,6 +144,12 @@ func resourceEventGridEventSubscription() *pluginsdk.Resource {
 
 			"advanced_filter": eventSubscriptionSchemaAdvancedFilter(),
 
+			"delivery_identity": eventSubscriptionSchemaIdentity(),
+
+			"delivery_property": eventSubscriptionSchemaDeliveryProperty(),
+
+			"dead_letter_identity": eventSubscriptionSchemaDeadLetterIdentity(),
+
 			"storage_blob_dead_letter_destination": eventSubscriptionSchemaStorageBlobDeadletterDestination(),
 
 			"retry_policy": eventSubscriptionSchemaRetryPolicy(),
@@ -187,13 +196,30 @@ func resourceEventGridEventSubscriptionCreateUpdate(d *pluginsdk.ResourceData, m
 	}
 
 	eventSubscriptionProperties := eventgrid.EventSubscriptionProperties{
-		Destination:           destination,
-		Filter:                filter,
-		DeadLetterDestination: expandEventGridEventSubscriptionStorageBlobDeadLetterDestination(d),
-		RetryPolicy:           expandEventGridEventSubscriptionRetryPolicy(d),
-		Labels:                utils.ExpandStringSlice(d.Get("labels").([]interface{})),
-		EventDeliverySchema:   eventgrid.EventDeliverySchema(d.Get("event_delivery_schema").(string)),
-		ExpirationTimeUtc:     expirationTime,
+		Filter:              filter,
+		RetryPolicy:         expandEventGridEventSubscriptionRetryPolicy(d),
+		Labels:              utils.ExpandStringSlice(d.Get("labels").([]interface{})),
+		EventDeliverySchema: eventgrid.EventDeliverySchema(d.Get("event_delivery_schema").(string)),
+		ExpirationTimeUtc:   expirationTime,
+	}
+
+	if deliveryIdentity := expandEventGridEventSubscriptionIdentity(d.Get("delivery_identity").([]interface{})); deliveryIdentity != nil {
+		eventSubscriptionProperties.DeliveryWithResourceIdentity = &eventgrid.DeliveryWithResourceIdentity{
+			Identity:    deliveryIdentity,
+			Destination: destination,
+		}
+	} else {
+		eventSubscriptionProperties.Destination = destination
+	}
+
+	deadLetterDestination := expandEventGridEventSubscriptionStorageBlobDeadLetterDestination(d)
+	if deadLetterIdentity := expandEventGridEventSubscriptionIdentity(d.Get("dead_letter_identity").([]interface{})); deadLetterIdentity != nil {
+		eventSubscriptionProperties.DeadLetterWithResourceIdentity = &eventgrid.DeadLetterWithResourceIdentity{
+			Identity:              deadLetterIdentity,
+			DeadLetterDestination: deadLetterDestination,
+		}
+	} else {
+		eventSubscriptionProperties.DeadLetterDestination = deadLetterDestination
 	}
 
 	eventSubscription := eventgrid.EventSubscription{
@@ -255,12 +281,30 @@ func resourceEventGridEventSubscriptionRead(d *pluginsdk.ResourceData, meta inte
 
 		d.Set("event_delivery_schema", string(props.EventDeliverySchema))
 
-		if azureFunctionEndpoint, ok := props.Destination.AsAzureFunctionEventSubscriptionDestination(); ok {
+		destination := props.Destination
+		var deliveryIdentity *eventgrid.EventSubscriptionIdentity
+		if v := props.DeliveryWithResourceIdentity; v != nil {
+			destination = v.Destination
+			deliveryIdentity = v.Identity
+		}
+		if err := d.Set("delivery_identity", flattenEventGridEventSubscriptionIdentity(deliveryIdentity)); err != nil {
+			return fmt.Errorf("Error setting `delivery_identity` for EventGrid Event Subscription %q (Scope %q): %s", id.Name, id.Scope, err)
+		}
+
+		deliveryAttributes, err := client.GetDeliveryAttributes(ctx, id.Scope, id.Name)
+		if err != nil {
+			return fmt.Errorf("Error retrieving Delivery Attributes for EventGrid Event Subscription %q (Scope %q): %+v", id.Name, id.Scope, err)
+		}
+		if err := d.Set("delivery_property", flattenEventGridEventSubscriptionDeliveryAttributeMappings(deliveryAttributes.Value, d.Get("delivery_property").([]interface{}))); err != nil {
+			return fmt.Errorf("Error setting `delivery_property` for EventGrid Event Subscription %q (Scope %q): %s", id.Name, id.Scope, err)
+		}
+
+		if azureFunctionEndpoint, ok := destination.AsAzureFunctionEventSubscriptionDestination(); ok {
 			if err := d.Set("azure_function_endpoint", flattenEventGridEventSubscriptionAzureFunctionEndpoint(azureFunctionEndpoint)); err != nil {
 				return fmt.Errorf("Error setting `%q` for EventGrid Event Subscription %q (Scope %q): %s", "azure_function_endpoint", id.Name, id.Scope, err)
 			}
 		}
-		if v, ok := props.Destination.AsEventHubEventSubscriptionDestination(); ok {
+		if v, ok := destination.AsEventHubEventSubscriptionDestination(); ok {
 			if err := d.Set("eventhub_endpoint_id", v.ResourceID); err != nil {
 				return fmt.Errorf("Error setting `%q` for EventGrid Event Subscription %q (Scope %q): %s", "eventhub_endpoint_id", id.Name, id.Scope, err)
 			}
@@ -269,7 +313,7 @@ func resourceEventGridEventSubscriptionRead(d *pluginsdk.ResourceData, meta inte
 				return fmt.Errorf("Error setting `%q` for EventGrid Event Subscription %q (Scope %q): %s", "eventhub_endpoint", id.Name, id.Scope, err)
 			}
 		}
-		if v, ok := props.Destination.AsHybridConnectionEventSubscriptionDestination(); ok {
+		if v, ok := destination.AsHybridConnectionEventSubscriptionDestination(); ok {
 			if err := d.Set("hybrid_connection_endpoint_id", v.ResourceID); err != nil {
 				return fmt.Errorf("Error setting `%q` for EventGrid Event Subscription %q (Scope %q): %s", "hybrid_connection_endpoint_id", id.Name, id.Scope, err)
 			}
@@ -278,22 +322,22 @@ func resourceEventGridEventSubscriptionRead(d *pluginsdk.ResourceData, meta inte
 				return fmt.Errorf("Error setting `%q` for EventGrid Event Subscription %q (Scope %q): %s", "hybrid_connection_endpoint", id.Name, id.Scope, err)
 			}
 		}
-		if serviceBusQueueEndpoint, ok := props.Destination.AsServiceBusQueueEventSubscriptionDestination(); ok {
+		if serviceBusQueueEndpoint, ok := destination.AsServiceBusQueueEventSubscriptionDestination(); ok {
 			if err := d.Set("service_bus_queue_endpoint_id", serviceBusQueueEndpoint.ResourceID); err != nil {
 				return fmt.Errorf("Error setting `%q` for EventGrid Event Subscription %q (Scope %q): %s", "service_bus_queue_endpoint_id", id.Name, id.Scope, err)
 			}
 		}
-		if serviceBusTopicEndpoint, ok := props.Destination.AsServiceBusTopicEventSubscriptionDestination(); ok {
+		if serviceBusTopicEndpoint, ok := destination.AsServiceBusTopicEventSubscriptionDestination(); ok {
 			if err := d.Set("service_bus_topic_endpoint_id", serviceBusTopicEndpoint.ResourceID); err != nil {
 				return fmt.Errorf("Error setting `%q` for EventGrid Event Subscription %q (Scope %q): %s", "service_bus_topic_endpoint_id", id.Name, id.Scope, err)
 			}
 		}
-		if v, ok := props.Destination.AsStorageQueueEventSubscriptionDestination(); ok {
+		if v, ok := destination.AsStorageQueueEventSubscriptionDestination(); ok {
 			if err := d.Set("storage_queue_endpoint", flattenEventGridEventSubscriptionStorageQueueEndpoint(v)); err != nil {
 				return fmt.Errorf("Error setting `%q` for EventGrid Event Subscription %q (Scope %q): %s", "storage_queue_endpoint", id.Name, id.Scope, err)
 			}
 		}
-		if v, ok := props.Destination.AsWebHookEventSubscriptionDestination(); ok {
+		if v, ok := destination.AsWebHookEventSubscriptionDestination(); ok {
 			fullURL, err := client.GetFullURL(ctx, id.Scope, id.Name)
 			if err != nil {
 				return fmt.Errorf("Error making Read request on EventGrid Event Subscription full URL '%s': %+v", id.Name, err)
@@ -313,8 +357,18 @@ func resourceEventGridEventSubscriptionRead(d *pluginsdk.ResourceData, meta inte
 			}
 		}
 
-		if props.DeadLetterDestination != nil {
-			if storageBlobDeadLetterDestination, ok := props.DeadLetterDestination.AsStorageBlobDeadLetterDestination(); ok {
+		deadLetterDestination := props.DeadLetterDestination
+		var deadLetterIdentity *eventgrid.EventSubscriptionIdentity
+		if v := props.DeadLetterWithResourceIdentity; v != nil {
+			deadLetterDestination = v.DeadLetterDestination
+			deadLetterIdentity = v.Identity
+		}
+		if err := d.Set("dead_letter_identity", flattenEventGridEventSubscriptionIdentity(deadLetterIdentity)); err != nil {
+			return fmt.Errorf("Error setting `dead_letter_identity` for EventGrid Event Subscription %q (Scope %q): %s", id.Name, id.Scope, err)
+		}
+
+		if deadLetterDestination != nil {
+			if storageBlobDeadLetterDestination, ok := deadLetterDestination.AsStorageBlobDeadLetterDestination(); ok {
 				if err := d.Set("storage_blob_dead_letter_destination", flattenEventGridEventSubscriptionStorageBlobDeadLetterDestination(storageBlobDeadLetterDestination)); err != nil {
 					return fmt.Errorf("Error setting `storage_blob_dead_letter_destination` for EventGrid Event Subscription %q (Scope %q): %s", id.Name, id.Scope, err)
 				}
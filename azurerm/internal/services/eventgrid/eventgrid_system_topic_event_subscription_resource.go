@@ -36,6 +36,11 @@ func resourceEventGridSystemTopicEventSubscription() *pluginsdk.Resource {
 		Update: resourceEventGridSystemTopicEventSubscriptionCreateUpdate,
 		Delete: resourceEventGridSystemTopicEventSubscriptionDelete,
 
+		CustomizeDiff: pluginsdk.CustomDiffWithAll(
+			eventSubscriptionCustomizeDiffAdvancedFilter,
+			eventSubscriptionCustomizeDiffDeliveryProperty,
+		),
+
 		Timeouts: &pluginsdk.ResourceTimeout{
 			Create: pluginsdk.DefaultTimeout(30 * time.Minute),
 			Read:   pluginsdk.DefaultTimeout(5 * time.Minute),
@@ -119,6 +124,12 @@ func resourceEventGridSystemTopicEventSubscription() *pluginsdk.Resource {
 
 			"advanced_filter": eventSubscriptionSchemaAdvancedFilter(),
 
+			"delivery_identity": eventSubscriptionSchemaIdentity(),
+
+			"delivery_property": eventSubscriptionSchemaDeliveryProperty(),
+
+			"dead_letter_identity": eventSubscriptionSchemaDeadLetterIdentity(),
+
 			"storage_blob_dead_letter_destination": eventSubscriptionSchemaStorageBlobDeadletterDestination(),
 
 			"retry_policy": eventSubscriptionSchemaRetryPolicy(),
@@ -166,13 +177,30 @@ func resourceEventGridSystemTopicEventSubscriptionCreateUpdate(d *pluginsdk.Reso
 	}
 
 	eventSubscriptionProperties := eventgrid.EventSubscriptionProperties{
-		Destination:           destination,
-		Filter:                filter,
-		DeadLetterDestination: expandEventGridEventSubscriptionStorageBlobDeadLetterDestination(d),
-		RetryPolicy:           expandEventGridEventSubscriptionRetryPolicy(d),
-		Labels:                utils.ExpandStringSlice(d.Get("labels").([]interface{})),
-		EventDeliverySchema:   eventgrid.EventDeliverySchema(d.Get("event_delivery_schema").(string)),
-		ExpirationTimeUtc:     expirationTime,
+		Filter:              filter,
+		RetryPolicy:         expandEventGridEventSubscriptionRetryPolicy(d),
+		Labels:              utils.ExpandStringSlice(d.Get("labels").([]interface{})),
+		EventDeliverySchema: eventgrid.EventDeliverySchema(d.Get("event_delivery_schema").(string)),
+		ExpirationTimeUtc:   expirationTime,
+	}
+
+	if deliveryIdentity := expandEventGridEventSubscriptionIdentity(d.Get("delivery_identity").([]interface{})); deliveryIdentity != nil {
+		eventSubscriptionProperties.DeliveryWithResourceIdentity = &eventgrid.DeliveryWithResourceIdentity{
+			Identity:    deliveryIdentity,
+			Destination: destination,
+		}
+	} else {
+		eventSubscriptionProperties.Destination = destination
+	}
+
+	deadLetterDestination := expandEventGridEventSubscriptionStorageBlobDeadLetterDestination(d)
+	if deadLetterIdentity := expandEventGridEventSubscriptionIdentity(d.Get("dead_letter_identity").([]interface{})); deadLetterIdentity != nil {
+		eventSubscriptionProperties.DeadLetterWithResourceIdentity = &eventgrid.DeadLetterWithResourceIdentity{
+			Identity:              deadLetterIdentity,
+			DeadLetterDestination: deadLetterDestination,
+		}
+	} else {
+		eventSubscriptionProperties.DeadLetterDestination = deadLetterDestination
 	}
 
 	eventSubscription := eventgrid.EventSubscription{
@@ -235,37 +263,55 @@ func resourceEventGridSystemTopicEventSubscriptionRead(d *pluginsdk.ResourceData
 
 		d.Set("event_delivery_schema", string(props.EventDeliverySchema))
 
-		if azureFunctionEndpoint, ok := props.Destination.AsAzureFunctionEventSubscriptionDestination(); ok {
+		destination := props.Destination
+		var deliveryIdentity *eventgrid.EventSubscriptionIdentity
+		if v := props.DeliveryWithResourceIdentity; v != nil {
+			destination = v.Destination
+			deliveryIdentity = v.Identity
+		}
+		if err := d.Set("delivery_identity", flattenEventGridEventSubscriptionIdentity(deliveryIdentity)); err != nil {
+			return fmt.Errorf("Error setting `delivery_identity` for EventGrid System Topic Event Subscription %q (System Topic %q): %s", id.Name, id.SystemTopic, err)
+		}
+
+		deliveryAttributes, err := client.GetDeliveryAttributes(ctx, id.ResourceGroup, id.SystemTopic, id.Name)
+		if err != nil {
+			return fmt.Errorf("Error retrieving Delivery Attributes for EventGrid System Topic Event Subscription %q (System Topic %q): %+v", id.Name, id.SystemTopic, err)
+		}
+		if err := d.Set("delivery_property", flattenEventGridEventSubscriptionDeliveryAttributeMappings(deliveryAttributes.Value, d.Get("delivery_property").([]interface{}))); err != nil {
+			return fmt.Errorf("Error setting `delivery_property` for EventGrid System Topic Event Subscription %q (System Topic %q): %s", id.Name, id.SystemTopic, err)
+		}
+
+		if azureFunctionEndpoint, ok := destination.AsAzureFunctionEventSubscriptionDestination(); ok {
 			if err := d.Set("azure_function_endpoint", flattenEventGridEventSubscriptionAzureFunctionEndpoint(azureFunctionEndpoint)); err != nil {
 				return fmt.Errorf("Error setting `%q` for EventGrid System Topic Event Subscription %q (System Topic %q): %s", "azure_function_endpoint", id.Name, id.SystemTopic, err)
 			}
 		}
-		if v, ok := props.Destination.AsEventHubEventSubscriptionDestination(); ok {
+		if v, ok := destination.AsEventHubEventSubscriptionDestination(); ok {
 			if err := d.Set("eventhub_endpoint_id", v.ResourceID); err != nil {
 				return fmt.Errorf("Error setting `%q` for EventGrid System Topic Event Subscription %q (System Topic %q): %s", "eventhub_endpoint_id", id.Name, id.SystemTopic, err)
 			}
 		}
-		if v, ok := props.Destination.AsHybridConnectionEventSubscriptionDestination(); ok {
+		if v, ok := destination.AsHybridConnectionEventSubscriptionDestination(); ok {
 			if err := d.Set("hybrid_connection_endpoint_id", v.ResourceID); err != nil {
 				return fmt.Errorf("Error setting `%q` for EventGrid System Topic Event Subscription %q (System Topic %q): %s", "hybrid_connection_endpoint_id", id.Name, id.SystemTopic, err)
 			}
 		}
-		if serviceBusQueueEndpoint, ok := props.Destination.AsServiceBusQueueEventSubscriptionDestination(); ok {
+		if serviceBusQueueEndpoint, ok := destination.AsServiceBusQueueEventSubscriptionDestination(); ok {
 			if err := d.Set("service_bus_queue_endpoint_id", serviceBusQueueEndpoint.ResourceID); err != nil {
 				return fmt.Errorf("Error setting `%q` for EventGrid System Topic Event Subscription %q (System Topic %q): %s", "service_bus_queue_endpoint_id", id.Name, id.SystemTopic, err)
 			}
 		}
-		if serviceBusTopicEndpoint, ok := props.Destination.AsServiceBusTopicEventSubscriptionDestination(); ok {
+		if serviceBusTopicEndpoint, ok := destination.AsServiceBusTopicEventSubscriptionDestination(); ok {
 			if err := d.Set("service_bus_topic_endpoint_id", serviceBusTopicEndpoint.ResourceID); err != nil {
 				return fmt.Errorf("Error setting `%q` for EventGrid System Topic Event Subscription %q (System Topic %q): %s", "service_bus_topic_endpoint_id", id.Name, id.SystemTopic, err)
 			}
 		}
-		if v, ok := props.Destination.AsStorageQueueEventSubscriptionDestination(); ok {
+		if v, ok := destination.AsStorageQueueEventSubscriptionDestination(); ok {
 			if err := d.Set("storage_queue_endpoint", flattenEventGridEventSubscriptionStorageQueueEndpoint(v)); err != nil {
 				return fmt.Errorf("Error setting `%q` for EventGrid System Topic Event Subscription %q (System Topic %q): %s", "storage_queue_endpoint", id.Name, id.SystemTopic, err)
 			}
 		}
-		if v, ok := props.Destination.AsWebHookEventSubscriptionDestination(); ok {
+		if v, ok := destination.AsWebHookEventSubscriptionDestination(); ok {
 			fullURL, err := client.GetFullURL(ctx, id.ResourceGroup, id.SystemTopic, id.Name)
 			if err != nil {
 				return fmt.Errorf("Error making Read request on EventGrid System Topic Event Subscription full URL '%s': %+v", id.Name, err)
@@ -285,8 +331,18 @@ func resourceEventGridSystemTopicEventSubscriptionRead(d *pluginsdk.ResourceData
 			}
 		}
 
-		if props.DeadLetterDestination != nil {
-			if storageBlobDeadLetterDestination, ok := props.DeadLetterDestination.AsStorageBlobDeadLetterDestination(); ok {
+		deadLetterDestination := props.DeadLetterDestination
+		var deadLetterIdentity *eventgrid.EventSubscriptionIdentity
+		if v := props.DeadLetterWithResourceIdentity; v != nil {
+			deadLetterDestination = v.DeadLetterDestination
+			deadLetterIdentity = v.Identity
+		}
+		if err := d.Set("dead_letter_identity", flattenEventGridEventSubscriptionIdentity(deadLetterIdentity)); err != nil {
+			return fmt.Errorf("Error setting `dead_letter_identity` for EventGrid System Topic Event Subscription %q (System Topic %q): %s", id.Name, id.SystemTopic, err)
+		}
+
+		if deadLetterDestination != nil {
+			if storageBlobDeadLetterDestination, ok := deadLetterDestination.AsStorageBlobDeadLetterDestination(); ok {
 				if err := d.Set("storage_blob_dead_letter_destination", flattenEventGridEventSubscriptionStorageBlobDeadLetterDestination(storageBlobDeadLetterDestination)); err != nil {
 					return fmt.Errorf("Error setting `storage_blob_dead_letter_destination` for EventGrid System Topic Event Subscription %q (System Topic %q): %s", id.Name, id.SystemTopic, err)
 				}
@@ -70,6 +70,8 @@ func resourceEventGridSystemTopic() *pluginsdk.Resource {
 				ValidateFunc: validation.StringIsNotEmpty,
 			},
 
+			"identity": eventGridIdentity{}.Schema(),
+
 			"metric_arm_resource_id": {
 				Type:     pluginsdk.TypeString,
 				Computed: true,
@@ -106,13 +108,19 @@ func resourceEventGridSystemTopicCreateUpdate(d *pluginsdk.ResourceData, meta in
 	location := azure.NormalizeLocation(d.Get("location").(string))
 	t := d.Get("tags").(map[string]interface{})
 
+	identity, err := expandEventGridIdentity(d.Get("identity").([]interface{}))
+	if err != nil {
+		return fmt.Errorf("expanding `identity`: %+v", err)
+	}
+
 	systemTopic := eventgrid.SystemTopic{
 		Location: &location,
 		SystemTopicProperties: &eventgrid.SystemTopicProperties{
 			Source:    &source,
 			TopicType: &topicType,
 		},
-		Tags: tags.Expand(t),
+		Identity: identity,
+		Tags:     tags.Expand(t),
 	}
 
 	log.Printf("[INFO] preparing arguments for AzureRM Event Grid System Topic creation with Properties: %+v.", systemTopic)
@@ -166,6 +174,14 @@ func resourceEventGridSystemTopicRead(d *pluginsdk.ResourceData, meta interface{
 		d.Set("location", azure.NormalizeLocation(*location))
 	}
 
+	identity, err := flattenEventGridIdentity(resp.Identity)
+	if err != nil {
+		return fmt.Errorf("Error flattening `identity` for Event Grid System Topic %q (Resource Group %q): %+v", id.Name, id.ResourceGroup, err)
+	}
+	if err := d.Set("identity", identity); err != nil {
+		return fmt.Errorf("Error setting `identity` for Event Grid System Topic %q (Resource Group %q): %+v", id.Name, id.ResourceGroup, err)
+	}
+
 	if props := resp.SystemTopicProperties; props != nil {
 		d.Set("source_arm_resource_id", props.Source)
 		d.Set("topic_type", props.TopicType)
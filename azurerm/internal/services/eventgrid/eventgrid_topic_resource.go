@@ -137,6 +137,8 @@ func resourceEventGridTopic() *pluginsdk.Resource {
 				},
 			},
 
+			"identity": eventGridIdentity{}.Schema(),
+
 			"public_network_access_enabled": eventSubscriptionPublicNetworkAccessEnabled(),
 
 			"inbound_ip_rule": eventSubscriptionInboundIPRule(),
@@ -194,9 +196,15 @@ func resourceEventGridTopicCreateUpdate(d *pluginsdk.ResourceData, meta interfac
 		InboundIPRules:      expandInboundIPRules(d),
 	}
 
+	identity, err := expandEventGridIdentity(d.Get("identity").([]interface{}))
+	if err != nil {
+		return fmt.Errorf("expanding `identity`: %+v", err)
+	}
+
 	properties := eventgrid.Topic{
 		Location:        &location,
 		TopicProperties: topicProperties,
+		Identity:        identity,
 		Tags:            tags.Expand(t),
 	}
 
@@ -244,6 +252,14 @@ func resourceEventGridTopicRead(d *pluginsdk.ResourceData, meta interface{}) err
 
 		return fmt.Errorf("making Read request on EventGrid Topic '%s': %+v", id.Name, err)
 	}
+	identity, err := flattenEventGridIdentity(resp.Identity)
+	if err != nil {
+		return fmt.Errorf("flattening `identity` for EventGrid Topic %q (Resource Group %q): %+v", id.Name, id.ResourceGroup, err)
+	}
+	if err := d.Set("identity", identity); err != nil {
+		return fmt.Errorf("setting `identity` for EventGrid Topic %q (Resource Group %q): %+v", id.Name, id.ResourceGroup, err)
+	}
+
 	if props := resp.TopicProperties; props != nil {
 		d.Set("endpoint", props.Endpoint)
 
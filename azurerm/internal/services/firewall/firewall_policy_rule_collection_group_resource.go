@@ -1,9 +1,12 @@
 package firewall
 
 import (
+	"bytes"
+	"context"
 	"fmt"
 	"log"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2020-11-01/network"
@@ -64,6 +67,7 @@ func resourceFirewallPolicyRuleCollectionGroup() *pluginsdk.Resource {
 				Type:     pluginsdk.TypeSet,
 				Optional: true,
 				MinItems: 1,
+				Set:      firewallPolicyRuleCollectionGroupNameHash,
 				Elem: &pluginsdk.Resource{
 					Schema: map[string]*pluginsdk.Schema{
 						"name": {
@@ -88,6 +92,7 @@ func resourceFirewallPolicyRuleCollectionGroup() *pluginsdk.Resource {
 							Type:     pluginsdk.TypeSet,
 							Required: true,
 							MinItems: 1,
+							Set:      firewallPolicyRuleCollectionGroupNameHash,
 							Elem: &pluginsdk.Resource{
 								Schema: map[string]*pluginsdk.Schema{
 									"name": {
@@ -163,6 +168,7 @@ func resourceFirewallPolicyRuleCollectionGroup() *pluginsdk.Resource {
 				Type:     pluginsdk.TypeSet,
 				Optional: true,
 				MinItems: 1,
+				Set:      firewallPolicyRuleCollectionGroupNameHash,
 				Elem: &pluginsdk.Resource{
 					Schema: map[string]*pluginsdk.Schema{
 						"name": {
@@ -187,6 +193,7 @@ func resourceFirewallPolicyRuleCollectionGroup() *pluginsdk.Resource {
 							Type:     pluginsdk.TypeSet,
 							Required: true,
 							MinItems: 1,
+							Set:      firewallPolicyRuleCollectionGroupNameHash,
 							Elem: &pluginsdk.Resource{
 								Schema: map[string]*pluginsdk.Schema{
 									"name": {
@@ -274,6 +281,7 @@ func resourceFirewallPolicyRuleCollectionGroup() *pluginsdk.Resource {
 				Type:     pluginsdk.TypeSet,
 				Optional: true,
 				MinItems: 1,
+				Set:      firewallPolicyRuleCollectionGroupNameHash,
 				Elem: &pluginsdk.Resource{
 					Schema: map[string]*pluginsdk.Schema{
 						"name": {
@@ -301,6 +309,7 @@ func resourceFirewallPolicyRuleCollectionGroup() *pluginsdk.Resource {
 							Type:     pluginsdk.TypeSet,
 							Required: true,
 							MinItems: 1,
+							Set:      firewallPolicyRuleCollectionGroupNameHash,
 							Elem: &pluginsdk.Resource{
 								Schema: map[string]*pluginsdk.Schema{
 									"name": {
@@ -372,6 +381,8 @@ func resourceFirewallPolicyRuleCollectionGroup() *pluginsdk.Resource {
 				},
 			},
 		},
+
+		CustomizeDiff: pluginsdk.CustomizeDiffShim(resourceFirewallPolicyRuleCollectionGroupCustomizeDiff),
 	}
 }
 
@@ -852,3 +863,156 @@ func flattenFirewallPolicyRuleNat(input *[]network.BasicFirewallPolicyRule) ([]i
 	}
 	return output, nil
 }
+
+// resourceFirewallPolicyRuleCollectionGroupCustomizeDiff checks the names and priorities used within this Rule Collection Group
+// don't conflict with those in the other Rule Collection Groups within the same Firewall Policy, which would otherwise only be
+// surfaced by the API during the apply.
+func resourceFirewallPolicyRuleCollectionGroupCustomizeDiff(ctx context.Context, d *pluginsdk.ResourceDiff, meta interface{}) error {
+	for _, key := range []string{"name", "firewall_policy_id", "priority", "application_rule_collection", "network_rule_collection", "nat_rule_collection"} {
+		if !d.NewValueKnown(key) {
+			return nil
+		}
+	}
+
+	client := meta.(*clients.Client).Firewall.FirewallPolicyRuleGroupClient
+
+	policyId, err := parse.FirewallPolicyID(d.Get("firewall_policy_id").(string))
+	if err != nil {
+		return err
+	}
+	name := d.Get("name").(string)
+
+	collections := make([]validate.FirewallPolicyRuleCollection, 0)
+	for _, key := range []string{"application_rule_collection", "network_rule_collection", "nat_rule_collection"} {
+		for _, item := range d.Get(key).(*pluginsdk.Set).List() {
+			if item == nil {
+				continue
+			}
+			collection := item.(map[string]interface{})
+
+			ruleNames := make([]string, 0)
+			for _, r := range collection["rule"].(*pluginsdk.Set).List() {
+				if r == nil {
+					continue
+				}
+				ruleNames = append(ruleNames, r.(map[string]interface{})["name"].(string))
+			}
+
+			collections = append(collections, validate.FirewallPolicyRuleCollection{
+				Name:      collection["name"].(string),
+				Priority:  collection["priority"].(int),
+				RuleNames: ruleNames,
+			})
+		}
+	}
+
+	groups := []validate.FirewallPolicyRuleCollectionGroup{
+		{
+			Name:            name,
+			Priority:        d.Get("priority").(int),
+			RuleCollections: collections,
+		},
+	}
+
+	iterator, err := client.ListComplete(ctx, policyId.ResourceGroup, policyId.Name)
+	if err != nil {
+		if utils.ResponseWasNotFound(iterator.Response().Response) {
+			return nil
+		}
+		return fmt.Errorf("listing Rule Collection Groups for %s: %+v", policyId, err)
+	}
+	for iterator.NotDone() {
+		group := iterator.Value()
+		if group.Name != nil && !strings.EqualFold(*group.Name, name) {
+			groups = append(groups, firewallPolicyRuleCollectionGroupForValidation(group))
+		}
+
+		if err := iterator.NextWithContext(ctx); err != nil {
+			return fmt.Errorf("listing Rule Collection Groups for %s: %+v", policyId, err)
+		}
+	}
+
+	if err := validate.FirewallPolicyRuleCollectionGroups(groups); err != nil {
+		return fmt.Errorf("validating the Rule Collection Groups within %s: %+v", policyId, err)
+	}
+
+	return nil
+}
+
+func firewallPolicyRuleCollectionGroupForValidation(input network.FirewallPolicyRuleCollectionGroup) validate.FirewallPolicyRuleCollectionGroup {
+	output := validate.FirewallPolicyRuleCollectionGroup{
+		RuleCollections: make([]validate.FirewallPolicyRuleCollection, 0),
+	}
+	if input.Name != nil {
+		output.Name = *input.Name
+	}
+
+	props := input.FirewallPolicyRuleCollectionGroupProperties
+	if props == nil {
+		return output
+	}
+	if props.Priority != nil {
+		output.Priority = int(*props.Priority)
+	}
+	if props.RuleCollections == nil {
+		return output
+	}
+
+	for _, e := range *props.RuleCollections {
+		var name *string
+		var priority *int32
+		var rules *[]network.BasicFirewallPolicyRule
+
+		switch collection := e.(type) {
+		case network.FirewallPolicyFilterRuleCollection:
+			name, priority, rules = collection.Name, collection.Priority, collection.Rules
+		case network.FirewallPolicyNatRuleCollection:
+			name, priority, rules = collection.Name, collection.Priority, collection.Rules
+		default:
+			continue
+		}
+
+		ruleCollection := validate.FirewallPolicyRuleCollection{
+			RuleNames: make([]string, 0),
+		}
+		if name != nil {
+			ruleCollection.Name = *name
+		}
+		if priority != nil {
+			ruleCollection.Priority = int(*priority)
+		}
+		if rules != nil {
+			for _, r := range *rules {
+				var ruleName *string
+				switch rule := r.(type) {
+				case network.ApplicationRule:
+					ruleName = rule.Name
+				case network.Rule:
+					ruleName = rule.Name
+				case network.NatRule:
+					ruleName = rule.Name
+				}
+				if ruleName != nil {
+					ruleCollection.RuleNames = append(ruleCollection.RuleNames, *ruleName)
+				}
+			}
+		}
+
+		output.RuleCollections = append(output.RuleCollections, ruleCollection)
+	}
+
+	return output
+}
+
+// firewallPolicyRuleCollectionGroupNameHash hashes Rule Collections and Rules by their name alone, so that changing
+// one of their other attributes is shown as an in-place change to that attribute, rather than the whole block being
+// removed and added again.
+func firewallPolicyRuleCollectionGroupNameHash(v interface{}) int {
+	var buf bytes.Buffer
+
+	if m, ok := v.(map[string]interface{}); ok {
+		buf.WriteString(fmt.Sprintf("%s-", m["name"].(string)))
+	}
+
+	return pluginsdk.HashString(buf.String())
+}
@@ -3,6 +3,7 @@ package firewall_test
 import (
 	"context"
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/acceptance"
@@ -90,6 +91,24 @@ func TestAccFirewallPolicyRuleCollectionGroup_requiresImport(t *testing.T) {
 	})
 }
 
+func TestAccFirewallPolicyRuleCollectionGroup_conflictingPriority(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_firewall_policy_rule_collection_group", "test")
+	r := FirewallPolicyRuleCollectionGroupResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		{
+			Config:      r.conflictingPriority(data),
+			ExpectError: regexp.MustCompile("have the same priority 500"),
+		},
+	})
+}
+
 func (FirewallPolicyRuleCollectionGroupResource) Exists(ctx context.Context, clients *clients.Client, state *pluginsdk.InstanceState) (*bool, error) {
 	var id, err = parse.FirewallPolicyRuleCollectionGroupID(state.ID)
 	if err != nil {
@@ -418,3 +437,16 @@ resource "azurerm_firewall_policy_rule_collection_group" "import" {
 }
 `, template)
 }
+
+func (FirewallPolicyRuleCollectionGroupResource) conflictingPriority(data acceptance.TestData) string {
+	template := FirewallPolicyRuleCollectionGroupResource{}.basic(data)
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_firewall_policy_rule_collection_group" "other" {
+  name               = "acctest-fwpolicy-RCG-other-%d"
+  firewall_policy_id = azurerm_firewall_policy.test.id
+  priority           = 500
+}
+`, template, data.RandomInteger)
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/locks"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/firewall/parse"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/firewall/validate"
+	networkParse "github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/network/parse"
 	networkValidate "github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/network/validate"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tags"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tf/pluginsdk"
@@ -264,18 +265,14 @@ func resourceFirewallCreateUpdate(d *pluginsdk.ResourceData, meta interface{}) e
 
 	m := d.Get("management_ip_configuration").([]interface{})
 	if len(m) == 1 {
-		mgmtIPConfig, mgmtSubnetName, mgmtVirtualNetworkName, err := expandFirewallIPConfigurations(m)
+		mgmtIPConfig, mgmtSubnetToLock, mgmtVnetToLock, err := expandFirewallIPConfigurations(m)
 		if err != nil {
 			return fmt.Errorf("Error parsing Azure Firewall Management IP Configurations: %+v", err)
 		}
 
-		if !utils.SliceContainsValue(*subnetToLock, (*mgmtSubnetName)[0]) {
-			*subnetToLock = append(*subnetToLock, (*mgmtSubnetName)[0])
-		}
-
-		if !utils.SliceContainsValue(*vnetToLock, (*mgmtVirtualNetworkName)[0]) {
-			*vnetToLock = append(*vnetToLock, (*mgmtVirtualNetworkName)[0])
-		}
+		// duplicates are removed when locking
+		subnetToLock = append(subnetToLock, mgmtSubnetToLock...)
+		vnetToLock = append(vnetToLock, mgmtVnetToLock...)
 		if *mgmtIPConfig != nil {
 			parameters.ManagementIPConfiguration = &(*mgmtIPConfig)[0]
 		}
@@ -326,11 +323,8 @@ func resourceFirewallCreateUpdate(d *pluginsdk.ResourceData, meta interface{}) e
 	locks.ByName(name, azureFirewallResourceName)
 	defer locks.UnlockByName(name, azureFirewallResourceName)
 
-	locks.MultipleByName(vnetToLock, VirtualNetworkResourceName)
-	defer locks.UnlockMultipleByName(vnetToLock, VirtualNetworkResourceName)
-
-	locks.MultipleByName(subnetToLock, SubnetResourceName)
-	defer locks.UnlockMultipleByName(subnetToLock, SubnetResourceName)
+	locks.MultipleChildrenByResourceID(vnetToLock, subnetToLock)
+	defer locks.UnlockMultipleChildrenByResourceID(vnetToLock, subnetToLock)
 
 	if !d.IsNewResource() {
 		exists, err2 := client.Get(ctx, resourceGroup, name)
@@ -469,8 +463,8 @@ func resourceFirewallDelete(d *pluginsdk.ResourceData, meta interface{}) error {
 		return fmt.Errorf("Error retrieving Firewall %q (Resource Group %q): %+v", name, resourceGroup, err)
 	}
 
-	subnetNamesToLock := make([]string, 0)
-	virtualNetworkNamesToLock := make([]string, 0)
+	subnetIdsToLock := make([]locks.ResourceID, 0)
+	virtualNetworkIdsToLock := make([]locks.ResourceID, 0)
 	if props := read.AzureFirewallPropertiesFormat; props != nil {
 		if configs := props.IPConfigurations; configs != nil {
 			for _, config := range *configs {
@@ -478,39 +472,25 @@ func resourceFirewallDelete(d *pluginsdk.ResourceData, meta interface{}) error {
 					continue
 				}
 
-				parsedSubnetID, err2 := azure.ParseAzureResourceID(*config.Subnet.ID)
+				parsedSubnetID, err2 := networkParse.SubnetIDInsensitively(*config.Subnet.ID)
 				if err2 != nil {
 					return err2
 				}
-				subnetName := parsedSubnetID.Path["subnets"]
 
-				if !utils.SliceContainsValue(subnetNamesToLock, subnetName) {
-					subnetNamesToLock = append(subnetNamesToLock, subnetName)
-				}
-
-				virtualNetworkName := parsedSubnetID.Path["virtualNetworks"]
-				if !utils.SliceContainsValue(virtualNetworkNamesToLock, virtualNetworkName) {
-					virtualNetworkNamesToLock = append(virtualNetworkNamesToLock, virtualNetworkName)
-				}
+				subnetIdsToLock = append(subnetIdsToLock, *parsedSubnetID)
+				virtualNetworkIdsToLock = append(virtualNetworkIdsToLock, networkParse.NewVirtualNetworkID(parsedSubnetID.SubscriptionId, parsedSubnetID.ResourceGroup, parsedSubnetID.VirtualNetworkName))
 			}
 		}
 
 		if mconfig := props.ManagementIPConfiguration; mconfig != nil {
 			if mconfig.Subnet != nil && mconfig.Subnet.ID != nil {
-				parsedSubnetID, err2 := azure.ParseAzureResourceID(*mconfig.Subnet.ID)
+				parsedSubnetID, err2 := networkParse.SubnetIDInsensitively(*mconfig.Subnet.ID)
 				if err2 != nil {
 					return err2
 				}
-				subnetName := parsedSubnetID.Path["subnets"]
 
-				if !utils.SliceContainsValue(subnetNamesToLock, subnetName) {
-					subnetNamesToLock = append(subnetNamesToLock, subnetName)
-				}
-
-				virtualNetworkName := parsedSubnetID.Path["virtualNetworks"]
-				if !utils.SliceContainsValue(virtualNetworkNamesToLock, virtualNetworkName) {
-					virtualNetworkNamesToLock = append(virtualNetworkNamesToLock, virtualNetworkName)
-				}
+				subnetIdsToLock = append(subnetIdsToLock, *parsedSubnetID)
+				virtualNetworkIdsToLock = append(virtualNetworkIdsToLock, networkParse.NewVirtualNetworkID(parsedSubnetID.SubscriptionId, parsedSubnetID.ResourceGroup, parsedSubnetID.VirtualNetworkName))
 			}
 		}
 	}
@@ -518,11 +498,8 @@ func resourceFirewallDelete(d *pluginsdk.ResourceData, meta interface{}) error {
 	locks.ByName(name, azureFirewallResourceName)
 	defer locks.UnlockByName(name, azureFirewallResourceName)
 
-	locks.MultipleByName(&virtualNetworkNamesToLock, VirtualNetworkResourceName)
-	defer locks.UnlockMultipleByName(&virtualNetworkNamesToLock, VirtualNetworkResourceName)
-
-	locks.MultipleByName(&subnetNamesToLock, SubnetResourceName)
-	defer locks.UnlockMultipleByName(&subnetNamesToLock, SubnetResourceName)
+	locks.MultipleChildrenByResourceID(virtualNetworkIdsToLock, subnetIdsToLock)
+	defer locks.UnlockMultipleChildrenByResourceID(virtualNetworkIdsToLock, subnetIdsToLock)
 
 	future, err := client.Delete(ctx, resourceGroup, name)
 	if err != nil {
@@ -536,10 +513,10 @@ func resourceFirewallDelete(d *pluginsdk.ResourceData, meta interface{}) error {
 	return err
 }
 
-func expandFirewallIPConfigurations(configs []interface{}) (*[]network.AzureFirewallIPConfiguration, *[]string, *[]string, error) {
+func expandFirewallIPConfigurations(configs []interface{}) (*[]network.AzureFirewallIPConfiguration, []locks.ResourceID, []locks.ResourceID, error) {
 	ipConfigs := make([]network.AzureFirewallIPConfiguration, 0)
-	subnetNamesToLock := make([]string, 0)
-	virtualNetworkNamesToLock := make([]string, 0)
+	subnetIdsToLock := make([]locks.ResourceID, 0)
+	virtualNetworkIdsToLock := make([]locks.ResourceID, 0)
 
 	for _, configRaw := range configs {
 		data := configRaw.(map[string]interface{})
@@ -557,21 +534,13 @@ func expandFirewallIPConfigurations(configs []interface{}) (*[]network.AzureFire
 		}
 
 		if subnetId != "" {
-			subnetID, err := azure.ParseAzureResourceID(subnetId)
+			subnetID, err := networkParse.SubnetIDInsensitively(subnetId)
 			if err != nil {
 				return nil, nil, nil, err
 			}
 
-			subnetName := subnetID.Path["subnets"]
-			virtualNetworkName := subnetID.Path["virtualNetworks"]
-
-			if !utils.SliceContainsValue(subnetNamesToLock, subnetName) {
-				subnetNamesToLock = append(subnetNamesToLock, subnetName)
-			}
-
-			if !utils.SliceContainsValue(virtualNetworkNamesToLock, virtualNetworkName) {
-				virtualNetworkNamesToLock = append(virtualNetworkNamesToLock, virtualNetworkName)
-			}
+			subnetIdsToLock = append(subnetIdsToLock, *subnetID)
+			virtualNetworkIdsToLock = append(virtualNetworkIdsToLock, networkParse.NewVirtualNetworkID(subnetID.SubscriptionId, subnetID.ResourceGroup, subnetID.VirtualNetworkName))
 
 			ipConfig.AzureFirewallIPConfigurationPropertiesFormat.Subnet = &network.SubResource{
 				ID: utils.String(subnetId),
@@ -579,7 +548,7 @@ func expandFirewallIPConfigurations(configs []interface{}) (*[]network.AzureFire
 		}
 		ipConfigs = append(ipConfigs, ipConfig)
 	}
-	return &ipConfigs, &subnetNamesToLock, &virtualNetworkNamesToLock, nil
+	return &ipConfigs, subnetIdsToLock, virtualNetworkIdsToLock, nil
 }
 
 func flattenFirewallIPConfigurations(input *[]network.AzureFirewallIPConfiguration) []interface{} {
@@ -0,0 +1,96 @@
+package validate
+
+import (
+	"fmt"
+	"sort"
+
+	multierror "github.com/hashicorp/go-multierror"
+)
+
+// FirewallPolicyRuleCollectionGroup contains the names and priorities within a Firewall Policy Rule Collection Group
+// which must not conflict with the other Rule Collection Groups in the same Firewall Policy.
+type FirewallPolicyRuleCollectionGroup struct {
+	Name            string
+	Priority        int
+	RuleCollections []FirewallPolicyRuleCollection
+}
+
+type FirewallPolicyRuleCollection struct {
+	Name      string
+	Priority  int
+	RuleNames []string
+}
+
+// FirewallPolicyRuleCollectionGroups validates the Rule Collection Groups within a single Firewall Policy, checking that:
+//
+// * the priority of each Rule Collection Group is unique within the Firewall Policy
+// * the name and priority of each Rule Collection is unique within its Rule Collection Group
+// * the name of each Rule is unique across all of the Rule Collection Groups
+func FirewallPolicyRuleCollectionGroups(groups []FirewallPolicyRuleCollectionGroup) error {
+	var err *multierror.Error
+
+	groupPriorities := make(map[int][]string)
+	ruleLocations := make(map[string][]string)
+	for _, group := range groups {
+		groupPriorities[group.Priority] = append(groupPriorities[group.Priority], group.Name)
+
+		collectionNames := make(map[string]int)
+		collectionPriorities := make(map[int][]string)
+		for _, collection := range group.RuleCollections {
+			collectionNames[collection.Name]++
+			collectionPriorities[collection.Priority] = append(collectionPriorities[collection.Priority], collection.Name)
+
+			for _, rule := range collection.RuleNames {
+				location := fmt.Sprintf("%q (Rule Collection Group %q)", collection.Name, group.Name)
+				ruleLocations[rule] = append(ruleLocations[rule], location)
+			}
+		}
+
+		for _, name := range sortedKeysWithMultipleValues(collectionNames) {
+			err = multierror.Append(err, fmt.Errorf("the Rule Collection name %q is used %d times in the Rule Collection Group %q", name, collectionNames[name], group.Name))
+		}
+
+		for _, priority := range sortedPrioritiesWithMultipleNames(collectionPriorities) {
+			err = multierror.Append(err, fmt.Errorf("the Rule Collections %q in the Rule Collection Group %q have the same priority %d", collectionPriorities[priority], group.Name, priority))
+		}
+	}
+
+	for _, priority := range sortedPrioritiesWithMultipleNames(groupPriorities) {
+		err = multierror.Append(err, fmt.Errorf("the Rule Collection Groups %q in the same Firewall Policy have the same priority %d", groupPriorities[priority], priority))
+	}
+
+	ruleNames := make([]string, 0)
+	for name, locations := range ruleLocations {
+		if len(locations) > 1 {
+			ruleNames = append(ruleNames, name)
+		}
+	}
+	sort.Strings(ruleNames)
+	for _, name := range ruleNames {
+		err = multierror.Append(err, fmt.Errorf("the Rule name %q is used more than once in the Firewall Policy, in the Rule Collections %v", name, ruleLocations[name]))
+	}
+
+	return err.ErrorOrNil()
+}
+
+func sortedKeysWithMultipleValues(input map[string]int) []string {
+	output := make([]string, 0)
+	for k, v := range input {
+		if v > 1 {
+			output = append(output, k)
+		}
+	}
+	sort.Strings(output)
+	return output
+}
+
+func sortedPrioritiesWithMultipleNames(input map[int][]string) []int {
+	output := make([]int, 0)
+	for k, v := range input {
+		if len(v) > 1 {
+			output = append(output, k)
+		}
+	}
+	sort.Ints(output)
+	return output
+}
@@ -0,0 +1,115 @@
+package validate
+
+import (
+	"testing"
+)
+
+func TestFirewallPolicyRuleCollectionGroups(t *testing.T) {
+	testData := []struct {
+		Name     string
+		Input    []FirewallPolicyRuleCollectionGroup
+		ErrCount int
+	}{
+		{
+			Name:     "Empty",
+			Input:    []FirewallPolicyRuleCollectionGroup{},
+			ErrCount: 0,
+		},
+		{
+			Name: "Valid",
+			Input: []FirewallPolicyRuleCollectionGroup{
+				{
+					Name:     "group1",
+					Priority: 100,
+					RuleCollections: []FirewallPolicyRuleCollection{
+						{Name: "app", Priority: 100, RuleNames: []string{"rule1", "rule2"}},
+						{Name: "network", Priority: 200, RuleNames: []string{"rule3"}},
+					},
+				},
+				{
+					Name:     "group2",
+					Priority: 200,
+					RuleCollections: []FirewallPolicyRuleCollection{
+						// collection names and priorities only need to be unique within a group
+						{Name: "app", Priority: 100, RuleNames: []string{"rule4"}},
+					},
+				},
+			},
+			ErrCount: 0,
+		},
+		{
+			Name: "Duplicate Group Priority",
+			Input: []FirewallPolicyRuleCollectionGroup{
+				{Name: "group1", Priority: 100},
+				{Name: "group2", Priority: 100},
+				{Name: "group3", Priority: 100},
+			},
+			ErrCount: 1,
+		},
+		{
+			Name: "Duplicate Collection Name And Priority",
+			Input: []FirewallPolicyRuleCollectionGroup{
+				{
+					Name:     "group1",
+					Priority: 100,
+					RuleCollections: []FirewallPolicyRuleCollection{
+						{Name: "app", Priority: 100, RuleNames: []string{"rule1"}},
+						{Name: "app", Priority: 100, RuleNames: []string{"rule2"}},
+					},
+				},
+			},
+			ErrCount: 2,
+		},
+		{
+			Name: "Duplicate Rule Name Within A Collection",
+			Input: []FirewallPolicyRuleCollectionGroup{
+				{
+					Name:     "group1",
+					Priority: 100,
+					RuleCollections: []FirewallPolicyRuleCollection{
+						{Name: "app", Priority: 100, RuleNames: []string{"rule1", "rule1"}},
+					},
+				},
+			},
+			ErrCount: 1,
+		},
+		{
+			Name: "Duplicate Rule Names Across Groups",
+			Input: []FirewallPolicyRuleCollectionGroup{
+				{
+					Name:     "group1",
+					Priority: 100,
+					RuleCollections: []FirewallPolicyRuleCollection{
+						{Name: "app", Priority: 100, RuleNames: []string{"rule1", "rule2"}},
+					},
+				},
+				{
+					Name:     "group2",
+					Priority: 200,
+					RuleCollections: []FirewallPolicyRuleCollection{
+						{Name: "network", Priority: 100, RuleNames: []string{"rule1", "rule2"}},
+					},
+				},
+			},
+			ErrCount: 2,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Name)
+
+		err := FirewallPolicyRuleCollectionGroups(v.Input)
+		errCount := 0
+		if err != nil {
+			if merr, ok := err.(interface{ WrappedErrors() []error }); ok {
+				errCount = len(merr.WrappedErrors())
+			} else {
+				errCount = 1
+			}
+		}
+
+		if errCount != v.ErrCount {
+			t.Fatalf("Expected %d errors but got %d: %+v", v.ErrCount, errCount, err)
+		}
+	}
+}
@@ -22,7 +22,6 @@ import (
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/keyvault/migration"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/keyvault/parse"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/keyvault/validate"
-	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/network"
 	networkParse "github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/network/parse"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tags"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tf/pluginsdk"
@@ -340,20 +339,21 @@ func resourceKeyVaultCreate(d *pluginsdk.ResourceData, meta interface{}) error {
 		parameters.Properties.CreateMode = keyvault.CreateModeRecover
 	}
 
-	// also lock on the Virtual Network ID's since modifications in the networking stack are exclusive
-	virtualNetworkNames := make([]string, 0)
+	// also lock on the Subnets (sharing a lock on their Virtual Networks) since modifications in the networking stack are exclusive
+	subnetIdsToLock := make([]locks.ResourceID, 0)
+	virtualNetworkIdsToLock := make([]locks.ResourceID, 0)
 	for _, v := range subnetIds {
 		id, err := networkParse.SubnetIDInsensitively(v)
 		if err != nil {
 			return err
 		}
-		if !utils.SliceContainsValue(virtualNetworkNames, id.VirtualNetworkName) {
-			virtualNetworkNames = append(virtualNetworkNames, id.VirtualNetworkName)
-		}
+
+		subnetIdsToLock = append(subnetIdsToLock, *id)
+		virtualNetworkIdsToLock = append(virtualNetworkIdsToLock, networkParse.NewVirtualNetworkID(id.SubscriptionId, id.ResourceGroup, id.VirtualNetworkName))
 	}
 
-	locks.MultipleByName(&virtualNetworkNames, network.VirtualNetworkResourceName)
-	defer locks.UnlockMultipleByName(&virtualNetworkNames, network.VirtualNetworkResourceName)
+	locks.MultipleChildrenByResourceID(virtualNetworkIdsToLock, subnetIdsToLock)
+	defer locks.UnlockMultipleChildrenByResourceID(virtualNetworkIdsToLock, subnetIdsToLock)
 
 	if _, err := client.CreateOrUpdate(ctx, id.ResourceGroup, id.Name, parameters); err != nil {
 		return fmt.Errorf("creating %s: %+v", id, err)
@@ -482,21 +482,21 @@ func resourceKeyVaultUpdate(d *pluginsdk.ResourceData, meta interface{}) error {
 		networkAclsRaw := d.Get("network_acls").([]interface{})
 		networkAcls, subnetIds := expandKeyVaultNetworkAcls(networkAclsRaw)
 
-		// also lock on the Virtual Network ID's since modifications in the networking stack are exclusive
-		virtualNetworkNames := make([]string, 0)
+		// also lock on the Subnets (sharing a lock on their Virtual Networks) since modifications in the networking stack are exclusive
+		subnetIdsToLock := make([]locks.ResourceID, 0)
+		virtualNetworkIdsToLock := make([]locks.ResourceID, 0)
 		for _, v := range subnetIds {
 			id, err := networkParse.SubnetIDInsensitively(v)
 			if err != nil {
 				return err
 			}
 
-			if !utils.SliceContainsValue(virtualNetworkNames, id.VirtualNetworkName) {
-				virtualNetworkNames = append(virtualNetworkNames, id.VirtualNetworkName)
-			}
+			subnetIdsToLock = append(subnetIdsToLock, *id)
+			virtualNetworkIdsToLock = append(virtualNetworkIdsToLock, networkParse.NewVirtualNetworkID(id.SubscriptionId, id.ResourceGroup, id.VirtualNetworkName))
 		}
 
-		locks.MultipleByName(&virtualNetworkNames, network.VirtualNetworkResourceName)
-		defer locks.UnlockMultipleByName(&virtualNetworkNames, network.VirtualNetworkResourceName)
+		locks.MultipleChildrenByResourceID(virtualNetworkIdsToLock, subnetIdsToLock)
+		defer locks.UnlockMultipleChildrenByResourceID(virtualNetworkIdsToLock, subnetIdsToLock)
 
 		update.Properties.NetworkAcls = networkAcls
 	}
@@ -727,8 +727,9 @@ func resourceKeyVaultDelete(d *pluginsdk.ResourceData, meta interface{}) error {
 		softDeleteEnabled = *sde
 	}
 
-	// ensure we lock on the latest network names, to ensure we handle Azure's networking layer being limited to one change at a time
-	virtualNetworkNames := make([]string, 0)
+	// ensure we lock on the latest subnets, to ensure we handle Azure's networking layer being limited to one change at a time
+	subnetIdsToLock := make([]locks.ResourceID, 0)
+	virtualNetworkIdsToLock := make([]locks.ResourceID, 0)
 	if props := read.Properties; props != nil {
 		if acls := props.NetworkAcls; acls != nil {
 			if rules := acls.VirtualNetworkRules; rules != nil {
@@ -742,16 +743,15 @@ func resourceKeyVaultDelete(d *pluginsdk.ResourceData, meta interface{}) error {
 						return err
 					}
 
-					if !utils.SliceContainsValue(virtualNetworkNames, subnetId.VirtualNetworkName) {
-						virtualNetworkNames = append(virtualNetworkNames, subnetId.VirtualNetworkName)
-					}
+					subnetIdsToLock = append(subnetIdsToLock, *subnetId)
+					virtualNetworkIdsToLock = append(virtualNetworkIdsToLock, networkParse.NewVirtualNetworkID(subnetId.SubscriptionId, subnetId.ResourceGroup, subnetId.VirtualNetworkName))
 				}
 			}
 		}
 	}
 
-	locks.MultipleByName(&virtualNetworkNames, network.VirtualNetworkResourceName)
-	defer locks.UnlockMultipleByName(&virtualNetworkNames, network.VirtualNetworkResourceName)
+	locks.MultipleChildrenByResourceID(virtualNetworkIdsToLock, subnetIdsToLock)
+	defer locks.UnlockMultipleChildrenByResourceID(virtualNetworkIdsToLock, subnetIdsToLock)
 
 	resp, err := client.Delete(ctx, id.ResourceGroup, id.Name)
 	if err != nil {
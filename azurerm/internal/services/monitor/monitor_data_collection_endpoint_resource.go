@@ -0,0 +1,209 @@
+package monitor
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/preview/monitor/mgmt/2021-04-01-preview/insights"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/azure"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/tf"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/clients"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/location"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/monitor/parse"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/monitor/validate"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tags"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tf/pluginsdk"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tf/validation"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/timeouts"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+func resourceMonitorDataCollectionEndpoint() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		Create: resourceMonitorDataCollectionEndpointCreateUpdate,
+		Read:   resourceMonitorDataCollectionEndpointRead,
+		Update: resourceMonitorDataCollectionEndpointCreateUpdate,
+		Delete: resourceMonitorDataCollectionEndpointDelete,
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Create: pluginsdk.DefaultTimeout(30 * time.Minute),
+			Read:   pluginsdk.DefaultTimeout(5 * time.Minute),
+			Update: pluginsdk.DefaultTimeout(30 * time.Minute),
+			Delete: pluginsdk.DefaultTimeout(30 * time.Minute),
+		},
+
+		Importer: pluginsdk.ImporterValidatingResourceId(func(id string) error {
+			_, err := parse.DataCollectionEndpointID(id)
+			return err
+		}),
+
+		Schema: map[string]*pluginsdk.Schema{
+			"name": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validate.DataCollectionEndpointName,
+			},
+
+			"resource_group_name": azure.SchemaResourceGroupName(),
+
+			"location": azure.SchemaLocation(),
+
+			"kind": {
+				Type:     pluginsdk.TypeString,
+				Optional: true,
+				ForceNew: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					string(insights.KnownDataCollectionEndpointResourceKindLinux),
+					string(insights.KnownDataCollectionEndpointResourceKindWindows),
+				}, false),
+			},
+
+			"description": {
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"public_network_access_enabled": {
+				Type:     pluginsdk.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+
+			"configuration_access_endpoint": {
+				Type:     pluginsdk.TypeString,
+				Computed: true,
+			},
+
+			"logs_ingestion_endpoint": {
+				Type:     pluginsdk.TypeString,
+				Computed: true,
+			},
+
+			"immutable_id": {
+				Type:     pluginsdk.TypeString,
+				Computed: true,
+			},
+
+			"tags": tags.Schema(),
+		},
+	}
+}
+
+func resourceMonitorDataCollectionEndpointCreateUpdate(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Monitor.DataCollectionEndpointsClient
+	subscriptionId := meta.(*clients.Client).Account.SubscriptionId
+	ctx, cancel := timeouts.ForCreateUpdate(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id := parse.NewDataCollectionEndpointID(subscriptionId, d.Get("resource_group_name").(string), d.Get("name").(string))
+
+	if d.IsNewResource() {
+		existing, err := client.Get(ctx, id.ResourceGroup, id.Name)
+		if err != nil {
+			if !utils.ResponseWasNotFound(existing.Response) {
+				return fmt.Errorf("checking for presence of existing %s: %+v", id, err)
+			}
+		}
+
+		if existing.ID != nil && *existing.ID != "" {
+			return tf.ImportAsExistsError("azurerm_monitor_data_collection_endpoint", id.ID())
+		}
+	}
+
+	publicNetworkAccess := insights.KnownPublicNetworkAccessOptionsDisabled
+	if d.Get("public_network_access_enabled").(bool) {
+		publicNetworkAccess = insights.KnownPublicNetworkAccessOptionsEnabled
+	}
+
+	parameters := insights.DataCollectionEndpointResource{
+		Location: utils.String(location.Normalize(d.Get("location").(string))),
+		Kind:     insights.KnownDataCollectionEndpointResourceKind(d.Get("kind").(string)),
+		DataCollectionEndpointResourceProperties: &insights.DataCollectionEndpointResourceProperties{
+			Description: utils.String(d.Get("description").(string)),
+			NetworkAcls: &insights.DataCollectionEndpointNetworkAcls{
+				PublicNetworkAccess: publicNetworkAccess,
+			},
+		},
+		Tags: tags.Expand(d.Get("tags").(map[string]interface{})),
+	}
+
+	if _, err := client.Create(ctx, id.ResourceGroup, id.Name, &parameters); err != nil {
+		return fmt.Errorf("creating/updating %s: %+v", id, err)
+	}
+
+	d.SetId(id.ID())
+	return resourceMonitorDataCollectionEndpointRead(d, meta)
+}
+
+func resourceMonitorDataCollectionEndpointRead(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Monitor.DataCollectionEndpointsClient
+	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := parse.DataCollectionEndpointID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.Get(ctx, id.ResourceGroup, id.Name)
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			log.Printf("[INFO] %s does not exist - removing from state", *id)
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("retrieving %s: %+v", *id, err)
+	}
+
+	d.Set("name", id.Name)
+	d.Set("resource_group_name", id.ResourceGroup)
+	d.Set("location", location.NormalizeNilable(resp.Location))
+	d.Set("kind", string(resp.Kind))
+
+	if props := resp.DataCollectionEndpointResourceProperties; props != nil {
+		d.Set("description", props.Description)
+		d.Set("immutable_id", props.ImmutableID)
+
+		configurationAccessEndpoint := ""
+		if props.ConfigurationAccess != nil && props.ConfigurationAccess.Endpoint != nil {
+			configurationAccessEndpoint = *props.ConfigurationAccess.Endpoint
+		}
+		d.Set("configuration_access_endpoint", configurationAccessEndpoint)
+
+		logsIngestionEndpoint := ""
+		if props.LogsIngestion != nil && props.LogsIngestion.Endpoint != nil {
+			logsIngestionEndpoint = *props.LogsIngestion.Endpoint
+		}
+		d.Set("logs_ingestion_endpoint", logsIngestionEndpoint)
+
+		publicNetworkAccessEnabled := true
+		if props.NetworkAcls != nil {
+			publicNetworkAccessEnabled = props.NetworkAcls.PublicNetworkAccess != insights.KnownPublicNetworkAccessOptionsDisabled
+		}
+		d.Set("public_network_access_enabled", publicNetworkAccessEnabled)
+	}
+
+	return tags.FlattenAndSet(d, resp.Tags)
+}
+
+func resourceMonitorDataCollectionEndpointDelete(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Monitor.DataCollectionEndpointsClient
+	ctx, cancel := timeouts.ForDelete(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := parse.DataCollectionEndpointID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	if resp, err := client.Delete(ctx, id.ResourceGroup, id.Name); err != nil {
+		if !utils.ResponseWasNotFound(resp) {
+			return fmt.Errorf("deleting %s: %+v", *id, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,202 @@
+package monitor
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/preview/monitor/mgmt/2021-04-01-preview/insights"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/azure"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/tf"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/clients"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/monitor/parse"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/monitor/validate"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tf/pluginsdk"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tf/validation"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/timeouts"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+// the API requires associations with a Data Collection Endpoint to use this name
+const dataCollectionEndpointAssociationName = "configurationAccessEndpoint"
+
+func resourceMonitorDataCollectionRuleAssociation() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		Create: resourceMonitorDataCollectionRuleAssociationCreateUpdate,
+		Read:   resourceMonitorDataCollectionRuleAssociationRead,
+		Update: resourceMonitorDataCollectionRuleAssociationCreateUpdate,
+		Delete: resourceMonitorDataCollectionRuleAssociationDelete,
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Create: pluginsdk.DefaultTimeout(30 * time.Minute),
+			Read:   pluginsdk.DefaultTimeout(5 * time.Minute),
+			Update: pluginsdk.DefaultTimeout(30 * time.Minute),
+			Delete: pluginsdk.DefaultTimeout(30 * time.Minute),
+		},
+
+		Importer: pluginsdk.ImporterValidatingResourceId(func(id string) error {
+			_, err := parse.DataCollectionRuleAssociationID(id)
+			return err
+		}),
+
+		Schema: map[string]*pluginsdk.Schema{
+			"name": {
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      dataCollectionEndpointAssociationName,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"target_resource_id": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: azure.ValidateResourceID,
+			},
+
+			"data_collection_rule_id": {
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				ValidateFunc: validate.DataCollectionRuleID,
+				ExactlyOneOf: []string{"data_collection_rule_id", "data_collection_endpoint_id"},
+			},
+
+			"data_collection_endpoint_id": {
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				ValidateFunc: validate.DataCollectionEndpointID,
+				ExactlyOneOf: []string{"data_collection_rule_id", "data_collection_endpoint_id"},
+			},
+
+			"description": {
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+		},
+	}
+}
+
+func resourceMonitorDataCollectionRuleAssociationCreateUpdate(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Monitor.DataCollectionRuleAssociationsClient
+	ctx, cancel := timeouts.ForCreateUpdate(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id := parse.NewDataCollectionRuleAssociationID(d.Get("target_resource_id").(string), d.Get("name").(string))
+
+	// the Azure SDK prefixes the URI with a `/` such this makes a bad request if we don't trim the `/`
+	targetResourceId := strings.TrimPrefix(id.TargetResourceId, "/")
+
+	if d.IsNewResource() {
+		existing, err := client.Get(ctx, targetResourceId, id.Name)
+		if err != nil {
+			if !utils.ResponseWasNotFound(existing.Response) {
+				return fmt.Errorf("checking for presence of existing %s: %+v", id, err)
+			}
+		}
+
+		if existing.ID != nil && *existing.ID != "" {
+			return tf.ImportAsExistsError("azurerm_monitor_data_collection_rule_association", id.ID())
+		}
+	}
+
+	properties := insights.DataCollectionRuleAssociationProxyOnlyResourceProperties{
+		Description: utils.String(d.Get("description").(string)),
+	}
+
+	if v := d.Get("data_collection_rule_id").(string); v != "" {
+		if id.Name == dataCollectionEndpointAssociationName {
+			return fmt.Errorf("the name %q is reserved for associations with a Data Collection Endpoint, please specify a different `name`", dataCollectionEndpointAssociationName)
+		}
+		properties.DataCollectionRuleID = utils.String(v)
+	}
+
+	if v := d.Get("data_collection_endpoint_id").(string); v != "" {
+		if id.Name != dataCollectionEndpointAssociationName {
+			return fmt.Errorf("`name` must be %q when associating a Data Collection Endpoint", dataCollectionEndpointAssociationName)
+		}
+		properties.DataCollectionEndpointID = utils.String(v)
+	}
+
+	parameters := insights.DataCollectionRuleAssociationProxyOnlyResource{
+		DataCollectionRuleAssociationProxyOnlyResourceProperties: &properties,
+	}
+
+	if _, err := client.Create(ctx, targetResourceId, id.Name, &parameters); err != nil {
+		return fmt.Errorf("creating/updating %s: %+v", id, err)
+	}
+
+	d.SetId(id.ID())
+	return resourceMonitorDataCollectionRuleAssociationRead(d, meta)
+}
+
+func resourceMonitorDataCollectionRuleAssociationRead(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Monitor.DataCollectionRuleAssociationsClient
+	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := parse.DataCollectionRuleAssociationID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.Get(ctx, strings.TrimPrefix(id.TargetResourceId, "/"), id.Name)
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			log.Printf("[INFO] %s does not exist - removing from state", *id)
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("retrieving %s: %+v", *id, err)
+	}
+
+	d.Set("name", id.Name)
+	d.Set("target_resource_id", id.TargetResourceId)
+
+	if props := resp.DataCollectionRuleAssociationProxyOnlyResourceProperties; props != nil {
+		d.Set("description", props.Description)
+
+		dataCollectionRuleId := ""
+		if props.DataCollectionRuleID != nil && *props.DataCollectionRuleID != "" {
+			parsedId, err := parse.DataCollectionRuleID(*props.DataCollectionRuleID)
+			if err != nil {
+				return err
+			}
+			dataCollectionRuleId = parsedId.ID()
+		}
+		d.Set("data_collection_rule_id", dataCollectionRuleId)
+
+		dataCollectionEndpointId := ""
+		if props.DataCollectionEndpointID != nil && *props.DataCollectionEndpointID != "" {
+			parsedId, err := parse.DataCollectionEndpointID(*props.DataCollectionEndpointID)
+			if err != nil {
+				return err
+			}
+			dataCollectionEndpointId = parsedId.ID()
+		}
+		d.Set("data_collection_endpoint_id", dataCollectionEndpointId)
+	}
+
+	return nil
+}
+
+func resourceMonitorDataCollectionRuleAssociationDelete(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Monitor.DataCollectionRuleAssociationsClient
+	ctx, cancel := timeouts.ForDelete(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := parse.DataCollectionRuleAssociationID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	if resp, err := client.Delete(ctx, strings.TrimPrefix(id.TargetResourceId, "/"), id.Name); err != nil {
+		if !utils.ResponseWasNotFound(resp) {
+			return fmt.Errorf("deleting %s: %+v", *id, err)
+		}
+	}
+
+	return nil
+}
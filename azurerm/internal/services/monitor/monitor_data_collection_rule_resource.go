@@ -0,0 +1,883 @@
+package monitor
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/preview/monitor/mgmt/2021-04-01-preview/insights"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/azure"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/tf"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/clients"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/location"
+	logAnalyticsParse "github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/loganalytics/parse"
+	logAnalyticsValidate "github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/loganalytics/validate"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/monitor/parse"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/monitor/validate"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tags"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tf/pluginsdk"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tf/validation"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/timeouts"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+func resourceMonitorDataCollectionRule() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		Create: resourceMonitorDataCollectionRuleCreateUpdate,
+		Read:   resourceMonitorDataCollectionRuleRead,
+		Update: resourceMonitorDataCollectionRuleCreateUpdate,
+		Delete: resourceMonitorDataCollectionRuleDelete,
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Create: pluginsdk.DefaultTimeout(30 * time.Minute),
+			Read:   pluginsdk.DefaultTimeout(5 * time.Minute),
+			Update: pluginsdk.DefaultTimeout(30 * time.Minute),
+			Delete: pluginsdk.DefaultTimeout(30 * time.Minute),
+		},
+
+		Importer: pluginsdk.ImporterValidatingResourceId(func(id string) error {
+			_, err := parse.DataCollectionRuleID(id)
+			return err
+		}),
+
+		CustomizeDiff: pluginsdk.CustomizeDiffShim(resourceMonitorDataCollectionRuleCustomizeDiff),
+
+		Schema: map[string]*pluginsdk.Schema{
+			"name": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validate.DataCollectionRuleName,
+			},
+
+			"resource_group_name": azure.SchemaResourceGroupName(),
+
+			"location": azure.SchemaLocation(),
+
+			"kind": {
+				Type:     pluginsdk.TypeString,
+				Optional: true,
+				ForceNew: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					string(insights.KnownDataCollectionRuleResourceKindLinux),
+					string(insights.KnownDataCollectionRuleResourceKindWindows),
+				}, false),
+			},
+
+			"description": {
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"data_sources": {
+				Type:     pluginsdk.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"performance_counter": {
+							Type:     pluginsdk.TypeList,
+							Optional: true,
+							Elem: &pluginsdk.Resource{
+								Schema: map[string]*pluginsdk.Schema{
+									"name": {
+										Type:         pluginsdk.TypeString,
+										Required:     true,
+										ValidateFunc: validation.StringIsNotEmpty,
+									},
+
+									"streams": {
+										Type:     pluginsdk.TypeList,
+										Required: true,
+										MinItems: 1,
+										Elem: &pluginsdk.Schema{
+											Type: pluginsdk.TypeString,
+											ValidateFunc: validation.StringInSlice([]string{
+												string(insights.KnownPerfCounterDataSourceStreamsMicrosoftInsightsMetrics),
+												string(insights.KnownPerfCounterDataSourceStreamsMicrosoftPerf),
+											}, false),
+										},
+									},
+
+									"sampling_frequency_in_seconds": {
+										Type:         pluginsdk.TypeInt,
+										Required:     true,
+										ValidateFunc: validation.IntBetween(1, 1800),
+									},
+
+									"counter_specifiers": {
+										Type:     pluginsdk.TypeList,
+										Required: true,
+										MinItems: 1,
+										Elem: &pluginsdk.Schema{
+											Type:         pluginsdk.TypeString,
+											ValidateFunc: validation.StringIsNotEmpty,
+										},
+									},
+								},
+							},
+						},
+
+						"windows_event_log": {
+							Type:     pluginsdk.TypeList,
+							Optional: true,
+							Elem: &pluginsdk.Resource{
+								Schema: map[string]*pluginsdk.Schema{
+									"name": {
+										Type:         pluginsdk.TypeString,
+										Required:     true,
+										ValidateFunc: validation.StringIsNotEmpty,
+									},
+
+									"streams": {
+										Type:     pluginsdk.TypeList,
+										Required: true,
+										MinItems: 1,
+										Elem: &pluginsdk.Schema{
+											Type: pluginsdk.TypeString,
+											ValidateFunc: validation.StringInSlice([]string{
+												string(insights.KnownWindowsEventLogDataSourceStreamsMicrosoftEvent),
+												string(insights.KnownWindowsEventLogDataSourceStreamsMicrosoftWindowsEvent),
+											}, false),
+										},
+									},
+
+									"x_path_queries": {
+										Type:     pluginsdk.TypeList,
+										Required: true,
+										MinItems: 1,
+										Elem: &pluginsdk.Schema{
+											Type:         pluginsdk.TypeString,
+											ValidateFunc: validation.StringIsNotEmpty,
+										},
+									},
+								},
+							},
+						},
+
+						"syslog": {
+							Type:     pluginsdk.TypeList,
+							Optional: true,
+							Elem: &pluginsdk.Resource{
+								Schema: map[string]*pluginsdk.Schema{
+									"name": {
+										Type:         pluginsdk.TypeString,
+										Required:     true,
+										ValidateFunc: validation.StringIsNotEmpty,
+									},
+
+									"streams": {
+										Type:     pluginsdk.TypeList,
+										Optional: true,
+										Computed: true,
+										Elem: &pluginsdk.Schema{
+											Type: pluginsdk.TypeString,
+											ValidateFunc: validation.StringInSlice([]string{
+												string(insights.KnownSyslogDataSourceStreamsMicrosoftSyslog),
+											}, false),
+										},
+									},
+
+									"facility_names": {
+										Type:     pluginsdk.TypeSet,
+										Required: true,
+										MinItems: 1,
+										Elem: &pluginsdk.Schema{
+											Type:         pluginsdk.TypeString,
+											ValidateFunc: validation.StringInSlice(monitorDataCollectionRuleSyslogFacilityNames(), false),
+										},
+									},
+
+									"log_levels": {
+										Type:     pluginsdk.TypeSet,
+										Required: true,
+										MinItems: 1,
+										Elem: &pluginsdk.Schema{
+											Type:         pluginsdk.TypeString,
+											ValidateFunc: validation.StringInSlice(monitorDataCollectionRuleSyslogLogLevels(), false),
+										},
+									},
+								},
+							},
+						},
+
+						"extension": {
+							Type:     pluginsdk.TypeList,
+							Optional: true,
+							Elem: &pluginsdk.Resource{
+								Schema: map[string]*pluginsdk.Schema{
+									"name": {
+										Type:         pluginsdk.TypeString,
+										Required:     true,
+										ValidateFunc: validation.StringIsNotEmpty,
+									},
+
+									"extension_name": {
+										Type:         pluginsdk.TypeString,
+										Required:     true,
+										ValidateFunc: validation.StringIsNotEmpty,
+									},
+
+									"streams": {
+										Type:     pluginsdk.TypeList,
+										Required: true,
+										MinItems: 1,
+										Elem: &pluginsdk.Schema{
+											Type:         pluginsdk.TypeString,
+											ValidateFunc: validation.StringInSlice(monitorDataCollectionRuleStreams(), false),
+										},
+									},
+
+									"extension_json": {
+										Type:             pluginsdk.TypeString,
+										Optional:         true,
+										ValidateFunc:     validation.StringIsJSON,
+										DiffSuppressFunc: pluginsdk.SuppressJsonDiff,
+									},
+
+									"input_data_sources": {
+										Type:     pluginsdk.TypeList,
+										Optional: true,
+										Elem: &pluginsdk.Schema{
+											Type:         pluginsdk.TypeString,
+											ValidateFunc: validation.StringIsNotEmpty,
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+
+			"destinations": {
+				Type:     pluginsdk.TypeList,
+				Required: true,
+				MaxItems: 1,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"log_analytics": {
+							Type:     pluginsdk.TypeList,
+							Optional: true,
+							Elem: &pluginsdk.Resource{
+								Schema: map[string]*pluginsdk.Schema{
+									"name": {
+										Type:         pluginsdk.TypeString,
+										Required:     true,
+										ValidateFunc: validation.StringIsNotEmpty,
+									},
+
+									"workspace_resource_id": {
+										Type:         pluginsdk.TypeString,
+										Required:     true,
+										ValidateFunc: logAnalyticsValidate.LogAnalyticsWorkspaceID,
+									},
+								},
+							},
+							AtLeastOneOf: []string{"destinations.0.log_analytics", "destinations.0.azure_monitor_metrics"},
+						},
+
+						"azure_monitor_metrics": {
+							Type:     pluginsdk.TypeList,
+							Optional: true,
+							MaxItems: 1,
+							Elem: &pluginsdk.Resource{
+								Schema: map[string]*pluginsdk.Schema{
+									"name": {
+										Type:         pluginsdk.TypeString,
+										Required:     true,
+										ValidateFunc: validation.StringIsNotEmpty,
+									},
+								},
+							},
+							AtLeastOneOf: []string{"destinations.0.log_analytics", "destinations.0.azure_monitor_metrics"},
+						},
+					},
+				},
+			},
+
+			"data_flow": {
+				Type:     pluginsdk.TypeList,
+				Required: true,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"streams": {
+							Type:     pluginsdk.TypeList,
+							Required: true,
+							MinItems: 1,
+							Elem: &pluginsdk.Schema{
+								Type:         pluginsdk.TypeString,
+								ValidateFunc: validation.StringInSlice(monitorDataCollectionRuleStreams(), false),
+							},
+						},
+
+						"destinations": {
+							Type:     pluginsdk.TypeList,
+							Required: true,
+							MinItems: 1,
+							Elem: &pluginsdk.Schema{
+								Type:         pluginsdk.TypeString,
+								ValidateFunc: validation.StringIsNotEmpty,
+							},
+						},
+					},
+				},
+			},
+
+			"immutable_id": {
+				Type:     pluginsdk.TypeString,
+				Computed: true,
+			},
+
+			"tags": tags.Schema(),
+		},
+	}
+}
+
+func resourceMonitorDataCollectionRuleCreateUpdate(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Monitor.DataCollectionRulesClient
+	subscriptionId := meta.(*clients.Client).Account.SubscriptionId
+	ctx, cancel := timeouts.ForCreateUpdate(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id := parse.NewDataCollectionRuleID(subscriptionId, d.Get("resource_group_name").(string), d.Get("name").(string))
+
+	if d.IsNewResource() {
+		existing, err := client.Get(ctx, id.ResourceGroup, id.Name)
+		if err != nil {
+			if !utils.ResponseWasNotFound(existing.Response) {
+				return fmt.Errorf("checking for presence of existing %s: %+v", id, err)
+			}
+		}
+
+		if existing.ID != nil && *existing.ID != "" {
+			return tf.ImportAsExistsError("azurerm_monitor_data_collection_rule", id.ID())
+		}
+	}
+
+	dataSources, err := expandMonitorDataCollectionRuleDataSources(d.Get("data_sources").([]interface{}))
+	if err != nil {
+		return fmt.Errorf("expanding `data_sources`: %+v", err)
+	}
+
+	parameters := insights.DataCollectionRuleResource{
+		Location: utils.String(location.Normalize(d.Get("location").(string))),
+		Kind:     insights.KnownDataCollectionRuleResourceKind(d.Get("kind").(string)),
+		DataCollectionRuleResourceProperties: &insights.DataCollectionRuleResourceProperties{
+			Description:  utils.String(d.Get("description").(string)),
+			DataSources:  dataSources,
+			Destinations: expandMonitorDataCollectionRuleDestinations(d.Get("destinations").([]interface{})),
+			DataFlows:    expandMonitorDataCollectionRuleDataFlows(d.Get("data_flow").([]interface{})),
+		},
+		Tags: tags.Expand(d.Get("tags").(map[string]interface{})),
+	}
+
+	if _, err := client.Create(ctx, id.ResourceGroup, id.Name, &parameters); err != nil {
+		return fmt.Errorf("creating/updating %s: %+v", id, err)
+	}
+
+	d.SetId(id.ID())
+	return resourceMonitorDataCollectionRuleRead(d, meta)
+}
+
+func resourceMonitorDataCollectionRuleRead(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Monitor.DataCollectionRulesClient
+	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := parse.DataCollectionRuleID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.Get(ctx, id.ResourceGroup, id.Name)
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			log.Printf("[INFO] %s does not exist - removing from state", *id)
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("retrieving %s: %+v", *id, err)
+	}
+
+	d.Set("name", id.Name)
+	d.Set("resource_group_name", id.ResourceGroup)
+	d.Set("location", location.NormalizeNilable(resp.Location))
+	d.Set("kind", string(resp.Kind))
+
+	if props := resp.DataCollectionRuleResourceProperties; props != nil {
+		d.Set("description", props.Description)
+		d.Set("immutable_id", props.ImmutableID)
+
+		dataSources, err := flattenMonitorDataCollectionRuleDataSources(props.DataSources)
+		if err != nil {
+			return fmt.Errorf("flattening `data_sources`: %+v", err)
+		}
+		if err := d.Set("data_sources", dataSources); err != nil {
+			return fmt.Errorf("setting `data_sources`: %+v", err)
+		}
+
+		destinations, err := flattenMonitorDataCollectionRuleDestinations(props.Destinations)
+		if err != nil {
+			return fmt.Errorf("flattening `destinations`: %+v", err)
+		}
+		if err := d.Set("destinations", destinations); err != nil {
+			return fmt.Errorf("setting `destinations`: %+v", err)
+		}
+
+		if err := d.Set("data_flow", flattenMonitorDataCollectionRuleDataFlows(props.DataFlows)); err != nil {
+			return fmt.Errorf("setting `data_flow`: %+v", err)
+		}
+	}
+
+	return tags.FlattenAndSet(d, resp.Tags)
+}
+
+func resourceMonitorDataCollectionRuleDelete(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Monitor.DataCollectionRulesClient
+	ctx, cancel := timeouts.ForDelete(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := parse.DataCollectionRuleID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	if resp, err := client.Delete(ctx, id.ResourceGroup, id.Name); err != nil {
+		if !utils.ResponseWasNotFound(resp) {
+			return fmt.Errorf("deleting %s: %+v", *id, err)
+		}
+	}
+
+	return nil
+}
+
+func resourceMonitorDataCollectionRuleCustomizeDiff(ctx context.Context, d *pluginsdk.ResourceDiff, _ interface{}) error {
+	// the API only reports an unknown destination after the rule has been submitted, so check it up-front
+	destinationNames := make(map[string]bool)
+	destinations := d.Get("destinations").([]interface{})
+	if len(destinations) == 0 || destinations[0] == nil {
+		return nil
+	}
+	destination := destinations[0].(map[string]interface{})
+	for _, raw := range destination["log_analytics"].([]interface{}) {
+		if raw == nil {
+			continue
+		}
+		name := raw.(map[string]interface{})["name"].(string)
+		if name == "" {
+			// the name isn't known until apply
+			return nil
+		}
+		if destinationNames[name] {
+			return fmt.Errorf("the destination name %q is used more than once within `destinations`", name)
+		}
+		destinationNames[name] = true
+	}
+	for _, raw := range destination["azure_monitor_metrics"].([]interface{}) {
+		if raw == nil {
+			continue
+		}
+		name := raw.(map[string]interface{})["name"].(string)
+		if name == "" {
+			return nil
+		}
+		if destinationNames[name] {
+			return fmt.Errorf("the destination name %q is used more than once within `destinations`", name)
+		}
+		destinationNames[name] = true
+	}
+
+	for i, raw := range d.Get("data_flow").([]interface{}) {
+		if raw == nil {
+			continue
+		}
+		for _, name := range raw.(map[string]interface{})["destinations"].([]interface{}) {
+			if name == nil || name.(string) == "" {
+				continue
+			}
+			if !destinationNames[name.(string)] {
+				return fmt.Errorf("`data_flow.%d.destinations` references the destination %q which isn't defined within `destinations`", i, name.(string))
+			}
+		}
+	}
+
+	return nil
+}
+
+func expandMonitorDataCollectionRuleDataSources(input []interface{}) (*insights.DataCollectionRuleDataSources, error) {
+	if len(input) == 0 || input[0] == nil {
+		return nil, nil
+	}
+	v := input[0].(map[string]interface{})
+
+	extensions, err := expandMonitorDataCollectionRuleExtensions(v["extension"].([]interface{}))
+	if err != nil {
+		return nil, err
+	}
+
+	return &insights.DataCollectionRuleDataSources{
+		PerformanceCounters: expandMonitorDataCollectionRulePerformanceCounters(v["performance_counter"].([]interface{})),
+		WindowsEventLogs:    expandMonitorDataCollectionRuleWindowsEventLogs(v["windows_event_log"].([]interface{})),
+		Syslog:              expandMonitorDataCollectionRuleSyslog(v["syslog"].([]interface{})),
+		Extensions:          extensions,
+	}, nil
+}
+
+func expandMonitorDataCollectionRulePerformanceCounters(input []interface{}) *[]insights.PerfCounterDataSource {
+	results := make([]insights.PerfCounterDataSource, 0)
+	for _, raw := range input {
+		v := raw.(map[string]interface{})
+
+		streams := make([]insights.KnownPerfCounterDataSourceStreams, 0)
+		for _, stream := range v["streams"].([]interface{}) {
+			streams = append(streams, insights.KnownPerfCounterDataSourceStreams(stream.(string)))
+		}
+
+		results = append(results, insights.PerfCounterDataSource{
+			Name:                       utils.String(v["name"].(string)),
+			Streams:                    &streams,
+			SamplingFrequencyInSeconds: utils.Int32(int32(v["sampling_frequency_in_seconds"].(int))),
+			CounterSpecifiers:          utils.ExpandStringSlice(v["counter_specifiers"].([]interface{})),
+		})
+	}
+	return &results
+}
+
+func expandMonitorDataCollectionRuleWindowsEventLogs(input []interface{}) *[]insights.WindowsEventLogDataSource {
+	results := make([]insights.WindowsEventLogDataSource, 0)
+	for _, raw := range input {
+		v := raw.(map[string]interface{})
+
+		streams := make([]insights.KnownWindowsEventLogDataSourceStreams, 0)
+		for _, stream := range v["streams"].([]interface{}) {
+			streams = append(streams, insights.KnownWindowsEventLogDataSourceStreams(stream.(string)))
+		}
+
+		results = append(results, insights.WindowsEventLogDataSource{
+			Name:         utils.String(v["name"].(string)),
+			Streams:      &streams,
+			XPathQueries: utils.ExpandStringSlice(v["x_path_queries"].([]interface{})),
+		})
+	}
+	return &results
+}
+
+func expandMonitorDataCollectionRuleSyslog(input []interface{}) *[]insights.SyslogDataSource {
+	results := make([]insights.SyslogDataSource, 0)
+	for _, raw := range input {
+		v := raw.(map[string]interface{})
+
+		// `Microsoft-Syslog` is the only stream syslog data can be sent to, so default to it
+		streams := make([]insights.KnownSyslogDataSourceStreams, 0)
+		for _, stream := range v["streams"].([]interface{}) {
+			streams = append(streams, insights.KnownSyslogDataSourceStreams(stream.(string)))
+		}
+		if len(streams) == 0 {
+			streams = append(streams, insights.KnownSyslogDataSourceStreamsMicrosoftSyslog)
+		}
+
+		facilityNames := make([]insights.KnownSyslogDataSourceFacilityNames, 0)
+		for _, facilityName := range v["facility_names"].(*pluginsdk.Set).List() {
+			facilityNames = append(facilityNames, insights.KnownSyslogDataSourceFacilityNames(facilityName.(string)))
+		}
+
+		logLevels := make([]insights.KnownSyslogDataSourceLogLevels, 0)
+		for _, logLevel := range v["log_levels"].(*pluginsdk.Set).List() {
+			logLevels = append(logLevels, insights.KnownSyslogDataSourceLogLevels(logLevel.(string)))
+		}
+
+		results = append(results, insights.SyslogDataSource{
+			Name:          utils.String(v["name"].(string)),
+			Streams:       &streams,
+			FacilityNames: &facilityNames,
+			LogLevels:     &logLevels,
+		})
+	}
+	return &results
+}
+
+func expandMonitorDataCollectionRuleExtensions(input []interface{}) (*[]insights.ExtensionDataSource, error) {
+	results := make([]insights.ExtensionDataSource, 0)
+	for _, raw := range input {
+		v := raw.(map[string]interface{})
+
+		streams := make([]insights.KnownExtensionDataSourceStreams, 0)
+		for _, stream := range v["streams"].([]interface{}) {
+			streams = append(streams, insights.KnownExtensionDataSourceStreams(stream.(string)))
+		}
+
+		result := insights.ExtensionDataSource{
+			Name:             utils.String(v["name"].(string)),
+			ExtensionName:    utils.String(v["extension_name"].(string)),
+			Streams:          &streams,
+			InputDataSources: utils.ExpandStringSlice(v["input_data_sources"].([]interface{})),
+		}
+
+		if extensionJson := v["extension_json"].(string); extensionJson != "" {
+			var extensionSettings interface{}
+			if err := json.Unmarshal([]byte(extensionJson), &extensionSettings); err != nil {
+				return nil, fmt.Errorf("unmarshalling `extension_json` for extension %q: %+v", v["name"].(string), err)
+			}
+			result.ExtensionSettings = extensionSettings
+		}
+
+		results = append(results, result)
+	}
+	return &results, nil
+}
+
+func expandMonitorDataCollectionRuleDestinations(input []interface{}) *insights.DataCollectionRuleDestinations {
+	if len(input) == 0 || input[0] == nil {
+		return nil
+	}
+	v := input[0].(map[string]interface{})
+
+	logAnalytics := make([]insights.LogAnalyticsDestination, 0)
+	for _, raw := range v["log_analytics"].([]interface{}) {
+		destination := raw.(map[string]interface{})
+		logAnalytics = append(logAnalytics, insights.LogAnalyticsDestination{
+			Name:                utils.String(destination["name"].(string)),
+			WorkspaceResourceID: utils.String(destination["workspace_resource_id"].(string)),
+		})
+	}
+
+	result := insights.DataCollectionRuleDestinations{
+		LogAnalytics: &logAnalytics,
+	}
+
+	if metrics := v["azure_monitor_metrics"].([]interface{}); len(metrics) > 0 && metrics[0] != nil {
+		destination := metrics[0].(map[string]interface{})
+		result.AzureMonitorMetrics = &insights.DestinationsSpecAzureMonitorMetrics{
+			Name: utils.String(destination["name"].(string)),
+		}
+	}
+
+	return &result
+}
+
+func expandMonitorDataCollectionRuleDataFlows(input []interface{}) *[]insights.DataFlow {
+	results := make([]insights.DataFlow, 0)
+	for _, raw := range input {
+		v := raw.(map[string]interface{})
+
+		streams := make([]insights.KnownDataFlowStreams, 0)
+		for _, stream := range v["streams"].([]interface{}) {
+			streams = append(streams, insights.KnownDataFlowStreams(stream.(string)))
+		}
+
+		results = append(results, insights.DataFlow{
+			Streams:      &streams,
+			Destinations: utils.ExpandStringSlice(v["destinations"].([]interface{})),
+		})
+	}
+	return &results
+}
+
+func flattenMonitorDataCollectionRuleDataSources(input *insights.DataCollectionRuleDataSources) ([]interface{}, error) {
+	if input == nil {
+		return []interface{}{}, nil
+	}
+
+	performanceCounters := make([]interface{}, 0)
+	if input.PerformanceCounters != nil {
+		for _, v := range *input.PerformanceCounters {
+			streams := make([]interface{}, 0)
+			if v.Streams != nil {
+				for _, stream := range *v.Streams {
+					streams = append(streams, string(stream))
+				}
+			}
+
+			samplingFrequency := 0
+			if v.SamplingFrequencyInSeconds != nil {
+				samplingFrequency = int(*v.SamplingFrequencyInSeconds)
+			}
+
+			performanceCounters = append(performanceCounters, map[string]interface{}{
+				"name":                          utils.NormalizeNilableString(v.Name),
+				"streams":                       streams,
+				"sampling_frequency_in_seconds": samplingFrequency,
+				"counter_specifiers":            utils.FlattenStringSlice(v.CounterSpecifiers),
+			})
+		}
+	}
+
+	windowsEventLogs := make([]interface{}, 0)
+	if input.WindowsEventLogs != nil {
+		for _, v := range *input.WindowsEventLogs {
+			streams := make([]interface{}, 0)
+			if v.Streams != nil {
+				for _, stream := range *v.Streams {
+					streams = append(streams, string(stream))
+				}
+			}
+
+			windowsEventLogs = append(windowsEventLogs, map[string]interface{}{
+				"name":           utils.NormalizeNilableString(v.Name),
+				"streams":        streams,
+				"x_path_queries": utils.FlattenStringSlice(v.XPathQueries),
+			})
+		}
+	}
+
+	syslog := make([]interface{}, 0)
+	if input.Syslog != nil {
+		for _, v := range *input.Syslog {
+			streams := make([]interface{}, 0)
+			if v.Streams != nil {
+				for _, stream := range *v.Streams {
+					streams = append(streams, string(stream))
+				}
+			}
+
+			facilityNames := make([]interface{}, 0)
+			if v.FacilityNames != nil {
+				for _, facilityName := range *v.FacilityNames {
+					facilityNames = append(facilityNames, string(facilityName))
+				}
+			}
+
+			logLevels := make([]interface{}, 0)
+			if v.LogLevels != nil {
+				for _, logLevel := range *v.LogLevels {
+					logLevels = append(logLevels, string(logLevel))
+				}
+			}
+
+			syslog = append(syslog, map[string]interface{}{
+				"name":           utils.NormalizeNilableString(v.Name),
+				"streams":        streams,
+				"facility_names": facilityNames,
+				"log_levels":     logLevels,
+			})
+		}
+	}
+
+	extensions := make([]interface{}, 0)
+	if input.Extensions != nil {
+		for _, v := range *input.Extensions {
+			streams := make([]interface{}, 0)
+			if v.Streams != nil {
+				for _, stream := range *v.Streams {
+					streams = append(streams, string(stream))
+				}
+			}
+
+			extensionJson := ""
+			if v.ExtensionSettings != nil {
+				raw, err := json.Marshal(v.ExtensionSettings)
+				if err != nil {
+					return nil, fmt.Errorf("marshalling the settings for extension %q: %+v", utils.NormalizeNilableString(v.Name), err)
+				}
+				extensionJson = string(raw)
+			}
+
+			extensions = append(extensions, map[string]interface{}{
+				"name":               utils.NormalizeNilableString(v.Name),
+				"extension_name":     utils.NormalizeNilableString(v.ExtensionName),
+				"streams":            streams,
+				"extension_json":     extensionJson,
+				"input_data_sources": utils.FlattenStringSlice(v.InputDataSources),
+			})
+		}
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"performance_counter": performanceCounters,
+			"windows_event_log":   windowsEventLogs,
+			"syslog":              syslog,
+			"extension":           extensions,
+		},
+	}, nil
+}
+
+func flattenMonitorDataCollectionRuleDestinations(input *insights.DataCollectionRuleDestinations) ([]interface{}, error) {
+	if input == nil {
+		return []interface{}{}, nil
+	}
+
+	logAnalytics := make([]interface{}, 0)
+	if input.LogAnalytics != nil {
+		for _, v := range *input.LogAnalytics {
+			workspaceResourceId := ""
+			if v.WorkspaceResourceID != nil && *v.WorkspaceResourceID != "" {
+				parsedId, err := logAnalyticsParse.LogAnalyticsWorkspaceID(*v.WorkspaceResourceID)
+				if err != nil {
+					return nil, err
+				}
+				workspaceResourceId = parsedId.ID()
+			}
+
+			logAnalytics = append(logAnalytics, map[string]interface{}{
+				"name":                  utils.NormalizeNilableString(v.Name),
+				"workspace_resource_id": workspaceResourceId,
+			})
+		}
+	}
+
+	azureMonitorMetrics := make([]interface{}, 0)
+	if input.AzureMonitorMetrics != nil {
+		azureMonitorMetrics = append(azureMonitorMetrics, map[string]interface{}{
+			"name": utils.NormalizeNilableString(input.AzureMonitorMetrics.Name),
+		})
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"log_analytics":         logAnalytics,
+			"azure_monitor_metrics": azureMonitorMetrics,
+		},
+	}, nil
+}
+
+func flattenMonitorDataCollectionRuleDataFlows(input *[]insights.DataFlow) []interface{} {
+	results := make([]interface{}, 0)
+	if input == nil {
+		return results
+	}
+
+	for _, v := range *input {
+		streams := make([]interface{}, 0)
+		if v.Streams != nil {
+			for _, stream := range *v.Streams {
+				streams = append(streams, string(stream))
+			}
+		}
+
+		results = append(results, map[string]interface{}{
+			"streams":      streams,
+			"destinations": utils.FlattenStringSlice(v.Destinations),
+		})
+	}
+
+	return results
+}
+
+func monitorDataCollectionRuleStreams() []string {
+	results := make([]string, 0)
+	for _, v := range insights.PossibleKnownDataFlowStreamsValues() {
+		results = append(results, string(v))
+	}
+	return results
+}
+
+func monitorDataCollectionRuleSyslogFacilityNames() []string {
+	results := make([]string, 0)
+	for _, v := range insights.PossibleKnownSyslogDataSourceFacilityNamesValues() {
+		results = append(results, string(v))
+	}
+	return results
+}
+
+func monitorDataCollectionRuleSyslogLogLevels() []string {
+	results := make([]string, 0)
+	for _, v := range insights.PossibleKnownSyslogDataSourceLogLevelsValues() {
+		results = append(results, string(v))
+	}
+	return results
+}
@@ -2,10 +2,8 @@ package monitor
 
 import (
 	"fmt"
-	"strings"
 	"time"
 
-	"github.com/Azure/azure-sdk-for-go/services/preview/monitor/mgmt/2019-06-01/insights"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/azure"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/clients"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tf/pluginsdk"
@@ -50,41 +48,14 @@ func dataSourceMonitorDiagnosticCategoriesRead(d *pluginsdk.ResourceData, meta i
 	defer cancel()
 
 	actualResourceId := d.Get("resource_id").(string)
-	// trim off the leading `/` since the CheckExistenceByID / List methods don't expect it
-	resourceId := strings.TrimPrefix(actualResourceId, "/")
 
 	// then retrieve the possible Diagnostics Categories for this Resource
-	categories, err := categoriesClient.List(ctx, resourceId)
+	logs, metrics, err := monitorDiagnosticSettingAvailableCategories(ctx, categoriesClient, actualResourceId)
 	if err != nil {
-		return fmt.Errorf("Error retrieving Diagnostics Categories for Resource %q: %+v", actualResourceId, err)
-	}
-
-	if categories.Value == nil {
-		return fmt.Errorf("Error retrieving Diagnostics Categories for Resource %q: `categories.Value` was nil", actualResourceId)
+		return err
 	}
 
 	d.SetId(actualResourceId)
-	val := *categories.Value
-
-	metrics := make([]string, 0)
-	logs := make([]string, 0)
-
-	for _, v := range val {
-		if v.Name == nil {
-			continue
-		}
-
-		if category := v.DiagnosticSettingsCategory; category != nil {
-			switch category.CategoryType {
-			case insights.Logs:
-				logs = append(logs, *v.Name)
-			case insights.Metrics:
-				metrics = append(metrics, *v.Name)
-			default:
-				return fmt.Errorf("Unsupported category type %q", string(category.CategoryType))
-			}
-		}
-	}
 
 	if err := d.Set("logs", logs); err != nil {
 		return fmt.Errorf("Error setting `logs`: %+v", err)
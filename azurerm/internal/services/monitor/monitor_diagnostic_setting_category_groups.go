@@ -0,0 +1,146 @@
+package monitor
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/Azure/azure-sdk-for-go/services/preview/monitor/mgmt/2019-06-01/insights"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/azure"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+// the SDK version in use doesn't support Category Groups, which were introduced in this API version - as such
+// Diagnostic Settings using them are sent/retrieved through the requests below, which otherwise match the SDK
+const monitorDiagnosticSettingCategoryGroupsApiVersion = "2021-05-01-preview"
+
+type monitorDiagnosticSettingLogCategoryGroup struct {
+	Category      *string `json:"category,omitempty"`
+	CategoryGroup *string `json:"categoryGroup,omitempty"`
+	Enabled       *bool   `json:"enabled,omitempty"`
+}
+
+type monitorDiagnosticSettingWithCategoryGroups struct {
+	insights.DiagnosticSettingsResource
+	CategoryGroups []string
+}
+
+func monitorDiagnosticSettingCreateOrUpdateWithCategoryGroups(ctx context.Context, client *insights.DiagnosticSettingsClient, resourceUri string, name string, input insights.DiagnosticSettingsResource, categoryGroups []string) error {
+	body, err := json.Marshal(input)
+	if err != nil {
+		return err
+	}
+
+	var payload map[string]interface{}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return err
+	}
+
+	props, ok := payload["properties"].(map[string]interface{})
+	if !ok {
+		props = make(map[string]interface{})
+	}
+
+	logs := make([]interface{}, 0)
+	if existing, ok := props["logs"].([]interface{}); ok {
+		logs = append(logs, existing...)
+	}
+	for _, group := range categoryGroups {
+		logs = append(logs, monitorDiagnosticSettingLogCategoryGroup{
+			CategoryGroup: utils.String(group),
+			Enabled:       utils.Bool(true),
+		})
+	}
+	props["logs"] = logs
+	payload["properties"] = props
+
+	req, err := autorest.CreatePreparer(
+		autorest.AsContentType("application/json; charset=utf-8"),
+		autorest.AsPut(),
+		autorest.WithBaseURL(client.BaseURI),
+		autorest.WithPathParameters("/{resourceUri}/providers/microsoft.insights/diagnosticSettings/{name}", map[string]interface{}{
+			"name":        autorest.Encode("path", name),
+			"resourceUri": resourceUri,
+		}),
+		autorest.WithJSON(payload),
+		autorest.WithQueryParameters(map[string]interface{}{
+			"api-version": monitorDiagnosticSettingCategoryGroupsApiVersion,
+		})).Prepare((&http.Request{}).WithContext(ctx))
+	if err != nil {
+		return autorest.NewErrorWithError(err, "monitor.DiagnosticSettingsClient", "CreateOrUpdate", nil, "Failure preparing request")
+	}
+
+	resp, err := client.Send(req, autorest.DoRetryForStatusCodes(client.RetryAttempts, client.RetryDuration, autorest.StatusCodesForRetry...))
+	if err != nil {
+		return autorest.NewErrorWithError(err, "monitor.DiagnosticSettingsClient", "CreateOrUpdate", resp, "Failure sending request")
+	}
+
+	err = autorest.Respond(
+		resp,
+		azure.WithErrorUnlessStatusCode(http.StatusOK),
+		autorest.ByClosing())
+	if err != nil {
+		return autorest.NewErrorWithError(err, "monitor.DiagnosticSettingsClient", "CreateOrUpdate", resp, "Failure responding to request")
+	}
+
+	return nil
+}
+
+func monitorDiagnosticSettingGetWithCategoryGroups(ctx context.Context, client *insights.DiagnosticSettingsClient, resourceUri string, name string) (result monitorDiagnosticSettingWithCategoryGroups, err error) {
+	req, err := autorest.CreatePreparer(
+		autorest.AsGet(),
+		autorest.WithBaseURL(client.BaseURI),
+		autorest.WithPathParameters("/{resourceUri}/providers/microsoft.insights/diagnosticSettings/{name}", map[string]interface{}{
+			"name":        autorest.Encode("path", name),
+			"resourceUri": resourceUri,
+		}),
+		autorest.WithQueryParameters(map[string]interface{}{
+			"api-version": monitorDiagnosticSettingCategoryGroupsApiVersion,
+		})).Prepare((&http.Request{}).WithContext(ctx))
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "monitor.DiagnosticSettingsClient", "Get", nil, "Failure preparing request")
+		return
+	}
+
+	resp, err := client.Send(req, autorest.DoRetryForStatusCodes(client.RetryAttempts, client.RetryDuration, autorest.StatusCodesForRetry...))
+	if err != nil {
+		result.Response = autorest.Response{Response: resp}
+		err = autorest.NewErrorWithError(err, "monitor.DiagnosticSettingsClient", "Get", resp, "Failure sending request")
+		return
+	}
+
+	var raw json.RawMessage
+	err = autorest.Respond(
+		resp,
+		azure.WithErrorUnlessStatusCode(http.StatusOK),
+		autorest.ByUnmarshallingJSON(&raw),
+		autorest.ByClosing())
+	result.Response = autorest.Response{Response: resp}
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "monitor.DiagnosticSettingsClient", "Get", resp, "Failure responding to request")
+		return
+	}
+
+	if err = json.Unmarshal(raw, &result.DiagnosticSettingsResource); err != nil {
+		return
+	}
+
+	var groups struct {
+		Properties struct {
+			Logs []monitorDiagnosticSettingLogCategoryGroup `json:"logs"`
+		} `json:"properties"`
+	}
+	if err = json.Unmarshal(raw, &groups); err != nil {
+		return
+	}
+
+	result.CategoryGroups = make([]string, 0)
+	for _, v := range groups.Properties.Logs {
+		if v.CategoryGroup != nil && v.Enabled != nil && *v.Enabled {
+			result.CategoryGroups = append(result.CategoryGroups, *v.CategoryGroup)
+		}
+	}
+
+	return
+}
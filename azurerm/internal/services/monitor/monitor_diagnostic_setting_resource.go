@@ -93,6 +93,43 @@ func resourceMonitorDiagnosticSetting() *pluginsdk.Resource {
 				}, false),
 			},
 
+			"all_logs": {
+				Type:          pluginsdk.TypeBool,
+				Optional:      true,
+				ConflictsWith: []string{"log", "enabled_log_category_groups"},
+			},
+
+			"all_metrics": {
+				Type:          pluginsdk.TypeBool,
+				Optional:      true,
+				ConflictsWith: []string{"metric"},
+			},
+
+			"enabled_log_category_groups": {
+				Type:          pluginsdk.TypeSet,
+				Optional:      true,
+				ConflictsWith: []string{"log", "all_logs"},
+				Elem: &pluginsdk.Schema{
+					Type:         pluginsdk.TypeString,
+					ValidateFunc: validation.StringIsNotEmpty,
+				},
+				Set: pluginsdk.HashString,
+			},
+
+			"enabled_log_categories": {
+				Type:     pluginsdk.TypeSet,
+				Computed: true,
+				Elem:     &pluginsdk.Schema{Type: pluginsdk.TypeString},
+				Set:      pluginsdk.HashString,
+			},
+
+			"enabled_metric_categories": {
+				Type:     pluginsdk.TypeSet,
+				Computed: true,
+				Elem:     &pluginsdk.Schema{Type: pluginsdk.TypeString},
+				Set:      pluginsdk.HashString,
+			},
+
 			"log": {
 				Type:     pluginsdk.TypeSet,
 				Optional: true,
@@ -171,6 +208,8 @@ func resourceMonitorDiagnosticSetting() *pluginsdk.Resource {
 				},
 			},
 		},
+
+		CustomizeDiff: pluginsdk.CustomizeDiffShim(resourceMonitorDiagnosticSettingCustomizeDiff),
 	}
 }
 
@@ -201,13 +240,33 @@ func resourceMonitorDiagnosticSettingCreateUpdate(d *pluginsdk.ResourceData, met
 	metricsRaw := d.Get("metric").(*pluginsdk.Set).List()
 	metrics := expandMonitorDiagnosticsSettingsMetrics(metricsRaw)
 
+	allLogs := d.Get("all_logs").(bool)
+	allMetrics := d.Get("all_metrics").(bool)
+	if allLogs || allMetrics {
+		// resolve the categories available right now, so that any added since the plan are also enabled
+		categoriesClient := meta.(*clients.Client).Monitor.DiagnosticSettingsCategoryClient
+		logCategories, metricCategories, err := monitorDiagnosticSettingAvailableCategories(ctx, categoriesClient, actualResourceId)
+		if err != nil {
+			return err
+		}
+
+		if allLogs {
+			logs = expandMonitorDiagnosticsSettingsAllLogs(logCategories)
+		}
+		if allMetrics {
+			metrics = expandMonitorDiagnosticsSettingsAllMetrics(metricCategories)
+		}
+	}
+
+	categoryGroups := utils.ExpandStringSlice(d.Get("enabled_log_category_groups").(*pluginsdk.Set).List())
+
 	// if no blocks are specified  the API "creates" but 404's on Read
-	if len(logs) == 0 && len(metrics) == 0 {
-		return fmt.Errorf("At least one `log` or `metric` block must be specified")
+	if len(logs) == 0 && len(metrics) == 0 && len(*categoryGroups) == 0 {
+		return fmt.Errorf("At least one `log` or `metric` block must be specified, or `all_logs`, `all_metrics` or `enabled_log_category_groups` must be set")
 	}
 
 	// also if there's none enabled
-	valid := false
+	valid := len(*categoryGroups) > 0
 	for _, v := range logs {
 		if v.Enabled != nil && *v.Enabled {
 			valid = true
@@ -269,8 +328,14 @@ func resourceMonitorDiagnosticSettingCreateUpdate(d *pluginsdk.ResourceData, met
 
 	// the Azure SDK prefixes the URI with a `/` such this makes a bad request if we don't trim the `/`
 	targetResourceId := strings.TrimPrefix(actualResourceId, "/")
-	if _, err := client.CreateOrUpdate(ctx, targetResourceId, properties, name); err != nil {
-		return fmt.Errorf("Error creating Monitor Diagnostics Setting %q for Resource %q: %+v", name, actualResourceId, err)
+	if len(*categoryGroups) > 0 {
+		if err := monitorDiagnosticSettingCreateOrUpdateWithCategoryGroups(ctx, client, targetResourceId, name, properties, *categoryGroups); err != nil {
+			return fmt.Errorf("Error creating Monitor Diagnostics Setting %q for Resource %q: %+v", name, actualResourceId, err)
+		}
+	} else {
+		if _, err := client.CreateOrUpdate(ctx, targetResourceId, properties, name); err != nil {
+			return fmt.Errorf("Error creating Monitor Diagnostics Setting %q for Resource %q: %+v", name, actualResourceId, err)
+		}
 	}
 
 	read, err := client.Get(ctx, targetResourceId, name)
@@ -298,7 +363,8 @@ func resourceMonitorDiagnosticSettingRead(d *pluginsdk.ResourceData, meta interf
 
 	actualResourceId := id.ResourceID
 	targetResourceId := strings.TrimPrefix(actualResourceId, "/")
-	resp, err := client.Get(ctx, targetResourceId, id.Name)
+	// retrieved using the API version supporting Category Groups, so that these are read back
+	resp, err := monitorDiagnosticSettingGetWithCategoryGroups(ctx, client, targetResourceId, id.Name)
 	if err != nil {
 		if utils.ResponseWasNotFound(resp.Response) {
 			log.Printf("[WARN] Monitor Diagnostics Setting %q was not found for Resource %q - removing from state!", id.Name, actualResourceId)
@@ -348,13 +414,39 @@ func resourceMonitorDiagnosticSettingRead(d *pluginsdk.ResourceData, meta interf
 
 	d.Set("log_analytics_destination_type", resp.LogAnalyticsDestinationType)
 
-	if err := d.Set("log", flattenMonitorDiagnosticLogs(resp.Logs)); err != nil {
+	// when every category is enabled the individual categories are tracked in the computed attributes
+	// rather than the `log`/`metric` blocks, so that categories which Azure adds later aren't treated as drift
+	allLogs := d.Get("all_logs").(bool)
+	logs := flattenMonitorDiagnosticLogs(resp.Logs)
+	if allLogs {
+		logs = make([]interface{}, 0)
+	}
+	if err := d.Set("log", logs); err != nil {
 		return fmt.Errorf("Error setting `log`: %+v", err)
 	}
+	d.Set("all_logs", allLogs)
 
-	if err := d.Set("metric", flattenMonitorDiagnosticMetrics(resp.Metrics)); err != nil {
+	allMetrics := d.Get("all_metrics").(bool)
+	metrics := flattenMonitorDiagnosticMetrics(resp.Metrics)
+	if allMetrics {
+		metrics = make([]interface{}, 0)
+	}
+	if err := d.Set("metric", metrics); err != nil {
 		return fmt.Errorf("Error setting `metric`: %+v", err)
 	}
+	d.Set("all_metrics", allMetrics)
+
+	if err := d.Set("enabled_log_category_groups", resp.CategoryGroups); err != nil {
+		return fmt.Errorf("Error setting `enabled_log_category_groups`: %+v", err)
+	}
+
+	if err := d.Set("enabled_log_categories", flattenMonitorDiagnosticEnabledLogCategories(resp.Logs)); err != nil {
+		return fmt.Errorf("Error setting `enabled_log_categories`: %+v", err)
+	}
+
+	if err := d.Set("enabled_metric_categories", flattenMonitorDiagnosticEnabledMetricCategories(resp.Metrics)); err != nil {
+		return fmt.Errorf("Error setting `enabled_metric_categories`: %+v", err)
+	}
 
 	return nil
 }
@@ -409,6 +501,167 @@ func monitorDiagnosticSettingDeletedRefreshFunc(ctx context.Context, client *ins
 	}
 }
 
+func resourceMonitorDiagnosticSettingCustomizeDiff(ctx context.Context, d *pluginsdk.ResourceDiff, meta interface{}) error {
+	allLogs := d.Get("all_logs").(bool)
+	allMetrics := d.Get("all_metrics").(bool)
+	if !allLogs && !allMetrics {
+		return nil
+	}
+
+	// the categories can only be resolved once the Resource they're for is known
+	if !d.NewValueKnown("target_resource_id") {
+		if allLogs {
+			if err := d.SetNewComputed("enabled_log_categories"); err != nil {
+				return err
+			}
+		}
+		if allMetrics {
+			if err := d.SetNewComputed("enabled_metric_categories"); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	targetResourceId := d.Get("target_resource_id").(string)
+	categoriesClient := meta.(*clients.Client).Monitor.DiagnosticSettingsCategoryClient
+	logCategories, metricCategories, err := monitorDiagnosticSettingAvailableCategories(ctx, categoriesClient, targetResourceId)
+	if err != nil {
+		return err
+	}
+
+	if allLogs {
+		if len(logCategories) == 0 {
+			return fmt.Errorf("`all_logs` cannot be enabled since Resource %q doesn't expose any log categories", targetResourceId)
+		}
+
+		// only categories which aren't enabled yet require an update, a category which is no longer available isn't a change
+		if !monitorDiagnosticSettingCategoriesContainAll(d.Get("enabled_log_categories").(*pluginsdk.Set), logCategories) {
+			if err := d.SetNew("enabled_log_categories", logCategories); err != nil {
+				return err
+			}
+		}
+	}
+
+	if allMetrics {
+		if len(metricCategories) == 0 {
+			return fmt.Errorf("`all_metrics` cannot be enabled since Resource %q doesn't expose any metric categories", targetResourceId)
+		}
+
+		if !monitorDiagnosticSettingCategoriesContainAll(d.Get("enabled_metric_categories").(*pluginsdk.Set), metricCategories) {
+			if err := d.SetNew("enabled_metric_categories", metricCategories); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// monitorDiagnosticSettingAvailableCategories returns the names of the log and metric categories which
+// can be configured for the specified Resource
+func monitorDiagnosticSettingAvailableCategories(ctx context.Context, client *insights.DiagnosticSettingsCategoryClient, actualResourceId string) ([]string, []string, error) {
+	// trim off the leading `/` since the List method doesn't expect it
+	resourceId := strings.TrimPrefix(actualResourceId, "/")
+
+	categories, err := client.List(ctx, resourceId)
+	if err != nil {
+		return nil, nil, fmt.Errorf("Error retrieving Diagnostics Categories for Resource %q: %+v", actualResourceId, err)
+	}
+
+	if categories.Value == nil {
+		return nil, nil, fmt.Errorf("Error retrieving Diagnostics Categories for Resource %q: `categories.Value` was nil", actualResourceId)
+	}
+
+	logs := make([]string, 0)
+	metrics := make([]string, 0)
+
+	for _, v := range *categories.Value {
+		if v.Name == nil {
+			continue
+		}
+
+		if category := v.DiagnosticSettingsCategory; category != nil {
+			switch category.CategoryType {
+			case insights.Logs:
+				logs = append(logs, *v.Name)
+			case insights.Metrics:
+				metrics = append(metrics, *v.Name)
+			default:
+				return nil, nil, fmt.Errorf("Unsupported category type %q", string(category.CategoryType))
+			}
+		}
+	}
+
+	return logs, metrics, nil
+}
+
+func monitorDiagnosticSettingCategoriesContainAll(existing *pluginsdk.Set, categories []string) bool {
+	for _, category := range categories {
+		if !existing.Contains(category) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func expandMonitorDiagnosticsSettingsAllLogs(categories []string) []insights.LogSettings {
+	results := make([]insights.LogSettings, 0)
+
+	for _, category := range categories {
+		results = append(results, insights.LogSettings{
+			Category: utils.String(category),
+			Enabled:  utils.Bool(true),
+		})
+	}
+
+	return results
+}
+
+func expandMonitorDiagnosticsSettingsAllMetrics(categories []string) []insights.MetricSettings {
+	results := make([]insights.MetricSettings, 0)
+
+	for _, category := range categories {
+		results = append(results, insights.MetricSettings{
+			Category: utils.String(category),
+			Enabled:  utils.Bool(true),
+		})
+	}
+
+	return results
+}
+
+func flattenMonitorDiagnosticEnabledLogCategories(input *[]insights.LogSettings) []interface{} {
+	results := make([]interface{}, 0)
+	if input == nil {
+		return results
+	}
+
+	for _, v := range *input {
+		if v.Category != nil && v.Enabled != nil && *v.Enabled {
+			results = append(results, *v.Category)
+		}
+	}
+
+	return results
+}
+
+func flattenMonitorDiagnosticEnabledMetricCategories(input *[]insights.MetricSettings) []interface{} {
+	results := make([]interface{}, 0)
+	if input == nil {
+		return results
+	}
+
+	for _, v := range *input {
+		if v.Category != nil && v.Enabled != nil && *v.Enabled {
+			results = append(results, *v.Category)
+		}
+	}
+
+	return results
+}
+
 func expandMonitorDiagnosticsSettingsLogs(input []interface{}) []insights.LogSettings {
 	results := make([]insights.LogSettings, 0)
 
@@ -448,12 +701,14 @@ func flattenMonitorDiagnosticLogs(input *[]insights.LogSettings) []interface{} {
 	}
 
 	for _, v := range *input {
-		output := make(map[string]interface{})
-
-		if v.Category != nil {
-			output["category"] = *v.Category
+		// Category Groups are exposed through `enabled_log_category_groups`
+		if v.Category == nil {
+			continue
 		}
 
+		output := make(map[string]interface{})
+		output["category"] = *v.Category
+
 		if v.Enabled != nil {
 			output["enabled"] = *v.Enabled
 		}
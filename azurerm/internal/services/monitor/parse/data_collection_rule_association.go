@@ -0,0 +1,59 @@
+package parse
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/azure"
+)
+
+const dataCollectionRuleAssociationIdSegment = "/providers/Microsoft.Insights/dataCollectionRuleAssociations/"
+
+type DataCollectionRuleAssociationId struct {
+	TargetResourceId string
+	Name             string
+}
+
+func NewDataCollectionRuleAssociationID(targetResourceId, name string) DataCollectionRuleAssociationId {
+	return DataCollectionRuleAssociationId{
+		TargetResourceId: targetResourceId,
+		Name:             name,
+	}
+}
+
+func (id DataCollectionRuleAssociationId) String() string {
+	segments := []string{
+		fmt.Sprintf("Name %q", id.Name),
+		fmt.Sprintf("Target Resource %q", id.TargetResourceId),
+	}
+	segmentsStr := strings.Join(segments, " / ")
+	return fmt.Sprintf("%s: (%s)", "Data Collection Rule Association", segmentsStr)
+}
+
+func (id DataCollectionRuleAssociationId) ID() string {
+	fmtString := "%s" + dataCollectionRuleAssociationIdSegment + "%s"
+	return fmt.Sprintf(fmtString, id.TargetResourceId, id.Name)
+}
+
+// DataCollectionRuleAssociationID parses a DataCollectionRuleAssociation ID into an DataCollectionRuleAssociationId struct
+func DataCollectionRuleAssociationID(input string) (*DataCollectionRuleAssociationId, error) {
+	index := strings.LastIndex(input, dataCollectionRuleAssociationIdSegment)
+	if index == -1 {
+		return nil, fmt.Errorf("expected ID to be in the format `{targetResourceId}%s{name}`", dataCollectionRuleAssociationIdSegment)
+	}
+
+	targetResourceId := input[:index]
+	name := input[index+len(dataCollectionRuleAssociationIdSegment):]
+	if name == "" || strings.Contains(name, "/") {
+		return nil, fmt.Errorf("ID was missing the 'dataCollectionRuleAssociations' element")
+	}
+
+	if _, err := azure.ParseAzureResourceID(targetResourceId); err != nil {
+		return nil, fmt.Errorf("parsing Target Resource ID %q: %+v", targetResourceId, err)
+	}
+
+	return &DataCollectionRuleAssociationId{
+		TargetResourceId: targetResourceId,
+		Name:             name,
+	}, nil
+}
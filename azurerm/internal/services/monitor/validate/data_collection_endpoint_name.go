@@ -0,0 +1,19 @@
+package validate
+
+import (
+	"fmt"
+	"regexp"
+)
+
+func DataCollectionEndpointName(i interface{}, k string) (warning []string, errors []error) {
+	v, ok := i.(string)
+	if !ok {
+		return nil, append(errors, fmt.Errorf("expected type of %s to be string", k))
+	}
+
+	if !regexp.MustCompile(`^[a-zA-Z\d][a-zA-Z\d-]{1,42}[a-zA-Z\d]$`).MatchString(v) {
+		errors = append(errors, fmt.Errorf("%s must be between 3 and 44 characters, begin and end with a letter or number and contain only letters, numbers and hyphens", k))
+	}
+
+	return
+}
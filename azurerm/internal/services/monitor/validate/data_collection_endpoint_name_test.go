@@ -0,0 +1,69 @@
+package validate
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDataCollectionEndpointName(t *testing.T) {
+	testData := []struct {
+		input    string
+		expected bool
+	}{
+		{
+			// empty
+			input:    "",
+			expected: false,
+		},
+		{
+			// too short
+			input:    "ab",
+			expected: false,
+		},
+		{
+			// basic example
+			input:    "abc",
+			expected: true,
+		},
+		{
+			// contains hyphen
+			input:    "a-b-c",
+			expected: true,
+		},
+		{
+			// can't end with hyphen
+			input:    "abc-",
+			expected: false,
+		},
+		{
+			// can't lead by hyphen
+			input:    "-abc",
+			expected: false,
+		},
+		{
+			// can not contain '_'
+			input:    "a_bc",
+			expected: false,
+		},
+		{
+			// 44 characters
+			input:    strings.Repeat("a", 44),
+			expected: true,
+		},
+		{
+			// 45 characters
+			input:    strings.Repeat("a", 45),
+			expected: false,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q..", v.input)
+
+		_, errors := DataCollectionEndpointName(v.input, "name")
+		actual := len(errors) == 0
+		if v.expected != actual {
+			t.Fatalf("Expected %t but got %t", v.expected, actual)
+		}
+	}
+}
@@ -0,0 +1,19 @@
+package validate
+
+import (
+	"fmt"
+	"regexp"
+)
+
+func DataCollectionRuleName(i interface{}, k string) (warning []string, errors []error) {
+	v, ok := i.(string)
+	if !ok {
+		return nil, append(errors, fmt.Errorf("expected type of %s to be string", k))
+	}
+
+	if !regexp.MustCompile(`^[a-zA-Z\d]([a-zA-Z\d._-]{0,62}[a-zA-Z\d_])?$`).MatchString(v) {
+		errors = append(errors, fmt.Errorf("%s must be between 1 and 64 characters, begin with a letter or number, end with a letter, number or underscore and contain only letters, numbers, periods, underscores and hyphens", k))
+	}
+
+	return
+}
@@ -0,0 +1,69 @@
+package validate
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDataCollectionRuleName(t *testing.T) {
+	testData := []struct {
+		input    string
+		expected bool
+	}{
+		{
+			// empty
+			input:    "",
+			expected: false,
+		},
+		{
+			// single character
+			input:    "a",
+			expected: true,
+		},
+		{
+			// contains period, underscore and hyphen
+			input:    "a.b_c-d",
+			expected: true,
+		},
+		{
+			// end with underscore
+			input:    "ab_",
+			expected: true,
+		},
+		{
+			// can't end with period
+			input:    "ab.",
+			expected: false,
+		},
+		{
+			// can't lead by '_'
+			input:    "_ab",
+			expected: false,
+		},
+		{
+			// can not contain '+'
+			input:    "a+b",
+			expected: false,
+		},
+		{
+			// 64 characters
+			input:    strings.Repeat("a", 64),
+			expected: true,
+		},
+		{
+			// 65 characters
+			input:    strings.Repeat("a", 65),
+			expected: false,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q..", v.input)
+
+		_, errors := DataCollectionRuleName(v.input, "name")
+		actual := len(errors) == 0
+		if v.expected != actual {
+			t.Fatalf("Expected %t but got %t", v.expected, actual)
+		}
+	}
+}
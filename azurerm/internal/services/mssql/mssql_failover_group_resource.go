@@ -0,0 +1,482 @@
+package mssql
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/preview/sql/mgmt/v3.0/sql"
+	"github.com/hashicorp/go-azure-helpers/response"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/location"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/sdk"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/mssql/parse"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/mssql/validate"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tags"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tf/pluginsdk"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tf/validation"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+type MsSqlFailoverGroupModel struct {
+	Name                                  string                                           `tfschema:"name"`
+	ServerId                              string                                           `tfschema:"server_id"`
+	PartnerServers                        []MsSqlFailoverGroupPartnerServerModel           `tfschema:"partner_server"`
+	Databases                             []string                                         `tfschema:"databases"`
+	ReadOnlyEndpointFailoverPolicyEnabled bool                                             `tfschema:"readonly_endpoint_failover_policy_enabled"`
+	ReadWriteEndpointFailoverPolicy       []MsSqlFailoverGroupReadWriteEndpointPolicyModel `tfschema:"read_write_endpoint_failover_policy"`
+	PrimaryServerId                       string                                           `tfschema:"primary_server_id"`
+	Tags                                  map[string]interface{}                           `tfschema:"tags"`
+}
+
+type MsSqlFailoverGroupPartnerServerModel struct {
+	Id       string `tfschema:"id"`
+	Location string `tfschema:"location"`
+	Role     string `tfschema:"role"`
+}
+
+type MsSqlFailoverGroupReadWriteEndpointPolicyModel struct {
+	Mode         string `tfschema:"mode"`
+	GraceMinutes int    `tfschema:"grace_minutes"`
+}
+
+type MsSqlFailoverGroupResource struct{}
+
+var _ sdk.Resource = MsSqlFailoverGroupResource{}
+var _ sdk.ResourceWithUpdate = MsSqlFailoverGroupResource{}
+
+func (r MsSqlFailoverGroupResource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"name": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validate.ValidateMsSqlFailoverGroupName,
+		},
+
+		"server_id": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validate.ServerID,
+		},
+
+		"partner_server": {
+			Type:     pluginsdk.TypeList,
+			Required: true,
+			Elem: &pluginsdk.Resource{
+				Schema: map[string]*pluginsdk.Schema{
+					"id": {
+						Type:         pluginsdk.TypeString,
+						Required:     true,
+						ValidateFunc: validate.ServerID,
+					},
+
+					"location": {
+						Type:     pluginsdk.TypeString,
+						Computed: true,
+					},
+
+					"role": {
+						Type:     pluginsdk.TypeString,
+						Computed: true,
+					},
+				},
+			},
+		},
+
+		"databases": {
+			Type:     pluginsdk.TypeSet,
+			Optional: true,
+			Elem: &pluginsdk.Schema{
+				Type:         pluginsdk.TypeString,
+				ValidateFunc: validate.DatabaseID,
+			},
+		},
+
+		"readonly_endpoint_failover_policy_enabled": {
+			Type:     pluginsdk.TypeBool,
+			Optional: true,
+			Default:  false,
+		},
+
+		"read_write_endpoint_failover_policy": {
+			Type:     pluginsdk.TypeList,
+			Required: true,
+			MaxItems: 1,
+			Elem: &pluginsdk.Resource{
+				Schema: map[string]*pluginsdk.Schema{
+					"mode": {
+						Type:     pluginsdk.TypeString,
+						Required: true,
+						ValidateFunc: validation.StringInSlice([]string{
+							string(sql.Automatic),
+							string(sql.Manual),
+						}, false),
+					},
+
+					"grace_minutes": {
+						Type:         pluginsdk.TypeInt,
+						Optional:     true,
+						ValidateFunc: validation.IntAtLeast(60),
+					},
+				},
+			},
+		},
+
+		"primary_server_id": {
+			Type:         pluginsdk.TypeString,
+			Optional:     true,
+			Computed:     true,
+			ValidateFunc: validate.ServerID,
+		},
+
+		"tags": tags.Schema(),
+	}
+}
+
+func (r MsSqlFailoverGroupResource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{}
+}
+
+func (r MsSqlFailoverGroupResource) ModelObject() interface{} {
+	return MsSqlFailoverGroupModel{}
+}
+
+func (r MsSqlFailoverGroupResource) ResourceType() string {
+	return "azurerm_mssql_failover_group"
+}
+
+func (r MsSqlFailoverGroupResource) IDValidationFunc() pluginsdk.SchemaValidateFunc {
+	return validate.FailoverGroupID
+}
+
+func (r MsSqlFailoverGroupResource) Create() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.MSSQL.FailoverGroupsClient
+
+			var model MsSqlFailoverGroupModel
+			if err := metadata.Decode(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			serverId, err := parse.ServerID(model.ServerId)
+			if err != nil {
+				return err
+			}
+
+			id := parse.NewFailoverGroupID(serverId.SubscriptionId, serverId.ResourceGroup, serverId.Name, model.Name)
+
+			existing, err := client.Get(ctx, id.ResourceGroup, id.ServerName, id.Name)
+			if err != nil && !utils.ResponseWasNotFound(existing.Response) {
+				return fmt.Errorf("checking for presence of existing %s: %+v", id, err)
+			}
+			if !utils.ResponseWasNotFound(existing.Response) {
+				return metadata.ResourceRequiresImport(r.ResourceType(), id)
+			}
+
+			// the failover group is always created on `server_id`, so it's the primary until a failover takes place
+			if model.PrimaryServerId != "" && !strings.EqualFold(model.PrimaryServerId, serverId.ID()) {
+				return fmt.Errorf("`primary_server_id` must be set to `server_id` when creating %s", id)
+			}
+
+			parameters, err := expandMsSqlFailoverGroup(model, *serverId)
+			if err != nil {
+				return err
+			}
+
+			metadata.Logger.Infof("creating %s", id)
+
+			future, err := client.CreateOrUpdate(ctx, id.ResourceGroup, id.ServerName, id.Name, *parameters)
+			if err != nil {
+				return fmt.Errorf("creating %s: %+v", id, err)
+			}
+
+			if err := future.WaitForCompletionRef(ctx, client.Client); err != nil {
+				return fmt.Errorf("waiting for creation of %s: %+v", id, err)
+			}
+
+			metadata.SetID(id)
+			return nil
+		},
+	}
+}
+
+func (r MsSqlFailoverGroupResource) Update() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.MSSQL.FailoverGroupsClient
+
+			id, err := parse.FailoverGroupID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			var model MsSqlFailoverGroupModel
+			if err := metadata.Decode(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			// changes to the failover group have to be made on whichever server is currently the primary
+			primaryId := parse.NewServerID(id.SubscriptionId, id.ResourceGroup, id.ServerName)
+			if model.PrimaryServerId != "" {
+				v, err := parse.ServerID(model.PrimaryServerId)
+				if err != nil {
+					return err
+				}
+				primaryId = *v
+			}
+
+			if metadata.ResourceData.HasChange("primary_server_id") && model.PrimaryServerId != "" {
+				if !msSqlFailoverGroupContainsServer(model, primaryId) {
+					return fmt.Errorf("`primary_server_id` must be either `server_id` or the `id` of a `partner_server`")
+				}
+
+				// a planned failover is initiated from the server which should become the primary
+				metadata.Logger.Infof("failing over %s to %s", id, primaryId)
+
+				future, err := client.Failover(ctx, primaryId.ResourceGroup, primaryId.Name, id.Name)
+				if err != nil {
+					return fmt.Errorf("failing over %s to %s: %+v", id, primaryId, err)
+				}
+
+				if err := future.WaitForCompletionRef(ctx, client.Client); err != nil {
+					return fmt.Errorf("waiting for failover of %s to %s: %+v", id, primaryId, err)
+				}
+			}
+
+			if metadata.ResourceData.HasChanges("partner_server", "databases", "readonly_endpoint_failover_policy_enabled", "read_write_endpoint_failover_policy", "tags") {
+				parameters, err := expandMsSqlFailoverGroup(model, primaryId)
+				if err != nil {
+					return err
+				}
+
+				metadata.Logger.Infof("updating %s", id)
+
+				future, err := client.CreateOrUpdate(ctx, primaryId.ResourceGroup, primaryId.Name, id.Name, *parameters)
+				if err != nil {
+					return fmt.Errorf("updating %s: %+v", id, err)
+				}
+
+				if err := future.WaitForCompletionRef(ctx, client.Client); err != nil {
+					return fmt.Errorf("waiting for update of %s: %+v", id, err)
+				}
+			}
+
+			return nil
+		},
+	}
+}
+
+func (r MsSqlFailoverGroupResource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.MSSQL.FailoverGroupsClient
+
+			id, err := parse.FailoverGroupID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			existing, err := client.Get(ctx, id.ResourceGroup, id.ServerName, id.Name)
+			if err != nil {
+				if utils.ResponseWasNotFound(existing.Response) {
+					return metadata.MarkAsGone(id)
+				}
+				return fmt.Errorf("retrieving %s: %+v", id, err)
+			}
+
+			// the partner servers are configured from the perspective of `server_id`, which won't match the API once
+			// a failover has taken place - so we only refresh the location and role of the configured partners
+			var state MsSqlFailoverGroupModel
+			if err := metadata.Decode(&state); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			serverId := parse.NewServerID(id.SubscriptionId, id.ResourceGroup, id.ServerName)
+			model := MsSqlFailoverGroupModel{
+				Name:     id.Name,
+				ServerId: serverId.ID(),
+				Tags:     tags.Flatten(existing.Tags),
+			}
+
+			if props := existing.FailoverGroupProperties; props != nil {
+				model.PrimaryServerId = serverId.ID()
+				apiPartners := make([]MsSqlFailoverGroupPartnerServerModel, 0)
+				if props.PartnerServers != nil {
+					for _, partner := range *props.PartnerServers {
+						if partner.ID == nil {
+							continue
+						}
+
+						partnerId, err := parse.ServerID(*partner.ID)
+						if err != nil {
+							return fmt.Errorf("parsing partner server ID %q: %+v", *partner.ID, err)
+						}
+
+						if partner.ReplicationRole == sql.Primary {
+							model.PrimaryServerId = partnerId.ID()
+						}
+
+						apiPartners = append(apiPartners, MsSqlFailoverGroupPartnerServerModel{
+							Id:       partnerId.ID(),
+							Location: location.NormalizeNilable(partner.Location),
+							Role:     string(partner.ReplicationRole),
+						})
+					}
+				}
+
+				model.PartnerServers = apiPartners
+				if props.ReplicationRole == sql.Secondary && len(state.PartnerServers) > 0 {
+					partners := make([]MsSqlFailoverGroupPartnerServerModel, 0)
+					for _, partner := range state.PartnerServers {
+						for _, v := range apiPartners {
+							if strings.EqualFold(v.Id, partner.Id) {
+								partner = v
+								break
+							}
+						}
+						partners = append(partners, partner)
+					}
+					model.PartnerServers = partners
+				}
+
+				if props.Databases != nil {
+					databases := make([]string, 0)
+					for _, v := range *props.Databases {
+						databaseId, err := parse.DatabaseID(v)
+						if err != nil {
+							return fmt.Errorf("parsing database ID %q: %+v", v, err)
+						}
+						// geo-replicated databases share a name, so these are expressed relative to `server_id`
+						databases = append(databases, parse.NewDatabaseID(serverId.SubscriptionId, serverId.ResourceGroup, serverId.Name, databaseId.Name).ID())
+					}
+					model.Databases = databases
+				}
+
+				if endpoint := props.ReadOnlyEndpoint; endpoint != nil {
+					model.ReadOnlyEndpointFailoverPolicyEnabled = endpoint.FailoverPolicy == sql.ReadOnlyEndpointFailoverPolicyEnabled
+				}
+
+				if endpoint := props.ReadWriteEndpoint; endpoint != nil {
+					policy := MsSqlFailoverGroupReadWriteEndpointPolicyModel{
+						Mode: string(endpoint.FailoverPolicy),
+					}
+					if endpoint.FailoverWithDataLossGracePeriodMinutes != nil {
+						policy.GraceMinutes = int(*endpoint.FailoverWithDataLossGracePeriodMinutes)
+					}
+					model.ReadWriteEndpointFailoverPolicy = []MsSqlFailoverGroupReadWriteEndpointPolicyModel{policy}
+				}
+			}
+
+			return metadata.Encode(&model)
+		},
+	}
+}
+
+func (r MsSqlFailoverGroupResource) Delete() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.MSSQL.FailoverGroupsClient
+
+			id, err := parse.FailoverGroupID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			metadata.Logger.Infof("deleting %s", id)
+
+			future, err := client.Delete(ctx, id.ResourceGroup, id.ServerName, id.Name)
+			if err != nil {
+				if response.WasNotFound(future.Response()) {
+					return nil
+				}
+				return fmt.Errorf("deleting %s: %+v", id, err)
+			}
+
+			if err := future.WaitForCompletionRef(ctx, client.Client); err != nil {
+				return fmt.Errorf("waiting for deletion of %s: %+v", id, err)
+			}
+
+			return nil
+		},
+	}
+}
+
+// expandMsSqlFailoverGroup builds the failover group from the perspective of the specified primary server, where the
+// partners are every other server in the group
+func expandMsSqlFailoverGroup(model MsSqlFailoverGroupModel, primaryId parse.ServerId) (*sql.FailoverGroup, error) {
+	readOnlyPolicy := sql.ReadOnlyEndpointFailoverPolicyDisabled
+	if model.ReadOnlyEndpointFailoverPolicyEnabled {
+		readOnlyPolicy = sql.ReadOnlyEndpointFailoverPolicyEnabled
+	}
+
+	readWriteEndpoint := &sql.FailoverGroupReadWriteEndpoint{}
+	if len(model.ReadWriteEndpointFailoverPolicy) > 0 {
+		policy := model.ReadWriteEndpointFailoverPolicy[0]
+		readWriteEndpoint.FailoverPolicy = sql.ReadWriteEndpointFailoverPolicy(policy.Mode)
+
+		if policy.Mode == string(sql.Automatic) {
+			if policy.GraceMinutes == 0 {
+				return nil, fmt.Errorf("`grace_minutes` must be specified when `mode` is `%s`", sql.Automatic)
+			}
+			readWriteEndpoint.FailoverWithDataLossGracePeriodMinutes = utils.Int32(int32(policy.GraceMinutes))
+		}
+	}
+
+	serverIds := []string{model.ServerId}
+	for _, partner := range model.PartnerServers {
+		serverIds = append(serverIds, partner.Id)
+	}
+
+	partners := make([]sql.PartnerInfo, 0)
+	for _, v := range serverIds {
+		if strings.EqualFold(v, primaryId.ID()) {
+			continue
+		}
+		partners = append(partners, sql.PartnerInfo{
+			ID: utils.String(v),
+		})
+	}
+
+	// the databases are configured relative to `server_id` but have to reference the replicas on the primary server
+	databases := make([]string, 0)
+	for _, v := range model.Databases {
+		databaseId, err := parse.DatabaseID(v)
+		if err != nil {
+			return nil, err
+		}
+		databases = append(databases, parse.NewDatabaseID(primaryId.SubscriptionId, primaryId.ResourceGroup, primaryId.Name, databaseId.Name).ID())
+	}
+
+	return &sql.FailoverGroup{
+		FailoverGroupProperties: &sql.FailoverGroupProperties{
+			ReadOnlyEndpoint: &sql.FailoverGroupReadOnlyEndpoint{
+				FailoverPolicy: readOnlyPolicy,
+			},
+			ReadWriteEndpoint: readWriteEndpoint,
+			PartnerServers:    &partners,
+			Databases:         &databases,
+		},
+		Tags: tags.Expand(model.Tags),
+	}, nil
+}
+
+func msSqlFailoverGroupContainsServer(model MsSqlFailoverGroupModel, serverId parse.ServerId) bool {
+	if strings.EqualFold(model.ServerId, serverId.ID()) {
+		return true
+	}
+
+	for _, partner := range model.PartnerServers {
+		if strings.EqualFold(partner.Id, serverId.ID()) {
+			return true
+		}
+	}
+
+	return false
+}
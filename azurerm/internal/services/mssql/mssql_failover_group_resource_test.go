@@ -0,0 +1,250 @@
+package mssql_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/acceptance"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/acceptance/check"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/clients"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/mssql/parse"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tf/pluginsdk"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+type MsSqlFailoverGroupResource struct{}
+
+func TestAccMsSqlFailoverGroup_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_mssql_failover_group", "test")
+	r := MsSqlFailoverGroupResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("partner_server.0.role").HasValue("Secondary"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccMsSqlFailoverGroup_requiresImport(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_mssql_failover_group", "test")
+	r := MsSqlFailoverGroupResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.RequiresImportErrorStep(r.requiresImport),
+	})
+}
+
+func TestAccMsSqlFailoverGroup_update(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_mssql_failover_group", "test")
+	r := MsSqlFailoverGroupResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.update(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("databases.#").HasValue("1"),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccMsSqlFailoverGroup_failover(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_mssql_failover_group", "test")
+	r := MsSqlFailoverGroupResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.primary(data, "azurerm_mssql_server.test.id"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.primary(data, "azurerm_mssql_server.secondary.id"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("partner_server.0.role").HasValue("Primary"),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.primary(data, "azurerm_mssql_server.test.id"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("partner_server.0.role").HasValue("Secondary"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func (r MsSqlFailoverGroupResource) Exists(ctx context.Context, client *clients.Client, state *pluginsdk.InstanceState) (*bool, error) {
+	id, err := parse.FailoverGroupID(state.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.MSSQL.FailoverGroupsClient.Get(ctx, id.ResourceGroup, id.ServerName, id.Name)
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			return utils.Bool(false), nil
+		}
+		return nil, fmt.Errorf("retrieving %s: %+v", id, err)
+	}
+
+	return utils.Bool(true), nil
+}
+
+func (r MsSqlFailoverGroupResource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_mssql_failover_group" "test" {
+  name      = "acctestsfg%d"
+  server_id = azurerm_mssql_server.test.id
+
+  partner_server {
+    id = azurerm_mssql_server.secondary.id
+  }
+
+  read_write_endpoint_failover_policy {
+    mode = "Manual"
+  }
+}
+`, r.template(data), data.RandomInteger)
+}
+
+func (r MsSqlFailoverGroupResource) requiresImport(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_mssql_failover_group" "import" {
+  name      = azurerm_mssql_failover_group.test.name
+  server_id = azurerm_mssql_failover_group.test.server_id
+
+  partner_server {
+    id = azurerm_mssql_failover_group.test.partner_server.0.id
+  }
+
+  read_write_endpoint_failover_policy {
+    mode = "Manual"
+  }
+}
+`, r.basic(data))
+}
+
+func (r MsSqlFailoverGroupResource) update(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_mssql_failover_group" "test" {
+  name      = "acctestsfg%d"
+  server_id = azurerm_mssql_server.test.id
+  databases = [azurerm_mssql_database.test.id]
+
+  partner_server {
+    id = azurerm_mssql_server.secondary.id
+  }
+
+  readonly_endpoint_failover_policy_enabled = true
+
+  read_write_endpoint_failover_policy {
+    mode          = "Automatic"
+    grace_minutes = 60
+  }
+
+  tags = {
+    environment = "prod"
+  }
+}
+`, r.template(data), data.RandomInteger)
+}
+
+func (r MsSqlFailoverGroupResource) primary(data acceptance.TestData, primaryServerId string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_mssql_failover_group" "test" {
+  name              = "acctestsfg%d"
+  server_id         = azurerm_mssql_server.test.id
+  databases         = [azurerm_mssql_database.test.id]
+  primary_server_id = %s
+
+  partner_server {
+    id = azurerm_mssql_server.secondary.id
+  }
+
+  read_write_endpoint_failover_policy {
+    mode = "Manual"
+  }
+}
+`, r.template(data), data.RandomInteger, primaryServerId)
+}
+
+func (MsSqlFailoverGroupResource) template(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-mssql-%[1]d"
+  location = "%[2]s"
+}
+
+resource "azurerm_mssql_server" "test" {
+  name                         = "acctestmssql%[1]d"
+  resource_group_name          = azurerm_resource_group.test.name
+  location                     = azurerm_resource_group.test.location
+  version                      = "12.0"
+  administrator_login          = "mradministrator"
+  administrator_login_password = "thisIsDog11"
+}
+
+resource "azurerm_mssql_server" "secondary" {
+  name                         = "acctestmssql2%[1]d"
+  resource_group_name          = azurerm_resource_group.test.name
+  location                     = "%[3]s"
+  version                      = "12.0"
+  administrator_login          = "mradministrator"
+  administrator_login_password = "thisIsDog11"
+}
+
+resource "azurerm_mssql_database" "test" {
+  name        = "acctestdb%[1]d"
+  server_id   = azurerm_mssql_server.test.id
+  sku_name    = "S1"
+  collation   = "SQL_Latin1_General_CP1_CI_AS"
+  max_size_gb = "200"
+}
+`, data.RandomInteger, data.Locations.Primary, data.Locations.Secondary)
+}
@@ -0,0 +1,252 @@
+package mssql
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/preview/sql/mgmt/v3.0/sql"
+	"github.com/gofrs/uuid"
+	"github.com/hashicorp/go-azure-helpers/response"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/sdk"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/mssql/parse"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/mssql/validate"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tf/pluginsdk"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tf/validation"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+// managedInstanceAdministratorName is the only supported name for the Azure Active Directory administrator of a
+// Managed Instance
+const managedInstanceAdministratorName = "ActiveDirectory"
+
+type MsSqlManagedInstanceActiveDirectoryAdministratorModel struct {
+	ManagedInstanceId string `tfschema:"managed_instance_id"`
+	LoginUsername     string `tfschema:"login_username"`
+	ObjectId          string `tfschema:"object_id"`
+	TenantId          string `tfschema:"tenant_id"`
+}
+
+type MsSqlManagedInstanceActiveDirectoryAdministratorResource struct{}
+
+var _ sdk.Resource = MsSqlManagedInstanceActiveDirectoryAdministratorResource{}
+var _ sdk.ResourceWithUpdate = MsSqlManagedInstanceActiveDirectoryAdministratorResource{}
+
+func (r MsSqlManagedInstanceActiveDirectoryAdministratorResource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"managed_instance_id": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validate.ManagedInstanceID,
+		},
+
+		"login_username": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+
+		"object_id": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ValidateFunc: validation.IsUUID,
+		},
+
+		"tenant_id": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ValidateFunc: validation.IsUUID,
+		},
+	}
+}
+
+func (r MsSqlManagedInstanceActiveDirectoryAdministratorResource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{}
+}
+
+func (r MsSqlManagedInstanceActiveDirectoryAdministratorResource) ModelObject() interface{} {
+	return MsSqlManagedInstanceActiveDirectoryAdministratorModel{}
+}
+
+func (r MsSqlManagedInstanceActiveDirectoryAdministratorResource) ResourceType() string {
+	return "azurerm_mssql_managed_instance_active_directory_administrator"
+}
+
+func (r MsSqlManagedInstanceActiveDirectoryAdministratorResource) IDValidationFunc() pluginsdk.SchemaValidateFunc {
+	return validate.ManagedInstanceAzureActiveDirectoryAdministratorID
+}
+
+func (r MsSqlManagedInstanceActiveDirectoryAdministratorResource) Create() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.MSSQL.ManagedInstanceAdministratorsClient
+
+			var model MsSqlManagedInstanceActiveDirectoryAdministratorModel
+			if err := metadata.Decode(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			instanceId, err := parse.ManagedInstanceID(model.ManagedInstanceId)
+			if err != nil {
+				return err
+			}
+
+			id := parse.NewManagedInstanceAzureActiveDirectoryAdministratorID(instanceId.SubscriptionId, instanceId.ResourceGroup, instanceId.Name, managedInstanceAdministratorName)
+
+			existing, err := client.Get(ctx, id.ResourceGroup, id.ManagedInstanceName)
+			if err != nil && !utils.ResponseWasNotFound(existing.Response) {
+				return fmt.Errorf("checking for presence of existing %s: %+v", id, err)
+			}
+			if !utils.ResponseWasNotFound(existing.Response) {
+				return metadata.ResourceRequiresImport(r.ResourceType(), id)
+			}
+
+			parameters, err := expandMsSqlManagedInstanceActiveDirectoryAdministrator(model)
+			if err != nil {
+				return err
+			}
+
+			metadata.Logger.Infof("creating %s", id)
+
+			future, err := client.CreateOrUpdate(ctx, id.ResourceGroup, id.ManagedInstanceName, *parameters)
+			if err != nil {
+				return fmt.Errorf("creating %s: %+v", id, err)
+			}
+
+			if err := future.WaitForCompletionRef(ctx, client.Client); err != nil {
+				return fmt.Errorf("waiting for creation of %s: %+v", id, err)
+			}
+
+			metadata.SetID(id)
+			return nil
+		},
+	}
+}
+
+func (r MsSqlManagedInstanceActiveDirectoryAdministratorResource) Update() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.MSSQL.ManagedInstanceAdministratorsClient
+
+			id, err := parse.ManagedInstanceAzureActiveDirectoryAdministratorID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			var model MsSqlManagedInstanceActiveDirectoryAdministratorModel
+			if err := metadata.Decode(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			parameters, err := expandMsSqlManagedInstanceActiveDirectoryAdministrator(model)
+			if err != nil {
+				return err
+			}
+
+			metadata.Logger.Infof("updating %s", id)
+
+			future, err := client.CreateOrUpdate(ctx, id.ResourceGroup, id.ManagedInstanceName, *parameters)
+			if err != nil {
+				return fmt.Errorf("updating %s: %+v", id, err)
+			}
+
+			if err := future.WaitForCompletionRef(ctx, client.Client); err != nil {
+				return fmt.Errorf("waiting for update of %s: %+v", id, err)
+			}
+
+			return nil
+		},
+	}
+}
+
+func (r MsSqlManagedInstanceActiveDirectoryAdministratorResource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.MSSQL.ManagedInstanceAdministratorsClient
+
+			id, err := parse.ManagedInstanceAzureActiveDirectoryAdministratorID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			existing, err := client.Get(ctx, id.ResourceGroup, id.ManagedInstanceName)
+			if err != nil {
+				if utils.ResponseWasNotFound(existing.Response) {
+					return metadata.MarkAsGone(id)
+				}
+				return fmt.Errorf("retrieving %s: %+v", id, err)
+			}
+
+			model := MsSqlManagedInstanceActiveDirectoryAdministratorModel{
+				ManagedInstanceId: parse.NewManagedInstanceID(id.SubscriptionId, id.ResourceGroup, id.ManagedInstanceName).ID(),
+			}
+
+			if props := existing.ManagedInstanceAdministratorProperties; props != nil {
+				model.LoginUsername = utils.NormalizeNilableString(props.Login)
+				if props.Sid != nil {
+					model.ObjectId = props.Sid.String()
+				}
+				if props.TenantID != nil {
+					model.TenantId = props.TenantID.String()
+				}
+			}
+
+			return metadata.Encode(&model)
+		},
+	}
+}
+
+func (r MsSqlManagedInstanceActiveDirectoryAdministratorResource) Delete() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.MSSQL.ManagedInstanceAdministratorsClient
+
+			id, err := parse.ManagedInstanceAzureActiveDirectoryAdministratorID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			metadata.Logger.Infof("deleting %s", id)
+
+			future, err := client.Delete(ctx, id.ResourceGroup, id.ManagedInstanceName)
+			if err != nil {
+				if response.WasNotFound(future.Response()) {
+					return nil
+				}
+				return fmt.Errorf("deleting %s: %+v", id, err)
+			}
+
+			if err := future.WaitForCompletionRef(ctx, client.Client); err != nil {
+				return fmt.Errorf("waiting for deletion of %s: %+v", id, err)
+			}
+
+			return nil
+		},
+	}
+}
+
+func expandMsSqlManagedInstanceActiveDirectoryAdministrator(model MsSqlManagedInstanceActiveDirectoryAdministratorModel) (*sql.ManagedInstanceAdministrator, error) {
+	objectId, err := uuid.FromString(model.ObjectId)
+	if err != nil {
+		return nil, fmt.Errorf("parsing `object_id`: %+v", err)
+	}
+
+	tenantId, err := uuid.FromString(model.TenantId)
+	if err != nil {
+		return nil, fmt.Errorf("parsing `tenant_id`: %+v", err)
+	}
+
+	return &sql.ManagedInstanceAdministrator{
+		ManagedInstanceAdministratorProperties: &sql.ManagedInstanceAdministratorProperties{
+			AdministratorType: utils.String(managedInstanceAdministratorName),
+			Login:             utils.String(model.LoginUsername),
+			Sid:               &objectId,
+			TenantID:          &tenantId,
+		},
+	}, nil
+}
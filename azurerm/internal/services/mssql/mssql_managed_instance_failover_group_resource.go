@@ -0,0 +1,389 @@
+package mssql
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/preview/sql/mgmt/v3.0/sql"
+	"github.com/hashicorp/go-azure-helpers/response"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/location"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/sdk"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/mssql/parse"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/mssql/validate"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tf/pluginsdk"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tf/validation"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+type MsSqlManagedInstanceFailoverGroupModel struct {
+	Name                                  string                                                     `tfschema:"name"`
+	Location                              string                                                     `tfschema:"location"`
+	ManagedInstanceId                     string                                                     `tfschema:"managed_instance_id"`
+	PartnerManagedInstanceId              string                                                     `tfschema:"partner_managed_instance_id"`
+	ReadOnlyEndpointFailoverPolicyEnabled bool                                                       `tfschema:"readonly_endpoint_failover_policy_enabled"`
+	ReadWriteEndpointFailoverPolicy       []MsSqlManagedInstanceReadWriteEndpointFailoverPolicyModel `tfschema:"read_write_endpoint_failover_policy"`
+	PartnerRegion                         []MsSqlManagedInstancePartnerRegionModel                   `tfschema:"partner_region"`
+	Role                                  string                                                     `tfschema:"role"`
+}
+
+type MsSqlManagedInstanceReadWriteEndpointFailoverPolicyModel struct {
+	Mode         string `tfschema:"mode"`
+	GraceMinutes int    `tfschema:"grace_minutes"`
+}
+
+type MsSqlManagedInstancePartnerRegionModel struct {
+	Location string `tfschema:"location"`
+	Role     string `tfschema:"role"`
+}
+
+type MsSqlManagedInstanceFailoverGroupResource struct{}
+
+var _ sdk.Resource = MsSqlManagedInstanceFailoverGroupResource{}
+var _ sdk.ResourceWithUpdate = MsSqlManagedInstanceFailoverGroupResource{}
+
+func (r MsSqlManagedInstanceFailoverGroupResource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"name": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validate.ValidateMsSqlFailoverGroupName,
+		},
+
+		"location": location.Schema(),
+
+		"managed_instance_id": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validate.ManagedInstanceID,
+		},
+
+		"partner_managed_instance_id": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validate.ManagedInstanceID,
+		},
+
+		"readonly_endpoint_failover_policy_enabled": {
+			Type:     pluginsdk.TypeBool,
+			Optional: true,
+			Default:  true,
+		},
+
+		"read_write_endpoint_failover_policy": {
+			Type:     pluginsdk.TypeList,
+			Required: true,
+			MaxItems: 1,
+			Elem: &pluginsdk.Resource{
+				Schema: map[string]*pluginsdk.Schema{
+					"mode": {
+						Type:     pluginsdk.TypeString,
+						Required: true,
+						ValidateFunc: validation.StringInSlice([]string{
+							string(sql.Automatic),
+							string(sql.Manual),
+						}, false),
+					},
+
+					"grace_minutes": {
+						Type:         pluginsdk.TypeInt,
+						Optional:     true,
+						ValidateFunc: validation.IntAtLeast(60),
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r MsSqlManagedInstanceFailoverGroupResource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"partner_region": {
+			Type:     pluginsdk.TypeList,
+			Computed: true,
+			Elem: &pluginsdk.Resource{
+				Schema: map[string]*pluginsdk.Schema{
+					"location": {
+						Type:     pluginsdk.TypeString,
+						Computed: true,
+					},
+
+					"role": {
+						Type:     pluginsdk.TypeString,
+						Computed: true,
+					},
+				},
+			},
+		},
+
+		"role": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+	}
+}
+
+func (r MsSqlManagedInstanceFailoverGroupResource) ModelObject() interface{} {
+	return MsSqlManagedInstanceFailoverGroupModel{}
+}
+
+func (r MsSqlManagedInstanceFailoverGroupResource) ResourceType() string {
+	return "azurerm_mssql_managed_instance_failover_group"
+}
+
+func (r MsSqlManagedInstanceFailoverGroupResource) IDValidationFunc() pluginsdk.SchemaValidateFunc {
+	return validate.InstanceFailoverGroupID
+}
+
+func (r MsSqlManagedInstanceFailoverGroupResource) Create() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.MSSQL.ManagedInstanceFailoverGroupsClient
+
+			var model MsSqlManagedInstanceFailoverGroupModel
+			if err := metadata.Decode(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			instanceId, err := parse.ManagedInstanceID(model.ManagedInstanceId)
+			if err != nil {
+				return err
+			}
+
+			id := parse.NewInstanceFailoverGroupID(instanceId.SubscriptionId, instanceId.ResourceGroup, location.Normalize(model.Location), model.Name)
+
+			existing, err := client.Get(ctx, id.ResourceGroup, id.LocationName, id.Name)
+			if err != nil && !utils.ResponseWasNotFound(existing.Response) {
+				return fmt.Errorf("checking for presence of existing %s: %+v", id, err)
+			}
+			if !utils.ResponseWasNotFound(existing.Response) {
+				return metadata.ResourceRequiresImport(r.ResourceType(), id)
+			}
+
+			parameters, err := expandMsSqlManagedInstanceFailoverGroup(ctx, metadata, model)
+			if err != nil {
+				return err
+			}
+
+			metadata.Logger.Infof("creating %s", id)
+
+			future, err := client.CreateOrUpdate(ctx, id.ResourceGroup, id.LocationName, id.Name, *parameters)
+			if err != nil {
+				return fmt.Errorf("creating %s: %+v", id, err)
+			}
+
+			if err := future.WaitForCompletionRef(ctx, client.Client); err != nil {
+				return fmt.Errorf("waiting for creation of %s: %+v", id, err)
+			}
+
+			metadata.SetID(id)
+			return nil
+		},
+	}
+}
+
+func (r MsSqlManagedInstanceFailoverGroupResource) Update() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.MSSQL.ManagedInstanceFailoverGroupsClient
+
+			id, err := parse.InstanceFailoverGroupID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			var model MsSqlManagedInstanceFailoverGroupModel
+			if err := metadata.Decode(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			parameters, err := expandMsSqlManagedInstanceFailoverGroup(ctx, metadata, model)
+			if err != nil {
+				return err
+			}
+
+			metadata.Logger.Infof("updating %s", id)
+
+			future, err := client.CreateOrUpdate(ctx, id.ResourceGroup, id.LocationName, id.Name, *parameters)
+			if err != nil {
+				return fmt.Errorf("updating %s: %+v", id, err)
+			}
+
+			if err := future.WaitForCompletionRef(ctx, client.Client); err != nil {
+				return fmt.Errorf("waiting for update of %s: %+v", id, err)
+			}
+
+			return nil
+		},
+	}
+}
+
+func (r MsSqlManagedInstanceFailoverGroupResource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.MSSQL.ManagedInstanceFailoverGroupsClient
+
+			id, err := parse.InstanceFailoverGroupID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			existing, err := client.Get(ctx, id.ResourceGroup, id.LocationName, id.Name)
+			if err != nil {
+				if utils.ResponseWasNotFound(existing.Response) {
+					return metadata.MarkAsGone(id)
+				}
+				return fmt.Errorf("retrieving %s: %+v", id, err)
+			}
+
+			model := MsSqlManagedInstanceFailoverGroupModel{
+				Name:     id.Name,
+				Location: location.Normalize(id.LocationName),
+			}
+
+			if props := existing.InstanceFailoverGroupProperties; props != nil {
+				model.Role = string(props.ReplicationRole)
+
+				if endpoint := props.ReadOnlyEndpoint; endpoint != nil {
+					model.ReadOnlyEndpointFailoverPolicyEnabled = endpoint.FailoverPolicy == sql.ReadOnlyEndpointFailoverPolicyEnabled
+				}
+
+				if endpoint := props.ReadWriteEndpoint; endpoint != nil {
+					policy := MsSqlManagedInstanceReadWriteEndpointFailoverPolicyModel{
+						Mode: string(endpoint.FailoverPolicy),
+					}
+					if endpoint.FailoverWithDataLossGracePeriodMinutes != nil {
+						policy.GraceMinutes = int(*endpoint.FailoverWithDataLossGracePeriodMinutes)
+					}
+					model.ReadWriteEndpointFailoverPolicy = []MsSqlManagedInstanceReadWriteEndpointFailoverPolicyModel{policy}
+				}
+
+				if pairs := props.ManagedInstancePairs; pairs != nil && len(*pairs) > 0 {
+					pair := (*pairs)[0]
+
+					// the pair is returned from the perspective of the current primary, so once a failover has taken
+					// place the instances are reversed - map them back onto the instances that were configured
+					primaryId := utils.NormalizeNilableString(pair.PrimaryManagedInstanceID)
+					partnerId := utils.NormalizeNilableString(pair.PartnerManagedInstanceID)
+					if props.ReplicationRole == sql.InstanceFailoverGroupReplicationRoleSecondary {
+						primaryId, partnerId = partnerId, primaryId
+					}
+
+					instanceId, err := parse.ManagedInstanceID(primaryId)
+					if err != nil {
+						return fmt.Errorf("parsing `primaryManagedInstanceId`: %+v", err)
+					}
+					model.ManagedInstanceId = instanceId.ID()
+
+					partnerInstanceId, err := parse.ManagedInstanceID(partnerId)
+					if err != nil {
+						return fmt.Errorf("parsing `partnerManagedInstanceId`: %+v", err)
+					}
+					model.PartnerManagedInstanceId = partnerInstanceId.ID()
+				}
+
+				partnerRegions := make([]MsSqlManagedInstancePartnerRegionModel, 0)
+				if props.PartnerRegions != nil {
+					for _, region := range *props.PartnerRegions {
+						partnerRegions = append(partnerRegions, MsSqlManagedInstancePartnerRegionModel{
+							Location: location.NormalizeNilable(region.Location),
+							Role:     string(region.ReplicationRole),
+						})
+					}
+				}
+				model.PartnerRegion = partnerRegions
+			}
+
+			return metadata.Encode(&model)
+		},
+	}
+}
+
+func (r MsSqlManagedInstanceFailoverGroupResource) Delete() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.MSSQL.ManagedInstanceFailoverGroupsClient
+
+			id, err := parse.InstanceFailoverGroupID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			metadata.Logger.Infof("deleting %s", id)
+
+			future, err := client.Delete(ctx, id.ResourceGroup, id.LocationName, id.Name)
+			if err != nil {
+				if response.WasNotFound(future.Response()) {
+					return nil
+				}
+				return fmt.Errorf("deleting %s: %+v", id, err)
+			}
+
+			if err := future.WaitForCompletionRef(ctx, client.Client); err != nil {
+				return fmt.Errorf("waiting for deletion of %s: %+v", id, err)
+			}
+
+			return nil
+		},
+	}
+}
+
+func expandMsSqlManagedInstanceFailoverGroup(ctx context.Context, metadata sdk.ResourceMetaData, model MsSqlManagedInstanceFailoverGroupModel) (*sql.InstanceFailoverGroup, error) {
+	instancesClient := metadata.Client.MSSQL.ManagedInstancesClient
+
+	partnerId, err := parse.ManagedInstanceID(model.PartnerManagedInstanceId)
+	if err != nil {
+		return nil, err
+	}
+
+	// the partner region has to be specified alongside the partner instance, so look it up
+	partner, err := instancesClient.Get(ctx, partnerId.ResourceGroup, partnerId.Name)
+	if err != nil {
+		return nil, fmt.Errorf("retrieving partner %s: %+v", partnerId, err)
+	}
+
+	readOnlyPolicy := sql.ReadOnlyEndpointFailoverPolicyDisabled
+	if model.ReadOnlyEndpointFailoverPolicyEnabled {
+		readOnlyPolicy = sql.ReadOnlyEndpointFailoverPolicyEnabled
+	}
+
+	readWriteEndpoint := &sql.InstanceFailoverGroupReadWriteEndpoint{}
+	if len(model.ReadWriteEndpointFailoverPolicy) > 0 {
+		policy := model.ReadWriteEndpointFailoverPolicy[0]
+		readWriteEndpoint.FailoverPolicy = sql.ReadWriteEndpointFailoverPolicy(policy.Mode)
+
+		if policy.Mode == string(sql.Automatic) {
+			if policy.GraceMinutes == 0 {
+				return nil, fmt.Errorf("`grace_minutes` must be specified when `mode` is `%s`", sql.Automatic)
+			}
+			readWriteEndpoint.FailoverWithDataLossGracePeriodMinutes = utils.Int32(int32(policy.GraceMinutes))
+		}
+	}
+
+	return &sql.InstanceFailoverGroup{
+		InstanceFailoverGroupProperties: &sql.InstanceFailoverGroupProperties{
+			ReadOnlyEndpoint: &sql.InstanceFailoverGroupReadOnlyEndpoint{
+				FailoverPolicy: readOnlyPolicy,
+			},
+			ReadWriteEndpoint: readWriteEndpoint,
+			PartnerRegions: &[]sql.PartnerRegionInfo{
+				{
+					Location: partner.Location,
+				},
+			},
+			ManagedInstancePairs: &[]sql.ManagedInstancePairInfo{
+				{
+					PrimaryManagedInstanceID: utils.String(model.ManagedInstanceId),
+					PartnerManagedInstanceID: utils.String(partnerId.ID()),
+				},
+			},
+		},
+	}, nil
+}
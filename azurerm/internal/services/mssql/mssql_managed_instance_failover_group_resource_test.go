@@ -0,0 +1,122 @@
+package mssql_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/acceptance"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/acceptance/check"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/clients"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/mssql/parse"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tf/pluginsdk"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+type MsSqlManagedInstanceFailoverGroupResource struct{}
+
+func TestAccMsSqlManagedInstanceFailoverGroup_update(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_mssql_managed_instance_failover_group", "test")
+	r := MsSqlManagedInstanceFailoverGroupResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data, "Automatic"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("role").HasValue("Primary"),
+				check.That(data.ResourceName).Key("partner_region.#").HasValue("1"),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.basic(data, "Manual"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func (r MsSqlManagedInstanceFailoverGroupResource) Exists(ctx context.Context, client *clients.Client, state *pluginsdk.InstanceState) (*bool, error) {
+	id, err := parse.InstanceFailoverGroupID(state.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.MSSQL.ManagedInstanceFailoverGroupsClient.Get(ctx, id.ResourceGroup, id.LocationName, id.Name)
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			return utils.Bool(false), nil
+		}
+		return nil, fmt.Errorf("retrieving %s: %+v", id, err)
+	}
+
+	return utils.Bool(true), nil
+}
+
+func (r MsSqlManagedInstanceFailoverGroupResource) basic(data acceptance.TestData, mode string) string {
+	graceMinutes := ""
+	if mode == "Automatic" {
+		graceMinutes = "grace_minutes = 60"
+	}
+
+	return fmt.Sprintf(`
+%[1]s
+
+%[2]s
+
+resource "azurerm_mssql_managed_instance" "secondary" {
+  name                = "acctestsqlserver2%[3]d"
+  resource_group_name = azurerm_resource_group.test.name
+  location            = "%[4]s"
+
+  license_type       = "BasePrice"
+  sku_name           = "GP_Gen5"
+  storage_size_in_gb = 32
+  subnet_id          = azurerm_subnet.secondary.id
+  vcores             = 4
+
+  administrator_login          = "missadministrator"
+  administrator_login_password = "NCC-1701-D"
+  dns_zone_partner_id          = azurerm_mssql_managed_instance.test.id
+
+  depends_on = [
+    azurerm_subnet_network_security_group_association.secondary,
+    azurerm_subnet_route_table_association.secondary,
+  ]
+}
+
+resource "azurerm_virtual_network_peering" "test" {
+  name                      = "peer-primary-%[3]d"
+  resource_group_name       = azurerm_resource_group.test.name
+  virtual_network_name      = azurerm_virtual_network.test.name
+  remote_virtual_network_id = azurerm_virtual_network.secondary.id
+}
+
+resource "azurerm_virtual_network_peering" "secondary" {
+  name                      = "peer-secondary-%[3]d"
+  resource_group_name       = azurerm_resource_group.test.name
+  virtual_network_name      = azurerm_virtual_network.secondary.name
+  remote_virtual_network_id = azurerm_virtual_network.test.id
+}
+
+resource "azurerm_mssql_managed_instance_failover_group" "test" {
+  name                        = "acctest-fog-%[3]d"
+  location                    = azurerm_mssql_managed_instance.test.location
+  managed_instance_id         = azurerm_mssql_managed_instance.test.id
+  partner_managed_instance_id = azurerm_mssql_managed_instance.secondary.id
+
+  read_write_endpoint_failover_policy {
+    mode = "%[5]s"
+    %[6]s
+  }
+
+  depends_on = [
+    azurerm_virtual_network_peering.test,
+    azurerm_virtual_network_peering.secondary,
+  ]
+}
+`, MsSqlManagedInstanceResource{}.basic(data), MsSqlManagedInstanceResource{}.network(data, data.Locations.Secondary, "secondary", "10.1.0.0/16"), data.RandomInteger, data.Locations.Secondary, mode, graceMinutes)
+}
@@ -0,0 +1,497 @@
+package mssql
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/preview/sql/mgmt/v3.0/sql"
+	"github.com/hashicorp/go-azure-helpers/response"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/azure"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/location"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/sdk"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/mssql/parse"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/mssql/validate"
+	networkValidate "github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/network/validate"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tags"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tf/pluginsdk"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tf/validation"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+type MsSqlManagedInstanceModel struct {
+	Name                       string                              `tfschema:"name"`
+	ResourceGroupName          string                              `tfschema:"resource_group_name"`
+	Location                   string                              `tfschema:"location"`
+	SkuName                    string                              `tfschema:"sku_name"`
+	AdministratorLogin         string                              `tfschema:"administrator_login"`
+	AdministratorLoginPassword string                              `tfschema:"administrator_login_password"`
+	SubnetId                   string                              `tfschema:"subnet_id"`
+	LicenseType                string                              `tfschema:"license_type"`
+	VCores                     int                                 `tfschema:"vcores"`
+	StorageSizeInGb            int                                 `tfschema:"storage_size_in_gb"`
+	Collation                  string                              `tfschema:"collation"`
+	DnsZonePartnerId           string                              `tfschema:"dns_zone_partner_id"`
+	PublicDataEndpointEnabled  bool                                `tfschema:"public_data_endpoint_enabled"`
+	ProxyOverride              string                              `tfschema:"proxy_override"`
+	TimezoneId                 string                              `tfschema:"timezone_id"`
+	MinimumTlsVersion          string                              `tfschema:"minimum_tls_version"`
+	StorageAccountType         string                              `tfschema:"storage_account_type"`
+	Identity                   []MsSqlManagedInstanceIdentityModel `tfschema:"identity"`
+	Fqdn                       string                              `tfschema:"fqdn"`
+	DnsZone                    string                              `tfschema:"dns_zone"`
+	Tags                       map[string]interface{}              `tfschema:"tags"`
+}
+
+type MsSqlManagedInstanceIdentityModel struct {
+	Type        string `tfschema:"type"`
+	PrincipalId string `tfschema:"principal_id"`
+	TenantId    string `tfschema:"tenant_id"`
+}
+
+type MsSqlManagedInstanceResource struct{}
+
+var _ sdk.Resource = MsSqlManagedInstanceResource{}
+var _ sdk.ResourceWithUpdate = MsSqlManagedInstanceResource{}
+
+func (r MsSqlManagedInstanceResource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"name": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validate.ValidateMsSqlServerName,
+		},
+
+		"resource_group_name": azure.SchemaResourceGroupName(),
+
+		"location": location.Schema(),
+
+		"sku_name": {
+			Type:     pluginsdk.TypeString,
+			Required: true,
+			ValidateFunc: validation.StringInSlice([]string{
+				"GP_Gen4",
+				"GP_Gen5",
+				"GP_G8IM",
+				"GP_G8IH",
+				"BC_Gen4",
+				"BC_Gen5",
+				"BC_G8IM",
+				"BC_G8IH",
+			}, false),
+		},
+
+		"administrator_login": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+
+		"administrator_login_password": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			Sensitive:    true,
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+
+		"subnet_id": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: networkValidate.SubnetID,
+		},
+
+		"license_type": {
+			Type:     pluginsdk.TypeString,
+			Required: true,
+			ValidateFunc: validation.StringInSlice([]string{
+				string(sql.ManagedInstanceLicenseTypeBasePrice),
+				string(sql.ManagedInstanceLicenseTypeLicenseIncluded),
+			}, false),
+		},
+
+		"vcores": {
+			Type:         pluginsdk.TypeInt,
+			Required:     true,
+			ValidateFunc: validation.IntInSlice([]int{4, 8, 16, 24, 32, 40, 48, 56, 64, 80}),
+		},
+
+		"storage_size_in_gb": {
+			Type:         pluginsdk.TypeInt,
+			Required:     true,
+			ValidateFunc: validation.All(validation.IntBetween(32, 16384), validation.IntDivisibleBy(32)),
+		},
+
+		"collation": {
+			Type:         pluginsdk.TypeString,
+			Optional:     true,
+			ForceNew:     true,
+			Default:      "SQL_Latin1_General_CP1_CI_AS",
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+
+		"dns_zone_partner_id": {
+			Type:         pluginsdk.TypeString,
+			Optional:     true,
+			ForceNew:     true,
+			ValidateFunc: validate.ManagedInstanceID,
+		},
+
+		"public_data_endpoint_enabled": {
+			Type:     pluginsdk.TypeBool,
+			Optional: true,
+			Default:  false,
+		},
+
+		"proxy_override": {
+			Type:     pluginsdk.TypeString,
+			Optional: true,
+			Default:  string(sql.ManagedInstanceProxyOverrideDefault),
+			ValidateFunc: validation.StringInSlice([]string{
+				string(sql.ManagedInstanceProxyOverrideDefault),
+				string(sql.ManagedInstanceProxyOverrideProxy),
+				string(sql.ManagedInstanceProxyOverrideRedirect),
+			}, false),
+		},
+
+		"timezone_id": {
+			Type:         pluginsdk.TypeString,
+			Optional:     true,
+			ForceNew:     true,
+			Default:      "UTC",
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+
+		"minimum_tls_version": {
+			Type:     pluginsdk.TypeString,
+			Optional: true,
+			Default:  "1.2",
+			ValidateFunc: validation.StringInSlice([]string{
+				"1.0",
+				"1.1",
+				"1.2",
+			}, false),
+		},
+
+		"storage_account_type": {
+			Type:     pluginsdk.TypeString,
+			Optional: true,
+			ForceNew: true,
+			Default:  string(sql.GRS),
+			ValidateFunc: validation.StringInSlice([]string{
+				string(sql.GRS),
+				string(sql.LRS),
+				string(sql.ZRS),
+			}, false),
+		},
+
+		"identity": {
+			Type:     pluginsdk.TypeList,
+			Optional: true,
+			MaxItems: 1,
+			Elem: &pluginsdk.Resource{
+				Schema: map[string]*pluginsdk.Schema{
+					"type": {
+						Type:     pluginsdk.TypeString,
+						Required: true,
+						ValidateFunc: validation.StringInSlice([]string{
+							string(sql.SystemAssigned),
+						}, false),
+					},
+
+					"principal_id": {
+						Type:     pluginsdk.TypeString,
+						Computed: true,
+					},
+
+					"tenant_id": {
+						Type:     pluginsdk.TypeString,
+						Computed: true,
+					},
+				},
+			},
+		},
+
+		"tags": tags.Schema(),
+	}
+}
+
+func (r MsSqlManagedInstanceResource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"fqdn": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+
+		"dns_zone": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+	}
+}
+
+func (r MsSqlManagedInstanceResource) ModelObject() interface{} {
+	return MsSqlManagedInstanceModel{}
+}
+
+func (r MsSqlManagedInstanceResource) ResourceType() string {
+	return "azurerm_mssql_managed_instance"
+}
+
+func (r MsSqlManagedInstanceResource) IDValidationFunc() pluginsdk.SchemaValidateFunc {
+	return validate.ManagedInstanceID
+}
+
+func (r MsSqlManagedInstanceResource) Create() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		// provisioning the first Managed Instance in a subnet can take several hours
+		Timeout: 24 * time.Hour,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.MSSQL.ManagedInstancesClient
+			subscriptionId := metadata.Client.Account.SubscriptionId
+
+			var model MsSqlManagedInstanceModel
+			if err := metadata.Decode(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			id := parse.NewManagedInstanceID(subscriptionId, model.ResourceGroupName, model.Name)
+
+			existing, err := client.Get(ctx, id.ResourceGroup, id.Name)
+			if err != nil && !utils.ResponseWasNotFound(existing.Response) {
+				return fmt.Errorf("checking for presence of existing %s: %+v", id, err)
+			}
+			if !utils.ResponseWasNotFound(existing.Response) {
+				return metadata.ResourceRequiresImport(r.ResourceType(), id)
+			}
+
+			parameters := expandMsSqlManagedInstance(model)
+
+			metadata.Logger.Infof("creating %s", id)
+
+			future, err := client.CreateOrUpdate(ctx, id.ResourceGroup, id.Name, parameters)
+			if err != nil {
+				return fmt.Errorf("creating %s: %+v", id, err)
+			}
+
+			if err := future.WaitForCompletionRef(ctx, client.Client); err != nil {
+				return fmt.Errorf("waiting for creation of %s: %+v", id, err)
+			}
+
+			metadata.SetID(id)
+			return nil
+		},
+	}
+}
+
+func (r MsSqlManagedInstanceResource) Update() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		// scaling the vCores or storage of a Managed Instance can take several hours
+		Timeout: 24 * time.Hour,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.MSSQL.ManagedInstancesClient
+
+			id, err := parse.ManagedInstanceID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			var model MsSqlManagedInstanceModel
+			if err := metadata.Decode(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			// the PATCH endpoint doesn't support changing the identity, so the full payload is sent
+			parameters := expandMsSqlManagedInstance(model)
+
+			metadata.Logger.Infof("updating %s", id)
+
+			future, err := client.CreateOrUpdate(ctx, id.ResourceGroup, id.Name, parameters)
+			if err != nil {
+				return fmt.Errorf("updating %s: %+v", id, err)
+			}
+
+			if err := future.WaitForCompletionRef(ctx, client.Client); err != nil {
+				return fmt.Errorf("waiting for update of %s: %+v", id, err)
+			}
+
+			return nil
+		},
+	}
+}
+
+func (r MsSqlManagedInstanceResource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.MSSQL.ManagedInstancesClient
+
+			id, err := parse.ManagedInstanceID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			existing, err := client.Get(ctx, id.ResourceGroup, id.Name)
+			if err != nil {
+				if utils.ResponseWasNotFound(existing.Response) {
+					return metadata.MarkAsGone(id)
+				}
+				return fmt.Errorf("retrieving %s: %+v", id, err)
+			}
+
+			// the password isn't returned by the API so we carry it over from the existing state
+			var state MsSqlManagedInstanceModel
+			if err := metadata.Decode(&state); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			model := MsSqlManagedInstanceModel{
+				Name:                       id.Name,
+				ResourceGroupName:          id.ResourceGroup,
+				Location:                   location.NormalizeNilable(existing.Location),
+				AdministratorLoginPassword: state.AdministratorLoginPassword,
+				DnsZonePartnerId:           state.DnsZonePartnerId,
+				Identity:                   flattenMsSqlManagedInstanceIdentity(existing.Identity),
+				Tags:                       tags.Flatten(existing.Tags),
+			}
+
+			if sku := existing.Sku; sku != nil && sku.Name != nil {
+				model.SkuName = *sku.Name
+			}
+
+			if props := existing.ManagedInstanceProperties; props != nil {
+				model.AdministratorLogin = utils.NormalizeNilableString(props.AdministratorLogin)
+				model.SubnetId = utils.NormalizeNilableString(props.SubnetID)
+				model.LicenseType = string(props.LicenseType)
+				model.Collation = utils.NormalizeNilableString(props.Collation)
+				model.ProxyOverride = string(props.ProxyOverride)
+				model.TimezoneId = utils.NormalizeNilableString(props.TimezoneID)
+				model.MinimumTlsVersion = utils.NormalizeNilableString(props.MinimalTLSVersion)
+				model.StorageAccountType = string(props.StorageAccountType)
+				model.Fqdn = utils.NormalizeNilableString(props.FullyQualifiedDomainName)
+				model.DnsZone = utils.NormalizeNilableString(props.DNSZone)
+
+				if props.VCores != nil {
+					model.VCores = int(*props.VCores)
+				}
+				if props.StorageSizeInGB != nil {
+					model.StorageSizeInGb = int(*props.StorageSizeInGB)
+				}
+				if props.PublicDataEndpointEnabled != nil {
+					model.PublicDataEndpointEnabled = *props.PublicDataEndpointEnabled
+				}
+			}
+
+			return metadata.Encode(&model)
+		},
+	}
+}
+
+func (r MsSqlManagedInstanceResource) Delete() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 24 * time.Hour,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.MSSQL.ManagedInstancesClient
+
+			id, err := parse.ManagedInstanceID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			metadata.Logger.Infof("deleting %s", id)
+
+			future, err := client.Delete(ctx, id.ResourceGroup, id.Name)
+			if err != nil {
+				if response.WasNotFound(future.Response()) {
+					return nil
+				}
+				return fmt.Errorf("deleting %s: %+v", id, err)
+			}
+
+			if err := future.WaitForCompletionRef(ctx, client.Client); err != nil {
+				return fmt.Errorf("waiting for deletion of %s: %+v", id, err)
+			}
+
+			return nil
+		},
+	}
+}
+
+func expandMsSqlManagedInstance(model MsSqlManagedInstanceModel) sql.ManagedInstance {
+	parameters := sql.ManagedInstance{
+		Location: utils.String(location.Normalize(model.Location)),
+		Identity: expandMsSqlManagedInstanceIdentity(model.Identity),
+		Sku:      expandMsSqlManagedInstanceSku(model.SkuName),
+		ManagedInstanceProperties: &sql.ManagedInstanceProperties{
+			AdministratorLogin:         utils.String(model.AdministratorLogin),
+			AdministratorLoginPassword: utils.String(model.AdministratorLoginPassword),
+			SubnetID:                   utils.String(model.SubnetId),
+			LicenseType:                sql.ManagedInstanceLicenseType(model.LicenseType),
+			VCores:                     utils.Int32(int32(model.VCores)),
+			StorageSizeInGB:            utils.Int32(int32(model.StorageSizeInGb)),
+			Collation:                  utils.String(model.Collation),
+			PublicDataEndpointEnabled:  utils.Bool(model.PublicDataEndpointEnabled),
+			ProxyOverride:              sql.ManagedInstanceProxyOverride(model.ProxyOverride),
+			TimezoneID:                 utils.String(model.TimezoneId),
+			MinimalTLSVersion:          utils.String(model.MinimumTlsVersion),
+			StorageAccountType:         sql.StorageAccountType(model.StorageAccountType),
+		},
+		Tags: tags.Expand(model.Tags),
+	}
+
+	if model.DnsZonePartnerId != "" {
+		parameters.ManagedInstanceProperties.DNSZonePartner = utils.String(model.DnsZonePartnerId)
+	}
+
+	return parameters
+}
+
+func expandMsSqlManagedInstanceSku(input string) *sql.Sku {
+	// the SKU name is in the format `{tier}_{family}`, e.g. `GP_Gen5`
+	tier := "GeneralPurpose"
+	if strings.HasPrefix(input, "BC_") {
+		tier = "BusinessCritical"
+	}
+
+	sku := sql.Sku{
+		Name: utils.String(input),
+		Tier: utils.String(tier),
+	}
+
+	if parts := strings.SplitN(input, "_", 2); len(parts) == 2 {
+		sku.Family = utils.String(parts[1])
+	}
+
+	return &sku
+}
+
+func expandMsSqlManagedInstanceIdentity(input []MsSqlManagedInstanceIdentityModel) *sql.ResourceIdentity {
+	if len(input) == 0 {
+		return nil
+	}
+
+	return &sql.ResourceIdentity{
+		Type: sql.IdentityType(input[0].Type),
+	}
+}
+
+func flattenMsSqlManagedInstanceIdentity(input *sql.ResourceIdentity) []MsSqlManagedInstanceIdentityModel {
+	if input == nil || input.Type == "" || strings.EqualFold(string(input.Type), "None") {
+		return []MsSqlManagedInstanceIdentityModel{}
+	}
+
+	identity := MsSqlManagedInstanceIdentityModel{
+		Type: string(input.Type),
+	}
+	if input.PrincipalID != nil {
+		identity.PrincipalId = input.PrincipalID.String()
+	}
+	if input.TenantID != nil {
+		identity.TenantId = input.TenantID.String()
+	}
+
+	return []MsSqlManagedInstanceIdentityModel{identity}
+}
@@ -0,0 +1,280 @@
+package mssql_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/acceptance"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/acceptance/check"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/clients"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/mssql/parse"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tf/pluginsdk"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+type MsSqlManagedInstanceResource struct{}
+
+func TestAccMsSqlManagedInstance_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_mssql_managed_instance", "test")
+	r := MsSqlManagedInstanceResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("fqdn").Exists(),
+				check.That(data.ResourceName).Key("dns_zone").Exists(),
+			),
+		},
+		data.ImportStep("administrator_login_password"),
+	})
+}
+
+func TestAccMsSqlManagedInstance_requiresImport(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_mssql_managed_instance", "test")
+	r := MsSqlManagedInstanceResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.RequiresImportErrorStep(r.requiresImport),
+	})
+}
+
+func TestAccMsSqlManagedInstance_update(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_mssql_managed_instance", "test")
+	r := MsSqlManagedInstanceResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep("administrator_login_password"),
+		{
+			Config: r.update(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("identity.0.principal_id").Exists(),
+			),
+		},
+		data.ImportStep("administrator_login_password"),
+	})
+}
+
+func (r MsSqlManagedInstanceResource) Exists(ctx context.Context, client *clients.Client, state *pluginsdk.InstanceState) (*bool, error) {
+	id, err := parse.ManagedInstanceID(state.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.MSSQL.ManagedInstancesClient.Get(ctx, id.ResourceGroup, id.Name)
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			return utils.Bool(false), nil
+		}
+		return nil, fmt.Errorf("retrieving %s: %+v", id, err)
+	}
+
+	return utils.Bool(true), nil
+}
+
+func (r MsSqlManagedInstanceResource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_mssql_managed_instance" "test" {
+  name                = "acctestsqlserver%d"
+  resource_group_name = azurerm_resource_group.test.name
+  location            = azurerm_resource_group.test.location
+
+  license_type       = "BasePrice"
+  sku_name           = "GP_Gen5"
+  storage_size_in_gb = 32
+  subnet_id          = azurerm_subnet.test.id
+  vcores             = 4
+
+  administrator_login          = "missadministrator"
+  administrator_login_password = "NCC-1701-D"
+
+  depends_on = [
+    azurerm_subnet_network_security_group_association.test,
+    azurerm_subnet_route_table_association.test,
+  ]
+}
+`, r.template(data), data.RandomInteger)
+}
+
+func (r MsSqlManagedInstanceResource) requiresImport(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_mssql_managed_instance" "import" {
+  name                = azurerm_mssql_managed_instance.test.name
+  resource_group_name = azurerm_mssql_managed_instance.test.resource_group_name
+  location            = azurerm_mssql_managed_instance.test.location
+
+  license_type       = azurerm_mssql_managed_instance.test.license_type
+  sku_name           = azurerm_mssql_managed_instance.test.sku_name
+  storage_size_in_gb = azurerm_mssql_managed_instance.test.storage_size_in_gb
+  subnet_id          = azurerm_mssql_managed_instance.test.subnet_id
+  vcores             = azurerm_mssql_managed_instance.test.vcores
+
+  administrator_login          = azurerm_mssql_managed_instance.test.administrator_login
+  administrator_login_password = azurerm_mssql_managed_instance.test.administrator_login_password
+}
+`, r.basic(data))
+}
+
+func (r MsSqlManagedInstanceResource) update(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_mssql_managed_instance" "test" {
+  name                = "acctestsqlserver%d"
+  resource_group_name = azurerm_resource_group.test.name
+  location            = azurerm_resource_group.test.location
+
+  license_type       = "LicenseIncluded"
+  sku_name           = "GP_Gen5"
+  storage_size_in_gb = 64
+  subnet_id          = azurerm_subnet.test.id
+  vcores             = 8
+
+  administrator_login          = "missadministrator"
+  administrator_login_password = "NCC-1701-E"
+
+  public_data_endpoint_enabled = true
+  proxy_override               = "Proxy"
+  minimum_tls_version          = "1.1"
+
+  identity {
+    type = "SystemAssigned"
+  }
+
+  tags = {
+    environment = "staging"
+  }
+
+  depends_on = [
+    azurerm_subnet_network_security_group_association.test,
+    azurerm_subnet_route_table_association.test,
+  ]
+}
+`, r.template(data), data.RandomInteger)
+}
+
+func (MsSqlManagedInstanceResource) template(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-sql-%[1]d"
+  location = "%[2]s"
+}
+
+%[3]s
+`, data.RandomInteger, data.Locations.Primary, MsSqlManagedInstanceResource{}.network(data, data.Locations.Primary, "test", "10.0.0.0/16"))
+}
+
+// network provisions the delegated subnet, security group and route table required by a Managed Instance, so that
+// it can be used more than once within a configuration for failover groups
+func (MsSqlManagedInstanceResource) network(data acceptance.TestData, location, name, addressSpace string) string {
+	return fmt.Sprintf(`
+resource "azurerm_network_security_group" "%[3]s" {
+  name                = "acctestnsg-%[3]s-%[1]d"
+  location            = "%[2]s"
+  resource_group_name = azurerm_resource_group.test.name
+
+  security_rule {
+    name                       = "allow_tds_inbound"
+    priority                   = 1000
+    direction                  = "Inbound"
+    access                     = "Allow"
+    protocol                   = "Tcp"
+    source_port_range          = "*"
+    destination_port_range     = "1433"
+    source_address_prefix      = "VirtualNetwork"
+    destination_address_prefix = "*"
+  }
+
+  security_rule {
+    name                       = "allow_redirect_inbound"
+    priority                   = 1100
+    direction                  = "Inbound"
+    access                     = "Allow"
+    protocol                   = "Tcp"
+    source_port_range          = "*"
+    destination_port_range     = "11000-11999"
+    source_address_prefix      = "VirtualNetwork"
+    destination_address_prefix = "*"
+  }
+
+  security_rule {
+    name                       = "allow_geodr_inbound"
+    priority                   = 1200
+    direction                  = "Inbound"
+    access                     = "Allow"
+    protocol                   = "Tcp"
+    source_port_range          = "*"
+    destination_port_range     = "5022"
+    source_address_prefix      = "VirtualNetwork"
+    destination_address_prefix = "*"
+  }
+}
+
+resource "azurerm_route_table" "%[3]s" {
+  name                          = "acctestrt-%[3]s-%[1]d"
+  location                      = "%[2]s"
+  resource_group_name           = azurerm_resource_group.test.name
+  disable_bgp_route_propagation = false
+}
+
+resource "azurerm_virtual_network" "%[3]s" {
+  name                = "acctestvnet-%[3]s-%[1]d"
+  resource_group_name = azurerm_resource_group.test.name
+  address_space       = ["%[4]s"]
+  location            = "%[2]s"
+}
+
+resource "azurerm_subnet" "%[3]s" {
+  name                 = "subnet-%[3]s-%[1]d"
+  resource_group_name  = azurerm_resource_group.test.name
+  virtual_network_name = azurerm_virtual_network.%[3]s.name
+  address_prefixes     = [cidrsubnet("%[4]s", 8, 0)]
+
+  delegation {
+    name = "managedinstancedelegation"
+
+    service_delegation {
+      name = "Microsoft.Sql/managedInstances"
+      actions = [
+        "Microsoft.Network/virtualNetworks/subnets/join/action",
+        "Microsoft.Network/virtualNetworks/subnets/prepareNetworkPolicies/action",
+        "Microsoft.Network/virtualNetworks/subnets/unprepareNetworkPolicies/action",
+      ]
+    }
+  }
+}
+
+resource "azurerm_subnet_network_security_group_association" "%[3]s" {
+  subnet_id                 = azurerm_subnet.%[3]s.id
+  network_security_group_id = azurerm_network_security_group.%[3]s.id
+}
+
+resource "azurerm_subnet_route_table_association" "%[3]s" {
+  subnet_id      = azurerm_subnet.%[3]s.id
+  route_table_id = azurerm_route_table.%[3]s.id
+}
+`, data.RandomInteger, location, name, addressSpace)
+}
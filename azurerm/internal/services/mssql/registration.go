@@ -1,6 +1,7 @@
 package mssql
 
 import (
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/sdk"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tf/pluginsdk"
 )
 
@@ -46,3 +47,17 @@ func (r Registration) SupportedResources() map[string]*pluginsdk.Resource {
 		"azurerm_mssql_server_transparent_data_encryption":              resourceMsSqlTransparentDataEncryption(),
 	}
 }
+
+func (r Registration) DataSources() []sdk.DataSource {
+	return []sdk.DataSource{}
+}
+
+func (r Registration) Resources() []sdk.Resource {
+	return []sdk.Resource{
+		MsSqlFailoverGroupResource{},
+		MsSqlManagedDatabaseResource{},
+		MsSqlManagedInstanceActiveDirectoryAdministratorResource{},
+		MsSqlManagedInstanceFailoverGroupResource{},
+		MsSqlManagedInstanceResource{},
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/tf"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/validate"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/clients"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/locks"
 	keyVaultValidate "github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/keyvault/validate"
 	msiParse "github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/msi/parse"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/network/parse"
@@ -109,36 +110,7 @@ func resourceApplicationGateway() *pluginsdk.Resource {
 				Type:     pluginsdk.TypeList,
 				Required: true,
 				Elem: &pluginsdk.Resource{
-					Schema: map[string]*pluginsdk.Schema{
-						"name": {
-							Type:     pluginsdk.TypeString,
-							Required: true,
-						},
-
-						"fqdns": {
-							Type:     pluginsdk.TypeList,
-							Optional: true,
-							MinItems: 1,
-							Elem: &pluginsdk.Schema{
-								Type:         pluginsdk.TypeString,
-								ValidateFunc: validation.NoZeroValues,
-							},
-						},
-
-						"ip_addresses": {
-							Type:     pluginsdk.TypeList,
-							Optional: true,
-							Elem: &pluginsdk.Schema{
-								Type:         pluginsdk.TypeString,
-								ValidateFunc: validate.IPv4Address,
-							},
-						},
-
-						"id": {
-							Type:     pluginsdk.TypeString,
-							Computed: true,
-						},
-					},
+					Schema: applicationGatewayBackendAddressPoolSchema(),
 				},
 			},
 
@@ -147,128 +119,7 @@ func resourceApplicationGateway() *pluginsdk.Resource {
 				Required: true,
 				MinItems: 1,
 				Elem: &pluginsdk.Resource{
-					Schema: map[string]*pluginsdk.Schema{
-						"name": {
-							Type:     pluginsdk.TypeString,
-							Required: true,
-						},
-
-						"path": {
-							Type:     pluginsdk.TypeString,
-							Optional: true,
-						},
-
-						"port": {
-							Type:         pluginsdk.TypeInt,
-							Required:     true,
-							ValidateFunc: validate.PortNumber,
-						},
-
-						"protocol": {
-							Type:             pluginsdk.TypeString,
-							Required:         true,
-							DiffSuppressFunc: suppress.CaseDifference,
-							ValidateFunc: validation.StringInSlice([]string{
-								string(network.ProtocolHTTP),
-								string(network.ProtocolHTTPS),
-							}, true),
-						},
-
-						"cookie_based_affinity": {
-							Type:             pluginsdk.TypeString,
-							Required:         true,
-							DiffSuppressFunc: suppress.CaseDifference,
-							ValidateFunc: validation.StringInSlice([]string{
-								string(network.ApplicationGatewayCookieBasedAffinityEnabled),
-								string(network.ApplicationGatewayCookieBasedAffinityDisabled),
-							}, true),
-						},
-
-						"affinity_cookie_name": {
-							Type:         pluginsdk.TypeString,
-							Optional:     true,
-							ValidateFunc: validation.StringIsNotEmpty,
-						},
-
-						"host_name": {
-							Type:     pluginsdk.TypeString,
-							Optional: true,
-						},
-
-						"pick_host_name_from_backend_address": {
-							Type:     pluginsdk.TypeBool,
-							Optional: true,
-							Default:  false,
-						},
-
-						"request_timeout": {
-							Type:         pluginsdk.TypeInt,
-							Optional:     true,
-							ValidateFunc: validation.IntBetween(1, 86400),
-						},
-
-						"authentication_certificate": {
-							Type:     pluginsdk.TypeList,
-							Optional: true,
-							Elem: &pluginsdk.Resource{
-								Schema: map[string]*pluginsdk.Schema{
-									"name": {
-										Type:     pluginsdk.TypeString,
-										Required: true,
-									},
-
-									"id": {
-										Type:     pluginsdk.TypeString,
-										Computed: true,
-									},
-								},
-							},
-						},
-
-						"trusted_root_certificate_names": {
-							Type:     pluginsdk.TypeList,
-							Optional: true,
-							Elem: &pluginsdk.Schema{
-								Type:         pluginsdk.TypeString,
-								ValidateFunc: validation.StringIsNotEmpty,
-							},
-						},
-
-						"connection_draining": {
-							Type:     pluginsdk.TypeList,
-							MaxItems: 1,
-							Optional: true,
-							Elem: &pluginsdk.Resource{
-								Schema: map[string]*pluginsdk.Schema{
-									"enabled": {
-										Type:     pluginsdk.TypeBool,
-										Required: true,
-									},
-
-									"drain_timeout_sec": {
-										Type:         pluginsdk.TypeInt,
-										Required:     true,
-										ValidateFunc: validation.IntBetween(1, 3600),
-									},
-								},
-							},
-						},
-
-						"probe_name": {
-							Type:     pluginsdk.TypeString,
-							Optional: true,
-						},
-
-						"id": {
-							Type:     pluginsdk.TypeString,
-							Computed: true,
-						},
-
-						"probe_id": {
-							Type:     pluginsdk.TypeString,
-							Computed: true,
-						},
-					},
+					Schema: applicationGatewayBackendHTTPSettingsSchema(),
 				},
 			},
 
@@ -373,108 +224,7 @@ func resourceApplicationGateway() *pluginsdk.Resource {
 				Type:     pluginsdk.TypeList,
 				Required: true,
 				Elem: &pluginsdk.Resource{
-					Schema: map[string]*pluginsdk.Schema{
-						"name": {
-							Type:     pluginsdk.TypeString,
-							Required: true,
-						},
-
-						"frontend_ip_configuration_name": {
-							Type:     pluginsdk.TypeString,
-							Required: true,
-						},
-
-						"frontend_port_name": {
-							Type:     pluginsdk.TypeString,
-							Required: true,
-						},
-
-						"protocol": {
-							Type:             pluginsdk.TypeString,
-							Required:         true,
-							DiffSuppressFunc: suppress.CaseDifference,
-							ValidateFunc: validation.StringInSlice([]string{
-								string(network.ProtocolHTTP),
-								string(network.ProtocolHTTPS),
-							}, true),
-						},
-
-						"host_name": {
-							Type:     pluginsdk.TypeString,
-							Optional: true,
-						},
-
-						"host_names": {
-							Type:     pluginsdk.TypeSet,
-							Optional: true,
-							Elem: &pluginsdk.Schema{
-								Type:         pluginsdk.TypeString,
-								ValidateFunc: validation.StringIsNotEmpty,
-							},
-						},
-
-						"ssl_certificate_name": {
-							Type:     pluginsdk.TypeString,
-							Optional: true,
-						},
-
-						"require_sni": {
-							Type:     pluginsdk.TypeBool,
-							Optional: true,
-						},
-
-						"frontend_ip_configuration_id": {
-							Type:     pluginsdk.TypeString,
-							Computed: true,
-						},
-
-						"frontend_port_id": {
-							Type:     pluginsdk.TypeString,
-							Computed: true,
-						},
-						"id": {
-							Type:     pluginsdk.TypeString,
-							Computed: true,
-						},
-
-						"ssl_certificate_id": {
-							Type:     pluginsdk.TypeString,
-							Computed: true,
-						},
-
-						"custom_error_configuration": {
-							Type:     pluginsdk.TypeList,
-							Optional: true,
-							Elem: &pluginsdk.Resource{
-								Schema: map[string]*pluginsdk.Schema{
-									"status_code": {
-										Type:     pluginsdk.TypeString,
-										Required: true,
-										ValidateFunc: validation.StringInSlice([]string{
-											string(network.ApplicationGatewayCustomErrorStatusCodeHTTPStatus403),
-											string(network.ApplicationGatewayCustomErrorStatusCodeHTTPStatus502),
-										}, false),
-									},
-
-									"custom_error_page_url": {
-										Type:     pluginsdk.TypeString,
-										Required: true,
-									},
-
-									"id": {
-										Type:     pluginsdk.TypeString,
-										Computed: true,
-									},
-								},
-							},
-						},
-
-						"firewall_policy_id": {
-							Type:         pluginsdk.TypeString,
-							Optional:     true,
-							ValidateFunc: azure.ValidateResourceID,
-						},
-					},
+					Schema: applicationGatewayHTTPListenerSchema(),
 				},
 			},
 
@@ -483,88 +233,7 @@ func resourceApplicationGateway() *pluginsdk.Resource {
 				Required: true,
 				MinItems: 1,
 				Elem: &pluginsdk.Resource{
-					Schema: map[string]*pluginsdk.Schema{
-						"name": {
-							Type:     pluginsdk.TypeString,
-							Required: true,
-						},
-
-						"rule_type": {
-							Type:     pluginsdk.TypeString,
-							Required: true,
-							ValidateFunc: validation.StringInSlice([]string{
-								string(network.ApplicationGatewayRequestRoutingRuleTypeBasic),
-								string(network.ApplicationGatewayRequestRoutingRuleTypePathBasedRouting),
-							}, false),
-						},
-
-						"http_listener_name": {
-							Type:     pluginsdk.TypeString,
-							Required: true,
-						},
-
-						"backend_address_pool_name": {
-							Type:     pluginsdk.TypeString,
-							Optional: true,
-						},
-
-						"backend_http_settings_name": {
-							Type:     pluginsdk.TypeString,
-							Optional: true,
-						},
-
-						"url_path_map_name": {
-							Type:     pluginsdk.TypeString,
-							Optional: true,
-						},
-
-						"redirect_configuration_name": {
-							Type:         pluginsdk.TypeString,
-							Optional:     true,
-							ValidateFunc: validation.StringIsNotEmpty,
-						},
-
-						"rewrite_rule_set_name": {
-							Type:         pluginsdk.TypeString,
-							Optional:     true,
-							ValidateFunc: validation.StringIsNotEmpty,
-						},
-
-						"backend_address_pool_id": {
-							Type:     pluginsdk.TypeString,
-							Computed: true,
-						},
-
-						"backend_http_settings_id": {
-							Type:     pluginsdk.TypeString,
-							Computed: true,
-						},
-
-						"http_listener_id": {
-							Type:     pluginsdk.TypeString,
-							Computed: true,
-						},
-
-						"id": {
-							Type:     pluginsdk.TypeString,
-							Computed: true,
-						},
-
-						"url_path_map_id": {
-							Type:     pluginsdk.TypeString,
-							Computed: true,
-						},
-
-						"redirect_configuration_id": {
-							Type:     pluginsdk.TypeString,
-							Computed: true,
-						},
-
-						"rewrite_rule_set_id": {
-							Type:     pluginsdk.TypeString,
-							Computed: true,
-						},
-					},
+					Schema: applicationGatewayRequestRoutingRuleSchema(),
 				},
 			},
 
@@ -811,94 +480,7 @@ func resourceApplicationGateway() *pluginsdk.Resource {
 				Type:     pluginsdk.TypeList,
 				Optional: true,
 				Elem: &pluginsdk.Resource{
-					Schema: map[string]*pluginsdk.Schema{
-						"name": {
-							Type:     pluginsdk.TypeString,
-							Required: true,
-						},
-
-						"protocol": {
-							Type:             pluginsdk.TypeString,
-							Required:         true,
-							DiffSuppressFunc: suppress.CaseDifference,
-							ValidateFunc: validation.StringInSlice([]string{
-								string(network.ProtocolHTTP),
-								string(network.ProtocolHTTPS),
-							}, true),
-						},
-
-						"path": {
-							Type:     pluginsdk.TypeString,
-							Required: true,
-						},
-
-						"host": {
-							Type:     pluginsdk.TypeString,
-							Optional: true,
-						},
-
-						"interval": {
-							Type:     pluginsdk.TypeInt,
-							Required: true,
-						},
-
-						"timeout": {
-							Type:     pluginsdk.TypeInt,
-							Required: true,
-						},
-
-						"unhealthy_threshold": {
-							Type:     pluginsdk.TypeInt,
-							Required: true,
-						},
-
-						"port": {
-							Type:         pluginsdk.TypeInt,
-							Optional:     true,
-							ValidateFunc: validate.PortNumber,
-						},
-
-						"pick_host_name_from_backend_http_settings": {
-							Type:     pluginsdk.TypeBool,
-							Optional: true,
-							Default:  false,
-						},
-
-						"minimum_servers": {
-							Type:     pluginsdk.TypeInt,
-							Optional: true,
-							Default:  0,
-						},
-
-						//lintignore:XS003
-						"match": {
-							Type:     pluginsdk.TypeList,
-							Optional: true,
-							Computed: true,
-							MaxItems: 1,
-							Elem: &pluginsdk.Resource{
-								Schema: map[string]*pluginsdk.Schema{
-									"body": {
-										Type:     pluginsdk.TypeString,
-										Optional: true,
-									},
-
-									"status_code": {
-										Type:     pluginsdk.TypeList,
-										Optional: true,
-										Elem: &pluginsdk.Schema{
-											Type: pluginsdk.TypeString,
-										},
-									},
-								},
-							},
-						},
-
-						"id": {
-							Type:     pluginsdk.TypeString,
-							Computed: true,
-						},
-					},
+					Schema: applicationGatewayProbeSchema(),
 				},
 			},
 
@@ -1312,6 +894,12 @@ func resourceApplicationGateway() *pluginsdk.Resource {
 				},
 			},
 
+			"ignore_unmanaged_sub_resources": {
+				Type:     pluginsdk.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
 			"firewall_policy_id": {
 				Type:         pluginsdk.TypeString,
 				Optional:     true,
@@ -1361,6 +949,10 @@ func resourceApplicationGatewayCreateUpdate(d *pluginsdk.ResourceData, meta inte
 	log.Printf("[INFO] preparing arguments for Application Gateway creation.")
 
 	id := parse.NewApplicationGatewayID(subscriptionId, d.Get("resource_group_name").(string), d.Get("name").(string))
+
+	locks.ByResourceID(id)
+	defer locks.UnlockByResourceID(id)
+
 	if d.IsNewResource() {
 		existing, err := client.Get(ctx, id.ResourceGroup, id.Name)
 		if err != nil {
@@ -1381,7 +973,7 @@ func resourceApplicationGatewayCreateUpdate(d *pluginsdk.ResourceData, meta inte
 	// Gateway ID is needed to link sub-resources together in expand functions
 	trustedRootCertificates := expandApplicationGatewayTrustedRootCertificates(d.Get("trusted_root_certificate").([]interface{}))
 
-	requestRoutingRules, err := expandApplicationGatewayRequestRoutingRules(d, id.ID())
+	requestRoutingRules, err := expandApplicationGatewayRequestRoutingRules(d.Get("request_routing_rule").(*pluginsdk.Set).List(), id.ID())
 	if err != nil {
 		return fmt.Errorf("Error expanding `request_routing_rule`: %+v", err)
 	}
@@ -1403,7 +995,7 @@ func resourceApplicationGatewayCreateUpdate(d *pluginsdk.ResourceData, meta inte
 
 	gatewayIPConfigurations, stopApplicationGateway := expandApplicationGatewayIPConfigurations(d)
 
-	httpListeners, err := expandApplicationGatewayHTTPListeners(d, id.ID())
+	httpListeners, err := expandApplicationGatewayHTTPListeners(d.Get("http_listener").([]interface{}), id.ID())
 	if err != nil {
 		return fmt.Errorf("fail to expand `http_listener`: %+v", err)
 	}
@@ -1423,14 +1015,14 @@ func resourceApplicationGatewayCreateUpdate(d *pluginsdk.ResourceData, meta inte
 			AuthenticationCertificates:    expandApplicationGatewayAuthenticationCertificates(d.Get("authentication_certificate").([]interface{})),
 			TrustedRootCertificates:       trustedRootCertificates,
 			CustomErrorConfigurations:     expandApplicationGatewayCustomErrorConfigurations(d.Get("custom_error_configuration").([]interface{})),
-			BackendAddressPools:           expandApplicationGatewayBackendAddressPools(d),
-			BackendHTTPSettingsCollection: expandApplicationGatewayBackendHTTPSettings(d, id.ID()),
+			BackendAddressPools:           expandApplicationGatewayBackendAddressPools(d.Get("backend_address_pool").([]interface{})),
+			BackendHTTPSettingsCollection: expandApplicationGatewayBackendHTTPSettings(d.Get("backend_http_settings").([]interface{}), id.ID()),
 			EnableHTTP2:                   utils.Bool(enablehttp2),
 			FrontendIPConfigurations:      expandApplicationGatewayFrontendIPConfigurations(d),
 			FrontendPorts:                 expandApplicationGatewayFrontendPorts(d),
 			GatewayIPConfigurations:       gatewayIPConfigurations,
 			HTTPListeners:                 httpListeners,
-			Probes:                        expandApplicationGatewayProbes(d),
+			Probes:                        expandApplicationGatewayProbes(d.Get("probe").([]interface{})),
 			RequestRoutingRules:           requestRoutingRules,
 			RedirectConfigurations:        redirectConfigurations,
 			Sku:                           expandApplicationGatewaySku(d),
@@ -1448,30 +1040,14 @@ func resourceApplicationGatewayCreateUpdate(d *pluginsdk.ResourceData, meta inte
 
 	// validation (todo these should probably be moved into their respective expand functions, which would then return an error?)
 	for _, backendHttpSettings := range *gateway.ApplicationGatewayPropertiesFormat.BackendHTTPSettingsCollection {
-		if props := backendHttpSettings.ApplicationGatewayBackendHTTPSettingsPropertiesFormat; props != nil {
-			if props.HostName == nil || props.PickHostNameFromBackendAddress == nil {
-				continue
-			}
-
-			if *props.HostName != "" && *props.PickHostNameFromBackendAddress {
-				return fmt.Errorf("Only one of `host_name` or `pick_host_name_from_backend_address` can be set")
-			}
+		if err := validateApplicationGatewayBackendHTTPSettings(backendHttpSettings); err != nil {
+			return err
 		}
 	}
 
 	for _, probe := range *gateway.ApplicationGatewayPropertiesFormat.Probes {
-		if props := probe.ApplicationGatewayProbePropertiesFormat; props != nil {
-			if props.Host == nil || props.PickHostNameFromBackendHTTPSettings == nil {
-				continue
-			}
-
-			if *props.Host == "" && !*props.PickHostNameFromBackendHTTPSettings {
-				return fmt.Errorf("One of `host` or `pick_host_name_from_backend_http_settings` must be set")
-			}
-
-			if *props.Host != "" && *props.PickHostNameFromBackendHTTPSettings {
-				return fmt.Errorf("Only one of `host` or `pick_host_name_from_backend_http_settings` can be set")
-			}
+		if err := validateApplicationGatewayProbe(probe); err != nil {
+			return err
 		}
 	}
 
@@ -1493,6 +1069,18 @@ func resourceApplicationGatewayCreateUpdate(d *pluginsdk.ResourceData, meta inte
 		}
 	}
 
+	if d.Get("ignore_unmanaged_sub_resources").(bool) && !d.IsNewResource() {
+		existing, err := client.Get(ctx, id.ResourceGroup, id.Name)
+		if err != nil {
+			return fmt.Errorf("retrieving %s: %+v", id, err)
+		}
+		if existing.ApplicationGatewayPropertiesFormat == nil {
+			return fmt.Errorf("retrieving %s: `properties` was nil", id)
+		}
+
+		retainUnmanagedApplicationGatewaySubResources(d, existing.ApplicationGatewayPropertiesFormat, gateway.ApplicationGatewayPropertiesFormat)
+	}
+
 	if stopApplicationGateway {
 		future, err := client.Stop(ctx, id.ResourceGroup, id.Name)
 		if err != nil {
@@ -1564,6 +1152,9 @@ func resourceApplicationGatewayRead(d *pluginsdk.ResourceData, meta interface{})
 		return err
 	}
 
+	// sub-resources which aren't managed by this resource (e.g. those managed by standalone resources) are ignored
+	ignoreUnmanaged := d.Get("ignore_unmanaged_sub_resources").(bool)
+
 	if props := applicationGateway.ApplicationGatewayPropertiesFormat; props != nil {
 		if err = d.Set("authentication_certificate", flattenApplicationGatewayAuthenticationCertificates(props.AuthenticationCertificates, d)); err != nil {
 			return fmt.Errorf("Error setting `authentication_certificate`: %+v", err)
@@ -1573,7 +1164,11 @@ func resourceApplicationGatewayRead(d *pluginsdk.ResourceData, meta interface{})
 			return fmt.Errorf("Error setting `trusted_root_certificate`: %+v", err)
 		}
 
-		if setErr := d.Set("backend_address_pool", flattenApplicationGatewayBackendAddressPools(props.BackendAddressPools)); setErr != nil {
+		backendAddressPools := flattenApplicationGatewayBackendAddressPools(props.BackendAddressPools)
+		if ignoreUnmanaged {
+			backendAddressPools = filterManagedApplicationGatewaySubResources(d, "backend_address_pool", backendAddressPools)
+		}
+		if setErr := d.Set("backend_address_pool", backendAddressPools); setErr != nil {
 			return fmt.Errorf("Error setting `backend_address_pool`: %+v", setErr)
 		}
 
@@ -1581,6 +1176,9 @@ func resourceApplicationGatewayRead(d *pluginsdk.ResourceData, meta interface{})
 		if err != nil {
 			return fmt.Errorf("Error flattening `backend_http_settings`: %+v", err)
 		}
+		if ignoreUnmanaged {
+			backendHttpSettings = filterManagedApplicationGatewaySubResources(d, "backend_http_settings", backendHttpSettings)
+		}
 		if setErr := d.Set("backend_http_settings", backendHttpSettings); setErr != nil {
 			return fmt.Errorf("Error setting `backend_http_settings`: %+v", setErr)
 		}
@@ -1595,6 +1193,9 @@ func resourceApplicationGatewayRead(d *pluginsdk.ResourceData, meta interface{})
 		if err != nil {
 			return fmt.Errorf("Error flattening `http_listener`: %+v", err)
 		}
+		if ignoreUnmanaged {
+			httpListeners = filterManagedApplicationGatewaySubResources(d, "http_listener", httpListeners)
+		}
 		if setErr := d.Set("http_listener", httpListeners); setErr != nil {
 			return fmt.Errorf("Error setting `http_listener`: %+v", setErr)
 		}
@@ -1611,7 +1212,11 @@ func resourceApplicationGatewayRead(d *pluginsdk.ResourceData, meta interface{})
 			return fmt.Errorf("Error setting `gateway_ip_configuration`: %+v", setErr)
 		}
 
-		if setErr := d.Set("probe", flattenApplicationGatewayProbes(props.Probes)); setErr != nil {
+		probes := flattenApplicationGatewayProbes(props.Probes)
+		if ignoreUnmanaged {
+			probes = filterManagedApplicationGatewaySubResources(d, "probe", probes)
+		}
+		if setErr := d.Set("probe", probes); setErr != nil {
 			return fmt.Errorf("Error setting `probe`: %+v", setErr)
 		}
 
@@ -1619,6 +1224,9 @@ func resourceApplicationGatewayRead(d *pluginsdk.ResourceData, meta interface{})
 		if err != nil {
 			return fmt.Errorf("Error flattening `request_routing_rule`: %+v", err)
 		}
+		if ignoreUnmanaged {
+			requestRoutingRules = filterManagedApplicationGatewaySubResources(d, "request_routing_rule", requestRoutingRules)
+		}
 		if setErr := d.Set("request_routing_rule", requestRoutingRules); setErr != nil {
 			return fmt.Errorf("Error setting `request_routing_rule`: %+v", setErr)
 		}
@@ -1682,6 +1290,9 @@ func resourceApplicationGatewayDelete(d *pluginsdk.ResourceData, meta interface{
 		return err
 	}
 
+	locks.ByResourceID(id)
+	defer locks.UnlockByResourceID(id)
+
 	future, err := client.Delete(ctx, id.ResourceGroup, id.Name)
 	if err != nil {
 		return fmt.Errorf("deleting %s: %+v", *id, err)
@@ -1873,8 +1484,7 @@ func flattenApplicationGatewayTrustedRootCertificates(certs *[]network.Applicati
 	return results
 }
 
-func expandApplicationGatewayBackendAddressPools(d *pluginsdk.ResourceData) *[]network.ApplicationGatewayBackendAddressPool {
-	vs := d.Get("backend_address_pool").([]interface{})
+func expandApplicationGatewayBackendAddressPools(vs []interface{}) *[]network.ApplicationGatewayBackendAddressPool {
 	results := make([]network.ApplicationGatewayBackendAddressPool, 0)
 
 	for _, raw := range vs {
@@ -1947,9 +1557,8 @@ func flattenApplicationGatewayBackendAddressPools(input *[]network.ApplicationGa
 	return results
 }
 
-func expandApplicationGatewayBackendHTTPSettings(d *pluginsdk.ResourceData, gatewayID string) *[]network.ApplicationGatewayBackendHTTPSettings {
+func expandApplicationGatewayBackendHTTPSettings(vs []interface{}, gatewayID string) *[]network.ApplicationGatewayBackendHTTPSettings {
 	results := make([]network.ApplicationGatewayBackendHTTPSettings, 0)
-	vs := d.Get("backend_http_settings").([]interface{})
 
 	for _, raw := range vs {
 		v := raw.(map[string]interface{})
@@ -2034,6 +1643,19 @@ func expandApplicationGatewayBackendHTTPSettings(d *pluginsdk.ResourceData, gate
 	return &results
 }
 
+func validateApplicationGatewayBackendHTTPSettings(input network.ApplicationGatewayBackendHTTPSettings) error {
+	props := input.ApplicationGatewayBackendHTTPSettingsPropertiesFormat
+	if props == nil || props.HostName == nil || props.PickHostNameFromBackendAddress == nil {
+		return nil
+	}
+
+	if *props.HostName != "" && *props.PickHostNameFromBackendAddress {
+		return fmt.Errorf("Only one of `host_name` or `pick_host_name_from_backend_address` can be set")
+	}
+
+	return nil
+}
+
 func flattenApplicationGatewayBackendHTTPSettings(input *[]network.ApplicationGatewayBackendHTTPSettings) ([]interface{}, error) {
 	results := make([]interface{}, 0)
 	if input == nil {
@@ -2248,8 +1870,7 @@ func flattenApplicationGatewaySslPolicy(input *network.ApplicationGatewaySslPoli
 	return results
 }
 
-func expandApplicationGatewayHTTPListeners(d *pluginsdk.ResourceData, gatewayID string) (*[]network.ApplicationGatewayHTTPListener, error) {
-	vs := d.Get("http_listener").([]interface{})
+func expandApplicationGatewayHTTPListeners(vs []interface{}, gatewayID string) (*[]network.ApplicationGatewayHTTPListener, error) {
 	results := make([]network.ApplicationGatewayHTTPListener, 0)
 
 	for _, raw := range vs {
@@ -2612,8 +2233,7 @@ func flattenApplicationGatewayFrontendIPConfigurations(input *[]network.Applicat
 	return results
 }
 
-func expandApplicationGatewayProbes(d *pluginsdk.ResourceData) *[]network.ApplicationGatewayProbe {
-	vs := d.Get("probe").([]interface{})
+func expandApplicationGatewayProbes(vs []interface{}) *[]network.ApplicationGatewayProbe {
 	results := make([]network.ApplicationGatewayProbe, 0)
 
 	for _, raw := range vs {
@@ -2672,6 +2292,23 @@ func expandApplicationGatewayProbes(d *pluginsdk.ResourceData) *[]network.Applic
 	return &results
 }
 
+func validateApplicationGatewayProbe(input network.ApplicationGatewayProbe) error {
+	props := input.ApplicationGatewayProbePropertiesFormat
+	if props == nil || props.Host == nil || props.PickHostNameFromBackendHTTPSettings == nil {
+		return nil
+	}
+
+	if *props.Host == "" && !*props.PickHostNameFromBackendHTTPSettings {
+		return fmt.Errorf("One of `host` or `pick_host_name_from_backend_http_settings` must be set")
+	}
+
+	if *props.Host != "" && *props.PickHostNameFromBackendHTTPSettings {
+		return fmt.Errorf("Only one of `host` or `pick_host_name_from_backend_http_settings` can be set")
+	}
+
+	return nil
+}
+
 func flattenApplicationGatewayProbes(input *[]network.ApplicationGatewayProbe) []interface{} {
 	results := make([]interface{}, 0)
 	if input == nil {
@@ -2751,8 +2388,7 @@ func flattenApplicationGatewayProbes(input *[]network.ApplicationGatewayProbe) [
 	return results
 }
 
-func expandApplicationGatewayRequestRoutingRules(d *pluginsdk.ResourceData, gatewayID string) (*[]network.ApplicationGatewayRequestRoutingRule, error) {
-	vs := d.Get("request_routing_rule").(*pluginsdk.Set).List()
+func expandApplicationGatewayRequestRoutingRules(vs []interface{}, gatewayID string) (*[]network.ApplicationGatewayRequestRoutingRule, error) {
 	results := make([]network.ApplicationGatewayRequestRoutingRule, 0)
 
 	for _, raw := range vs {
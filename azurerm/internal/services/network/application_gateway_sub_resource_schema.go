@@ -0,0 +1,449 @@
+package network
+
+import (
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2020-11-01/network"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/azure"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/validate"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tf/pluginsdk"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tf/suppress"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tf/validation"
+)
+
+func applicationGatewayBackendAddressPoolSchema() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"name": {
+			Type:     pluginsdk.TypeString,
+			Required: true,
+		},
+
+		"fqdns": {
+			Type:     pluginsdk.TypeList,
+			Optional: true,
+			MinItems: 1,
+			Elem: &pluginsdk.Schema{
+				Type:         pluginsdk.TypeString,
+				ValidateFunc: validation.NoZeroValues,
+			},
+		},
+
+		"ip_addresses": {
+			Type:     pluginsdk.TypeList,
+			Optional: true,
+			Elem: &pluginsdk.Schema{
+				Type:         pluginsdk.TypeString,
+				ValidateFunc: validate.IPv4Address,
+			},
+		},
+
+		"id": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+	}
+}
+
+func applicationGatewayBackendHTTPSettingsSchema() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"name": {
+			Type:     pluginsdk.TypeString,
+			Required: true,
+		},
+
+		"path": {
+			Type:     pluginsdk.TypeString,
+			Optional: true,
+		},
+
+		"port": {
+			Type:         pluginsdk.TypeInt,
+			Required:     true,
+			ValidateFunc: validate.PortNumber,
+		},
+
+		"protocol": {
+			Type:             pluginsdk.TypeString,
+			Required:         true,
+			DiffSuppressFunc: suppress.CaseDifference,
+			ValidateFunc: validation.StringInSlice([]string{
+				string(network.ProtocolHTTP),
+				string(network.ProtocolHTTPS),
+			}, true),
+		},
+
+		"cookie_based_affinity": {
+			Type:             pluginsdk.TypeString,
+			Required:         true,
+			DiffSuppressFunc: suppress.CaseDifference,
+			ValidateFunc: validation.StringInSlice([]string{
+				string(network.ApplicationGatewayCookieBasedAffinityEnabled),
+				string(network.ApplicationGatewayCookieBasedAffinityDisabled),
+			}, true),
+		},
+
+		"affinity_cookie_name": {
+			Type:         pluginsdk.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+
+		"host_name": {
+			Type:     pluginsdk.TypeString,
+			Optional: true,
+		},
+
+		"pick_host_name_from_backend_address": {
+			Type:     pluginsdk.TypeBool,
+			Optional: true,
+			Default:  false,
+		},
+
+		"request_timeout": {
+			Type:         pluginsdk.TypeInt,
+			Optional:     true,
+			ValidateFunc: validation.IntBetween(1, 86400),
+		},
+
+		"authentication_certificate": {
+			Type:     pluginsdk.TypeList,
+			Optional: true,
+			Elem: &pluginsdk.Resource{
+				Schema: map[string]*pluginsdk.Schema{
+					"name": {
+						Type:     pluginsdk.TypeString,
+						Required: true,
+					},
+
+					"id": {
+						Type:     pluginsdk.TypeString,
+						Computed: true,
+					},
+				},
+			},
+		},
+
+		"trusted_root_certificate_names": {
+			Type:     pluginsdk.TypeList,
+			Optional: true,
+			Elem: &pluginsdk.Schema{
+				Type:         pluginsdk.TypeString,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+		},
+
+		"connection_draining": {
+			Type:     pluginsdk.TypeList,
+			MaxItems: 1,
+			Optional: true,
+			Elem: &pluginsdk.Resource{
+				Schema: map[string]*pluginsdk.Schema{
+					"enabled": {
+						Type:     pluginsdk.TypeBool,
+						Required: true,
+					},
+
+					"drain_timeout_sec": {
+						Type:         pluginsdk.TypeInt,
+						Required:     true,
+						ValidateFunc: validation.IntBetween(1, 3600),
+					},
+				},
+			},
+		},
+
+		"probe_name": {
+			Type:     pluginsdk.TypeString,
+			Optional: true,
+		},
+
+		"id": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+
+		"probe_id": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+	}
+}
+
+func applicationGatewayHTTPListenerSchema() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"name": {
+			Type:     pluginsdk.TypeString,
+			Required: true,
+		},
+
+		"frontend_ip_configuration_name": {
+			Type:     pluginsdk.TypeString,
+			Required: true,
+		},
+
+		"frontend_port_name": {
+			Type:     pluginsdk.TypeString,
+			Required: true,
+		},
+
+		"protocol": {
+			Type:             pluginsdk.TypeString,
+			Required:         true,
+			DiffSuppressFunc: suppress.CaseDifference,
+			ValidateFunc: validation.StringInSlice([]string{
+				string(network.ProtocolHTTP),
+				string(network.ProtocolHTTPS),
+			}, true),
+		},
+
+		"host_name": {
+			Type:     pluginsdk.TypeString,
+			Optional: true,
+		},
+
+		"host_names": {
+			Type:     pluginsdk.TypeSet,
+			Optional: true,
+			Elem: &pluginsdk.Schema{
+				Type:         pluginsdk.TypeString,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+		},
+
+		"ssl_certificate_name": {
+			Type:     pluginsdk.TypeString,
+			Optional: true,
+		},
+
+		"require_sni": {
+			Type:     pluginsdk.TypeBool,
+			Optional: true,
+		},
+
+		"frontend_ip_configuration_id": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+
+		"frontend_port_id": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+		"id": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+
+		"ssl_certificate_id": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+
+		"custom_error_configuration": {
+			Type:     pluginsdk.TypeList,
+			Optional: true,
+			Elem: &pluginsdk.Resource{
+				Schema: map[string]*pluginsdk.Schema{
+					"status_code": {
+						Type:     pluginsdk.TypeString,
+						Required: true,
+						ValidateFunc: validation.StringInSlice([]string{
+							string(network.ApplicationGatewayCustomErrorStatusCodeHTTPStatus403),
+							string(network.ApplicationGatewayCustomErrorStatusCodeHTTPStatus502),
+						}, false),
+					},
+
+					"custom_error_page_url": {
+						Type:     pluginsdk.TypeString,
+						Required: true,
+					},
+
+					"id": {
+						Type:     pluginsdk.TypeString,
+						Computed: true,
+					},
+				},
+			},
+		},
+
+		"firewall_policy_id": {
+			Type:         pluginsdk.TypeString,
+			Optional:     true,
+			ValidateFunc: azure.ValidateResourceID,
+		},
+	}
+}
+
+func applicationGatewayProbeSchema() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"name": {
+			Type:     pluginsdk.TypeString,
+			Required: true,
+		},
+
+		"protocol": {
+			Type:             pluginsdk.TypeString,
+			Required:         true,
+			DiffSuppressFunc: suppress.CaseDifference,
+			ValidateFunc: validation.StringInSlice([]string{
+				string(network.ProtocolHTTP),
+				string(network.ProtocolHTTPS),
+			}, true),
+		},
+
+		"path": {
+			Type:     pluginsdk.TypeString,
+			Required: true,
+		},
+
+		"host": {
+			Type:     pluginsdk.TypeString,
+			Optional: true,
+		},
+
+		"interval": {
+			Type:     pluginsdk.TypeInt,
+			Required: true,
+		},
+
+		"timeout": {
+			Type:     pluginsdk.TypeInt,
+			Required: true,
+		},
+
+		"unhealthy_threshold": {
+			Type:     pluginsdk.TypeInt,
+			Required: true,
+		},
+
+		"port": {
+			Type:         pluginsdk.TypeInt,
+			Optional:     true,
+			ValidateFunc: validate.PortNumber,
+		},
+
+		"pick_host_name_from_backend_http_settings": {
+			Type:     pluginsdk.TypeBool,
+			Optional: true,
+			Default:  false,
+		},
+
+		"minimum_servers": {
+			Type:     pluginsdk.TypeInt,
+			Optional: true,
+			Default:  0,
+		},
+
+		//lintignore:XS003
+		"match": {
+			Type:     pluginsdk.TypeList,
+			Optional: true,
+			Computed: true,
+			MaxItems: 1,
+			Elem: &pluginsdk.Resource{
+				Schema: map[string]*pluginsdk.Schema{
+					"body": {
+						Type:     pluginsdk.TypeString,
+						Optional: true,
+					},
+
+					"status_code": {
+						Type:     pluginsdk.TypeList,
+						Optional: true,
+						Elem: &pluginsdk.Schema{
+							Type: pluginsdk.TypeString,
+						},
+					},
+				},
+			},
+		},
+
+		"id": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+	}
+}
+
+func applicationGatewayRequestRoutingRuleSchema() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"name": {
+			Type:     pluginsdk.TypeString,
+			Required: true,
+		},
+
+		"rule_type": {
+			Type:     pluginsdk.TypeString,
+			Required: true,
+			ValidateFunc: validation.StringInSlice([]string{
+				string(network.ApplicationGatewayRequestRoutingRuleTypeBasic),
+				string(network.ApplicationGatewayRequestRoutingRuleTypePathBasedRouting),
+			}, false),
+		},
+
+		"http_listener_name": {
+			Type:     pluginsdk.TypeString,
+			Required: true,
+		},
+
+		"backend_address_pool_name": {
+			Type:     pluginsdk.TypeString,
+			Optional: true,
+		},
+
+		"backend_http_settings_name": {
+			Type:     pluginsdk.TypeString,
+			Optional: true,
+		},
+
+		"url_path_map_name": {
+			Type:     pluginsdk.TypeString,
+			Optional: true,
+		},
+
+		"redirect_configuration_name": {
+			Type:         pluginsdk.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+
+		"rewrite_rule_set_name": {
+			Type:         pluginsdk.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+
+		"backend_address_pool_id": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+
+		"backend_http_settings_id": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+
+		"http_listener_id": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+
+		"id": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+
+		"url_path_map_id": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+
+		"redirect_configuration_id": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+
+		"rewrite_rule_set_id": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+	}
+}
@@ -0,0 +1,551 @@
+package network
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2020-11-01/network"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/tf"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/clients"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/locks"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/network/parse"
+	networkValidate "github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/network/validate"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tf/pluginsdk"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/timeouts"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+// applicationGatewaySubResource describes a type of sub-resource within an Application Gateway (for example an HTTP
+// Listener) which can be managed either inline within the `azurerm_application_gateway` resource or using a
+// standalone resource, which reads, modifies and then writes the Application Gateway whilst holding a lock on it
+type applicationGatewaySubResource struct {
+	// resourceType is the name of the standalone resource
+	resourceType string
+
+	// block is the name of the block within the `azurerm_application_gateway` resource
+	block string
+
+	schema  func() map[string]*pluginsdk.Schema
+	segment string
+	parseId func(input string) (*parse.ApplicationGatewayId, string, error)
+
+	get     func(props *network.ApplicationGatewayPropertiesFormat) []applicationGatewaySubResourceItem
+	set     func(props *network.ApplicationGatewayPropertiesFormat, items []applicationGatewaySubResourceItem)
+	expand  func(input map[string]interface{}, gatewayId string) (interface{}, error)
+	flatten func(props *network.ApplicationGatewayPropertiesFormat) ([]interface{}, error)
+}
+
+type applicationGatewaySubResourceItem struct {
+	name  string
+	value interface{}
+}
+
+func (r applicationGatewaySubResource) id(gatewayId parse.ApplicationGatewayId, name string) string {
+	return fmt.Sprintf("%s/%s/%s", gatewayId.ID(), r.segment, name)
+}
+
+// applicationGatewaySubResources are the sub-resources which can be managed using standalone resources
+var applicationGatewaySubResources = []applicationGatewaySubResource{
+	applicationGatewayBackendAddressPoolSubResource,
+	applicationGatewayBackendHTTPSettingsSubResource,
+	applicationGatewayHTTPListenerSubResource,
+	applicationGatewayProbeSubResource,
+	applicationGatewayRequestRoutingRuleSubResource,
+}
+
+var applicationGatewayBackendAddressPoolSubResource = applicationGatewaySubResource{
+	resourceType: "azurerm_application_gateway_backend_pool",
+	block:        "backend_address_pool",
+	schema:       applicationGatewayBackendAddressPoolSchema,
+	segment:      "backendAddressPools",
+	parseId: func(input string) (*parse.ApplicationGatewayId, string, error) {
+		id, err := parse.ApplicationGatewayBackendAddressPoolID(input)
+		if err != nil {
+			return nil, "", err
+		}
+		gatewayId := parse.NewApplicationGatewayID(id.SubscriptionId, id.ResourceGroup, id.ApplicationGatewayName)
+		return &gatewayId, id.BackendAddressPoolName, nil
+	},
+	get: func(props *network.ApplicationGatewayPropertiesFormat) []applicationGatewaySubResourceItem {
+		items := make([]applicationGatewaySubResourceItem, 0)
+		if props.BackendAddressPools != nil {
+			for _, v := range *props.BackendAddressPools {
+				items = append(items, applicationGatewaySubResourceItem{name: utils.NormalizeNilableString(v.Name), value: v})
+			}
+		}
+		return items
+	},
+	set: func(props *network.ApplicationGatewayPropertiesFormat, items []applicationGatewaySubResourceItem) {
+		values := make([]network.ApplicationGatewayBackendAddressPool, 0)
+		for _, item := range items {
+			values = append(values, item.value.(network.ApplicationGatewayBackendAddressPool))
+		}
+		props.BackendAddressPools = &values
+	},
+	expand: func(input map[string]interface{}, _ string) (interface{}, error) {
+		return (*expandApplicationGatewayBackendAddressPools([]interface{}{input}))[0], nil
+	},
+	flatten: func(props *network.ApplicationGatewayPropertiesFormat) ([]interface{}, error) {
+		return flattenApplicationGatewayBackendAddressPools(props.BackendAddressPools), nil
+	},
+}
+
+var applicationGatewayBackendHTTPSettingsSubResource = applicationGatewaySubResource{
+	resourceType: "azurerm_application_gateway_backend_http_settings",
+	block:        "backend_http_settings",
+	schema:       applicationGatewayBackendHTTPSettingsSchema,
+	segment:      "backendHttpSettingsCollection",
+	parseId: func(input string) (*parse.ApplicationGatewayId, string, error) {
+		id, err := parse.ApplicationGatewayBackendHTTPSettingsID(input)
+		if err != nil {
+			return nil, "", err
+		}
+		gatewayId := parse.NewApplicationGatewayID(id.SubscriptionId, id.ResourceGroup, id.ApplicationGatewayName)
+		return &gatewayId, id.BackendHttpSettingsCollectionName, nil
+	},
+	get: func(props *network.ApplicationGatewayPropertiesFormat) []applicationGatewaySubResourceItem {
+		items := make([]applicationGatewaySubResourceItem, 0)
+		if props.BackendHTTPSettingsCollection != nil {
+			for _, v := range *props.BackendHTTPSettingsCollection {
+				items = append(items, applicationGatewaySubResourceItem{name: utils.NormalizeNilableString(v.Name), value: v})
+			}
+		}
+		return items
+	},
+	set: func(props *network.ApplicationGatewayPropertiesFormat, items []applicationGatewaySubResourceItem) {
+		values := make([]network.ApplicationGatewayBackendHTTPSettings, 0)
+		for _, item := range items {
+			values = append(values, item.value.(network.ApplicationGatewayBackendHTTPSettings))
+		}
+		props.BackendHTTPSettingsCollection = &values
+	},
+	expand: func(input map[string]interface{}, gatewayId string) (interface{}, error) {
+		value := (*expandApplicationGatewayBackendHTTPSettings([]interface{}{input}, gatewayId))[0]
+		if err := validateApplicationGatewayBackendHTTPSettings(value); err != nil {
+			return nil, err
+		}
+		return value, nil
+	},
+	flatten: func(props *network.ApplicationGatewayPropertiesFormat) ([]interface{}, error) {
+		return flattenApplicationGatewayBackendHTTPSettings(props.BackendHTTPSettingsCollection)
+	},
+}
+
+var applicationGatewayHTTPListenerSubResource = applicationGatewaySubResource{
+	resourceType: "azurerm_application_gateway_listener",
+	block:        "http_listener",
+	schema:       applicationGatewayHTTPListenerSchema,
+	segment:      "httpListeners",
+	parseId: func(input string) (*parse.ApplicationGatewayId, string, error) {
+		id, err := parse.ApplicationGatewayHTTPListenerID(input)
+		if err != nil {
+			return nil, "", err
+		}
+		gatewayId := parse.NewApplicationGatewayID(id.SubscriptionId, id.ResourceGroup, id.ApplicationGatewayName)
+		return &gatewayId, id.HttpListenerName, nil
+	},
+	get: func(props *network.ApplicationGatewayPropertiesFormat) []applicationGatewaySubResourceItem {
+		items := make([]applicationGatewaySubResourceItem, 0)
+		if props.HTTPListeners != nil {
+			for _, v := range *props.HTTPListeners {
+				items = append(items, applicationGatewaySubResourceItem{name: utils.NormalizeNilableString(v.Name), value: v})
+			}
+		}
+		return items
+	},
+	set: func(props *network.ApplicationGatewayPropertiesFormat, items []applicationGatewaySubResourceItem) {
+		values := make([]network.ApplicationGatewayHTTPListener, 0)
+		for _, item := range items {
+			values = append(values, item.value.(network.ApplicationGatewayHTTPListener))
+		}
+		props.HTTPListeners = &values
+	},
+	expand: func(input map[string]interface{}, gatewayId string) (interface{}, error) {
+		values, err := expandApplicationGatewayHTTPListeners([]interface{}{input}, gatewayId)
+		if err != nil {
+			return nil, err
+		}
+		return (*values)[0], nil
+	},
+	flatten: func(props *network.ApplicationGatewayPropertiesFormat) ([]interface{}, error) {
+		return flattenApplicationGatewayHTTPListeners(props.HTTPListeners)
+	},
+}
+
+var applicationGatewayProbeSubResource = applicationGatewaySubResource{
+	resourceType: "azurerm_application_gateway_probe",
+	block:        "probe",
+	schema:       applicationGatewayProbeSchema,
+	segment:      "probes",
+	parseId: func(input string) (*parse.ApplicationGatewayId, string, error) {
+		id, err := parse.ApplicationGatewayProbeID(input)
+		if err != nil {
+			return nil, "", err
+		}
+		gatewayId := parse.NewApplicationGatewayID(id.SubscriptionId, id.ResourceGroup, id.ApplicationGatewayName)
+		return &gatewayId, id.ProbeName, nil
+	},
+	get: func(props *network.ApplicationGatewayPropertiesFormat) []applicationGatewaySubResourceItem {
+		items := make([]applicationGatewaySubResourceItem, 0)
+		if props.Probes != nil {
+			for _, v := range *props.Probes {
+				items = append(items, applicationGatewaySubResourceItem{name: utils.NormalizeNilableString(v.Name), value: v})
+			}
+		}
+		return items
+	},
+	set: func(props *network.ApplicationGatewayPropertiesFormat, items []applicationGatewaySubResourceItem) {
+		values := make([]network.ApplicationGatewayProbe, 0)
+		for _, item := range items {
+			values = append(values, item.value.(network.ApplicationGatewayProbe))
+		}
+		props.Probes = &values
+	},
+	expand: func(input map[string]interface{}, _ string) (interface{}, error) {
+		value := (*expandApplicationGatewayProbes([]interface{}{input}))[0]
+		if err := validateApplicationGatewayProbe(value); err != nil {
+			return nil, err
+		}
+		return value, nil
+	},
+	flatten: func(props *network.ApplicationGatewayPropertiesFormat) ([]interface{}, error) {
+		return flattenApplicationGatewayProbes(props.Probes), nil
+	},
+}
+
+var applicationGatewayRequestRoutingRuleSubResource = applicationGatewaySubResource{
+	resourceType: "azurerm_application_gateway_routing_rule",
+	block:        "request_routing_rule",
+	schema:       applicationGatewayRequestRoutingRuleSchema,
+	segment:      "requestRoutingRules",
+	parseId: func(input string) (*parse.ApplicationGatewayId, string, error) {
+		id, err := parse.ApplicationGatewayRequestRoutingRuleID(input)
+		if err != nil {
+			return nil, "", err
+		}
+		gatewayId := parse.NewApplicationGatewayID(id.SubscriptionId, id.ResourceGroup, id.ApplicationGatewayName)
+		return &gatewayId, id.RequestRoutingRuleName, nil
+	},
+	get: func(props *network.ApplicationGatewayPropertiesFormat) []applicationGatewaySubResourceItem {
+		items := make([]applicationGatewaySubResourceItem, 0)
+		if props.RequestRoutingRules != nil {
+			for _, v := range *props.RequestRoutingRules {
+				items = append(items, applicationGatewaySubResourceItem{name: utils.NormalizeNilableString(v.Name), value: v})
+			}
+		}
+		return items
+	},
+	set: func(props *network.ApplicationGatewayPropertiesFormat, items []applicationGatewaySubResourceItem) {
+		values := make([]network.ApplicationGatewayRequestRoutingRule, 0)
+		for _, item := range items {
+			values = append(values, item.value.(network.ApplicationGatewayRequestRoutingRule))
+		}
+		props.RequestRoutingRules = &values
+	},
+	expand: func(input map[string]interface{}, gatewayId string) (interface{}, error) {
+		values, err := expandApplicationGatewayRequestRoutingRules([]interface{}{input}, gatewayId)
+		if err != nil {
+			return nil, err
+		}
+		return (*values)[0], nil
+	},
+	flatten: func(props *network.ApplicationGatewayPropertiesFormat) ([]interface{}, error) {
+		return flattenApplicationGatewayRequestRoutingRules(props.RequestRoutingRules)
+	},
+}
+
+// resourceApplicationGatewaySubResource returns a standalone resource for the specified sub-resource type, whose
+// schema is the same as the block within the `azurerm_application_gateway` resource
+func resourceApplicationGatewaySubResource(subResource applicationGatewaySubResource) *pluginsdk.Resource {
+	schema := subResource.schema()
+
+	// the ID of the sub-resource is exposed as the ID of the resource
+	delete(schema, "id")
+
+	schema["name"].ForceNew = true
+	schema["application_gateway_id"] = &pluginsdk.Schema{
+		Type:         pluginsdk.TypeString,
+		Required:     true,
+		ForceNew:     true,
+		ValidateFunc: networkValidate.ApplicationGatewayID,
+	}
+
+	return &pluginsdk.Resource{
+		Create: func(d *pluginsdk.ResourceData, meta interface{}) error {
+			return resourceApplicationGatewaySubResourceCreateUpdate(d, meta, subResource)
+		},
+		Read: func(d *pluginsdk.ResourceData, meta interface{}) error {
+			return resourceApplicationGatewaySubResourceRead(d, meta, subResource)
+		},
+		Update: func(d *pluginsdk.ResourceData, meta interface{}) error {
+			return resourceApplicationGatewaySubResourceCreateUpdate(d, meta, subResource)
+		},
+		Delete: func(d *pluginsdk.ResourceData, meta interface{}) error {
+			return resourceApplicationGatewaySubResourceDelete(d, meta, subResource)
+		},
+
+		Importer: pluginsdk.ImporterValidatingResourceId(func(id string) error {
+			_, _, err := subResource.parseId(id)
+			return err
+		}),
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Create: pluginsdk.DefaultTimeout(90 * time.Minute),
+			Read:   pluginsdk.DefaultTimeout(5 * time.Minute),
+			Update: pluginsdk.DefaultTimeout(90 * time.Minute),
+			Delete: pluginsdk.DefaultTimeout(90 * time.Minute),
+		},
+
+		Schema: schema,
+	}
+}
+
+func resourceApplicationGatewaySubResourceCreateUpdate(d *pluginsdk.ResourceData, meta interface{}, subResource applicationGatewaySubResource) error {
+	client := meta.(*clients.Client).Network.ApplicationGatewaysClient
+	ctx, cancel := timeouts.ForCreateUpdate(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	gatewayId, err := parse.ApplicationGatewayID(d.Get("application_gateway_id").(string))
+	if err != nil {
+		return err
+	}
+	name := d.Get("name").(string)
+	id := subResource.id(*gatewayId, name)
+
+	locks.ByResourceID(gatewayId)
+	defer locks.UnlockByResourceID(gatewayId)
+
+	gateway, err := client.Get(ctx, gatewayId.ResourceGroup, gatewayId.Name)
+	if err != nil {
+		return fmt.Errorf("retrieving %s: %+v", *gatewayId, err)
+	}
+	if gateway.ApplicationGatewayPropertiesFormat == nil {
+		return fmt.Errorf("retrieving %s: `properties` was nil", *gatewayId)
+	}
+
+	items := subResource.get(gateway.ApplicationGatewayPropertiesFormat)
+	index := -1
+	for i, item := range items {
+		if strings.EqualFold(item.name, name) {
+			index = i
+			break
+		}
+	}
+
+	if d.IsNewResource() && index != -1 {
+		return tf.ImportAsExistsError(subResource.resourceType, id)
+	}
+
+	input := make(map[string]interface{})
+	for key := range subResource.schema() {
+		input[key] = d.Get(key)
+	}
+	value, err := subResource.expand(input, gatewayId.ID())
+	if err != nil {
+		return fmt.Errorf("expanding %q: %+v", id, err)
+	}
+
+	item := applicationGatewaySubResourceItem{
+		name:  name,
+		value: value,
+	}
+	if index == -1 {
+		items = append(items, item)
+	} else {
+		items[index] = item
+	}
+	subResource.set(gateway.ApplicationGatewayPropertiesFormat, items)
+
+	future, err := client.CreateOrUpdate(ctx, gatewayId.ResourceGroup, gatewayId.Name, gateway)
+	if err != nil {
+		return fmt.Errorf("updating %s to add %q: %+v", *gatewayId, id, err)
+	}
+
+	if err = future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		return fmt.Errorf("waiting for update of %s to add %q: %+v", *gatewayId, id, err)
+	}
+
+	d.SetId(id)
+	return resourceApplicationGatewaySubResourceRead(d, meta, subResource)
+}
+
+func resourceApplicationGatewaySubResourceRead(d *pluginsdk.ResourceData, meta interface{}, subResource applicationGatewaySubResource) error {
+	client := meta.(*clients.Client).Network.ApplicationGatewaysClient
+	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	gatewayId, name, err := subResource.parseId(d.Id())
+	if err != nil {
+		return err
+	}
+
+	gateway, err := client.Get(ctx, gatewayId.ResourceGroup, gatewayId.Name)
+	if err != nil {
+		if utils.ResponseWasNotFound(gateway.Response) {
+			log.Printf("[DEBUG] %s was not found - removing %q from state", *gatewayId, d.Id())
+			d.SetId("")
+			return nil
+		}
+
+		return fmt.Errorf("retrieving %s: %+v", *gatewayId, err)
+	}
+	if gateway.ApplicationGatewayPropertiesFormat == nil {
+		return fmt.Errorf("retrieving %s: `properties` was nil", *gatewayId)
+	}
+
+	flattened, err := subResource.flatten(gateway.ApplicationGatewayPropertiesFormat)
+	if err != nil {
+		return fmt.Errorf("flattening `%s`: %+v", subResource.block, err)
+	}
+
+	var values map[string]interface{}
+	for _, raw := range flattened {
+		if v, ok := raw.(map[string]interface{}); ok && strings.EqualFold(v["name"].(string), name) {
+			values = v
+			break
+		}
+	}
+	if values == nil {
+		log.Printf("[DEBUG] %q was not found within %s - removing from state", d.Id(), *gatewayId)
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("name", name)
+	d.Set("application_gateway_id", gatewayId.ID())
+
+	schema := subResource.schema()
+	for key, value := range values {
+		if _, ok := schema[key]; !ok || key == "id" || key == "name" {
+			continue
+		}
+
+		if err := d.Set(key, value); err != nil {
+			return fmt.Errorf("setting `%s`: %+v", key, err)
+		}
+	}
+
+	return nil
+}
+
+func resourceApplicationGatewaySubResourceDelete(d *pluginsdk.ResourceData, meta interface{}, subResource applicationGatewaySubResource) error {
+	client := meta.(*clients.Client).Network.ApplicationGatewaysClient
+	ctx, cancel := timeouts.ForDelete(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	gatewayId, name, err := subResource.parseId(d.Id())
+	if err != nil {
+		return err
+	}
+
+	locks.ByResourceID(gatewayId)
+	defer locks.UnlockByResourceID(gatewayId)
+
+	gateway, err := client.Get(ctx, gatewayId.ResourceGroup, gatewayId.Name)
+	if err != nil {
+		if utils.ResponseWasNotFound(gateway.Response) {
+			return nil
+		}
+
+		return fmt.Errorf("retrieving %s: %+v", *gatewayId, err)
+	}
+	if gateway.ApplicationGatewayPropertiesFormat == nil {
+		return fmt.Errorf("retrieving %s: `properties` was nil", *gatewayId)
+	}
+
+	items := make([]applicationGatewaySubResourceItem, 0)
+	for _, item := range subResource.get(gateway.ApplicationGatewayPropertiesFormat) {
+		if !strings.EqualFold(item.name, name) {
+			items = append(items, item)
+		}
+	}
+	subResource.set(gateway.ApplicationGatewayPropertiesFormat, items)
+
+	future, err := client.CreateOrUpdate(ctx, gatewayId.ResourceGroup, gatewayId.Name, gateway)
+	if err != nil {
+		return fmt.Errorf("updating %s to remove %q: %+v", *gatewayId, d.Id(), err)
+	}
+
+	if err = future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		return fmt.Errorf("waiting for update of %s to remove %q: %+v", *gatewayId, d.Id(), err)
+	}
+
+	return nil
+}
+
+// applicationGatewaySubResourceNames returns the (lower-cased) names of the sub-resources within a block of the
+// `azurerm_application_gateway` resource, which is either a list or a set
+func applicationGatewaySubResourceNames(input interface{}) map[string]struct{} {
+	var items []interface{}
+	switch v := input.(type) {
+	case []interface{}:
+		items = v
+	case *pluginsdk.Set:
+		items = v.List()
+	}
+
+	names := make(map[string]struct{})
+	for _, raw := range items {
+		if v, ok := raw.(map[string]interface{}); ok {
+			if name, ok := v["name"].(string); ok {
+				names[strings.ToLower(name)] = struct{}{}
+			}
+		}
+	}
+	return names
+}
+
+// retainUnmanagedApplicationGatewaySubResources adds the sub-resources from the existing Application Gateway which
+// aren't managed by the `azurerm_application_gateway` resource (e.g. those managed by standalone resources) to the
+// Application Gateway being sent to the API. Sub-resources which were previously defined in the configuration (whilst
+// `ignore_unmanaged_sub_resources` was enabled) and have since been removed are deleted.
+func retainUnmanagedApplicationGatewaySubResources(d *pluginsdk.ResourceData, existing *network.ApplicationGatewayPropertiesFormat, props *network.ApplicationGatewayPropertiesFormat) {
+	oldIgnoreUnmanaged, _ := d.GetChange("ignore_unmanaged_sub_resources")
+
+	for _, subResource := range applicationGatewaySubResources {
+		configured := applicationGatewaySubResourceNames(d.Get(subResource.block))
+
+		// when `ignore_unmanaged_sub_resources` has just been enabled the existing state contains every sub-resource,
+		// so nothing which isn't defined in the configuration is removed
+		previouslyManaged := make(map[string]struct{})
+		if oldIgnoreUnmanaged.(bool) {
+			old, _ := d.GetChange(subResource.block)
+			previouslyManaged = applicationGatewaySubResourceNames(old)
+		}
+
+		items := subResource.get(props)
+		for _, item := range subResource.get(existing) {
+			name := strings.ToLower(item.name)
+			if _, ok := configured[name]; ok {
+				continue
+			}
+			if _, ok := previouslyManaged[name]; ok {
+				continue
+			}
+
+			items = append(items, item)
+		}
+		subResource.set(props, items)
+	}
+}
+
+// filterManagedApplicationGatewaySubResources returns only the flattened sub-resources which are managed by the
+// `azurerm_application_gateway` resource, which are those within the state/configuration for the block
+func filterManagedApplicationGatewaySubResources(d *pluginsdk.ResourceData, block string, input []interface{}) []interface{} {
+	managed := applicationGatewaySubResourceNames(d.Get(block))
+
+	output := make([]interface{}, 0)
+	for _, raw := range input {
+		if v, ok := raw.(map[string]interface{}); ok {
+			if name, ok := v["name"].(string); ok {
+				if _, ok := managed[strings.ToLower(name)]; !ok {
+					continue
+				}
+			}
+		}
+		output = append(output, raw)
+	}
+	return output
+}
@@ -0,0 +1,380 @@
+package network_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2020-11-01/network"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/acceptance"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/acceptance/check"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/clients"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/network/parse"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tf/pluginsdk"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+type ApplicationGatewaySubResourceResource struct {
+	resourceType string
+}
+
+func TestAccApplicationGatewaySubResources_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_application_gateway_routing_rule", "test")
+	r := ApplicationGatewaySubResourceResource{resourceType: "azurerm_application_gateway_routing_rule"}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That("azurerm_application_gateway_backend_pool.test").ExistsInAzure(ApplicationGatewaySubResourceResource{resourceType: "azurerm_application_gateway_backend_pool"}),
+				check.That("azurerm_application_gateway_backend_http_settings.test").ExistsInAzure(ApplicationGatewaySubResourceResource{resourceType: "azurerm_application_gateway_backend_http_settings"}),
+				check.That("azurerm_application_gateway_listener.test").ExistsInAzure(ApplicationGatewaySubResourceResource{resourceType: "azurerm_application_gateway_listener"}),
+				check.That("azurerm_application_gateway_probe.test").ExistsInAzure(ApplicationGatewaySubResourceResource{resourceType: "azurerm_application_gateway_probe"}),
+			),
+		},
+		data.ImportStep(),
+		data.ImportStepFor("azurerm_application_gateway_backend_pool.test"),
+		data.ImportStepFor("azurerm_application_gateway_backend_http_settings.test"),
+		data.ImportStepFor("azurerm_application_gateway_listener.test"),
+		data.ImportStepFor("azurerm_application_gateway_probe.test"),
+	})
+}
+
+func TestAccApplicationGatewaySubResources_update(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_application_gateway_probe", "test")
+	r := ApplicationGatewaySubResourceResource{resourceType: "azurerm_application_gateway_probe"}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.updated(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("interval").HasValue("60"),
+				check.That("azurerm_application_gateway_backend_pool.test").Key("ip_addresses.#").HasValue("2"),
+			),
+		},
+		data.ImportStep(),
+		data.ImportStepFor("azurerm_application_gateway_backend_pool.test"),
+	})
+}
+
+func TestAccApplicationGatewaySubResources_requiresImport(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_application_gateway_listener", "test")
+	r := ApplicationGatewaySubResourceResource{resourceType: "azurerm_application_gateway_listener"}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.RequiresImportErrorStep(r.requiresImport),
+	})
+}
+
+func (r ApplicationGatewaySubResourceResource) Exists(ctx context.Context, clients *clients.Client, state *pluginsdk.InstanceState) (*bool, error) {
+	gatewayId, name, err := r.parseId(state.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := clients.Network.ApplicationGatewaysClient.Get(ctx, gatewayId.ResourceGroup, gatewayId.Name)
+	if err != nil {
+		return nil, fmt.Errorf("retrieving %s: %+v", *gatewayId, err)
+	}
+	if resp.ApplicationGatewayPropertiesFormat == nil {
+		return nil, fmt.Errorf("retrieving %s: `properties` was nil", *gatewayId)
+	}
+
+	for _, v := range r.names(resp.ApplicationGatewayPropertiesFormat) {
+		if v != nil && strings.EqualFold(*v, name) {
+			return utils.Bool(true), nil
+		}
+	}
+
+	return utils.Bool(false), nil
+}
+
+func (r ApplicationGatewaySubResourceResource) parseId(input string) (*parse.ApplicationGatewayId, string, error) {
+	switch r.resourceType {
+	case "azurerm_application_gateway_backend_pool":
+		id, err := parse.ApplicationGatewayBackendAddressPoolID(input)
+		if err != nil {
+			return nil, "", err
+		}
+		gatewayId := parse.NewApplicationGatewayID(id.SubscriptionId, id.ResourceGroup, id.ApplicationGatewayName)
+		return &gatewayId, id.BackendAddressPoolName, nil
+
+	case "azurerm_application_gateway_backend_http_settings":
+		id, err := parse.ApplicationGatewayBackendHTTPSettingsID(input)
+		if err != nil {
+			return nil, "", err
+		}
+		gatewayId := parse.NewApplicationGatewayID(id.SubscriptionId, id.ResourceGroup, id.ApplicationGatewayName)
+		return &gatewayId, id.BackendHttpSettingsCollectionName, nil
+
+	case "azurerm_application_gateway_listener":
+		id, err := parse.ApplicationGatewayHTTPListenerID(input)
+		if err != nil {
+			return nil, "", err
+		}
+		gatewayId := parse.NewApplicationGatewayID(id.SubscriptionId, id.ResourceGroup, id.ApplicationGatewayName)
+		return &gatewayId, id.HttpListenerName, nil
+
+	case "azurerm_application_gateway_probe":
+		id, err := parse.ApplicationGatewayProbeID(input)
+		if err != nil {
+			return nil, "", err
+		}
+		gatewayId := parse.NewApplicationGatewayID(id.SubscriptionId, id.ResourceGroup, id.ApplicationGatewayName)
+		return &gatewayId, id.ProbeName, nil
+
+	case "azurerm_application_gateway_routing_rule":
+		id, err := parse.ApplicationGatewayRequestRoutingRuleID(input)
+		if err != nil {
+			return nil, "", err
+		}
+		gatewayId := parse.NewApplicationGatewayID(id.SubscriptionId, id.ResourceGroup, id.ApplicationGatewayName)
+		return &gatewayId, id.RequestRoutingRuleName, nil
+	}
+
+	return nil, "", fmt.Errorf("unsupported resource type %q", r.resourceType)
+}
+
+func (r ApplicationGatewaySubResourceResource) names(props *network.ApplicationGatewayPropertiesFormat) []*string {
+	names := make([]*string, 0)
+	switch r.resourceType {
+	case "azurerm_application_gateway_backend_pool":
+		if props.BackendAddressPools != nil {
+			for _, v := range *props.BackendAddressPools {
+				names = append(names, v.Name)
+			}
+		}
+	case "azurerm_application_gateway_backend_http_settings":
+		if props.BackendHTTPSettingsCollection != nil {
+			for _, v := range *props.BackendHTTPSettingsCollection {
+				names = append(names, v.Name)
+			}
+		}
+	case "azurerm_application_gateway_listener":
+		if props.HTTPListeners != nil {
+			for _, v := range *props.HTTPListeners {
+				names = append(names, v.Name)
+			}
+		}
+	case "azurerm_application_gateway_probe":
+		if props.Probes != nil {
+			for _, v := range *props.Probes {
+				names = append(names, v.Name)
+			}
+		}
+	case "azurerm_application_gateway_routing_rule":
+		if props.RequestRoutingRules != nil {
+			for _, v := range *props.RequestRoutingRules {
+				names = append(names, v.Name)
+			}
+		}
+	}
+	return names
+}
+
+func (r ApplicationGatewaySubResourceResource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_application_gateway_backend_pool" "test" {
+  name                   = "standalone-beap"
+  application_gateway_id = azurerm_application_gateway.test.id
+  ip_addresses           = ["10.0.1.4"]
+}
+
+resource "azurerm_application_gateway_probe" "test" {
+  name                   = "standalone-probe"
+  application_gateway_id = azurerm_application_gateway.test.id
+  protocol               = "Http"
+  host                   = "contoso.com"
+  path                   = "/health"
+  interval               = 30
+  timeout                = 30
+  unhealthy_threshold    = 3
+}
+
+resource "azurerm_application_gateway_backend_http_settings" "test" {
+  name                   = "standalone-be-htst"
+  application_gateway_id = azurerm_application_gateway.test.id
+  cookie_based_affinity  = "Disabled"
+  port                   = 8080
+  protocol               = "Http"
+  request_timeout        = 30
+  probe_name             = azurerm_application_gateway_probe.test.name
+  host_name              = "contoso.com"
+}
+
+resource "azurerm_application_gateway_listener" "test" {
+  name                           = "standalone-httplstn"
+  application_gateway_id         = azurerm_application_gateway.test.id
+  frontend_ip_configuration_name = local.frontend_ip_configuration_name
+  frontend_port_name             = local.frontend_port_name
+  protocol                       = "Http"
+  host_name                      = "contoso.com"
+}
+
+resource "azurerm_application_gateway_routing_rule" "test" {
+  name                       = "standalone-rqrt"
+  application_gateway_id     = azurerm_application_gateway.test.id
+  rule_type                  = "Basic"
+  http_listener_name         = azurerm_application_gateway_listener.test.name
+  backend_address_pool_name  = azurerm_application_gateway_backend_pool.test.name
+  backend_http_settings_name = azurerm_application_gateway_backend_http_settings.test.name
+}
+`, r.template(data))
+}
+
+func (r ApplicationGatewaySubResourceResource) updated(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_application_gateway_backend_pool" "test" {
+  name                   = "standalone-beap"
+  application_gateway_id = azurerm_application_gateway.test.id
+  ip_addresses           = ["10.0.1.4", "10.0.1.5"]
+}
+
+resource "azurerm_application_gateway_probe" "test" {
+  name                   = "standalone-probe"
+  application_gateway_id = azurerm_application_gateway.test.id
+  protocol               = "Http"
+  host                   = "contoso.com"
+  path                   = "/health"
+  interval               = 60
+  timeout                = 30
+  unhealthy_threshold    = 3
+}
+
+resource "azurerm_application_gateway_backend_http_settings" "test" {
+  name                   = "standalone-be-htst"
+  application_gateway_id = azurerm_application_gateway.test.id
+  cookie_based_affinity  = "Disabled"
+  port                   = 8080
+  protocol               = "Http"
+  request_timeout        = 30
+  probe_name             = azurerm_application_gateway_probe.test.name
+  host_name              = "contoso.com"
+}
+
+resource "azurerm_application_gateway_listener" "test" {
+  name                           = "standalone-httplstn"
+  application_gateway_id         = azurerm_application_gateway.test.id
+  frontend_ip_configuration_name = local.frontend_ip_configuration_name
+  frontend_port_name             = local.frontend_port_name
+  protocol                       = "Http"
+  host_name                      = "contoso.com"
+}
+
+resource "azurerm_application_gateway_routing_rule" "test" {
+  name                       = "standalone-rqrt"
+  application_gateway_id     = azurerm_application_gateway.test.id
+  rule_type                  = "Basic"
+  http_listener_name         = azurerm_application_gateway_listener.test.name
+  backend_address_pool_name  = azurerm_application_gateway_backend_pool.test.name
+  backend_http_settings_name = azurerm_application_gateway_backend_http_settings.test.name
+}
+`, r.template(data))
+}
+
+func (r ApplicationGatewaySubResourceResource) requiresImport(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_application_gateway_listener" "import" {
+  name                           = azurerm_application_gateway_listener.test.name
+  application_gateway_id         = azurerm_application_gateway_listener.test.application_gateway_id
+  frontend_ip_configuration_name = azurerm_application_gateway_listener.test.frontend_ip_configuration_name
+  frontend_port_name             = azurerm_application_gateway_listener.test.frontend_port_name
+  protocol                       = azurerm_application_gateway_listener.test.protocol
+  host_name                      = azurerm_application_gateway_listener.test.host_name
+}
+`, r.basic(data))
+}
+
+func (ApplicationGatewaySubResourceResource) template(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+# since these variables are re-used - a locals block makes this more maintainable
+locals {
+  backend_address_pool_name      = "${azurerm_virtual_network.test.name}-beap"
+  frontend_port_name             = "${azurerm_virtual_network.test.name}-feport"
+  frontend_ip_configuration_name = "${azurerm_virtual_network.test.name}-feip"
+  http_setting_name              = "${azurerm_virtual_network.test.name}-be-htst"
+  listener_name                  = "${azurerm_virtual_network.test.name}-httplstn"
+  request_routing_rule_name      = "${azurerm_virtual_network.test.name}-rqrt"
+}
+
+resource "azurerm_application_gateway" "test" {
+  name                = "acctestag-%d"
+  resource_group_name = azurerm_resource_group.test.name
+  location            = azurerm_resource_group.test.location
+
+  ignore_unmanaged_sub_resources = true
+
+  sku {
+    name     = "Standard_Small"
+    tier     = "Standard"
+    capacity = 2
+  }
+
+  gateway_ip_configuration {
+    name      = "my-gateway-ip-configuration"
+    subnet_id = azurerm_subnet.test.id
+  }
+
+  frontend_port {
+    name = local.frontend_port_name
+    port = 80
+  }
+
+  frontend_ip_configuration {
+    name                 = local.frontend_ip_configuration_name
+    public_ip_address_id = azurerm_public_ip.test.id
+  }
+
+  backend_address_pool {
+    name = local.backend_address_pool_name
+  }
+
+  backend_http_settings {
+    name                  = local.http_setting_name
+    cookie_based_affinity = "Disabled"
+    port                  = 80
+    protocol              = "Http"
+    request_timeout       = 1
+  }
+
+  http_listener {
+    name                           = local.listener_name
+    frontend_ip_configuration_name = local.frontend_ip_configuration_name
+    frontend_port_name             = local.frontend_port_name
+    protocol                       = "Http"
+  }
+
+  request_routing_rule {
+    name                       = local.request_routing_rule_name
+    rule_type                  = "Basic"
+    http_listener_name         = local.listener_name
+    backend_address_pool_name  = local.backend_address_pool_name
+    backend_http_settings_name = local.http_setting_name
+  }
+}
+`, ApplicationGatewayResource{}.template(data), data.RandomInteger)
+}
@@ -277,3 +277,11 @@ func NewClient(o *common.ClientOptions) *Client {
 		ResourceNavigationLinkClient:           &ResourceNavigationLinkClient,
 	}
 }
+
+// VnetPeeringsClientForSubscription returns a Virtual Network Peerings Client scoped to the specified Subscription,
+// which uses the same Authorizer (and as such any Auxiliary Tenants configured in the Provider) as the other clients
+func (c *Client) VnetPeeringsClientForSubscription(subscriptionId string) *network.VirtualNetworkPeeringsClient {
+	client := *c.VnetPeeringsClient
+	client.SubscriptionID = subscriptionId
+	return &client
+}
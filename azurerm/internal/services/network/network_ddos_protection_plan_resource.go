@@ -10,6 +10,7 @@ import (
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/tf"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/clients"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/locks"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/network/parse"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tags"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tf/pluginsdk"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/timeouts"
@@ -85,16 +86,16 @@ func resourceNetworkDDoSProtectionPlanCreateUpdate(d *pluginsdk.ResourceData, me
 	location := azure.NormalizeLocation(d.Get("location").(string))
 	t := d.Get("tags").(map[string]interface{})
 
-	vnetsToLock, err := expandNetworkDDoSProtectionPlanVnetNames(d)
+	vnetsToLock, err := expandNetworkDDoSProtectionPlanVirtualNetworkIDsToLock(d)
 	if err != nil {
-		return fmt.Errorf("Error extracting names of Virtual Network: %+v", err)
+		return fmt.Errorf("Error extracting IDs of Virtual Network: %+v", err)
 	}
 
 	locks.ByName(name, azureNetworkDDoSProtectionPlanResourceName)
 	defer locks.UnlockByName(name, azureNetworkDDoSProtectionPlanResourceName)
 
-	locks.MultipleByName(vnetsToLock, VirtualNetworkResourceName)
-	defer locks.UnlockMultipleByName(vnetsToLock, VirtualNetworkResourceName)
+	locks.MultipleByResourceID(vnetsToLock)
+	defer locks.UnlockMultipleByResourceID(vnetsToLock)
 
 	parameters := network.DdosProtectionPlan{
 		Location: &location,
@@ -186,16 +187,16 @@ func resourceNetworkDDoSProtectionPlanDelete(d *pluginsdk.ResourceData, meta int
 		return fmt.Errorf("Error retrieving DDoS Protection Plan %q (Resource Group %q): %+v", name, resourceGroup, err)
 	}
 
-	vnetsToLock, err := extractVnetNames(d)
+	vnetsToLock, err := expandNetworkDDoSProtectionPlanVirtualNetworkIDsToLock(d)
 	if err != nil {
-		return fmt.Errorf("Error extracting names of Virtual Network: %+v", err)
+		return fmt.Errorf("Error extracting IDs of Virtual Network: %+v", err)
 	}
 
 	locks.ByName(name, azureNetworkDDoSProtectionPlanResourceName)
 	defer locks.UnlockByName(name, azureNetworkDDoSProtectionPlanResourceName)
 
-	locks.MultipleByName(vnetsToLock, VirtualNetworkResourceName)
-	defer locks.UnlockMultipleByName(vnetsToLock, VirtualNetworkResourceName)
+	locks.MultipleByResourceID(vnetsToLock)
+	defer locks.UnlockMultipleByResourceID(vnetsToLock)
 
 	future, err := client.Delete(ctx, resourceGroup, name)
 	if err != nil {
@@ -209,24 +210,20 @@ func resourceNetworkDDoSProtectionPlanDelete(d *pluginsdk.ResourceData, meta int
 	return err
 }
 
-func expandNetworkDDoSProtectionPlanVnetNames(d *pluginsdk.ResourceData) (*[]string, error) {
+func expandNetworkDDoSProtectionPlanVirtualNetworkIDsToLock(d *pluginsdk.ResourceData) ([]locks.ResourceID, error) {
 	vnetIDs := d.Get("virtual_network_ids").([]interface{})
-	vnetNames := make([]string, 0)
+	ids := make([]locks.ResourceID, 0)
 
 	for _, vnetID := range vnetIDs {
-		vnetResourceID, err := azure.ParseAzureResourceID(vnetID.(string))
+		id, err := parse.VirtualNetworkIDInsensitively(vnetID.(string))
 		if err != nil {
 			return nil, err
 		}
 
-		vnetName := vnetResourceID.Path["virtualNetworks"]
-
-		if !utils.SliceContainsValue(vnetNames, vnetName) {
-			vnetNames = append(vnetNames, vnetName)
-		}
+		ids = append(ids, *id)
 	}
 
-	return &vnetNames, nil
+	return ids, nil
 }
 
 func flattenNetworkDDoSProtectionPlanVirtualNetworkIDs(input *[]network.SubResource) []string {
@@ -244,23 +241,3 @@ func flattenNetworkDDoSProtectionPlanVirtualNetworkIDs(input *[]network.SubResou
 
 	return vnetIDs
 }
-
-func extractVnetNames(d *pluginsdk.ResourceData) (*[]string, error) {
-	vnetIDs := d.Get("virtual_network_ids").([]interface{})
-	vnetNames := make([]string, 0)
-
-	for _, vnetID := range vnetIDs {
-		vnetResourceID, err := azure.ParseAzureResourceID(vnetID.(string))
-		if err != nil {
-			return nil, err
-		}
-
-		vnetName := vnetResourceID.Path["virtualNetworks"]
-
-		if !utils.SliceContainsValue(vnetNames, vnetName) {
-			vnetNames = append(vnetNames, vnetName)
-		}
-	}
-
-	return &vnetNames, nil
-}
@@ -11,6 +11,7 @@ import (
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/tf"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/clients"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/locks"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/network/parse"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tf/pluginsdk"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tf/validation"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/timeouts"
@@ -76,8 +77,9 @@ func resourceNetworkInterfaceApplicationGatewayBackendAddressPoolAssociationCrea
 	networkInterfaceName := id.Path["networkInterfaces"]
 	resourceGroup := id.ResourceGroup
 
-	locks.ByName(networkInterfaceName, networkInterfaceResourceName)
-	defer locks.UnlockByName(networkInterfaceName, networkInterfaceResourceName)
+	networkInterfaceLockId := parse.NewNetworkInterfaceID(id.SubscriptionID, resourceGroup, networkInterfaceName)
+	locks.ByResourceID(networkInterfaceLockId)
+	defer locks.UnlockByResourceID(networkInterfaceLockId)
 
 	read, err := client.Get(ctx, resourceGroup, networkInterfaceName, "")
 	if err != nil {
@@ -245,8 +247,9 @@ func resourceNetworkInterfaceApplicationGatewayBackendAddressPoolAssociationDele
 	resourceGroup := nicID.ResourceGroup
 	backendAddressPoolId := splitId[1]
 
-	locks.ByName(networkInterfaceName, networkInterfaceResourceName)
-	defer locks.UnlockByName(networkInterfaceName, networkInterfaceResourceName)
+	networkInterfaceLockId := parse.NewNetworkInterfaceID(nicID.SubscriptionID, resourceGroup, networkInterfaceName)
+	locks.ByResourceID(networkInterfaceLockId)
+	defer locks.UnlockByResourceID(networkInterfaceLockId)
 
 	read, err := client.Get(ctx, resourceGroup, networkInterfaceName, "")
 	if err != nil {
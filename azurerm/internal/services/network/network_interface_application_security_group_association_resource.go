@@ -11,6 +11,7 @@ import (
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/clients"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/locks"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/network/migration"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/network/parse"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tf/pluginsdk"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/timeouts"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
@@ -72,8 +73,9 @@ func resourceNetworkInterfaceApplicationSecurityGroupAssociationCreate(d *plugin
 	networkInterfaceName := id.Path["networkInterfaces"]
 	resourceGroup := id.ResourceGroup
 
-	locks.ByName(networkInterfaceName, networkInterfaceResourceName)
-	defer locks.UnlockByName(networkInterfaceName, networkInterfaceResourceName)
+	networkInterfaceLockId := parse.NewNetworkInterfaceID(id.SubscriptionID, resourceGroup, networkInterfaceName)
+	locks.ByResourceID(networkInterfaceLockId)
+	defer locks.UnlockByResourceID(networkInterfaceLockId)
 
 	read, err := client.Get(ctx, resourceGroup, networkInterfaceName, "")
 	if err != nil {
@@ -192,8 +194,9 @@ func resourceNetworkInterfaceApplicationSecurityGroupAssociationDelete(d *plugin
 	resourceGroup := nicID.ResourceGroup
 	applicationSecurityGroupId := splitId[1]
 
-	locks.ByName(networkInterfaceName, networkInterfaceResourceName)
-	defer locks.UnlockByName(networkInterfaceName, networkInterfaceResourceName)
+	networkInterfaceLockId := parse.NewNetworkInterfaceID(nicID.SubscriptionID, resourceGroup, networkInterfaceName)
+	locks.ByResourceID(networkInterfaceLockId)
+	defer locks.UnlockByResourceID(networkInterfaceLockId)
 
 	read, err := client.Get(ctx, resourceGroup, networkInterfaceName, "")
 	if err != nil {
@@ -4,34 +4,32 @@ import (
 	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2020-11-01/network"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/locks"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/network/parse"
-	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
 )
 
 type networkInterfaceIPConfigurationLockingDetails struct {
-	subnetNamesToLock         []string
-	virtualNetworkNamesToLock []string
+	subnetIdsToLock         []locks.ResourceID
+	virtualNetworkIdsToLock []locks.ResourceID
 }
 
+// lock takes a shared lock on each Virtual Network and an exclusive lock on each Subnet used by the IP Configurations
 func (details networkInterfaceIPConfigurationLockingDetails) lock() {
-	locks.MultipleByName(&details.subnetNamesToLock, SubnetResourceName)
-	locks.MultipleByName(&details.virtualNetworkNamesToLock, VirtualNetworkResourceName)
+	locks.MultipleChildrenByResourceID(details.virtualNetworkIdsToLock, details.subnetIdsToLock)
 }
 
 func (details networkInterfaceIPConfigurationLockingDetails) unlock() {
-	locks.UnlockMultipleByName(&details.subnetNamesToLock, SubnetResourceName)
-	locks.UnlockMultipleByName(&details.virtualNetworkNamesToLock, VirtualNetworkResourceName)
+	locks.UnlockMultipleChildrenByResourceID(details.virtualNetworkIdsToLock, details.subnetIdsToLock)
 }
 
 func determineResourcesToLockFromIPConfiguration(input *[]network.InterfaceIPConfiguration) (*networkInterfaceIPConfigurationLockingDetails, error) {
 	if input == nil {
 		return &networkInterfaceIPConfigurationLockingDetails{
-			subnetNamesToLock:         []string{},
-			virtualNetworkNamesToLock: []string{},
+			subnetIdsToLock:         []locks.ResourceID{},
+			virtualNetworkIdsToLock: []locks.ResourceID{},
 		}, nil
 	}
 
-	subnetNamesToLock := make([]string, 0)
-	virtualNetworkNamesToLock := make([]string, 0)
+	subnetIdsToLock := make([]locks.ResourceID, 0)
+	virtualNetworkIdsToLock := make([]locks.ResourceID, 0)
 
 	for _, config := range *input {
 		if config.Subnet == nil || config.Subnet.ID == nil {
@@ -43,20 +41,13 @@ func determineResourcesToLockFromIPConfiguration(input *[]network.InterfaceIPCon
 			return nil, err
 		}
 
-		virtualNetworkName := id.VirtualNetworkName
-		subnetName := id.Name
-
-		if !utils.SliceContainsValue(virtualNetworkNamesToLock, virtualNetworkName) {
-			virtualNetworkNamesToLock = append(virtualNetworkNamesToLock, virtualNetworkName)
-		}
-
-		if !utils.SliceContainsValue(subnetNamesToLock, subnetName) {
-			subnetNamesToLock = append(subnetNamesToLock, subnetName)
-		}
+		// duplicates are removed when locking
+		subnetIdsToLock = append(subnetIdsToLock, *id)
+		virtualNetworkIdsToLock = append(virtualNetworkIdsToLock, parse.NewVirtualNetworkID(id.SubscriptionId, id.ResourceGroup, id.VirtualNetworkName))
 	}
 
 	return &networkInterfaceIPConfigurationLockingDetails{
-		subnetNamesToLock:         subnetNamesToLock,
-		virtualNetworkNamesToLock: virtualNetworkNamesToLock,
+		subnetIdsToLock:         subnetIdsToLock,
+		virtualNetworkIdsToLock: virtualNetworkIdsToLock,
 	}, nil
 }
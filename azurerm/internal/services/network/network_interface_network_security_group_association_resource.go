@@ -12,6 +12,7 @@ import (
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/azuresdkhacks"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/clients"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/locks"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/network/parse"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tf/pluginsdk"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/timeouts"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
@@ -68,8 +69,9 @@ func resourceNetworkInterfaceSecurityGroupAssociationCreate(d *pluginsdk.Resourc
 	networkInterfaceName := nicId.Path["networkInterfaces"]
 	resourceGroup := nicId.ResourceGroup
 
-	locks.ByName(networkInterfaceName, networkInterfaceResourceName)
-	defer locks.UnlockByName(networkInterfaceName, networkInterfaceResourceName)
+	networkInterfaceLockId := parse.NewNetworkInterfaceID(nicId.SubscriptionID, resourceGroup, networkInterfaceName)
+	locks.ByResourceID(networkInterfaceLockId)
+	defer locks.UnlockByResourceID(networkInterfaceLockId)
 
 	nsgId, err := azure.ParseAzureResourceID(networkSecurityGroupId)
 	if err != nil {
@@ -184,8 +186,9 @@ func resourceNetworkInterfaceSecurityGroupAssociationDelete(d *pluginsdk.Resourc
 	name := nicID.Path["networkInterfaces"]
 	resourceGroup := nicID.ResourceGroup
 
-	locks.ByName(name, networkInterfaceResourceName)
-	defer locks.UnlockByName(name, networkInterfaceResourceName)
+	networkInterfaceLockId := parse.NewNetworkInterfaceID(nicID.SubscriptionID, resourceGroup, name)
+	locks.ByResourceID(networkInterfaceLockId)
+	defer locks.UnlockByResourceID(networkInterfaceLockId)
 
 	read, err := client.Get(ctx, resourceGroup, name, "")
 	if err != nil {
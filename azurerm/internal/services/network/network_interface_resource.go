@@ -20,8 +20,6 @@ import (
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
 )
 
-var networkInterfaceResourceName = "azurerm_network_interface"
-
 func resourceNetworkInterface() *pluginsdk.Resource {
 	return &pluginsdk.Resource{
 		Create: resourceNetworkInterfaceCreate,
@@ -213,8 +211,8 @@ func resourceNetworkInterfaceCreate(d *pluginsdk.ResourceData, meta interface{})
 		EnableAcceleratedNetworking: &enableAcceleratedNetworking,
 	}
 
-	locks.ByName(id.Name, networkInterfaceResourceName)
-	defer locks.UnlockByName(id.Name, networkInterfaceResourceName)
+	locks.ByResourceID(id)
+	defer locks.UnlockByResourceID(id)
 
 	dns, hasDns := d.GetOk("dns_servers")
 	nameLabel, hasNameLabel := d.GetOk("internal_dns_name_label")
@@ -281,8 +279,8 @@ func resourceNetworkInterfaceUpdate(d *pluginsdk.ResourceData, meta interface{})
 		return err
 	}
 
-	locks.ByName(id.Name, networkInterfaceResourceName)
-	defer locks.UnlockByName(id.Name, networkInterfaceResourceName)
+	locks.ByResourceID(id)
+	defer locks.UnlockByResourceID(id)
 
 	// first get the existing one so that we can pull things as needed
 	existing, err := client.Get(ctx, id.ResourceGroup, id.Name, "")
@@ -476,8 +474,8 @@ func resourceNetworkInterfaceDelete(d *pluginsdk.ResourceData, meta interface{})
 		return err
 	}
 
-	locks.ByName(id.Name, networkInterfaceResourceName)
-	defer locks.UnlockByName(id.Name, networkInterfaceResourceName)
+	locks.ByResourceID(id)
+	defer locks.UnlockByResourceID(id)
 
 	existing, err := client.Get(ctx, id.ResourceGroup, id.Name, "")
 	if err != nil {
@@ -10,6 +10,7 @@ import (
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/tf"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/clients"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/locks"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/network/parse"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tags"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tf/pluginsdk"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tf/validation"
@@ -119,19 +120,16 @@ func resourceNetworkProfileCreateUpdate(d *pluginsdk.ResourceData, meta interfac
 	location := azure.NormalizeLocation(d.Get("location").(string))
 	t := d.Get("tags").(map[string]interface{})
 
-	subnetsToLock, vnetsToLock, err := expandNetworkProfileVirtualNetworkSubnetNames(d)
+	subnetsToLock, vnetsToLock, err := expandNetworkProfileVirtualNetworkSubnetIDsToLock(d)
 	if err != nil {
-		return fmt.Errorf("Error extracting names of Subnet and Virtual Network: %+v", err)
+		return fmt.Errorf("Error extracting IDs of Subnet and Virtual Network: %+v", err)
 	}
 
 	locks.ByName(name, azureNetworkProfileResourceName)
 	defer locks.UnlockByName(name, azureNetworkProfileResourceName)
 
-	locks.MultipleByName(vnetsToLock, VirtualNetworkResourceName)
-	defer locks.UnlockMultipleByName(vnetsToLock, VirtualNetworkResourceName)
-
-	locks.MultipleByName(subnetsToLock, SubnetResourceName)
-	defer locks.UnlockMultipleByName(subnetsToLock, SubnetResourceName)
+	locks.MultipleChildrenByResourceID(vnetsToLock, subnetsToLock)
+	defer locks.UnlockMultipleChildrenByResourceID(vnetsToLock, subnetsToLock)
 
 	parameters := network.Profile{
 		Location: &location,
@@ -226,19 +224,16 @@ func resourceNetworkProfileDelete(d *pluginsdk.ResourceData, meta interface{}) e
 		return fmt.Errorf("Error retrieving Network Profile %q (Resource Group %q): %+v", name, resourceGroup, err)
 	}
 
-	subnetsToLock, vnetsToLock, err := expandNetworkProfileVirtualNetworkSubnetNames(d)
+	subnetsToLock, vnetsToLock, err := expandNetworkProfileVirtualNetworkSubnetIDsToLock(d)
 	if err != nil {
-		return fmt.Errorf("Error extracting names of Subnet and Virtual Network: %+v", err)
+		return fmt.Errorf("Error extracting IDs of Subnet and Virtual Network: %+v", err)
 	}
 
 	locks.ByName(name, azureNetworkProfileResourceName)
 	defer locks.UnlockByName(name, azureNetworkProfileResourceName)
 
-	locks.MultipleByName(vnetsToLock, VirtualNetworkResourceName)
-	defer locks.UnlockMultipleByName(vnetsToLock, VirtualNetworkResourceName)
-
-	locks.MultipleByName(subnetsToLock, SubnetResourceName)
-	defer locks.UnlockMultipleByName(subnetsToLock, SubnetResourceName)
+	locks.MultipleChildrenByResourceID(vnetsToLock, subnetsToLock)
+	defer locks.UnlockMultipleChildrenByResourceID(vnetsToLock, subnetsToLock)
 
 	if _, err = client.Delete(ctx, resourceGroup, name); err != nil {
 		return fmt.Errorf("Error deleting Network Profile %q (Resource Group %q): %+v", name, resourceGroup, err)
@@ -287,10 +282,10 @@ func expandNetworkProfileContainerNetworkInterface(d *pluginsdk.ResourceData) *[
 	return &retCNIConfigs
 }
 
-func expandNetworkProfileVirtualNetworkSubnetNames(d *pluginsdk.ResourceData) (*[]string, *[]string, error) {
+func expandNetworkProfileVirtualNetworkSubnetIDsToLock(d *pluginsdk.ResourceData) ([]locks.ResourceID, []locks.ResourceID, error) {
 	cniConfigs := d.Get("container_network_interface").([]interface{})
-	subnetNames := make([]string, 0)
-	vnetNames := make([]string, 0)
+	subnetIds := make([]locks.ResourceID, 0)
+	vnetIds := make([]locks.ResourceID, 0)
 
 	for _, cniConfig := range cniConfigs {
 		nciData := cniConfig.(map[string]interface{})
@@ -300,25 +295,18 @@ func expandNetworkProfileVirtualNetworkSubnetNames(d *pluginsdk.ResourceData) (*
 			ipData := ipConfig.(map[string]interface{})
 			subnetID := ipData["subnet_id"].(string)
 
-			subnetResourceID, err := azure.ParseAzureResourceID(subnetID)
+			id, err := parse.SubnetIDInsensitively(subnetID)
 			if err != nil {
 				return nil, nil, err
 			}
 
-			subnetName := subnetResourceID.Path["subnets"]
-			vnetName := subnetResourceID.Path["virtualNetworks"]
-
-			if !utils.SliceContainsValue(subnetNames, subnetName) {
-				subnetNames = append(subnetNames, subnetName)
-			}
-
-			if !utils.SliceContainsValue(vnetNames, vnetName) {
-				vnetNames = append(vnetNames, vnetName)
-			}
+			// duplicates are removed when locking
+			subnetIds = append(subnetIds, *id)
+			vnetIds = append(vnetIds, parse.NewVirtualNetworkID(id.SubscriptionId, id.ResourceGroup, id.VirtualNetworkName))
 		}
 	}
 
-	return &subnetNames, &vnetNames, nil
+	return subnetIds, vnetIds, nil
 }
 
 func flattenNetworkProfileContainerNetworkInterface(input *[]network.ContainerNetworkInterfaceConfiguration) []interface{} {
@@ -1,7 +1,9 @@
 package network
 
 import (
+	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2020-11-01/network"
@@ -9,6 +11,7 @@ import (
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/azure"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/tf"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/clients"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/features"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/locks"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/network/parse"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tags"
@@ -41,6 +44,8 @@ func resourceNetworkSecurityGroup() *pluginsdk.Resource {
 			Delete: pluginsdk.DefaultTimeout(30 * time.Minute),
 		},
 
+		CustomizeDiff: pluginsdk.CustomizeDiffShim(resourceNetworkSecurityGroupCustomizeDiff),
+
 		Schema: map[string]*pluginsdk.Schema{
 			"name": {
 				Type:     pluginsdk.TypeString,
@@ -173,6 +178,8 @@ func resourceNetworkSecurityGroup() *pluginsdk.Resource {
 				},
 			},
 
+			"security_rule_analysis_warnings": securityRuleAnalysisWarningsSchema(),
+
 			"tags": tags.Schema(),
 		},
 	}
@@ -501,3 +508,63 @@ func validateSecurityRule(sgRule map[string]interface{}) error {
 
 	return err.ErrorOrNil()
 }
+
+func resourceNetworkSecurityGroupCustomizeDiff(ctx context.Context, diff *pluginsdk.ResourceDiff, meta interface{}) error {
+	client := meta.(*clients.Client)
+	mode := client.Features.Network.SecurityRuleAnalysis
+	if mode == features.NetworkSecurityRuleAnalysisDisabled {
+		return nil
+	}
+
+	// the rules are only analysed when they change, so that existing rules don't block unrelated changes
+	if diff.Id() != "" && !diff.HasChange("security_rule") {
+		return nil
+	}
+	if !diff.NewValueKnown("security_rule") {
+		return nil
+	}
+
+	rules := diff.Get("security_rule").(*pluginsdk.Set).List()
+	inlineRuleNames := make([]string, 0)
+	for _, rule := range rules {
+		inlineRuleNames = append(inlineRuleNames, strings.ToLower(rule.(map[string]interface{})["name"].(string)))
+	}
+
+	// the existing standalone rules (from `azurerm_network_security_rule`) are analysed alongside the inline rules,
+	// these are the rules within the Network Security Group which aren't defined inline
+	if diff.Id() != "" {
+		id, err := parse.NetworkSecurityGroupID(diff.Id())
+		if err != nil {
+			return err
+		}
+
+		nsg, err := client.Network.SecurityGroupClient.Get(ctx, id.ResourceGroup, id.Name, "")
+		if err != nil && !utils.ResponseWasNotFound(nsg.Response) {
+			return fmt.Errorf("retrieving Network Security Group %q (Resource Group %q) to analyse the Security Rules: %+v", id.Name, id.ResourceGroup, err)
+		}
+		if nsg.SecurityGroupPropertiesFormat != nil && nsg.SecurityGroupPropertiesFormat.SecurityRules != nil {
+			for _, rule := range *nsg.SecurityGroupPropertiesFormat.SecurityRules {
+				if rule.Name == nil || utils.SliceContainsValue(inlineRuleNames, strings.ToLower(*rule.Name)) {
+					continue
+				}
+				rules = append(rules, flattenSecurityRuleForAnalysis(rule))
+			}
+		}
+	}
+
+	analyser := newSecurityRuleAnalyser(ctx, client.Network.ServiceTagsClient, diff.Get("location").(string))
+
+	// only the findings involving an inline rule are reported, since the others belong to the standalone rules
+	findings := make([]securityRuleFinding, 0)
+	for _, finding := range analyser.analyse(rules) {
+		for _, name := range inlineRuleNames {
+			if finding.involves(name) {
+				findings = append(findings, finding)
+				break
+			}
+		}
+	}
+
+	resource := fmt.Sprintf("Network Security Group %q (Resource Group %q)", diff.Get("name").(string), diff.Get("resource_group_name").(string))
+	return setSecurityRuleAnalysisWarnings(diff, mode, resource, findings)
+}
@@ -0,0 +1,493 @@
+package network
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math/big"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2020-11-01/network"
+	multierror "github.com/hashicorp/go-multierror"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/features"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tf/pluginsdk"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+// securityRuleFinding is an issue found when analysing the Security Rules within a Network Security Group
+type securityRuleFinding struct {
+	// isError is set for findings which are always errors (e.g. duplicate priorities, which the API rejects),
+	// other findings are warnings unless the analysis is configured to treat them as errors
+	isError bool
+	message string
+	rules   []string
+}
+
+func (f securityRuleFinding) involves(ruleName string) bool {
+	for _, v := range f.rules {
+		if strings.EqualFold(v, ruleName) {
+			return true
+		}
+	}
+	return false
+}
+
+// reportSecurityRuleFindings returns the findings in `Warn` mode as warnings, which are exposed through the
+// `security_rule_analysis_warnings` attribute (since CustomizeDiff is unable to return warnings) and returns an
+// error for any finding in `Error` mode, or for a finding which is always an error
+func reportSecurityRuleFindings(mode string, resource string, findings []securityRuleFinding) ([]string, error) {
+	var errs *multierror.Error
+	warnings := make([]string, 0)
+	for _, finding := range findings {
+		if finding.isError || mode == features.NetworkSecurityRuleAnalysisError {
+			errs = multierror.Append(errs, fmt.Errorf("%s: %s", resource, finding.message))
+			continue
+		}
+
+		log.Printf("[WARN] %s: %s", resource, finding.message)
+		warnings = append(warnings, finding.message)
+	}
+
+	return warnings, errs.ErrorOrNil()
+}
+
+// setSecurityRuleAnalysisWarnings reports the findings, setting any warnings into the planned value of the
+// `security_rule_analysis_warnings` attribute so that they're shown in the plan
+func setSecurityRuleAnalysisWarnings(diff *pluginsdk.ResourceDiff, mode string, resource string, findings []securityRuleFinding) error {
+	warnings, err := reportSecurityRuleFindings(mode, resource, findings)
+	if err != nil {
+		return err
+	}
+
+	return diff.SetNew("security_rule_analysis_warnings", warnings)
+}
+
+func securityRuleAnalysisWarningsSchema() *pluginsdk.Schema {
+	return &pluginsdk.Schema{
+		Type:     pluginsdk.TypeList,
+		Computed: true,
+		Elem: &pluginsdk.Schema{
+			Type: pluginsdk.TypeString,
+		},
+	}
+}
+
+// securityRuleAnalyser expands Service Tags into the address prefixes they represent, using the cached Service
+// Tags for the location of the Network Security Group where they're available
+type securityRuleAnalyser struct {
+	serviceTags []network.ServiceTagInformation
+}
+
+func newSecurityRuleAnalyser(ctx context.Context, client *network.ServiceTagsClient, location string) securityRuleAnalyser {
+	analyser := securityRuleAnalyser{}
+	if location == "" {
+		return analyser
+	}
+
+	serviceTags, err := serviceTagsCache.get(ctx, client, location)
+	if err != nil {
+		// Service Tags are then compared by name, rather than by the address prefixes they contain
+		log.Printf("[DEBUG] unable to retrieve the Service Tags for %q to analyse Security Rules: %+v", location, err)
+		return analyser
+	}
+
+	analyser.serviceTags = serviceTags
+	return analyser
+}
+
+type analysedSecurityRule struct {
+	name      string
+	priority  int
+	direction string
+	access    string
+	protocol  string
+
+	// complete is false when a port or address couldn't be parsed, in which case only the priority is checked
+	complete             bool
+	sourcePorts          matchSet
+	destinationPorts     matchSet
+	sourceAddresses      matchSet
+	destinationAddresses matchSet
+}
+
+func (r analysedSecurityRule) covers(other analysedSecurityRule) bool {
+	return (r.protocol == "*" || r.protocol == other.protocol) &&
+		r.sourcePorts.covers(other.sourcePorts) &&
+		r.destinationPorts.covers(other.destinationPorts) &&
+		r.sourceAddresses.covers(other.sourceAddresses) &&
+		r.destinationAddresses.covers(other.destinationAddresses)
+}
+
+func (r analysedSecurityRule) intersects(other analysedSecurityRule) bool {
+	return (r.protocol == "*" || other.protocol == "*" || r.protocol == other.protocol) &&
+		r.sourcePorts.intersects(other.sourcePorts) &&
+		r.destinationPorts.intersects(other.destinationPorts) &&
+		r.sourceAddresses.intersects(other.sourceAddresses) &&
+		r.destinationAddresses.intersects(other.destinationAddresses)
+}
+
+// analyse checks the Security Rules within a single Network Security Group (in the same form as the `security_rule`
+// block) for duplicate priorities, rules which are fully shadowed by a single rule with a higher priority and
+// partially overlapping Allow/Deny rules
+func (a securityRuleAnalyser) analyse(input []interface{}) []securityRuleFinding {
+	rulesByDirection := make(map[string][]analysedSecurityRule)
+	directions := make([]string, 0)
+	for _, raw := range input {
+		v, ok := raw.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		rule := a.expandRule(v)
+		if _, ok := rulesByDirection[rule.direction]; !ok {
+			directions = append(directions, rule.direction)
+		}
+		rulesByDirection[rule.direction] = append(rulesByDirection[rule.direction], rule)
+	}
+	sort.Strings(directions)
+
+	findings := make([]securityRuleFinding, 0)
+	for _, direction := range directions {
+		rules := rulesByDirection[direction]
+		sort.SliceStable(rules, func(i, j int) bool {
+			if rules[i].priority == rules[j].priority {
+				return rules[i].name < rules[j].name
+			}
+			return rules[i].priority < rules[j].priority
+		})
+
+		for i := 1; i < len(rules); i++ {
+			if rules[i].priority == rules[i-1].priority {
+				findings = append(findings, securityRuleFinding{
+					isError: true,
+					message: fmt.Sprintf("the %s Security Rules %q and %q both use the priority %d", direction, rules[i-1].name, rules[i].name, rules[i].priority),
+					rules:   []string{rules[i-1].name, rules[i].name},
+				})
+			}
+		}
+
+		for i, rule := range rules {
+			if !rule.complete {
+				continue
+			}
+
+			for _, higher := range rules[:i] {
+				if !higher.complete || higher.priority == rule.priority {
+					continue
+				}
+
+				if higher.covers(rule) {
+					findings = append(findings, securityRuleFinding{
+						message: fmt.Sprintf("the %s Security Rule %q (priority %d) is fully shadowed by the Security Rule %q (priority %d) which %ss all of the same traffic, so will never be evaluated", direction, rule.name, rule.priority, higher.name, higher.priority, strings.ToLower(higher.access)),
+						rules:   []string{higher.name, rule.name},
+					})
+					break
+				}
+
+				if !strings.EqualFold(higher.access, rule.access) && higher.intersects(rule) {
+					findings = append(findings, securityRuleFinding{
+						message: fmt.Sprintf("the %s Security Rule %q (priority %d, %s) overlaps the Security Rule %q (priority %d, %s) - traffic matching both is %sed by %q", direction, rule.name, rule.priority, rule.access, higher.name, higher.priority, higher.access, strings.ToLower(higher.access), higher.name),
+						rules:   []string{higher.name, rule.name},
+					})
+				}
+			}
+		}
+	}
+
+	return findings
+}
+
+func (a securityRuleAnalyser) expandRule(input map[string]interface{}) analysedSecurityRule {
+	// values which aren't known yet are returned as their zero value
+	name, _ := input["name"].(string)
+	priority, _ := input["priority"].(int)
+	direction, _ := input["direction"].(string)
+	access, _ := input["access"].(string)
+	protocol, _ := input["protocol"].(string)
+	rule := analysedSecurityRule{
+		name:      name,
+		priority:  priority,
+		direction: direction,
+		access:    access,
+		protocol:  strings.ToLower(protocol),
+	}
+
+	var sourcePortsOk, destinationPortsOk, sourceAddressesOk, destinationAddressesOk bool
+	rule.sourcePorts, sourcePortsOk = expandSecurityRulePorts(securityRuleValues(input, "source_port_range", "source_port_ranges"))
+	rule.destinationPorts, destinationPortsOk = expandSecurityRulePorts(securityRuleValues(input, "destination_port_range", "destination_port_ranges"))
+	rule.sourceAddresses, sourceAddressesOk = a.expandAddresses(
+		securityRuleValues(input, "source_address_prefix", "source_address_prefixes"),
+		securityRuleValues(input, "source_application_security_group_ids"))
+	rule.destinationAddresses, destinationAddressesOk = a.expandAddresses(
+		securityRuleValues(input, "destination_address_prefix", "destination_address_prefixes"),
+		securityRuleValues(input, "destination_application_security_group_ids"))
+	rule.complete = sourcePortsOk && destinationPortsOk && sourceAddressesOk && destinationAddressesOk
+
+	return rule
+}
+
+// securityRuleValues returns the non-empty values of the specified fields, which are either strings or sets of strings
+func securityRuleValues(input map[string]interface{}, keys ...string) []string {
+	values := make([]string, 0)
+	for _, key := range keys {
+		var items []interface{}
+		switch v := input[key].(type) {
+		case string:
+			items = []interface{}{v}
+		case *pluginsdk.Set:
+			items = v.List()
+		case []interface{}:
+			items = v
+		}
+
+		for _, item := range items {
+			if s, ok := item.(string); ok && s != "" {
+				values = append(values, s)
+			}
+		}
+	}
+	return values
+}
+
+func expandSecurityRulePorts(input []string) (matchSet, bool) {
+	result := matchSet{}
+	for _, v := range input {
+		if v == "*" {
+			result.all = true
+			continue
+		}
+
+		lower, upper := v, v
+		if split := strings.Split(v, "-"); len(split) == 2 {
+			lower, upper = split[0], split[1]
+		}
+
+		start, err := strconv.Atoi(strings.TrimSpace(lower))
+		if err != nil {
+			return result, false
+		}
+		end, err := strconv.Atoi(strings.TrimSpace(upper))
+		if err != nil || end < start {
+			return result, false
+		}
+
+		// ports are compared in the same way as address ranges, using a 16 bit "address family"
+		result.ranges = append(result.ranges, addressRange{
+			start: big.NewInt(int64(start)),
+			end:   big.NewInt(int64(end) + 1),
+			bits:  16,
+		})
+	}
+
+	return result, len(input) > 0
+}
+
+func (a securityRuleAnalyser) expandAddresses(prefixes []string, applicationSecurityGroupIds []string) (matchSet, bool) {
+	result := matchSet{
+		tokens: make(map[string]struct{}),
+	}
+
+	for _, id := range applicationSecurityGroupIds {
+		result.tokens[strings.ToLower(id)] = struct{}{}
+	}
+
+	for _, v := range prefixes {
+		if v == "*" {
+			result.all = true
+			continue
+		}
+
+		if r, ok := parseSecurityRuleAddress(v); ok {
+			result.ranges = append(result.ranges, *r)
+			continue
+		}
+
+		// otherwise this is a Service Tag - which can be expanded if it's a known Service Tag where every address
+		// prefix can be parsed, otherwise (e.g. for the `VirtualNetwork` and `Internet` tags) it's compared by name
+		if expanded, ok := a.expandServiceTag(v); ok {
+			result.ranges = append(result.ranges, expanded...)
+			continue
+		}
+		result.tokens[strings.ToLower(v)] = struct{}{}
+	}
+
+	return result, len(prefixes) > 0 || len(applicationSecurityGroupIds) > 0
+}
+
+func (a securityRuleAnalyser) expandServiceTag(name string) ([]addressRange, bool) {
+	prefixes, ok := addressPrefixesForServiceTag(a.serviceTags, name)
+	if !ok || len(prefixes) == 0 {
+		return nil, false
+	}
+
+	ranges := make([]addressRange, 0, len(prefixes))
+	for _, prefix := range prefixes {
+		r, ok := parseSecurityRuleAddress(prefix)
+		if !ok {
+			return nil, false
+		}
+		ranges = append(ranges, *r)
+	}
+	return ranges, true
+}
+
+// parseSecurityRuleAddress parses either an IP Address or a CIDR into the range of addresses it covers
+func parseSecurityRuleAddress(input string) (*addressRange, bool) {
+	if ip := net.ParseIP(input); ip != nil {
+		if v4 := ip.To4(); v4 != nil {
+			input = fmt.Sprintf("%s/32", v4.String())
+		} else {
+			input = fmt.Sprintf("%s/128", ip.String())
+		}
+	}
+
+	r, _, err := parseAddressRange(input)
+	if err != nil {
+		return nil, false
+	}
+	return r, true
+}
+
+// matchSet is the set of values (either ports or addresses) matched by a Security Rule, where `tokens` are values
+// which can only be compared by name (such as Application Security Group IDs or unknown Service Tags)
+type matchSet struct {
+	all    bool
+	ranges []addressRange
+	tokens map[string]struct{}
+}
+
+func (s matchSet) isEmpty() bool {
+	return !s.all && len(s.ranges) == 0 && len(s.tokens) == 0
+}
+
+func (s matchSet) covers(other matchSet) bool {
+	if s.all {
+		return true
+	}
+	if other.all {
+		return false
+	}
+
+	for token := range other.tokens {
+		if _, ok := s.tokens[token]; !ok {
+			return false
+		}
+	}
+
+	merged := mergeAddressRanges(s.ranges)
+	for _, r := range other.ranges {
+		covered := false
+		for _, m := range merged {
+			if m.bits == r.bits && m.start.Cmp(r.start) <= 0 && r.end.Cmp(m.end) <= 0 {
+				covered = true
+				break
+			}
+		}
+		if !covered {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (s matchSet) intersects(other matchSet) bool {
+	if s.isEmpty() || other.isEmpty() {
+		return false
+	}
+	if s.all || other.all {
+		return true
+	}
+
+	for token := range other.tokens {
+		if _, ok := s.tokens[token]; ok {
+			return true
+		}
+	}
+
+	for _, r := range s.ranges {
+		for _, o := range other.ranges {
+			if r.bits == o.bits && r.overlaps(o.start, o.end) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// mergeAddressRanges combines overlapping and adjacent ranges within the same address family, so that a range which
+// spans multiple (contiguous) ranges is treated as covered by them
+func mergeAddressRanges(input []addressRange) []addressRange {
+	ranges := make([]addressRange, len(input))
+	copy(ranges, input)
+	sort.Slice(ranges, func(i, j int) bool {
+		if ranges[i].bits != ranges[j].bits {
+			return ranges[i].bits < ranges[j].bits
+		}
+		return ranges[i].start.Cmp(ranges[j].start) < 0
+	})
+
+	merged := make([]addressRange, 0, len(ranges))
+	for _, r := range ranges {
+		if last := len(merged) - 1; last >= 0 && merged[last].bits == r.bits && r.start.Cmp(merged[last].end) <= 0 {
+			if r.end.Cmp(merged[last].end) > 0 {
+				merged[last].end = r.end
+			}
+			continue
+		}
+		merged = append(merged, addressRange{
+			start: r.start,
+			end:   r.end,
+			bits:  r.bits,
+		})
+	}
+
+	return merged
+}
+
+// flattenSecurityRuleForAnalysis converts a Security Rule returned from the API into the same form as the
+// `security_rule` block, so that it can be analysed alongside the rules defined in the configuration
+func flattenSecurityRuleForAnalysis(input network.SecurityRule) map[string]interface{} {
+	output := map[string]interface{}{
+		"name":      "",
+		"priority":  0,
+		"direction": "",
+		"access":    "",
+		"protocol":  "",
+	}
+	if input.Name != nil {
+		output["name"] = *input.Name
+	}
+
+	props := input.SecurityRulePropertiesFormat
+	if props == nil {
+		return output
+	}
+
+	if props.Priority != nil {
+		output["priority"] = int(*props.Priority)
+	}
+	output["direction"] = string(props.Direction)
+	output["access"] = string(props.Access)
+	output["protocol"] = string(props.Protocol)
+
+	output["source_port_range"] = utils.NormalizeNilableString(props.SourcePortRange)
+	output["source_port_ranges"] = utils.FlattenStringSlice(props.SourcePortRanges)
+	output["destination_port_range"] = utils.NormalizeNilableString(props.DestinationPortRange)
+	output["destination_port_ranges"] = utils.FlattenStringSlice(props.DestinationPortRanges)
+	output["source_address_prefix"] = utils.NormalizeNilableString(props.SourceAddressPrefix)
+	output["source_address_prefixes"] = utils.FlattenStringSlice(props.SourceAddressPrefixes)
+	output["destination_address_prefix"] = utils.NormalizeNilableString(props.DestinationAddressPrefix)
+	output["destination_address_prefixes"] = utils.FlattenStringSlice(props.DestinationAddressPrefixes)
+
+	sourceApplicationSecurityGroupIds := flattenApplicationSecurityGroupIds(props.SourceApplicationSecurityGroups)
+	output["source_application_security_group_ids"] = utils.FlattenStringSlice(&sourceApplicationSecurityGroupIds)
+	destinationApplicationSecurityGroupIds := flattenApplicationSecurityGroupIds(props.DestinationApplicationSecurityGroups)
+	output["destination_application_security_group_ids"] = utils.FlattenStringSlice(&destinationApplicationSecurityGroupIds)
+
+	return output
+}
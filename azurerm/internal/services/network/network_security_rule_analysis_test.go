@@ -0,0 +1,215 @@
+package network
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2020-11-01/network"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+func testSecurityRule(name string, priority int, access, protocol, destinationPortRange, sourceAddressPrefix string) map[string]interface{} {
+	return map[string]interface{}{
+		"name":                       name,
+		"priority":                   priority,
+		"direction":                  "Inbound",
+		"access":                     access,
+		"protocol":                   protocol,
+		"source_port_range":          "*",
+		"destination_port_range":     destinationPortRange,
+		"source_address_prefix":      sourceAddressPrefix,
+		"destination_address_prefix": "*",
+	}
+}
+
+func TestSecurityRuleAnalyser(t *testing.T) {
+	serviceTags := []network.ServiceTagInformation{
+		{
+			Name: utils.String("Storage.WestEurope"),
+			Properties: &network.ServiceTagInformationPropertiesFormat{
+				AddressPrefixes: &[]string{"10.1.0.0/24", "10.1.1.0/24"},
+			},
+		},
+	}
+
+	cases := []struct {
+		Name     string
+		Input    []interface{}
+		Expected [][]string
+		IsError  []bool
+	}{
+		{
+			Name: "no issues",
+			Input: []interface{}{
+				testSecurityRule("ssh", 100, "Allow", "Tcp", "22", "10.0.0.0/24"),
+				testSecurityRule("https", 110, "Allow", "Tcp", "443", "*"),
+			},
+			Expected: [][]string{},
+		},
+		{
+			Name: "duplicate priority",
+			Input: []interface{}{
+				testSecurityRule("ssh", 100, "Allow", "Tcp", "22", "10.0.0.0/24"),
+				testSecurityRule("https", 100, "Allow", "Tcp", "443", "*"),
+			},
+			Expected: [][]string{{"https", "ssh"}},
+			IsError:  []bool{true},
+		},
+		{
+			Name: "same priority in different directions",
+			Input: []interface{}{
+				testSecurityRule("ssh", 100, "Allow", "Tcp", "22", "10.0.0.0/24"),
+				func() map[string]interface{} {
+					v := testSecurityRule("https", 100, "Allow", "Tcp", "443", "*")
+					v["direction"] = "Outbound"
+					return v
+				}(),
+			},
+			Expected: [][]string{},
+		},
+		{
+			Name: "fully shadowed",
+			Input: []interface{}{
+				testSecurityRule("deny-all", 100, "Deny", "*", "*", "*"),
+				testSecurityRule("ssh", 200, "Allow", "Tcp", "22", "10.0.0.0/24"),
+			},
+			Expected: [][]string{{"deny-all", "ssh"}},
+			IsError:  []bool{false},
+		},
+		{
+			Name: "shadowed by a port range and a larger prefix",
+			Input: []interface{}{
+				testSecurityRule("ports", 100, "Allow", "Tcp", "20-30", "10.0.0.0/16"),
+				testSecurityRule("ssh", 200, "Allow", "Tcp", "22", "10.0.5.4"),
+			},
+			Expected: [][]string{{"ports", "ssh"}},
+			IsError:  []bool{false},
+		},
+		{
+			Name: "shadowed by an expanded service tag",
+			Input: []interface{}{
+				testSecurityRule("storage", 100, "Deny", "Tcp", "443", "Storage.WestEurope"),
+				func() map[string]interface{} {
+					v := testSecurityRule("https", 200, "Allow", "Tcp", "443", "")
+					v["source_address_prefixes"] = []interface{}{"10.1.0.0/25", "10.1.0.128/25", "10.1.1.0/24"}
+					return v
+				}(),
+			},
+			Expected: [][]string{{"storage", "https"}},
+			IsError:  []bool{false},
+		},
+		{
+			Name: "different protocol isn't shadowed",
+			Input: []interface{}{
+				testSecurityRule("udp", 100, "Allow", "Udp", "*", "*"),
+				testSecurityRule("ssh", 200, "Deny", "Tcp", "22", "*"),
+			},
+			Expected: [][]string{},
+		},
+		{
+			Name: "overlapping allow and deny",
+			Input: []interface{}{
+				testSecurityRule("deny-range", 100, "Deny", "Tcp", "20-25", "10.0.0.0/24"),
+				testSecurityRule("allow-range", 200, "Allow", "Tcp", "22-80", "10.0.0.0/16"),
+			},
+			Expected: [][]string{{"deny-range", "allow-range"}},
+			IsError:  []bool{false},
+		},
+		{
+			Name: "overlapping rules with the same access",
+			Input: []interface{}{
+				testSecurityRule("first", 100, "Allow", "Tcp", "20-25", "10.0.0.0/24"),
+				testSecurityRule("second", 200, "Allow", "Tcp", "22-80", "10.0.0.0/16"),
+			},
+			Expected: [][]string{},
+		},
+		{
+			Name: "unknown service tags are compared by name",
+			Input: []interface{}{
+				testSecurityRule("internet", 100, "Deny", "Tcp", "*", "Internet"),
+				testSecurityRule("vnet", 200, "Allow", "Tcp", "22", "VirtualNetwork"),
+				testSecurityRule("internet-ssh", 300, "Allow", "Tcp", "22", "internet"),
+			},
+			Expected: [][]string{{"internet", "internet-ssh"}},
+			IsError:  []bool{false},
+		},
+		{
+			Name: "invalid ports are only checked for priority",
+			Input: []interface{}{
+				testSecurityRule("deny-all", 100, "Deny", "*", "*", "*"),
+				testSecurityRule("invalid", 200, "Allow", "Tcp", "abc", "*"),
+			},
+			Expected: [][]string{},
+		},
+	}
+
+	analyser := securityRuleAnalyser{
+		serviceTags: serviceTags,
+	}
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			findings := analyser.analyse(tc.Input)
+
+			actual := make([][]string, 0)
+			for i, finding := range findings {
+				actual = append(actual, finding.rules)
+				if i < len(tc.IsError) && finding.isError != tc.IsError[i] {
+					t.Fatalf("expected finding %q to have isError %t", finding.message, tc.IsError[i])
+				}
+			}
+			if !reflect.DeepEqual(actual, tc.Expected) {
+				t.Fatalf("expected findings for %+v but got %+v", tc.Expected, actual)
+			}
+		})
+	}
+}
+
+func TestSecurityRuleAnalyser_existingRule(t *testing.T) {
+	existing := network.SecurityRule{
+		Name: utils.String("deny-ssh"),
+		SecurityRulePropertiesFormat: &network.SecurityRulePropertiesFormat{
+			Priority:                 utils.Int32(100),
+			Direction:                network.SecurityRuleDirectionInbound,
+			Access:                   network.SecurityRuleAccessDeny,
+			Protocol:                 network.SecurityRuleProtocolTCP,
+			SourcePortRange:          utils.String("*"),
+			DestinationPortRanges:    &[]string{"22", "3389"},
+			SourceAddressPrefix:      utils.String("*"),
+			DestinationAddressPrefix: utils.String("*"),
+		},
+	}
+
+	findings := securityRuleAnalyser{}.analyse([]interface{}{
+		flattenSecurityRuleForAnalysis(existing),
+		testSecurityRule("ssh", 200, "Allow", "Tcp", "22", "10.0.0.0/24"),
+	})
+	if len(findings) != 1 || !findings[0].involves("SSH") || !strings.Contains(findings[0].message, "fully shadowed") {
+		t.Fatalf("expected a single shadowed finding for the rule `ssh` but got %+v", findings)
+	}
+}
+
+func TestReportSecurityRuleFindings(t *testing.T) {
+	findings := []securityRuleFinding{
+		{
+			message: "rule is shadowed",
+			rules:   []string{"first", "second"},
+		},
+	}
+
+	warnings, err := reportSecurityRuleFindings("Warn", "Network Security Group", findings)
+	if err != nil {
+		t.Fatalf("expected warnings not to return an error but got %+v", err)
+	}
+	if len(warnings) != 1 || warnings[0] != "rule is shadowed" {
+		t.Fatalf("expected a single warning but got %+v", warnings)
+	}
+	if _, err := reportSecurityRuleFindings("Error", "Network Security Group", findings); err == nil {
+		t.Fatalf("expected an error")
+	}
+
+	findings[0].isError = true
+	if _, err := reportSecurityRuleFindings("Warn", "Network Security Group", findings); err == nil {
+		t.Fatalf("expected an error")
+	}
+}
@@ -1,13 +1,16 @@
 package network
 
 import (
+	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2020-11-01/network"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/azure"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/tf"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/clients"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/features"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/locks"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tf/pluginsdk"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tf/suppress"
@@ -32,6 +35,8 @@ func resourceNetworkSecurityRule() *pluginsdk.Resource {
 			Delete: pluginsdk.DefaultTimeout(30 * time.Minute),
 		},
 
+		CustomizeDiff: pluginsdk.CustomizeDiffShim(resourceNetworkSecurityRuleCustomizeDiff),
+
 		Schema: map[string]*pluginsdk.Schema{
 			"name": {
 				Type:     pluginsdk.TypeString,
@@ -166,6 +171,8 @@ func resourceNetworkSecurityRule() *pluginsdk.Resource {
 				}, true),
 				DiffSuppressFunc: suppress.CaseDifference,
 			},
+
+			"security_rule_analysis_warnings": securityRuleAnalysisWarningsSchema(),
 		},
 	}
 }
@@ -403,3 +410,82 @@ func flattenApplicationSecurityGroupIds(groups *[]network.ApplicationSecurityGro
 
 	return ids
 }
+
+func resourceNetworkSecurityRuleCustomizeDiff(ctx context.Context, diff *pluginsdk.ResourceDiff, meta interface{}) error {
+	client := meta.(*clients.Client)
+	mode := client.Features.Network.SecurityRuleAnalysis
+	if mode == features.NetworkSecurityRuleAnalysisDisabled {
+		return nil
+	}
+
+	analysedFields := []string{
+		"priority", "direction", "access", "protocol",
+		"source_port_range", "source_port_ranges", "destination_port_range", "destination_port_ranges",
+		"source_address_prefix", "source_address_prefixes", "destination_address_prefix", "destination_address_prefixes",
+		"source_application_security_group_ids", "destination_application_security_group_ids",
+	}
+	// the rule is only analysed when it changes, so that existing rules don't block unrelated changes
+	if diff.Id() != "" {
+		hasChanges := false
+		for _, field := range analysedFields {
+			hasChanges = hasChanges || diff.HasChange(field)
+		}
+		if !hasChanges {
+			return nil
+		}
+	}
+	for _, field := range append(analysedFields, "name", "resource_group_name", "network_security_group_name") {
+		if !diff.NewValueKnown(field) {
+			return nil
+		}
+	}
+
+	name := diff.Get("name").(string)
+	nsgName := diff.Get("network_security_group_name").(string)
+	resourceGroup := diff.Get("resource_group_name").(string)
+
+	nsg, err := client.Network.SecurityGroupClient.Get(ctx, resourceGroup, nsgName, "")
+	if err != nil {
+		if utils.ResponseWasNotFound(nsg.Response) {
+			// the Network Security Group is being created alongside this rule, so there's nothing to compare against
+			return nil
+		}
+		return fmt.Errorf("retrieving Network Security Group %q (Resource Group %q) to analyse Security Rule %q: %+v", nsgName, resourceGroup, name, err)
+	}
+
+	// the existing rules (both inline and standalone) are analysed alongside the planned version of this rule
+	rules := make([]interface{}, 0)
+	if nsg.SecurityGroupPropertiesFormat != nil && nsg.SecurityGroupPropertiesFormat.SecurityRules != nil {
+		for _, rule := range *nsg.SecurityGroupPropertiesFormat.SecurityRules {
+			if rule.Name != nil && strings.EqualFold(*rule.Name, name) {
+				continue
+			}
+			rules = append(rules, flattenSecurityRuleForAnalysis(rule))
+		}
+	}
+
+	planned := map[string]interface{}{
+		"name": name,
+	}
+	for _, field := range analysedFields {
+		planned[field] = diff.Get(field)
+	}
+	rules = append(rules, planned)
+
+	location := ""
+	if nsg.Location != nil {
+		location = *nsg.Location
+	}
+	analyser := newSecurityRuleAnalyser(ctx, client.Network.ServiceTagsClient, location)
+
+	// only the findings involving this rule are reported, since the others belong to other resources
+	findings := make([]securityRuleFinding, 0)
+	for _, finding := range analyser.analyse(rules) {
+		if finding.involves(name) {
+			findings = append(findings, finding)
+		}
+	}
+
+	resource := fmt.Sprintf("Security Rule %q (Network Security Group %q / Resource Group %q)", name, nsgName, resourceGroup)
+	return setSecurityRuleAnalysisWarnings(diff, mode, resource, findings)
+}
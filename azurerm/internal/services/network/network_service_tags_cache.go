@@ -0,0 +1,63 @@
+package network
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2020-11-01/network"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/azure"
+)
+
+// serviceTagsCache holds the Service Tags retrieved for each location, so that they're only retrieved once per
+// run - both by the `azurerm_network_service_tags` Data Source and by the Network Security Group rule analysis
+var serviceTagsCache = &serviceTagsByLocation{
+	values: make(map[string][]network.ServiceTagInformation),
+}
+
+type serviceTagsByLocation struct {
+	lock   sync.Mutex
+	values map[string][]network.ServiceTagInformation
+}
+
+func (c *serviceTagsByLocation) get(ctx context.Context, client *network.ServiceTagsClient, location string) ([]network.ServiceTagInformation, error) {
+	location = azure.NormalizeLocation(location)
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if values, ok := c.values[location]; ok {
+		return values, nil
+	}
+
+	res, err := client.List(ctx, location)
+	if err != nil {
+		return nil, fmt.Errorf("listing network service tags in %q: %+v", location, err)
+	}
+
+	if res.Values == nil {
+		return nil, fmt.Errorf("unexpected nil value for service tag information")
+	}
+
+	c.values[location] = *res.Values
+	return *res.Values, nil
+}
+
+// addressPrefixesForServiceTag returns the address prefixes for the Service Tag with the specified name (either
+// `serviceName` or `serviceName.locationName`), or false if no Service Tag exists with this name
+func addressPrefixesForServiceTag(values []network.ServiceTagInformation, name string) ([]string, bool) {
+	for _, v := range values {
+		if v.Name == nil || !strings.EqualFold(*v.Name, name) {
+			continue
+		}
+
+		prefixes := make([]string, 0)
+		if v.Properties != nil && v.Properties.AddressPrefixes != nil {
+			prefixes = *v.Properties.AddressPrefixes
+		}
+		return prefixes, true
+	}
+
+	return nil, false
+}
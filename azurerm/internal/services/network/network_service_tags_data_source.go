@@ -52,19 +52,15 @@ func dataSourceNetworkServiceTagsRead(d *pluginsdk.ResourceData, meta interface{
 	defer cancel()
 
 	location := azure.NormalizeLocation(d.Get("location"))
-	res, err := client.List(ctx, location)
+	values, err := serviceTagsCache.get(ctx, client, location)
 	if err != nil {
 		return fmt.Errorf("error listing network service tags: %+v", err)
 	}
 
-	if res.Values == nil {
-		return fmt.Errorf("unexpected nil value for service tag information")
-	}
-
 	service := d.Get("service").(string)
 	locationFilter := azure.NormalizeLocation(d.Get("location_filter"))
 
-	for _, sti := range *res.Values {
+	for _, sti := range values {
 		if sti.Name == nil || !isServiceTagOf(*sti.Name, service) {
 			continue
 		}
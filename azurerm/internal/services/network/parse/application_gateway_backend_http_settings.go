@@ -0,0 +1,75 @@
+package parse
+
+// NOTE: this file is generated via 'go:generate' - manual changes will be overwritten
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/azure"
+)
+
+type ApplicationGatewayBackendHTTPSettingsId struct {
+	SubscriptionId                    string
+	ResourceGroup                     string
+	ApplicationGatewayName            string
+	BackendHttpSettingsCollectionName string
+}
+
+func NewApplicationGatewayBackendHTTPSettingsID(subscriptionId, resourceGroup, applicationGatewayName, backendHttpSettingsCollectionName string) ApplicationGatewayBackendHTTPSettingsId {
+	return ApplicationGatewayBackendHTTPSettingsId{
+		SubscriptionId:                    subscriptionId,
+		ResourceGroup:                     resourceGroup,
+		ApplicationGatewayName:            applicationGatewayName,
+		BackendHttpSettingsCollectionName: backendHttpSettingsCollectionName,
+	}
+}
+
+func (id ApplicationGatewayBackendHTTPSettingsId) String() string {
+	segments := []string{
+		fmt.Sprintf("Backend Http Settings Collection Name %q", id.BackendHttpSettingsCollectionName),
+		fmt.Sprintf("Application Gateway Name %q", id.ApplicationGatewayName),
+		fmt.Sprintf("Resource Group %q", id.ResourceGroup),
+	}
+	segmentsStr := strings.Join(segments, " / ")
+	return fmt.Sprintf("%s: (%s)", "Application Gateway Backend H T T P Settings", segmentsStr)
+}
+
+func (id ApplicationGatewayBackendHTTPSettingsId) ID() string {
+	fmtString := "/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Network/applicationGateways/%s/backendHttpSettingsCollection/%s"
+	return fmt.Sprintf(fmtString, id.SubscriptionId, id.ResourceGroup, id.ApplicationGatewayName, id.BackendHttpSettingsCollectionName)
+}
+
+// ApplicationGatewayBackendHTTPSettingsID parses a ApplicationGatewayBackendHTTPSettings ID into an ApplicationGatewayBackendHTTPSettingsId struct
+func ApplicationGatewayBackendHTTPSettingsID(input string) (*ApplicationGatewayBackendHTTPSettingsId, error) {
+	id, err := azure.ParseAzureResourceID(input)
+	if err != nil {
+		return nil, err
+	}
+
+	resourceId := ApplicationGatewayBackendHTTPSettingsId{
+		SubscriptionId: id.SubscriptionID,
+		ResourceGroup:  id.ResourceGroup,
+	}
+
+	if resourceId.SubscriptionId == "" {
+		return nil, fmt.Errorf("ID was missing the 'subscriptions' element")
+	}
+
+	if resourceId.ResourceGroup == "" {
+		return nil, fmt.Errorf("ID was missing the 'resourceGroups' element")
+	}
+
+	if resourceId.ApplicationGatewayName, err = id.PopSegment("applicationGateways"); err != nil {
+		return nil, err
+	}
+	if resourceId.BackendHttpSettingsCollectionName, err = id.PopSegment("backendHttpSettingsCollection"); err != nil {
+		return nil, err
+	}
+
+	if err := id.ValidateNoEmptySegments(input); err != nil {
+		return nil, err
+	}
+
+	return &resourceId, nil
+}
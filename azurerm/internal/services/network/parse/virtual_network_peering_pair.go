@@ -0,0 +1,48 @@
+package parse
+
+import (
+	"fmt"
+	"strings"
+)
+
+type VirtualNetworkPeeringPairId struct {
+	Local  VirtualNetworkPeeringId
+	Remote VirtualNetworkPeeringId
+}
+
+func NewVirtualNetworkPeeringPairID(local, remote VirtualNetworkPeeringId) VirtualNetworkPeeringPairId {
+	return VirtualNetworkPeeringPairId{
+		Local:  local,
+		Remote: remote,
+	}
+}
+
+func (id VirtualNetworkPeeringPairId) ID() string {
+	return fmt.Sprintf("%s|%s", id.Local.ID(), id.Remote.ID())
+}
+
+func (id VirtualNetworkPeeringPairId) String() string {
+	return fmt.Sprintf("Virtual Network Peering Pair: (Local %s / Remote %s)", id.Local, id.Remote)
+}
+
+func VirtualNetworkPeeringPairID(input string) (*VirtualNetworkPeeringPairId, error) {
+	segments := strings.Split(input, "|")
+	if len(segments) != 2 {
+		return nil, fmt.Errorf("Expected an ID in the format `{localVirtualNetworkPeeringID}|{remoteVirtualNetworkPeeringID} but got %q", input)
+	}
+
+	local, err := VirtualNetworkPeeringID(segments[0])
+	if err != nil {
+		return nil, fmt.Errorf("parsing Local Virtual Network Peering ID %q: %+v", segments[0], err)
+	}
+
+	remote, err := VirtualNetworkPeeringID(segments[1])
+	if err != nil {
+		return nil, fmt.Errorf("parsing Remote Virtual Network Peering ID %q: %+v", segments[1], err)
+	}
+
+	return &VirtualNetworkPeeringPairId{
+		Local:  *local,
+		Remote: *remote,
+	}, nil
+}
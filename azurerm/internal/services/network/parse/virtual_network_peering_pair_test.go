@@ -0,0 +1,87 @@
+package parse
+
+import (
+	"testing"
+)
+
+func TestVirtualNetworkPeeringPairID(t *testing.T) {
+	testData := []struct {
+		Name   string
+		Input  string
+		Error  bool
+		Expect *VirtualNetworkPeeringPairId
+	}{
+		{
+			Name:  "Empty",
+			Input: "",
+			Error: true,
+		},
+		{
+			Name:  "One Segment",
+			Input: "hello",
+			Error: true,
+		},
+		{
+			Name:  "Two Segments Invalid ID's",
+			Input: "hello|world",
+			Error: true,
+		},
+		{
+			Name:  "Missing Remote Peering",
+			Input: "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/resGroup1/providers/Microsoft.Network/virtualNetworks/network1/virtualNetworkPeerings/peering1|",
+			Error: true,
+		},
+		{
+			Name:  "Remote Virtual Network ID",
+			Input: "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/resGroup1/providers/Microsoft.Network/virtualNetworks/network1/virtualNetworkPeerings/peering1|/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/resGroup2/providers/Microsoft.Network/virtualNetworks/network2",
+			Error: true,
+		},
+		{
+			Name:  "Valid",
+			Input: "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/resGroup1/providers/Microsoft.Network/virtualNetworks/network1/virtualNetworkPeerings/peering1|/subscriptions/22222222-1234-9876-4563-123456789012/resourceGroups/resGroup2/providers/Microsoft.Network/virtualNetworks/network2/virtualNetworkPeerings/peering2",
+			Expect: &VirtualNetworkPeeringPairId{
+				Local: VirtualNetworkPeeringId{
+					SubscriptionId:     "12345678-1234-9876-4563-123456789012",
+					ResourceGroup:      "resGroup1",
+					VirtualNetworkName: "network1",
+					Name:               "peering1",
+				},
+				Remote: VirtualNetworkPeeringId{
+					SubscriptionId:     "22222222-1234-9876-4563-123456789012",
+					ResourceGroup:      "resGroup2",
+					VirtualNetworkName: "network2",
+					Name:               "peering2",
+				},
+			},
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q..", v.Name)
+
+		actual, err := VirtualNetworkPeeringPairID(v.Input)
+		if err != nil {
+			if v.Error {
+				continue
+			}
+
+			t.Fatalf("Expected a value but got an error: %s", err)
+		}
+
+		if v.Error {
+			t.Fatalf("Expected an error but didn't get one")
+		}
+
+		if actual.Local != v.Expect.Local {
+			t.Fatalf("Expected %+v but got %+v for Local", v.Expect.Local, actual.Local)
+		}
+
+		if actual.Remote != v.Expect.Remote {
+			t.Fatalf("Expected %+v but got %+v for Remote", v.Expect.Remote, actual.Remote)
+		}
+
+		if actual.ID() != v.Input {
+			t.Fatalf("Expected the ID %q but got %q", v.Input, actual.ID())
+		}
+	}
+}
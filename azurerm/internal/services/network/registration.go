@@ -53,22 +53,27 @@ func (r Registration) SupportedDataSources() map[string]*pluginsdk.Resource {
 // SupportedResources returns the supported Resources supported by this Service
 func (r Registration) SupportedResources() map[string]*pluginsdk.Resource {
 	return map[string]*pluginsdk.Resource{
-		"azurerm_application_gateway":                 resourceApplicationGateway(),
-		"azurerm_application_security_group":          resourceApplicationSecurityGroup(),
-		"azurerm_bastion_host":                        resourceBastionHost(),
-		"azurerm_express_route_circuit_connection":    resourceExpressRouteCircuitConnection(),
-		"azurerm_express_route_circuit_authorization": resourceExpressRouteCircuitAuthorization(),
-		"azurerm_express_route_circuit_peering":       resourceExpressRouteCircuitPeering(),
-		"azurerm_express_route_circuit":               resourceExpressRouteCircuit(),
-		"azurerm_express_route_connection":            resourceExpressRouteConnection(),
-		"azurerm_express_route_gateway":               resourceExpressRouteGateway(),
-		"azurerm_express_route_port":                  resourceArmExpressRoutePort(),
-		"azurerm_ip_group":                            resourceIpGroup(),
-		"azurerm_local_network_gateway":               resourceLocalNetworkGateway(),
-		"azurerm_nat_gateway":                         resourceNatGateway(),
-		"azurerm_network_connection_monitor":          resourceNetworkConnectionMonitor(),
-		"azurerm_network_ddos_protection_plan":        resourceNetworkDDoSProtectionPlan(),
-		"azurerm_network_interface":                   resourceNetworkInterface(),
+		"azurerm_application_gateway":                       resourceApplicationGateway(),
+		"azurerm_application_gateway_backend_http_settings": resourceApplicationGatewaySubResource(applicationGatewayBackendHTTPSettingsSubResource),
+		"azurerm_application_gateway_backend_pool":          resourceApplicationGatewaySubResource(applicationGatewayBackendAddressPoolSubResource),
+		"azurerm_application_gateway_listener":              resourceApplicationGatewaySubResource(applicationGatewayHTTPListenerSubResource),
+		"azurerm_application_gateway_probe":                 resourceApplicationGatewaySubResource(applicationGatewayProbeSubResource),
+		"azurerm_application_gateway_routing_rule":          resourceApplicationGatewaySubResource(applicationGatewayRequestRoutingRuleSubResource),
+		"azurerm_application_security_group":                resourceApplicationSecurityGroup(),
+		"azurerm_bastion_host":                              resourceBastionHost(),
+		"azurerm_express_route_circuit_connection":          resourceExpressRouteCircuitConnection(),
+		"azurerm_express_route_circuit_authorization":       resourceExpressRouteCircuitAuthorization(),
+		"azurerm_express_route_circuit_peering":             resourceExpressRouteCircuitPeering(),
+		"azurerm_express_route_circuit":                     resourceExpressRouteCircuit(),
+		"azurerm_express_route_connection":                  resourceExpressRouteConnection(),
+		"azurerm_express_route_gateway":                     resourceExpressRouteGateway(),
+		"azurerm_express_route_port":                        resourceArmExpressRoutePort(),
+		"azurerm_ip_group":                                  resourceIpGroup(),
+		"azurerm_local_network_gateway":                     resourceLocalNetworkGateway(),
+		"azurerm_nat_gateway":                               resourceNatGateway(),
+		"azurerm_network_connection_monitor":                resourceNetworkConnectionMonitor(),
+		"azurerm_network_ddos_protection_plan":              resourceNetworkDDoSProtectionPlan(),
+		"azurerm_network_interface":                         resourceNetworkInterface(),
 		"azurerm_network_interface_application_gateway_backend_address_pool_association": resourceNetworkInterfaceApplicationGatewayBackendAddressPoolAssociation(),
 		"azurerm_network_interface_application_security_group_association":               resourceNetworkInterfaceApplicationSecurityGroupAssociation(),
 		"azurerm_network_interface_backend_address_pool_association":                     resourceNetworkInterfaceBackendAddressPoolAssociation(),
@@ -105,6 +110,7 @@ func (r Registration) SupportedResources() map[string]*pluginsdk.Resource {
 		"azurerm_virtual_network_gateway_connection":                                     resourceVirtualNetworkGatewayConnection(),
 		"azurerm_virtual_network_gateway":                                                resourceVirtualNetworkGateway(),
 		"azurerm_virtual_network_peering":                                                resourceVirtualNetworkPeering(),
+		"azurerm_virtual_network_peering_pair":                                           resourceVirtualNetworkPeeringPair(),
 		"azurerm_virtual_network":                                                        resourceVirtualNetwork(),
 		"azurerm_virtual_wan":                                                            resourceVirtualWan(),
 		"azurerm_vpn_gateway":                                                            resourceVPNGateway(),
@@ -0,0 +1,204 @@
+package network
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"net"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2020-11-01/network"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/network/parse"
+)
+
+// allocateSubnetAddressPrefixes retrieves the Virtual Network and returns the next free IPv4 (and optionally IPv6)
+// address prefix of the requested length within its address space. The caller is responsible for holding an
+// exclusive lock on the Virtual Network, so that the returned prefixes can't be allocated to another Subnet
+func allocateSubnetAddressPrefixes(ctx context.Context, client *network.VirtualNetworksClient, id parse.VirtualNetworkId, prefixLength int, ipv6PrefixLength int) ([]string, error) {
+	vnet, err := client.Get(ctx, id.ResourceGroup, id.Name, "")
+	if err != nil {
+		return nil, fmt.Errorf("retrieving %s: %+v", id, err)
+	}
+	if vnet.VirtualNetworkPropertiesFormat == nil {
+		return nil, fmt.Errorf("retrieving %s: `properties` was nil", id)
+	}
+	props := *vnet.VirtualNetworkPropertiesFormat
+
+	addressSpaces := make([]string, 0)
+	if props.AddressSpace != nil && props.AddressSpace.AddressPrefixes != nil {
+		addressSpaces = *props.AddressSpace.AddressPrefixes
+	}
+
+	allocated := make([]string, 0)
+	if props.Subnets != nil {
+		for _, subnet := range *props.Subnets {
+			if subnet.SubnetPropertiesFormat == nil {
+				continue
+			}
+
+			if subnet.AddressPrefix != nil {
+				allocated = append(allocated, *subnet.AddressPrefix)
+			}
+			if subnet.AddressPrefixes != nil {
+				allocated = append(allocated, *subnet.AddressPrefixes...)
+			}
+		}
+	}
+
+	prefix, err := nextFreeSubnetAddressPrefix(addressSpaces, allocated, prefixLength, false)
+	if err != nil {
+		return nil, fmt.Errorf("allocating a /%d IPv4 address prefix within %s: %+v", prefixLength, id, err)
+	}
+	prefixes := []string{prefix}
+
+	if ipv6PrefixLength > 0 {
+		prefix, err := nextFreeSubnetAddressPrefix(addressSpaces, allocated, ipv6PrefixLength, true)
+		if err != nil {
+			return nil, fmt.Errorf("allocating a /%d IPv6 address prefix within %s: %+v", ipv6PrefixLength, id, err)
+		}
+		prefixes = append(prefixes, prefix)
+	}
+
+	return prefixes, nil
+}
+
+// subnetAddressPrefixLengths returns the lengths of the first IPv4 and IPv6 address prefixes, which are zero when
+// there's no address prefix of that type
+func subnetAddressPrefixLengths(addressPrefixes []string) (int, int) {
+	prefixLength := 0
+	ipv6PrefixLength := 0
+	for _, v := range addressPrefixes {
+		_, ipNet, err := net.ParseCIDR(v)
+		if err != nil {
+			continue
+		}
+
+		ones, bits := ipNet.Mask.Size()
+		if bits == 128 {
+			if ipv6PrefixLength == 0 {
+				ipv6PrefixLength = ones
+			}
+			continue
+		}
+		if prefixLength == 0 {
+			prefixLength = ones
+		}
+	}
+
+	return prefixLength, ipv6PrefixLength
+}
+
+// nextFreeSubnetAddressPrefix returns the lowest block of the given prefix length which is aligned to that prefix
+// length, sits within one of the address spaces (of the requested IP version) and doesn't overlap any of the
+// allocated prefixes. Address spaces are searched in order, so that allocations are stable between runs.
+func nextFreeSubnetAddressPrefix(addressSpaces []string, allocated []string, prefixLength int, ipv6 bool) (string, error) {
+	allocatedRanges := make([]addressRange, 0)
+	for _, v := range allocated {
+		r, isIPv6, err := parseAddressRange(v)
+		if err != nil {
+			return "", fmt.Errorf("parsing allocated address prefix %q: %+v", v, err)
+		}
+		if isIPv6 == ipv6 {
+			allocatedRanges = append(allocatedRanges, *r)
+		}
+	}
+
+	searched := make([]string, 0)
+	for _, space := range addressSpaces {
+		spaceRange, isIPv6, err := parseAddressRange(space)
+		if err != nil {
+			return "", fmt.Errorf("parsing address space %q: %+v", space, err)
+		}
+		if isIPv6 != ipv6 {
+			continue
+		}
+		searched = append(searched, space)
+
+		if prefixLength < spaceRange.prefixLength || prefixLength > spaceRange.bits {
+			continue
+		}
+
+		size := new(big.Int).Lsh(big.NewInt(1), uint(spaceRange.bits-prefixLength))
+		candidate := new(big.Int).Set(spaceRange.start)
+		for {
+			candidateEnd := new(big.Int).Add(candidate, size)
+			if candidateEnd.Cmp(spaceRange.end) > 0 {
+				break
+			}
+
+			var overlapping *addressRange
+			for i := range allocatedRanges {
+				if allocatedRanges[i].overlaps(candidate, candidateEnd) {
+					overlapping = &allocatedRanges[i]
+					break
+				}
+			}
+
+			if overlapping == nil {
+				ip := intToIP(candidate, spaceRange.bits)
+				return fmt.Sprintf("%s/%d", ip.String(), prefixLength), nil
+			}
+
+			// skip past the overlapping prefix, to the next aligned block
+			candidate = alignUp(overlapping.end, size)
+		}
+	}
+
+	if len(searched) == 0 {
+		version := "IPv4"
+		if ipv6 {
+			version = "IPv6"
+		}
+		return "", fmt.Errorf("the Virtual Network has no %s address space", version)
+	}
+
+	return "", fmt.Errorf("the address space %q has no free /%d block remaining", strings.Join(searched, ", "), prefixLength)
+}
+
+// addressRange is the half-open range of addresses [start, end) covered by an address prefix
+type addressRange struct {
+	start        *big.Int
+	end          *big.Int
+	prefixLength int
+	bits         int
+}
+
+func (r addressRange) overlaps(start, end *big.Int) bool {
+	return start.Cmp(r.end) < 0 && r.start.Cmp(end) < 0
+}
+
+func parseAddressRange(input string) (*addressRange, bool, error) {
+	_, ipNet, err := net.ParseCIDR(input)
+	if err != nil {
+		return nil, false, err
+	}
+
+	ones, bits := ipNet.Mask.Size()
+	ip := ipNet.IP
+	if v4 := ip.To4(); v4 != nil && bits == 32 {
+		ip = v4
+	}
+
+	start := new(big.Int).SetBytes(ip)
+	end := new(big.Int).Add(start, new(big.Int).Lsh(big.NewInt(1), uint(bits-ones)))
+	return &addressRange{
+		start:        start,
+		end:          end,
+		prefixLength: ones,
+		bits:         bits,
+	}, bits == 128, nil
+}
+
+func alignUp(value *big.Int, size *big.Int) *big.Int {
+	remainder := new(big.Int).Mod(value, size)
+	if remainder.Sign() == 0 {
+		return new(big.Int).Set(value)
+	}
+
+	return new(big.Int).Add(value, new(big.Int).Sub(size, remainder))
+}
+
+func intToIP(value *big.Int, bits int) net.IP {
+	ip := make(net.IP, bits/8)
+	return value.FillBytes(ip)
+}
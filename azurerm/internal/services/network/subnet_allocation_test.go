@@ -0,0 +1,156 @@
+package network
+
+import (
+	"testing"
+)
+
+func TestNextFreeSubnetAddressPrefix(t *testing.T) {
+	cases := []struct {
+		Name          string
+		AddressSpaces []string
+		Allocated     []string
+		PrefixLength  int
+		IPv6          bool
+		Expected      string
+		ExpectError   bool
+	}{
+		{
+			Name:          "empty address space",
+			AddressSpaces: []string{"10.0.0.0/16"},
+			Allocated:     []string{},
+			PrefixLength:  24,
+			Expected:      "10.0.0.0/24",
+		},
+		{
+			Name:          "next block",
+			AddressSpaces: []string{"10.0.0.0/16"},
+			Allocated:     []string{"10.0.0.0/24", "10.0.1.0/24"},
+			PrefixLength:  24,
+			Expected:      "10.0.2.0/24",
+		},
+		{
+			Name:          "fills gaps",
+			AddressSpaces: []string{"10.0.0.0/16"},
+			Allocated:     []string{"10.0.0.0/24", "10.0.2.0/24"},
+			PrefixLength:  24,
+			Expected:      "10.0.1.0/24",
+		},
+		{
+			Name:          "aligned after a smaller prefix",
+			AddressSpaces: []string{"10.0.0.0/16"},
+			Allocated:     []string{"10.0.0.0/27"},
+			PrefixLength:  24,
+			Expected:      "10.0.1.0/24",
+		},
+		{
+			Name:          "skips a larger prefix",
+			AddressSpaces: []string{"10.0.0.0/16"},
+			Allocated:     []string{"10.0.0.0/22"},
+			PrefixLength:  26,
+			Expected:      "10.0.4.0/26",
+		},
+		{
+			Name:          "second address space",
+			AddressSpaces: []string{"10.0.0.0/24", "10.1.0.0/16"},
+			Allocated:     []string{"10.0.0.0/25", "10.0.0.128/25"},
+			PrefixLength:  25,
+			Expected:      "10.1.0.0/25",
+		},
+		{
+			Name:          "address space smaller than the prefix",
+			AddressSpaces: []string{"10.0.0.0/24", "10.1.0.0/16"},
+			Allocated:     []string{},
+			PrefixLength:  20,
+			Expected:      "10.1.0.0/20",
+		},
+		{
+			Name:          "exhausted",
+			AddressSpaces: []string{"10.0.0.0/24"},
+			Allocated:     []string{"10.0.0.0/25", "10.0.0.128/26"},
+			PrefixLength:  25,
+			ExpectError:   true,
+		},
+		{
+			Name:          "ignores the other IP version",
+			AddressSpaces: []string{"10.0.0.0/16", "ace:cab:deca::/48"},
+			Allocated:     []string{"10.0.0.0/24", "ace:cab:deca::/64"},
+			PrefixLength:  64,
+			IPv6:          true,
+			Expected:      "ace:cab:deca:1::/64",
+		},
+		{
+			Name:          "no IPv6 address space",
+			AddressSpaces: []string{"10.0.0.0/16"},
+			Allocated:     []string{},
+			PrefixLength:  64,
+			IPv6:          true,
+			ExpectError:   true,
+		},
+		{
+			Name:          "invalid allocated prefix",
+			AddressSpaces: []string{"10.0.0.0/16"},
+			Allocated:     []string{"10.0.0.0"},
+			PrefixLength:  24,
+			ExpectError:   true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			actual, err := nextFreeSubnetAddressPrefix(tc.AddressSpaces, tc.Allocated, tc.PrefixLength, tc.IPv6)
+			if err != nil {
+				if tc.ExpectError {
+					return
+				}
+				t.Fatalf("unexpected error: %+v", err)
+			}
+			if tc.ExpectError {
+				t.Fatalf("expected an error but got %q", actual)
+			}
+			if actual != tc.Expected {
+				t.Fatalf("expected %q but got %q", tc.Expected, actual)
+			}
+		})
+	}
+}
+
+func TestSubnetAddressPrefixLengths(t *testing.T) {
+	cases := []struct {
+		Name             string
+		AddressPrefixes  []string
+		PrefixLength     int
+		IPv6PrefixLength int
+	}{
+		{
+			Name:            "none",
+			AddressPrefixes: []string{},
+		},
+		{
+			Name:            "ipv4",
+			AddressPrefixes: []string{"10.0.2.0/24"},
+			PrefixLength:    24,
+		},
+		{
+			Name:             "ipv4 and ipv6",
+			AddressPrefixes:  []string{"10.0.2.0/26", "fd00:db8:deca::/64"},
+			PrefixLength:     26,
+			IPv6PrefixLength: 64,
+		},
+		{
+			Name:            "invalid",
+			AddressPrefixes: []string{"10.0.2.0"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			prefixLength, ipv6PrefixLength := subnetAddressPrefixLengths(tc.AddressPrefixes)
+			if prefixLength != tc.PrefixLength {
+				t.Fatalf("expected a prefix length of %d but got %d", tc.PrefixLength, prefixLength)
+			}
+			if ipv6PrefixLength != tc.IPv6PrefixLength {
+				t.Fatalf("expected an IPv6 prefix length of %d but got %d", tc.IPv6PrefixLength, ipv6PrefixLength)
+			}
+		})
+	}
+}
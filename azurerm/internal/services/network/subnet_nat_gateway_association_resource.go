@@ -74,10 +74,9 @@ func resourceSubnetNatGatewayAssociationCreate(d *pluginsdk.ResourceData, meta i
 
 	locks.ByName(gatewayName, natGatewayResourceName)
 	defer locks.UnlockByName(gatewayName, natGatewayResourceName)
-	locks.ByName(virtualNetworkName, VirtualNetworkResourceName)
-	defer locks.UnlockByName(virtualNetworkName, VirtualNetworkResourceName)
-	locks.ByName(subnetName, SubnetResourceName)
-	defer locks.UnlockByName(subnetName, SubnetResourceName)
+	virtualNetworkId := parse.NewVirtualNetworkID(parsedSubnetId.SubscriptionId, parsedSubnetId.ResourceGroup, parsedSubnetId.VirtualNetworkName)
+	locks.ChildByResourceID(virtualNetworkId, parsedSubnetId)
+	defer locks.UnlockChildByResourceID(virtualNetworkId, parsedSubnetId)
 
 	subnet, err := client.Get(ctx, resourceGroup, virtualNetworkName, subnetName, "")
 	if err != nil {
@@ -197,8 +196,9 @@ func resourceSubnetNatGatewayAssociationDelete(d *pluginsdk.ResourceData, meta i
 	gatewayName := parsedGatewayId.Path["natGateways"]
 	locks.ByName(gatewayName, natGatewayResourceName)
 	defer locks.UnlockByName(gatewayName, natGatewayResourceName)
-	locks.ByName(virtualNetworkName, VirtualNetworkResourceName)
-	defer locks.UnlockByName(virtualNetworkName, VirtualNetworkResourceName)
+	virtualNetworkId := parse.NewVirtualNetworkID(id.SubscriptionId, id.ResourceGroup, id.VirtualNetworkName)
+	locks.ChildByResourceID(virtualNetworkId, id)
+	defer locks.UnlockChildByResourceID(virtualNetworkId, id)
 
 	// ensure we get the latest state
 	subnet, err = client.Get(ctx, resourceGroup, virtualNetworkName, subnetName, "")
@@ -76,11 +76,10 @@ func resourceSubnetNetworkSecurityGroupAssociationCreate(d *pluginsdk.ResourceDa
 	virtualNetworkName := parsedSubnetId.Path["virtualNetworks"]
 	resourceGroup := parsedSubnetId.ResourceGroup
 
-	locks.ByName(virtualNetworkName, VirtualNetworkResourceName)
-	defer locks.UnlockByName(virtualNetworkName, VirtualNetworkResourceName)
-
-	locks.ByName(subnetName, SubnetResourceName)
-	defer locks.UnlockByName(subnetName, SubnetResourceName)
+	virtualNetworkId := parse.NewVirtualNetworkID(parsedSubnetId.SubscriptionID, resourceGroup, virtualNetworkName)
+	subnetLockId := parse.NewSubnetID(parsedSubnetId.SubscriptionID, resourceGroup, virtualNetworkName, subnetName)
+	locks.ChildByResourceID(virtualNetworkId, subnetLockId)
+	defer locks.UnlockChildByResourceID(virtualNetworkId, subnetLockId)
 
 	subnet, err := client.Get(ctx, resourceGroup, virtualNetworkName, subnetName, "")
 	if err != nil {
@@ -207,11 +206,10 @@ func resourceSubnetNetworkSecurityGroupAssociationDelete(d *pluginsdk.ResourceDa
 	locks.ByName(parsedNetworkSecurityGroupId.Name, networkSecurityGroupResourceName)
 	defer locks.UnlockByName(parsedNetworkSecurityGroupId.Name, networkSecurityGroupResourceName)
 
-	locks.ByName(virtualNetworkName, VirtualNetworkResourceName)
-	defer locks.UnlockByName(virtualNetworkName, VirtualNetworkResourceName)
-
-	locks.ByName(subnetName, SubnetResourceName)
-	defer locks.UnlockByName(subnetName, SubnetResourceName)
+	virtualNetworkId := parse.NewVirtualNetworkID(id.SubscriptionID, resourceGroup, virtualNetworkName)
+	subnetLockId := parse.NewSubnetID(id.SubscriptionID, resourceGroup, virtualNetworkName, subnetName)
+	locks.ChildByResourceID(virtualNetworkId, subnetLockId)
+	defer locks.UnlockChildByResourceID(virtualNetworkId, subnetLockId)
 
 	// then re-retrieve it to ensure we've got the latest state
 	read, err = client.Get(ctx, resourceGroup, virtualNetworkName, subnetName, "")
@@ -19,8 +19,6 @@ import (
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
 )
 
-var SubnetResourceName = "azurerm_subnet"
-
 func resourceSubnet() *pluginsdk.Resource {
 	return &pluginsdk.Resource{
 		Create: resourceSubnetCreate,
@@ -60,7 +58,7 @@ func resourceSubnet() *pluginsdk.Resource {
 				Computed: true,
 				// TODO Remove this in the next major version release
 				Deprecated:   "Use the `address_prefixes` property instead.",
-				ExactlyOneOf: []string{"address_prefix", "address_prefixes"},
+				ExactlyOneOf: []string{"address_prefix", "address_prefixes", "prefix_length"},
 			},
 
 			"address_prefixes": {
@@ -72,7 +70,26 @@ func resourceSubnet() *pluginsdk.Resource {
 					Type:         pluginsdk.TypeString,
 					ValidateFunc: validation.StringIsNotEmpty,
 				},
-				ExactlyOneOf: []string{"address_prefix", "address_prefixes"},
+				ExactlyOneOf: []string{"address_prefix", "address_prefixes", "prefix_length"},
+			},
+
+			"prefix_length": {
+				Type:         pluginsdk.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IntBetween(8, 29),
+				ExactlyOneOf: []string{"address_prefix", "address_prefixes", "prefix_length"},
+			},
+
+			// Azure only supports IPv6 subnets with a /64 prefix
+			"ipv6_prefix_length": {
+				Type:         pluginsdk.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IntInSlice([]int{64}),
+				RequiredWith: []string{"prefix_length"},
 			},
 
 			"service_endpoints": {
@@ -179,6 +196,7 @@ func resourceSubnet() *pluginsdk.Resource {
 // TODO: refactor the create/flatten functions
 func resourceSubnetCreate(d *pluginsdk.ResourceData, meta interface{}) error {
 	client := meta.(*clients.Client).Network.SubnetsClient
+	vnetClient := meta.(*clients.Client).Network.VnetClient
 	subscriptionId := meta.(*clients.Client).Account.SubscriptionId
 	ctx, cancel := timeouts.ForCreateUpdate(meta.(*clients.Client).StopContext, d)
 	defer cancel()
@@ -197,10 +215,29 @@ func resourceSubnetCreate(d *pluginsdk.ResourceData, meta interface{}) error {
 		return tf.ImportAsExistsError("azurerm_subnet", id.ID())
 	}
 
-	locks.ByName(id.VirtualNetworkName, VirtualNetworkResourceName)
-	defer locks.UnlockByName(id.VirtualNetworkName, VirtualNetworkResourceName)
+	virtualNetworkId := parse.NewVirtualNetworkID(id.SubscriptionId, id.ResourceGroup, id.VirtualNetworkName)
+	prefixLength, allocateAddressPrefixes := d.GetOk("prefix_length")
+	if allocateAddressPrefixes {
+		// allocating an address prefix requires that no other Subnet is being created or updated within the
+		// Virtual Network, otherwise the same block could be allocated twice
+		locks.ByResourceID(virtualNetworkId)
+		defer locks.UnlockByResourceID(virtualNetworkId)
+	} else {
+		// operations on Subnets within the same Virtual Network can run in parallel, but not alongside
+		// changes to the Virtual Network itself
+		locks.ChildByResourceID(virtualNetworkId, id)
+		defer locks.UnlockChildByResourceID(virtualNetworkId, id)
+	}
 
 	properties := network.SubnetPropertiesFormat{}
+	if allocateAddressPrefixes {
+		addressPrefixes, err := allocateSubnetAddressPrefixes(ctx, vnetClient, virtualNetworkId, prefixLength.(int), d.Get("ipv6_prefix_length").(int))
+		if err != nil {
+			return err
+		}
+		log.Printf("[DEBUG] Allocated the address prefixes %q to %s", strings.Join(addressPrefixes, ", "), id)
+		properties.AddressPrefixes = &addressPrefixes
+	}
 	if value, ok := d.GetOk("address_prefixes"); ok {
 		var addressPrefixes []string
 		for _, item := range value.([]interface{}) {
@@ -261,6 +298,10 @@ func resourceSubnetUpdate(d *pluginsdk.ResourceData, meta interface{}) error {
 		return err
 	}
 
+	virtualNetworkId := parse.NewVirtualNetworkID(id.SubscriptionId, id.ResourceGroup, id.VirtualNetworkName)
+	locks.ChildByResourceID(virtualNetworkId, id)
+	defer locks.UnlockChildByResourceID(virtualNetworkId, id)
+
 	existing, err := client.Get(ctx, id.ResourceGroup, id.VirtualNetworkName, id.Name, "")
 	if err != nil {
 		return fmt.Errorf("retrieving %s: %+v", *id, err)
@@ -372,6 +413,17 @@ func resourceSubnetRead(d *pluginsdk.ResourceData, meta interface{}) error {
 			d.Set("address_prefixes", props.AddressPrefixes)
 		}
 
+		// the prefix lengths are derived from the allocated address prefixes, so that these are available after an import
+		addressPrefixes := make([]string, 0)
+		if props.AddressPrefixes != nil {
+			addressPrefixes = *props.AddressPrefixes
+		} else if props.AddressPrefix != nil {
+			addressPrefixes = append(addressPrefixes, *props.AddressPrefix)
+		}
+		prefixLength, ipv6PrefixLength := subnetAddressPrefixLengths(addressPrefixes)
+		d.Set("prefix_length", prefixLength)
+		d.Set("ipv6_prefix_length", ipv6PrefixLength)
+
 		delegation := flattenSubnetDelegation(props.Delegations)
 		if err := d.Set("delegation", delegation); err != nil {
 			return fmt.Errorf("Error flattening `delegation`: %+v", err)
@@ -404,11 +456,9 @@ func resourceSubnetDelete(d *pluginsdk.ResourceData, meta interface{}) error {
 		return err
 	}
 
-	locks.ByName(id.VirtualNetworkName, VirtualNetworkResourceName)
-	defer locks.UnlockByName(id.VirtualNetworkName, VirtualNetworkResourceName)
-
-	locks.ByName(id.Name, SubnetResourceName)
-	defer locks.UnlockByName(id.Name, SubnetResourceName)
+	virtualNetworkId := parse.NewVirtualNetworkID(id.SubscriptionId, id.ResourceGroup, id.VirtualNetworkName)
+	locks.ChildByResourceID(virtualNetworkId, id)
+	defer locks.UnlockChildByResourceID(virtualNetworkId, id)
 
 	future, err := client.Delete(ctx, id.ResourceGroup, id.VirtualNetworkName, id.Name)
 	if err != nil {
@@ -61,6 +61,41 @@ func TestAccSubnet_complete_addressPrefixes(t *testing.T) {
 	})
 }
 
+func TestAccSubnet_prefixLength(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_subnet", "test")
+	r := SubnetResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.prefixLength(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("address_prefixes.#").HasValue("1"),
+				check.That(data.ResourceName).Key("address_prefixes.0").HasValue("10.0.1.0/24"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccSubnet_prefixLengthIPv6(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_subnet", "test")
+	r := SubnetResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.prefixLengthIPv6(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("address_prefixes.#").HasValue("2"),
+				check.That(data.ResourceName).Key("address_prefixes.0").HasValue("10.0.0.0/24"),
+				check.That(data.ResourceName).Key("address_prefixes.1").HasValue("ace:cab:deca::/64"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
 func TestAccSubnet_update_addressPrefixes(t *testing.T) {
 	data := acceptance.BuildTestData(t, "azurerm_subnet", "test")
 	r := SubnetResource{}
@@ -547,6 +582,56 @@ resource "azurerm_subnet" "test" {
 `, data.RandomInteger, data.Locations.Primary, data.RandomInteger, data.RandomInteger)
 }
 
+func (r SubnetResource) prefixLength(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_subnet" "existing" {
+  name                 = "acctestsubnet-existing-%d"
+  resource_group_name  = azurerm_resource_group.test.name
+  virtual_network_name = azurerm_virtual_network.test.name
+  address_prefixes     = ["10.0.0.0/24"]
+}
+
+resource "azurerm_subnet" "test" {
+  name                 = "acctestsubnet%d"
+  resource_group_name  = azurerm_resource_group.test.name
+  virtual_network_name = azurerm_virtual_network.test.name
+  prefix_length        = 24
+
+  depends_on = [azurerm_subnet.existing]
+}
+`, r.template(data), data.RandomInteger, data.RandomInteger)
+}
+
+func (SubnetResource) prefixLengthIPv6(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-n-%d"
+  location = "%s"
+}
+
+resource "azurerm_virtual_network" "test" {
+  name                = "acctestvirtnet%d"
+  address_space       = ["10.0.0.0/16", "ace:cab:deca::/48"]
+  location            = azurerm_resource_group.test.location
+  resource_group_name = azurerm_resource_group.test.name
+}
+
+resource "azurerm_subnet" "test" {
+  name                 = "acctestsubnet%d"
+  resource_group_name  = azurerm_resource_group.test.name
+  virtual_network_name = azurerm_virtual_network.test.name
+  prefix_length        = 24
+  ipv6_prefix_length   = 64
+}
+`, data.RandomInteger, data.Locations.Primary, data.RandomInteger, data.RandomInteger)
+}
+
 func (r SubnetResource) requiresImport(data acceptance.TestData) string {
 	return fmt.Sprintf(`
 %s
@@ -0,0 +1,443 @@
+package network
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2020-11-01/network"
+	"github.com/hashicorp/go-azure-helpers/response"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/tf"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/clients"
+	networkClient "github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/network/client"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/network/parse"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/network/validate"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tf/pluginsdk"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tf/validation"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/timeouts"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+func resourceVirtualNetworkPeeringPair() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		Create: resourceVirtualNetworkPeeringPairCreate,
+		Read:   resourceVirtualNetworkPeeringPairRead,
+		Update: resourceVirtualNetworkPeeringPairUpdate,
+		Delete: resourceVirtualNetworkPeeringPairDelete,
+
+		Importer: pluginsdk.ImporterValidatingResourceId(func(id string) error {
+			_, err := parse.VirtualNetworkPeeringPairID(id)
+			return err
+		}),
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Create: pluginsdk.DefaultTimeout(30 * time.Minute),
+			Read:   pluginsdk.DefaultTimeout(5 * time.Minute),
+			Update: pluginsdk.DefaultTimeout(30 * time.Minute),
+			Delete: pluginsdk.DefaultTimeout(30 * time.Minute),
+		},
+
+		Schema: map[string]*pluginsdk.Schema{
+			"local": virtualNetworkPeeringPairSideSchema(),
+
+			"remote": virtualNetworkPeeringPairSideSchema(),
+
+			"peering_state": {
+				Type:     pluginsdk.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func virtualNetworkPeeringPairSideSchema() *pluginsdk.Schema {
+	return &pluginsdk.Schema{
+		Type:     pluginsdk.TypeList,
+		Required: true,
+		MaxItems: 1,
+		Elem: &pluginsdk.Resource{
+			Schema: map[string]*pluginsdk.Schema{
+				"name": {
+					Type:         pluginsdk.TypeString,
+					Required:     true,
+					ForceNew:     true,
+					ValidateFunc: validation.StringIsNotEmpty,
+				},
+
+				"virtual_network_id": {
+					Type:         pluginsdk.TypeString,
+					Required:     true,
+					ForceNew:     true,
+					ValidateFunc: validate.VirtualNetworkID,
+				},
+
+				"allow_virtual_network_access": {
+					Type:     pluginsdk.TypeBool,
+					Optional: true,
+					Default:  true,
+				},
+
+				"allow_forwarded_traffic": {
+					Type:     pluginsdk.TypeBool,
+					Optional: true,
+					Default:  false,
+				},
+
+				"allow_gateway_transit": {
+					Type:     pluginsdk.TypeBool,
+					Optional: true,
+					Default:  false,
+				},
+
+				"use_remote_gateways": {
+					Type:     pluginsdk.TypeBool,
+					Optional: true,
+					Default:  false,
+				},
+			},
+		},
+	}
+}
+
+// virtualNetworkPeeringPairSide is one direction of a Virtual Network Peering Pair
+type virtualNetworkPeeringPairSide struct {
+	description string
+	id          parse.VirtualNetworkPeeringId
+	client      *network.VirtualNetworkPeeringsClient
+	peering     network.VirtualNetworkPeering
+}
+
+func resourceVirtualNetworkPeeringPairCreate(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Network
+	ctx, cancel := timeouts.ForCreate(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	sides, err := expandVirtualNetworkPeeringPairSides(d, client)
+	if err != nil {
+		return err
+	}
+
+	for _, side := range sides {
+		existing, err := side.client.Get(ctx, side.id.ResourceGroup, side.id.VirtualNetworkName, side.id.Name)
+		if err != nil {
+			if !utils.ResponseWasNotFound(existing.Response) {
+				return fmt.Errorf("checking for presence of existing %s: %+v", side.id, err)
+			}
+		}
+
+		if !utils.ResponseWasNotFound(existing.Response) {
+			return tf.ImportAsExistsError("azurerm_virtual_network_peering_pair", side.id.ID())
+		}
+	}
+
+	peerMutex.Lock()
+	defer peerMutex.Unlock()
+
+	first, second := orderVirtualNetworkPeeringPairSides(sides)
+	if err := createUpdateVirtualNetworkPeeringPairSide(ctx, first); err != nil {
+		return fmt.Errorf("creating %s: %+v", first.id, err)
+	}
+
+	if err := createUpdateVirtualNetworkPeeringPairSide(ctx, second); err != nil {
+		// roll back the first side, so that a half-created peering isn't left behind
+		if rollbackErr := deleteVirtualNetworkPeeringPairSide(ctx, first); rollbackErr != nil {
+			return fmt.Errorf("creating %s: %+v\n\nadditionally, rolling back the creation of %s failed: %+v", second.id, err, first.id, rollbackErr)
+		}
+
+		return fmt.Errorf("creating %s (the creation of %s has been rolled back): %+v", second.id, first.id, err)
+	}
+
+	id := parse.NewVirtualNetworkPeeringPairID(sides[0].id, sides[1].id)
+	d.SetId(id.ID())
+
+	return resourceVirtualNetworkPeeringPairRead(d, meta)
+}
+
+func resourceVirtualNetworkPeeringPairRead(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Network
+	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := parse.VirtualNetworkPeeringPairID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	local, err := client.VnetPeeringsClientForSubscription(id.Local.SubscriptionId).Get(ctx, id.Local.ResourceGroup, id.Local.VirtualNetworkName, id.Local.Name)
+	if err != nil && !utils.ResponseWasNotFound(local.Response) {
+		return fmt.Errorf("retrieving %s: %+v", id.Local, err)
+	}
+	localExists := err == nil
+
+	remote, err := client.VnetPeeringsClientForSubscription(id.Remote.SubscriptionId).Get(ctx, id.Remote.ResourceGroup, id.Remote.VirtualNetworkName, id.Remote.Name)
+	if err != nil && !utils.ResponseWasNotFound(remote.Response) {
+		return fmt.Errorf("retrieving %s: %+v", id.Remote, err)
+	}
+	remoteExists := err == nil
+
+	if !localExists && !remoteExists {
+		log.Printf("[DEBUG] %s was not found - removing from state", *id)
+		d.SetId("")
+		return nil
+	}
+
+	// when only one side exists the other side is removed from the state, so that the pair is recreated
+	localSide := make([]interface{}, 0)
+	if localExists {
+		localSide = flattenVirtualNetworkPeeringPairSide(id.Local, local)
+	} else {
+		log.Printf("[DEBUG] %s was not found - removing the `local` block from state", id.Local)
+	}
+	if err := d.Set("local", localSide); err != nil {
+		return fmt.Errorf("setting `local`: %+v", err)
+	}
+
+	remoteSide := make([]interface{}, 0)
+	if remoteExists {
+		remoteSide = flattenVirtualNetworkPeeringPairSide(id.Remote, remote)
+	} else {
+		log.Printf("[DEBUG] %s was not found - removing the `remote` block from state", id.Remote)
+	}
+	if err := d.Set("remote", remoteSide); err != nil {
+		return fmt.Errorf("setting `remote`: %+v", err)
+	}
+
+	localState := network.VirtualNetworkPeeringStateDisconnected
+	if localExists && local.VirtualNetworkPeeringPropertiesFormat != nil {
+		localState = local.VirtualNetworkPeeringPropertiesFormat.PeeringState
+	}
+	remoteState := network.VirtualNetworkPeeringStateDisconnected
+	if remoteExists && remote.VirtualNetworkPeeringPropertiesFormat != nil {
+		remoteState = remote.VirtualNetworkPeeringPropertiesFormat.PeeringState
+	}
+	d.Set("peering_state", string(combineVirtualNetworkPeeringPairStates(localState, remoteState)))
+
+	return nil
+}
+
+func resourceVirtualNetworkPeeringPairUpdate(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Network
+	ctx, cancel := timeouts.ForUpdate(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	sides, err := expandVirtualNetworkPeeringPairSides(d, client)
+	if err != nil {
+		return err
+	}
+
+	peerMutex.Lock()
+	defer peerMutex.Unlock()
+
+	first, second := orderVirtualNetworkPeeringPairSides(sides)
+
+	// retrieve the existing first side, so that it can be rolled back if updating the second side fails
+	existing, err := first.client.Get(ctx, first.id.ResourceGroup, first.id.VirtualNetworkName, first.id.Name)
+	if err != nil {
+		return fmt.Errorf("retrieving %s: %+v", first.id, err)
+	}
+	if existing.VirtualNetworkPeeringPropertiesFormat == nil {
+		return fmt.Errorf("retrieving %s: `properties` was nil", first.id)
+	}
+
+	if d.HasChange(first.description) {
+		if err := createUpdateVirtualNetworkPeeringPairSide(ctx, first); err != nil {
+			return fmt.Errorf("updating %s: %+v", first.id, err)
+		}
+	}
+
+	if d.HasChange(second.description) {
+		if err := createUpdateVirtualNetworkPeeringPairSide(ctx, second); err != nil {
+			if !d.HasChange(first.description) {
+				return fmt.Errorf("updating %s: %+v", second.id, err)
+			}
+
+			props := existing.VirtualNetworkPeeringPropertiesFormat
+			rollback := virtualNetworkPeeringPairSide{
+				id:     first.id,
+				client: first.client,
+				peering: network.VirtualNetworkPeering{
+					Name: utils.String(first.id.Name),
+					VirtualNetworkPeeringPropertiesFormat: &network.VirtualNetworkPeeringPropertiesFormat{
+						AllowVirtualNetworkAccess: props.AllowVirtualNetworkAccess,
+						AllowForwardedTraffic:     props.AllowForwardedTraffic,
+						AllowGatewayTransit:       props.AllowGatewayTransit,
+						UseRemoteGateways:         props.UseRemoteGateways,
+						RemoteVirtualNetwork:      props.RemoteVirtualNetwork,
+					},
+				},
+			}
+			if rollbackErr := createUpdateVirtualNetworkPeeringPairSide(ctx, rollback); rollbackErr != nil {
+				return fmt.Errorf("updating %s: %+v\n\nadditionally, rolling back the update of %s failed: %+v", second.id, err, first.id, rollbackErr)
+			}
+
+			return fmt.Errorf("updating %s (the update of %s has been rolled back): %+v", second.id, first.id, err)
+		}
+	}
+
+	return resourceVirtualNetworkPeeringPairRead(d, meta)
+}
+
+func resourceVirtualNetworkPeeringPairDelete(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Network
+	ctx, cancel := timeouts.ForDelete(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := parse.VirtualNetworkPeeringPairID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	peerMutex.Lock()
+	defer peerMutex.Unlock()
+
+	for _, peeringId := range []parse.VirtualNetworkPeeringId{id.Local, id.Remote} {
+		side := virtualNetworkPeeringPairSide{
+			id:     peeringId,
+			client: client.VnetPeeringsClientForSubscription(peeringId.SubscriptionId),
+		}
+		if err := deleteVirtualNetworkPeeringPairSide(ctx, side); err != nil {
+			return fmt.Errorf("deleting %s: %+v", peeringId, err)
+		}
+	}
+
+	return nil
+}
+
+func expandVirtualNetworkPeeringPairSides(d *pluginsdk.ResourceData, client *networkClient.Client) ([]virtualNetworkPeeringPairSide, error) {
+	local := d.Get("local").([]interface{})[0].(map[string]interface{})
+	remote := d.Get("remote").([]interface{})[0].(map[string]interface{})
+
+	sides := make([]virtualNetworkPeeringPairSide, 0)
+	for _, v := range []struct {
+		description string
+		side        map[string]interface{}
+		peer        map[string]interface{}
+	}{
+		{description: "local", side: local, peer: remote},
+		{description: "remote", side: remote, peer: local},
+	} {
+		virtualNetworkId, err := parse.VirtualNetworkID(v.side["virtual_network_id"].(string))
+		if err != nil {
+			return nil, err
+		}
+
+		id := parse.NewVirtualNetworkPeeringID(virtualNetworkId.SubscriptionId, virtualNetworkId.ResourceGroup, virtualNetworkId.Name, v.side["name"].(string))
+		sides = append(sides, virtualNetworkPeeringPairSide{
+			description: v.description,
+			id:          id,
+			client:      client.VnetPeeringsClientForSubscription(id.SubscriptionId),
+			peering: network.VirtualNetworkPeering{
+				Name: utils.String(id.Name),
+				VirtualNetworkPeeringPropertiesFormat: &network.VirtualNetworkPeeringPropertiesFormat{
+					AllowVirtualNetworkAccess: utils.Bool(v.side["allow_virtual_network_access"].(bool)),
+					AllowForwardedTraffic:     utils.Bool(v.side["allow_forwarded_traffic"].(bool)),
+					AllowGatewayTransit:       utils.Bool(v.side["allow_gateway_transit"].(bool)),
+					UseRemoteGateways:         utils.Bool(v.side["use_remote_gateways"].(bool)),
+					RemoteVirtualNetwork: &network.SubResource{
+						ID: utils.String(v.peer["virtual_network_id"].(string)),
+					},
+				},
+			},
+		})
+	}
+
+	return sides, nil
+}
+
+// orderVirtualNetworkPeeringPairSides returns the sides in the order they should be provisioned - a side which uses
+// the remote gateways is provisioned after the side which allows gateway transit
+func orderVirtualNetworkPeeringPairSides(sides []virtualNetworkPeeringPairSide) (virtualNetworkPeeringPairSide, virtualNetworkPeeringPairSide) {
+	if props := sides[0].peering.VirtualNetworkPeeringPropertiesFormat; props.UseRemoteGateways != nil && *props.UseRemoteGateways {
+		return sides[1], sides[0]
+	}
+
+	return sides[0], sides[1]
+}
+
+func createUpdateVirtualNetworkPeeringPairSide(ctx context.Context, side virtualNetworkPeeringPairSide) error {
+	return pluginsdk.Retry(300*time.Second, func() *pluginsdk.RetryError {
+		future, err := side.client.CreateOrUpdate(ctx, side.id.ResourceGroup, side.id.VirtualNetworkName, side.id.Name, side.peering)
+		if err != nil {
+			if utils.ResponseErrorIsRetryable(err) {
+				return pluginsdk.RetryableError(err)
+			} else if future.Response() != nil && future.Response().StatusCode == 400 && strings.Contains(err.Error(), "ReferencedResourceNotProvisioned") {
+				// Resource is not yet ready, this may be the case if the Vnet was just created or another peering was just initiated.
+				return pluginsdk.RetryableError(err)
+			}
+
+			return pluginsdk.NonRetryableError(err)
+		}
+
+		if err = future.WaitForCompletionRef(ctx, side.client.Client); err != nil {
+			return pluginsdk.NonRetryableError(err)
+		}
+
+		return nil
+	})
+}
+
+func deleteVirtualNetworkPeeringPairSide(ctx context.Context, side virtualNetworkPeeringPairSide) error {
+	future, err := side.client.Delete(ctx, side.id.ResourceGroup, side.id.VirtualNetworkName, side.id.Name)
+	if err != nil {
+		if response.WasNotFound(future.Response()) {
+			return nil
+		}
+		return err
+	}
+
+	if err := future.WaitForCompletionRef(ctx, side.client.Client); err != nil {
+		if !response.WasNotFound(future.Response()) {
+			return fmt.Errorf("waiting for deletion: %+v", err)
+		}
+	}
+
+	return nil
+}
+
+func flattenVirtualNetworkPeeringPairSide(id parse.VirtualNetworkPeeringId, input network.VirtualNetworkPeering) []interface{} {
+	allowVirtualNetworkAccess := false
+	allowForwardedTraffic := false
+	allowGatewayTransit := false
+	useRemoteGateways := false
+	if props := input.VirtualNetworkPeeringPropertiesFormat; props != nil {
+		if props.AllowVirtualNetworkAccess != nil {
+			allowVirtualNetworkAccess = *props.AllowVirtualNetworkAccess
+		}
+		if props.AllowForwardedTraffic != nil {
+			allowForwardedTraffic = *props.AllowForwardedTraffic
+		}
+		if props.AllowGatewayTransit != nil {
+			allowGatewayTransit = *props.AllowGatewayTransit
+		}
+		if props.UseRemoteGateways != nil {
+			useRemoteGateways = *props.UseRemoteGateways
+		}
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"name":                         id.Name,
+			"virtual_network_id":           parse.NewVirtualNetworkID(id.SubscriptionId, id.ResourceGroup, id.VirtualNetworkName).ID(),
+			"allow_virtual_network_access": allowVirtualNetworkAccess,
+			"allow_forwarded_traffic":      allowForwardedTraffic,
+			"allow_gateway_transit":        allowGatewayTransit,
+			"use_remote_gateways":          useRemoteGateways,
+		},
+	}
+}
+
+// combineVirtualNetworkPeeringPairStates returns `Connected` only when both sides are connected, `Disconnected` when
+// either side is disconnected and otherwise `Initiated`
+func combineVirtualNetworkPeeringPairStates(local, remote network.VirtualNetworkPeeringState) network.VirtualNetworkPeeringState {
+	if local == remote {
+		return local
+	}
+
+	if local == network.VirtualNetworkPeeringStateDisconnected || remote == network.VirtualNetworkPeeringStateDisconnected {
+		return network.VirtualNetworkPeeringStateDisconnected
+	}
+
+	return network.VirtualNetworkPeeringStateInitiated
+}
@@ -0,0 +1,216 @@
+package network_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/acceptance"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/acceptance/check"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/clients"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/network/parse"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tf/pluginsdk"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+type VirtualNetworkPeeringPairResource struct {
+}
+
+func TestAccVirtualNetworkPeeringPair_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_virtual_network_peering_pair", "test")
+	r := VirtualNetworkPeeringPairResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("peering_state").HasValue("Connected"),
+				check.That(data.ResourceName).Key("local.0.allow_virtual_network_access").HasValue("true"),
+				check.That(data.ResourceName).Key("remote.0.allow_virtual_network_access").HasValue("true"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccVirtualNetworkPeeringPair_requiresImport(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_virtual_network_peering_pair", "test")
+	r := VirtualNetworkPeeringPairResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.RequiresImportErrorStep(r.requiresImport),
+	})
+}
+
+func TestAccVirtualNetworkPeeringPair_disappears(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_virtual_network_peering_pair", "test")
+	r := VirtualNetworkPeeringPairResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		data.DisappearsStep(acceptance.DisappearsStepData{
+			Config:       r.basic,
+			TestResource: r,
+		}),
+	})
+}
+
+func TestAccVirtualNetworkPeeringPair_update(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_virtual_network_peering_pair", "test")
+	r := VirtualNetworkPeeringPairResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.update(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("local.0.allow_forwarded_traffic").HasValue("true"),
+				check.That(data.ResourceName).Key("remote.0.allow_forwarded_traffic").HasValue("false"),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func (t VirtualNetworkPeeringPairResource) Exists(ctx context.Context, clients *clients.Client, state *pluginsdk.InstanceState) (*bool, error) {
+	id, err := parse.VirtualNetworkPeeringPairID(state.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, peeringId := range []parse.VirtualNetworkPeeringId{id.Local, id.Remote} {
+		resp, err := clients.Network.VnetPeeringsClientForSubscription(peeringId.SubscriptionId).Get(ctx, peeringId.ResourceGroup, peeringId.VirtualNetworkName, peeringId.Name)
+		if err != nil {
+			if utils.ResponseWasNotFound(resp.Response) {
+				return utils.Bool(false), nil
+			}
+			return nil, fmt.Errorf("retrieving %s: %+v", peeringId, err)
+		}
+	}
+
+	return utils.Bool(true), nil
+}
+
+func (r VirtualNetworkPeeringPairResource) Destroy(ctx context.Context, client *clients.Client, state *pluginsdk.InstanceState) (*bool, error) {
+	id, err := parse.VirtualNetworkPeeringPairID(state.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// removing one side is enough for the pair to be recreated
+	peeringsClient := client.Network.VnetPeeringsClientForSubscription(id.Remote.SubscriptionId)
+	future, err := peeringsClient.Delete(ctx, id.Remote.ResourceGroup, id.Remote.VirtualNetworkName, id.Remote.Name)
+	if err != nil {
+		return nil, fmt.Errorf("deleting %s: %+v", id.Remote, err)
+	}
+
+	if err = future.WaitForCompletionRef(ctx, peeringsClient.Client); err != nil {
+		return nil, fmt.Errorf("waiting for deletion of %s: %+v", id.Remote, err)
+	}
+
+	return utils.Bool(true), nil
+}
+
+func (VirtualNetworkPeeringPairResource) template(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%d"
+  location = "%s"
+}
+
+resource "azurerm_virtual_network" "test1" {
+  name                = "acctestvirtnet-1-%d"
+  resource_group_name = azurerm_resource_group.test.name
+  address_space       = ["10.0.1.0/24"]
+  location            = azurerm_resource_group.test.location
+}
+
+resource "azurerm_virtual_network" "test2" {
+  name                = "acctestvirtnet-2-%d"
+  resource_group_name = azurerm_resource_group.test.name
+  address_space       = ["10.0.2.0/24"]
+  location            = azurerm_resource_group.test.location
+}
+`, data.RandomInteger, data.Locations.Primary, data.RandomInteger, data.RandomInteger)
+}
+
+func (r VirtualNetworkPeeringPairResource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_virtual_network_peering_pair" "test" {
+  local {
+    name               = "acctestpeer-1-%d"
+    virtual_network_id = azurerm_virtual_network.test1.id
+  }
+
+  remote {
+    name               = "acctestpeer-2-%d"
+    virtual_network_id = azurerm_virtual_network.test2.id
+  }
+}
+`, r.template(data), data.RandomInteger, data.RandomInteger)
+}
+
+func (r VirtualNetworkPeeringPairResource) requiresImport(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_virtual_network_peering_pair" "import" {
+  local {
+    name               = azurerm_virtual_network_peering_pair.test.local.0.name
+    virtual_network_id = azurerm_virtual_network_peering_pair.test.local.0.virtual_network_id
+  }
+
+  remote {
+    name               = azurerm_virtual_network_peering_pair.test.remote.0.name
+    virtual_network_id = azurerm_virtual_network_peering_pair.test.remote.0.virtual_network_id
+  }
+}
+`, r.basic(data))
+}
+
+func (r VirtualNetworkPeeringPairResource) update(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_virtual_network_peering_pair" "test" {
+  local {
+    name                         = "acctestpeer-1-%d"
+    virtual_network_id           = azurerm_virtual_network.test1.id
+    allow_virtual_network_access = true
+    allow_forwarded_traffic      = true
+  }
+
+  remote {
+    name                         = "acctestpeer-2-%d"
+    virtual_network_id           = azurerm_virtual_network.test2.id
+    allow_virtual_network_access = false
+  }
+}
+`, r.template(data), data.RandomInteger, data.RandomInteger)
+}
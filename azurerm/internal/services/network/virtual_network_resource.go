@@ -22,8 +22,6 @@ import (
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
 )
 
-var VirtualNetworkResourceName = "azurerm_virtual_network"
-
 func resourceVirtualNetwork() *pluginsdk.Resource {
 	return &pluginsdk.Resource{
 		Create: resourceVirtualNetworkCreateUpdate,
@@ -201,6 +199,10 @@ func resourceVirtualNetworkCreateUpdate(d *pluginsdk.ResourceData, meta interfac
 	locks.MultipleByName(&networkSecurityGroupNames, networkSecurityGroupResourceName)
 	defer locks.UnlockMultipleByName(&networkSecurityGroupNames, networkSecurityGroupResourceName)
 
+	// changes to the Virtual Network itself can't run alongside operations on its Subnets
+	locks.ByResourceID(id)
+	defer locks.UnlockByResourceID(id)
+
 	future, err := client.CreateOrUpdate(ctx, id.ResourceGroup, id.Name, vnet)
 	if err != nil {
 		return fmt.Errorf("creating/updating %s: %+v", id, err)
@@ -290,8 +292,11 @@ func resourceVirtualNetworkDelete(d *pluginsdk.ResourceData, meta interface{}) e
 		return fmt.Errorf("Error parsing Network Security Group ID's: %+v", err)
 	}
 
-	locks.MultipleByName(&nsgNames, VirtualNetworkResourceName)
-	defer locks.UnlockMultipleByName(&nsgNames, VirtualNetworkResourceName)
+	locks.MultipleByName(&nsgNames, networkSecurityGroupResourceName)
+	defer locks.UnlockMultipleByName(&nsgNames, networkSecurityGroupResourceName)
+
+	locks.ByResourceID(id)
+	defer locks.UnlockByResourceID(id)
 
 	future, err := client.Delete(ctx, id.ResourceGroup, id.Name)
 	if err != nil {
@@ -8,7 +8,7 @@ import (
 	"strings"
 	"time"
 
-	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2020-12-01/compute"
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-03-01/compute"
 	"github.com/Azure/azure-sdk-for-go/services/recoveryservices/mgmt/2018-07-10/siterecovery"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/azure"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/tf"
@@ -145,10 +145,10 @@ func resourceSiteRecoveryReplicatedVM() *pluginsdk.Resource {
 							Required: true,
 							ForceNew: true,
 							ValidateFunc: validation.StringInSlice([]string{
-								string(compute.StandardLRS),
-								string(compute.PremiumLRS),
-								string(compute.StandardSSDLRS),
-								string(compute.UltraSSDLRS),
+								string(compute.DiskStorageAccountTypesStandardLRS),
+								string(compute.DiskStorageAccountTypesPremiumLRS),
+								string(compute.DiskStorageAccountTypesStandardSSDLRS),
+								string(compute.DiskStorageAccountTypesUltraSSDLRS),
 							}, true),
 							DiffSuppressFunc: suppress.CaseDifference,
 						},
@@ -157,10 +157,10 @@ func resourceSiteRecoveryReplicatedVM() *pluginsdk.Resource {
 							Required: true,
 							ForceNew: true,
 							ValidateFunc: validation.StringInSlice([]string{
-								string(compute.StandardLRS),
-								string(compute.PremiumLRS),
-								string(compute.StandardSSDLRS),
-								string(compute.UltraSSDLRS),
+								string(compute.DiskStorageAccountTypesStandardLRS),
+								string(compute.DiskStorageAccountTypesPremiumLRS),
+								string(compute.DiskStorageAccountTypesStandardSSDLRS),
+								string(compute.DiskStorageAccountTypesUltraSSDLRS),
 							}, true),
 							DiffSuppressFunc: suppress.CaseDifference,
 						},
@@ -16,7 +16,6 @@ import (
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/clients"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/location"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/locks"
-	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/network"
 	networkParse "github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/network/parse"
 	networkValidate "github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/network/validate"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/redis/parse"
@@ -373,11 +372,9 @@ func resourceRedisCacheCreate(d *pluginsdk.ResourceData, meta interface{}) error
 			return err
 		}
 
-		locks.ByName(parsed.VirtualNetworkName, network.VirtualNetworkResourceName)
-		defer locks.UnlockByName(parsed.VirtualNetworkName, network.VirtualNetworkResourceName)
-
-		locks.ByName(parsed.Name, network.SubnetResourceName)
-		defer locks.UnlockByName(parsed.Name, network.SubnetResourceName)
+		virtualNetworkId := networkParse.NewVirtualNetworkID(parsed.SubscriptionId, parsed.ResourceGroup, parsed.VirtualNetworkName)
+		locks.ChildByResourceID(virtualNetworkId, parsed)
+		defer locks.UnlockChildByResourceID(virtualNetworkId, parsed)
 
 		parameters.SubnetID = utils.String(v.(string))
 	}
@@ -633,11 +630,9 @@ func resourceRedisCacheDelete(d *pluginsdk.ResourceData, meta interface{}) error
 			return err
 		}
 
-		locks.ByName(parsed.VirtualNetworkName, network.VirtualNetworkResourceName)
-		defer locks.UnlockByName(parsed.VirtualNetworkName, network.VirtualNetworkResourceName)
-
-		locks.ByName(parsed.Name, network.SubnetResourceName)
-		defer locks.UnlockByName(parsed.Name, network.SubnetResourceName)
+		virtualNetworkId := networkParse.NewVirtualNetworkID(parsed.SubscriptionId, parsed.ResourceGroup, parsed.VirtualNetworkName)
+		locks.ChildByResourceID(virtualNetworkId, parsed)
+		defer locks.UnlockChildByResourceID(virtualNetworkId, parsed)
 	}
 
 	future, err := client.Delete(ctx, id.ResourceGroup, id.RediName)
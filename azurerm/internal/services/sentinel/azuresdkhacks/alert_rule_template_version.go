@@ -0,0 +1,54 @@
+package azuresdkhacks
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/Azure/azure-sdk-for-go/services/preview/securityinsight/mgmt/2019-01-01-preview/securityinsight"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/azure"
+)
+
+type alertRuleTemplateVersion struct {
+	Properties *struct {
+		Version *string `json:"version,omitempty"`
+	} `json:"properties,omitempty"`
+}
+
+// GetAlertRuleTemplateVersion returns the version of an Alert Rule Template, which is nil for templates that aren't versioned.
+func GetAlertRuleTemplateVersion(ctx context.Context, client *securityinsight.AlertRuleTemplatesClient, resourceGroup, operationalInsightsResourceProvider, workspaceName, name string) (*string, error) {
+	// NOTE: the `version` property is only returned by newer API versions than the one used by the SDK, so the
+	// request is prepared by the SDK and then sent using the newer API version
+	req, err := client.GetPreparer(ctx, resourceGroup, operationalInsightsResourceProvider, workspaceName, name)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "securityinsight.AlertRuleTemplatesClient", "Get", nil, "Failure preparing request")
+		return nil, fmt.Errorf("retrieving Alert Rule Template version: %+v", err)
+	}
+
+	query := req.URL.Query()
+	query.Set("api-version", "2021-09-01-preview")
+	req.URL.RawQuery = query.Encode()
+
+	resp, err := client.GetSender(req)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "securityinsight.AlertRuleTemplatesClient", "Get", resp, "Failure sending request")
+		return nil, fmt.Errorf("retrieving Alert Rule Template version: %+v", err)
+	}
+
+	var result alertRuleTemplateVersion
+	err = autorest.Respond(
+		resp,
+		azure.WithErrorUnlessStatusCode(http.StatusOK),
+		autorest.ByUnmarshallingJSON(&result),
+		autorest.ByClosing())
+	if err != nil {
+		return nil, fmt.Errorf("retrieving Alert Rule Template version: %+v", err)
+	}
+
+	if result.Properties == nil {
+		return nil, nil
+	}
+
+	return result.Properties.Version, nil
+}
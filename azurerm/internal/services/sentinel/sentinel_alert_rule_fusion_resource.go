@@ -11,6 +11,7 @@ import (
 	loganalyticsParse "github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/loganalytics/parse"
 	loganalyticsValidate "github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/loganalytics/validate"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/sentinel/parse"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/sentinel/validate"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tf/pluginsdk"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tf/validation"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/timeouts"
@@ -58,12 +59,25 @@ func resourceSentinelAlertRuleFusion() *pluginsdk.Resource {
 				ValidateFunc: validation.IsUUID,
 			},
 
+			"alert_rule_template_version": {
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				ValidateFunc: validate.AlertRuleTemplateVersion,
+			},
+
+			"latest_alert_rule_template_version": {
+				Type:     pluginsdk.TypeString,
+				Computed: true,
+			},
+
 			"enabled": {
 				Type:     pluginsdk.TypeBool,
 				Optional: true,
 				Default:  true,
 			},
 		},
+
+		CustomizeDiff: pluginsdk.CustomizeDiffShim(alertRuleTemplateVersionCustomizeDiff),
 	}
 }
 
@@ -158,6 +172,8 @@ func resourceSentinelAlertRuleFusionRead(d *pluginsdk.ResourceData, meta interfa
 	if prop := rule.FusionAlertRuleProperties; prop != nil {
 		d.Set("enabled", prop.Enabled)
 		d.Set("alert_rule_template_guid", prop.AlertRuleTemplateName)
+		// `alert_rule_template_version` isn't returned by the API, so the value from the configuration is kept
+		setAlertRuleTemplateLatestVersion(ctx, d, meta, workspaceId, prop.AlertRuleTemplateName)
 	}
 
 	return nil
@@ -11,6 +11,7 @@ import (
 	loganalyticsParse "github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/loganalytics/parse"
 	loganalyticsValidate "github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/loganalytics/validate"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/sentinel/parse"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/sentinel/validate"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tf/pluginsdk"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tf/validation"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/timeouts"
@@ -93,6 +94,18 @@ func resourceSentinelAlertRuleMsSecurityIncident() *pluginsdk.Resource {
 				ValidateFunc: validation.IsUUID,
 			},
 
+			"alert_rule_template_version": {
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				ValidateFunc: validate.AlertRuleTemplateVersion,
+				RequiredWith: []string{"alert_rule_template_guid"},
+			},
+
+			"latest_alert_rule_template_version": {
+				Type:     pluginsdk.TypeString,
+				Computed: true,
+			},
+
 			"description": {
 				Type:         pluginsdk.TypeString,
 				Optional:     true,
@@ -140,6 +153,8 @@ func resourceSentinelAlertRuleMsSecurityIncident() *pluginsdk.Resource {
 				},
 			},
 		},
+
+		CustomizeDiff: pluginsdk.CustomizeDiffShim(alertRuleTemplateVersionCustomizeDiff),
 	}
 }
 
@@ -252,6 +267,8 @@ func resourceSentinelAlertRuleMsSecurityIncidentRead(d *pluginsdk.ResourceData,
 		d.Set("description", prop.Description)
 		d.Set("enabled", prop.Enabled)
 		d.Set("alert_rule_template_guid", prop.AlertRuleTemplateName)
+		// `alert_rule_template_version` isn't returned by the API, so the value from the configuration is kept
+		setAlertRuleTemplateLatestVersion(ctx, d, meta, workspaceId, prop.AlertRuleTemplateName)
 
 		if err := d.Set("text_whitelist", utils.FlattenStringSlice(prop.DisplayNamesFilter)); err != nil {
 			return fmt.Errorf(`setting "text_whitelist": %+v`, err)
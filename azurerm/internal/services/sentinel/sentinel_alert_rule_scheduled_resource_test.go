@@ -106,6 +106,31 @@ func TestAccSentinelAlertRuleScheduled_withAlertRuleTemplateGuid(t *testing.T) {
 	})
 }
 
+func TestAccSentinelAlertRuleScheduled_withAlertRuleTemplateVersion(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_sentinel_alert_rule_scheduled", "test")
+	r := SentinelAlertRuleScheduledResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.alertRuleTemplateVersion(data, "1.0.0"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("alert_rule_template_version").HasValue("1.0.0"),
+				check.That(data.ResourceName).Key("latest_alert_rule_template_version").Exists(),
+			),
+		},
+		data.ImportStep("alert_rule_template_version"),
+		{
+			Config: r.alertRuleTemplateVersion(data, "1.0.1"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("alert_rule_template_version").HasValue("1.0.1"),
+			),
+		},
+		data.ImportStep("alert_rule_template_version"),
+	})
+}
+
 func TestAccSentinelAlertRuleScheduled_updateEventGroupingSetting(t *testing.T) {
 	data := acceptance.BuildTestData(t, "azurerm_sentinel_alert_rule_scheduled", "test")
 	r := SentinelAlertRuleScheduledResource{}
@@ -238,6 +263,27 @@ QUERY
 `, r.template(data), data.RandomInteger)
 }
 
+func (r SentinelAlertRuleScheduledResource) alertRuleTemplateVersion(data acceptance.TestData, version string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_sentinel_alert_rule_scheduled" "test" {
+  name                        = "acctest-SentinelAlertRule-Sche-%d"
+  log_analytics_workspace_id  = azurerm_log_analytics_solution.test.workspace_resource_id
+  display_name                = "Some Rule"
+  severity                    = "Low"
+  alert_rule_template_guid    = "65360bb0-8986-4ade-a89d-af3cf44d28aa"
+  alert_rule_template_version = "%s"
+  query                       = <<QUERY
+AzureActivity |
+  where OperationName == "Create or Update Virtual Machine" or OperationName =="Create Deployment" |
+  where ActivityStatus == "Succeeded" |
+  make-series dcount(ResourceId) default=0 on EventSubmissionTimestamp in range(ago(7d), now(), 1d) by Caller
+QUERY
+}
+`, r.template(data), data.RandomInteger, version)
+}
+
 func (r SentinelAlertRuleScheduledResource) eventGroupingSetting(data acceptance.TestData) string {
 	return fmt.Sprintf(`
 %s
@@ -0,0 +1,134 @@
+package sentinel
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/clients"
+	loganalyticsParse "github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/loganalytics/parse"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/sentinel/azuresdkhacks"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tf/pluginsdk"
+)
+
+// alertRuleTemplateVersionCustomizeDiff exposes the latest version of the Alert Rule Template which an Alert Rule
+// was built from in `latest_alert_rule_template_version`, so that updates to the template show up in the plan.
+func alertRuleTemplateVersionCustomizeDiff(ctx context.Context, d *pluginsdk.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("alert_rule_template_guid") || !d.NewValueKnown("log_analytics_workspace_id") {
+		return nil
+	}
+
+	templateName := d.Get("alert_rule_template_guid").(string)
+	if templateName == "" {
+		return nil
+	}
+
+	workspaceId, err := loganalyticsParse.LogAnalyticsWorkspaceID(d.Get("log_analytics_workspace_id").(string))
+	if err != nil {
+		return err
+	}
+
+	latestVersion, err := alertRuleTemplateLatestVersion(ctx, meta, *workspaceId, templateName)
+	if err != nil {
+		// the template may not be available yet (e.g. when the workspace is being created), which shouldn't block the plan
+		log.Printf("[DEBUG] %+v", err)
+		return nil
+	}
+	if latestVersion == "" {
+		return nil
+	}
+
+	if latestVersion != d.Get("latest_alert_rule_template_version").(string) {
+		if err := d.SetNew("latest_alert_rule_template_version", latestVersion); err != nil {
+			return fmt.Errorf("setting `latest_alert_rule_template_version`: %+v", err)
+		}
+	}
+
+	version := d.Get("alert_rule_template_version").(string)
+	if version == "" || !d.NewValueKnown("alert_rule_template_version") {
+		return nil
+	}
+
+	newer, err := alertRuleTemplateVersionIsNewer(latestVersion, version)
+	if err != nil {
+		log.Printf("[DEBUG] unable to compare the versions of Sentinel Alert Rule Template %q: %+v", templateName, err)
+		return nil
+	}
+
+	if newer {
+		log.Printf("[WARN] Sentinel Alert Rule Template %q (Workspace %q / Resource Group %q) is at version %q, which is newer than the `alert_rule_template_version` %q this Alert Rule was built from", templateName, workspaceId.WorkspaceName, workspaceId.ResourceGroup, latestVersion, version)
+	}
+
+	return nil
+}
+
+// setAlertRuleTemplateLatestVersion refreshes `latest_alert_rule_template_version` from the Alert Rule Template
+// which an Alert Rule was built from - a template which can't be retrieved doesn't fail the read.
+func setAlertRuleTemplateLatestVersion(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}, workspaceId loganalyticsParse.LogAnalyticsWorkspaceId, templateName *string) {
+	latestVersion := ""
+	if templateName != nil && *templateName != "" {
+		v, err := alertRuleTemplateLatestVersion(ctx, meta, workspaceId, *templateName)
+		if err != nil {
+			log.Printf("[DEBUG] %+v", err)
+		}
+		latestVersion = v
+	}
+	d.Set("latest_alert_rule_template_version", latestVersion)
+}
+
+func alertRuleTemplateLatestVersion(ctx context.Context, meta interface{}, workspaceId loganalyticsParse.LogAnalyticsWorkspaceId, templateName string) (string, error) {
+	client := meta.(*clients.Client).Sentinel.AlertRuleTemplatesClient
+	version, err := azuresdkhacks.GetAlertRuleTemplateVersion(ctx, client, workspaceId.ResourceGroup, OperationalInsightsResourceProvider, workspaceId.WorkspaceName, templateName)
+	if err != nil {
+		return "", fmt.Errorf("retrieving the version of Sentinel Alert Rule Template %q (Workspace %q / Resource Group %q): %+v", templateName, workspaceId.WorkspaceName, workspaceId.ResourceGroup, err)
+	}
+	if version == nil {
+		return "", nil
+	}
+
+	return *version, nil
+}
+
+// alertRuleTemplateVersionIsNewer returns whether the dotted version `latest` is greater than `current`, where
+// missing components are treated as zero (e.g. `1.0` and `1.0.0` are equal).
+func alertRuleTemplateVersionIsNewer(latest, current string) (bool, error) {
+	latestParts, err := parseAlertRuleTemplateVersion(latest)
+	if err != nil {
+		return false, err
+	}
+
+	currentParts, err := parseAlertRuleTemplateVersion(current)
+	if err != nil {
+		return false, err
+	}
+
+	for len(latestParts) < len(currentParts) {
+		latestParts = append(latestParts, 0)
+	}
+	for len(currentParts) < len(latestParts) {
+		currentParts = append(currentParts, 0)
+	}
+
+	for i := range latestParts {
+		if latestParts[i] != currentParts[i] {
+			return latestParts[i] > currentParts[i], nil
+		}
+	}
+
+	return false, nil
+}
+
+func parseAlertRuleTemplateVersion(input string) ([]int, error) {
+	output := make([]int, 0)
+	for _, part := range strings.Split(input, ".") {
+		v, err := strconv.Atoi(part)
+		if err != nil || v < 0 {
+			return nil, fmt.Errorf("parsing version %q: %q is not a non-negative number", input, part)
+		}
+		output = append(output, v)
+	}
+
+	return output, nil
+}
@@ -0,0 +1,43 @@
+package sentinel
+
+import "testing"
+
+func TestAlertRuleTemplateVersionIsNewer(t *testing.T) {
+	testData := []struct {
+		Latest   string
+		Current  string
+		Expected bool
+		Error    bool
+	}{
+		{Latest: "1.0.0", Current: "1.0.0", Expected: false},
+		{Latest: "1.0.1", Current: "1.0.0", Expected: true},
+		{Latest: "1.0.0", Current: "1.0.1", Expected: false},
+		{Latest: "1.10.0", Current: "1.9.0", Expected: true},
+		{Latest: "2", Current: "1.9.9", Expected: true},
+		{Latest: "1.0", Current: "1.0.0", Expected: false},
+		{Latest: "1.0.0.1", Current: "1.0", Expected: true},
+		{Latest: "1.0.a", Current: "1.0.0", Error: true},
+		{Latest: "1.0.0", Current: "", Error: true},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q against %q", v.Latest, v.Current)
+
+		actual, err := alertRuleTemplateVersionIsNewer(v.Latest, v.Current)
+		if err != nil {
+			if v.Error {
+				continue
+			}
+
+			t.Fatalf("Expected no error but got: %+v", err)
+		}
+
+		if v.Error {
+			t.Fatalf("Expected an error but didn't get one")
+		}
+
+		if actual != v.Expected {
+			t.Fatalf("Expected %t but got %t", v.Expected, actual)
+		}
+	}
+}
@@ -0,0 +1,619 @@
+package sentinel
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/preview/securityinsight/mgmt/2019-01-01-preview/securityinsight"
+	"github.com/Azure/go-autorest/autorest/date"
+	"github.com/gofrs/uuid"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/azure"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/tf"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/clients"
+	loganalyticsParse "github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/loganalytics/parse"
+	loganalyticsValidate "github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/loganalytics/validate"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/sentinel/parse"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tf/pluginsdk"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tf/suppress"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tf/validation"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/timeouts"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+// the classification and its reason are exposed as a single value, as only these combinations are accepted by the API
+var sentinelAutomationRuleClassifications = map[string]struct {
+	classification securityinsight.IncidentClassification
+	reason         securityinsight.IncidentClassificationReason
+}{
+	string(securityinsight.IncidentClassificationUndetermined): {
+		classification: securityinsight.IncidentClassificationUndetermined,
+	},
+	"TruePositive_SuspiciousActivity": {
+		classification: securityinsight.IncidentClassificationTruePositive,
+		reason:         securityinsight.IncidentClassificationReasonSuspiciousActivity,
+	},
+	"BenignPositive_SuspiciousButExpected": {
+		classification: securityinsight.IncidentClassificationBenignPositive,
+		reason:         securityinsight.IncidentClassificationReasonSuspiciousButExpected,
+	},
+	"FalsePositive_IncorrectAlertLogic": {
+		classification: securityinsight.IncidentClassificationFalsePositive,
+		reason:         securityinsight.IncidentClassificationReasonIncorrectAlertLogic,
+	},
+	"FalsePositive_InaccurateData": {
+		classification: securityinsight.IncidentClassificationFalsePositive,
+		reason:         securityinsight.IncidentClassificationReasonInaccurateData,
+	},
+}
+
+func resourceSentinelAutomationRule() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		Create: resourceSentinelAutomationRuleCreateUpdate,
+		Read:   resourceSentinelAutomationRuleRead,
+		Update: resourceSentinelAutomationRuleCreateUpdate,
+		Delete: resourceSentinelAutomationRuleDelete,
+
+		Importer: pluginsdk.ImporterValidatingResourceId(func(id string) error {
+			_, err := parse.AutomationRuleID(id)
+			return err
+		}),
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Create: pluginsdk.DefaultTimeout(30 * time.Minute),
+			Read:   pluginsdk.DefaultTimeout(5 * time.Minute),
+			Update: pluginsdk.DefaultTimeout(30 * time.Minute),
+			Delete: pluginsdk.DefaultTimeout(30 * time.Minute),
+		},
+
+		Schema: map[string]*pluginsdk.Schema{
+			"name": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IsUUID,
+			},
+
+			"log_analytics_workspace_id": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: loganalyticsValidate.LogAnalyticsWorkspaceID,
+			},
+
+			"display_name": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"order": {
+				Type:         pluginsdk.TypeInt,
+				Required:     true,
+				ValidateFunc: validation.IntBetween(1, 1000),
+			},
+
+			"enabled": {
+				Type:     pluginsdk.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+
+			"expiration": {
+				Type:             pluginsdk.TypeString,
+				Optional:         true,
+				ValidateFunc:     validation.IsRFC3339Time,
+				DiffSuppressFunc: suppress.RFC3339Time,
+			},
+
+			"condition": {
+				Type:     pluginsdk.TypeList,
+				Optional: true,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"property": {
+							Type:         pluginsdk.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringInSlice(sentinelAutomationRuleConditionProperties(), false),
+						},
+
+						"operator": {
+							Type:     pluginsdk.TypeString,
+							Required: true,
+							ValidateFunc: validation.StringInSlice([]string{
+								string(securityinsight.AutomationRulePropertyConditionSupportedOperatorEquals),
+								string(securityinsight.AutomationRulePropertyConditionSupportedOperatorNotEquals),
+								string(securityinsight.AutomationRulePropertyConditionSupportedOperatorContains),
+								string(securityinsight.AutomationRulePropertyConditionSupportedOperatorNotContains),
+								string(securityinsight.AutomationRulePropertyConditionSupportedOperatorStartsWith),
+								string(securityinsight.AutomationRulePropertyConditionSupportedOperatorNotStartsWith),
+								string(securityinsight.AutomationRulePropertyConditionSupportedOperatorEndsWith),
+								string(securityinsight.AutomationRulePropertyConditionSupportedOperatorNotEndsWith),
+							}, false),
+						},
+
+						"values": {
+							Type:     pluginsdk.TypeList,
+							Required: true,
+							Elem: &pluginsdk.Schema{
+								Type:         pluginsdk.TypeString,
+								ValidateFunc: validation.StringIsNotEmpty,
+							},
+						},
+					},
+				},
+			},
+
+			"action_incident": {
+				Type:         pluginsdk.TypeList,
+				Optional:     true,
+				AtLeastOneOf: []string{"action_incident", "action_playbook"},
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"order": {
+							Type:         pluginsdk.TypeInt,
+							Required:     true,
+							ValidateFunc: validation.IntAtLeast(1),
+						},
+
+						"status": {
+							Type:     pluginsdk.TypeString,
+							Optional: true,
+							ValidateFunc: validation.StringInSlice([]string{
+								string(securityinsight.IncidentStatusActive),
+								string(securityinsight.IncidentStatusClosed),
+								string(securityinsight.IncidentStatusNew),
+							}, false),
+						},
+
+						"classification": {
+							Type:         pluginsdk.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.StringInSlice(sentinelAutomationRuleClassificationValues(), false),
+						},
+
+						"classification_comment": {
+							Type:         pluginsdk.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
+
+						"labels": {
+							Type:     pluginsdk.TypeList,
+							Optional: true,
+							Elem: &pluginsdk.Schema{
+								Type:         pluginsdk.TypeString,
+								ValidateFunc: validation.StringIsNotEmpty,
+							},
+						},
+
+						"owner_id": {
+							Type:         pluginsdk.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.IsUUID,
+						},
+
+						"severity": {
+							Type:     pluginsdk.TypeString,
+							Optional: true,
+							ValidateFunc: validation.StringInSlice([]string{
+								string(securityinsight.IncidentSeverityHigh),
+								string(securityinsight.IncidentSeverityInformational),
+								string(securityinsight.IncidentSeverityLow),
+								string(securityinsight.IncidentSeverityMedium),
+							}, false),
+						},
+					},
+				},
+			},
+
+			"action_playbook": {
+				Type:         pluginsdk.TypeList,
+				Optional:     true,
+				AtLeastOneOf: []string{"action_incident", "action_playbook"},
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"logic_app_id": {
+							Type:         pluginsdk.TypeString,
+							Required:     true,
+							ValidateFunc: azure.ValidateResourceID,
+						},
+
+						"order": {
+							Type:         pluginsdk.TypeInt,
+							Required:     true,
+							ValidateFunc: validation.IntAtLeast(1),
+						},
+
+						"tenant_id": {
+							Type:         pluginsdk.TypeString,
+							Optional:     true,
+							Computed:     true,
+							ValidateFunc: validation.IsUUID,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceSentinelAutomationRuleCreateUpdate(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Sentinel.AutomationRulesClient
+	tenantId := meta.(*clients.Client).Account.TenantId
+	ctx, cancel := timeouts.ForCreateUpdate(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	workspaceId, err := loganalyticsParse.LogAnalyticsWorkspaceID(d.Get("log_analytics_workspace_id").(string))
+	if err != nil {
+		return err
+	}
+	id := parse.NewAutomationRuleID(workspaceId.SubscriptionId, workspaceId.ResourceGroup, workspaceId.WorkspaceName, d.Get("name").(string))
+
+	if d.IsNewResource() {
+		existing, err := client.Get(ctx, id.ResourceGroup, OperationalInsightsResourceProvider, id.WorkspaceName, id.Name)
+		if err != nil {
+			if !utils.ResponseWasNotFound(existing.Response) {
+				return fmt.Errorf("checking for presence of existing %s: %+v", id, err)
+			}
+		}
+		if !utils.ResponseWasNotFound(existing.Response) {
+			return tf.ImportAsExistsError("azurerm_sentinel_automation_rule", id.ID())
+		}
+	}
+
+	actions, err := expandSentinelAutomationRuleActions(d.Get("action_incident").([]interface{}), d.Get("action_playbook").([]interface{}), tenantId)
+	if err != nil {
+		return err
+	}
+
+	param := securityinsight.AutomationRule{
+		AutomationRuleProperties: &securityinsight.AutomationRuleProperties{
+			DisplayName: utils.String(d.Get("display_name").(string)),
+			Order:       utils.Int32(int32(d.Get("order").(int))),
+			TriggeringLogic: &securityinsight.AutomationRuleTriggeringLogic{
+				IsEnabled:    utils.Bool(d.Get("enabled").(bool)),
+				TriggersOn:   utils.String("Incidents"),
+				TriggersWhen: utils.String("Created"),
+				Conditions:   expandSentinelAutomationRuleConditions(d.Get("condition").([]interface{})),
+			},
+			Actions: actions,
+		},
+	}
+
+	if v, ok := d.GetOk("expiration"); ok {
+		expiration, _ := time.Parse(time.RFC3339, v.(string)) // validated by the schema
+		param.AutomationRuleProperties.TriggeringLogic.ExpirationTimeUtc = &date.Time{Time: expiration}
+	}
+
+	if _, err := client.CreateOrUpdate(ctx, id.ResourceGroup, OperationalInsightsResourceProvider, id.WorkspaceName, id.Name, param); err != nil {
+		return fmt.Errorf("creating/updating %s: %+v", id, err)
+	}
+
+	d.SetId(id.ID())
+
+	return resourceSentinelAutomationRuleRead(d, meta)
+}
+
+func resourceSentinelAutomationRuleRead(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Sentinel.AutomationRulesClient
+	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := parse.AutomationRuleID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.Get(ctx, id.ResourceGroup, OperationalInsightsResourceProvider, id.WorkspaceName, id.Name)
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			log.Printf("[DEBUG] %s was not found - removing from state!", id)
+			d.SetId("")
+			return nil
+		}
+
+		return fmt.Errorf("retrieving %s: %+v", id, err)
+	}
+
+	d.Set("name", id.Name)
+	d.Set("log_analytics_workspace_id", loganalyticsParse.NewLogAnalyticsWorkspaceID(id.SubscriptionId, id.ResourceGroup, id.WorkspaceName).ID())
+
+	if props := resp.AutomationRuleProperties; props != nil {
+		d.Set("display_name", props.DisplayName)
+
+		order := 0
+		if props.Order != nil {
+			order = int(*props.Order)
+		}
+		d.Set("order", order)
+
+		enabled := false
+		expiration := ""
+		var conditions []interface{}
+		if logic := props.TriggeringLogic; logic != nil {
+			if logic.IsEnabled != nil {
+				enabled = *logic.IsEnabled
+			}
+			if logic.ExpirationTimeUtc != nil {
+				expiration = logic.ExpirationTimeUtc.Format(time.RFC3339)
+			}
+			conditions = flattenSentinelAutomationRuleConditions(logic.Conditions)
+		}
+		d.Set("enabled", enabled)
+		d.Set("expiration", expiration)
+		if err := d.Set("condition", conditions); err != nil {
+			return fmt.Errorf("setting `condition`: %+v", err)
+		}
+
+		actionIncident, actionPlaybook := flattenSentinelAutomationRuleActions(props.Actions)
+		if err := d.Set("action_incident", actionIncident); err != nil {
+			return fmt.Errorf("setting `action_incident`: %+v", err)
+		}
+		if err := d.Set("action_playbook", actionPlaybook); err != nil {
+			return fmt.Errorf("setting `action_playbook`: %+v", err)
+		}
+	}
+
+	return nil
+}
+
+func resourceSentinelAutomationRuleDelete(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Sentinel.AutomationRulesClient
+	ctx, cancel := timeouts.ForDelete(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := parse.AutomationRuleID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	if _, err := client.Delete(ctx, id.ResourceGroup, OperationalInsightsResourceProvider, id.WorkspaceName, id.Name); err != nil {
+		return fmt.Errorf("deleting %s: %+v", id, err)
+	}
+
+	return nil
+}
+
+func sentinelAutomationRuleConditionProperties() []string {
+	output := make([]string, 0)
+	for _, v := range securityinsight.PossibleAutomationRulePropertyConditionSupportedPropertyValues() {
+		output = append(output, string(v))
+	}
+	return output
+}
+
+func sentinelAutomationRuleClassificationValues() []string {
+	output := make([]string, 0)
+	for k := range sentinelAutomationRuleClassifications {
+		output = append(output, k)
+	}
+	sort.Strings(output)
+	return output
+}
+
+func expandSentinelAutomationRuleConditions(input []interface{}) *[]securityinsight.BasicAutomationRuleCondition {
+	output := make([]securityinsight.BasicAutomationRuleCondition, 0)
+
+	for _, item := range input {
+		v := item.(map[string]interface{})
+		output = append(output, securityinsight.AutomationRulePropertyValuesCondition{
+			ConditionType: securityinsight.ConditionTypeProperty,
+			ConditionProperties: &securityinsight.AutomationRulePropertyValuesConditionConditionProperties{
+				PropertyName:   securityinsight.AutomationRulePropertyConditionSupportedProperty(v["property"].(string)),
+				Operator:       securityinsight.AutomationRulePropertyConditionSupportedOperator(v["operator"].(string)),
+				PropertyValues: utils.ExpandStringSlice(v["values"].([]interface{})),
+			},
+		})
+	}
+
+	return &output
+}
+
+func flattenSentinelAutomationRuleConditions(input *[]securityinsight.BasicAutomationRuleCondition) []interface{} {
+	if input == nil {
+		return []interface{}{}
+	}
+
+	output := make([]interface{}, 0)
+	for _, item := range *input {
+		condition, ok := item.AsAutomationRulePropertyValuesCondition()
+		if !ok || condition.ConditionProperties == nil {
+			continue
+		}
+
+		props := condition.ConditionProperties
+		output = append(output, map[string]interface{}{
+			"property": string(props.PropertyName),
+			"operator": string(props.Operator),
+			"values":   utils.FlattenStringSlice(props.PropertyValues),
+		})
+	}
+
+	return output
+}
+
+func expandSentinelAutomationRuleActions(incidentActions []interface{}, playbookActions []interface{}, defaultTenantId string) (*[]securityinsight.BasicAutomationRuleAction, error) {
+	output := make([]securityinsight.BasicAutomationRuleAction, 0)
+
+	for _, item := range incidentActions {
+		v := item.(map[string]interface{})
+
+		status := securityinsight.IncidentStatus(v["status"].(string))
+		config := &securityinsight.AutomationRuleModifyPropertiesActionActionConfiguration{
+			Status:   status,
+			Severity: securityinsight.IncidentSeverity(v["severity"].(string)),
+		}
+
+		classification := v["classification"].(string)
+		classificationComment := v["classification_comment"].(string)
+		if status == securityinsight.IncidentStatusClosed {
+			if classification == "" {
+				return nil, fmt.Errorf("`classification` must be specified when `status` is `%s`", securityinsight.IncidentStatusClosed)
+			}
+		} else if classification != "" || classificationComment != "" {
+			return nil, fmt.Errorf("`classification` and `classification_comment` can only be specified when `status` is `%s`", securityinsight.IncidentStatusClosed)
+		}
+		if classification != "" {
+			config.Classification = sentinelAutomationRuleClassifications[classification].classification
+			config.ClassificationReason = sentinelAutomationRuleClassifications[classification].reason
+		}
+		if classificationComment != "" {
+			config.ClassificationComment = utils.String(classificationComment)
+		}
+
+		if labels := v["labels"].([]interface{}); len(labels) > 0 {
+			incidentLabels := make([]securityinsight.IncidentLabel, 0)
+			for _, label := range labels {
+				incidentLabels = append(incidentLabels, securityinsight.IncidentLabel{
+					LabelName: utils.String(label.(string)),
+				})
+			}
+			config.Labels = &incidentLabels
+		}
+
+		if ownerId := v["owner_id"].(string); ownerId != "" {
+			objectId, err := uuid.FromString(ownerId)
+			if err != nil {
+				return nil, fmt.Errorf("parsing `owner_id` %q: %+v", ownerId, err)
+			}
+			config.Owner = &securityinsight.IncidentOwnerInfo{
+				ObjectID: &objectId,
+			}
+		}
+
+		if config.Status == "" && config.Severity == "" && config.Labels == nil && config.Owner == nil {
+			return nil, fmt.Errorf("at least one of `status`, `severity`, `labels` or `owner_id` must be specified in an `action_incident` block")
+		}
+
+		output = append(output, securityinsight.AutomationRuleModifyPropertiesAction{
+			ActionType:          securityinsight.ActionTypeModifyProperties,
+			Order:               utils.Int32(int32(v["order"].(int))),
+			ActionConfiguration: config,
+		})
+	}
+
+	for _, item := range playbookActions {
+		v := item.(map[string]interface{})
+
+		tenantId := v["tenant_id"].(string)
+		if tenantId == "" {
+			tenantId = defaultTenantId
+		}
+
+		output = append(output, securityinsight.AutomationRuleRunPlaybookAction{
+			ActionType: securityinsight.ActionTypeRunPlaybook,
+			Order:      utils.Int32(int32(v["order"].(int))),
+			ActionConfiguration: &securityinsight.AutomationRuleRunPlaybookActionActionConfiguration{
+				LogicAppResourceID: utils.String(v["logic_app_id"].(string)),
+				TenantID:           utils.String(tenantId),
+			},
+		})
+	}
+
+	// the order of the actions must be unique across both kinds of action
+	orders := make(map[int32]struct{})
+	for _, action := range output {
+		var order *int32
+		switch action := action.(type) {
+		case securityinsight.AutomationRuleModifyPropertiesAction:
+			order = action.Order
+		case securityinsight.AutomationRuleRunPlaybookAction:
+			order = action.Order
+		}
+		if order == nil {
+			continue
+		}
+
+		if _, exists := orders[*order]; exists {
+			return nil, fmt.Errorf("the `order` of each `action_incident` and `action_playbook` block must be unique, but %d is used more than once", *order)
+		}
+		orders[*order] = struct{}{}
+	}
+
+	return &output, nil
+}
+
+func flattenSentinelAutomationRuleActions(input *[]securityinsight.BasicAutomationRuleAction) ([]interface{}, []interface{}) {
+	incidentActions := make([]interface{}, 0)
+	playbookActions := make([]interface{}, 0)
+	if input == nil {
+		return incidentActions, playbookActions
+	}
+
+	for _, item := range *input {
+		switch action := item.(type) {
+		case securityinsight.AutomationRuleModifyPropertiesAction:
+			order := 0
+			if action.Order != nil {
+				order = int(*action.Order)
+			}
+
+			var status, severity, classification, classificationComment, ownerId string
+			labels := make([]interface{}, 0)
+			if config := action.ActionConfiguration; config != nil {
+				status = string(config.Status)
+				severity = string(config.Severity)
+
+				if config.Classification != "" {
+					classification = string(config.Classification)
+					if config.ClassificationReason != "" {
+						classification = strings.Join([]string{string(config.Classification), string(config.ClassificationReason)}, "_")
+					}
+				}
+
+				if config.ClassificationComment != nil {
+					classificationComment = *config.ClassificationComment
+				}
+
+				if config.Labels != nil {
+					for _, label := range *config.Labels {
+						if label.LabelName != nil {
+							labels = append(labels, *label.LabelName)
+						}
+					}
+				}
+
+				if config.Owner != nil && config.Owner.ObjectID != nil {
+					ownerId = config.Owner.ObjectID.String()
+				}
+			}
+
+			incidentActions = append(incidentActions, map[string]interface{}{
+				"order":                  order,
+				"status":                 status,
+				"classification":         classification,
+				"classification_comment": classificationComment,
+				"labels":                 labels,
+				"owner_id":               ownerId,
+				"severity":               severity,
+			})
+
+		case securityinsight.AutomationRuleRunPlaybookAction:
+			order := 0
+			if action.Order != nil {
+				order = int(*action.Order)
+			}
+
+			var logicAppId, tenantId string
+			if config := action.ActionConfiguration; config != nil {
+				if config.LogicAppResourceID != nil {
+					logicAppId = *config.LogicAppResourceID
+				}
+				if config.TenantID != nil {
+					tenantId = *config.TenantID
+				}
+			}
+
+			playbookActions = append(playbookActions, map[string]interface{}{
+				"logic_app_id": logicAppId,
+				"order":        order,
+				"tenant_id":    tenantId,
+			})
+		}
+	}
+
+	return incidentActions, playbookActions
+}
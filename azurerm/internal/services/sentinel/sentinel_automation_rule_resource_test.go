@@ -0,0 +1,239 @@
+package sentinel_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/acceptance"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/acceptance/check"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/clients"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/sentinel/parse"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tf/pluginsdk"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+type SentinelAutomationRuleResource struct {
+	uuid string
+}
+
+func TestAccSentinelAutomationRule_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_sentinel_automation_rule", "test")
+	r := SentinelAutomationRuleResource{uuid: "5fc0ea15-2b4f-4dd0-9a22-d03f1a0bcdb2"}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccSentinelAutomationRule_complete(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_sentinel_automation_rule", "test")
+	r := SentinelAutomationRuleResource{uuid: "5fc0ea15-2b4f-4dd0-9a22-d03f1a0bcdb2"}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.complete(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccSentinelAutomationRule_update(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_sentinel_automation_rule", "test")
+	r := SentinelAutomationRuleResource{uuid: "5fc0ea15-2b4f-4dd0-9a22-d03f1a0bcdb2"}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.complete(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccSentinelAutomationRule_closeIncident(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_sentinel_automation_rule", "test")
+	r := SentinelAutomationRuleResource{uuid: "5fc0ea15-2b4f-4dd0-9a22-d03f1a0bcdb2"}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.closeIncident(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("action_incident.0.classification").HasValue("BenignPositive_SuspiciousButExpected"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccSentinelAutomationRule_requiresImport(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_sentinel_automation_rule", "test")
+	r := SentinelAutomationRuleResource{uuid: "5fc0ea15-2b4f-4dd0-9a22-d03f1a0bcdb2"}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.RequiresImportErrorStep(r.requiresImport),
+	})
+}
+
+func (r SentinelAutomationRuleResource) Exists(ctx context.Context, clients *clients.Client, state *pluginsdk.InstanceState) (*bool, error) {
+	id, err := parse.AutomationRuleID(state.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := clients.Sentinel.AutomationRulesClient.Get(ctx, id.ResourceGroup, "Microsoft.OperationalInsights", id.WorkspaceName, id.Name)
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			return utils.Bool(false), nil
+		}
+		return nil, fmt.Errorf("retrieving %s: %+v", id, err)
+	}
+
+	return utils.Bool(resp.ID != nil), nil
+}
+
+func (r SentinelAutomationRuleResource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_sentinel_automation_rule" "test" {
+  name                       = "%s"
+  log_analytics_workspace_id = azurerm_log_analytics_solution.test.workspace_resource_id
+  display_name               = "acctest-SentinelAutoRule-%d"
+  order                      = 1
+
+  action_incident {
+    order  = 1
+    status = "Active"
+  }
+}
+`, r.template(data), r.uuid, data.RandomInteger)
+}
+
+func (r SentinelAutomationRuleResource) complete(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+data "azurerm_client_config" "current" {}
+
+resource "azurerm_logic_app_workflow" "test" {
+  name                = "acctestlaw-%d"
+  location            = azurerm_resource_group.test.location
+  resource_group_name = azurerm_resource_group.test.name
+}
+
+resource "azurerm_sentinel_automation_rule" "test" {
+  name                       = "%s"
+  log_analytics_workspace_id = azurerm_log_analytics_solution.test.workspace_resource_id
+  display_name               = "acctest-SentinelAutoRule-%d-update"
+  order                      = 2
+  enabled                    = false
+  expiration                 = "%s"
+
+  condition {
+    property = "IncidentTitle"
+    operator = "Contains"
+    values   = ["a", "b"]
+  }
+
+  condition {
+    property = "IncidentSeverity"
+    operator = "Equals"
+    values   = ["High"]
+  }
+
+  action_incident {
+    order    = 1
+    severity = "Medium"
+    labels   = ["foo", "bar"]
+    owner_id = data.azurerm_client_config.current.object_id
+  }
+
+  action_playbook {
+    order        = 2
+    logic_app_id = azurerm_logic_app_workflow.test.id
+  }
+}
+`, r.template(data), data.RandomInteger, r.uuid, data.RandomInteger, time.Now().UTC().Add(time.Hour*24).Format(time.RFC3339))
+}
+
+func (r SentinelAutomationRuleResource) closeIncident(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_sentinel_automation_rule" "test" {
+  name                       = "%s"
+  log_analytics_workspace_id = azurerm_log_analytics_solution.test.workspace_resource_id
+  display_name               = "acctest-SentinelAutoRule-%d"
+  order                      = 1
+
+  condition {
+    property = "IncidentProviderName"
+    operator = "Equals"
+    values   = ["Azure Security Center"]
+  }
+
+  action_incident {
+    order                  = 1
+    status                 = "Closed"
+    classification         = "BenignPositive_SuspiciousButExpected"
+    classification_comment = "closed by automation"
+  }
+}
+`, r.template(data), r.uuid, data.RandomInteger)
+}
+
+func (r SentinelAutomationRuleResource) requiresImport(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_sentinel_automation_rule" "import" {
+  name                       = azurerm_sentinel_automation_rule.test.name
+  log_analytics_workspace_id = azurerm_sentinel_automation_rule.test.log_analytics_workspace_id
+  display_name               = azurerm_sentinel_automation_rule.test.display_name
+  order                      = azurerm_sentinel_automation_rule.test.order
+
+  action_incident {
+    order  = 1
+    status = "Active"
+  }
+}
+`, r.basic(data))
+}
+
+func (r SentinelAutomationRuleResource) template(data acceptance.TestData) string {
+	return SentinelWatchlistResource{}.template(data)
+}
@@ -0,0 +1,181 @@
+package sentinel
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/preview/securityinsight/mgmt/2019-01-01-preview/securityinsight"
+	"github.com/hashicorp/go-uuid"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/tf"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/clients"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/sentinel/parse"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/sentinel/validate"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tf/pluginsdk"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tf/validation"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/timeouts"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+func resourceSentinelWatchlistItem() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		Create: resourceSentinelWatchlistItemCreateUpdate,
+		Read:   resourceSentinelWatchlistItemRead,
+		Update: resourceSentinelWatchlistItemCreateUpdate,
+		Delete: resourceSentinelWatchlistItemDelete,
+
+		Importer: pluginsdk.ImporterValidatingResourceId(func(id string) error {
+			_, err := parse.WatchlistItemID(id)
+			return err
+		}),
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Create: pluginsdk.DefaultTimeout(30 * time.Minute),
+			Read:   pluginsdk.DefaultTimeout(5 * time.Minute),
+			Update: pluginsdk.DefaultTimeout(30 * time.Minute),
+			Delete: pluginsdk.DefaultTimeout(30 * time.Minute),
+		},
+
+		Schema: map[string]*pluginsdk.Schema{
+			"name": {
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IsUUID,
+			},
+
+			"watchlist_id": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validate.WatchlistID,
+			},
+
+			"properties": {
+				Type:     pluginsdk.TypeMap,
+				Required: true,
+				Elem: &pluginsdk.Schema{
+					Type:         pluginsdk.TypeString,
+					ValidateFunc: validation.StringIsNotEmpty,
+				},
+			},
+		},
+	}
+}
+
+func resourceSentinelWatchlistItemCreateUpdate(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Sentinel.WatchlistItemsClient
+	ctx, cancel := timeouts.ForCreateUpdate(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	watchlistId, err := parse.WatchlistID(d.Get("watchlist_id").(string))
+	if err != nil {
+		return err
+	}
+
+	name := d.Get("name").(string)
+	if name == "" {
+		name, err = uuid.GenerateUUID()
+		if err != nil {
+			return fmt.Errorf("generating UUID for Sentinel Watchlist Item: %+v", err)
+		}
+	}
+	id := parse.NewWatchlistItemID(watchlistId.SubscriptionId, watchlistId.ResourceGroup, watchlistId.WorkspaceName, watchlistId.Name, name)
+
+	if d.IsNewResource() {
+		existing, err := client.Get(ctx, id.ResourceGroup, OperationalInsightsResourceProvider, id.WorkspaceName, id.WatchlistName, id.Name)
+		if err != nil {
+			if !utils.ResponseWasNotFound(existing.Response) {
+				return fmt.Errorf("checking for presence of existing %s: %+v", id, err)
+			}
+		}
+		if !utils.ResponseWasNotFound(existing.Response) {
+			return tf.ImportAsExistsError("azurerm_sentinel_watchlist_item", id.ID())
+		}
+	}
+
+	param := securityinsight.WatchlistItem{
+		WatchlistItemProperties: &securityinsight.WatchlistItemProperties{
+			ItemsKeyValue: d.Get("properties").(map[string]interface{}),
+		},
+	}
+
+	if _, err := client.CreateOrUpdate(ctx, id.ResourceGroup, OperationalInsightsResourceProvider, id.WorkspaceName, id.WatchlistName, id.Name, param); err != nil {
+		return fmt.Errorf("creating/updating %s: %+v", id, err)
+	}
+
+	d.SetId(id.ID())
+
+	return resourceSentinelWatchlistItemRead(d, meta)
+}
+
+func resourceSentinelWatchlistItemRead(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Sentinel.WatchlistItemsClient
+	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := parse.WatchlistItemID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.Get(ctx, id.ResourceGroup, OperationalInsightsResourceProvider, id.WorkspaceName, id.WatchlistName, id.Name)
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			log.Printf("[DEBUG] %s was not found - removing from state!", id)
+			d.SetId("")
+			return nil
+		}
+
+		return fmt.Errorf("retrieving %s: %+v", id, err)
+	}
+
+	d.Set("name", id.Name)
+	d.Set("watchlist_id", parse.NewWatchlistID(id.SubscriptionId, id.ResourceGroup, id.WorkspaceName, id.WatchlistName).ID())
+
+	if props := resp.WatchlistItemProperties; props != nil {
+		if err := d.Set("properties", flattenSentinelWatchlistItemProperties(props.ItemsKeyValue)); err != nil {
+			return fmt.Errorf("setting `properties`: %+v", err)
+		}
+	}
+
+	return nil
+}
+
+func resourceSentinelWatchlistItemDelete(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Sentinel.WatchlistItemsClient
+	ctx, cancel := timeouts.ForDelete(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := parse.WatchlistItemID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	if _, err := client.Delete(ctx, id.ResourceGroup, OperationalInsightsResourceProvider, id.WorkspaceName, id.WatchlistName, id.Name); err != nil {
+		return fmt.Errorf("deleting %s: %+v", id, err)
+	}
+
+	return nil
+}
+
+func flattenSentinelWatchlistItemProperties(input interface{}) map[string]interface{} {
+	output := make(map[string]interface{})
+
+	values, ok := input.(map[string]interface{})
+	if !ok {
+		return output
+	}
+
+	for k, v := range values {
+		// the values of a CSV upload are always strings, but guard against the API returning other types
+		if s, ok := v.(string); ok {
+			output[k] = s
+		} else if v != nil {
+			output[k] = fmt.Sprintf("%v", v)
+		}
+	}
+
+	return output
+}
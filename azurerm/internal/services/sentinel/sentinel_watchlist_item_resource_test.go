@@ -0,0 +1,131 @@
+package sentinel_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/acceptance"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/acceptance/check"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/clients"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/sentinel/parse"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tf/pluginsdk"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+type SentinelWatchlistItemResource struct{}
+
+func TestAccSentinelWatchlistItem_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_sentinel_watchlist_item", "test")
+	r := SentinelWatchlistItemResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("name").Exists(),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccSentinelWatchlistItem_update(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_sentinel_watchlist_item", "test")
+	r := SentinelWatchlistItemResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.complete(data, "10.0.0.4"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.complete(data, "10.0.0.5"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("properties.IPAddress").HasValue("10.0.0.5"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccSentinelWatchlistItem_requiresImport(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_sentinel_watchlist_item", "test")
+	r := SentinelWatchlistItemResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.complete(data, "10.0.0.4"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.RequiresImportErrorStep(r.requiresImport),
+	})
+}
+
+func (r SentinelWatchlistItemResource) Exists(ctx context.Context, clients *clients.Client, state *pluginsdk.InstanceState) (*bool, error) {
+	id, err := parse.WatchlistItemID(state.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := clients.Sentinel.WatchlistItemsClient.Get(ctx, id.ResourceGroup, "Microsoft.OperationalInsights", id.WorkspaceName, id.WatchlistName, id.Name)
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			return utils.Bool(false), nil
+		}
+		return nil, fmt.Errorf("retrieving %s: %+v", id, err)
+	}
+
+	return utils.Bool(resp.ID != nil), nil
+}
+
+func (r SentinelWatchlistItemResource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_sentinel_watchlist_item" "test" {
+  watchlist_id = azurerm_sentinel_watchlist.test.id
+  properties = {
+    Hostname = "web-01"
+  }
+}
+`, r.template(data))
+}
+
+func (r SentinelWatchlistItemResource) complete(data acceptance.TestData, ipAddress string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_sentinel_watchlist_item" "test" {
+  name         = "196abd06-eb52-4ee5-b5e8-a3d4b0ac4d37"
+  watchlist_id = azurerm_sentinel_watchlist.test.id
+  properties = {
+    Hostname  = "web-01"
+    IPAddress = "%s"
+  }
+}
+`, r.template(data), ipAddress)
+}
+
+func (r SentinelWatchlistItemResource) requiresImport(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_sentinel_watchlist_item" "import" {
+  name         = azurerm_sentinel_watchlist_item.test.name
+  watchlist_id = azurerm_sentinel_watchlist_item.test.watchlist_id
+  properties   = azurerm_sentinel_watchlist_item.test.properties
+}
+`, r.complete(data, "10.0.0.4"))
+}
+
+func (r SentinelWatchlistItemResource) template(data acceptance.TestData) string {
+	return SentinelWatchlistResource{}.basic(data)
+}
@@ -0,0 +1,317 @@
+package sentinel
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/preview/securityinsight/mgmt/2019-01-01-preview/securityinsight"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/tf"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/validate"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/clients"
+	loganalyticsParse "github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/loganalytics/parse"
+	loganalyticsValidate "github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/loganalytics/validate"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/sentinel/parse"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tf/pluginsdk"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tf/validation"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/timeouts"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+func resourceSentinelWatchlist() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		Create: resourceSentinelWatchlistCreate,
+		Read:   resourceSentinelWatchlistRead,
+		Delete: resourceSentinelWatchlistDelete,
+
+		Importer: pluginsdk.ImporterValidatingResourceId(func(id string) error {
+			_, err := parse.WatchlistID(id)
+			return err
+		}),
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Create: pluginsdk.DefaultTimeout(30 * time.Minute),
+			Read:   pluginsdk.DefaultTimeout(5 * time.Minute),
+			Delete: pluginsdk.DefaultTimeout(30 * time.Minute),
+		},
+
+		Schema: map[string]*pluginsdk.Schema{
+			"name": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"log_analytics_workspace_id": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: loganalyticsValidate.LogAnalyticsWorkspaceID,
+			},
+
+			"display_name": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"description": {
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"labels": {
+				Type:     pluginsdk.TypeList,
+				Optional: true,
+				ForceNew: true,
+				Elem: &pluginsdk.Schema{
+					Type:         pluginsdk.TypeString,
+					ValidateFunc: validation.StringIsNotEmpty,
+				},
+			},
+
+			"default_duration": {
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validate.ISO8601Duration,
+			},
+
+			"source_file": {
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"number_of_lines_to_skip": {
+				Type:         pluginsdk.TypeInt,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      0,
+				ValidateFunc: validation.IntAtLeast(0),
+				RequiredWith: []string{"source_file"},
+			},
+
+			"source_file_sha256": {
+				Type:     pluginsdk.TypeString,
+				Computed: true,
+			},
+
+			"items_count": {
+				Type:     pluginsdk.TypeInt,
+				Computed: true,
+			},
+		},
+
+		CustomizeDiff: pluginsdk.CustomizeDiffShim(resourceSentinelWatchlistCustomizeDiff),
+	}
+}
+
+// resourceSentinelWatchlistCustomizeDiff tracks the contents of the `source_file`, since the Watchlist Items
+// uploaded from it can't be updated in-place - a change to the file recreates the Watchlist.
+func resourceSentinelWatchlistCustomizeDiff(_ context.Context, d *pluginsdk.ResourceDiff, _ interface{}) error {
+	if !d.NewValueKnown("source_file") {
+		return d.SetNewComputed("source_file_sha256")
+	}
+
+	path := d.Get("source_file").(string)
+	if path == "" {
+		return nil
+	}
+
+	_, checksum, err := readSentinelWatchlistSourceFile(path)
+	if err != nil {
+		return err
+	}
+
+	if d.Get("source_file_sha256").(string) == checksum {
+		return nil
+	}
+
+	if err := d.SetNew("source_file_sha256", checksum); err != nil {
+		return fmt.Errorf("setting `source_file_sha256`: %+v", err)
+	}
+	if d.Id() != "" {
+		return d.ForceNew("source_file_sha256")
+	}
+
+	return nil
+}
+
+func resourceSentinelWatchlistCreate(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Sentinel.WatchlistsClient
+	ctx, cancel := timeouts.ForCreate(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	workspaceId, err := loganalyticsParse.LogAnalyticsWorkspaceID(d.Get("log_analytics_workspace_id").(string))
+	if err != nil {
+		return err
+	}
+	id := parse.NewWatchlistID(workspaceId.SubscriptionId, workspaceId.ResourceGroup, workspaceId.WorkspaceName, d.Get("name").(string))
+
+	existing, err := client.Get(ctx, id.ResourceGroup, OperationalInsightsResourceProvider, id.WorkspaceName, id.Name)
+	if err != nil {
+		if !utils.ResponseWasNotFound(existing.Response) {
+			return fmt.Errorf("checking for presence of existing %s: %+v", id, err)
+		}
+	}
+	if !utils.ResponseWasNotFound(existing.Response) {
+		return tf.ImportAsExistsError("azurerm_sentinel_watchlist", id.ID())
+	}
+
+	param := securityinsight.Watchlist{
+		WatchlistProperties: &securityinsight.WatchlistProperties{
+			DisplayName: utils.String(d.Get("display_name").(string)),
+			// only "Microsoft" is accepted by the service
+			Provider: utils.String("Microsoft"),
+			Source:   securityinsight.SourceLocalfile,
+			Labels:   utils.ExpandStringSlice(d.Get("labels").([]interface{})),
+		},
+	}
+
+	if v, ok := d.GetOk("description"); ok {
+		param.WatchlistProperties.Description = utils.String(v.(string))
+	}
+
+	if v, ok := d.GetOk("default_duration"); ok {
+		param.WatchlistProperties.DefaultDuration = utils.String(v.(string))
+	}
+
+	var checksum string
+	if v, ok := d.GetOk("source_file"); ok {
+		var content string
+		content, checksum, err = readSentinelWatchlistSourceFile(v.(string))
+		if err != nil {
+			return err
+		}
+
+		param.WatchlistProperties.RawContent = utils.String(content)
+		param.WatchlistProperties.ContentType = utils.String("text/csv")
+		param.WatchlistProperties.NumberOfLinesToSkip = utils.Int32(int32(d.Get("number_of_lines_to_skip").(int)))
+	}
+
+	if _, err := client.Create(ctx, id.ResourceGroup, OperationalInsightsResourceProvider, id.WorkspaceName, id.Name, param); err != nil {
+		return fmt.Errorf("creating %s: %+v", id, err)
+	}
+
+	d.SetId(id.ID())
+	d.Set("source_file_sha256", checksum)
+
+	// the Watchlist can't be deleted until the items have been uploaded, so wait for the upload to complete
+	if param.WatchlistProperties.RawContent != nil {
+		deadline, ok := ctx.Deadline()
+		if !ok {
+			return fmt.Errorf("context had no deadline")
+		}
+		stateConf := &pluginsdk.StateChangeConf{
+			Pending:    []string{"New", "InProgress"},
+			Target:     []string{"Complete"},
+			Refresh:    sentinelWatchlistUploadStatusRefreshFunc(ctx, client, id),
+			MinTimeout: 15 * time.Second,
+			Timeout:    time.Until(deadline),
+		}
+
+		if _, err := stateConf.WaitForStateContext(ctx); err != nil {
+			return fmt.Errorf("waiting for the items of %s to be uploaded: %+v", id, err)
+		}
+	}
+
+	return resourceSentinelWatchlistRead(d, meta)
+}
+
+func resourceSentinelWatchlistRead(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Sentinel.WatchlistsClient
+	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := parse.WatchlistID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.Get(ctx, id.ResourceGroup, OperationalInsightsResourceProvider, id.WorkspaceName, id.Name)
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			log.Printf("[DEBUG] %s was not found - removing from state!", id)
+			d.SetId("")
+			return nil
+		}
+
+		return fmt.Errorf("retrieving %s: %+v", id, err)
+	}
+
+	d.Set("name", id.Name)
+	d.Set("log_analytics_workspace_id", loganalyticsParse.NewLogAnalyticsWorkspaceID(id.SubscriptionId, id.ResourceGroup, id.WorkspaceName).ID())
+
+	if props := resp.WatchlistProperties; props != nil {
+		d.Set("display_name", props.DisplayName)
+		d.Set("description", props.Description)
+		d.Set("labels", utils.FlattenStringSlice(props.Labels))
+		d.Set("default_duration", props.DefaultDuration)
+
+		itemsCount := 0
+		if props.WatchlistItemsCount != nil {
+			itemsCount = int(*props.WatchlistItemsCount)
+		}
+		d.Set("items_count", itemsCount)
+	}
+
+	// the raw content isn't returned by the API, so `source_file`, `number_of_lines_to_skip` and
+	// `source_file_sha256` are left as-is
+
+	return nil
+}
+
+func resourceSentinelWatchlistDelete(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Sentinel.WatchlistsClient
+	ctx, cancel := timeouts.ForDelete(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := parse.WatchlistID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	if _, err := client.Delete(ctx, id.ResourceGroup, OperationalInsightsResourceProvider, id.WorkspaceName, id.Name); err != nil {
+		return fmt.Errorf("deleting %s: %+v", id, err)
+	}
+
+	return nil
+}
+
+func sentinelWatchlistUploadStatusRefreshFunc(ctx context.Context, client *securityinsight.WatchlistsClient, id parse.WatchlistId) pluginsdk.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		resp, err := client.Get(ctx, id.ResourceGroup, OperationalInsightsResourceProvider, id.WorkspaceName, id.Name)
+		if err != nil {
+			return nil, "", fmt.Errorf("retrieving %s: %+v", id, err)
+		}
+
+		if resp.WatchlistProperties == nil || resp.WatchlistProperties.UploadStatus == nil {
+			return nil, "", fmt.Errorf("retrieving %s: `uploadStatus` was nil", id)
+		}
+
+		return resp, *resp.WatchlistProperties.UploadStatus, nil
+	}
+}
+
+func readSentinelWatchlistSourceFile(path string) (string, string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", "", fmt.Errorf("reading `source_file` %q: %+v", path, err)
+	}
+
+	if strings.TrimSpace(string(content)) == "" {
+		return "", "", fmt.Errorf("`source_file` %q is empty", path)
+	}
+
+	return string(content), fmt.Sprintf("%x", sha256.Sum256(content)), nil
+}
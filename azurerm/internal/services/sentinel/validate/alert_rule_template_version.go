@@ -0,0 +1,20 @@
+package validate
+
+import (
+	"fmt"
+	"regexp"
+)
+
+func AlertRuleTemplateVersion(v interface{}, k string) (warnings []string, errors []error) {
+	value, ok := v.(string)
+	if !ok {
+		errors = append(errors, fmt.Errorf("expected type of %q to be string", k))
+		return warnings, errors
+	}
+
+	if !regexp.MustCompile(`^[0-9]+(\.[0-9]+)*$`).MatchString(value) {
+		errors = append(errors, fmt.Errorf("%q must be a version made up of numbers separated by periods, such as `1.0.2`", k))
+	}
+
+	return warnings, errors
+}
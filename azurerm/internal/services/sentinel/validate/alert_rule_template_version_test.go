@@ -0,0 +1,52 @@
+package validate
+
+import (
+	"testing"
+)
+
+func TestAlertRuleTemplateVersion(t *testing.T) {
+	cases := []struct {
+		Value    string
+		ErrCount int
+	}{
+		{
+			Value:    "",
+			ErrCount: 1,
+		},
+		{
+			Value:    "1",
+			ErrCount: 0,
+		},
+		{
+			Value:    "1.0.2",
+			ErrCount: 0,
+		},
+		{
+			Value:    "10.20.30.40",
+			ErrCount: 0,
+		},
+		{
+			Value:    "1.0.",
+			ErrCount: 1,
+		},
+		{
+			Value:    ".1",
+			ErrCount: 1,
+		},
+		{
+			Value:    "v1.0",
+			ErrCount: 1,
+		},
+		{
+			Value:    "1.0-beta",
+			ErrCount: 1,
+		},
+	}
+
+	for _, tc := range cases {
+		_, errors := AlertRuleTemplateVersion(tc.Value, "alert_rule_template_version")
+		if len(errors) != tc.ErrCount {
+			t.Fatalf("Expected %d errors for %q but got %d", tc.ErrCount, tc.Value, len(errors))
+		}
+	}
+}
@@ -19,7 +19,7 @@ import (
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/locks"
 	msiparse "github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/msi/parse"
 	msiValidate "github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/msi/validate"
-	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/network"
+	networkParse "github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/network/parse"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/storage/migration"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/storage/validate"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tags"
@@ -1776,7 +1776,8 @@ func resourceStorageAccountDelete(d *pluginsdk.ResourceData, meta interface{}) e
 	}
 
 	// the networking api's only allow a single change to be made to a network layout at once, so let's lock to handle that
-	virtualNetworkNames := make([]string, 0)
+	subnetIdsToLock := make([]locks.ResourceID, 0)
+	virtualNetworkIdsToLock := make([]locks.ResourceID, 0)
 	if props := read.AccountProperties; props != nil {
 		if rules := props.NetworkRuleSet; rules != nil {
 			if vnr := rules.VirtualNetworkRules; vnr != nil {
@@ -1785,25 +1786,21 @@ func resourceStorageAccountDelete(d *pluginsdk.ResourceData, meta interface{}) e
 						continue
 					}
 
-					id, err2 := azure.ParseAzureResourceID(*v.VirtualNetworkResourceID)
+					id, err2 := networkParse.SubnetIDInsensitively(*v.VirtualNetworkResourceID)
 					if err2 != nil {
 						return err2
 					}
 
-					networkName := id.Path["virtualNetworks"]
-					for _, virtualNetworkName := range virtualNetworkNames {
-						if networkName == virtualNetworkName {
-							continue
-						}
-					}
-					virtualNetworkNames = append(virtualNetworkNames, networkName)
+					// duplicates are removed when locking
+					subnetIdsToLock = append(subnetIdsToLock, *id)
+					virtualNetworkIdsToLock = append(virtualNetworkIdsToLock, networkParse.NewVirtualNetworkID(id.SubscriptionId, id.ResourceGroup, id.VirtualNetworkName))
 				}
 			}
 		}
 	}
 
-	locks.MultipleByName(&virtualNetworkNames, network.VirtualNetworkResourceName)
-	defer locks.UnlockMultipleByName(&virtualNetworkNames, network.VirtualNetworkResourceName)
+	locks.MultipleChildrenByResourceID(virtualNetworkIdsToLock, subnetIdsToLock)
+	defer locks.UnlockMultipleChildrenByResourceID(virtualNetworkIdsToLock, subnetIdsToLock)
 
 	resp, err := client.Delete(ctx, resourceGroup, name)
 	if err != nil {
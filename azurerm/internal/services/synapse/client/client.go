@@ -3,6 +3,7 @@ package client
 import (
 	"fmt"
 
+	"github.com/Azure/azure-sdk-for-go/services/preview/synapse/2019-06-01-preview/artifacts"
 	"github.com/Azure/azure-sdk-for-go/services/preview/synapse/2019-06-01-preview/managedvirtualnetwork"
 	"github.com/Azure/azure-sdk-for-go/services/preview/synapse/2020-08-01-preview/accesscontrol"
 	"github.com/Azure/azure-sdk-for-go/services/synapse/mgmt/2021-03-01/synapse"
@@ -12,6 +13,8 @@ import (
 
 type Client struct {
 	FirewallRulesClient                              *synapse.IPFirewallRulesClient
+	IntegrationRuntimeAuthKeysClient                 *synapse.IntegrationRuntimeAuthKeysClient
+	IntegrationRuntimesClient                        *synapse.IntegrationRuntimesClient
 	PrivateLinkHubsClient                            *synapse.PrivateLinkHubsClient
 	SparkPoolClient                                  *synapse.BigDataPoolsClient
 	SqlPoolClient                                    *synapse.SQLPoolsClient
@@ -27,6 +30,12 @@ func NewClient(o *common.ClientOptions) *Client {
 	firewallRuleClient := synapse.NewIPFirewallRulesClientWithBaseURI(o.ResourceManagerEndpoint, o.SubscriptionId)
 	o.ConfigureClient(&firewallRuleClient.Client, o.ResourceManagerAuthorizer)
 
+	integrationRuntimeAuthKeysClient := synapse.NewIntegrationRuntimeAuthKeysClientWithBaseURI(o.ResourceManagerEndpoint, o.SubscriptionId)
+	o.ConfigureClient(&integrationRuntimeAuthKeysClient.Client, o.ResourceManagerAuthorizer)
+
+	integrationRuntimesClient := synapse.NewIntegrationRuntimesClientWithBaseURI(o.ResourceManagerEndpoint, o.SubscriptionId)
+	o.ConfigureClient(&integrationRuntimesClient.Client, o.ResourceManagerAuthorizer)
+
 	privateLinkHubsClient := synapse.NewPrivateLinkHubsClientWithBaseURI(o.ResourceManagerEndpoint, o.SubscriptionId)
 	o.ConfigureClient(&privateLinkHubsClient.Client, o.ResourceManagerAuthorizer)
 
@@ -51,6 +60,8 @@ func NewClient(o *common.ClientOptions) *Client {
 
 	return &Client{
 		FirewallRulesClient:                              &firewallRuleClient,
+		IntegrationRuntimeAuthKeysClient:                 &integrationRuntimeAuthKeysClient,
+		IntegrationRuntimesClient:                        &integrationRuntimesClient,
 		PrivateLinkHubsClient:                            &privateLinkHubsClient,
 		SparkPoolClient:                                  &sparkPoolClient,
 		SqlPoolClient:                                    &sqlPoolClient,
@@ -93,6 +104,36 @@ func (client Client) ManagedPrivateEndpointsClient(workspaceName, synapseEndpoin
 	return &managedPrivateEndpointsClient, nil
 }
 
+func (client Client) LinkedServiceClient(workspaceName, synapseEndpointSuffix string) (*artifacts.LinkedServiceClient, error) {
+	if client.synapseAuthorizer == nil {
+		return nil, fmt.Errorf("Synapse is not supported in this Azure Environment")
+	}
+	endpoint := buildEndpoint(workspaceName, synapseEndpointSuffix)
+	linkedServiceClient := artifacts.NewLinkedServiceClient(endpoint)
+	linkedServiceClient.Client.Authorizer = client.synapseAuthorizer
+	return &linkedServiceClient, nil
+}
+
+func (client Client) NotebookClient(workspaceName, synapseEndpointSuffix string) (*artifacts.NotebookClient, error) {
+	if client.synapseAuthorizer == nil {
+		return nil, fmt.Errorf("Synapse is not supported in this Azure Environment")
+	}
+	endpoint := buildEndpoint(workspaceName, synapseEndpointSuffix)
+	notebookClient := artifacts.NewNotebookClient(endpoint)
+	notebookClient.Client.Authorizer = client.synapseAuthorizer
+	return &notebookClient, nil
+}
+
+func (client Client) SqlScriptClient(workspaceName, synapseEndpointSuffix string) (*artifacts.SQLScriptClient, error) {
+	if client.synapseAuthorizer == nil {
+		return nil, fmt.Errorf("Synapse is not supported in this Azure Environment")
+	}
+	endpoint := buildEndpoint(workspaceName, synapseEndpointSuffix)
+	sqlScriptClient := artifacts.NewSQLScriptClient(endpoint)
+	sqlScriptClient.Client.Authorizer = client.synapseAuthorizer
+	return &sqlScriptClient, nil
+}
+
 func buildEndpoint(workspaceName string, synapseEndpointSuffix string) string {
 	return fmt.Sprintf("https://%s.%s", workspaceName, synapseEndpointSuffix)
 }
@@ -0,0 +1,134 @@
+package synapse
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/preview/synapse/2019-06-01-preview/artifacts"
+	"github.com/Azure/azure-sdk-for-go/services/synapse/mgmt/2021-03-01/synapse"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/clients"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/synapse/parse"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tf/pluginsdk"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+const (
+	synapseDataPlaneStateProvisioning = "Provisioning"
+	synapseDataPlaneStateUnavailable  = "Unavailable"
+	synapseDataPlaneStateReady        = "Ready"
+)
+
+// waitForSynapseWorkspaceReady waits until the Synapse Workspace has been provisioned and, when checkDataPlane is set,
+// until its data plane endpoint accepts requests - the endpoint of a new Workspace takes a while to become resolvable,
+// and firewall rules (including the one allowing the machine running Terraform) take a few minutes to propagate, during
+// which requests are rejected
+func waitForSynapseWorkspaceReady(ctx context.Context, meta interface{}, workspaceId parse.WorkspaceId, checkDataPlane bool, timeout time.Duration) error {
+	workspaceClient := meta.(*clients.Client).Synapse.WorkspaceClient
+	environment := meta.(*clients.Client).Account.Environment
+
+	linkedServiceClient, err := meta.(*clients.Client).Synapse.LinkedServiceClient(workspaceId.Name, environment.SynapseEndpointSuffix)
+	if err != nil {
+		return err
+	}
+
+	stateConf := &pluginsdk.StateChangeConf{
+		Pending: []string{
+			synapseDataPlaneStateProvisioning,
+			synapseDataPlaneStateUnavailable,
+		},
+		Target: []string{
+			synapseDataPlaneStateReady,
+		},
+		Refresh: func() (interface{}, string, error) {
+			workspace, err := workspaceClient.Get(ctx, workspaceId.ResourceGroup, workspaceId.Name)
+			if err != nil {
+				return nil, "", fmt.Errorf("retrieving %s: %+v", workspaceId, err)
+			}
+			if props := workspace.WorkspaceProperties; props == nil || props.ProvisioningState == nil || !strings.EqualFold(*props.ProvisioningState, "Succeeded") {
+				return workspace, synapseDataPlaneStateProvisioning, nil
+			}
+
+			if !checkDataPlane {
+				return workspace, synapseDataPlaneStateReady, nil
+			}
+
+			page, err := linkedServiceClient.GetLinkedServicesByWorkspace(ctx)
+			if err != nil {
+				if isSynapseDataPlaneUnavailable(page.Response().Response, err) {
+					log.Printf("[DEBUG] the data plane of %s is unavailable: %+v", workspaceId, err)
+					return workspace, synapseDataPlaneStateUnavailable, nil
+				}
+				return nil, "", fmt.Errorf("connecting to the data plane of %s: %+v", workspaceId, err)
+			}
+
+			return workspace, synapseDataPlaneStateReady, nil
+		},
+		MinTimeout:                10 * time.Second,
+		ContinuousTargetOccurence: 2,
+		Timeout:                   timeout,
+	}
+
+	if _, err := stateConf.WaitForStateContext(ctx); err != nil {
+		return fmt.Errorf("waiting for %s to become ready: %+v", workspaceId, err)
+	}
+
+	return nil
+}
+
+// isSynapseDataPlaneUnavailable returns whether a data plane request failed because the endpoint couldn't be reached
+// yet, or because the Workspace firewall (still) rejects the client IP address - other errors (such as a missing role
+// assignment) won't resolve themselves and are returned straight away
+func isSynapseDataPlaneUnavailable(resp autorest.Response, err error) bool {
+	if resp.Response == nil {
+		return true
+	}
+
+	return utils.ResponseWasForbidden(resp) && strings.Contains(strings.ToLower(err.Error()), "ip address")
+}
+
+// synapseWorkspaceManagedVirtualNetworkEnabled returns whether the compute of the Synapse Workspace runs in a
+// Managed Virtual Network
+func synapseWorkspaceManagedVirtualNetworkEnabled(workspace synapse.Workspace) bool {
+	props := workspace.WorkspaceProperties
+	return props != nil && props.ManagedVirtualNetwork != nil && strings.EqualFold(*props.ManagedVirtualNetwork, "default")
+}
+
+func suppressJsonOrderingDifference(_, old, new string, _ *pluginsdk.ResourceData) bool {
+	return utils.NormalizeJson(old) == utils.NormalizeJson(new)
+}
+
+func expandSynapseParameters(input map[string]interface{}) map[string]*artifacts.ParameterSpecification {
+	output := make(map[string]*artifacts.ParameterSpecification)
+
+	for k, v := range input {
+		output[k] = &artifacts.ParameterSpecification{
+			Type:         artifacts.ParameterTypeString,
+			DefaultValue: v.(string),
+		}
+	}
+
+	return output
+}
+
+func flattenSynapseParameters(input map[string]*artifacts.ParameterSpecification) map[string]interface{} {
+	output := make(map[string]interface{})
+
+	for k, v := range input {
+		if v != nil {
+			// we only support string parameters at this time
+			val, ok := v.DefaultValue.(string)
+			if !ok {
+				log.Printf("[DEBUG] Skipping parameter %q since it's not a string", k)
+				continue
+			}
+
+			output[k] = val
+		}
+	}
+
+	return output
+}
@@ -0,0 +1,259 @@
+package synapse
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/synapse/mgmt/2021-03-01/synapse"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/azure"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/tf"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/clients"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/location"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/synapse/parse"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/synapse/validate"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tf/pluginsdk"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tf/validation"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/timeouts"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+func resourceSynapseIntegrationRuntimeAzure() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		Create: resourceSynapseIntegrationRuntimeAzureCreateUpdate,
+		Read:   resourceSynapseIntegrationRuntimeAzureRead,
+		Update: resourceSynapseIntegrationRuntimeAzureCreateUpdate,
+		Delete: resourceSynapseIntegrationRuntimeDelete,
+
+		Importer: pluginsdk.ImporterValidatingResourceId(func(id string) error {
+			_, err := parse.IntegrationRuntimeID(id)
+			return err
+		}),
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Create: pluginsdk.DefaultTimeout(30 * time.Minute),
+			Read:   pluginsdk.DefaultTimeout(5 * time.Minute),
+			Update: pluginsdk.DefaultTimeout(30 * time.Minute),
+			Delete: pluginsdk.DefaultTimeout(30 * time.Minute),
+		},
+
+		Schema: map[string]*pluginsdk.Schema{
+			"name": synapseIntegrationRuntimeSchemaName(),
+
+			"synapse_workspace_id": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validate.WorkspaceID,
+			},
+
+			"location": azure.SchemaLocation(),
+
+			"compute_type": {
+				Type:     pluginsdk.TypeString,
+				Optional: true,
+				Default:  string(synapse.General),
+				ValidateFunc: validation.StringInSlice([]string{
+					string(synapse.General),
+					string(synapse.ComputeOptimized),
+					string(synapse.MemoryOptimized),
+				}, false),
+			},
+
+			"core_count": {
+				Type:     pluginsdk.TypeInt,
+				Optional: true,
+				Default:  8,
+				ValidateFunc: validation.IntInSlice([]int{
+					8, 16, 32, 48, 80, 144, 272,
+				}),
+			},
+
+			"time_to_live_min": {
+				Type:         pluginsdk.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+
+			"description": {
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+		},
+	}
+}
+
+func resourceSynapseIntegrationRuntimeAzureCreateUpdate(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Synapse.IntegrationRuntimesClient
+	workspaceClient := meta.(*clients.Client).Synapse.WorkspaceClient
+	ctx, cancel := timeouts.ForCreateUpdate(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	workspaceId, err := parse.WorkspaceID(d.Get("synapse_workspace_id").(string))
+	if err != nil {
+		return err
+	}
+
+	id := parse.NewIntegrationRuntimeID(workspaceId.SubscriptionId, workspaceId.ResourceGroup, workspaceId.Name, d.Get("name").(string))
+	if d.IsNewResource() {
+		existing, err := client.Get(ctx, id.ResourceGroup, id.WorkspaceName, id.Name, "")
+		if err != nil {
+			if !utils.ResponseWasNotFound(existing.Response) {
+				return fmt.Errorf("checking for presence of existing %s: %+v", id, err)
+			}
+		}
+		if !utils.ResponseWasNotFound(existing.Response) {
+			return tf.ImportAsExistsError("azurerm_synapse_integration_runtime_azure", id.ID())
+		}
+	}
+
+	timeout := d.Timeout(pluginsdk.TimeoutUpdate)
+	if d.IsNewResource() {
+		timeout = d.Timeout(pluginsdk.TimeoutCreate)
+	}
+	if err := waitForSynapseWorkspaceReady(ctx, meta, *workspaceId, false, timeout); err != nil {
+		return err
+	}
+
+	workspace, err := workspaceClient.Get(ctx, workspaceId.ResourceGroup, workspaceId.Name)
+	if err != nil {
+		return fmt.Errorf("retrieving %s: %+v", *workspaceId, err)
+	}
+
+	managedIntegrationRuntime := synapse.ManagedIntegrationRuntime{
+		Description: utils.String(d.Get("description").(string)),
+		Type:        synapse.TypeManaged,
+		ManagedIntegrationRuntimeTypeProperties: &synapse.ManagedIntegrationRuntimeTypeProperties{
+			ComputeProperties: &synapse.IntegrationRuntimeComputeProperties{
+				Location: utils.String(location.Normalize(d.Get("location").(string))),
+				DataFlowProperties: &synapse.IntegrationRuntimeDataFlowProperties{
+					ComputeType: synapse.DataFlowComputeType(d.Get("compute_type").(string)),
+					CoreCount:   utils.Int32(int32(d.Get("core_count").(int))),
+					TimeToLive:  utils.Int32(int32(d.Get("time_to_live_min").(int))),
+				},
+			},
+		},
+	}
+
+	// Integration Runtimes within a Workspace using a Managed Virtual Network have to be placed within it,
+	// which the API doesn't expose a field for at this time
+	if synapseWorkspaceManagedVirtualNetworkEnabled(workspace) {
+		managedIntegrationRuntime.AdditionalProperties = map[string]interface{}{
+			"managedVirtualNetwork": map[string]interface{}{
+				"referenceName": *workspace.WorkspaceProperties.ManagedVirtualNetwork,
+				"type":          "ManagedVirtualNetworkReference",
+			},
+		}
+	}
+
+	integrationRuntime := synapse.IntegrationRuntimeResource{
+		Name:       utils.String(id.Name),
+		Properties: managedIntegrationRuntime,
+	}
+
+	future, err := client.Create(ctx, id.ResourceGroup, id.WorkspaceName, id.Name, integrationRuntime, "")
+	if err != nil {
+		return fmt.Errorf("creating/updating %s: %+v", id, err)
+	}
+	if err = future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		return fmt.Errorf("waiting for creation/update of %s: %+v", id, err)
+	}
+
+	d.SetId(id.ID())
+
+	return resourceSynapseIntegrationRuntimeAzureRead(d, meta)
+}
+
+func resourceSynapseIntegrationRuntimeAzureRead(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Synapse.IntegrationRuntimesClient
+	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := parse.IntegrationRuntimeID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.Get(ctx, id.ResourceGroup, id.WorkspaceName, id.Name, "")
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			log.Printf("[DEBUG] %s was not found - removing from state", *id)
+			d.SetId("")
+			return nil
+		}
+
+		return fmt.Errorf("retrieving %s: %+v", *id, err)
+	}
+
+	d.Set("name", id.Name)
+	d.Set("synapse_workspace_id", parse.NewWorkspaceID(id.SubscriptionId, id.ResourceGroup, id.WorkspaceName).ID())
+
+	managedIntegrationRuntime, ok := resp.Properties.AsManagedIntegrationRuntime()
+	if !ok {
+		return fmt.Errorf("converting %s to a Managed Integration Runtime", *id)
+	}
+
+	description := ""
+	if managedIntegrationRuntime.Description != nil {
+		description = *managedIntegrationRuntime.Description
+	}
+	d.Set("description", description)
+
+	if props := managedIntegrationRuntime.ManagedIntegrationRuntimeTypeProperties; props != nil && props.ComputeProperties != nil {
+		d.Set("location", location.NormalizeNilable(props.ComputeProperties.Location))
+
+		if dataFlowProps := props.ComputeProperties.DataFlowProperties; dataFlowProps != nil {
+			d.Set("compute_type", string(dataFlowProps.ComputeType))
+
+			coreCount := 0
+			if dataFlowProps.CoreCount != nil {
+				coreCount = int(*dataFlowProps.CoreCount)
+			}
+			d.Set("core_count", coreCount)
+
+			timeToLive := 0
+			if dataFlowProps.TimeToLive != nil {
+				timeToLive = int(*dataFlowProps.TimeToLive)
+			}
+			d.Set("time_to_live_min", timeToLive)
+		}
+	}
+
+	return nil
+}
+
+func resourceSynapseIntegrationRuntimeDelete(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Synapse.IntegrationRuntimesClient
+	ctx, cancel := timeouts.ForDelete(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := parse.IntegrationRuntimeID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	future, err := client.Delete(ctx, id.ResourceGroup, id.WorkspaceName, id.Name)
+	if err != nil {
+		return fmt.Errorf("deleting %s: %+v", *id, err)
+	}
+	if err = future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		return fmt.Errorf("waiting for deletion of %s: %+v", *id, err)
+	}
+
+	return nil
+}
+
+func synapseIntegrationRuntimeSchemaName() *pluginsdk.Schema {
+	return &pluginsdk.Schema{
+		Type:     pluginsdk.TypeString,
+		Required: true,
+		ForceNew: true,
+		ValidateFunc: validation.StringMatch(
+			regexp.MustCompile(`^([a-zA-Z0-9](-|-?[a-zA-Z0-9]+)+[a-zA-Z0-9])$`),
+			`Invalid name for Integration Runtime: minimum 3 characters, must start and end with a number or a letter, may only consist of letters, numbers and dashes and no consecutive dashes.`,
+		),
+	}
+}
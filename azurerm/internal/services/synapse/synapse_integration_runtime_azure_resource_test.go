@@ -0,0 +1,203 @@
+package synapse_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/acceptance"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/acceptance/check"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/clients"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/synapse/parse"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tf/pluginsdk"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+type SynapseIntegrationRuntimeAzureResource struct{}
+
+func TestAccSynapseIntegrationRuntimeAzure_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_synapse_integration_runtime_azure", "test")
+	r := SynapseIntegrationRuntimeAzureResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccSynapseIntegrationRuntimeAzure_requiresImport(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_synapse_integration_runtime_azure", "test")
+	r := SynapseIntegrationRuntimeAzureResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.RequiresImportErrorStep(r.requiresImport),
+	})
+}
+
+func TestAccSynapseIntegrationRuntimeAzure_update(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_synapse_integration_runtime_azure", "test")
+	r := SynapseIntegrationRuntimeAzureResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.complete(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("compute_type").HasValue("ComputeOptimized"),
+				check.That(data.ResourceName).Key("core_count").HasValue("16"),
+				check.That(data.ResourceName).Key("time_to_live_min").HasValue("10"),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccSynapseIntegrationRuntimeAzure_managedVirtualNetwork(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_synapse_integration_runtime_azure", "test")
+	r := SynapseIntegrationRuntimeAzureResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.managedVirtualNetwork(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func (r SynapseIntegrationRuntimeAzureResource) Exists(ctx context.Context, client *clients.Client, state *pluginsdk.InstanceState) (*bool, error) {
+	id, err := parse.IntegrationRuntimeID(state.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.Synapse.IntegrationRuntimesClient.Get(ctx, id.ResourceGroup, id.WorkspaceName, id.Name, "")
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			return utils.Bool(false), nil
+		}
+		return nil, fmt.Errorf("retrieving %s: %+v", *id, err)
+	}
+
+	return utils.Bool(true), nil
+}
+
+func (r SynapseIntegrationRuntimeAzureResource) basic(data acceptance.TestData) string {
+	template := synapseIntegrationRuntimeTemplate(data, false)
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_synapse_integration_runtime_azure" "test" {
+  name                 = "acctestir%d"
+  synapse_workspace_id = azurerm_synapse_workspace.test.id
+  location             = azurerm_resource_group.test.location
+}
+`, template, data.RandomInteger)
+}
+
+func (r SynapseIntegrationRuntimeAzureResource) complete(data acceptance.TestData) string {
+	template := synapseIntegrationRuntimeTemplate(data, false)
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_synapse_integration_runtime_azure" "test" {
+  name                 = "acctestir%d"
+  synapse_workspace_id = azurerm_synapse_workspace.test.id
+  location             = azurerm_resource_group.test.location
+  compute_type         = "ComputeOptimized"
+  core_count           = 16
+  time_to_live_min     = 10
+  description          = "test description"
+}
+`, template, data.RandomInteger)
+}
+
+func (r SynapseIntegrationRuntimeAzureResource) managedVirtualNetwork(data acceptance.TestData) string {
+	template := synapseIntegrationRuntimeTemplate(data, true)
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_synapse_integration_runtime_azure" "test" {
+  name                 = "acctestir%d"
+  synapse_workspace_id = azurerm_synapse_workspace.test.id
+  location             = "AutoResolve"
+}
+`, template, data.RandomInteger)
+}
+
+func (r SynapseIntegrationRuntimeAzureResource) requiresImport(data acceptance.TestData) string {
+	config := r.basic(data)
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_synapse_integration_runtime_azure" "import" {
+  name                 = azurerm_synapse_integration_runtime_azure.test.name
+  synapse_workspace_id = azurerm_synapse_integration_runtime_azure.test.synapse_workspace_id
+  location             = azurerm_synapse_integration_runtime_azure.test.location
+}
+`, config)
+}
+
+func synapseIntegrationRuntimeTemplate(data acceptance.TestData, managedVirtualNetworkEnabled bool) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-synapse-%d"
+  location = "%s"
+}
+
+resource "azurerm_storage_account" "test" {
+  name                     = "acctestacc%s"
+  resource_group_name      = azurerm_resource_group.test.name
+  location                 = azurerm_resource_group.test.location
+  account_kind             = "BlobStorage"
+  account_tier             = "Standard"
+  account_replication_type = "LRS"
+}
+
+resource "azurerm_storage_data_lake_gen2_filesystem" "test" {
+  name               = "acctest-%d"
+  storage_account_id = azurerm_storage_account.test.id
+}
+
+resource "azurerm_synapse_workspace" "test" {
+  name                                 = "acctestsw%d"
+  resource_group_name                  = azurerm_resource_group.test.name
+  location                             = azurerm_resource_group.test.location
+  storage_data_lake_gen2_filesystem_id = azurerm_storage_data_lake_gen2_filesystem.test.id
+  sql_administrator_login              = "sqladminuser"
+  sql_administrator_login_password     = "H@Sh1CoR3!"
+  managed_virtual_network_enabled      = %t
+}
+`, data.RandomInteger, data.Locations.Primary, data.RandomString, data.RandomInteger, data.RandomInteger, managedVirtualNetworkEnabled)
+}
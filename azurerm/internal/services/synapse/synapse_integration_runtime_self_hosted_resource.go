@@ -0,0 +1,167 @@
+package synapse
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/synapse/mgmt/2021-03-01/synapse"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/tf"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/clients"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/synapse/parse"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/synapse/validate"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tf/pluginsdk"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tf/validation"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/timeouts"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+func resourceSynapseIntegrationRuntimeSelfHosted() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		Create: resourceSynapseIntegrationRuntimeSelfHostedCreateUpdate,
+		Read:   resourceSynapseIntegrationRuntimeSelfHostedRead,
+		Update: resourceSynapseIntegrationRuntimeSelfHostedCreateUpdate,
+		Delete: resourceSynapseIntegrationRuntimeDelete,
+
+		Importer: pluginsdk.ImporterValidatingResourceId(func(id string) error {
+			_, err := parse.IntegrationRuntimeID(id)
+			return err
+		}),
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Create: pluginsdk.DefaultTimeout(30 * time.Minute),
+			Read:   pluginsdk.DefaultTimeout(5 * time.Minute),
+			Update: pluginsdk.DefaultTimeout(30 * time.Minute),
+			Delete: pluginsdk.DefaultTimeout(30 * time.Minute),
+		},
+
+		Schema: map[string]*pluginsdk.Schema{
+			"name": synapseIntegrationRuntimeSchemaName(),
+
+			"synapse_workspace_id": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validate.WorkspaceID,
+			},
+
+			"description": {
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"authorization_key_primary": {
+				Type:      pluginsdk.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+
+			"authorization_key_secondary": {
+				Type:      pluginsdk.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+		},
+	}
+}
+
+func resourceSynapseIntegrationRuntimeSelfHostedCreateUpdate(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Synapse.IntegrationRuntimesClient
+	ctx, cancel := timeouts.ForCreateUpdate(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	workspaceId, err := parse.WorkspaceID(d.Get("synapse_workspace_id").(string))
+	if err != nil {
+		return err
+	}
+
+	id := parse.NewIntegrationRuntimeID(workspaceId.SubscriptionId, workspaceId.ResourceGroup, workspaceId.Name, d.Get("name").(string))
+	if d.IsNewResource() {
+		existing, err := client.Get(ctx, id.ResourceGroup, id.WorkspaceName, id.Name, "")
+		if err != nil {
+			if !utils.ResponseWasNotFound(existing.Response) {
+				return fmt.Errorf("checking for presence of existing %s: %+v", id, err)
+			}
+		}
+		if !utils.ResponseWasNotFound(existing.Response) {
+			return tf.ImportAsExistsError("azurerm_synapse_integration_runtime_self_hosted", id.ID())
+		}
+	}
+
+	timeout := d.Timeout(pluginsdk.TimeoutUpdate)
+	if d.IsNewResource() {
+		timeout = d.Timeout(pluginsdk.TimeoutCreate)
+	}
+	if err := waitForSynapseWorkspaceReady(ctx, meta, *workspaceId, false, timeout); err != nil {
+		return err
+	}
+
+	selfHostedIntegrationRuntime := synapse.SelfHostedIntegrationRuntime{
+		Description: utils.String(d.Get("description").(string)),
+		Type:        synapse.TypeSelfHosted,
+	}
+
+	integrationRuntime := synapse.IntegrationRuntimeResource{
+		Name:       utils.String(id.Name),
+		Properties: selfHostedIntegrationRuntime,
+	}
+
+	future, err := client.Create(ctx, id.ResourceGroup, id.WorkspaceName, id.Name, integrationRuntime, "")
+	if err != nil {
+		return fmt.Errorf("creating/updating %s: %+v", id, err)
+	}
+	if err = future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		return fmt.Errorf("waiting for creation/update of %s: %+v", id, err)
+	}
+
+	d.SetId(id.ID())
+
+	return resourceSynapseIntegrationRuntimeSelfHostedRead(d, meta)
+}
+
+func resourceSynapseIntegrationRuntimeSelfHostedRead(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Synapse.IntegrationRuntimesClient
+	authKeysClient := meta.(*clients.Client).Synapse.IntegrationRuntimeAuthKeysClient
+	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := parse.IntegrationRuntimeID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.Get(ctx, id.ResourceGroup, id.WorkspaceName, id.Name, "")
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			log.Printf("[DEBUG] %s was not found - removing from state", *id)
+			d.SetId("")
+			return nil
+		}
+
+		return fmt.Errorf("retrieving %s: %+v", *id, err)
+	}
+
+	d.Set("name", id.Name)
+	d.Set("synapse_workspace_id", parse.NewWorkspaceID(id.SubscriptionId, id.ResourceGroup, id.WorkspaceName).ID())
+
+	selfHostedIntegrationRuntime, ok := resp.Properties.AsSelfHostedIntegrationRuntime()
+	if !ok {
+		return fmt.Errorf("converting %s to a Self-Hosted Integration Runtime", *id)
+	}
+
+	description := ""
+	if selfHostedIntegrationRuntime.Description != nil {
+		description = *selfHostedIntegrationRuntime.Description
+	}
+	d.Set("description", description)
+
+	authKeys, err := authKeysClient.List(ctx, id.ResourceGroup, id.WorkspaceName, id.Name)
+	if err != nil {
+		return fmt.Errorf("retrieving Authorization Keys for %s: %+v", *id, err)
+	}
+	d.Set("authorization_key_primary", authKeys.AuthKey1)
+	d.Set("authorization_key_secondary", authKeys.AuthKey2)
+
+	return nil
+}
@@ -0,0 +1,125 @@
+package synapse_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/acceptance"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/acceptance/check"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/clients"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/synapse/parse"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tf/pluginsdk"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+type SynapseIntegrationRuntimeSelfHostedResource struct{}
+
+func TestAccSynapseIntegrationRuntimeSelfHosted_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_synapse_integration_runtime_self_hosted", "test")
+	r := SynapseIntegrationRuntimeSelfHostedResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("authorization_key_primary").Exists(),
+				check.That(data.ResourceName).Key("authorization_key_secondary").Exists(),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccSynapseIntegrationRuntimeSelfHosted_requiresImport(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_synapse_integration_runtime_self_hosted", "test")
+	r := SynapseIntegrationRuntimeSelfHostedResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.RequiresImportErrorStep(r.requiresImport),
+	})
+}
+
+func TestAccSynapseIntegrationRuntimeSelfHosted_update(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_synapse_integration_runtime_self_hosted", "test")
+	r := SynapseIntegrationRuntimeSelfHostedResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.complete(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("description").HasValue("test description"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func (r SynapseIntegrationRuntimeSelfHostedResource) Exists(ctx context.Context, client *clients.Client, state *pluginsdk.InstanceState) (*bool, error) {
+	id, err := parse.IntegrationRuntimeID(state.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.Synapse.IntegrationRuntimesClient.Get(ctx, id.ResourceGroup, id.WorkspaceName, id.Name, "")
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			return utils.Bool(false), nil
+		}
+		return nil, fmt.Errorf("retrieving %s: %+v", *id, err)
+	}
+
+	return utils.Bool(true), nil
+}
+
+func (r SynapseIntegrationRuntimeSelfHostedResource) basic(data acceptance.TestData) string {
+	template := synapseIntegrationRuntimeTemplate(data, false)
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_synapse_integration_runtime_self_hosted" "test" {
+  name                 = "acctestir%d"
+  synapse_workspace_id = azurerm_synapse_workspace.test.id
+}
+`, template, data.RandomInteger)
+}
+
+func (r SynapseIntegrationRuntimeSelfHostedResource) complete(data acceptance.TestData) string {
+	template := synapseIntegrationRuntimeTemplate(data, false)
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_synapse_integration_runtime_self_hosted" "test" {
+  name                 = "acctestir%d"
+  synapse_workspace_id = azurerm_synapse_workspace.test.id
+  description          = "test description"
+}
+`, template, data.RandomInteger)
+}
+
+func (r SynapseIntegrationRuntimeSelfHostedResource) requiresImport(data acceptance.TestData) string {
+	config := r.basic(data)
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_synapse_integration_runtime_self_hosted" "import" {
+  name                 = azurerm_synapse_integration_runtime_self_hosted.test.name
+  synapse_workspace_id = azurerm_synapse_integration_runtime_self_hosted.test.synapse_workspace_id
+}
+`, config)
+}
@@ -0,0 +1,373 @@
+package synapse
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/preview/synapse/2019-06-01-preview/artifacts"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/tf"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/clients"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/synapse/parse"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/synapse/validate"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tf/pluginsdk"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tf/validation"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/timeouts"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+func resourceSynapseLinkedService() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		Create: resourceSynapseLinkedServiceCreateUpdate,
+		Read:   resourceSynapseLinkedServiceRead,
+		Update: resourceSynapseLinkedServiceCreateUpdate,
+		Delete: resourceSynapseLinkedServiceDelete,
+
+		Importer: pluginsdk.ImporterValidatingResourceId(func(id string) error {
+			_, err := parse.LinkedServiceID(id)
+			return err
+		}),
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Create: pluginsdk.DefaultTimeout(30 * time.Minute),
+			Read:   pluginsdk.DefaultTimeout(5 * time.Minute),
+			Update: pluginsdk.DefaultTimeout(30 * time.Minute),
+			Delete: pluginsdk.DefaultTimeout(30 * time.Minute),
+		},
+
+		Schema: map[string]*pluginsdk.Schema{
+			"name": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"synapse_workspace_id": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validate.WorkspaceID,
+			},
+
+			"type": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"type_properties_json": {
+				Type:             pluginsdk.TypeString,
+				Required:         true,
+				StateFunc:        utils.NormalizeJson,
+				DiffSuppressFunc: suppressJsonOrderingDifference,
+				ValidateFunc:     validation.StringIsJSON,
+			},
+
+			"description": {
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"integration_runtime": {
+				Type:     pluginsdk.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"name": {
+							Type:         pluginsdk.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
+
+						"parameters": {
+							Type:     pluginsdk.TypeMap,
+							Optional: true,
+							Elem: &pluginsdk.Schema{
+								Type: pluginsdk.TypeString,
+							},
+						},
+					},
+				},
+			},
+
+			"parameters": {
+				Type:     pluginsdk.TypeMap,
+				Optional: true,
+				Elem: &pluginsdk.Schema{
+					Type: pluginsdk.TypeString,
+				},
+			},
+
+			"annotations": {
+				Type:     pluginsdk.TypeList,
+				Optional: true,
+				Elem: &pluginsdk.Schema{
+					Type: pluginsdk.TypeString,
+				},
+			},
+
+			"additional_properties": {
+				Type:     pluginsdk.TypeMap,
+				Optional: true,
+				Elem: &pluginsdk.Schema{
+					Type: pluginsdk.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func resourceSynapseLinkedServiceCreateUpdate(d *pluginsdk.ResourceData, meta interface{}) error {
+	synapseClient := meta.(*clients.Client).Synapse
+	ctx, cancel := timeouts.ForCreateUpdate(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+	environment := meta.(*clients.Client).Account.Environment
+
+	workspaceId, err := parse.WorkspaceID(d.Get("synapse_workspace_id").(string))
+	if err != nil {
+		return err
+	}
+
+	id := parse.NewLinkedServiceID(workspaceId.SubscriptionId, workspaceId.ResourceGroup, workspaceId.Name, d.Get("name").(string))
+
+	timeout := d.Timeout(pluginsdk.TimeoutUpdate)
+	if d.IsNewResource() {
+		timeout = d.Timeout(pluginsdk.TimeoutCreate)
+	}
+	if err := waitForSynapseWorkspaceReady(ctx, meta, *workspaceId, true, timeout); err != nil {
+		return err
+	}
+
+	client, err := synapseClient.LinkedServiceClient(workspaceId.Name, environment.SynapseEndpointSuffix)
+	if err != nil {
+		return err
+	}
+
+	if d.IsNewResource() {
+		existing, err := client.GetLinkedService(ctx, id.Name, "")
+		if err != nil {
+			if !utils.ResponseWasNotFound(existing.Response) {
+				return fmt.Errorf("checking for presence of existing %s: %+v", id, err)
+			}
+		}
+		if !utils.ResponseWasNotFound(existing.Response) {
+			return tf.ImportAsExistsError("azurerm_synapse_linked_service", id.ID())
+		}
+	}
+
+	props := map[string]interface{}{
+		"type":       d.Get("type").(string),
+		"connectVia": expandSynapseLinkedServiceIntegrationRuntime(d.Get("integration_runtime").([]interface{})),
+	}
+
+	jsonDataStr := fmt.Sprintf(`{ "typeProperties": %s }`, d.Get("type_properties_json").(string))
+	if err = json.Unmarshal([]byte(jsonDataStr), &props); err != nil {
+		return err
+	}
+
+	if v, ok := d.GetOk("description"); ok {
+		props["description"] = v.(string)
+	}
+
+	if v, ok := d.GetOk("parameters"); ok {
+		props["parameters"] = expandSynapseParameters(v.(map[string]interface{}))
+	}
+
+	if v, ok := d.GetOk("annotations"); ok {
+		props["annotations"] = v.([]interface{})
+	}
+
+	additionalProperties := d.Get("additional_properties").(map[string]interface{})
+	for k, v := range additionalProperties {
+		props[k] = v
+	}
+
+	jsonData, err := json.Marshal(map[string]interface{}{
+		"properties": props,
+	})
+	if err != nil {
+		return err
+	}
+
+	linkedService := &artifacts.LinkedServiceResource{}
+	if err := linkedService.UnmarshalJSON(jsonData); err != nil {
+		return err
+	}
+
+	future, err := client.CreateOrUpdateLinkedService(ctx, id.Name, *linkedService, "")
+	if err != nil {
+		return fmt.Errorf("creating/updating %s: %+v", id, err)
+	}
+	if err = future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		return fmt.Errorf("waiting for creation/update of %s: %+v", id, err)
+	}
+
+	d.SetId(id.ID())
+
+	return resourceSynapseLinkedServiceRead(d, meta)
+}
+
+func resourceSynapseLinkedServiceRead(d *pluginsdk.ResourceData, meta interface{}) error {
+	synapseClient := meta.(*clients.Client).Synapse
+	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+	environment := meta.(*clients.Client).Account.Environment
+
+	id, err := parse.LinkedServiceID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	client, err := synapseClient.LinkedServiceClient(id.WorkspaceName, environment.SynapseEndpointSuffix)
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.GetLinkedService(ctx, id.Name, "")
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			log.Printf("[DEBUG] %s was not found - removing from state", *id)
+			d.SetId("")
+			return nil
+		}
+
+		return fmt.Errorf("retrieving %s: %+v", *id, err)
+	}
+
+	d.Set("name", id.Name)
+	d.Set("synapse_workspace_id", parse.NewWorkspaceID(id.SubscriptionId, id.ResourceGroup, id.WorkspaceName).ID())
+
+	byteArr, err := json.Marshal(resp.Properties)
+	if err != nil {
+		return err
+	}
+
+	var m map[string]*json.RawMessage
+	if err = json.Unmarshal(byteArr, &m); err != nil {
+		return err
+	}
+
+	description := ""
+	if v, ok := m["description"]; ok && v != nil {
+		if err := json.Unmarshal(*v, &description); err != nil {
+			return err
+		}
+		delete(m, "description")
+	}
+	d.Set("description", description)
+
+	t := ""
+	if v, ok := m["type"]; ok && v != nil {
+		if err := json.Unmarshal(*v, &t); err != nil {
+			return err
+		}
+		delete(m, "type")
+	}
+	d.Set("type", t)
+
+	annotations := make([]interface{}, 0)
+	if v, ok := m["annotations"]; ok && v != nil {
+		if err := json.Unmarshal(*v, &annotations); err != nil {
+			return err
+		}
+		delete(m, "annotations")
+	}
+	d.Set("annotations", annotations)
+
+	parameters := make(map[string]*artifacts.ParameterSpecification)
+	if v, ok := m["parameters"]; ok && v != nil {
+		if err := json.Unmarshal(*v, &parameters); err != nil {
+			return err
+		}
+		delete(m, "parameters")
+	}
+	if err := d.Set("parameters", flattenSynapseParameters(parameters)); err != nil {
+		return fmt.Errorf("setting `parameters`: %+v", err)
+	}
+
+	var integrationRuntime *artifacts.IntegrationRuntimeReference
+	if v, ok := m["connectVia"]; ok && v != nil {
+		integrationRuntime = &artifacts.IntegrationRuntimeReference{}
+		if err := json.Unmarshal(*v, &integrationRuntime); err != nil {
+			return err
+		}
+		delete(m, "connectVia")
+	}
+	if err := d.Set("integration_runtime", flattenSynapseLinkedServiceIntegrationRuntime(integrationRuntime)); err != nil {
+		return fmt.Errorf("setting `integration_runtime`: %+v", err)
+	}
+
+	// the secrets within `typeProperties` are not returned by the API, so this is intentionally not set
+	delete(m, "typeProperties")
+
+	additionalProperties := make(map[string]interface{})
+	for k, v := range m {
+		additionalProperties[k] = v
+	}
+	d.Set("additional_properties", additionalProperties)
+
+	return nil
+}
+
+func resourceSynapseLinkedServiceDelete(d *pluginsdk.ResourceData, meta interface{}) error {
+	synapseClient := meta.(*clients.Client).Synapse
+	ctx, cancel := timeouts.ForDelete(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+	environment := meta.(*clients.Client).Account.Environment
+
+	id, err := parse.LinkedServiceID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	client, err := synapseClient.LinkedServiceClient(id.WorkspaceName, environment.SynapseEndpointSuffix)
+	if err != nil {
+		return err
+	}
+
+	future, err := client.DeleteLinkedService(ctx, id.Name)
+	if err != nil {
+		return fmt.Errorf("deleting %s: %+v", *id, err)
+	}
+	if err = future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		return fmt.Errorf("waiting for deletion of %s: %+v", *id, err)
+	}
+
+	return nil
+}
+
+func expandSynapseLinkedServiceIntegrationRuntime(input []interface{}) *artifacts.IntegrationRuntimeReference {
+	if len(input) == 0 || input[0] == nil {
+		return nil
+	}
+
+	v := input[0].(map[string]interface{})
+	return &artifacts.IntegrationRuntimeReference{
+		ReferenceName: utils.String(v["name"].(string)),
+		Type:          utils.String("IntegrationRuntimeReference"),
+		Parameters:    v["parameters"].(map[string]interface{}),
+	}
+}
+
+func flattenSynapseLinkedServiceIntegrationRuntime(input *artifacts.IntegrationRuntimeReference) []interface{} {
+	if input == nil {
+		return []interface{}{}
+	}
+
+	name := ""
+	if input.ReferenceName != nil {
+		name = *input.ReferenceName
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"name":       name,
+			"parameters": input.Parameters,
+		},
+	}
+}
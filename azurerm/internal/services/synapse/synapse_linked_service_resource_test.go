@@ -0,0 +1,215 @@
+package synapse_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/acceptance"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/acceptance/check"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/clients"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/synapse/parse"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tf/pluginsdk"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+type SynapseLinkedServiceResource struct{}
+
+func TestAccSynapseLinkedService_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_synapse_linked_service", "test")
+	r := SynapseLinkedServiceResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep("type_properties_json"),
+	})
+}
+
+func TestAccSynapseLinkedService_requiresImport(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_synapse_linked_service", "test")
+	r := SynapseLinkedServiceResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.RequiresImportErrorStep(r.requiresImport),
+	})
+}
+
+func TestAccSynapseLinkedService_update(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_synapse_linked_service", "test")
+	r := SynapseLinkedServiceResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep("type_properties_json"),
+		{
+			Config: r.complete(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("description").HasValue("test description"),
+				check.That(data.ResourceName).Key("annotations.#").HasValue("2"),
+				check.That(data.ResourceName).Key("parameters.%").HasValue("1"),
+				check.That(data.ResourceName).Key("integration_runtime.0.name").HasValue(fmt.Sprintf("acctestir%d", data.RandomInteger)),
+			),
+		},
+		data.ImportStep("type_properties_json"),
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep("type_properties_json"),
+	})
+}
+
+func (r SynapseLinkedServiceResource) Exists(ctx context.Context, client *clients.Client, state *pluginsdk.InstanceState) (*bool, error) {
+	id, err := parse.LinkedServiceID(state.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	environment := client.Account.Environment
+	linkedServiceClient, err := client.Synapse.LinkedServiceClient(id.WorkspaceName, environment.SynapseEndpointSuffix)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := linkedServiceClient.GetLinkedService(ctx, id.Name, "")
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			return utils.Bool(false), nil
+		}
+		return nil, fmt.Errorf("retrieving %s: %+v", *id, err)
+	}
+
+	return utils.Bool(true), nil
+}
+
+func (r SynapseLinkedServiceResource) basic(data acceptance.TestData) string {
+	template := synapseDataPlaneTemplate(data)
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_synapse_linked_service" "test" {
+  name                 = "acctestls%d"
+  synapse_workspace_id = azurerm_synapse_workspace.test.id
+  type                 = "AzureBlobStorage"
+  type_properties_json = <<JSON
+{
+  "connectionString": "${azurerm_storage_account.test.primary_connection_string}"
+}
+JSON
+
+  depends_on = [azurerm_synapse_firewall_rule.test]
+}
+`, template, data.RandomInteger)
+}
+
+func (r SynapseLinkedServiceResource) complete(data acceptance.TestData) string {
+	template := synapseDataPlaneTemplate(data)
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_synapse_integration_runtime_azure" "test" {
+  name                 = "acctestir%d"
+  synapse_workspace_id = azurerm_synapse_workspace.test.id
+  location             = azurerm_resource_group.test.location
+}
+
+resource "azurerm_synapse_linked_service" "test" {
+  name                 = "acctestls%d"
+  synapse_workspace_id = azurerm_synapse_workspace.test.id
+  type                 = "AzureBlobStorage"
+  type_properties_json = <<JSON
+{
+  "connectionString": "${azurerm_storage_account.test.primary_connection_string}"
+}
+JSON
+  description = "test description"
+  annotations = ["test1", "test2"]
+
+  parameters = {
+    foo = "bar"
+  }
+
+  integration_runtime {
+    name = azurerm_synapse_integration_runtime_azure.test.name
+  }
+
+  depends_on = [azurerm_synapse_firewall_rule.test]
+}
+`, template, data.RandomInteger, data.RandomInteger)
+}
+
+func (r SynapseLinkedServiceResource) requiresImport(data acceptance.TestData) string {
+	config := r.basic(data)
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_synapse_linked_service" "import" {
+  name                 = azurerm_synapse_linked_service.test.name
+  synapse_workspace_id = azurerm_synapse_linked_service.test.synapse_workspace_id
+  type                 = azurerm_synapse_linked_service.test.type
+  type_properties_json = azurerm_synapse_linked_service.test.type_properties_json
+}
+`, config)
+}
+
+func synapseDataPlaneTemplate(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-synapse-%d"
+  location = "%s"
+}
+
+resource "azurerm_storage_account" "test" {
+  name                     = "acctestacc%s"
+  resource_group_name      = azurerm_resource_group.test.name
+  location                 = azurerm_resource_group.test.location
+  account_kind             = "BlobStorage"
+  account_tier             = "Standard"
+  account_replication_type = "LRS"
+}
+
+resource "azurerm_storage_data_lake_gen2_filesystem" "test" {
+  name               = "acctest-%d"
+  storage_account_id = azurerm_storage_account.test.id
+}
+
+resource "azurerm_synapse_workspace" "test" {
+  name                                 = "acctestsw%d"
+  resource_group_name                  = azurerm_resource_group.test.name
+  location                             = azurerm_resource_group.test.location
+  storage_data_lake_gen2_filesystem_id = azurerm_storage_data_lake_gen2_filesystem.test.id
+  sql_administrator_login              = "sqladminuser"
+  sql_administrator_login_password     = "H@Sh1CoR3!"
+  managed_virtual_network_enabled      = true
+}
+
+resource "azurerm_synapse_firewall_rule" "test" {
+  name                 = "AllowAll"
+  synapse_workspace_id = azurerm_synapse_workspace.test.id
+  start_ip_address     = "0.0.0.0"
+  end_ip_address       = "255.255.255.255"
+}
+`, data.RandomInteger, data.Locations.Primary, data.RandomString, data.RandomInteger, data.RandomInteger)
+}
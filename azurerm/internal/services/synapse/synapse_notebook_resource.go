@@ -0,0 +1,411 @@
+package synapse
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/preview/synapse/2019-06-01-preview/artifacts"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/tf"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/clients"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/synapse/parse"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/synapse/validate"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tf/pluginsdk"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tf/validation"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/timeouts"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+func resourceSynapseNotebook() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		Create: resourceSynapseNotebookCreateUpdate,
+		Read:   resourceSynapseNotebookRead,
+		Update: resourceSynapseNotebookCreateUpdate,
+		Delete: resourceSynapseNotebookDelete,
+
+		Importer: pluginsdk.ImporterValidatingResourceId(func(id string) error {
+			_, err := parse.NotebookID(id)
+			return err
+		}),
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Create: pluginsdk.DefaultTimeout(30 * time.Minute),
+			Read:   pluginsdk.DefaultTimeout(5 * time.Minute),
+			Update: pluginsdk.DefaultTimeout(30 * time.Minute),
+			Delete: pluginsdk.DefaultTimeout(30 * time.Minute),
+		},
+
+		Schema: map[string]*pluginsdk.Schema{
+			"name": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"synapse_workspace_id": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validate.WorkspaceID,
+			},
+
+			"content_json": {
+				Type:             pluginsdk.TypeString,
+				Required:         true,
+				StateFunc:        utils.NormalizeJson,
+				DiffSuppressFunc: suppressJsonOrderingDifference,
+				ValidateFunc:     validation.StringIsJSON,
+			},
+
+			"spark_pool_id": {
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				ValidateFunc: validate.SparkPoolID,
+			},
+
+			"session": {
+				Type:         pluginsdk.TypeList,
+				Optional:     true,
+				Computed:     true,
+				MaxItems:     1,
+				RequiredWith: []string{"spark_pool_id"},
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"driver_memory": {
+							Type:         pluginsdk.TypeString,
+							Optional:     true,
+							Default:      "28g",
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
+
+						"driver_cores": {
+							Type:         pluginsdk.TypeInt,
+							Optional:     true,
+							Default:      4,
+							ValidateFunc: validation.IntAtLeast(1),
+						},
+
+						"executor_memory": {
+							Type:         pluginsdk.TypeString,
+							Optional:     true,
+							Default:      "28g",
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
+
+						"executor_cores": {
+							Type:         pluginsdk.TypeInt,
+							Optional:     true,
+							Default:      4,
+							ValidateFunc: validation.IntAtLeast(1),
+						},
+
+						"number_of_executors": {
+							Type:         pluginsdk.TypeInt,
+							Optional:     true,
+							Default:      2,
+							ValidateFunc: validation.IntAtLeast(1),
+						},
+					},
+				},
+			},
+
+			"description": {
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+		},
+	}
+}
+
+func resourceSynapseNotebookCreateUpdate(d *pluginsdk.ResourceData, meta interface{}) error {
+	synapseClient := meta.(*clients.Client).Synapse
+	ctx, cancel := timeouts.ForCreateUpdate(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+	environment := meta.(*clients.Client).Account.Environment
+
+	workspaceId, err := parse.WorkspaceID(d.Get("synapse_workspace_id").(string))
+	if err != nil {
+		return err
+	}
+
+	id := parse.NewNotebookID(workspaceId.SubscriptionId, workspaceId.ResourceGroup, workspaceId.Name, d.Get("name").(string))
+
+	notebook := artifacts.Notebook{}
+	if err := json.Unmarshal([]byte(d.Get("content_json").(string)), &notebook); err != nil {
+		return fmt.Errorf("parsing `content_json`: %+v", err)
+	}
+	if notebook.Cells == nil {
+		notebook.Cells = &[]artifacts.NotebookCell{}
+	}
+	if notebook.Metadata == nil {
+		notebook.Metadata = &artifacts.NotebookMetadata{}
+	}
+
+	if v, ok := d.GetOk("spark_pool_id"); ok {
+		sparkPoolId, err := parse.SparkPoolID(v.(string))
+		if err != nil {
+			return err
+		}
+		if sparkPoolId.ResourceGroup != workspaceId.ResourceGroup || sparkPoolId.WorkspaceName != workspaceId.Name {
+			return fmt.Errorf("the Spark Pool specified in `spark_pool_id` must belong to the Synapse Workspace %q (Resource Group %q)", workspaceId.Name, workspaceId.ResourceGroup)
+		}
+		notebook.BigDataPool = &artifacts.BigDataPoolReference{
+			Type:          utils.String("BigDataPoolReference"),
+			ReferenceName: utils.String(sparkPoolId.BigDataPoolName),
+		}
+		notebook.SessionProperties = expandSynapseNotebookSessionProperties(d.Get("session").([]interface{}))
+	}
+
+	if v, ok := d.GetOk("description"); ok {
+		notebook.Description = utils.String(v.(string))
+	}
+
+	timeout := d.Timeout(pluginsdk.TimeoutUpdate)
+	if d.IsNewResource() {
+		timeout = d.Timeout(pluginsdk.TimeoutCreate)
+	}
+	if err := waitForSynapseWorkspaceReady(ctx, meta, *workspaceId, true, timeout); err != nil {
+		return err
+	}
+
+	client, err := synapseClient.NotebookClient(workspaceId.Name, environment.SynapseEndpointSuffix)
+	if err != nil {
+		return err
+	}
+
+	if d.IsNewResource() {
+		existing, err := client.GetNotebook(ctx, id.Name, "")
+		if err != nil {
+			if !utils.ResponseWasNotFound(existing.Response) {
+				return fmt.Errorf("checking for presence of existing %s: %+v", id, err)
+			}
+		}
+		if !utils.ResponseWasNotFound(existing.Response) {
+			return tf.ImportAsExistsError("azurerm_synapse_notebook", id.ID())
+		}
+	}
+
+	notebookResource := artifacts.NotebookResource{
+		Name:       utils.String(id.Name),
+		Properties: &notebook,
+	}
+
+	future, err := client.CreateOrUpdateNotebook(ctx, id.Name, notebookResource, "")
+	if err != nil {
+		return fmt.Errorf("creating/updating %s: %+v", id, err)
+	}
+	if err = future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		return fmt.Errorf("waiting for creation/update of %s: %+v", id, err)
+	}
+
+	d.SetId(id.ID())
+
+	return resourceSynapseNotebookRead(d, meta)
+}
+
+func resourceSynapseNotebookRead(d *pluginsdk.ResourceData, meta interface{}) error {
+	synapseClient := meta.(*clients.Client).Synapse
+	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+	environment := meta.(*clients.Client).Account.Environment
+
+	id, err := parse.NotebookID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	client, err := synapseClient.NotebookClient(id.WorkspaceName, environment.SynapseEndpointSuffix)
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.GetNotebook(ctx, id.Name, "")
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			log.Printf("[DEBUG] %s was not found - removing from state", *id)
+			d.SetId("")
+			return nil
+		}
+
+		return fmt.Errorf("retrieving %s: %+v", *id, err)
+	}
+
+	d.Set("name", id.Name)
+	d.Set("synapse_workspace_id", parse.NewWorkspaceID(id.SubscriptionId, id.ResourceGroup, id.WorkspaceName).ID())
+
+	if props := resp.Properties; props != nil {
+		d.Set("description", props.Description)
+
+		sparkPoolId := ""
+		if props.BigDataPool != nil && props.BigDataPool.ReferenceName != nil {
+			sparkPoolId = parse.NewSparkPoolID(id.SubscriptionId, id.ResourceGroup, id.WorkspaceName, *props.BigDataPool.ReferenceName).ID()
+		}
+		d.Set("spark_pool_id", sparkPoolId)
+
+		if err := d.Set("session", flattenSynapseNotebookSessionProperties(props.SessionProperties)); err != nil {
+			return fmt.Errorf("setting `session`: %+v", err)
+		}
+
+		content, err := flattenSynapseNotebookContent(props, d.Get("content_json").(string))
+		if err != nil {
+			return fmt.Errorf("flattening `content_json`: %+v", err)
+		}
+		d.Set("content_json", content)
+	}
+
+	return nil
+}
+
+func resourceSynapseNotebookDelete(d *pluginsdk.ResourceData, meta interface{}) error {
+	synapseClient := meta.(*clients.Client).Synapse
+	ctx, cancel := timeouts.ForDelete(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+	environment := meta.(*clients.Client).Account.Environment
+
+	id, err := parse.NotebookID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	client, err := synapseClient.NotebookClient(id.WorkspaceName, environment.SynapseEndpointSuffix)
+	if err != nil {
+		return err
+	}
+
+	future, err := client.DeleteNotebook(ctx, id.Name)
+	if err != nil {
+		return fmt.Errorf("deleting %s: %+v", *id, err)
+	}
+	if err = future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		return fmt.Errorf("waiting for deletion of %s: %+v", *id, err)
+	}
+
+	return nil
+}
+
+func expandSynapseNotebookSessionProperties(input []interface{}) *artifacts.NotebookSessionProperties {
+	// a session is required when attaching a Spark Pool, so fall back to the defaults when it's omitted
+	v := map[string]interface{}{
+		"driver_memory":       "28g",
+		"driver_cores":        4,
+		"executor_memory":     "28g",
+		"executor_cores":      4,
+		"number_of_executors": 2,
+	}
+	if len(input) > 0 && input[0] != nil {
+		v = input[0].(map[string]interface{})
+	}
+
+	return &artifacts.NotebookSessionProperties{
+		DriverMemory:   utils.String(v["driver_memory"].(string)),
+		DriverCores:    utils.Int32(int32(v["driver_cores"].(int))),
+		ExecutorMemory: utils.String(v["executor_memory"].(string)),
+		ExecutorCores:  utils.Int32(int32(v["executor_cores"].(int))),
+		NumExecutors:   utils.Int32(int32(v["number_of_executors"].(int))),
+	}
+}
+
+func flattenSynapseNotebookSessionProperties(input *artifacts.NotebookSessionProperties) []interface{} {
+	if input == nil {
+		return make([]interface{}, 0)
+	}
+
+	driverMemory := ""
+	if input.DriverMemory != nil {
+		driverMemory = *input.DriverMemory
+	}
+	driverCores := 0
+	if input.DriverCores != nil {
+		driverCores = int(*input.DriverCores)
+	}
+	executorMemory := ""
+	if input.ExecutorMemory != nil {
+		executorMemory = *input.ExecutorMemory
+	}
+	executorCores := 0
+	if input.ExecutorCores != nil {
+		executorCores = int(*input.ExecutorCores)
+	}
+	numberOfExecutors := 0
+	if input.NumExecutors != nil {
+		numberOfExecutors = int(*input.NumExecutors)
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"driver_memory":       driverMemory,
+			"driver_cores":        driverCores,
+			"executor_memory":     executorMemory,
+			"executor_cores":      executorCores,
+			"number_of_executors": numberOfExecutors,
+		},
+	}
+}
+
+// flattenSynapseNotebookContent returns the notebook document without the properties exposed as separate fields. The
+// service adds its own keys to the document (e.g. compute options in the metadata), so when a document is already
+// known only the keys present within it are kept
+func flattenSynapseNotebookContent(input *artifacts.Notebook, existing string) (string, error) {
+	b, err := json.Marshal(input)
+	if err != nil {
+		return "", err
+	}
+
+	var content map[string]interface{}
+	if err := json.Unmarshal(b, &content); err != nil {
+		return "", err
+	}
+	delete(content, "description")
+	delete(content, "bigDataPool")
+	delete(content, "sessionProperties")
+
+	var result interface{} = content
+	if existing != "" {
+		var configured interface{}
+		if err := json.Unmarshal([]byte(existing), &configured); err == nil {
+			result = filterSynapseNotebookContent(content, configured)
+		}
+	}
+
+	b, err = json.Marshal(result)
+	if err != nil {
+		return "", err
+	}
+
+	return utils.NormalizeJson(string(b)), nil
+}
+
+func filterSynapseNotebookContent(remote interface{}, configured interface{}) interface{} {
+	switch r := remote.(type) {
+	case map[string]interface{}:
+		c, ok := configured.(map[string]interface{})
+		if !ok {
+			return remote
+		}
+
+		output := make(map[string]interface{})
+		for k, v := range r {
+			if cv, ok := c[k]; ok {
+				output[k] = filterSynapseNotebookContent(v, cv)
+			}
+		}
+		return output
+
+	case []interface{}:
+		c, ok := configured.([]interface{})
+		if !ok || len(c) != len(r) {
+			return remote
+		}
+
+		output := make([]interface{}, 0, len(r))
+		for i, v := range r {
+			output = append(output, filterSynapseNotebookContent(v, c[i]))
+		}
+		return output
+	}
+
+	return remote
+}
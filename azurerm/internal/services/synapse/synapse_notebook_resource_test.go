@@ -0,0 +1,193 @@
+package synapse_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/acceptance"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/acceptance/check"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/clients"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/synapse/parse"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tf/pluginsdk"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+type SynapseNotebookResource struct{}
+
+func TestAccSynapseNotebook_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_synapse_notebook", "test")
+	r := SynapseNotebookResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep("content_json"),
+	})
+}
+
+func TestAccSynapseNotebook_requiresImport(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_synapse_notebook", "test")
+	r := SynapseNotebookResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.RequiresImportErrorStep(r.requiresImport),
+	})
+}
+
+func TestAccSynapseNotebook_update(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_synapse_notebook", "test")
+	r := SynapseNotebookResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep("content_json"),
+		{
+			Config: r.complete(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("description").HasValue("test description"),
+				check.That(data.ResourceName).Key("session.0.number_of_executors").HasValue("3"),
+			),
+		},
+		data.ImportStep("content_json"),
+	})
+}
+
+func (r SynapseNotebookResource) Exists(ctx context.Context, client *clients.Client, state *pluginsdk.InstanceState) (*bool, error) {
+	id, err := parse.NotebookID(state.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	environment := client.Account.Environment
+	notebookClient, err := client.Synapse.NotebookClient(id.WorkspaceName, environment.SynapseEndpointSuffix)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := notebookClient.GetNotebook(ctx, id.Name, "")
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			return utils.Bool(false), nil
+		}
+		return nil, fmt.Errorf("retrieving %s: %+v", *id, err)
+	}
+
+	return utils.Bool(true), nil
+}
+
+func (r SynapseNotebookResource) basic(data acceptance.TestData) string {
+	template := synapseDataPlaneTemplate(data)
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_synapse_notebook" "test" {
+  name                 = "acctestnb%d"
+  synapse_workspace_id = azurerm_synapse_workspace.test.id
+  content_json         = <<JSON
+{
+  "nbformat": 4,
+  "nbformat_minor": 2,
+  "metadata": {
+    "language_info": {
+      "name": "python"
+    }
+  },
+  "cells": [
+    {
+      "cell_type": "code",
+      "metadata": {},
+      "source": [
+        "print('hello world')"
+      ],
+      "outputs": []
+    }
+  ]
+}
+JSON
+
+  depends_on = [azurerm_synapse_firewall_rule.test]
+}
+`, template, data.RandomInteger)
+}
+
+func (r SynapseNotebookResource) complete(data acceptance.TestData) string {
+	template := synapseDataPlaneTemplate(data)
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_synapse_spark_pool" "test" {
+  name                 = "acctestSSP%s"
+  synapse_workspace_id = azurerm_synapse_workspace.test.id
+  node_size_family     = "MemoryOptimized"
+  node_size            = "Small"
+  node_count           = 3
+}
+
+resource "azurerm_synapse_notebook" "test" {
+  name                 = "acctestnb%d"
+  synapse_workspace_id = azurerm_synapse_workspace.test.id
+  content_json         = <<JSON
+{
+  "nbformat": 4,
+  "nbformat_minor": 2,
+  "metadata": {
+    "language_info": {
+      "name": "python"
+    }
+  },
+  "cells": [
+    {
+      "cell_type": "code",
+      "metadata": {},
+      "source": [
+        "print('hello terraform')"
+      ],
+      "outputs": []
+    }
+  ]
+}
+JSON
+  spark_pool_id = azurerm_synapse_spark_pool.test.id
+  description   = "test description"
+
+  session {
+    driver_memory       = "28g"
+    driver_cores        = 4
+    executor_memory     = "28g"
+    executor_cores      = 4
+    number_of_executors = 3
+  }
+
+  depends_on = [azurerm_synapse_firewall_rule.test]
+}
+`, template, data.RandomString, data.RandomInteger)
+}
+
+func (r SynapseNotebookResource) requiresImport(data acceptance.TestData) string {
+	config := r.basic(data)
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_synapse_notebook" "import" {
+  name                 = azurerm_synapse_notebook.test.name
+  synapse_workspace_id = azurerm_synapse_notebook.test.synapse_workspace_id
+  content_json         = azurerm_synapse_notebook.test.content_json
+}
+`, config)
+}
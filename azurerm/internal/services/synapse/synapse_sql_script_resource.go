@@ -0,0 +1,231 @@
+package synapse
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/preview/synapse/2019-06-01-preview/artifacts"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/tf"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/clients"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/synapse/parse"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/synapse/validate"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tf/pluginsdk"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tf/validation"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/timeouts"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+// the name of the serverless SQL pool which is available within every Synapse Workspace
+const synapseBuiltInSqlPoolName = "Built-in"
+
+func resourceSynapseSqlScript() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		Create: resourceSynapseSqlScriptCreateUpdate,
+		Read:   resourceSynapseSqlScriptRead,
+		Update: resourceSynapseSqlScriptCreateUpdate,
+		Delete: resourceSynapseSqlScriptDelete,
+
+		Importer: pluginsdk.ImporterValidatingResourceId(func(id string) error {
+			_, err := parse.SqlScriptID(id)
+			return err
+		}),
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Create: pluginsdk.DefaultTimeout(30 * time.Minute),
+			Read:   pluginsdk.DefaultTimeout(5 * time.Minute),
+			Update: pluginsdk.DefaultTimeout(30 * time.Minute),
+			Delete: pluginsdk.DefaultTimeout(30 * time.Minute),
+		},
+
+		Schema: map[string]*pluginsdk.Schema{
+			"name": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"synapse_workspace_id": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validate.WorkspaceID,
+			},
+
+			"query": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"sql_pool_id": {
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				ValidateFunc: validate.SqlPoolID,
+			},
+
+			"description": {
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+		},
+	}
+}
+
+func resourceSynapseSqlScriptCreateUpdate(d *pluginsdk.ResourceData, meta interface{}) error {
+	synapseClient := meta.(*clients.Client).Synapse
+	ctx, cancel := timeouts.ForCreateUpdate(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+	environment := meta.(*clients.Client).Account.Environment
+
+	workspaceId, err := parse.WorkspaceID(d.Get("synapse_workspace_id").(string))
+	if err != nil {
+		return err
+	}
+
+	id := parse.NewSqlScriptID(workspaceId.SubscriptionId, workspaceId.ResourceGroup, workspaceId.Name, d.Get("name").(string))
+
+	connection := &artifacts.SQLConnection{
+		Type: artifacts.SQLConnectionTypeSQLOnDemand,
+		Name: utils.String(synapseBuiltInSqlPoolName),
+	}
+	if v, ok := d.GetOk("sql_pool_id"); ok {
+		sqlPoolId, err := parse.SqlPoolID(v.(string))
+		if err != nil {
+			return err
+		}
+		if sqlPoolId.ResourceGroup != workspaceId.ResourceGroup || sqlPoolId.WorkspaceName != workspaceId.Name {
+			return fmt.Errorf("the SQL Pool specified in `sql_pool_id` must belong to the Synapse Workspace %q (Resource Group %q)", workspaceId.Name, workspaceId.ResourceGroup)
+		}
+		connection = &artifacts.SQLConnection{
+			Type: artifacts.SQLConnectionTypeSQLPool,
+			Name: utils.String(sqlPoolId.Name),
+		}
+	}
+
+	timeout := d.Timeout(pluginsdk.TimeoutUpdate)
+	if d.IsNewResource() {
+		timeout = d.Timeout(pluginsdk.TimeoutCreate)
+	}
+	if err := waitForSynapseWorkspaceReady(ctx, meta, *workspaceId, true, timeout); err != nil {
+		return err
+	}
+
+	client, err := synapseClient.SqlScriptClient(workspaceId.Name, environment.SynapseEndpointSuffix)
+	if err != nil {
+		return err
+	}
+
+	if d.IsNewResource() {
+		existing, err := client.GetSQLScript(ctx, id.Name, "")
+		if err != nil {
+			if !utils.ResponseWasNotFound(existing.Response) {
+				return fmt.Errorf("checking for presence of existing %s: %+v", id, err)
+			}
+		}
+		if !utils.ResponseWasNotFound(existing.Response) {
+			return tf.ImportAsExistsError("azurerm_synapse_sql_script", id.ID())
+		}
+	}
+
+	sqlScript := artifacts.SQLScriptResource{
+		Name: utils.String(id.Name),
+		Properties: &artifacts.SQLScript{
+			Type: artifacts.SQLQuery,
+			Content: &artifacts.SQLScriptContent{
+				Query:             utils.String(d.Get("query").(string)),
+				CurrentConnection: connection,
+				Metadata: &artifacts.SQLScriptMetadata{
+					Language: utils.String("sql"),
+				},
+			},
+		},
+	}
+	if v, ok := d.GetOk("description"); ok {
+		sqlScript.Properties.Description = utils.String(v.(string))
+	}
+
+	if _, err := client.CreateOrUpdateSQLScript(ctx, id.Name, sqlScript, ""); err != nil {
+		return fmt.Errorf("creating/updating %s: %+v", id, err)
+	}
+
+	d.SetId(id.ID())
+
+	return resourceSynapseSqlScriptRead(d, meta)
+}
+
+func resourceSynapseSqlScriptRead(d *pluginsdk.ResourceData, meta interface{}) error {
+	synapseClient := meta.(*clients.Client).Synapse
+	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+	environment := meta.(*clients.Client).Account.Environment
+
+	id, err := parse.SqlScriptID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	client, err := synapseClient.SqlScriptClient(id.WorkspaceName, environment.SynapseEndpointSuffix)
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.GetSQLScript(ctx, id.Name, "")
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			log.Printf("[DEBUG] %s was not found - removing from state", *id)
+			d.SetId("")
+			return nil
+		}
+
+		return fmt.Errorf("retrieving %s: %+v", *id, err)
+	}
+
+	d.Set("name", id.Name)
+	d.Set("synapse_workspace_id", parse.NewWorkspaceID(id.SubscriptionId, id.ResourceGroup, id.WorkspaceName).ID())
+
+	if props := resp.Properties; props != nil {
+		d.Set("description", props.Description)
+
+		query := ""
+		sqlPoolId := ""
+		if content := props.Content; content != nil {
+			if content.Query != nil {
+				query = *content.Query
+			}
+
+			if connection := content.CurrentConnection; connection != nil && connection.Type == artifacts.SQLConnectionTypeSQLPool && connection.Name != nil {
+				sqlPoolId = parse.NewSqlPoolID(id.SubscriptionId, id.ResourceGroup, id.WorkspaceName, *connection.Name).ID()
+			}
+		}
+		d.Set("query", query)
+		d.Set("sql_pool_id", sqlPoolId)
+	}
+
+	return nil
+}
+
+func resourceSynapseSqlScriptDelete(d *pluginsdk.ResourceData, meta interface{}) error {
+	synapseClient := meta.(*clients.Client).Synapse
+	ctx, cancel := timeouts.ForDelete(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+	environment := meta.(*clients.Client).Account.Environment
+
+	id, err := parse.SqlScriptID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	client, err := synapseClient.SqlScriptClient(id.WorkspaceName, environment.SynapseEndpointSuffix)
+	if err != nil {
+		return err
+	}
+
+	if _, err := client.DeleteSQLScript(ctx, id.Name); err != nil {
+		return fmt.Errorf("deleting %s: %+v", *id, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,151 @@
+package synapse_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/acceptance"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/acceptance/check"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/clients"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/synapse/parse"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tf/pluginsdk"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+type SynapseSqlScriptResource struct{}
+
+func TestAccSynapseSqlScript_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_synapse_sql_script", "test")
+	r := SynapseSqlScriptResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccSynapseSqlScript_requiresImport(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_synapse_sql_script", "test")
+	r := SynapseSqlScriptResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.RequiresImportErrorStep(r.requiresImport),
+	})
+}
+
+func TestAccSynapseSqlScript_update(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_synapse_sql_script", "test")
+	r := SynapseSqlScriptResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.complete(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("description").HasValue("test description"),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("sql_pool_id").HasValue(""),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func (r SynapseSqlScriptResource) Exists(ctx context.Context, client *clients.Client, state *pluginsdk.InstanceState) (*bool, error) {
+	id, err := parse.SqlScriptID(state.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	environment := client.Account.Environment
+	sqlScriptClient, err := client.Synapse.SqlScriptClient(id.WorkspaceName, environment.SynapseEndpointSuffix)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := sqlScriptClient.GetSQLScript(ctx, id.Name, "")
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			return utils.Bool(false), nil
+		}
+		return nil, fmt.Errorf("retrieving %s: %+v", *id, err)
+	}
+
+	return utils.Bool(true), nil
+}
+
+func (r SynapseSqlScriptResource) basic(data acceptance.TestData) string {
+	template := synapseDataPlaneTemplate(data)
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_synapse_sql_script" "test" {
+  name                 = "acctestscript%d"
+  synapse_workspace_id = azurerm_synapse_workspace.test.id
+  query                = "SELECT 1"
+
+  depends_on = [azurerm_synapse_firewall_rule.test]
+}
+`, template, data.RandomInteger)
+}
+
+func (r SynapseSqlScriptResource) complete(data acceptance.TestData) string {
+	template := synapseDataPlaneTemplate(data)
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_synapse_sql_pool" "test" {
+  name                 = "acctestSP%s"
+  synapse_workspace_id = azurerm_synapse_workspace.test.id
+  sku_name             = "DW100c"
+  create_mode          = "Default"
+}
+
+resource "azurerm_synapse_sql_script" "test" {
+  name                 = "acctestscript%d"
+  synapse_workspace_id = azurerm_synapse_workspace.test.id
+  query                = "SELECT TOP 10 * FROM sys.objects"
+  sql_pool_id          = azurerm_synapse_sql_pool.test.id
+  description          = "test description"
+
+  depends_on = [azurerm_synapse_firewall_rule.test]
+}
+`, template, data.RandomString, data.RandomInteger)
+}
+
+func (r SynapseSqlScriptResource) requiresImport(data acceptance.TestData) string {
+	config := r.basic(data)
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_synapse_sql_script" "import" {
+  name                 = azurerm_synapse_sql_script.test.name
+  synapse_workspace_id = azurerm_synapse_sql_script.test.synapse_workspace_id
+  query                = azurerm_synapse_sql_script.test.query
+}
+`, config)
+}
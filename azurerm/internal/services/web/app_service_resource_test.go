@@ -1,9 +1,11 @@
 package web_test
 
 import (
+	"archive/zip"
 	"context"
 	"fmt"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/Azure/azure-sdk-for-go/services/web/mgmt/2020-06-01/web"
@@ -1900,6 +1902,32 @@ func (r AppServiceResource) Exists(ctx context.Context, clients *clients.Client,
 	return utils.Bool(true), nil
 }
 
+func TestAccAppService_zipDeployFile(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_app_service", "test")
+	r := AppServiceResource{}
+	first := writeZipDeployTestPackage(t, "first.zip", "<h1>first</h1>")
+	second := writeZipDeployTestPackage(t, "second.zip", "<h1>second</h1>")
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.zipDeployFile(data, first),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("zip_deploy_file_sha256").Exists(),
+			),
+		},
+		data.ImportStep("zip_deploy_file", "zip_deploy_file_sha256"),
+		{
+			Config: r.zipDeployFile(data, second),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("zip_deploy_file_sha256").Exists(),
+			),
+		},
+		data.ImportStep("zip_deploy_file", "zip_deploy_file_sha256"),
+	})
+}
+
 func (r AppServiceResource) basic(data acceptance.TestData) string {
 	return fmt.Sprintf(`
 provider "azurerm" {
@@ -5387,3 +5415,61 @@ resource "azurerm_app_service" "test" {
 
 `, data.RandomInteger, data.Locations.Primary, data.RandomInteger, data.RandomInteger, data.RandomInteger, data.RandomInteger)
 }
+
+func (r AppServiceResource) zipDeployFile(data acceptance.TestData, path string) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%d"
+  location = "%s"
+}
+
+resource "azurerm_app_service_plan" "test" {
+  name                = "acctestASP-%d"
+  location            = azurerm_resource_group.test.location
+  resource_group_name = azurerm_resource_group.test.name
+
+  sku {
+    tier = "Standard"
+    size = "S1"
+  }
+}
+
+resource "azurerm_app_service" "test" {
+  name                = "acctestAS-%d"
+  location            = azurerm_resource_group.test.location
+  resource_group_name = azurerm_resource_group.test.name
+  app_service_plan_id = azurerm_app_service_plan.test.id
+  zip_deploy_file     = %q
+}
+`, data.RandomInteger, data.Locations.Primary, data.RandomInteger, data.RandomInteger, path)
+}
+
+// writeZipDeployTestPackage writes a zip package containing a single `index.html` file into a temporary directory
+// and returns the path to it.
+func writeZipDeployTestPackage(t *testing.T, name, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+
+	file, err := os.Create(path)
+	if err != nil {
+		t.Fatalf("creating %q: %+v", path, err)
+	}
+	defer file.Close()
+
+	writer := zip.NewWriter(file)
+	entry, err := writer.Create("index.html")
+	if err != nil {
+		t.Fatalf("adding index.html to %q: %+v", path, err)
+	}
+	if _, err := entry.Write([]byte(content)); err != nil {
+		t.Fatalf("writing index.html to %q: %+v", path, err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("closing %q: %+v", path, err)
+	}
+
+	return path
+}
@@ -2,6 +2,7 @@ package client
 
 import (
 	"github.com/Azure/azure-sdk-for-go/services/web/mgmt/2020-06-01/web"
+	webStaticSites "github.com/Azure/azure-sdk-for-go/services/web/mgmt/2021-01-15/web"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/common"
 )
 
@@ -12,7 +13,7 @@ type Client struct {
 	BaseClient                   *web.BaseClient
 	CertificatesClient           *web.CertificatesClient
 	CertificatesOrderClient      *web.AppServiceCertificateOrdersClient
-	StaticSitesClient            *web.StaticSitesClient
+	StaticSitesClient            *webStaticSites.StaticSitesClient
 }
 
 func NewClient(o *common.ClientOptions) *Client {
@@ -34,7 +35,7 @@ func NewClient(o *common.ClientOptions) *Client {
 	certificatesOrderClient := web.NewAppServiceCertificateOrdersClientWithBaseURI(o.ResourceManagerEndpoint, o.SubscriptionId)
 	o.ConfigureClient(&certificatesOrderClient.Client, o.ResourceManagerAuthorizer)
 
-	staticSitesClient := web.NewStaticSitesClientWithBaseURI(o.ResourceManagerEndpoint, o.SubscriptionId)
+	staticSitesClient := webStaticSites.NewStaticSitesClientWithBaseURI(o.ResourceManagerEndpoint, o.SubscriptionId)
 	o.ConfigureClient(&staticSitesClient.Client, o.ResourceManagerAuthorizer)
 
 	return &Client{
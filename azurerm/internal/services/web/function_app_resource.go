@@ -41,6 +41,8 @@ func resourceFunctionApp() *pluginsdk.Resource {
 			Delete: pluginsdk.DefaultTimeout(30 * time.Minute),
 		},
 
+		CustomizeDiff: pluginsdk.CustomizeDiffShim(zipDeployFileCustomizeDiff),
+
 		Schema: map[string]*pluginsdk.Schema{
 			"name": {
 				Type:         pluginsdk.TypeString,
@@ -163,6 +165,12 @@ func resourceFunctionApp() *pluginsdk.Resource {
 
 			"source_control": schemaAppServiceSiteSourceControl(),
 
+			"zip_deploy_file": {
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
 			"storage_account_name": {
 				Type: pluginsdk.TypeString,
 				// Required: true, // Uncomment this in 3.0
@@ -202,6 +210,11 @@ func resourceFunctionApp() *pluginsdk.Resource {
 
 			"tags": tags.Schema(),
 
+			"zip_deploy_file_sha256": {
+				Type:     pluginsdk.TypeString,
+				Computed: true,
+			},
+
 			// Computed Only
 
 			"custom_domain_verification_id": {
@@ -557,6 +570,23 @@ func resourceFunctionAppUpdate(d *pluginsdk.ResourceData, meta interface{}) erro
 		}
 	}
 
+	// the hash isn't known during the plan when the path is computed, so a change to the path is also deployed
+	if d.HasChanges("zip_deploy_file", "zip_deploy_file_sha256") {
+		if path := d.Get("zip_deploy_file").(string); path != "" {
+			if err := deployZipPackage(ctx, client, id.ResourceGroup, id.SiteName, path); err != nil {
+				// keep the previous `zip_deploy_file_sha256` in the state so that the deployment is retried on the next apply
+				d.Partial(true)
+				return fmt.Errorf("deploying `zip_deploy_file` to Function App %q (Resource Group %q): %+v", id.SiteName, id.ResourceGroup, err)
+			}
+
+			hash, err := zipDeployFileSha256(path)
+			if err != nil {
+				return fmt.Errorf("computing SHA-256 of `zip_deploy_file` %q: %+v", path, err)
+			}
+			d.Set("zip_deploy_file_sha256", hash)
+		}
+	}
+
 	return resourceFunctionAppRead(d, meta)
 }
 
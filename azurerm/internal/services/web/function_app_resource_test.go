@@ -1001,6 +1001,44 @@ func (r FunctionAppResource) hasContentShareAppSetting(shouldExist bool) func(ct
 	}
 }
 
+func TestAccFunctionApp_zipDeployFile(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_function_app", "test")
+	r := FunctionAppResource{}
+	first := writeZipDeployTestPackage(t, "first.zip", `{"version": "2.0"}`)
+	second := writeZipDeployTestPackage(t, "second.zip", `{"version": "2.0", "logging": {}}`)
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.zipDeployFile(data, first),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("zip_deploy_file_sha256").Exists(),
+			),
+		},
+		data.ImportStep("zip_deploy_file", "zip_deploy_file_sha256"),
+		{
+			Config: r.zipDeployFile(data, second),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep("zip_deploy_file", "zip_deploy_file_sha256"),
+	})
+}
+
+func TestAccFunctionApp_zipDeployFileRunFromPackageUrl(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_function_app", "test")
+	r := FunctionAppResource{}
+	path := writeZipDeployTestPackage(t, "package.zip", `{"version": "2.0"}`)
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config:      r.zipDeployFileRunFromPackageUrl(data, path),
+			ExpectError: regexp.MustCompile("`zip_deploy_file` cannot be used when the `WEBSITE_RUN_FROM_PACKAGE` app setting is set to a URL"),
+		},
+	})
+}
+
 func (r FunctionAppResource) basic(data acceptance.TestData) string {
 	return fmt.Sprintf(`
 provider "azurerm" {
@@ -3084,3 +3122,91 @@ resource "azurerm_function_app" "test" {
 }
 `, data.RandomInteger, data.Locations.Primary, data.RandomString, modeValue)
 }
+
+func (r FunctionAppResource) zipDeployFile(data acceptance.TestData, path string) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%[1]d"
+  location = "%[2]s"
+}
+
+resource "azurerm_storage_account" "test" {
+  name                     = "acctestsa%[3]s"
+  resource_group_name      = azurerm_resource_group.test.name
+  location                 = azurerm_resource_group.test.location
+  account_tier             = "Standard"
+  account_replication_type = "LRS"
+}
+
+resource "azurerm_app_service_plan" "test" {
+  name                = "acctestASP-%[1]d"
+  location            = azurerm_resource_group.test.location
+  resource_group_name = azurerm_resource_group.test.name
+
+  sku {
+    tier = "Standard"
+    size = "S1"
+  }
+}
+
+resource "azurerm_function_app" "test" {
+  name                       = "acctest-%[1]d-func"
+  location                   = azurerm_resource_group.test.location
+  resource_group_name        = azurerm_resource_group.test.name
+  app_service_plan_id        = azurerm_app_service_plan.test.id
+  storage_account_name       = azurerm_storage_account.test.name
+  storage_account_access_key = azurerm_storage_account.test.primary_access_key
+  zip_deploy_file            = %[4]q
+}
+`, data.RandomInteger, data.Locations.Primary, data.RandomString, path)
+}
+
+func (r FunctionAppResource) zipDeployFileRunFromPackageUrl(data acceptance.TestData, path string) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%[1]d"
+  location = "%[2]s"
+}
+
+resource "azurerm_storage_account" "test" {
+  name                     = "acctestsa%[3]s"
+  resource_group_name      = azurerm_resource_group.test.name
+  location                 = azurerm_resource_group.test.location
+  account_tier             = "Standard"
+  account_replication_type = "LRS"
+}
+
+resource "azurerm_app_service_plan" "test" {
+  name                = "acctestASP-%[1]d"
+  location            = azurerm_resource_group.test.location
+  resource_group_name = azurerm_resource_group.test.name
+
+  sku {
+    tier = "Standard"
+    size = "S1"
+  }
+}
+
+resource "azurerm_function_app" "test" {
+  name                       = "acctest-%[1]d-func"
+  location                   = azurerm_resource_group.test.location
+  resource_group_name        = azurerm_resource_group.test.name
+  app_service_plan_id        = azurerm_app_service_plan.test.id
+  storage_account_name       = azurerm_storage_account.test.name
+  storage_account_access_key = azurerm_storage_account.test.primary_access_key
+  zip_deploy_file            = %[4]q
+
+  app_settings = {
+    WEBSITE_RUN_FROM_PACKAGE = "https://example.com/package.zip"
+  }
+}
+`, data.RandomInteger, data.Locations.Primary, data.RandomString, path)
+}
@@ -0,0 +1,517 @@
+package web
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/web/mgmt/2020-06-01/web"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/azure"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/location"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/sdk"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/web/parse"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/web/validate"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tags"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tf/pluginsdk"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+type LinuxWebAppResource struct{}
+
+type LinuxWebAppModel struct {
+	Name                          string                   `tfschema:"name"`
+	ResourceGroup                 string                   `tfschema:"resource_group_name"`
+	Location                      string                   `tfschema:"location"`
+	ServicePlanId                 string                   `tfschema:"service_plan_id"`
+	AppSettings                   map[string]string        `tfschema:"app_settings"`
+	AuthV2Settings                []AuthV2Settings         `tfschema:"auth_settings_v2"`
+	Backup                        []WebAppBackup           `tfschema:"backup"`
+	ClientAffinityEnabled         bool                     `tfschema:"client_affinity_enabled"`
+	ClientCertEnabled             bool                     `tfschema:"client_certificate_enabled"`
+	ClientCertMode                string                   `tfschema:"client_certificate_mode"`
+	ConnectionStrings             []WebAppConnectionString `tfschema:"connection_string"`
+	Enabled                       bool                     `tfschema:"enabled"`
+	HttpsOnly                     bool                     `tfschema:"https_only"`
+	Identity                      []WebAppIdentity         `tfschema:"identity"`
+	SiteConfig                    []SiteConfigLinux        `tfschema:"site_config"`
+	StickySettings                []WebAppStickySettings   `tfschema:"sticky_settings"`
+	Tags                          map[string]interface{}   `tfschema:"tags"`
+	CustomDomainVerificationId    string                   `tfschema:"custom_domain_verification_id"`
+	DefaultHostname               string                   `tfschema:"default_hostname"`
+	Kind                          string                   `tfschema:"kind"`
+	OutboundIPAddresses           string                   `tfschema:"outbound_ip_addresses"`
+	OutboundIPAddressList         []string                 `tfschema:"outbound_ip_address_list"`
+	PossibleOutboundIPAddresses   string                   `tfschema:"possible_outbound_ip_addresses"`
+	PossibleOutboundIPAddressList []string                 `tfschema:"possible_outbound_ip_address_list"`
+}
+
+var _ sdk.Resource = LinuxWebAppResource{}
+var _ sdk.ResourceWithUpdate = LinuxWebAppResource{}
+
+func (r LinuxWebAppResource) ModelObject() interface{} {
+	return LinuxWebAppModel{}
+}
+
+func (r LinuxWebAppResource) ResourceType() string {
+	return "azurerm_linux_web_app"
+}
+
+func (r LinuxWebAppResource) IDValidationFunc() pluginsdk.SchemaValidateFunc {
+	return validate.AppServiceID
+}
+
+func (r LinuxWebAppResource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"name": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validate.AppServiceName,
+		},
+
+		"resource_group_name": azure.SchemaResourceGroupName(),
+
+		"location": location.Schema(),
+
+		"service_plan_id": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ValidateFunc: validate.AppServicePlanID,
+		},
+
+		"app_settings": {
+			Type:     pluginsdk.TypeMap,
+			Optional: true,
+			Elem: &pluginsdk.Schema{
+				Type: pluginsdk.TypeString,
+			},
+		},
+
+		"auth_settings_v2": schemaAuthV2Settings(),
+
+		"backup": schemaAppServiceBackup(),
+
+		"client_affinity_enabled": {
+			Type:     pluginsdk.TypeBool,
+			Optional: true,
+			Default:  false,
+		},
+
+		"client_certificate_enabled": {
+			Type:     pluginsdk.TypeBool,
+			Optional: true,
+			Default:  false,
+		},
+
+		"client_certificate_mode": schemaWebAppClientCertificateMode(),
+
+		"connection_string": schemaWebAppConnectionStrings(),
+
+		"enabled": {
+			Type:     pluginsdk.TypeBool,
+			Optional: true,
+			Default:  true,
+		},
+
+		"https_only": {
+			Type:     pluginsdk.TypeBool,
+			Optional: true,
+			Default:  false,
+		},
+
+		"identity": schemaAppServiceIdentity(),
+
+		"site_config": schemaSiteConfigLinux(),
+
+		"sticky_settings": schemaWebAppStickySettings(),
+
+		"tags": tags.Schema(),
+	}
+}
+
+func (r LinuxWebAppResource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"custom_domain_verification_id": {
+			Type:      pluginsdk.TypeString,
+			Computed:  true,
+			Sensitive: true,
+		},
+
+		"default_hostname": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+
+		"kind": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+
+		"outbound_ip_addresses": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+
+		"outbound_ip_address_list": {
+			Type:     pluginsdk.TypeList,
+			Computed: true,
+			Elem: &pluginsdk.Schema{
+				Type: pluginsdk.TypeString,
+			},
+		},
+
+		"possible_outbound_ip_addresses": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+
+		"possible_outbound_ip_address_list": {
+			Type:     pluginsdk.TypeList,
+			Computed: true,
+			Elem: &pluginsdk.Schema{
+				Type: pluginsdk.TypeString,
+			},
+		},
+	}
+}
+
+func (r LinuxWebAppResource) Create() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Web.AppServicesClient
+			servicePlanClient := metadata.Client.Web.AppServicePlansClient
+			subscriptionId := metadata.Client.Account.SubscriptionId
+
+			var webApp LinuxWebAppModel
+			if err := metadata.Decode(&webApp); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			id := parse.NewAppServiceID(subscriptionId, webApp.ResourceGroup, webApp.Name)
+			existing, err := client.Get(ctx, id.ResourceGroup, id.SiteName)
+			if err != nil && !utils.ResponseWasNotFound(existing.Response) {
+				return fmt.Errorf("checking for presence of existing %s: %+v", id, err)
+			}
+			if !utils.ResponseWasNotFound(existing.Response) {
+				return metadata.ResourceRequiresImport(r.ResourceType(), id)
+			}
+
+			if err := validateWebAppServicePlanOS(ctx, servicePlanClient, webApp.ServicePlanId, true); err != nil {
+				return err
+			}
+
+			siteConfig, err := expandSiteConfigLinux(webApp.SiteConfig)
+			if err != nil {
+				return fmt.Errorf("expanding `site_config` for %s: %+v", id, err)
+			}
+
+			siteEnvelope := web.Site{
+				Location: utils.String(location.Normalize(webApp.Location)),
+				Tags:     tags.Expand(webApp.Tags),
+				Identity: expandWebAppIdentity(webApp.Identity),
+				SiteProperties: &web.SiteProperties{
+					ServerFarmID:          utils.String(webApp.ServicePlanId),
+					Enabled:               utils.Bool(webApp.Enabled),
+					HTTPSOnly:             utils.Bool(webApp.HttpsOnly),
+					Reserved:              utils.Bool(true),
+					SiteConfig:            siteConfig,
+					ClientAffinityEnabled: utils.Bool(webApp.ClientAffinityEnabled),
+					ClientCertEnabled:     utils.Bool(webApp.ClientCertEnabled),
+					ClientCertMode:        web.ClientCertMode(webApp.ClientCertMode),
+				},
+			}
+
+			future, err := client.CreateOrUpdate(ctx, id.ResourceGroup, id.SiteName, siteEnvelope)
+			if err != nil {
+				return fmt.Errorf("creating %s: %+v", id, err)
+			}
+
+			if err := future.WaitForCompletionRef(ctx, client.Client); err != nil {
+				return fmt.Errorf("waiting for creation of %s: %+v", id, err)
+			}
+
+			metadata.SetID(id)
+
+			if len(webApp.AppSettings) > 0 {
+				appSettings := web.StringDictionary{
+					Properties: expandWebAppAppSettingsDictionary(webApp.AppSettings),
+				}
+				if _, err := client.UpdateApplicationSettings(ctx, id.ResourceGroup, id.SiteName, appSettings); err != nil {
+					return fmt.Errorf("updating App Settings for %s: %+v", id, err)
+				}
+			}
+
+			if len(webApp.ConnectionStrings) > 0 {
+				connectionStrings := web.ConnectionStringDictionary{
+					Properties: expandWebAppConnectionStrings(webApp.ConnectionStrings),
+				}
+				if _, err := client.UpdateConnectionStrings(ctx, id.ResourceGroup, id.SiteName, connectionStrings); err != nil {
+					return fmt.Errorf("updating Connection Strings for %s: %+v", id, err)
+				}
+			}
+
+			if len(webApp.AuthV2Settings) > 0 {
+				if _, err := client.UpdateAuthSettingsV2(ctx, id.ResourceGroup, id.SiteName, expandAuthV2Settings(webApp.AuthV2Settings)); err != nil {
+					return fmt.Errorf("updating Authentication v2 Settings for %s: %+v", id, err)
+				}
+			}
+
+			backupConfig, err := expandWebAppBackup(webApp.Backup)
+			if err != nil {
+				return fmt.Errorf("expanding `backup` for %s: %+v", id, err)
+			}
+			if backupConfig != nil {
+				if _, err := client.UpdateBackupConfiguration(ctx, id.ResourceGroup, id.SiteName, *backupConfig); err != nil {
+					return fmt.Errorf("updating Backup Settings for %s: %+v", id, err)
+				}
+			}
+
+			if len(webApp.StickySettings) > 0 {
+				if _, err := client.UpdateSlotConfigurationNames(ctx, id.ResourceGroup, id.SiteName, expandWebAppStickySettings(webApp.StickySettings)); err != nil {
+					return fmt.Errorf("updating Sticky Settings for %s: %+v", id, err)
+				}
+			}
+
+			return nil
+		},
+	}
+}
+
+func (r LinuxWebAppResource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Web.AppServicesClient
+
+			id, err := parse.AppServiceID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			webApp, err := client.Get(ctx, id.ResourceGroup, id.SiteName)
+			if err != nil {
+				if utils.ResponseWasNotFound(webApp.Response) {
+					return metadata.MarkAsGone(id)
+				}
+				return fmt.Errorf("retrieving %s: %+v", id, err)
+			}
+
+			siteConfig, err := client.GetConfiguration(ctx, id.ResourceGroup, id.SiteName)
+			if err != nil {
+				return fmt.Errorf("retrieving Site Config for %s: %+v", id, err)
+			}
+
+			appSettings, err := client.ListApplicationSettings(ctx, id.ResourceGroup, id.SiteName)
+			if err != nil {
+				return fmt.Errorf("retrieving App Settings for %s: %+v", id, err)
+			}
+
+			connectionStrings, err := client.ListConnectionStrings(ctx, id.ResourceGroup, id.SiteName)
+			if err != nil {
+				return fmt.Errorf("retrieving Connection Strings for %s: %+v", id, err)
+			}
+
+			authV2Settings, err := client.GetAuthSettingsV2(ctx, id.ResourceGroup, id.SiteName)
+			if err != nil {
+				return fmt.Errorf("retrieving Authentication v2 Settings for %s: %+v", id, err)
+			}
+
+			backup, err := client.GetBackupConfiguration(ctx, id.ResourceGroup, id.SiteName)
+			if err != nil && !utils.ResponseWasNotFound(backup.Response) {
+				return fmt.Errorf("retrieving Backup Settings for %s: %+v", id, err)
+			}
+
+			stickySettings, err := client.ListSlotConfigurationNames(ctx, id.ResourceGroup, id.SiteName)
+			if err != nil {
+				return fmt.Errorf("retrieving Sticky Settings for %s: %+v", id, err)
+			}
+
+			state := LinuxWebAppModel{
+				Name:           id.SiteName,
+				ResourceGroup:  id.ResourceGroup,
+				Location:       location.NormalizeNilable(webApp.Location),
+				Kind:           utils.NormalizeNilableString(webApp.Kind),
+				AppSettings:    flattenWebAppAppSettings(appSettings.Properties),
+				AuthV2Settings: flattenAuthV2Settings(authV2Settings),
+				Backup:         flattenWebAppBackup(backup.BackupRequestProperties),
+				SiteConfig:     flattenSiteConfigLinux(siteConfig.SiteConfig),
+				StickySettings: flattenWebAppStickySettings(stickySettings.SlotConfigNames),
+				Tags:           tags.Flatten(webApp.Tags),
+			}
+
+			if props := webApp.SiteProperties; props != nil {
+				state.ServicePlanId = utils.NormalizeNilableString(props.ServerFarmID)
+				state.ClientAffinityEnabled = utils.NormaliseNilableBool(props.ClientAffinityEnabled)
+				state.ClientCertEnabled = utils.NormaliseNilableBool(props.ClientCertEnabled)
+				state.ClientCertMode = string(props.ClientCertMode)
+				state.Enabled = utils.NormaliseNilableBool(props.Enabled)
+				state.HttpsOnly = utils.NormaliseNilableBool(props.HTTPSOnly)
+				state.CustomDomainVerificationId = utils.NormalizeNilableString(props.CustomDomainVerificationID)
+				state.DefaultHostname = utils.NormalizeNilableString(props.DefaultHostName)
+				state.OutboundIPAddresses = utils.NormalizeNilableString(props.OutboundIPAddresses)
+				state.OutboundIPAddressList = flattenWebAppIPAddressList(state.OutboundIPAddresses)
+				state.PossibleOutboundIPAddresses = utils.NormalizeNilableString(props.PossibleOutboundIPAddresses)
+				state.PossibleOutboundIPAddressList = flattenWebAppIPAddressList(state.PossibleOutboundIPAddresses)
+			}
+
+			state.ConnectionStrings = flattenWebAppConnectionStrings(connectionStrings.Properties)
+
+			identity, err := flattenWebAppIdentity(webApp.Identity)
+			if err != nil {
+				return fmt.Errorf("flattening `identity`: %+v", err)
+			}
+			state.Identity = identity
+
+			return metadata.Encode(&state)
+		},
+	}
+}
+
+func (r LinuxWebAppResource) Update() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Web.AppServicesClient
+			servicePlanClient := metadata.Client.Web.AppServicePlansClient
+
+			id, err := parse.AppServiceID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			var state LinuxWebAppModel
+			if err := metadata.Decode(&state); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			if metadata.ResourceData.HasChange("service_plan_id") {
+				if err := validateWebAppServicePlanOS(ctx, servicePlanClient, state.ServicePlanId, true); err != nil {
+					return err
+				}
+			}
+
+			existing, err := client.Get(ctx, id.ResourceGroup, id.SiteName)
+			if err != nil {
+				return fmt.Errorf("retrieving %s: %+v", id, err)
+			}
+			if existing.SiteProperties == nil {
+				return fmt.Errorf("retrieving %s: `properties` was nil", id)
+			}
+
+			existing.SiteProperties.ServerFarmID = utils.String(state.ServicePlanId)
+			existing.SiteProperties.Enabled = utils.Bool(state.Enabled)
+			existing.SiteProperties.HTTPSOnly = utils.Bool(state.HttpsOnly)
+			existing.SiteProperties.ClientAffinityEnabled = utils.Bool(state.ClientAffinityEnabled)
+			existing.SiteProperties.ClientCertEnabled = utils.Bool(state.ClientCertEnabled)
+			existing.SiteProperties.ClientCertMode = web.ClientCertMode(state.ClientCertMode)
+			existing.Tags = tags.Expand(state.Tags)
+
+			if metadata.ResourceData.HasChange("identity") {
+				identity := expandWebAppIdentity(state.Identity)
+				if identity == nil {
+					identity = &web.ManagedServiceIdentity{
+						Type: web.ManagedServiceIdentityTypeNone,
+					}
+				}
+				existing.Identity = identity
+			}
+
+			siteConfig, err := expandSiteConfigLinux(state.SiteConfig)
+			if err != nil {
+				return fmt.Errorf("expanding `site_config` for %s: %+v", id, err)
+			}
+			existing.SiteProperties.SiteConfig = siteConfig
+
+			future, err := client.CreateOrUpdate(ctx, id.ResourceGroup, id.SiteName, existing)
+			if err != nil {
+				return fmt.Errorf("updating %s: %+v", id, err)
+			}
+
+			if err := future.WaitForCompletionRef(ctx, client.Client); err != nil {
+				return fmt.Errorf("waiting for update of %s: %+v", id, err)
+			}
+
+			if metadata.ResourceData.HasChange("site_config") {
+				if _, err := client.CreateOrUpdateConfiguration(ctx, id.ResourceGroup, id.SiteName, web.SiteConfigResource{SiteConfig: siteConfig}); err != nil {
+					return fmt.Errorf("updating Site Config for %s: %+v", id, err)
+				}
+			}
+
+			if metadata.ResourceData.HasChange("app_settings") {
+				appSettings := web.StringDictionary{
+					Properties: expandWebAppAppSettingsDictionary(state.AppSettings),
+				}
+				if _, err := client.UpdateApplicationSettings(ctx, id.ResourceGroup, id.SiteName, appSettings); err != nil {
+					return fmt.Errorf("updating App Settings for %s: %+v", id, err)
+				}
+			}
+
+			if metadata.ResourceData.HasChange("connection_string") {
+				connectionStrings := web.ConnectionStringDictionary{
+					Properties: expandWebAppConnectionStrings(state.ConnectionStrings),
+				}
+				if _, err := client.UpdateConnectionStrings(ctx, id.ResourceGroup, id.SiteName, connectionStrings); err != nil {
+					return fmt.Errorf("updating Connection Strings for %s: %+v", id, err)
+				}
+			}
+
+			if metadata.ResourceData.HasChange("auth_settings_v2") {
+				if _, err := client.UpdateAuthSettingsV2(ctx, id.ResourceGroup, id.SiteName, expandAuthV2Settings(state.AuthV2Settings)); err != nil {
+					return fmt.Errorf("updating Authentication v2 Settings for %s: %+v", id, err)
+				}
+			}
+
+			if metadata.ResourceData.HasChange("backup") {
+				backupConfig, err := expandWebAppBackup(state.Backup)
+				if err != nil {
+					return fmt.Errorf("expanding `backup` for %s: %+v", id, err)
+				}
+
+				if backupConfig == nil {
+					if _, err := client.DeleteBackupConfiguration(ctx, id.ResourceGroup, id.SiteName); err != nil {
+						return fmt.Errorf("removing Backup Settings for %s: %+v", id, err)
+					}
+				} else {
+					if _, err := client.UpdateBackupConfiguration(ctx, id.ResourceGroup, id.SiteName, *backupConfig); err != nil {
+						return fmt.Errorf("updating Backup Settings for %s: %+v", id, err)
+					}
+				}
+			}
+
+			if metadata.ResourceData.HasChange("sticky_settings") {
+				if _, err := client.UpdateSlotConfigurationNames(ctx, id.ResourceGroup, id.SiteName, expandWebAppStickySettings(state.StickySettings)); err != nil {
+					return fmt.Errorf("updating Sticky Settings for %s: %+v", id, err)
+				}
+			}
+
+			return nil
+		},
+	}
+}
+
+func (r LinuxWebAppResource) Delete() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Web.AppServicesClient
+
+			id, err := parse.AppServiceID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			metadata.Logger.Infof("deleting %s", id)
+
+			deleteMetrics := true
+			deleteEmptyServerFarm := false
+			if resp, err := client.Delete(ctx, id.ResourceGroup, id.SiteName, &deleteMetrics, &deleteEmptyServerFarm); err != nil {
+				if !utils.ResponseWasNotFound(resp) {
+					return fmt.Errorf("deleting %s: %+v", id, err)
+				}
+			}
+
+			return nil
+		},
+	}
+}
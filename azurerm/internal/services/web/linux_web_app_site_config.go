@@ -0,0 +1,539 @@
+package web
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/services/web/mgmt/2020-06-01/web"
+	apimValidate "github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/apimanagement/validate"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tf/pluginsdk"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tf/validation"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+type SiteConfigLinux struct {
+	AlwaysOn                bool                    `tfschema:"always_on"`
+	ApiManagementApiId      string                  `tfschema:"api_management_api_id"`
+	ApiDefinition           string                  `tfschema:"api_definition_url"`
+	AppCommandLine          string                  `tfschema:"app_command_line"`
+	ApplicationStack        []ApplicationStackLinux `tfschema:"application_stack"`
+	Cors                    []WebAppCorsSetting     `tfschema:"cors"`
+	DefaultDocuments        []string                `tfschema:"default_documents"`
+	DetailedErrorLogging    bool                    `tfschema:"detailed_error_logging_enabled"`
+	FtpsState               string                  `tfschema:"ftps_state"`
+	HealthCheckPath         string                  `tfschema:"health_check_path"`
+	Http2Enabled            bool                    `tfschema:"http2_enabled"`
+	IpRestriction           []WebAppIpRestriction   `tfschema:"ip_restriction"`
+	LinuxFxVersion          string                  `tfschema:"linux_fx_version"`
+	LoadBalancing           string                  `tfschema:"load_balancing_mode"`
+	MinTlsVersion           string                  `tfschema:"minimum_tls_version"`
+	RemoteDebugging         bool                    `tfschema:"remote_debugging_enabled"`
+	RemoteDebuggingVersion  string                  `tfschema:"remote_debugging_version"`
+	ScmIpRestriction        []WebAppIpRestriction   `tfschema:"scm_ip_restriction"`
+	ScmMinTlsVersion        string                  `tfschema:"scm_minimum_tls_version"`
+	ScmType                 string                  `tfschema:"scm_type"`
+	ScmUseMainIpRestriction bool                    `tfschema:"scm_use_main_ip_restriction"`
+	Use32BitWorker          bool                    `tfschema:"use_32_bit_worker"`
+	VnetRouteAllEnabled     bool                    `tfschema:"vnet_route_all_enabled"`
+	WebSockets              bool                    `tfschema:"websockets_enabled"`
+	WorkerCount             int                     `tfschema:"worker_count"`
+}
+
+type ApplicationStackLinux struct {
+	NetFrameworkVersion string `tfschema:"dotnet_version"`
+	PhpVersion          string `tfschema:"php_version"`
+	PythonVersion       string `tfschema:"python_version"`
+	NodeVersion         string `tfschema:"node_version"`
+	RubyVersion         string `tfschema:"ruby_version"`
+	JavaServer          string `tfschema:"java_server"`
+	JavaServerVersion   string `tfschema:"java_server_version"`
+	JavaVersion         string `tfschema:"java_version"`
+	DockerImage         string `tfschema:"docker_image"`
+	DockerImageTag      string `tfschema:"docker_image_tag"`
+}
+
+const (
+	linuxFxVersionDocker     = "DOCKER"
+	linuxFxVersionDotNetCore = "DOTNETCORE"
+	linuxFxVersionJava       = "JAVA"
+	linuxFxVersionJBossEAP   = "JBOSSEAP"
+	linuxFxVersionNode       = "NODE"
+	linuxFxVersionPhp        = "PHP"
+	linuxFxVersionPython     = "PYTHON"
+	linuxFxVersionRuby       = "RUBY"
+	linuxFxVersionTomcat     = "TOMCAT"
+)
+
+func schemaSiteConfigLinux() *pluginsdk.Schema {
+	return &pluginsdk.Schema{
+		Type:     pluginsdk.TypeList,
+		Required: true,
+		MaxItems: 1,
+		Elem: &pluginsdk.Resource{
+			Schema: map[string]*pluginsdk.Schema{
+				"always_on": {
+					Type:     pluginsdk.TypeBool,
+					Optional: true,
+					Default:  true,
+				},
+
+				"api_management_api_id": {
+					Type:         pluginsdk.TypeString,
+					Optional:     true,
+					ValidateFunc: apimValidate.ApiID,
+				},
+
+				"api_definition_url": {
+					Type:         pluginsdk.TypeString,
+					Optional:     true,
+					ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+				},
+
+				"app_command_line": {
+					Type:     pluginsdk.TypeString,
+					Optional: true,
+				},
+
+				"application_stack": schemaApplicationStackLinux(),
+
+				"cors": SchemaWebCorsSettings(),
+
+				"default_documents": {
+					Type:     pluginsdk.TypeList,
+					Optional: true,
+					Computed: true,
+					Elem: &pluginsdk.Schema{
+						Type: pluginsdk.TypeString,
+					},
+				},
+
+				"detailed_error_logging_enabled": {
+					Type:     pluginsdk.TypeBool,
+					Computed: true,
+				},
+
+				"ftps_state": schemaWebAppSiteConfigFtpsState(),
+
+				"health_check_path": {
+					Type:         pluginsdk.TypeString,
+					Optional:     true,
+					ValidateFunc: validation.StringIsNotEmpty,
+				},
+
+				"http2_enabled": {
+					Type:     pluginsdk.TypeBool,
+					Optional: true,
+					Default:  false,
+				},
+
+				"ip_restriction": schemaAppServiceIpRestriction(),
+
+				"linux_fx_version": {
+					Type:     pluginsdk.TypeString,
+					Computed: true,
+				},
+
+				"load_balancing_mode": schemaWebAppSiteConfigLoadBalancingMode(),
+
+				"minimum_tls_version": schemaWebAppSiteConfigMinimumTlsVersion(),
+
+				"remote_debugging_enabled": {
+					Type:     pluginsdk.TypeBool,
+					Optional: true,
+					Default:  false,
+				},
+
+				"remote_debugging_version": {
+					Type:     pluginsdk.TypeString,
+					Optional: true,
+					Computed: true,
+					ValidateFunc: validation.StringInSlice([]string{
+						"VS2017",
+						"VS2019",
+					}, false),
+				},
+
+				"scm_ip_restriction": schemaAppServiceIpRestriction(),
+
+				"scm_minimum_tls_version": schemaWebAppSiteConfigMinimumTlsVersion(),
+
+				"scm_type": {
+					Type:     pluginsdk.TypeString,
+					Computed: true,
+				},
+
+				"scm_use_main_ip_restriction": {
+					Type:     pluginsdk.TypeBool,
+					Optional: true,
+					Default:  false,
+				},
+
+				"use_32_bit_worker": {
+					Type:     pluginsdk.TypeBool,
+					Optional: true,
+					Default:  true,
+				},
+
+				"vnet_route_all_enabled": {
+					Type:     pluginsdk.TypeBool,
+					Optional: true,
+					Default:  false,
+				},
+
+				"websockets_enabled": {
+					Type:     pluginsdk.TypeBool,
+					Optional: true,
+					Default:  false,
+				},
+
+				"worker_count": {
+					Type:         pluginsdk.TypeInt,
+					Optional:     true,
+					Computed:     true,
+					ValidateFunc: validation.IntBetween(1, 100),
+				},
+			},
+		},
+	}
+}
+
+func schemaApplicationStackLinux() *pluginsdk.Schema {
+	linuxApplicationStackConstraint := []string{
+		"site_config.0.application_stack.0.dotnet_version",
+		"site_config.0.application_stack.0.php_version",
+		"site_config.0.application_stack.0.python_version",
+		"site_config.0.application_stack.0.node_version",
+		"site_config.0.application_stack.0.ruby_version",
+		"site_config.0.application_stack.0.java_version",
+		"site_config.0.application_stack.0.docker_image",
+	}
+
+	return &pluginsdk.Schema{
+		Type:     pluginsdk.TypeList,
+		Optional: true,
+		MaxItems: 1,
+		Elem: &pluginsdk.Resource{
+			Schema: map[string]*pluginsdk.Schema{
+				"dotnet_version": {
+					Type:     pluginsdk.TypeString,
+					Optional: true,
+					ValidateFunc: validation.StringInSlice([]string{
+						"2.1",
+						"3.1",
+						"5.0",
+					}, false),
+					ExactlyOneOf: linuxApplicationStackConstraint,
+				},
+
+				"php_version": {
+					Type:     pluginsdk.TypeString,
+					Optional: true,
+					ValidateFunc: validation.StringInSlice([]string{
+						"5.6",
+						"7.2",
+						"7.3",
+						"7.4",
+					}, false),
+					ExactlyOneOf: linuxApplicationStackConstraint,
+				},
+
+				"python_version": {
+					Type:     pluginsdk.TypeString,
+					Optional: true,
+					ValidateFunc: validation.StringInSlice([]string{
+						"3.6",
+						"3.7",
+						"3.8",
+						"3.9",
+					}, false),
+					ExactlyOneOf: linuxApplicationStackConstraint,
+				},
+
+				"node_version": {
+					Type:     pluginsdk.TypeString,
+					Optional: true,
+					ValidateFunc: validation.StringInSlice([]string{
+						"10-lts",
+						"12-lts",
+						"14-lts",
+					}, false),
+					ExactlyOneOf: linuxApplicationStackConstraint,
+				},
+
+				"ruby_version": {
+					Type:     pluginsdk.TypeString,
+					Optional: true,
+					ValidateFunc: validation.StringInSlice([]string{
+						"2.5",
+						"2.6",
+						"2.7",
+					}, false),
+					ExactlyOneOf: linuxApplicationStackConstraint,
+				},
+
+				"java_version": {
+					Type:         pluginsdk.TypeString,
+					Optional:     true,
+					ValidateFunc: validation.StringInSlice([]string{"8", "11"}, false),
+					ExactlyOneOf: linuxApplicationStackConstraint,
+					RequiredWith: []string{
+						"site_config.0.application_stack.0.java_server",
+						"site_config.0.application_stack.0.java_server_version",
+					},
+				},
+
+				"java_server": {
+					Type:     pluginsdk.TypeString,
+					Optional: true,
+					ValidateFunc: validation.StringInSlice([]string{
+						linuxFxVersionJava,
+						linuxFxVersionTomcat,
+						linuxFxVersionJBossEAP,
+					}, false),
+					RequiredWith: []string{
+						"site_config.0.application_stack.0.java_version",
+					},
+				},
+
+				"java_server_version": {
+					Type:         pluginsdk.TypeString,
+					Optional:     true,
+					ValidateFunc: validation.StringIsNotEmpty,
+					RequiredWith: []string{
+						"site_config.0.application_stack.0.java_version",
+					},
+				},
+
+				"docker_image": {
+					Type:         pluginsdk.TypeString,
+					Optional:     true,
+					ValidateFunc: validation.StringIsNotEmpty,
+					ExactlyOneOf: linuxApplicationStackConstraint,
+					RequiredWith: []string{
+						"site_config.0.application_stack.0.docker_image_tag",
+					},
+				},
+
+				"docker_image_tag": {
+					Type:         pluginsdk.TypeString,
+					Optional:     true,
+					ValidateFunc: validation.StringIsNotEmpty,
+					RequiredWith: []string{
+						"site_config.0.application_stack.0.docker_image",
+					},
+				},
+			},
+		},
+	}
+}
+
+func expandSiteConfigLinux(input []SiteConfigLinux) (*web.SiteConfig, error) {
+	if len(input) == 0 {
+		return nil, nil
+	}
+
+	linuxSiteConfig := input[0]
+
+	defaultDocuments := make([]string, 0)
+	defaultDocuments = append(defaultDocuments, linuxSiteConfig.DefaultDocuments...)
+
+	expanded := &web.SiteConfig{
+		AlwaysOn:                         utils.Bool(linuxSiteConfig.AlwaysOn),
+		AppCommandLine:                   utils.String(linuxSiteConfig.AppCommandLine),
+		Cors:                             expandWebAppCorsSettings(linuxSiteConfig.Cors),
+		DefaultDocuments:                 &defaultDocuments,
+		FtpsState:                        web.FtpsState(linuxSiteConfig.FtpsState),
+		HealthCheckPath:                  utils.String(linuxSiteConfig.HealthCheckPath),
+		HTTP20Enabled:                    utils.Bool(linuxSiteConfig.Http2Enabled),
+		LoadBalancing:                    web.SiteLoadBalancing(linuxSiteConfig.LoadBalancing),
+		MinTLSVersion:                    web.SupportedTLSVersions(linuxSiteConfig.MinTlsVersion),
+		RemoteDebuggingEnabled:           utils.Bool(linuxSiteConfig.RemoteDebugging),
+		ScmIPSecurityRestrictionsUseMain: utils.Bool(linuxSiteConfig.ScmUseMainIpRestriction),
+		ScmMinTLSVersion:                 web.SupportedTLSVersions(linuxSiteConfig.ScmMinTlsVersion),
+		Use32BitWorkerProcess:            utils.Bool(linuxSiteConfig.Use32BitWorker),
+		VnetRouteAllEnabled:              utils.Bool(linuxSiteConfig.VnetRouteAllEnabled),
+		WebSocketsEnabled:                utils.Bool(linuxSiteConfig.WebSockets),
+	}
+
+	if linuxSiteConfig.ApiManagementApiId != "" {
+		expanded.APIManagementConfig = &web.APIManagementConfig{
+			ID: utils.String(linuxSiteConfig.ApiManagementApiId),
+		}
+	}
+
+	if linuxSiteConfig.ApiDefinition != "" {
+		expanded.APIDefinition = &web.APIDefinitionInfo{
+			URL: utils.String(linuxSiteConfig.ApiDefinition),
+		}
+	}
+
+	if linuxSiteConfig.RemoteDebuggingVersion != "" {
+		expanded.RemoteDebuggingVersion = utils.String(linuxSiteConfig.RemoteDebuggingVersion)
+	}
+
+	if linuxSiteConfig.WorkerCount != 0 {
+		expanded.NumberOfWorkers = utils.Int32(int32(linuxSiteConfig.WorkerCount))
+	}
+
+	linuxFxVersion, err := expandApplicationStackLinux(linuxSiteConfig.ApplicationStack)
+	if err != nil {
+		return nil, err
+	}
+	expanded.LinuxFxVersion = utils.String(linuxFxVersion)
+
+	ipRestrictions, err := expandWebAppIpRestrictions(linuxSiteConfig.IpRestriction)
+	if err != nil {
+		return nil, fmt.Errorf("expanding `ip_restriction`: %+v", err)
+	}
+	expanded.IPSecurityRestrictions = ipRestrictions
+
+	scmIpRestrictions, err := expandWebAppIpRestrictions(linuxSiteConfig.ScmIpRestriction)
+	if err != nil {
+		return nil, fmt.Errorf("expanding `scm_ip_restriction`: %+v", err)
+	}
+	expanded.ScmIPSecurityRestrictions = scmIpRestrictions
+
+	return expanded, nil
+}
+
+func flattenSiteConfigLinux(input *web.SiteConfig) []SiteConfigLinux {
+	if input == nil {
+		return []SiteConfigLinux{}
+	}
+
+	siteConfig := SiteConfigLinux{
+		AlwaysOn:                utils.NormaliseNilableBool(input.AlwaysOn),
+		AppCommandLine:          utils.NormalizeNilableString(input.AppCommandLine),
+		ApplicationStack:        flattenApplicationStackLinux(utils.NormalizeNilableString(input.LinuxFxVersion)),
+		Cors:                    flattenWebAppCorsSettings(input.Cors),
+		DetailedErrorLogging:    utils.NormaliseNilableBool(input.DetailedErrorLoggingEnabled),
+		FtpsState:               string(input.FtpsState),
+		HealthCheckPath:         utils.NormalizeNilableString(input.HealthCheckPath),
+		Http2Enabled:            utils.NormaliseNilableBool(input.HTTP20Enabled),
+		IpRestriction:           flattenWebAppIpRestrictions(input.IPSecurityRestrictions),
+		LinuxFxVersion:          utils.NormalizeNilableString(input.LinuxFxVersion),
+		LoadBalancing:           string(input.LoadBalancing),
+		MinTlsVersion:           string(input.MinTLSVersion),
+		RemoteDebugging:         utils.NormaliseNilableBool(input.RemoteDebuggingEnabled),
+		RemoteDebuggingVersion:  strings.ToUpper(utils.NormalizeNilableString(input.RemoteDebuggingVersion)),
+		ScmIpRestriction:        flattenWebAppIpRestrictions(input.ScmIPSecurityRestrictions),
+		ScmMinTlsVersion:        string(input.ScmMinTLSVersion),
+		ScmType:                 string(input.ScmType),
+		ScmUseMainIpRestriction: utils.NormaliseNilableBool(input.ScmIPSecurityRestrictionsUseMain),
+		Use32BitWorker:          utils.NormaliseNilableBool(input.Use32BitWorkerProcess),
+		VnetRouteAllEnabled:     utils.NormaliseNilableBool(input.VnetRouteAllEnabled),
+		WebSockets:              utils.NormaliseNilableBool(input.WebSocketsEnabled),
+	}
+
+	if input.APIManagementConfig != nil {
+		siteConfig.ApiManagementApiId = utils.NormalizeNilableString(input.APIManagementConfig.ID)
+	}
+
+	if input.APIDefinition != nil {
+		siteConfig.ApiDefinition = utils.NormalizeNilableString(input.APIDefinition.URL)
+	}
+
+	if input.DefaultDocuments != nil {
+		siteConfig.DefaultDocuments = *input.DefaultDocuments
+	}
+
+	if input.NumberOfWorkers != nil {
+		siteConfig.WorkerCount = int(*input.NumberOfWorkers)
+	}
+
+	return []SiteConfigLinux{siteConfig}
+}
+
+// expandApplicationStackLinux builds the `linuxFxVersion` string (in the format `{STACK}|{VERSION}`) which is
+// how the runtime of a Linux Web App is configured.
+func expandApplicationStackLinux(input []ApplicationStackLinux) (string, error) {
+	if len(input) == 0 {
+		return "", nil
+	}
+
+	stack := input[0]
+	switch {
+	case stack.NetFrameworkVersion != "":
+		return fmt.Sprintf("%s|%s", linuxFxVersionDotNetCore, stack.NetFrameworkVersion), nil
+
+	case stack.PhpVersion != "":
+		return fmt.Sprintf("%s|%s", linuxFxVersionPhp, stack.PhpVersion), nil
+
+	case stack.PythonVersion != "":
+		return fmt.Sprintf("%s|%s", linuxFxVersionPython, stack.PythonVersion), nil
+
+	case stack.NodeVersion != "":
+		return fmt.Sprintf("%s|%s", linuxFxVersionNode, stack.NodeVersion), nil
+
+	case stack.RubyVersion != "":
+		return fmt.Sprintf("%s|%s", linuxFxVersionRuby, stack.RubyVersion), nil
+
+	case stack.JavaVersion != "":
+		if stack.JavaServer == "" || stack.JavaServerVersion == "" {
+			return "", fmt.Errorf("`java_server` and `java_server_version` must be specified with `java_version`")
+		}
+		javaVersion := fmt.Sprintf("java%s", stack.JavaVersion)
+		if stack.JavaVersion == "8" {
+			javaVersion = "jre8"
+		}
+		return fmt.Sprintf("%s|%s-%s", stack.JavaServer, stack.JavaServerVersion, javaVersion), nil
+
+	case stack.DockerImage != "":
+		return fmt.Sprintf("%s|%s:%s", linuxFxVersionDocker, stack.DockerImage, stack.DockerImageTag), nil
+	}
+
+	return "", nil
+}
+
+func flattenApplicationStackLinux(linuxFxVersion string) []ApplicationStackLinux {
+	parts := strings.SplitN(linuxFxVersion, "|", 2)
+	if len(parts) != 2 || parts[1] == "" {
+		return []ApplicationStackLinux{}
+	}
+
+	kind := strings.ToUpper(parts[0])
+	version := parts[1]
+	stack := ApplicationStackLinux{}
+
+	switch kind {
+	case linuxFxVersionDotNetCore:
+		stack.NetFrameworkVersion = version
+
+	case linuxFxVersionPhp:
+		stack.PhpVersion = version
+
+	case linuxFxVersionPython:
+		stack.PythonVersion = version
+
+	case linuxFxVersionNode:
+		stack.NodeVersion = strings.ToLower(version)
+
+	case linuxFxVersionRuby:
+		stack.RubyVersion = version
+
+	case linuxFxVersionJava, linuxFxVersionTomcat, linuxFxVersionJBossEAP:
+		stack.JavaServer = kind
+		javaParts := strings.Split(version, "-")
+		if len(javaParts) != 2 {
+			return []ApplicationStackLinux{}
+		}
+		stack.JavaServerVersion = javaParts[0]
+		stack.JavaVersion = strings.TrimPrefix(strings.TrimPrefix(strings.ToLower(javaParts[1]), "java"), "jre")
+
+	case linuxFxVersionDocker:
+		index := strings.LastIndex(version, ":")
+		// a `:` may also be present as part of the registry's port, so the tag must follow the last path separator
+		if index == -1 || index < strings.LastIndex(version, "/") {
+			stack.DockerImage = version
+			stack.DockerImageTag = "latest"
+		} else {
+			stack.DockerImage = version[:index]
+			stack.DockerImageTag = version[index+1:]
+		}
+
+	default:
+		return []ApplicationStackLinux{}
+	}
+
+	return []ApplicationStackLinux{stack}
+}
@@ -0,0 +1,75 @@
+package parse
+
+// NOTE: this file is generated via 'go:generate' - manual changes will be overwritten
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/azure"
+)
+
+type StaticSiteUserProvidedFunctionAppId struct {
+	SubscriptionId              string
+	ResourceGroup               string
+	StaticSiteName              string
+	UserProvidedFunctionAppName string
+}
+
+func NewStaticSiteUserProvidedFunctionAppID(subscriptionId, resourceGroup, staticSiteName, userProvidedFunctionAppName string) StaticSiteUserProvidedFunctionAppId {
+	return StaticSiteUserProvidedFunctionAppId{
+		SubscriptionId:              subscriptionId,
+		ResourceGroup:               resourceGroup,
+		StaticSiteName:              staticSiteName,
+		UserProvidedFunctionAppName: userProvidedFunctionAppName,
+	}
+}
+
+func (id StaticSiteUserProvidedFunctionAppId) String() string {
+	segments := []string{
+		fmt.Sprintf("User Provided Function App Name %q", id.UserProvidedFunctionAppName),
+		fmt.Sprintf("Static Site Name %q", id.StaticSiteName),
+		fmt.Sprintf("Resource Group %q", id.ResourceGroup),
+	}
+	segmentsStr := strings.Join(segments, " / ")
+	return fmt.Sprintf("%s: (%s)", "Static Site User Provided Function App", segmentsStr)
+}
+
+func (id StaticSiteUserProvidedFunctionAppId) ID() string {
+	fmtString := "/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Web/staticSites/%s/userProvidedFunctionApps/%s"
+	return fmt.Sprintf(fmtString, id.SubscriptionId, id.ResourceGroup, id.StaticSiteName, id.UserProvidedFunctionAppName)
+}
+
+// StaticSiteUserProvidedFunctionAppID parses a StaticSiteUserProvidedFunctionApp ID into an StaticSiteUserProvidedFunctionAppId struct
+func StaticSiteUserProvidedFunctionAppID(input string) (*StaticSiteUserProvidedFunctionAppId, error) {
+	id, err := azure.ParseAzureResourceID(input)
+	if err != nil {
+		return nil, err
+	}
+
+	resourceId := StaticSiteUserProvidedFunctionAppId{
+		SubscriptionId: id.SubscriptionID,
+		ResourceGroup:  id.ResourceGroup,
+	}
+
+	if resourceId.SubscriptionId == "" {
+		return nil, fmt.Errorf("ID was missing the 'subscriptions' element")
+	}
+
+	if resourceId.ResourceGroup == "" {
+		return nil, fmt.Errorf("ID was missing the 'resourceGroups' element")
+	}
+
+	if resourceId.StaticSiteName, err = id.PopSegment("staticSites"); err != nil {
+		return nil, err
+	}
+	if resourceId.UserProvidedFunctionAppName, err = id.PopSegment("userProvidedFunctionApps"); err != nil {
+		return nil, err
+	}
+
+	if err := id.ValidateNoEmptySegments(input); err != nil {
+		return nil, err
+	}
+
+	return &resourceId, nil
+}
@@ -64,5 +64,11 @@ func (r Registration) DataSources() []sdk.DataSource {
 func (r Registration) Resources() []sdk.Resource {
 	return []sdk.Resource{
 		AppServiceEnvironmentV3Resource{},
+		LinuxWebAppResource{},
+		LinuxWebAppSlotResource{},
+		StaticSiteCustomDomainResource{},
+		StaticSiteFunctionAppRegistrationResource{},
+		WindowsWebAppResource{},
+		WindowsWebAppSlotResource{},
 	}
 }
@@ -0,0 +1,264 @@
+package web
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/web/mgmt/2021-01-15/web"
+	"github.com/hashicorp/go-azure-helpers/response"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/sdk"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/web/parse"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/web/validate"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tf/pluginsdk"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tf/validation"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+const (
+	staticSiteCustomDomainValidationCname    = "cname-delegation"
+	staticSiteCustomDomainValidationTxtToken = "dns-txt-token"
+)
+
+type StaticSiteCustomDomainModel struct {
+	StaticSiteId    string `tfschema:"static_site_id"`
+	DomainName      string `tfschema:"domain_name"`
+	ValidationType  string `tfschema:"validation_type"`
+	ValidationToken string `tfschema:"validation_token"`
+}
+
+type StaticSiteCustomDomainResource struct{}
+
+var _ sdk.Resource = StaticSiteCustomDomainResource{}
+
+func (r StaticSiteCustomDomainResource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"static_site_id": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validate.StaticSiteID,
+		},
+
+		"domain_name": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+
+		"validation_type": {
+			Type:     pluginsdk.TypeString,
+			Optional: true,
+			ForceNew: true,
+			Default:  staticSiteCustomDomainValidationCname,
+			ValidateFunc: validation.StringInSlice([]string{
+				staticSiteCustomDomainValidationCname,
+				staticSiteCustomDomainValidationTxtToken,
+			}, false),
+		},
+	}
+}
+
+func (r StaticSiteCustomDomainResource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"validation_token": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+	}
+}
+
+func (r StaticSiteCustomDomainResource) ModelObject() interface{} {
+	return StaticSiteCustomDomainModel{}
+}
+
+func (r StaticSiteCustomDomainResource) ResourceType() string {
+	return "azurerm_static_site_custom_domain"
+}
+
+func (r StaticSiteCustomDomainResource) IDValidationFunc() pluginsdk.SchemaValidateFunc {
+	return validate.StaticSiteCustomDomainID
+}
+
+func (r StaticSiteCustomDomainResource) Create() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Web.StaticSitesClient
+
+			var model StaticSiteCustomDomainModel
+			if err := metadata.Decode(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			staticSiteId, err := parse.StaticSiteID(model.StaticSiteId)
+			if err != nil {
+				return err
+			}
+
+			id := parse.NewStaticSiteCustomDomainID(staticSiteId.SubscriptionId, staticSiteId.ResourceGroup, staticSiteId.Name, model.DomainName)
+
+			existing, err := client.GetStaticSiteCustomDomain(ctx, id.ResourceGroup, id.StaticSiteName, id.CustomDomainName)
+			if err != nil && !utils.ResponseWasNotFound(existing.Response) {
+				return fmt.Errorf("checking for presence of existing %s: %+v", id, err)
+			}
+			if !utils.ResponseWasNotFound(existing.Response) {
+				return metadata.ResourceRequiresImport(r.ResourceType(), id)
+			}
+
+			envelope := web.StaticSiteCustomDomainRequestPropertiesARMResource{
+				StaticSiteCustomDomainRequestPropertiesARMResourceProperties: &web.StaticSiteCustomDomainRequestPropertiesARMResourceProperties{
+					ValidationMethod: utils.String(model.ValidationType),
+				},
+			}
+
+			future, err := client.CreateOrUpdateStaticSiteCustomDomain(ctx, id.ResourceGroup, id.StaticSiteName, id.CustomDomainName, envelope)
+			if err != nil {
+				return fmt.Errorf("creating %s: %+v", id, err)
+			}
+
+			// when validating using a TXT record the operation only completes once the record exists, which can't
+			// be created until the validation token has been exported - so we only wait for the token to be issued
+			if model.ValidationType == staticSiteCustomDomainValidationTxtToken {
+				tokenWait := pluginsdk.StateChangeConf{
+					Pending: []string{
+						string(web.CustomDomainStatusAdding),
+						string(web.CustomDomainStatusRetrievingValidationToken),
+					},
+					Target: []string{
+						string(web.CustomDomainStatusValidating),
+						string(web.CustomDomainStatusReady),
+					},
+					MinTimeout: 15 * time.Second,
+					Refresh:    staticSiteCustomDomainRefreshFunc(ctx, client, id),
+				}
+
+				timeout, _ := ctx.Deadline()
+				tokenWait.Timeout = time.Until(timeout)
+
+				if _, err := tokenWait.WaitForStateContext(ctx); err != nil {
+					return fmt.Errorf("waiting for the validation token for %s: %+v", id, err)
+				}
+			} else if err := future.WaitForCompletionRef(ctx, client.Client); err != nil {
+				return fmt.Errorf("waiting for creation of %s: %+v", id, err)
+			}
+
+			metadata.SetID(id)
+			return nil
+		},
+	}
+}
+
+func (r StaticSiteCustomDomainResource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Web.StaticSitesClient
+
+			id, err := parse.StaticSiteCustomDomainID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			resp, err := client.GetStaticSiteCustomDomain(ctx, id.ResourceGroup, id.StaticSiteName, id.CustomDomainName)
+			if err != nil {
+				if utils.ResponseWasNotFound(resp.Response) {
+					return metadata.MarkAsGone(id)
+				}
+				return fmt.Errorf("retrieving %s: %+v", id, err)
+			}
+
+			// the validation method isn't returned by the API so we carry it over from the existing state
+			var state StaticSiteCustomDomainModel
+			if err := metadata.Decode(&state); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			model := StaticSiteCustomDomainModel{
+				StaticSiteId:   parse.NewStaticSiteID(id.SubscriptionId, id.ResourceGroup, id.StaticSiteName).ID(),
+				DomainName:     id.CustomDomainName,
+				ValidationType: state.ValidationType,
+			}
+
+			if props := resp.StaticSiteCustomDomainOverviewARMResourceProperties; props != nil {
+				if props.DomainName != nil {
+					model.DomainName = *props.DomainName
+				}
+				// the token is only returned whilst the domain is being validated, so keep the existing value once it's Ready
+				model.ValidationToken = state.ValidationToken
+				if props.ValidationToken != nil && *props.ValidationToken != "" {
+					model.ValidationToken = *props.ValidationToken
+				}
+			}
+
+			if model.ValidationType == "" {
+				model.ValidationType = staticSiteCustomDomainValidationCname
+			}
+
+			return metadata.Encode(&model)
+		},
+	}
+}
+
+func (r StaticSiteCustomDomainResource) Delete() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Web.StaticSitesClient
+
+			id, err := parse.StaticSiteCustomDomainID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			metadata.Logger.Infof("deleting %s", id)
+
+			future, err := client.DeleteStaticSiteCustomDomain(ctx, id.ResourceGroup, id.StaticSiteName, id.CustomDomainName)
+			if err != nil {
+				if response.WasNotFound(future.Response()) {
+					return nil
+				}
+				return fmt.Errorf("deleting %s: %+v", id, err)
+			}
+
+			if err := future.WaitForCompletionRef(ctx, client.Client); err != nil {
+				return fmt.Errorf("waiting for deletion of %s: %+v", id, err)
+			}
+
+			return nil
+		},
+	}
+}
+
+func staticSiteCustomDomainRefreshFunc(ctx context.Context, client *web.StaticSitesClient, id parse.StaticSiteCustomDomainId) pluginsdk.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		resp, err := client.GetStaticSiteCustomDomain(ctx, id.ResourceGroup, id.StaticSiteName, id.CustomDomainName)
+		if err != nil {
+			if utils.ResponseWasNotFound(resp.Response) {
+				return resp, string(web.CustomDomainStatusAdding), nil
+			}
+			return nil, "", fmt.Errorf("retrieving %s: %+v", id, err)
+		}
+
+		props := resp.StaticSiteCustomDomainOverviewARMResourceProperties
+		if props == nil {
+			return nil, "", fmt.Errorf("retrieving %s: `properties` was nil", id)
+		}
+
+		if props.Status == web.CustomDomainStatusFailed {
+			message := ""
+			if props.ErrorMessage != nil {
+				message = *props.ErrorMessage
+			}
+			return nil, "", fmt.Errorf("adding %s failed: %s", id, message)
+		}
+
+		// the status can move to Validating before the token has been populated
+		if props.Status == web.CustomDomainStatusValidating && (props.ValidationToken == nil || *props.ValidationToken == "") {
+			return resp, string(web.CustomDomainStatusRetrievingValidationToken), nil
+		}
+
+		return resp, string(props.Status), nil
+	}
+}
@@ -5,11 +5,14 @@ import (
 	"log"
 	"time"
 
-	"github.com/Azure/azure-sdk-for-go/services/web/mgmt/2020-06-01/web"
+	"github.com/Azure/azure-sdk-for-go/services/web/mgmt/2021-01-15/web"
+	"github.com/hashicorp/go-azure-helpers/response"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/azure"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/tf"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/clients"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/location"
+	msiParse "github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/msi/parse"
+	msiValidate "github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/msi/validate"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/web/parse"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/web/validate"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tags"
@@ -53,14 +56,61 @@ func resourceStaticSite() *pluginsdk.Resource {
 				Type:         pluginsdk.TypeString,
 				Optional:     true,
 				Default:      "Free",
-				ValidateFunc: validation.StringInSlice([]string{"Free"}, false),
+				ValidateFunc: validation.StringInSlice([]string{"Free", "Standard"}, false),
 			},
 
 			"sku_size": {
 				Type:         pluginsdk.TypeString,
 				Optional:     true,
 				Default:      "Free",
-				ValidateFunc: validation.StringInSlice([]string{"Free"}, false),
+				ValidateFunc: validation.StringInSlice([]string{"Free", "Standard"}, false),
+			},
+
+			"app_settings": {
+				Type:     pluginsdk.TypeMap,
+				Optional: true,
+				Elem: &pluginsdk.Schema{
+					Type: pluginsdk.TypeString,
+				},
+			},
+
+			"identity": {
+				Type:     pluginsdk.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"type": {
+							Type:     pluginsdk.TypeString,
+							Required: true,
+							ValidateFunc: validation.StringInSlice([]string{
+								string(web.ManagedServiceIdentityTypeSystemAssigned),
+								string(web.ManagedServiceIdentityTypeSystemAssignedUserAssigned),
+								string(web.ManagedServiceIdentityTypeUserAssigned),
+							}, false),
+						},
+
+						"identity_ids": {
+							Type:     pluginsdk.TypeList,
+							Optional: true,
+							MinItems: 1,
+							Elem: &pluginsdk.Schema{
+								Type:         pluginsdk.TypeString,
+								ValidateFunc: msiValidate.UserAssignedIdentityID,
+							},
+						},
+
+						"principal_id": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+
+						"tenant_id": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+					},
+				},
 			},
 
 			"default_host_name": {
@@ -109,16 +159,32 @@ func resourceStaticSiteCreateOrUpdate(d *pluginsdk.ResourceData, meta interface{
 			Tier: utils.String(d.Get("sku_tier").(string)),
 		},
 		StaticSite: &web.StaticSite{},
+		Identity:   expandStaticSiteIdentity(d.Get("identity").([]interface{})),
 		Location:   &loc,
 		Tags:       tags.Expand(d.Get("tags").(map[string]interface{})),
 	}
 
-	if _, err := client.CreateOrUpdateStaticSite(ctx, id.ResourceGroup, id.Name, siteEnvelope); err != nil {
+	future, err := client.CreateOrUpdateStaticSite(ctx, id.ResourceGroup, id.Name, siteEnvelope)
+	if err != nil {
 		return fmt.Errorf("failed creating %s: %+v", id, err)
 	}
 
+	if err := future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		return fmt.Errorf("waiting for creation of %s: %+v", id, err)
+	}
+
 	d.SetId(id.ID())
 
+	if d.IsNewResource() || d.HasChange("app_settings") {
+		appSettings := web.StringDictionary{
+			Properties: expandStaticSiteAppSettings(d.Get("app_settings").(map[string]interface{})),
+		}
+
+		if _, err := client.CreateOrUpdateStaticSiteAppSettings(ctx, id.ResourceGroup, id.Name, appSettings); err != nil {
+			return fmt.Errorf("updating App Settings for %s: %+v", id, err)
+		}
+	}
+
 	return resourceStaticSiteRead(d, meta)
 }
 
@@ -168,6 +234,23 @@ func resourceStaticSiteRead(d *pluginsdk.ResourceData, meta interface{}) error {
 	d.Set("sku_size", skuName)
 	d.Set("sku_tier", skuTier)
 
+	identity, err := flattenStaticSiteIdentity(resp.Identity)
+	if err != nil {
+		return fmt.Errorf("flattening `identity`: %+v", err)
+	}
+	if err := d.Set("identity", identity); err != nil {
+		return fmt.Errorf("setting `identity`: %+v", err)
+	}
+
+	appSettingsResp, err := client.ListStaticSiteAppSettings(ctx, id.ResourceGroup, id.Name)
+	if err != nil {
+		return fmt.Errorf("listing App Settings for %s: %+v", id, err)
+	}
+
+	if err := d.Set("app_settings", flattenStaticSiteAppSettings(appSettingsResp.Properties)); err != nil {
+		return fmt.Errorf("setting `app_settings`: %+v", err)
+	}
+
 	secretResp, err := client.ListStaticSiteSecrets(ctx, id.ResourceGroup, id.Name)
 	if err != nil {
 		return fmt.Errorf("listing secretes for %s: %v", id, err)
@@ -194,12 +277,93 @@ func resourceStaticSiteDelete(d *pluginsdk.ResourceData, meta interface{}) error
 
 	log.Printf("[DEBUG] Deleting Static Site %q (resource group %q)", id.Name, id.ResourceGroup)
 
-	resp, err := client.DeleteStaticSite(ctx, id.ResourceGroup, id.Name)
+	future, err := client.DeleteStaticSite(ctx, id.ResourceGroup, id.Name)
 	if err != nil {
-		if !utils.ResponseWasNotFound(resp) {
-			return err
+		if !response.WasNotFound(future.Response()) {
+			return fmt.Errorf("deleting %s: %+v", id, err)
 		}
+		return nil
+	}
+
+	if err := future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		return fmt.Errorf("waiting for deletion of %s: %+v", id, err)
 	}
 
 	return nil
 }
+
+func expandStaticSiteAppSettings(input map[string]interface{}) map[string]*string {
+	output := make(map[string]*string)
+	for k, v := range input {
+		output[k] = utils.String(v.(string))
+	}
+	return output
+}
+
+func flattenStaticSiteAppSettings(input map[string]*string) map[string]interface{} {
+	output := make(map[string]interface{})
+	for k, v := range input {
+		if v != nil {
+			output[k] = *v
+		}
+	}
+	return output
+}
+
+func expandStaticSiteIdentity(input []interface{}) *web.ManagedServiceIdentity {
+	// the identity is explicitly set to `None` when the block is removed, since omitting it leaves it unchanged
+	if len(input) == 0 || input[0] == nil {
+		return &web.ManagedServiceIdentity{
+			Type: web.ManagedServiceIdentityTypeNone,
+		}
+	}
+
+	raw := input[0].(map[string]interface{})
+	identity := web.ManagedServiceIdentity{
+		Type: web.ManagedServiceIdentityType(raw["type"].(string)),
+	}
+
+	if identity.Type == web.ManagedServiceIdentityTypeUserAssigned || identity.Type == web.ManagedServiceIdentityTypeSystemAssignedUserAssigned {
+		identityIds := make(map[string]*web.UserAssignedIdentity)
+		for _, id := range raw["identity_ids"].([]interface{}) {
+			identityIds[id.(string)] = &web.UserAssignedIdentity{}
+		}
+		identity.UserAssignedIdentities = identityIds
+	}
+
+	return &identity
+}
+
+func flattenStaticSiteIdentity(input *web.ManagedServiceIdentity) ([]interface{}, error) {
+	if input == nil || input.Type == web.ManagedServiceIdentityTypeNone {
+		return []interface{}{}, nil
+	}
+
+	principalId := ""
+	if input.PrincipalID != nil {
+		principalId = *input.PrincipalID
+	}
+
+	tenantId := ""
+	if input.TenantID != nil {
+		tenantId = *input.TenantID
+	}
+
+	identityIds := make([]interface{}, 0)
+	for key := range input.UserAssignedIdentities {
+		id, err := msiParse.UserAssignedIdentityID(key)
+		if err != nil {
+			return nil, err
+		}
+		identityIds = append(identityIds, id.ID())
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"type":         string(input.Type),
+			"identity_ids": identityIds,
+			"principal_id": principalId,
+			"tenant_id":    tenantId,
+		},
+	}, nil
+}
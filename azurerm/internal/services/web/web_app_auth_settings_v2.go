@@ -0,0 +1,1030 @@
+package web
+
+import (
+	"github.com/Azure/azure-sdk-for-go/services/web/mgmt/2020-06-01/web"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tf/pluginsdk"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tf/validation"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+type AuthV2Settings struct {
+	AuthEnabled                        bool                 `tfschema:"auth_enabled"`
+	RuntimeVersion                     string               `tfschema:"runtime_version"`
+	ConfigFilePath                     string               `tfschema:"config_file_path"`
+	RequireAuthentication              bool                 `tfschema:"require_authentication"`
+	UnauthenticatedAction              string               `tfschema:"unauthenticated_action"`
+	DefaultProvider                    string               `tfschema:"default_provider"`
+	ExcludedPaths                      []string             `tfschema:"excluded_paths"`
+	RequireHTTPS                       bool                 `tfschema:"require_https"`
+	HttpRouteApiPrefix                 string               `tfschema:"http_route_api_prefix"`
+	ForwardProxyConvention             string               `tfschema:"forward_proxy_convention"`
+	ForwardProxyCustomHostHeaderName   string               `tfschema:"forward_proxy_custom_host_header_name"`
+	ForwardProxyCustomSchemeHeaderName string               `tfschema:"forward_proxy_custom_scheme_header_name"`
+	AzureActiveDirectoryAuth           []AadAuthV2Settings  `tfschema:"active_directory_v2"`
+	FacebookAuth                       []FacebookAuthV2     `tfschema:"facebook_v2"`
+	GithubAuth                         []GithubAuthV2       `tfschema:"github_v2"`
+	GoogleAuth                         []GoogleAuthV2       `tfschema:"google_v2"`
+	TwitterAuth                        []TwitterAuthV2      `tfschema:"twitter_v2"`
+	Login                              []AuthV2LoginSetting `tfschema:"login"`
+}
+
+type AadAuthV2Settings struct {
+	ClientId                          string   `tfschema:"client_id"`
+	TenantAuthURI                     string   `tfschema:"tenant_auth_endpoint"`
+	ClientSecretSettingName           string   `tfschema:"client_secret_setting_name"`
+	ClientSecretCertificateThumbprint string   `tfschema:"client_secret_certificate_thumbprint"`
+	JWTAllowedGroups                  []string `tfschema:"jwt_allowed_groups"`
+	JWTAllowedClientApps              []string `tfschema:"jwt_allowed_client_applications"`
+	WWWAuthDisabled                   bool     `tfschema:"www_authentication_disabled"`
+	AllowedAudiences                  []string `tfschema:"allowed_audiences"`
+	LoginParameters                   []string `tfschema:"login_parameters"`
+}
+
+type FacebookAuthV2 struct {
+	AppId                string   `tfschema:"app_id"`
+	AppSecretSettingName string   `tfschema:"app_secret_setting_name"`
+	GraphAPIVersion      string   `tfschema:"graph_api_version"`
+	LoginScopes          []string `tfschema:"login_scopes"`
+}
+
+type GithubAuthV2 struct {
+	ClientId                string   `tfschema:"client_id"`
+	ClientSecretSettingName string   `tfschema:"client_secret_setting_name"`
+	LoginScopes             []string `tfschema:"login_scopes"`
+}
+
+type GoogleAuthV2 struct {
+	ClientId                string   `tfschema:"client_id"`
+	ClientSecretSettingName string   `tfschema:"client_secret_setting_name"`
+	AllowedAudiences        []string `tfschema:"allowed_audiences"`
+	LoginScopes             []string `tfschema:"login_scopes"`
+}
+
+type TwitterAuthV2 struct {
+	ConsumerKey               string `tfschema:"consumer_key"`
+	ConsumerSecretSettingName string `tfschema:"consumer_secret_setting_name"`
+}
+
+type AuthV2LoginSetting struct {
+	LogoutEndpoint                string   `tfschema:"logout_endpoint"`
+	TokenStoreEnabled             bool     `tfschema:"token_store_enabled"`
+	TokenRefreshExtensionHours    float64  `tfschema:"token_refresh_extension_time"`
+	TokenFilesystemPath           string   `tfschema:"token_store_path"`
+	TokenBlobStorageSAS           string   `tfschema:"token_store_sas_setting_name"`
+	PreserveURLFragmentsForLogins bool     `tfschema:"preserve_url_fragments_for_logins"`
+	AllowedExternalRedirectURLs   []string `tfschema:"allowed_external_redirect_urls"`
+	CookieExpirationConvention    string   `tfschema:"cookie_expiration_convention"`
+	CookieExpirationTime          string   `tfschema:"cookie_expiration_time"`
+	ValidateNonce                 bool     `tfschema:"validate_nonce"`
+	NonceExpirationTime           string   `tfschema:"nonce_expiration_time"`
+}
+
+func schemaAuthV2Settings() *pluginsdk.Schema {
+	return &pluginsdk.Schema{
+		Type:     pluginsdk.TypeList,
+		Optional: true,
+		MaxItems: 1,
+		Elem: &pluginsdk.Resource{
+			Schema: map[string]*pluginsdk.Schema{
+				"auth_enabled": {
+					Type:     pluginsdk.TypeBool,
+					Optional: true,
+					Default:  false,
+				},
+
+				"runtime_version": {
+					Type:         pluginsdk.TypeString,
+					Optional:     true,
+					Default:      "~1",
+					ValidateFunc: validation.StringIsNotEmpty,
+				},
+
+				"config_file_path": {
+					Type:         pluginsdk.TypeString,
+					Optional:     true,
+					ValidateFunc: validation.StringIsNotEmpty,
+				},
+
+				"require_authentication": {
+					Type:     pluginsdk.TypeBool,
+					Optional: true,
+					Default:  false,
+				},
+
+				"unauthenticated_action": {
+					Type:     pluginsdk.TypeString,
+					Optional: true,
+					Default:  string(web.UnauthenticatedClientActionV2RedirectToLoginPage),
+					ValidateFunc: validation.StringInSlice([]string{
+						string(web.UnauthenticatedClientActionV2AllowAnonymous),
+						string(web.UnauthenticatedClientActionV2RedirectToLoginPage),
+						string(web.UnauthenticatedClientActionV2Return401),
+						string(web.UnauthenticatedClientActionV2Return403),
+					}, false),
+				},
+
+				"default_provider": {
+					Type:     pluginsdk.TypeString,
+					Optional: true,
+					ValidateFunc: validation.StringInSlice([]string{
+						"azureactivedirectory",
+						"facebook",
+						"github",
+						"google",
+						"twitter",
+					}, false),
+				},
+
+				"excluded_paths": {
+					Type:     pluginsdk.TypeList,
+					Optional: true,
+					Elem: &pluginsdk.Schema{
+						Type:         pluginsdk.TypeString,
+						ValidateFunc: validation.StringIsNotEmpty,
+					},
+				},
+
+				"require_https": {
+					Type:     pluginsdk.TypeBool,
+					Optional: true,
+					Default:  true,
+				},
+
+				"http_route_api_prefix": {
+					Type:         pluginsdk.TypeString,
+					Optional:     true,
+					Default:      "/.auth",
+					ValidateFunc: validation.StringIsNotEmpty,
+				},
+
+				"forward_proxy_convention": {
+					Type:     pluginsdk.TypeString,
+					Optional: true,
+					Default:  string(web.ForwardProxyConventionNoProxy),
+					ValidateFunc: validation.StringInSlice([]string{
+						string(web.ForwardProxyConventionNoProxy),
+						string(web.ForwardProxyConventionStandard),
+						string(web.ForwardProxyConventionCustom),
+					}, false),
+				},
+
+				"forward_proxy_custom_host_header_name": {
+					Type:         pluginsdk.TypeString,
+					Optional:     true,
+					ValidateFunc: validation.StringIsNotEmpty,
+				},
+
+				"forward_proxy_custom_scheme_header_name": {
+					Type:         pluginsdk.TypeString,
+					Optional:     true,
+					ValidateFunc: validation.StringIsNotEmpty,
+				},
+
+				"active_directory_v2": schemaAadAuthV2Settings(),
+
+				"facebook_v2": schemaFacebookAuthV2Settings(),
+
+				"github_v2": schemaGithubAuthV2Settings(),
+
+				"google_v2": schemaGoogleAuthV2Settings(),
+
+				"twitter_v2": schemaTwitterAuthV2Settings(),
+
+				"login": schemaAuthV2LoginSettings(),
+			},
+		},
+	}
+}
+
+func schemaAadAuthV2Settings() *pluginsdk.Schema {
+	return &pluginsdk.Schema{
+		Type:     pluginsdk.TypeList,
+		Optional: true,
+		MaxItems: 1,
+		Elem: &pluginsdk.Resource{
+			Schema: map[string]*pluginsdk.Schema{
+				"client_id": {
+					Type:         pluginsdk.TypeString,
+					Required:     true,
+					ValidateFunc: validation.StringIsNotEmpty,
+				},
+
+				"tenant_auth_endpoint": {
+					Type:         pluginsdk.TypeString,
+					Required:     true,
+					ValidateFunc: validation.IsURLWithHTTPS,
+				},
+
+				"client_secret_setting_name": {
+					Type:         pluginsdk.TypeString,
+					Optional:     true,
+					ValidateFunc: validation.StringIsNotEmpty,
+					ConflictsWith: []string{
+						"auth_settings_v2.0.active_directory_v2.0.client_secret_certificate_thumbprint",
+					},
+				},
+
+				"client_secret_certificate_thumbprint": {
+					Type:         pluginsdk.TypeString,
+					Optional:     true,
+					ValidateFunc: validation.StringIsNotEmpty,
+					ConflictsWith: []string{
+						"auth_settings_v2.0.active_directory_v2.0.client_secret_setting_name",
+					},
+				},
+
+				"jwt_allowed_groups": {
+					Type:     pluginsdk.TypeList,
+					Optional: true,
+					Elem: &pluginsdk.Schema{
+						Type:         pluginsdk.TypeString,
+						ValidateFunc: validation.StringIsNotEmpty,
+					},
+				},
+
+				"jwt_allowed_client_applications": {
+					Type:     pluginsdk.TypeList,
+					Optional: true,
+					Elem: &pluginsdk.Schema{
+						Type:         pluginsdk.TypeString,
+						ValidateFunc: validation.StringIsNotEmpty,
+					},
+				},
+
+				"www_authentication_disabled": {
+					Type:     pluginsdk.TypeBool,
+					Optional: true,
+					Default:  false,
+				},
+
+				"allowed_audiences": {
+					Type:     pluginsdk.TypeList,
+					Optional: true,
+					Elem: &pluginsdk.Schema{
+						Type:         pluginsdk.TypeString,
+						ValidateFunc: validation.StringIsNotEmpty,
+					},
+				},
+
+				"login_parameters": {
+					Type:     pluginsdk.TypeList,
+					Optional: true,
+					Elem: &pluginsdk.Schema{
+						Type:         pluginsdk.TypeString,
+						ValidateFunc: validation.StringIsNotEmpty,
+					},
+				},
+			},
+		},
+	}
+}
+
+func schemaFacebookAuthV2Settings() *pluginsdk.Schema {
+	return &pluginsdk.Schema{
+		Type:     pluginsdk.TypeList,
+		Optional: true,
+		MaxItems: 1,
+		Elem: &pluginsdk.Resource{
+			Schema: map[string]*pluginsdk.Schema{
+				"app_id": {
+					Type:         pluginsdk.TypeString,
+					Required:     true,
+					ValidateFunc: validation.StringIsNotEmpty,
+				},
+
+				"app_secret_setting_name": {
+					Type:         pluginsdk.TypeString,
+					Required:     true,
+					ValidateFunc: validation.StringIsNotEmpty,
+				},
+
+				"graph_api_version": {
+					Type:         pluginsdk.TypeString,
+					Optional:     true,
+					Computed:     true,
+					ValidateFunc: validation.StringIsNotEmpty,
+				},
+
+				"login_scopes": schemaAuthV2LoginScopes(),
+			},
+		},
+	}
+}
+
+func schemaGithubAuthV2Settings() *pluginsdk.Schema {
+	return &pluginsdk.Schema{
+		Type:     pluginsdk.TypeList,
+		Optional: true,
+		MaxItems: 1,
+		Elem: &pluginsdk.Resource{
+			Schema: map[string]*pluginsdk.Schema{
+				"client_id": {
+					Type:         pluginsdk.TypeString,
+					Required:     true,
+					ValidateFunc: validation.StringIsNotEmpty,
+				},
+
+				"client_secret_setting_name": {
+					Type:         pluginsdk.TypeString,
+					Required:     true,
+					ValidateFunc: validation.StringIsNotEmpty,
+				},
+
+				"login_scopes": schemaAuthV2LoginScopes(),
+			},
+		},
+	}
+}
+
+func schemaGoogleAuthV2Settings() *pluginsdk.Schema {
+	return &pluginsdk.Schema{
+		Type:     pluginsdk.TypeList,
+		Optional: true,
+		MaxItems: 1,
+		Elem: &pluginsdk.Resource{
+			Schema: map[string]*pluginsdk.Schema{
+				"client_id": {
+					Type:         pluginsdk.TypeString,
+					Required:     true,
+					ValidateFunc: validation.StringIsNotEmpty,
+				},
+
+				"client_secret_setting_name": {
+					Type:         pluginsdk.TypeString,
+					Required:     true,
+					ValidateFunc: validation.StringIsNotEmpty,
+				},
+
+				"allowed_audiences": {
+					Type:     pluginsdk.TypeList,
+					Optional: true,
+					Elem: &pluginsdk.Schema{
+						Type:         pluginsdk.TypeString,
+						ValidateFunc: validation.StringIsNotEmpty,
+					},
+				},
+
+				"login_scopes": schemaAuthV2LoginScopes(),
+			},
+		},
+	}
+}
+
+func schemaTwitterAuthV2Settings() *pluginsdk.Schema {
+	return &pluginsdk.Schema{
+		Type:     pluginsdk.TypeList,
+		Optional: true,
+		MaxItems: 1,
+		Elem: &pluginsdk.Resource{
+			Schema: map[string]*pluginsdk.Schema{
+				"consumer_key": {
+					Type:         pluginsdk.TypeString,
+					Required:     true,
+					ValidateFunc: validation.StringIsNotEmpty,
+				},
+
+				"consumer_secret_setting_name": {
+					Type:         pluginsdk.TypeString,
+					Required:     true,
+					ValidateFunc: validation.StringIsNotEmpty,
+				},
+			},
+		},
+	}
+}
+
+func schemaAuthV2LoginScopes() *pluginsdk.Schema {
+	return &pluginsdk.Schema{
+		Type:     pluginsdk.TypeList,
+		Optional: true,
+		Elem: &pluginsdk.Schema{
+			Type:         pluginsdk.TypeString,
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+	}
+}
+
+func schemaAuthV2LoginSettings() *pluginsdk.Schema {
+	return &pluginsdk.Schema{
+		Type:     pluginsdk.TypeList,
+		Required: true,
+		MaxItems: 1,
+		Elem: &pluginsdk.Resource{
+			Schema: map[string]*pluginsdk.Schema{
+				"logout_endpoint": {
+					Type:         pluginsdk.TypeString,
+					Optional:     true,
+					ValidateFunc: validation.StringIsNotEmpty,
+				},
+
+				"token_store_enabled": {
+					Type:     pluginsdk.TypeBool,
+					Optional: true,
+					Default:  false,
+				},
+
+				"token_refresh_extension_time": {
+					Type:         pluginsdk.TypeFloat,
+					Optional:     true,
+					Default:      72,
+					ValidateFunc: validation.FloatAtLeast(0),
+				},
+
+				"token_store_path": {
+					Type:         pluginsdk.TypeString,
+					Optional:     true,
+					ValidateFunc: validation.StringIsNotEmpty,
+					ConflictsWith: []string{
+						"auth_settings_v2.0.login.0.token_store_sas_setting_name",
+					},
+				},
+
+				"token_store_sas_setting_name": {
+					Type:         pluginsdk.TypeString,
+					Optional:     true,
+					ValidateFunc: validation.StringIsNotEmpty,
+					ConflictsWith: []string{
+						"auth_settings_v2.0.login.0.token_store_path",
+					},
+				},
+
+				"preserve_url_fragments_for_logins": {
+					Type:     pluginsdk.TypeBool,
+					Optional: true,
+					Default:  false,
+				},
+
+				"allowed_external_redirect_urls": {
+					Type:     pluginsdk.TypeList,
+					Optional: true,
+					Elem: &pluginsdk.Schema{
+						Type:         pluginsdk.TypeString,
+						ValidateFunc: validation.StringIsNotEmpty,
+					},
+				},
+
+				"cookie_expiration_convention": {
+					Type:     pluginsdk.TypeString,
+					Optional: true,
+					Default:  string(web.FixedTime),
+					ValidateFunc: validation.StringInSlice([]string{
+						string(web.FixedTime),
+						string(web.IdentityProviderDerived),
+					}, false),
+				},
+
+				"cookie_expiration_time": {
+					Type:         pluginsdk.TypeString,
+					Optional:     true,
+					Default:      "08:00:00",
+					ValidateFunc: validation.StringIsNotEmpty,
+				},
+
+				"validate_nonce": {
+					Type:     pluginsdk.TypeBool,
+					Optional: true,
+					Default:  true,
+				},
+
+				"nonce_expiration_time": {
+					Type:         pluginsdk.TypeString,
+					Optional:     true,
+					Default:      "00:05:00",
+					ValidateFunc: validation.StringIsNotEmpty,
+				},
+			},
+		},
+	}
+}
+
+// expandAuthV2Settings builds the full set of Authentication v2 settings - since the API replaces the settings
+// wholesale removing the block results in the platform being disabled, rather than the settings being removed.
+func expandAuthV2Settings(input []AuthV2Settings) web.SiteAuthSettingsV2 {
+	if len(input) == 0 {
+		return web.SiteAuthSettingsV2{
+			SiteAuthSettingsV2Properties: &web.SiteAuthSettingsV2Properties{
+				Platform: &web.AuthPlatform{
+					AuthPlatformProperties: &web.AuthPlatformProperties{
+						Enabled: utils.Bool(false),
+					},
+				},
+			},
+		}
+	}
+
+	settings := input[0]
+
+	excludedPaths := make([]string, 0)
+	excludedPaths = append(excludedPaths, settings.ExcludedPaths...)
+
+	globalValidation := &web.GlobalValidationProperties{
+		RequireAuthentication:       utils.Bool(settings.RequireAuthentication),
+		UnauthenticatedClientAction: web.UnauthenticatedClientActionV2(settings.UnauthenticatedAction),
+		ExcludedPaths:               &excludedPaths,
+	}
+	if settings.DefaultProvider != "" {
+		globalValidation.RedirectToProvider = utils.String(settings.DefaultProvider)
+	}
+
+	forwardProxy := &web.ForwardProxyProperties{
+		Convention: web.ForwardProxyConvention(settings.ForwardProxyConvention),
+	}
+	if settings.ForwardProxyCustomHostHeaderName != "" {
+		forwardProxy.CustomHostHeaderName = utils.String(settings.ForwardProxyCustomHostHeaderName)
+	}
+	if settings.ForwardProxyCustomSchemeHeaderName != "" {
+		forwardProxy.CustomProtoHeaderName = utils.String(settings.ForwardProxyCustomSchemeHeaderName)
+	}
+
+	platform := &web.AuthPlatformProperties{
+		Enabled:        utils.Bool(settings.AuthEnabled),
+		RuntimeVersion: utils.String(settings.RuntimeVersion),
+	}
+	if settings.ConfigFilePath != "" {
+		platform.ConfigFilePath = utils.String(settings.ConfigFilePath)
+	}
+
+	return web.SiteAuthSettingsV2{
+		SiteAuthSettingsV2Properties: &web.SiteAuthSettingsV2Properties{
+			Platform: &web.AuthPlatform{
+				AuthPlatformProperties: platform,
+			},
+			GlobalValidation: &web.GlobalValidation{
+				GlobalValidationProperties: globalValidation,
+			},
+			IdentityProviders: &web.IdentityProviders{
+				IdentityProvidersProperties: &web.IdentityProvidersProperties{
+					AzureActiveDirectory: expandAadAuthV2Settings(settings.AzureActiveDirectoryAuth),
+					Facebook:             expandFacebookAuthV2Settings(settings.FacebookAuth),
+					GitHub:               expandGithubAuthV2Settings(settings.GithubAuth),
+					Google:               expandGoogleAuthV2Settings(settings.GoogleAuth),
+					Twitter:              expandTwitterAuthV2Settings(settings.TwitterAuth),
+				},
+			},
+			Login: expandAuthV2LoginSettings(settings.Login),
+			HTTPSettings: &web.HTTPSettings{
+				HTTPSettingsProperties: &web.HTTPSettingsProperties{
+					RequireHTTPS: utils.Bool(settings.RequireHTTPS),
+					Routes: &web.HTTPSettingsRoutes{
+						HTTPSettingsRoutesProperties: &web.HTTPSettingsRoutesProperties{
+							APIPrefix: utils.String(settings.HttpRouteApiPrefix),
+						},
+					},
+					ForwardProxy: &web.ForwardProxy{
+						ForwardProxyProperties: forwardProxy,
+					},
+				},
+			},
+		},
+	}
+}
+
+func expandAadAuthV2Settings(input []AadAuthV2Settings) *web.AzureActiveDirectory {
+	if len(input) == 0 {
+		return &web.AzureActiveDirectory{
+			AzureActiveDirectoryProperties: &web.AzureActiveDirectoryProperties{
+				Enabled: utils.Bool(false),
+			},
+		}
+	}
+
+	aad := input[0]
+	registration := &web.AzureActiveDirectoryRegistrationProperties{
+		OpenIDIssuer: utils.String(aad.TenantAuthURI),
+		ClientID:     utils.String(aad.ClientId),
+	}
+	if aad.ClientSecretSettingName != "" {
+		registration.ClientSecretSettingName = utils.String(aad.ClientSecretSettingName)
+	}
+	if aad.ClientSecretCertificateThumbprint != "" {
+		registration.ClientSecretCertificateThumbprint = utils.String(aad.ClientSecretCertificateThumbprint)
+	}
+
+	return &web.AzureActiveDirectory{
+		AzureActiveDirectoryProperties: &web.AzureActiveDirectoryProperties{
+			Enabled: utils.Bool(true),
+			Registration: &web.AzureActiveDirectoryRegistration{
+				AzureActiveDirectoryRegistrationProperties: registration,
+			},
+			Login: &web.AzureActiveDirectoryLogin{
+				AzureActiveDirectoryLoginProperties: &web.AzureActiveDirectoryLoginProperties{
+					DisableWWWAuthenticate: utils.Bool(aad.WWWAuthDisabled),
+					LoginParameters:        &aad.LoginParameters,
+				},
+			},
+			Validation: &web.AzureActiveDirectoryValidation{
+				AzureActiveDirectoryValidationProperties: &web.AzureActiveDirectoryValidationProperties{
+					JwtClaimChecks: &web.JwtClaimChecks{
+						JwtClaimChecksProperties: &web.JwtClaimChecksProperties{
+							AllowedGroups:             &aad.JWTAllowedGroups,
+							AllowedClientApplications: &aad.JWTAllowedClientApps,
+						},
+					},
+					AllowedAudiences: &aad.AllowedAudiences,
+				},
+			},
+		},
+	}
+}
+
+func expandFacebookAuthV2Settings(input []FacebookAuthV2) *web.Facebook {
+	if len(input) == 0 {
+		return &web.Facebook{
+			FacebookProperties: &web.FacebookProperties{
+				Enabled: utils.Bool(false),
+			},
+		}
+	}
+
+	facebook := input[0]
+	result := &web.Facebook{
+		FacebookProperties: &web.FacebookProperties{
+			Enabled: utils.Bool(true),
+			Registration: &web.AppRegistration{
+				AppRegistrationProperties: &web.AppRegistrationProperties{
+					AppID:                utils.String(facebook.AppId),
+					AppSecretSettingName: utils.String(facebook.AppSecretSettingName),
+				},
+			},
+			Login: expandAuthV2LoginScopes(facebook.LoginScopes),
+		},
+	}
+	if facebook.GraphAPIVersion != "" {
+		result.FacebookProperties.GraphAPIVersion = utils.String(facebook.GraphAPIVersion)
+	}
+
+	return result
+}
+
+func expandGithubAuthV2Settings(input []GithubAuthV2) *web.GitHub {
+	if len(input) == 0 {
+		return &web.GitHub{
+			GitHubProperties: &web.GitHubProperties{
+				Enabled: utils.Bool(false),
+			},
+		}
+	}
+
+	github := input[0]
+	return &web.GitHub{
+		GitHubProperties: &web.GitHubProperties{
+			Enabled: utils.Bool(true),
+			Registration: &web.ClientRegistration{
+				ClientRegistrationProperties: &web.ClientRegistrationProperties{
+					ClientID:                utils.String(github.ClientId),
+					ClientSecretSettingName: utils.String(github.ClientSecretSettingName),
+				},
+			},
+			Login: expandAuthV2LoginScopes(github.LoginScopes),
+		},
+	}
+}
+
+func expandGoogleAuthV2Settings(input []GoogleAuthV2) *web.Google {
+	if len(input) == 0 {
+		return &web.Google{
+			GoogleProperties: &web.GoogleProperties{
+				Enabled: utils.Bool(false),
+			},
+		}
+	}
+
+	google := input[0]
+	return &web.Google{
+		GoogleProperties: &web.GoogleProperties{
+			Enabled: utils.Bool(true),
+			Registration: &web.ClientRegistration{
+				ClientRegistrationProperties: &web.ClientRegistrationProperties{
+					ClientID:                utils.String(google.ClientId),
+					ClientSecretSettingName: utils.String(google.ClientSecretSettingName),
+				},
+			},
+			Login: expandAuthV2LoginScopes(google.LoginScopes),
+			Validation: &web.AllowedAudiencesValidation{
+				AllowedAudiencesValidationProperties: &web.AllowedAudiencesValidationProperties{
+					AllowedAudiences: &google.AllowedAudiences,
+				},
+			},
+		},
+	}
+}
+
+func expandTwitterAuthV2Settings(input []TwitterAuthV2) *web.Twitter {
+	if len(input) == 0 {
+		return &web.Twitter{
+			TwitterProperties: &web.TwitterProperties{
+				Enabled: utils.Bool(false),
+			},
+		}
+	}
+
+	twitter := input[0]
+	return &web.Twitter{
+		TwitterProperties: &web.TwitterProperties{
+			Enabled: utils.Bool(true),
+			Registration: &web.TwitterRegistration{
+				TwitterRegistrationProperties: &web.TwitterRegistrationProperties{
+					ConsumerKey:               utils.String(twitter.ConsumerKey),
+					ConsumerSecretSettingName: utils.String(twitter.ConsumerSecretSettingName),
+				},
+			},
+		},
+	}
+}
+
+func expandAuthV2LoginScopes(input []string) *web.LoginScopes {
+	scopes := make([]string, 0)
+	scopes = append(scopes, input...)
+
+	return &web.LoginScopes{
+		LoginScopesProperties: &web.LoginScopesProperties{
+			Scopes: &scopes,
+		},
+	}
+}
+
+func expandAuthV2LoginSettings(input []AuthV2LoginSetting) *web.Login {
+	if len(input) == 0 {
+		return nil
+	}
+
+	login := input[0]
+
+	tokenStore := &web.TokenStoreProperties{
+		Enabled:                    utils.Bool(login.TokenStoreEnabled),
+		TokenRefreshExtensionHours: utils.Float(login.TokenRefreshExtensionHours),
+	}
+	if login.TokenFilesystemPath != "" {
+		tokenStore.FileSystem = &web.FileSystemTokenStore{
+			FileSystemTokenStoreProperties: &web.FileSystemTokenStoreProperties{
+				Directory: utils.String(login.TokenFilesystemPath),
+			},
+		}
+	}
+	if login.TokenBlobStorageSAS != "" {
+		tokenStore.AzureBlobStorage = &web.BlobStorageTokenStore{
+			BlobStorageTokenStoreProperties: &web.BlobStorageTokenStoreProperties{
+				SasURLSettingName: utils.String(login.TokenBlobStorageSAS),
+			},
+		}
+	}
+
+	redirectUrls := make([]string, 0)
+	redirectUrls = append(redirectUrls, login.AllowedExternalRedirectURLs...)
+
+	result := &web.Login{
+		LoginProperties: &web.LoginProperties{
+			TokenStore: &web.TokenStore{
+				TokenStoreProperties: tokenStore,
+			},
+			PreserveURLFragmentsForLogins: utils.Bool(login.PreserveURLFragmentsForLogins),
+			AllowedExternalRedirectUrls:   &redirectUrls,
+			CookieExpiration: &web.CookieExpiration{
+				CookieExpirationProperties: &web.CookieExpirationProperties{
+					Convention:       web.CookieExpirationConvention(login.CookieExpirationConvention),
+					TimeToExpiration: utils.String(login.CookieExpirationTime),
+				},
+			},
+			Nonce: &web.Nonce{
+				NonceProperties: &web.NonceProperties{
+					ValidateNonce:           utils.Bool(login.ValidateNonce),
+					NonceExpirationInterval: utils.String(login.NonceExpirationTime),
+				},
+			},
+		},
+	}
+
+	if login.LogoutEndpoint != "" {
+		result.LoginProperties.Routes = &web.LoginRoutes{
+			LoginRoutesProperties: &web.LoginRoutesProperties{
+				LogoutEndpoint: utils.String(login.LogoutEndpoint),
+			},
+		}
+	}
+
+	return result
+}
+
+func flattenAuthV2Settings(input web.SiteAuthSettingsV2) []AuthV2Settings {
+	props := input.SiteAuthSettingsV2Properties
+	if props == nil {
+		return []AuthV2Settings{}
+	}
+
+	result := AuthV2Settings{}
+
+	if platform := props.Platform; platform != nil && platform.AuthPlatformProperties != nil {
+		if platform.Enabled != nil {
+			result.AuthEnabled = *platform.Enabled
+		}
+		result.RuntimeVersion = utils.NormalizeNilableString(platform.RuntimeVersion)
+		result.ConfigFilePath = utils.NormalizeNilableString(platform.ConfigFilePath)
+	}
+
+	if global := props.GlobalValidation; global != nil && global.GlobalValidationProperties != nil {
+		if global.RequireAuthentication != nil {
+			result.RequireAuthentication = *global.RequireAuthentication
+		}
+		result.UnauthenticatedAction = string(global.UnauthenticatedClientAction)
+		result.DefaultProvider = utils.NormalizeNilableString(global.RedirectToProvider)
+		if global.ExcludedPaths != nil {
+			result.ExcludedPaths = *global.ExcludedPaths
+		}
+	}
+
+	if http := props.HTTPSettings; http != nil && http.HTTPSettingsProperties != nil {
+		if http.RequireHTTPS != nil {
+			result.RequireHTTPS = *http.RequireHTTPS
+		}
+		if routes := http.Routes; routes != nil && routes.HTTPSettingsRoutesProperties != nil {
+			result.HttpRouteApiPrefix = utils.NormalizeNilableString(routes.APIPrefix)
+		}
+		if proxy := http.ForwardProxy; proxy != nil && proxy.ForwardProxyProperties != nil {
+			result.ForwardProxyConvention = string(proxy.Convention)
+			result.ForwardProxyCustomHostHeaderName = utils.NormalizeNilableString(proxy.CustomHostHeaderName)
+			result.ForwardProxyCustomSchemeHeaderName = utils.NormalizeNilableString(proxy.CustomProtoHeaderName)
+		}
+	}
+
+	if providers := props.IdentityProviders; providers != nil && providers.IdentityProvidersProperties != nil {
+		result.AzureActiveDirectoryAuth = flattenAadAuthV2Settings(providers.AzureActiveDirectory)
+		result.FacebookAuth = flattenFacebookAuthV2Settings(providers.Facebook)
+		result.GithubAuth = flattenGithubAuthV2Settings(providers.GitHub)
+		result.GoogleAuth = flattenGoogleAuthV2Settings(providers.Google)
+		result.TwitterAuth = flattenTwitterAuthV2Settings(providers.Twitter)
+	}
+
+	// when Authentication v2 has never been configured (or has been removed) the API returns the platform as disabled
+	// with no identity providers - which we treat as the block being absent
+	if !result.AuthEnabled && len(result.AzureActiveDirectoryAuth) == 0 && len(result.FacebookAuth) == 0 &&
+		len(result.GithubAuth) == 0 && len(result.GoogleAuth) == 0 && len(result.TwitterAuth) == 0 {
+		return []AuthV2Settings{}
+	}
+
+	result.Login = flattenAuthV2LoginSettings(props.Login)
+
+	return []AuthV2Settings{result}
+}
+
+func flattenAadAuthV2Settings(input *web.AzureActiveDirectory) []AadAuthV2Settings {
+	if input == nil || input.AzureActiveDirectoryProperties == nil || input.Enabled == nil || !*input.Enabled {
+		return []AadAuthV2Settings{}
+	}
+
+	result := AadAuthV2Settings{}
+
+	if registration := input.Registration; registration != nil && registration.AzureActiveDirectoryRegistrationProperties != nil {
+		result.ClientId = utils.NormalizeNilableString(registration.ClientID)
+		result.TenantAuthURI = utils.NormalizeNilableString(registration.OpenIDIssuer)
+		result.ClientSecretSettingName = utils.NormalizeNilableString(registration.ClientSecretSettingName)
+		result.ClientSecretCertificateThumbprint = utils.NormalizeNilableString(registration.ClientSecretCertificateThumbprint)
+	}
+
+	if login := input.Login; login != nil && login.AzureActiveDirectoryLoginProperties != nil {
+		if login.DisableWWWAuthenticate != nil {
+			result.WWWAuthDisabled = *login.DisableWWWAuthenticate
+		}
+		if login.LoginParameters != nil {
+			result.LoginParameters = *login.LoginParameters
+		}
+	}
+
+	if validation := input.Validation; validation != nil && validation.AzureActiveDirectoryValidationProperties != nil {
+		if validation.AllowedAudiences != nil {
+			result.AllowedAudiences = *validation.AllowedAudiences
+		}
+		if checks := validation.JwtClaimChecks; checks != nil && checks.JwtClaimChecksProperties != nil {
+			if checks.AllowedGroups != nil {
+				result.JWTAllowedGroups = *checks.AllowedGroups
+			}
+			if checks.AllowedClientApplications != nil {
+				result.JWTAllowedClientApps = *checks.AllowedClientApplications
+			}
+		}
+	}
+
+	return []AadAuthV2Settings{result}
+}
+
+func flattenFacebookAuthV2Settings(input *web.Facebook) []FacebookAuthV2 {
+	if input == nil || input.FacebookProperties == nil || input.Enabled == nil || !*input.Enabled {
+		return []FacebookAuthV2{}
+	}
+
+	result := FacebookAuthV2{
+		GraphAPIVersion: utils.NormalizeNilableString(input.GraphAPIVersion),
+		LoginScopes:     flattenAuthV2LoginScopes(input.Login),
+	}
+	if registration := input.Registration; registration != nil && registration.AppRegistrationProperties != nil {
+		result.AppId = utils.NormalizeNilableString(registration.AppID)
+		result.AppSecretSettingName = utils.NormalizeNilableString(registration.AppSecretSettingName)
+	}
+
+	return []FacebookAuthV2{result}
+}
+
+func flattenGithubAuthV2Settings(input *web.GitHub) []GithubAuthV2 {
+	if input == nil || input.GitHubProperties == nil || input.Enabled == nil || !*input.Enabled {
+		return []GithubAuthV2{}
+	}
+
+	result := GithubAuthV2{
+		LoginScopes: flattenAuthV2LoginScopes(input.Login),
+	}
+	if registration := input.Registration; registration != nil && registration.ClientRegistrationProperties != nil {
+		result.ClientId = utils.NormalizeNilableString(registration.ClientID)
+		result.ClientSecretSettingName = utils.NormalizeNilableString(registration.ClientSecretSettingName)
+	}
+
+	return []GithubAuthV2{result}
+}
+
+func flattenGoogleAuthV2Settings(input *web.Google) []GoogleAuthV2 {
+	if input == nil || input.GoogleProperties == nil || input.Enabled == nil || !*input.Enabled {
+		return []GoogleAuthV2{}
+	}
+
+	result := GoogleAuthV2{
+		LoginScopes: flattenAuthV2LoginScopes(input.Login),
+	}
+	if registration := input.Registration; registration != nil && registration.ClientRegistrationProperties != nil {
+		result.ClientId = utils.NormalizeNilableString(registration.ClientID)
+		result.ClientSecretSettingName = utils.NormalizeNilableString(registration.ClientSecretSettingName)
+	}
+	if validation := input.Validation; validation != nil && validation.AllowedAudiencesValidationProperties != nil && validation.AllowedAudiences != nil {
+		result.AllowedAudiences = *validation.AllowedAudiences
+	}
+
+	return []GoogleAuthV2{result}
+}
+
+func flattenTwitterAuthV2Settings(input *web.Twitter) []TwitterAuthV2 {
+	if input == nil || input.TwitterProperties == nil || input.Enabled == nil || !*input.Enabled {
+		return []TwitterAuthV2{}
+	}
+
+	result := TwitterAuthV2{}
+	if registration := input.Registration; registration != nil && registration.TwitterRegistrationProperties != nil {
+		result.ConsumerKey = utils.NormalizeNilableString(registration.ConsumerKey)
+		result.ConsumerSecretSettingName = utils.NormalizeNilableString(registration.ConsumerSecretSettingName)
+	}
+
+	return []TwitterAuthV2{result}
+}
+
+func flattenAuthV2LoginScopes(input *web.LoginScopes) []string {
+	if input == nil || input.LoginScopesProperties == nil || input.Scopes == nil {
+		return []string{}
+	}
+
+	return *input.Scopes
+}
+
+func flattenAuthV2LoginSettings(input *web.Login) []AuthV2LoginSetting {
+	if input == nil || input.LoginProperties == nil {
+		return []AuthV2LoginSetting{}
+	}
+
+	result := AuthV2LoginSetting{}
+
+	if routes := input.Routes; routes != nil && routes.LoginRoutesProperties != nil {
+		result.LogoutEndpoint = utils.NormalizeNilableString(routes.LogoutEndpoint)
+	}
+
+	if tokenStore := input.TokenStore; tokenStore != nil && tokenStore.TokenStoreProperties != nil {
+		if tokenStore.Enabled != nil {
+			result.TokenStoreEnabled = *tokenStore.Enabled
+		}
+		if tokenStore.TokenRefreshExtensionHours != nil {
+			result.TokenRefreshExtensionHours = *tokenStore.TokenRefreshExtensionHours
+		}
+		if fs := tokenStore.FileSystem; fs != nil && fs.FileSystemTokenStoreProperties != nil {
+			result.TokenFilesystemPath = utils.NormalizeNilableString(fs.Directory)
+		}
+		if blob := tokenStore.AzureBlobStorage; blob != nil && blob.BlobStorageTokenStoreProperties != nil {
+			result.TokenBlobStorageSAS = utils.NormalizeNilableString(blob.SasURLSettingName)
+		}
+	}
+
+	if input.PreserveURLFragmentsForLogins != nil {
+		result.PreserveURLFragmentsForLogins = *input.PreserveURLFragmentsForLogins
+	}
+
+	if input.AllowedExternalRedirectUrls != nil {
+		result.AllowedExternalRedirectURLs = *input.AllowedExternalRedirectUrls
+	}
+
+	if cookie := input.CookieExpiration; cookie != nil && cookie.CookieExpirationProperties != nil {
+		result.CookieExpirationConvention = string(cookie.Convention)
+		result.CookieExpirationTime = utils.NormalizeNilableString(cookie.TimeToExpiration)
+	}
+
+	if nonce := input.Nonce; nonce != nil && nonce.NonceProperties != nil {
+		if nonce.ValidateNonce != nil {
+			result.ValidateNonce = *nonce.ValidateNonce
+		}
+		result.NonceExpirationTime = utils.NormalizeNilableString(nonce.NonceExpirationInterval)
+	}
+
+	return []AuthV2LoginSetting{result}
+}
@@ -0,0 +1,638 @@
+package web
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/web/mgmt/2020-06-01/web"
+	"github.com/Azure/go-autorest/autorest/date"
+	msiParse "github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/msi/parse"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/web/parse"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tf/pluginsdk"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tf/validation"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+type WebAppIdentity struct {
+	Type        string   `tfschema:"type"`
+	IdentityIds []string `tfschema:"identity_ids"`
+	PrincipalId string   `tfschema:"principal_id"`
+	TenantId    string   `tfschema:"tenant_id"`
+}
+
+type WebAppConnectionString struct {
+	Name  string `tfschema:"name"`
+	Type  string `tfschema:"type"`
+	Value string `tfschema:"value"`
+}
+
+type WebAppBackup struct {
+	Name              string                 `tfschema:"name"`
+	StorageAccountUrl string                 `tfschema:"storage_account_url"`
+	Enabled           bool                   `tfschema:"enabled"`
+	Schedule          []WebAppBackupSchedule `tfschema:"schedule"`
+}
+
+type WebAppBackupSchedule struct {
+	FrequencyInterval    int    `tfschema:"frequency_interval"`
+	FrequencyUnit        string `tfschema:"frequency_unit"`
+	KeepAtLeastOneBackup bool   `tfschema:"keep_at_least_one_backup"`
+	RetentionPeriodDays  int    `tfschema:"retention_period_in_days"`
+	StartTime            string `tfschema:"start_time"`
+}
+
+type WebAppStickySettings struct {
+	AppSettingNames       []string `tfschema:"app_setting_names"`
+	ConnectionStringNames []string `tfschema:"connection_string_names"`
+}
+
+type WebAppIpRestriction struct {
+	IpAddress              string                       `tfschema:"ip_address"`
+	ServiceTag             string                       `tfschema:"service_tag"`
+	VirtualNetworkSubnetId string                       `tfschema:"virtual_network_subnet_id"`
+	Name                   string                       `tfschema:"name"`
+	Priority               int                          `tfschema:"priority"`
+	Action                 string                       `tfschema:"action"`
+	Headers                []WebAppIpRestrictionHeaders `tfschema:"headers"`
+}
+
+type WebAppIpRestrictionHeaders struct {
+	XForwardedHost []string `tfschema:"x_forwarded_host"`
+	XForwardedFor  []string `tfschema:"x_forwarded_for"`
+	XAzureFDID     []string `tfschema:"x_azure_fdid"`
+	XFDHealthProbe []string `tfschema:"x_fd_health_probe"`
+}
+
+type WebAppCorsSetting struct {
+	AllowedOrigins     []string `tfschema:"allowed_origins"`
+	SupportCredentials bool     `tfschema:"support_credentials"`
+}
+
+func schemaWebAppConnectionStrings() *pluginsdk.Schema {
+	return &pluginsdk.Schema{
+		Type:     pluginsdk.TypeSet,
+		Optional: true,
+		Elem: &pluginsdk.Resource{
+			Schema: map[string]*pluginsdk.Schema{
+				"name": {
+					Type:         pluginsdk.TypeString,
+					Required:     true,
+					ValidateFunc: validation.StringIsNotEmpty,
+				},
+
+				"type": {
+					Type:     pluginsdk.TypeString,
+					Required: true,
+					ValidateFunc: validation.StringInSlice([]string{
+						string(web.APIHub),
+						string(web.Custom),
+						string(web.DocDb),
+						string(web.EventHub),
+						string(web.MySQL),
+						string(web.NotificationHub),
+						string(web.PostgreSQL),
+						string(web.RedisCache),
+						string(web.ServiceBus),
+						string(web.SQLAzure),
+						string(web.SQLServer),
+					}, false),
+				},
+
+				"value": {
+					Type:      pluginsdk.TypeString,
+					Required:  true,
+					Sensitive: true,
+				},
+			},
+		},
+	}
+}
+
+func schemaWebAppStickySettings() *pluginsdk.Schema {
+	return &pluginsdk.Schema{
+		Type:     pluginsdk.TypeList,
+		Optional: true,
+		MaxItems: 1,
+		Elem: &pluginsdk.Resource{
+			Schema: map[string]*pluginsdk.Schema{
+				"app_setting_names": {
+					Type:     pluginsdk.TypeList,
+					Optional: true,
+					Elem: &pluginsdk.Schema{
+						Type:         pluginsdk.TypeString,
+						ValidateFunc: validation.StringIsNotEmpty,
+					},
+					AtLeastOneOf: []string{
+						"sticky_settings.0.app_setting_names",
+						"sticky_settings.0.connection_string_names",
+					},
+				},
+
+				"connection_string_names": {
+					Type:     pluginsdk.TypeList,
+					Optional: true,
+					Elem: &pluginsdk.Schema{
+						Type:         pluginsdk.TypeString,
+						ValidateFunc: validation.StringIsNotEmpty,
+					},
+					AtLeastOneOf: []string{
+						"sticky_settings.0.app_setting_names",
+						"sticky_settings.0.connection_string_names",
+					},
+				},
+			},
+		},
+	}
+}
+
+func schemaWebAppSiteConfigMinimumTlsVersion() *pluginsdk.Schema {
+	return &pluginsdk.Schema{
+		Type:     pluginsdk.TypeString,
+		Optional: true,
+		Default:  string(web.OneFullStopTwo),
+		ValidateFunc: validation.StringInSlice([]string{
+			string(web.OneFullStopZero),
+			string(web.OneFullStopOne),
+			string(web.OneFullStopTwo),
+		}, false),
+	}
+}
+
+func schemaWebAppSiteConfigFtpsState() *pluginsdk.Schema {
+	return &pluginsdk.Schema{
+		Type:     pluginsdk.TypeString,
+		Optional: true,
+		Default:  string(web.Disabled),
+		ValidateFunc: validation.StringInSlice([]string{
+			string(web.AllAllowed),
+			string(web.Disabled),
+			string(web.FtpsOnly),
+		}, false),
+	}
+}
+
+func schemaWebAppSiteConfigLoadBalancingMode() *pluginsdk.Schema {
+	return &pluginsdk.Schema{
+		Type:     pluginsdk.TypeString,
+		Optional: true,
+		Default:  string(web.LeastRequests),
+		ValidateFunc: validation.StringInSlice([]string{
+			string(web.WeightedRoundRobin),
+			string(web.LeastRequests),
+			string(web.LeastResponseTime),
+			string(web.WeightedTotalTraffic),
+			string(web.RequestHash),
+		}, false),
+	}
+}
+
+func schemaWebAppSiteConfigManagedPipelineMode() *pluginsdk.Schema {
+	return &pluginsdk.Schema{
+		Type:     pluginsdk.TypeString,
+		Optional: true,
+		Default:  string(web.Integrated),
+		ValidateFunc: validation.StringInSlice([]string{
+			string(web.Classic),
+			string(web.Integrated),
+		}, false),
+	}
+}
+
+func schemaWebAppClientCertificateMode() *pluginsdk.Schema {
+	return &pluginsdk.Schema{
+		Type:     pluginsdk.TypeString,
+		Optional: true,
+		Default:  string(web.Required),
+		ValidateFunc: validation.StringInSlice([]string{
+			string(web.Required),
+			string(web.Optional),
+		}, false),
+	}
+}
+
+func expandWebAppIdentity(input []WebAppIdentity) *web.ManagedServiceIdentity {
+	if len(input) == 0 {
+		return nil
+	}
+
+	identity := input[0]
+	result := &web.ManagedServiceIdentity{
+		Type: web.ManagedServiceIdentityType(identity.Type),
+	}
+
+	if result.Type == web.ManagedServiceIdentityTypeUserAssigned || result.Type == web.ManagedServiceIdentityTypeSystemAssignedUserAssigned {
+		result.UserAssignedIdentities = make(map[string]*web.ManagedServiceIdentityUserAssignedIdentitiesValue)
+		for _, id := range identity.IdentityIds {
+			result.UserAssignedIdentities[id] = &web.ManagedServiceIdentityUserAssignedIdentitiesValue{}
+		}
+	}
+
+	return result
+}
+
+func flattenWebAppIdentity(input *web.ManagedServiceIdentity) ([]WebAppIdentity, error) {
+	if input == nil || input.Type == web.ManagedServiceIdentityTypeNone {
+		return []WebAppIdentity{}, nil
+	}
+
+	identityIds := make([]string, 0)
+	for key := range input.UserAssignedIdentities {
+		parsedId, err := msiParse.UserAssignedIdentityID(key)
+		if err != nil {
+			return nil, err
+		}
+		identityIds = append(identityIds, parsedId.ID())
+	}
+
+	return []WebAppIdentity{
+		{
+			Type:        string(input.Type),
+			IdentityIds: identityIds,
+			PrincipalId: utils.NormalizeNilableString(input.PrincipalID),
+			TenantId:    utils.NormalizeNilableString(input.TenantID),
+		},
+	}, nil
+}
+
+func expandWebAppAppSettingsDictionary(input map[string]string) map[string]*string {
+	output := make(map[string]*string)
+	for k, v := range input {
+		output[k] = utils.String(v)
+	}
+
+	return output
+}
+
+func flattenWebAppAppSettings(input map[string]*string) map[string]string {
+	output := make(map[string]string)
+	for k, v := range input {
+		if v == nil {
+			continue
+		}
+		output[k] = *v
+	}
+
+	return output
+}
+
+func expandWebAppConnectionStrings(input []WebAppConnectionString) map[string]*web.ConnStringValueTypePair {
+	output := make(map[string]*web.ConnStringValueTypePair)
+	for _, v := range input {
+		output[v.Name] = &web.ConnStringValueTypePair{
+			Value: utils.String(v.Value),
+			Type:  web.ConnectionStringType(v.Type),
+		}
+	}
+
+	return output
+}
+
+func flattenWebAppConnectionStrings(input map[string]*web.ConnStringValueTypePair) []WebAppConnectionString {
+	output := make([]WebAppConnectionString, 0)
+	for k, v := range input {
+		if v == nil {
+			continue
+		}
+
+		output = append(output, WebAppConnectionString{
+			Name:  k,
+			Type:  string(v.Type),
+			Value: utils.NormalizeNilableString(v.Value),
+		})
+	}
+
+	return output
+}
+
+func expandWebAppBackup(input []WebAppBackup) (*web.BackupRequest, error) {
+	if len(input) == 0 {
+		return nil, nil
+	}
+
+	backup := input[0]
+	request := &web.BackupRequest{
+		BackupRequestProperties: &web.BackupRequestProperties{
+			BackupName:        utils.String(backup.Name),
+			StorageAccountURL: utils.String(backup.StorageAccountUrl),
+			Enabled:           utils.Bool(backup.Enabled),
+		},
+	}
+
+	if len(backup.Schedule) > 0 {
+		schedule := backup.Schedule[0]
+		backupSchedule := &web.BackupSchedule{
+			FrequencyInterval:     utils.Int32(int32(schedule.FrequencyInterval)),
+			FrequencyUnit:         web.FrequencyUnit(schedule.FrequencyUnit),
+			KeepAtLeastOneBackup:  utils.Bool(schedule.KeepAtLeastOneBackup),
+			RetentionPeriodInDays: utils.Int32(int32(schedule.RetentionPeriodDays)),
+		}
+
+		if schedule.StartTime != "" {
+			startTime, err := time.Parse(time.RFC3339, schedule.StartTime)
+			if err != nil {
+				return nil, fmt.Errorf("parsing `start_time`: %+v", err)
+			}
+			backupSchedule.StartTime = &date.Time{Time: startTime}
+		}
+
+		request.BackupRequestProperties.BackupSchedule = backupSchedule
+	}
+
+	return request, nil
+}
+
+func flattenWebAppBackup(input *web.BackupRequestProperties) []WebAppBackup {
+	if input == nil {
+		return []WebAppBackup{}
+	}
+
+	schedules := make([]WebAppBackupSchedule, 0)
+	if v := input.BackupSchedule; v != nil {
+		schedule := WebAppBackupSchedule{
+			FrequencyUnit: string(v.FrequencyUnit),
+		}
+		if v.FrequencyInterval != nil {
+			schedule.FrequencyInterval = int(*v.FrequencyInterval)
+		}
+		if v.KeepAtLeastOneBackup != nil {
+			schedule.KeepAtLeastOneBackup = *v.KeepAtLeastOneBackup
+		}
+		if v.RetentionPeriodInDays != nil {
+			schedule.RetentionPeriodDays = int(*v.RetentionPeriodInDays)
+		}
+		if v.StartTime != nil && !v.StartTime.IsZero() {
+			schedule.StartTime = v.StartTime.Format(time.RFC3339)
+		}
+		schedules = append(schedules, schedule)
+	}
+
+	enabled := true
+	if input.Enabled != nil {
+		enabled = *input.Enabled
+	}
+
+	return []WebAppBackup{
+		{
+			Name:              utils.NormalizeNilableString(input.BackupName),
+			StorageAccountUrl: utils.NormalizeNilableString(input.StorageAccountURL),
+			Enabled:           enabled,
+			Schedule:          schedules,
+		},
+	}
+}
+
+func expandWebAppStickySettings(input []WebAppStickySettings) web.SlotConfigNamesResource {
+	appSettingNames := make([]string, 0)
+	connectionStringNames := make([]string, 0)
+	if len(input) > 0 {
+		appSettingNames = append(appSettingNames, input[0].AppSettingNames...)
+		connectionStringNames = append(connectionStringNames, input[0].ConnectionStringNames...)
+	}
+
+	return web.SlotConfigNamesResource{
+		SlotConfigNames: &web.SlotConfigNames{
+			AppSettingNames:       &appSettingNames,
+			ConnectionStringNames: &connectionStringNames,
+		},
+	}
+}
+
+func flattenWebAppStickySettings(input *web.SlotConfigNames) []WebAppStickySettings {
+	if input == nil {
+		return []WebAppStickySettings{}
+	}
+
+	result := WebAppStickySettings{}
+	if input.AppSettingNames != nil {
+		result.AppSettingNames = *input.AppSettingNames
+	}
+	if input.ConnectionStringNames != nil {
+		result.ConnectionStringNames = *input.ConnectionStringNames
+	}
+
+	if len(result.AppSettingNames) == 0 && len(result.ConnectionStringNames) == 0 {
+		return []WebAppStickySettings{}
+	}
+
+	return []WebAppStickySettings{result}
+}
+
+func expandWebAppIpRestrictions(input []WebAppIpRestriction) (*[]web.IPSecurityRestriction, error) {
+	restrictions := make([]web.IPSecurityRestriction, 0)
+
+	for _, v := range input {
+		set := 0
+		for _, value := range []string{v.IpAddress, v.ServiceTag, v.VirtualNetworkSubnetId} {
+			if value != "" {
+				set++
+			}
+		}
+		if set != 1 {
+			return nil, fmt.Errorf("exactly one of `ip_address`, `service_tag` or `virtual_network_subnet_id` must be set for an IP restriction")
+		}
+
+		restriction := web.IPSecurityRestriction{
+			Action:  utils.String(v.Action),
+			Headers: expandWebAppIpRestrictionHeaders(v.Headers),
+		}
+
+		if v.IpAddress != "" {
+			restriction.IPAddress = utils.String(v.IpAddress)
+		}
+
+		if v.ServiceTag != "" {
+			restriction.IPAddress = utils.String(v.ServiceTag)
+			restriction.Tag = web.ServiceTag
+		}
+
+		if v.VirtualNetworkSubnetId != "" {
+			restriction.VnetSubnetResourceID = utils.String(v.VirtualNetworkSubnetId)
+		}
+
+		if v.Name != "" {
+			restriction.Name = utils.String(v.Name)
+		}
+
+		if v.Priority != 0 {
+			restriction.Priority = utils.Int32(int32(v.Priority))
+		}
+
+		restrictions = append(restrictions, restriction)
+	}
+
+	return &restrictions, nil
+}
+
+func flattenWebAppIpRestrictions(input *[]web.IPSecurityRestriction) []WebAppIpRestriction {
+	restrictions := make([]WebAppIpRestriction, 0)
+	if input == nil {
+		return restrictions
+	}
+
+	for _, v := range *input {
+		ipAddress := utils.NormalizeNilableString(v.IPAddress)
+		// the implicit rule allowing (or denying) all other traffic is returned by the API but isn't configurable
+		if ipAddress == "Any" {
+			continue
+		}
+
+		restriction := WebAppIpRestriction{
+			VirtualNetworkSubnetId: utils.NormalizeNilableString(v.VnetSubnetResourceID),
+			Name:                   utils.NormalizeNilableString(v.Name),
+			Action:                 utils.NormalizeNilableString(v.Action),
+			Headers:                flattenWebAppIpRestrictionHeaders(v.Headers),
+		}
+
+		if v.Tag == web.ServiceTag {
+			restriction.ServiceTag = ipAddress
+		} else {
+			restriction.IpAddress = ipAddress
+		}
+
+		if v.Priority != nil {
+			restriction.Priority = int(*v.Priority)
+		}
+
+		restrictions = append(restrictions, restriction)
+	}
+
+	return restrictions
+}
+
+func expandWebAppIpRestrictionHeaders(input []WebAppIpRestrictionHeaders) map[string][]string {
+	output := make(map[string][]string)
+	if len(input) == 0 {
+		return output
+	}
+
+	headers := input[0]
+	if len(headers.XForwardedHost) > 0 {
+		output["x-forwarded-host"] = headers.XForwardedHost
+	}
+	if len(headers.XForwardedFor) > 0 {
+		output["x-forwarded-for"] = headers.XForwardedFor
+	}
+	if len(headers.XAzureFDID) > 0 {
+		output["x-azure-fdid"] = headers.XAzureFDID
+	}
+	if len(headers.XFDHealthProbe) > 0 {
+		output["x-fd-healthprobe"] = headers.XFDHealthProbe
+	}
+
+	return output
+}
+
+func flattenWebAppIpRestrictionHeaders(input map[string][]string) []WebAppIpRestrictionHeaders {
+	if len(input) == 0 {
+		return []WebAppIpRestrictionHeaders{}
+	}
+
+	return []WebAppIpRestrictionHeaders{
+		{
+			XForwardedHost: input["x-forwarded-host"],
+			XForwardedFor:  input["x-forwarded-for"],
+			XAzureFDID:     input["x-azure-fdid"],
+			XFDHealthProbe: input["x-fd-healthprobe"],
+		},
+	}
+}
+
+func expandWebAppCorsSettings(input []WebAppCorsSetting) *web.CorsSettings {
+	if len(input) == 0 {
+		return nil
+	}
+
+	allowedOrigins := make([]string, 0)
+	allowedOrigins = append(allowedOrigins, input[0].AllowedOrigins...)
+
+	return &web.CorsSettings{
+		AllowedOrigins:     &allowedOrigins,
+		SupportCredentials: utils.Bool(input[0].SupportCredentials),
+	}
+}
+
+func flattenWebAppCorsSettings(input *web.CorsSettings) []WebAppCorsSetting {
+	if input == nil {
+		return []WebAppCorsSetting{}
+	}
+
+	result := WebAppCorsSetting{}
+	if input.AllowedOrigins != nil {
+		result.AllowedOrigins = *input.AllowedOrigins
+	}
+	if input.SupportCredentials != nil {
+		result.SupportCredentials = *input.SupportCredentials
+	}
+
+	if len(result.AllowedOrigins) == 0 && !result.SupportCredentials {
+		return []WebAppCorsSetting{}
+	}
+
+	return []WebAppCorsSetting{result}
+}
+
+func flattenWebAppIPAddressList(input string) []string {
+	output := make([]string, 0)
+	for _, v := range strings.Split(input, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			output = append(output, v)
+		}
+	}
+
+	return output
+}
+
+// validateWebAppServicePlanOS checks that the operating system of the App Service Plan matches the operating
+// system of the Web App, since a Linux Web App can only be hosted on a Linux App Service Plan (and vice versa)
+// - and otherwise settings specific to the operating system are silently ignored by the API.
+func validateWebAppServicePlanOS(ctx context.Context, client *web.AppServicePlansClient, servicePlanId string, linux bool) error {
+	id, err := parse.AppServicePlanID(servicePlanId)
+	if err != nil {
+		return err
+	}
+
+	plan, err := client.Get(ctx, id.ResourceGroup, id.ServerfarmName)
+	if err != nil {
+		return fmt.Errorf("retrieving %s: %+v", id, err)
+	}
+
+	// the API returns a 200 with an empty body when the App Service Plan doesn't exist
+	if plan.AppServicePlanProperties == nil {
+		return fmt.Errorf("%s was not found", id)
+	}
+
+	isLinux := plan.Reserved != nil && *plan.Reserved
+	if kind := plan.Kind; kind != nil && strings.Contains(strings.ToLower(*kind), "linux") {
+		isLinux = true
+	}
+
+	if linux && !isLinux {
+		return fmt.Errorf("a Linux Web App must be hosted on a Linux App Service Plan but %s is a Windows App Service Plan", id)
+	}
+	if !linux && isLinux {
+		return fmt.Errorf("a Windows Web App must be hosted on a Windows App Service Plan but %s is a Linux App Service Plan", id)
+	}
+
+	return nil
+}
+
+// getWebAppSlotParent retrieves the Web App a Slot belongs to, checking that it's hosted on an App Service Plan
+// with the expected operating system - since the Slot is hosted on the same App Service Plan as the Web App.
+func getWebAppSlotParent(ctx context.Context, client *web.AppsClient, servicePlanClient *web.AppServicePlansClient, appId parse.AppServiceId, linux bool) (*web.Site, error) {
+	webApp, err := client.Get(ctx, appId.ResourceGroup, appId.SiteName)
+	if err != nil {
+		return nil, fmt.Errorf("retrieving %s: %+v", appId, err)
+	}
+
+	if webApp.SiteProperties == nil || webApp.ServerFarmID == nil {
+		return nil, fmt.Errorf("retrieving %s: `properties.serverFarmId` was nil", appId)
+	}
+
+	if err := validateWebAppServicePlanOS(ctx, servicePlanClient, *webApp.ServerFarmID, linux); err != nil {
+		return nil, err
+	}
+
+	return &webApp, nil
+}
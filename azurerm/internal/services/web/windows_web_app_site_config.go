@@ -0,0 +1,589 @@
+package web
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/services/web/mgmt/2020-06-01/web"
+	apimValidate "github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/apimanagement/validate"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tf/pluginsdk"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tf/validation"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+// windowsNodeVersionAppSetting is the App Setting used to configure the version of Node on a Windows Web App,
+// since (unlike the other runtimes) there's no field for this within the Site Config
+const windowsNodeVersionAppSetting = "WEBSITE_NODE_DEFAULT_VERSION"
+
+type SiteConfigWindows struct {
+	AlwaysOn                bool                      `tfschema:"always_on"`
+	ApiManagementApiId      string                    `tfschema:"api_management_api_id"`
+	ApiDefinition           string                    `tfschema:"api_definition_url"`
+	ApplicationStack        []ApplicationStackWindows `tfschema:"application_stack"`
+	Cors                    []WebAppCorsSetting       `tfschema:"cors"`
+	DefaultDocuments        []string                  `tfschema:"default_documents"`
+	DetailedErrorLogging    bool                      `tfschema:"detailed_error_logging_enabled"`
+	FtpsState               string                    `tfschema:"ftps_state"`
+	HealthCheckPath         string                    `tfschema:"health_check_path"`
+	Http2Enabled            bool                      `tfschema:"http2_enabled"`
+	IpRestriction           []WebAppIpRestriction     `tfschema:"ip_restriction"`
+	LoadBalancing           string                    `tfschema:"load_balancing_mode"`
+	LocalMysql              bool                      `tfschema:"local_mysql_enabled"`
+	ManagedPipelineMode     string                    `tfschema:"managed_pipeline_mode"`
+	MinTlsVersion           string                    `tfschema:"minimum_tls_version"`
+	RemoteDebugging         bool                      `tfschema:"remote_debugging_enabled"`
+	RemoteDebuggingVersion  string                    `tfschema:"remote_debugging_version"`
+	ScmIpRestriction        []WebAppIpRestriction     `tfschema:"scm_ip_restriction"`
+	ScmMinTlsVersion        string                    `tfschema:"scm_minimum_tls_version"`
+	ScmType                 string                    `tfschema:"scm_type"`
+	ScmUseMainIpRestriction bool                      `tfschema:"scm_use_main_ip_restriction"`
+	Use32BitWorker          bool                      `tfschema:"use_32_bit_worker"`
+	VirtualApplications     []VirtualApplication      `tfschema:"virtual_application"`
+	VnetRouteAllEnabled     bool                      `tfschema:"vnet_route_all_enabled"`
+	WebSockets              bool                      `tfschema:"websockets_enabled"`
+	WindowsFxVersion        string                    `tfschema:"windows_fx_version"`
+	WorkerCount             int                       `tfschema:"worker_count"`
+}
+
+type ApplicationStackWindows struct {
+	NetFrameworkVersion  string `tfschema:"dotnet_version"`
+	PhpVersion           string `tfschema:"php_version"`
+	PythonVersion        string `tfschema:"python_version"`
+	NodeVersion          string `tfschema:"node_version"`
+	JavaVersion          string `tfschema:"java_version"`
+	JavaContainer        string `tfschema:"java_container"`
+	JavaContainerVersion string `tfschema:"java_container_version"`
+	DockerContainerName  string `tfschema:"docker_container_name"`
+	DockerContainerTag   string `tfschema:"docker_container_tag"`
+}
+
+type VirtualApplication struct {
+	VirtualPath        string             `tfschema:"virtual_path"`
+	PhysicalPath       string             `tfschema:"physical_path"`
+	Preload            bool               `tfschema:"preload"`
+	VirtualDirectories []VirtualDirectory `tfschema:"virtual_directory"`
+}
+
+type VirtualDirectory struct {
+	VirtualPath  string `tfschema:"virtual_path"`
+	PhysicalPath string `tfschema:"physical_path"`
+}
+
+func schemaSiteConfigWindows() *pluginsdk.Schema {
+	return &pluginsdk.Schema{
+		Type:     pluginsdk.TypeList,
+		Required: true,
+		MaxItems: 1,
+		Elem: &pluginsdk.Resource{
+			Schema: map[string]*pluginsdk.Schema{
+				"always_on": {
+					Type:     pluginsdk.TypeBool,
+					Optional: true,
+					Default:  true,
+				},
+
+				"api_management_api_id": {
+					Type:         pluginsdk.TypeString,
+					Optional:     true,
+					ValidateFunc: apimValidate.ApiID,
+				},
+
+				"api_definition_url": {
+					Type:         pluginsdk.TypeString,
+					Optional:     true,
+					ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+				},
+
+				"application_stack": schemaApplicationStackWindows(),
+
+				"cors": SchemaWebCorsSettings(),
+
+				"default_documents": {
+					Type:     pluginsdk.TypeList,
+					Optional: true,
+					Computed: true,
+					Elem: &pluginsdk.Schema{
+						Type: pluginsdk.TypeString,
+					},
+				},
+
+				"detailed_error_logging_enabled": {
+					Type:     pluginsdk.TypeBool,
+					Computed: true,
+				},
+
+				"ftps_state": schemaWebAppSiteConfigFtpsState(),
+
+				"health_check_path": {
+					Type:         pluginsdk.TypeString,
+					Optional:     true,
+					ValidateFunc: validation.StringIsNotEmpty,
+				},
+
+				"http2_enabled": {
+					Type:     pluginsdk.TypeBool,
+					Optional: true,
+					Default:  false,
+				},
+
+				"ip_restriction": schemaAppServiceIpRestriction(),
+
+				"load_balancing_mode": schemaWebAppSiteConfigLoadBalancingMode(),
+
+				"local_mysql_enabled": {
+					Type:     pluginsdk.TypeBool,
+					Optional: true,
+					Default:  false,
+				},
+
+				"managed_pipeline_mode": schemaWebAppSiteConfigManagedPipelineMode(),
+
+				"minimum_tls_version": schemaWebAppSiteConfigMinimumTlsVersion(),
+
+				"remote_debugging_enabled": {
+					Type:     pluginsdk.TypeBool,
+					Optional: true,
+					Default:  false,
+				},
+
+				"remote_debugging_version": {
+					Type:     pluginsdk.TypeString,
+					Optional: true,
+					Computed: true,
+					ValidateFunc: validation.StringInSlice([]string{
+						"VS2017",
+						"VS2019",
+					}, false),
+				},
+
+				"scm_ip_restriction": schemaAppServiceIpRestriction(),
+
+				"scm_minimum_tls_version": schemaWebAppSiteConfigMinimumTlsVersion(),
+
+				"scm_type": {
+					Type:     pluginsdk.TypeString,
+					Computed: true,
+				},
+
+				"scm_use_main_ip_restriction": {
+					Type:     pluginsdk.TypeBool,
+					Optional: true,
+					Default:  false,
+				},
+
+				"use_32_bit_worker": {
+					Type:     pluginsdk.TypeBool,
+					Optional: true,
+					Default:  true,
+				},
+
+				"virtual_application": schemaVirtualApplications(),
+
+				"vnet_route_all_enabled": {
+					Type:     pluginsdk.TypeBool,
+					Optional: true,
+					Default:  false,
+				},
+
+				"websockets_enabled": {
+					Type:     pluginsdk.TypeBool,
+					Optional: true,
+					Default:  false,
+				},
+
+				"windows_fx_version": {
+					Type:     pluginsdk.TypeString,
+					Computed: true,
+				},
+
+				"worker_count": {
+					Type:         pluginsdk.TypeInt,
+					Optional:     true,
+					Computed:     true,
+					ValidateFunc: validation.IntBetween(1, 100),
+				},
+			},
+		},
+	}
+}
+
+func schemaApplicationStackWindows() *pluginsdk.Schema {
+	return &pluginsdk.Schema{
+		Type:     pluginsdk.TypeList,
+		Optional: true,
+		Computed: true,
+		MaxItems: 1,
+		Elem: &pluginsdk.Resource{
+			Schema: map[string]*pluginsdk.Schema{
+				"dotnet_version": {
+					Type:     pluginsdk.TypeString,
+					Optional: true,
+					Computed: true,
+					ValidateFunc: validation.StringInSlice([]string{
+						"v2.0",
+						"v3.0",
+						"v4.0",
+						"v5.0",
+					}, false),
+				},
+
+				"php_version": {
+					Type:     pluginsdk.TypeString,
+					Optional: true,
+					Computed: true,
+					ValidateFunc: validation.StringInSlice([]string{
+						"Off",
+						"5.6",
+						"7.2",
+						"7.3",
+						"7.4",
+					}, false),
+				},
+
+				"python_version": {
+					Type:     pluginsdk.TypeString,
+					Optional: true,
+					Computed: true,
+					ValidateFunc: validation.StringInSlice([]string{
+						"2.7",
+						"3.4.0",
+					}, false),
+				},
+
+				"node_version": {
+					Type:     pluginsdk.TypeString,
+					Optional: true,
+					ValidateFunc: validation.StringInSlice([]string{
+						"~10",
+						"~12",
+						"~14",
+					}, false),
+				},
+
+				"java_version": {
+					Type:         pluginsdk.TypeString,
+					Optional:     true,
+					ValidateFunc: validation.StringIsNotEmpty,
+					RequiredWith: []string{
+						"site_config.0.application_stack.0.java_container",
+						"site_config.0.application_stack.0.java_container_version",
+					},
+				},
+
+				"java_container": {
+					Type:     pluginsdk.TypeString,
+					Optional: true,
+					ValidateFunc: validation.StringInSlice([]string{
+						"JAVA",
+						"JETTY",
+						"TOMCAT",
+					}, false),
+					RequiredWith: []string{
+						"site_config.0.application_stack.0.java_version",
+					},
+				},
+
+				"java_container_version": {
+					Type:         pluginsdk.TypeString,
+					Optional:     true,
+					ValidateFunc: validation.StringIsNotEmpty,
+					RequiredWith: []string{
+						"site_config.0.application_stack.0.java_version",
+					},
+				},
+
+				"docker_container_name": {
+					Type:         pluginsdk.TypeString,
+					Optional:     true,
+					ValidateFunc: validation.StringIsNotEmpty,
+					RequiredWith: []string{
+						"site_config.0.application_stack.0.docker_container_tag",
+					},
+				},
+
+				"docker_container_tag": {
+					Type:         pluginsdk.TypeString,
+					Optional:     true,
+					ValidateFunc: validation.StringIsNotEmpty,
+					RequiredWith: []string{
+						"site_config.0.application_stack.0.docker_container_name",
+					},
+				},
+			},
+		},
+	}
+}
+
+func schemaVirtualApplications() *pluginsdk.Schema {
+	return &pluginsdk.Schema{
+		Type:     pluginsdk.TypeSet,
+		Optional: true,
+		Computed: true,
+		Elem: &pluginsdk.Resource{
+			Schema: map[string]*pluginsdk.Schema{
+				"virtual_path": {
+					Type:         pluginsdk.TypeString,
+					Required:     true,
+					ValidateFunc: validation.StringIsNotEmpty,
+				},
+
+				"physical_path": {
+					Type:         pluginsdk.TypeString,
+					Required:     true,
+					ValidateFunc: validation.StringIsNotEmpty,
+				},
+
+				"preload": {
+					Type:     pluginsdk.TypeBool,
+					Required: true,
+				},
+
+				"virtual_directory": {
+					Type:     pluginsdk.TypeSet,
+					Optional: true,
+					Elem: &pluginsdk.Resource{
+						Schema: map[string]*pluginsdk.Schema{
+							"virtual_path": {
+								Type:         pluginsdk.TypeString,
+								Required:     true,
+								ValidateFunc: validation.StringIsNotEmpty,
+							},
+
+							"physical_path": {
+								Type:         pluginsdk.TypeString,
+								Required:     true,
+								ValidateFunc: validation.StringIsNotEmpty,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func expandSiteConfigWindows(input []SiteConfigWindows) (*web.SiteConfig, error) {
+	if len(input) == 0 {
+		return nil, nil
+	}
+
+	winSiteConfig := input[0]
+
+	defaultDocuments := make([]string, 0)
+	defaultDocuments = append(defaultDocuments, winSiteConfig.DefaultDocuments...)
+
+	expanded := &web.SiteConfig{
+		AlwaysOn:                         utils.Bool(winSiteConfig.AlwaysOn),
+		Cors:                             expandWebAppCorsSettings(winSiteConfig.Cors),
+		DefaultDocuments:                 &defaultDocuments,
+		FtpsState:                        web.FtpsState(winSiteConfig.FtpsState),
+		HealthCheckPath:                  utils.String(winSiteConfig.HealthCheckPath),
+		HTTP20Enabled:                    utils.Bool(winSiteConfig.Http2Enabled),
+		LoadBalancing:                    web.SiteLoadBalancing(winSiteConfig.LoadBalancing),
+		LocalMySQLEnabled:                utils.Bool(winSiteConfig.LocalMysql),
+		ManagedPipelineMode:              web.ManagedPipelineMode(winSiteConfig.ManagedPipelineMode),
+		MinTLSVersion:                    web.SupportedTLSVersions(winSiteConfig.MinTlsVersion),
+		RemoteDebuggingEnabled:           utils.Bool(winSiteConfig.RemoteDebugging),
+		ScmIPSecurityRestrictionsUseMain: utils.Bool(winSiteConfig.ScmUseMainIpRestriction),
+		ScmMinTLSVersion:                 web.SupportedTLSVersions(winSiteConfig.ScmMinTlsVersion),
+		Use32BitWorkerProcess:            utils.Bool(winSiteConfig.Use32BitWorker),
+		VirtualApplications:              expandVirtualApplications(winSiteConfig.VirtualApplications),
+		VnetRouteAllEnabled:              utils.Bool(winSiteConfig.VnetRouteAllEnabled),
+		WebSocketsEnabled:                utils.Bool(winSiteConfig.WebSockets),
+	}
+
+	if winSiteConfig.ApiManagementApiId != "" {
+		expanded.APIManagementConfig = &web.APIManagementConfig{
+			ID: utils.String(winSiteConfig.ApiManagementApiId),
+		}
+	}
+
+	if winSiteConfig.ApiDefinition != "" {
+		expanded.APIDefinition = &web.APIDefinitionInfo{
+			URL: utils.String(winSiteConfig.ApiDefinition),
+		}
+	}
+
+	if winSiteConfig.RemoteDebuggingVersion != "" {
+		expanded.RemoteDebuggingVersion = utils.String(winSiteConfig.RemoteDebuggingVersion)
+	}
+
+	if winSiteConfig.WorkerCount != 0 {
+		expanded.NumberOfWorkers = utils.Int32(int32(winSiteConfig.WorkerCount))
+	}
+
+	if len(winSiteConfig.ApplicationStack) == 1 {
+		stack := winSiteConfig.ApplicationStack[0]
+		if stack.NetFrameworkVersion != "" {
+			expanded.NetFrameworkVersion = utils.String(stack.NetFrameworkVersion)
+		}
+		if stack.PhpVersion != "" {
+			expanded.PhpVersion = utils.String(stack.PhpVersion)
+		}
+		if stack.PythonVersion != "" {
+			expanded.PythonVersion = utils.String(stack.PythonVersion)
+		}
+		if stack.JavaVersion != "" {
+			expanded.JavaVersion = utils.String(stack.JavaVersion)
+			expanded.JavaContainer = utils.String(stack.JavaContainer)
+			expanded.JavaContainerVersion = utils.String(stack.JavaContainerVersion)
+		}
+		if stack.DockerContainerName != "" {
+			expanded.WindowsFxVersion = utils.String(fmt.Sprintf("DOCKER|%s:%s", stack.DockerContainerName, stack.DockerContainerTag))
+		}
+	}
+
+	ipRestrictions, err := expandWebAppIpRestrictions(winSiteConfig.IpRestriction)
+	if err != nil {
+		return nil, fmt.Errorf("expanding `ip_restriction`: %+v", err)
+	}
+	expanded.IPSecurityRestrictions = ipRestrictions
+
+	scmIpRestrictions, err := expandWebAppIpRestrictions(winSiteConfig.ScmIpRestriction)
+	if err != nil {
+		return nil, fmt.Errorf("expanding `scm_ip_restriction`: %+v", err)
+	}
+	expanded.ScmIPSecurityRestrictions = scmIpRestrictions
+
+	return expanded, nil
+}
+
+// flattenSiteConfigWindows flattens the Site Config for a Windows Web App - the version of Node is held in the
+// App Settings rather than the Site Config and so is passed in separately.
+func flattenSiteConfigWindows(input *web.SiteConfig, nodeVersion string) []SiteConfigWindows {
+	if input == nil {
+		return []SiteConfigWindows{}
+	}
+
+	siteConfig := SiteConfigWindows{
+		AlwaysOn:                utils.NormaliseNilableBool(input.AlwaysOn),
+		Cors:                    flattenWebAppCorsSettings(input.Cors),
+		DetailedErrorLogging:    utils.NormaliseNilableBool(input.DetailedErrorLoggingEnabled),
+		FtpsState:               string(input.FtpsState),
+		HealthCheckPath:         utils.NormalizeNilableString(input.HealthCheckPath),
+		Http2Enabled:            utils.NormaliseNilableBool(input.HTTP20Enabled),
+		IpRestriction:           flattenWebAppIpRestrictions(input.IPSecurityRestrictions),
+		LoadBalancing:           string(input.LoadBalancing),
+		LocalMysql:              utils.NormaliseNilableBool(input.LocalMySQLEnabled),
+		ManagedPipelineMode:     string(input.ManagedPipelineMode),
+		MinTlsVersion:           string(input.MinTLSVersion),
+		RemoteDebugging:         utils.NormaliseNilableBool(input.RemoteDebuggingEnabled),
+		RemoteDebuggingVersion:  strings.ToUpper(utils.NormalizeNilableString(input.RemoteDebuggingVersion)),
+		ScmIpRestriction:        flattenWebAppIpRestrictions(input.ScmIPSecurityRestrictions),
+		ScmMinTlsVersion:        string(input.ScmMinTLSVersion),
+		ScmType:                 string(input.ScmType),
+		ScmUseMainIpRestriction: utils.NormaliseNilableBool(input.ScmIPSecurityRestrictionsUseMain),
+		Use32BitWorker:          utils.NormaliseNilableBool(input.Use32BitWorkerProcess),
+		VirtualApplications:     flattenVirtualApplications(input.VirtualApplications),
+		VnetRouteAllEnabled:     utils.NormaliseNilableBool(input.VnetRouteAllEnabled),
+		WebSockets:              utils.NormaliseNilableBool(input.WebSocketsEnabled),
+		WindowsFxVersion:        utils.NormalizeNilableString(input.WindowsFxVersion),
+	}
+
+	if input.APIManagementConfig != nil {
+		siteConfig.ApiManagementApiId = utils.NormalizeNilableString(input.APIManagementConfig.ID)
+	}
+
+	if input.APIDefinition != nil {
+		siteConfig.ApiDefinition = utils.NormalizeNilableString(input.APIDefinition.URL)
+	}
+
+	if input.DefaultDocuments != nil {
+		siteConfig.DefaultDocuments = *input.DefaultDocuments
+	}
+
+	if input.NumberOfWorkers != nil {
+		siteConfig.WorkerCount = int(*input.NumberOfWorkers)
+	}
+
+	stack := ApplicationStackWindows{
+		NetFrameworkVersion:  utils.NormalizeNilableString(input.NetFrameworkVersion),
+		PhpVersion:           utils.NormalizeNilableString(input.PhpVersion),
+		PythonVersion:        utils.NormalizeNilableString(input.PythonVersion),
+		NodeVersion:          nodeVersion,
+		JavaVersion:          utils.NormalizeNilableString(input.JavaVersion),
+		JavaContainer:        utils.NormalizeNilableString(input.JavaContainer),
+		JavaContainerVersion: utils.NormalizeNilableString(input.JavaContainerVersion),
+	}
+
+	if parts := strings.SplitN(siteConfig.WindowsFxVersion, "|", 2); len(parts) == 2 && strings.EqualFold(parts[0], "DOCKER") {
+		image := parts[1]
+		if index := strings.LastIndex(image, ":"); index != -1 && index > strings.LastIndex(image, "/") {
+			stack.DockerContainerName = image[:index]
+			stack.DockerContainerTag = image[index+1:]
+		} else {
+			stack.DockerContainerName = image
+			stack.DockerContainerTag = "latest"
+		}
+	}
+
+	siteConfig.ApplicationStack = []ApplicationStackWindows{stack}
+
+	return []SiteConfigWindows{siteConfig}
+}
+
+// expandWindowsWebAppAppSettings combines the App Settings with the App Setting used to configure the version of Node
+func expandWindowsWebAppAppSettings(appSettings map[string]string, siteConfig []SiteConfigWindows) map[string]*string {
+	output := expandWebAppAppSettingsDictionary(appSettings)
+	if len(siteConfig) > 0 && len(siteConfig[0].ApplicationStack) > 0 {
+		if nodeVersion := siteConfig[0].ApplicationStack[0].NodeVersion; nodeVersion != "" {
+			output[windowsNodeVersionAppSetting] = utils.String(nodeVersion)
+		}
+	}
+
+	return output
+}
+
+func expandVirtualApplications(input []VirtualApplication) *[]web.VirtualApplication {
+	if len(input) == 0 {
+		return nil
+	}
+
+	result := make([]web.VirtualApplication, 0)
+	for _, v := range input {
+		directories := make([]web.VirtualDirectory, 0)
+		for _, d := range v.VirtualDirectories {
+			directories = append(directories, web.VirtualDirectory{
+				VirtualPath:  utils.String(d.VirtualPath),
+				PhysicalPath: utils.String(d.PhysicalPath),
+			})
+		}
+
+		result = append(result, web.VirtualApplication{
+			VirtualPath:        utils.String(v.VirtualPath),
+			PhysicalPath:       utils.String(v.PhysicalPath),
+			PreloadEnabled:     utils.Bool(v.Preload),
+			VirtualDirectories: &directories,
+		})
+	}
+
+	return &result
+}
+
+func flattenVirtualApplications(input *[]web.VirtualApplication) []VirtualApplication {
+	result := make([]VirtualApplication, 0)
+	if input == nil {
+		return result
+	}
+
+	for _, v := range *input {
+		directories := make([]VirtualDirectory, 0)
+		if v.VirtualDirectories != nil {
+			for _, d := range *v.VirtualDirectories {
+				directories = append(directories, VirtualDirectory{
+					VirtualPath:  utils.NormalizeNilableString(d.VirtualPath),
+					PhysicalPath: utils.NormalizeNilableString(d.PhysicalPath),
+				})
+			}
+		}
+
+		result = append(result, VirtualApplication{
+			VirtualPath:        utils.NormalizeNilableString(v.VirtualPath),
+			PhysicalPath:       utils.NormalizeNilableString(v.PhysicalPath),
+			Preload:            utils.NormaliseNilableBool(v.PreloadEnabled),
+			VirtualDirectories: directories,
+		})
+	}
+
+	return result
+}
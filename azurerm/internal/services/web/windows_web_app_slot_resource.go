@@ -0,0 +1,490 @@
+package web
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/web/mgmt/2020-06-01/web"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/sdk"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/web/parse"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/web/validate"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tags"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tf/pluginsdk"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+type WindowsWebAppSlotResource struct{}
+
+type WindowsWebAppSlotModel struct {
+	Name                          string                   `tfschema:"name"`
+	AppServiceId                  string                   `tfschema:"app_service_id"`
+	AppSettings                   map[string]string        `tfschema:"app_settings"`
+	AuthV2Settings                []AuthV2Settings         `tfschema:"auth_settings_v2"`
+	Backup                        []WebAppBackup           `tfschema:"backup"`
+	ClientAffinityEnabled         bool                     `tfschema:"client_affinity_enabled"`
+	ClientCertEnabled             bool                     `tfschema:"client_certificate_enabled"`
+	ClientCertMode                string                   `tfschema:"client_certificate_mode"`
+	ConnectionStrings             []WebAppConnectionString `tfschema:"connection_string"`
+	Enabled                       bool                     `tfschema:"enabled"`
+	HttpsOnly                     bool                     `tfschema:"https_only"`
+	Identity                      []WebAppIdentity         `tfschema:"identity"`
+	SiteConfig                    []SiteConfigWindows      `tfschema:"site_config"`
+	Tags                          map[string]interface{}   `tfschema:"tags"`
+	CustomDomainVerificationId    string                   `tfschema:"custom_domain_verification_id"`
+	DefaultHostname               string                   `tfschema:"default_hostname"`
+	Kind                          string                   `tfschema:"kind"`
+	OutboundIPAddresses           string                   `tfschema:"outbound_ip_addresses"`
+	OutboundIPAddressList         []string                 `tfschema:"outbound_ip_address_list"`
+	PossibleOutboundIPAddresses   string                   `tfschema:"possible_outbound_ip_addresses"`
+	PossibleOutboundIPAddressList []string                 `tfschema:"possible_outbound_ip_address_list"`
+}
+
+var _ sdk.Resource = WindowsWebAppSlotResource{}
+var _ sdk.ResourceWithUpdate = WindowsWebAppSlotResource{}
+
+func (r WindowsWebAppSlotResource) ModelObject() interface{} {
+	return WindowsWebAppSlotModel{}
+}
+
+func (r WindowsWebAppSlotResource) ResourceType() string {
+	return "azurerm_windows_web_app_slot"
+}
+
+func (r WindowsWebAppSlotResource) IDValidationFunc() pluginsdk.SchemaValidateFunc {
+	return validate.AppServiceSlotID
+}
+
+func (r WindowsWebAppSlotResource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"name": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validate.AppServiceName,
+		},
+
+		"app_service_id": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validate.AppServiceID,
+		},
+
+		"app_settings": {
+			Type:     pluginsdk.TypeMap,
+			Optional: true,
+			Elem: &pluginsdk.Schema{
+				Type: pluginsdk.TypeString,
+			},
+		},
+
+		"auth_settings_v2": schemaAuthV2Settings(),
+
+		"backup": schemaAppServiceBackup(),
+
+		"client_affinity_enabled": {
+			Type:     pluginsdk.TypeBool,
+			Optional: true,
+			Default:  false,
+		},
+
+		"client_certificate_enabled": {
+			Type:     pluginsdk.TypeBool,
+			Optional: true,
+			Default:  false,
+		},
+
+		"client_certificate_mode": schemaWebAppClientCertificateMode(),
+
+		"connection_string": schemaWebAppConnectionStrings(),
+
+		"enabled": {
+			Type:     pluginsdk.TypeBool,
+			Optional: true,
+			Default:  true,
+		},
+
+		"https_only": {
+			Type:     pluginsdk.TypeBool,
+			Optional: true,
+			Default:  false,
+		},
+
+		"identity": schemaAppServiceIdentity(),
+
+		"site_config": schemaSiteConfigWindows(),
+
+		"tags": tags.Schema(),
+	}
+}
+
+func (r WindowsWebAppSlotResource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"custom_domain_verification_id": {
+			Type:      pluginsdk.TypeString,
+			Computed:  true,
+			Sensitive: true,
+		},
+
+		"default_hostname": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+
+		"kind": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+
+		"outbound_ip_addresses": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+
+		"outbound_ip_address_list": {
+			Type:     pluginsdk.TypeList,
+			Computed: true,
+			Elem: &pluginsdk.Schema{
+				Type: pluginsdk.TypeString,
+			},
+		},
+
+		"possible_outbound_ip_addresses": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+
+		"possible_outbound_ip_address_list": {
+			Type:     pluginsdk.TypeList,
+			Computed: true,
+			Elem: &pluginsdk.Schema{
+				Type: pluginsdk.TypeString,
+			},
+		},
+	}
+}
+
+func (r WindowsWebAppSlotResource) Create() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Web.AppServicesClient
+			servicePlanClient := metadata.Client.Web.AppServicePlansClient
+
+			var webAppSlot WindowsWebAppSlotModel
+			if err := metadata.Decode(&webAppSlot); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			appId, err := parse.AppServiceID(webAppSlot.AppServiceId)
+			if err != nil {
+				return err
+			}
+
+			id := parse.NewAppServiceSlotID(appId.SubscriptionId, appId.ResourceGroup, appId.SiteName, webAppSlot.Name)
+			existing, err := client.GetSlot(ctx, id.ResourceGroup, id.SiteName, id.SlotName)
+			if err != nil && !utils.ResponseWasNotFound(existing.Response) {
+				return fmt.Errorf("checking for presence of existing %s: %+v", id, err)
+			}
+			if !utils.ResponseWasNotFound(existing.Response) {
+				return metadata.ResourceRequiresImport(r.ResourceType(), id)
+			}
+
+			webApp, err := getWebAppSlotParent(ctx, client, servicePlanClient, *appId, false)
+			if err != nil {
+				return err
+			}
+
+			siteConfig, err := expandSiteConfigWindows(webAppSlot.SiteConfig)
+			if err != nil {
+				return fmt.Errorf("expanding `site_config` for %s: %+v", id, err)
+			}
+
+			siteEnvelope := web.Site{
+				Location: webApp.Location,
+				Tags:     tags.Expand(webAppSlot.Tags),
+				Identity: expandWebAppIdentity(webAppSlot.Identity),
+				SiteProperties: &web.SiteProperties{
+					ServerFarmID:          webApp.ServerFarmID,
+					Enabled:               utils.Bool(webAppSlot.Enabled),
+					HTTPSOnly:             utils.Bool(webAppSlot.HttpsOnly),
+					Reserved:              utils.Bool(false),
+					SiteConfig:            siteConfig,
+					ClientAffinityEnabled: utils.Bool(webAppSlot.ClientAffinityEnabled),
+					ClientCertEnabled:     utils.Bool(webAppSlot.ClientCertEnabled),
+					ClientCertMode:        web.ClientCertMode(webAppSlot.ClientCertMode),
+				},
+			}
+
+			future, err := client.CreateOrUpdateSlot(ctx, id.ResourceGroup, id.SiteName, siteEnvelope, id.SlotName)
+			if err != nil {
+				return fmt.Errorf("creating %s: %+v", id, err)
+			}
+
+			if err := future.WaitForCompletionRef(ctx, client.Client); err != nil {
+				return fmt.Errorf("waiting for creation of %s: %+v", id, err)
+			}
+
+			metadata.SetID(id)
+
+			if appSettingsProps := expandWindowsWebAppAppSettings(webAppSlot.AppSettings, webAppSlot.SiteConfig); len(appSettingsProps) > 0 {
+				appSettings := web.StringDictionary{
+					Properties: appSettingsProps,
+				}
+				if _, err := client.UpdateApplicationSettingsSlot(ctx, id.ResourceGroup, id.SiteName, appSettings, id.SlotName); err != nil {
+					return fmt.Errorf("updating App Settings for %s: %+v", id, err)
+				}
+			}
+
+			if len(webAppSlot.ConnectionStrings) > 0 {
+				connectionStrings := web.ConnectionStringDictionary{
+					Properties: expandWebAppConnectionStrings(webAppSlot.ConnectionStrings),
+				}
+				if _, err := client.UpdateConnectionStringsSlot(ctx, id.ResourceGroup, id.SiteName, connectionStrings, id.SlotName); err != nil {
+					return fmt.Errorf("updating Connection Strings for %s: %+v", id, err)
+				}
+			}
+
+			if len(webAppSlot.AuthV2Settings) > 0 {
+				if _, err := client.UpdateAuthSettingsV2Slot(ctx, id.ResourceGroup, id.SiteName, expandAuthV2Settings(webAppSlot.AuthV2Settings), id.SlotName); err != nil {
+					return fmt.Errorf("updating Authentication v2 Settings for %s: %+v", id, err)
+				}
+			}
+
+			backupConfig, err := expandWebAppBackup(webAppSlot.Backup)
+			if err != nil {
+				return fmt.Errorf("expanding `backup` for %s: %+v", id, err)
+			}
+			if backupConfig != nil {
+				if _, err := client.UpdateBackupConfigurationSlot(ctx, id.ResourceGroup, id.SiteName, *backupConfig, id.SlotName); err != nil {
+					return fmt.Errorf("updating Backup Settings for %s: %+v", id, err)
+				}
+			}
+
+			return nil
+		},
+	}
+}
+
+func (r WindowsWebAppSlotResource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Web.AppServicesClient
+
+			id, err := parse.AppServiceSlotID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			webAppSlot, err := client.GetSlot(ctx, id.ResourceGroup, id.SiteName, id.SlotName)
+			if err != nil {
+				if utils.ResponseWasNotFound(webAppSlot.Response) {
+					return metadata.MarkAsGone(id)
+				}
+				return fmt.Errorf("retrieving %s: %+v", id, err)
+			}
+
+			siteConfig, err := client.GetConfigurationSlot(ctx, id.ResourceGroup, id.SiteName, id.SlotName)
+			if err != nil {
+				return fmt.Errorf("retrieving Site Config for %s: %+v", id, err)
+			}
+
+			appSettings, err := client.ListApplicationSettingsSlot(ctx, id.ResourceGroup, id.SiteName, id.SlotName)
+			if err != nil {
+				return fmt.Errorf("retrieving App Settings for %s: %+v", id, err)
+			}
+
+			connectionStrings, err := client.ListConnectionStringsSlot(ctx, id.ResourceGroup, id.SiteName, id.SlotName)
+			if err != nil {
+				return fmt.Errorf("retrieving Connection Strings for %s: %+v", id, err)
+			}
+
+			authV2Settings, err := client.GetAuthSettingsV2Slot(ctx, id.ResourceGroup, id.SiteName, id.SlotName)
+			if err != nil {
+				return fmt.Errorf("retrieving Authentication v2 Settings for %s: %+v", id, err)
+			}
+
+			backup, err := client.GetBackupConfigurationSlot(ctx, id.ResourceGroup, id.SiteName, id.SlotName)
+			if err != nil && !utils.ResponseWasNotFound(backup.Response) {
+				return fmt.Errorf("retrieving Backup Settings for %s: %+v", id, err)
+			}
+
+			nodeVersion := ""
+			if v, ok := appSettings.Properties[windowsNodeVersionAppSetting]; ok && v != nil {
+				nodeVersion = *v
+				delete(appSettings.Properties, windowsNodeVersionAppSetting)
+			}
+
+			state := WindowsWebAppSlotModel{
+				Name:              id.SlotName,
+				AppServiceId:      parse.NewAppServiceID(id.SubscriptionId, id.ResourceGroup, id.SiteName).ID(),
+				Kind:              utils.NormalizeNilableString(webAppSlot.Kind),
+				AppSettings:       flattenWebAppAppSettings(appSettings.Properties),
+				AuthV2Settings:    flattenAuthV2Settings(authV2Settings),
+				Backup:            flattenWebAppBackup(backup.BackupRequestProperties),
+				ConnectionStrings: flattenWebAppConnectionStrings(connectionStrings.Properties),
+				SiteConfig:        flattenSiteConfigWindows(siteConfig.SiteConfig, nodeVersion),
+				Tags:              tags.Flatten(webAppSlot.Tags),
+			}
+
+			if props := webAppSlot.SiteProperties; props != nil {
+				state.ClientAffinityEnabled = utils.NormaliseNilableBool(props.ClientAffinityEnabled)
+				state.ClientCertEnabled = utils.NormaliseNilableBool(props.ClientCertEnabled)
+				state.ClientCertMode = string(props.ClientCertMode)
+				state.Enabled = utils.NormaliseNilableBool(props.Enabled)
+				state.HttpsOnly = utils.NormaliseNilableBool(props.HTTPSOnly)
+				state.CustomDomainVerificationId = utils.NormalizeNilableString(props.CustomDomainVerificationID)
+				state.DefaultHostname = utils.NormalizeNilableString(props.DefaultHostName)
+				state.OutboundIPAddresses = utils.NormalizeNilableString(props.OutboundIPAddresses)
+				state.OutboundIPAddressList = flattenWebAppIPAddressList(state.OutboundIPAddresses)
+				state.PossibleOutboundIPAddresses = utils.NormalizeNilableString(props.PossibleOutboundIPAddresses)
+				state.PossibleOutboundIPAddressList = flattenWebAppIPAddressList(state.PossibleOutboundIPAddresses)
+			}
+
+			identity, err := flattenWebAppIdentity(webAppSlot.Identity)
+			if err != nil {
+				return fmt.Errorf("flattening `identity`: %+v", err)
+			}
+			state.Identity = identity
+
+			return metadata.Encode(&state)
+		},
+	}
+}
+
+func (r WindowsWebAppSlotResource) Update() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Web.AppServicesClient
+
+			id, err := parse.AppServiceSlotID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			var state WindowsWebAppSlotModel
+			if err := metadata.Decode(&state); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			existing, err := client.GetSlot(ctx, id.ResourceGroup, id.SiteName, id.SlotName)
+			if err != nil {
+				return fmt.Errorf("retrieving %s: %+v", id, err)
+			}
+			if existing.SiteProperties == nil {
+				return fmt.Errorf("retrieving %s: `properties` was nil", id)
+			}
+
+			existing.SiteProperties.Enabled = utils.Bool(state.Enabled)
+			existing.SiteProperties.HTTPSOnly = utils.Bool(state.HttpsOnly)
+			existing.SiteProperties.ClientAffinityEnabled = utils.Bool(state.ClientAffinityEnabled)
+			existing.SiteProperties.ClientCertEnabled = utils.Bool(state.ClientCertEnabled)
+			existing.SiteProperties.ClientCertMode = web.ClientCertMode(state.ClientCertMode)
+			existing.Tags = tags.Expand(state.Tags)
+
+			if metadata.ResourceData.HasChange("identity") {
+				identity := expandWebAppIdentity(state.Identity)
+				if identity == nil {
+					identity = &web.ManagedServiceIdentity{
+						Type: web.ManagedServiceIdentityTypeNone,
+					}
+				}
+				existing.Identity = identity
+			}
+
+			siteConfig, err := expandSiteConfigWindows(state.SiteConfig)
+			if err != nil {
+				return fmt.Errorf("expanding `site_config` for %s: %+v", id, err)
+			}
+			existing.SiteProperties.SiteConfig = siteConfig
+
+			future, err := client.CreateOrUpdateSlot(ctx, id.ResourceGroup, id.SiteName, existing, id.SlotName)
+			if err != nil {
+				return fmt.Errorf("updating %s: %+v", id, err)
+			}
+
+			if err := future.WaitForCompletionRef(ctx, client.Client); err != nil {
+				return fmt.Errorf("waiting for update of %s: %+v", id, err)
+			}
+
+			if metadata.ResourceData.HasChange("site_config") {
+				if _, err := client.CreateOrUpdateConfigurationSlot(ctx, id.ResourceGroup, id.SiteName, web.SiteConfigResource{SiteConfig: siteConfig}, id.SlotName); err != nil {
+					return fmt.Errorf("updating Site Config for %s: %+v", id, err)
+				}
+			}
+
+			// the version of Node is configured using an App Setting, so this also needs updating when the `site_config` changes
+			if metadata.ResourceData.HasChanges("app_settings", "site_config") {
+				appSettings := web.StringDictionary{
+					Properties: expandWindowsWebAppAppSettings(state.AppSettings, state.SiteConfig),
+				}
+				if _, err := client.UpdateApplicationSettingsSlot(ctx, id.ResourceGroup, id.SiteName, appSettings, id.SlotName); err != nil {
+					return fmt.Errorf("updating App Settings for %s: %+v", id, err)
+				}
+			}
+
+			if metadata.ResourceData.HasChange("connection_string") {
+				connectionStrings := web.ConnectionStringDictionary{
+					Properties: expandWebAppConnectionStrings(state.ConnectionStrings),
+				}
+				if _, err := client.UpdateConnectionStringsSlot(ctx, id.ResourceGroup, id.SiteName, connectionStrings, id.SlotName); err != nil {
+					return fmt.Errorf("updating Connection Strings for %s: %+v", id, err)
+				}
+			}
+
+			if metadata.ResourceData.HasChange("auth_settings_v2") {
+				if _, err := client.UpdateAuthSettingsV2Slot(ctx, id.ResourceGroup, id.SiteName, expandAuthV2Settings(state.AuthV2Settings), id.SlotName); err != nil {
+					return fmt.Errorf("updating Authentication v2 Settings for %s: %+v", id, err)
+				}
+			}
+
+			if metadata.ResourceData.HasChange("backup") {
+				backupConfig, err := expandWebAppBackup(state.Backup)
+				if err != nil {
+					return fmt.Errorf("expanding `backup` for %s: %+v", id, err)
+				}
+
+				if backupConfig == nil {
+					if _, err := client.DeleteBackupConfigurationSlot(ctx, id.ResourceGroup, id.SiteName, id.SlotName); err != nil {
+						return fmt.Errorf("removing Backup Settings for %s: %+v", id, err)
+					}
+				} else {
+					if _, err := client.UpdateBackupConfigurationSlot(ctx, id.ResourceGroup, id.SiteName, *backupConfig, id.SlotName); err != nil {
+						return fmt.Errorf("updating Backup Settings for %s: %+v", id, err)
+					}
+				}
+			}
+
+			return nil
+		},
+	}
+}
+
+func (r WindowsWebAppSlotResource) Delete() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Web.AppServicesClient
+
+			id, err := parse.AppServiceSlotID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			metadata.Logger.Infof("deleting %s", id)
+
+			deleteMetrics := true
+			deleteEmptyServerFarm := false
+			if resp, err := client.DeleteSlot(ctx, id.ResourceGroup, id.SiteName, id.SlotName, &deleteMetrics, &deleteEmptyServerFarm); err != nil {
+				if !utils.ResponseWasNotFound(resp) {
+					return fmt.Errorf("deleting %s: %+v", id, err)
+				}
+			}
+
+			return nil
+		},
+	}
+}
@@ -0,0 +1,205 @@
+package web
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/web/mgmt/2020-06-01/web"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tf/pluginsdk"
+)
+
+const (
+	// zipDeployStatusFailed and zipDeployStatusSuccess are the values of the `status` field returned by the
+	// Kudu deployments API once a deployment has completed.
+	zipDeployStatusFailed  = 3
+	zipDeployStatusSuccess = 4
+
+	zipDeployStatePending   = "Pending"
+	zipDeployStateSucceeded = "Succeeded"
+)
+
+type zipDeployStatus struct {
+	Complete   bool   `json:"complete"`
+	Status     int    `json:"status"`
+	StatusText string `json:"status_text"`
+	LogURL     string `json:"log_url"`
+}
+
+// zipDeployFileSha256 returns the hex-encoded SHA-256 hash of the package at the specified path.
+func zipDeployFileSha256(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// zipDeployFileCustomizeDiff hashes the local `zip_deploy_file` at plan time so that changes to the contents of the
+// package (rather than just its path) are surfaced as a diff on `zip_deploy_file_sha256`.
+func zipDeployFileCustomizeDiff(ctx context.Context, diff *pluginsdk.ResourceDiff, _ interface{}) error {
+	// the path may not be known until apply time, for example when it's the output of another resource
+	if !diff.NewValueKnown("zip_deploy_file") {
+		return diff.SetNewComputed("zip_deploy_file_sha256")
+	}
+
+	path := diff.Get("zip_deploy_file").(string)
+	if path == "" {
+		if diff.Get("zip_deploy_file_sha256").(string) != "" {
+			return diff.SetNew("zip_deploy_file_sha256", "")
+		}
+		return nil
+	}
+
+	if diff.NewValueKnown("app_settings") {
+		appSettings := diff.Get("app_settings").(map[string]interface{})
+		if v, ok := appSettings["WEBSITE_RUN_FROM_PACKAGE"]; ok && strings.HasPrefix(strings.ToLower(v.(string)), "http") {
+			return fmt.Errorf("`zip_deploy_file` cannot be used when the `WEBSITE_RUN_FROM_PACKAGE` app setting is set to a URL")
+		}
+	}
+
+	hash, err := zipDeployFileSha256(path)
+	if err != nil {
+		return fmt.Errorf("computing SHA-256 of `zip_deploy_file` %q: %+v", path, err)
+	}
+
+	if diff.Get("zip_deploy_file_sha256").(string) != hash {
+		return diff.SetNew("zip_deploy_file_sha256", hash)
+	}
+
+	return nil
+}
+
+// deployZipPackage pushes the package at the specified path to the Kudu zipdeploy endpoint of the App and waits
+// for the deployment to complete.
+func deployZipPackage(ctx context.Context, client *web.AppsClient, resourceGroup, name, path string) error {
+	site, err := client.Get(ctx, resourceGroup, name)
+	if err != nil {
+		return fmt.Errorf("retrieving App %q (Resource Group %q): %+v", name, resourceGroup, err)
+	}
+
+	scmHostName := ""
+	if site.SiteProperties != nil && site.SiteProperties.HostNameSslStates != nil {
+		for _, v := range *site.SiteProperties.HostNameSslStates {
+			if v.HostType == web.HostTypeRepository && v.Name != nil {
+				scmHostName = *v.Name
+				break
+			}
+		}
+	}
+	if scmHostName == "" {
+		return fmt.Errorf("could not determine the SCM host name for App %q (Resource Group %q)", name, resourceGroup)
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("opening `zip_deploy_file` %q: %+v", path, err)
+	}
+	defer file.Close()
+
+	// the request is sent using the same client as the App Service API, so that the proxy, retry and user agent
+	// settings of the provider apply - Kudu accepts the same Azure AD token as the Resource Manager API
+	req, err := autorest.CreatePreparer(
+		autorest.AsContentType("application/zip"),
+		autorest.AsPost(),
+		autorest.WithBaseURL(fmt.Sprintf("https://%s", scmHostName)),
+		autorest.WithPath("/api/zipdeploy"),
+		autorest.WithQueryParameters(map[string]interface{}{
+			"isAsync": "true",
+		}),
+		autorest.WithFile(file)).Prepare((&http.Request{}).WithContext(ctx))
+	if err != nil {
+		return fmt.Errorf("building zipdeploy request for App %q (Resource Group %q): %+v", name, resourceGroup, err)
+	}
+
+	log.Printf("[DEBUG] Deploying %q to App %q (Resource Group %q)..", path, name, resourceGroup)
+	resp, err := client.Send(req, autorest.DoRetryForStatusCodes(client.RetryAttempts, client.RetryDuration, autorest.StatusCodesForRetry...))
+	if err != nil {
+		return fmt.Errorf("deploying %q to App %q (Resource Group %q): %+v", path, name, resourceGroup, err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusAccepted && resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("deploying %q to App %q (Resource Group %q): unexpected status code %d", path, name, resourceGroup, resp.StatusCode)
+	}
+
+	statusUrl := resp.Header.Get("Location")
+	if statusUrl == "" {
+		statusUrl = fmt.Sprintf("https://%s/api/deployments/latest", scmHostName)
+	}
+
+	deployWait := pluginsdk.StateChangeConf{
+		Pending:    []string{zipDeployStatePending},
+		Target:     []string{zipDeployStateSucceeded},
+		MinTimeout: 10 * time.Second,
+		Refresh:    zipDeployRefreshFunc(ctx, client, statusUrl),
+	}
+
+	timeout, _ := ctx.Deadline()
+	deployWait.Timeout = time.Until(timeout)
+
+	if _, err := deployWait.WaitForStateContext(ctx); err != nil {
+		return fmt.Errorf("waiting for deployment of %q to App %q (Resource Group %q): %+v", path, name, resourceGroup, err)
+	}
+
+	return nil
+}
+
+func zipDeployRefreshFunc(ctx context.Context, client *web.AppsClient, statusUrl string) pluginsdk.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		req, err := autorest.CreatePreparer(
+			autorest.AsGet(),
+			autorest.WithBaseURL(statusUrl)).Prepare((&http.Request{}).WithContext(ctx))
+		if err != nil {
+			return nil, "", err
+		}
+
+		resp, err := client.Send(req, autorest.DoRetryForStatusCodes(client.RetryAttempts, client.RetryDuration, autorest.StatusCodesForRetry...))
+		if err != nil {
+			return nil, "", fmt.Errorf("polling deployment status at %q: %+v", statusUrl, err)
+		}
+		defer resp.Body.Close()
+
+		// the deployment record isn't always available immediately after the package has been accepted
+		if resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusAccepted {
+			return resp, zipDeployStatePending, nil
+		}
+		if resp.StatusCode != http.StatusOK {
+			return nil, "", fmt.Errorf("polling deployment status at %q: unexpected status code %d", statusUrl, resp.StatusCode)
+		}
+
+		var status zipDeployStatus
+		if err := json.NewDecoder(resp.Body).Decode(&status); err != nil {
+			return nil, "", fmt.Errorf("decoding deployment status from %q: %+v", statusUrl, err)
+		}
+
+		if !status.Complete {
+			return status, zipDeployStatePending, nil
+		}
+
+		switch status.Status {
+		case zipDeployStatusSuccess:
+			return status, zipDeployStateSucceeded, nil
+		case zipDeployStatusFailed:
+			return nil, "", fmt.Errorf("deployment failed: %s (see %s for details)", status.StatusText, status.LogURL)
+		}
+
+		return status, zipDeployStatePending, nil
+	}
+}
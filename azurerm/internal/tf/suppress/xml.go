@@ -2,46 +2,145 @@ package suppress
 
 import (
 	"encoding/xml"
+	"fmt"
 	"io"
-	"reflect"
+	"sort"
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
+// XmlDiff suppresses the diff between two XML documents when their canonical forms are the same - meaning that
+// differences in whitespace between elements, attribute order, quote style, entity escaping, CDATA sections,
+// the XML declaration and the prefixes used for namespaces are ignored.
 func XmlDiff(_, old, new string, _ *schema.ResourceData) bool {
-	oldTokens, err := expandXmlTokensFromString(old)
+	oldXml, err := canonicalizeXml(old)
 	if err != nil {
 		return false
 	}
 
-	newTokens, err := expandXmlTokensFromString(new)
+	newXml, err := canonicalizeXml(new)
 	if err != nil {
 		return false
 	}
 
-	return reflect.DeepEqual(oldTokens, newTokens)
+	return oldXml == newXml
 }
 
-// This function will extract all XML tokens from a string, but ignoring all white-space tokens
-func expandXmlTokensFromString(input string) ([]xml.Token, error) {
+// canonicalizeXml serializes the XML document in a form loosely based on Canonical XML (C14N), where:
+//
+// * the XML declaration is removed
+// * namespace prefixes are replaced by the namespace they refer to, and namespace declarations are removed
+// * attributes are sorted by namespace and name, and always quoted using double quotes
+// * entities, character references and CDATA sections are replaced by their (escaped) character content
+// * adjacent character content is merged, and character content consisting only of whitespace is removed
+// * empty elements are always written as a start/end tag pair
+func canonicalizeXml(input string) (string, error) {
 	decoder := xml.NewDecoder(strings.NewReader(input))
-	tokens := make([]xml.Token, 0)
+
+	var sb strings.Builder
+	var text strings.Builder
+	flushText := func() error {
+		v := text.String()
+		text.Reset()
+
+		// whitespace between elements is only used for formatting, however any other text is significant as-is
+		if strings.TrimSpace(v) == "" {
+			return nil
+		}
+		return xml.EscapeText(&sb, []byte(v))
+	}
+
+	depth := 0
 	for {
 		token, err := decoder.Token()
 		if err != nil {
 			if err == io.EOF {
 				break
 			}
-			return nil, err
+			return "", err
 		}
-		if chars, ok := token.(xml.CharData); ok {
-			text := string(chars)
-			if strings.TrimSpace(text) == "" {
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			if err := flushText(); err != nil {
+				return "", err
+			}
+			depth++
+
+			sb.WriteString("<")
+			sb.WriteString(canonicalXmlName(t.Name))
+
+			attrs := make([]xml.Attr, 0, len(t.Attr))
+			for _, attr := range t.Attr {
+				// namespace declarations are accounted for in the namespace of the elements/attributes using them
+				if attr.Name.Space == "xmlns" || (attr.Name.Space == "" && attr.Name.Local == "xmlns") {
+					continue
+				}
+				attrs = append(attrs, attr)
+			}
+			sort.Slice(attrs, func(i, j int) bool {
+				if attrs[i].Name.Space != attrs[j].Name.Space {
+					return attrs[i].Name.Space < attrs[j].Name.Space
+				}
+				return attrs[i].Name.Local < attrs[j].Name.Local
+			})
+			for _, attr := range attrs {
+				fmt.Fprintf(&sb, " %s=\"", canonicalXmlName(attr.Name))
+				if err := xml.EscapeText(&sb, []byte(attr.Value)); err != nil {
+					return "", err
+				}
+				sb.WriteString("\"")
+			}
+			sb.WriteString(">")
+
+		case xml.EndElement:
+			if err := flushText(); err != nil {
+				return "", err
+			}
+			depth--
+
+			fmt.Fprintf(&sb, "</%s>", canonicalXmlName(t.Name))
+
+		case xml.CharData:
+			text.Write(t)
+
+		case xml.Comment:
+			if err := flushText(); err != nil {
+				return "", err
+			}
+			fmt.Fprintf(&sb, "<!--%s-->", string(t))
+
+		case xml.ProcInst:
+			if err := flushText(); err != nil {
+				return "", err
+			}
+			if t.Target == "xml" {
 				continue
 			}
+			fmt.Fprintf(&sb, "<?%s %s?>", t.Target, string(t.Inst))
+
+		case xml.Directive:
+			if err := flushText(); err != nil {
+				return "", err
+			}
+			fmt.Fprintf(&sb, "<!%s>", string(t))
 		}
-		tokens = append(tokens, xml.CopyToken(token))
 	}
-	return tokens, nil
+
+	if depth != 0 {
+		return "", fmt.Errorf("unexpected end of XML document")
+	}
+	if err := flushText(); err != nil {
+		return "", err
+	}
+
+	return sb.String(), nil
+}
+
+func canonicalXmlName(name xml.Name) string {
+	if name.Space == "" {
+		return name.Local
+	}
+	return fmt.Sprintf("{%s}%s", name.Space, name.Local)
 }
@@ -69,6 +69,96 @@ func TestXmlDiff(t *testing.T) {
 			XmlB:     "<r>\r\n\t<c attr='test'>\r\n\t</c>\r\n</r>",
 			Suppress: true,
 		},
+		{
+			Name:     "xml with different attribute order",
+			XmlA:     "<r><c a=\"1\" b=\"2\"></c></r>",
+			XmlB:     "<r><c b=\"2\" a=\"1\"></c></r>",
+			Suppress: true,
+		},
+		{
+			Name:     "xml with different attribute values",
+			XmlA:     "<r><c a=\"1\" b=\"2\"></c></r>",
+			XmlB:     "<r><c a=\"2\" b=\"1\"></c></r>",
+			Suppress: false,
+		},
+		{
+			Name:     "xml with different namespace prefixes",
+			XmlA:     "<a:r xmlns:a=\"urn:test\"><a:c a:attr=\"1\"></a:c></a:r>",
+			XmlB:     "<b:r xmlns:b=\"urn:test\"><b:c b:attr=\"1\"></b:c></b:r>",
+			Suppress: true,
+		},
+		{
+			Name:     "xml with prefixed and default namespace",
+			XmlA:     "<a:r xmlns:a=\"urn:test\"><a:c></a:c></a:r>",
+			XmlB:     "<r xmlns=\"urn:test\"><c></c></r>",
+			Suppress: true,
+		},
+		{
+			Name:     "xml with different namespaces",
+			XmlA:     "<a:r xmlns:a=\"urn:test\"></a:r>",
+			XmlB:     "<a:r xmlns:a=\"urn:other\"></a:r>",
+			Suppress: false,
+		},
+		{
+			Name:     "xml with different entity escaping",
+			XmlA:     "<r><c attr=\"&quot;a&quot; &amp; 'b'\">x &gt; y</c></r>",
+			XmlB:     "<r><c attr='\"a\" &#38; &apos;b&apos;'>x > y</c></r>",
+			Suppress: true,
+		},
+		{
+			Name:     "xml with cdata",
+			XmlA:     "<r><c><![CDATA[a < b]]></c></r>",
+			XmlB:     "<r><c>a &lt; b</c></r>",
+			Suppress: true,
+		},
+		{
+			Name:     "xml with self closing elements",
+			XmlA:     "<r><c /></r>",
+			XmlB:     "<r><c></c></r>",
+			Suppress: true,
+		},
+		{
+			Name:     "xml with declaration",
+			XmlA:     "<?xml version=\"1.0\" encoding=\"utf-8\"?>\n<r><c></c></r>",
+			XmlB:     "<r><c></c></r>",
+			Suppress: true,
+		},
+		{
+			Name:     "xml with different text",
+			XmlA:     "<r><c>a</c></r>",
+			XmlB:     "<r><c>b</c></r>",
+			Suppress: false,
+		},
+		{
+			Name:     "xml with different whitespace within text",
+			XmlA:     "<r><c>a b</c></r>",
+			XmlB:     "<r><c> a b </c></r>",
+			Suppress: false,
+		},
+		{
+			Name:     "xml with different whitespace within comments",
+			XmlA:     "<r><!-- a --></r>",
+			XmlB:     "<r><!--a--></r>",
+			Suppress: false,
+		},
+		{
+			Name:     "xml with whitespace around comments",
+			XmlA:     "<r>\n  <!-- a -->\n  <c></c>\n</r>",
+			XmlB:     "<r><!-- a --><c></c></r>",
+			Suppress: true,
+		},
+		{
+			Name:     "xml with different element order",
+			XmlA:     "<r><a></a><b></b></r>",
+			XmlB:     "<r><b></b><a></a></r>",
+			Suppress: false,
+		},
+		{
+			Name:     "unclosed xml",
+			XmlA:     "<r><c></c>",
+			XmlB:     "<r><c></c>",
+			Suppress: false,
+		},
 	}
 
 	for _, tc := range cases {